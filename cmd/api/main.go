@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	awslambda "github.com/aws/aws-lambda-go/lambda"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/configprovider"
+	"github.com/synaptiq/standup-bot/internal/lambda"
+	"github.com/synaptiq/standup-bot/internal/metrics"
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/standup"
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/validation"
+)
+
+var (
+	// Global instances initialized in init().
+	botCtx      botcontext.BotContext
+	dataStore   store.Store
+	service     *standup.Service
+	teamID      string
+	handlerFunc lambda.Handler
+)
+
+// defaultSessionWindowDays is how far back session listings go without a from date.
+const defaultSessionWindowDays = 30
+
+func init() {
+	// Initialize components
+	ctx := context.Background()
+	initConfig := lambda.DefaultInitConfig()
+
+	var (
+		slackClient slack.Client
+		err         error
+	)
+	botCtx, dataStore, slackClient, err = lambda.Initialize(ctx, initConfig)
+	if err != nil {
+		log.Fatalf("Failed to initialize: %v", err)
+	}
+	teamID = initConfig.TeamID
+	service = standup.NewService(botCtx, dataStore, slackClient)
+
+	apiToken := os.Getenv("API_TOKEN")
+	if apiToken == "" {
+		log.Fatal("API_TOKEN not set")
+	}
+
+	// Create handler with middleware
+	handlerFunc = lambda.Chain(
+		lambda.StandardMiddleware(botCtx),
+		lambda.WithMetrics(metrics.Default()),
+		lambda.WithBearerAuth(apiToken),
+	)(handler)
+}
+
+func main() {
+	awslambda.Start(lambda.Adapt(handlerFunc))
+}
+
+// Response shapes exposed by the API.
+type channelView struct {
+	ID          string       `json:"id"`
+	TeamID      string       `json:"team_id"`
+	Name        string       `json:"name"`
+	Enabled     bool         `json:"enabled"`
+	Schedule    scheduleView `json:"schedule"`
+	Users       []string     `json:"users"`
+	Questions   []string     `json:"questions"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	SessionsURL string       `json:"sessions_url"`
+}
+
+type scheduleView struct {
+	Timezone      string   `json:"timezone"`
+	SummaryTime   string   `json:"summary_time"`
+	ReminderTimes []string `json:"reminder_times"`
+	ActiveDays    []string `json:"active_days"`
+}
+
+type sessionView struct {
+	ID            string     `json:"id"`
+	ChannelID     string     `json:"channel_id"`
+	Date          string     `json:"date"`
+	Status        string     `json:"status"`
+	SummaryPosted bool       `json:"summary_posted"`
+	ResponseCount int        `json:"response_count"`
+	CreatedAt     time.Time  `json:"created_at"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	ResponsesURL  string     `json:"responses_url"`
+}
+
+type statusView struct {
+	ChannelID string   `json:"channel_id"`
+	Date      string   `json:"date"`
+	Started   bool     `json:"started"`
+	Complete  bool     `json:"complete"`
+	Submitted []string `json:"submitted"`
+	Skipped   []string `json:"skipped"`
+	Pending   []string `json:"pending"`
+}
+
+type responseView struct {
+	SessionID     string            `json:"session_id"`
+	ChannelID     string            `json:"channel_id"`
+	Date          string            `json:"date"`
+	UserID        string            `json:"user_id"`
+	UserName      string            `json:"user_name"`
+	Responses     map[string]string `json:"responses"`
+	SubmittedAt   time.Time         `json:"submitted_at"`
+	ReminderCount int               `json:"reminder_count"`
+}
+
+//nolint:gocritic // Lambda requires value types for request
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if request.HTTPMethod != http.MethodGet {
+		return lambda.Response(http.StatusMethodNotAllowed, map[string]string{
+			"error": "Method not allowed",
+		}), nil
+	}
+
+	switch request.Resource {
+	case "/channels":
+		return handleListChannels(ctx, request)
+	case "/channels/{id}/sessions":
+		return handleListSessions(ctx, request)
+	case "/channels/{id}/standup-status":
+		return handleStandupStatus(ctx, request)
+	case "/sessions/{id}/responses":
+		return handleListResponses(ctx, request)
+	}
+
+	return lambda.NotFound("Unknown endpoint"), nil
+}
+
+//nolint:gocritic // Lambda requires value types for request
+func handleListChannels(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	team := request.QueryStringParameters["team_id"]
+	if team == "" {
+		team = teamID
+	}
+	if err := validation.ValidateTeamID(team); err != nil {
+		return lambda.BadRequest("A valid team_id is required"), nil
+	}
+
+	configs, err := dataStore.ListChannelConfigs(ctx, team)
+	if err != nil {
+		botCtx.Logger().Error(ctx, "Failed to list channels", err,
+			botcontext.Field{Key: "team_id", Value: security.SanitizeLogValue(team)},
+		)
+		return lambda.InternalServerError("Failed to list channels"), nil
+	}
+
+	channels := make([]channelView, 0, len(configs))
+	for _, config := range configs {
+		channels = append(channels, channelView{
+			ID:      config.ChannelID,
+			TeamID:  config.TeamID,
+			Name:    config.ChannelName,
+			Enabled: config.Enabled,
+			Schedule: scheduleView{
+				Timezone:      config.Schedule.Timezone,
+				SummaryTime:   config.Schedule.SummaryTime,
+				ReminderTimes: config.Schedule.ReminderTimes,
+				ActiveDays:    config.Schedule.ActiveDays,
+			},
+			Users:       config.Users,
+			Questions:   config.Questions,
+			UpdatedAt:   config.UpdatedAt,
+			SessionsURL: "/channels/" + config.ChannelID + "/sessions",
+		})
+	}
+
+	return lambda.OK(map[string]interface{}{"channels": channels}), nil
+}
+
+// handleListSessions lists a channel's sessions. The optional from and to
+// query parameters (YYYY-MM-DD) default to the last 30 days.
+//
+//nolint:gocritic // Lambda requires value types for request
+func handleListSessions(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	channelID := request.PathParameters["id"]
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return lambda.BadRequest("Invalid channel ID"), nil
+	}
+
+	now := time.Now().UTC()
+	from := request.QueryStringParameters["from"]
+	if from == "" {
+		from = now.AddDate(0, 0, -defaultSessionWindowDays).Format("2006-01-02")
+	}
+	to := request.QueryStringParameters["to"]
+	if to == "" {
+		to = now.Format("2006-01-02")
+	}
+	if validation.ValidateDate(from) != nil || validation.ValidateDate(to) != nil {
+		return lambda.BadRequest("from and to must be dates in YYYY-MM-DD format"), nil
+	}
+
+	ctx, ok := withTeam(ctx, request)
+	if !ok {
+		return lambda.BadRequest("The team_id of a served workspace is required"), nil
+	}
+
+	sessions, err := dataStore.ListSessions(ctx, channelID, from, to)
+	if err != nil {
+		botCtx.Logger().Error(ctx, "Failed to list sessions", err,
+			botcontext.Field{Key: "channel_id", Value: channelID},
+		)
+		return lambda.InternalServerError("Failed to list sessions"), nil
+	}
+
+	views := make([]sessionView, 0, len(sessions))
+	for _, session := range sessions {
+		views = append(views, sessionView{
+			ID:            session.SessionID,
+			ChannelID:     session.ChannelID,
+			Date:          session.Date,
+			Status:        string(session.Status),
+			SummaryPosted: session.SummaryPosted,
+			ResponseCount: session.ResponseCount,
+			CreatedAt:     session.CreatedAt,
+			CompletedAt:   session.CompletedAt,
+			ResponsesURL:  "/sessions/" + session.SessionID + "/responses",
+		})
+	}
+
+	return lambda.OK(map[string]interface{}{
+		"channel_id": channelID,
+		"from":       from,
+		"to":         to,
+		"sessions":   views,
+	}), nil
+}
+
+// withTeam scopes ctx to the request's team_id when the deployment serves
+// several workspaces, as sessions are stored per workspace then. It returns
+// false if the team_id isn't of a served workspace.
+//
+//nolint:gocritic // Lambda requires value types for request
+func withTeam(ctx context.Context, request events.APIGatewayProxyRequest) (context.Context, bool) {
+	teams, ok := botCtx.Config().(configprovider.TeamConfigs)
+	if !ok {
+		return ctx, true
+	}
+	team := request.QueryStringParameters["team_id"]
+	if _, served := teams.ForTeam(team); !served {
+		return ctx, false
+	}
+	return botCtx.WithTeamID(ctx, team), true
+}
+
+// handleStandupStatus reports whether everyone required in a channel's
+// standup has submitted (or skipped) on the date query parameter
+// (YYYY-MM-DD, defaults to today in the channel's timezone), so CI pipelines
+// can gate on it.
+//
+//nolint:gocritic // Lambda requires value types for request
+func handleStandupStatus(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	channelID := request.PathParameters["id"]
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return lambda.BadRequest("Invalid channel ID"), nil
+	}
+
+	date := request.QueryStringParameters["date"]
+	if date != "" && validation.ValidateDate(date) != nil {
+		return lambda.BadRequest("date must be in YYYY-MM-DD format"), nil
+	}
+
+	ctx, ok := withTeam(ctx, request)
+	if !ok {
+		return lambda.BadRequest("The team_id of a served workspace is required"), nil
+	}
+	if date == "" {
+		date = service.Today(ctx, channelID)
+	}
+
+	status, err := service.StandupStatus(ctx, channelID, date)
+	if errors.Is(err, store.ErrNotFound) {
+		return lambda.NotFound("Channel has no standups"), nil
+	}
+	if err != nil {
+		botCtx.Logger().Error(ctx, "Failed to get standup status", err,
+			botcontext.Field{Key: "channel_id", Value: channelID},
+		)
+		return lambda.InternalServerError("Failed to get standup status"), nil
+	}
+
+	return lambda.OK(statusView{
+		ChannelID: status.ChannelID,
+		Date:      status.Date,
+		Started:   status.Started,
+		Complete:  status.Complete,
+		Submitted: nonNil(status.Submitted),
+		Skipped:   nonNil(status.Skipped),
+		Pending:   nonNil(status.Pending),
+	}), nil
+}
+
+// nonNil returns userIDs, or an empty slice for nil so it's encoded as [].
+func nonNil(userIDs []string) []string {
+	if userIDs == nil {
+		return []string{}
+	}
+	return userIDs
+}
+
+//nolint:gocritic // Lambda requires value types for request
+func handleListResponses(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	sessionID := request.PathParameters["id"]
+	if err := validation.ValidateSessionID(sessionID); err != nil {
+		return lambda.BadRequest("Invalid session ID"), nil
+	}
+
+	responses, err := dataStore.ListSessionResponses(ctx, sessionID)
+	if err != nil {
+		botCtx.Logger().Error(ctx, "Failed to list responses", err,
+			botcontext.Field{Key: "session_id", Value: sessionID},
+		)
+		return lambda.InternalServerError("Failed to list responses"), nil
+	}
+
+	views := make([]responseView, 0, len(responses))
+	for _, response := range responses {
+		views = append(views, responseView{
+			SessionID:     response.SessionID,
+			ChannelID:     response.ChannelID,
+			Date:          response.Date,
+			UserID:        response.UserID,
+			UserName:      response.UserName,
+			Responses:     response.Responses,
+			SubmittedAt:   response.SubmittedAt,
+			ReminderCount: response.ReminderCount,
+		})
+	}
+
+	return lambda.OK(map[string]interface{}{
+		"session_id": sessionID,
+		"responses":  views,
+	}), nil
+}
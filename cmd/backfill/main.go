@@ -0,0 +1,45 @@
+// Command backfill indexes the sessions and responses stored on DynamoDB
+// before ListSessions and ListSessionResponses used the GSI1 index, so the
+// API, search and digests find them. It's run once after upgrading, with the
+// AWS credentials of the deployment, and can be run again safely.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	dynamostore "github.com/synaptiq/standup-bot/internal/store/dynamodb"
+)
+
+func main() {
+	table := flag.String("table", os.Getenv("DYNAMODB_TABLE"), "DynamoDB table to backfill")
+	endpoint := flag.String("endpoint", os.Getenv("DYNAMO_ENDPOINT"), "DynamoDB endpoint, e.g. for DynamoDB Local")
+	flag.Parse()
+
+	if *table == "" {
+		log.Fatal("-table or DYNAMODB_TABLE is required")
+	}
+
+	ctx := context.Background()
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+	client := dynamodb.NewFromConfig(awsCfg, func(o *dynamodb.Options) {
+		if *endpoint != "" {
+			o.BaseEndpoint = aws.String(*endpoint)
+		}
+	})
+
+	updated, err := dynamostore.BackfillSessionIndex(ctx, client, *table)
+	if err != nil {
+		log.Fatalf("Backfill stopped after indexing %d items: %v", updated, err)
+	}
+	log.Printf("Indexed %d sessions and responses in %s", updated, *table)
+}
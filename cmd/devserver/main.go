@@ -0,0 +1,234 @@
+// Command devserver serves the webhook handler over plain HTTP for local
+// development. It keeps data in memory and logs Slack API calls instead of
+// making them, so it needs no AWS or Slack credentials.
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/configprovider"
+	"github.com/synaptiq/standup-bot/internal/lambda"
+	"github.com/synaptiq/standup-bot/internal/metrics"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/standup"
+	"github.com/synaptiq/standup-bot/internal/store/memory"
+	"github.com/synaptiq/standup-bot/internal/webhook"
+)
+
+// maxBodySize caps request bodies; Slack payloads are far smaller.
+const maxBodySize = 1 << 20
+
+func main() {
+	addr := flag.String("addr", ":3000", "address to listen on")
+	configPath := flag.String("config", "config.yaml", "path to the bot configuration")
+	schedule := flag.Duration("schedule", 0, "run the scheduler at this interval (0 disables it)")
+	teamID := flag.String("team", "T0000000000", "workspace ID the configured channels are stored under")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	provider := botconfig.NewYAMLProvider(*configPath)
+	cfg, err := provider.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if err := botconfig.NewValidator().Validate(cfg); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	botCtx, err := botcontext.New(botcontext.Options{
+		Config:         cfg,
+		ConfigProvider: provider,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create bot context: %v", err)
+	}
+
+	// Store the configured channels so commands that read them work
+	dataStore := memory.NewStore()
+	if err := configprovider.NewStoreProvider(dataStore, configprovider.StoreProviderOptions{
+		TeamID: *teamID,
+		Seed:   provider,
+	}).Seed(ctx); errors.Is(err, configprovider.ErrUnstorableQuestions) {
+		// The service reads questions from the file; only commands that read
+		// the stored channels miss these
+		log.Printf("Some channels weren't stored: %v", err)
+	} else if err != nil {
+		log.Fatalf("Failed to seed store: %v", err)
+	}
+
+	slackClient := &fakeSlackClient{}
+	// Metrics would only clutter the console locally
+	opts := append(standup.ReminderOptionsFromEnv(), standup.WithMetrics(metrics.Discard))
+	service := standup.NewService(botCtx, dataStore, slackClient, opts...)
+
+	// Check signatures when a signing secret is available, e.g. behind ngrok
+	var verifier *slack.RequestVerifier
+	if secret := os.Getenv("SLACK_SIGNING_SECRET"); secret != "" {
+		verifier = slack.NewRequestVerifier(secret, os.Getenv("SLACK_SIGNING_SECRET_NEXT"))
+	} else {
+		log.Print("SLACK_SIGNING_SECRET not set; request signatures are not checked")
+	}
+
+	handler := webhook.New(webhook.Options{
+		BotContext:  botCtx,
+		Store:       dataStore,
+		SlackClient: slackClient,
+		Service:     service,
+		Verifier:    verifier,
+		Metrics:     metrics.Discard,
+	}).Lambda()
+
+	if *schedule > 0 {
+		go runScheduler(ctx, standup.NewScheduler(service, botCtx, dataStore), *schedule)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/slack/", serveLambda(handler))
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Serving Slack webhooks on %s/slack/", *addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Server failed: %v", err)
+	}
+}
+
+// runScheduler processes scheduled tasks every interval until ctx is done.
+func runScheduler(ctx context.Context, scheduler *standup.Scheduler, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := scheduler.ProcessScheduledTasks(ctx); err != nil {
+				log.Printf("Scheduler failed: %v", err)
+			}
+		}
+	}
+}
+
+// serveLambda adapts a Lambda handler to net/http.
+func serveLambda(handler lambda.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request, err := toProxyRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		response, err := handler(r.Context(), request)
+		if err != nil {
+			log.Printf("Handler error: %v", err)
+		}
+
+		if err := writeProxyResponse(w, &response); err != nil {
+			log.Printf("Failed to write response: %v", err)
+		}
+	})
+}
+
+// toProxyRequest translates an HTTP request into the API Gateway event the
+// Lambda handler receives. Headers keep their canonical names, as API Gateway
+// forwards them as sent by Slack.
+func toProxyRequest(r *http.Request) (events.APIGatewayProxyRequest, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return events.APIGatewayProxyRequest{}, fmt.Errorf("failed to read body: %w", err)
+	}
+	if len(body) > maxBodySize {
+		return events.APIGatewayProxyRequest{}, fmt.Errorf("body exceeds %d bytes", maxBodySize)
+	}
+
+	headers := make(map[string]string, len(r.Header))
+	multiHeaders := make(map[string][]string, len(r.Header))
+	for name, values := range r.Header {
+		headers[name] = strings.Join(values, ",")
+		multiHeaders[name] = values
+	}
+
+	query := make(map[string]string)
+	multiQuery := make(map[string][]string)
+	for name, values := range r.URL.Query() {
+		query[name] = values[len(values)-1]
+		multiQuery[name] = values
+	}
+
+	return events.APIGatewayProxyRequest{
+		Resource:                        r.URL.Path,
+		Path:                            r.URL.Path,
+		HTTPMethod:                      r.Method,
+		Headers:                         headers,
+		MultiValueHeaders:               multiHeaders,
+		QueryStringParameters:           query,
+		MultiValueQueryStringParameters: multiQuery,
+		RequestContext: events.APIGatewayProxyRequestContext{
+			HTTPMethod: r.Method,
+			Path:       r.URL.Path,
+			Identity:   events.APIGatewayRequestIdentity{SourceIP: r.RemoteAddr},
+		},
+		Body: string(body),
+	}, nil
+}
+
+// writeProxyResponse writes an API Gateway response to w.
+func writeProxyResponse(w http.ResponseWriter, response *events.APIGatewayProxyResponse) error {
+	for name, value := range response.Headers {
+		w.Header().Set(name, value)
+	}
+	for name, values := range response.MultiValueHeaders {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+
+	body := []byte(response.Body)
+	if response.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(response.Body)
+		if err != nil {
+			return fmt.Errorf("failed to decode body: %w", err)
+		}
+		body = decoded
+	}
+
+	status := response.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+
+	_, err := w.Write(body)
+	return err
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+// fakeSlackClient logs Slack API calls instead of making them.
+type fakeSlackClient struct {
+	seq atomic.Int64
+}
+
+// timestamp returns a unique message timestamp in Slack's format.
+func (c *fakeSlackClient) timestamp() string {
+	return fmt.Sprintf("%d.%06d", time.Now().Unix(), c.seq.Add(1))
+}
+
+func (c *fakeSlackClient) log(method string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data = []byte(fmt.Sprintf("%+v", v))
+	}
+	log.Printf("slack %s %s", method, data)
+}
+
+func buildMessage(channel string, opts []slack.MessageOption) *slack.Message {
+	msg := &slack.Message{Channel: channel}
+	for _, opt := range opts {
+		opt(msg)
+	}
+	return msg
+}
+
+func (c *fakeSlackClient) PostMessage(ctx context.Context, channel string, opts ...slack.MessageOption) (string, error) {
+	c.log("chat.postMessage", buildMessage(channel, opts))
+	return c.timestamp(), nil
+}
+
+func (c *fakeSlackClient) PostEphemeral(
+	ctx context.Context,
+	channel, userID string,
+	opts ...slack.MessageOption,
+) (string, error) {
+	c.log("chat.postEphemeral", map[string]interface{}{"user": userID, "message": buildMessage(channel, opts)})
+	return c.timestamp(), nil
+}
+
+func (c *fakeSlackClient) ScheduleMessage(
+	ctx context.Context,
+	channel string,
+	postAt time.Time,
+	opts ...slack.MessageOption,
+) (string, error) {
+	c.log("chat.scheduleMessage", map[string]interface{}{"post_at": postAt.Unix(), "message": buildMessage(channel, opts)})
+	return "Q" + c.timestamp(), nil
+}
+
+func (c *fakeSlackClient) ListScheduledMessages(ctx context.Context, channel string) ([]slack.ScheduledMessage, error) {
+	return nil, nil
+}
+
+func (c *fakeSlackClient) DeleteScheduledMessage(ctx context.Context, channel, scheduledMessageID string) error {
+	c.log("chat.deleteScheduledMessage", map[string]string{"channel": channel, "scheduled_message_id": scheduledMessageID})
+	return nil
+}
+
+func (c *fakeSlackClient) UpdateMessage(
+	ctx context.Context,
+	channel, timestamp string,
+	opts ...slack.MessageOption,
+) error {
+	c.log("chat.update", map[string]interface{}{"ts": timestamp, "message": buildMessage(channel, opts)})
+	return nil
+}
+
+func (c *fakeSlackClient) DeleteMessage(ctx context.Context, channel, timestamp string) error {
+	c.log("chat.delete", map[string]string{"channel": channel, "ts": timestamp})
+	return nil
+}
+
+func (c *fakeSlackClient) UnfurlLinks(
+	ctx context.Context, target slack.UnfurlTarget, unfurls map[string][]slack.Block,
+) error {
+	c.log("chat.unfurl", map[string]string{"channel": target.Channel, "ts": target.TS, "links": fmt.Sprint(len(unfurls))})
+	return nil
+}
+
+func (c *fakeSlackClient) GetPermalink(ctx context.Context, channel, timestamp string) (string, error) {
+	c.log("chat.getPermalink", map[string]string{"channel": channel, "message_ts": timestamp})
+	return fmt.Sprintf("https://example.slack.com/archives/%s/p%s", channel, strings.ReplaceAll(timestamp, ".", "")), nil
+}
+
+func (c *fakeSlackClient) GetReactions(ctx context.Context, channel, timestamp string) ([]slack.Reaction, error) {
+	return nil, nil
+}
+
+func (c *fakeSlackClient) PostToResponseURL(
+	ctx context.Context,
+	responseURL string,
+	message *slack.ResponseMessage,
+) error {
+	c.log("response_url", message)
+	return nil
+}
+
+func (c *fakeSlackClient) UploadFile(
+	ctx context.Context,
+	channels []string,
+	filename string,
+	content []byte,
+	opts ...slack.FileOption,
+) (string, error) {
+	c.log("files.upload", map[string]interface{}{"channels": channels, "filename": filename, "bytes": len(content)})
+	return fmt.Sprintf("F%010d", c.seq.Add(1)), nil
+}
+
+func (c *fakeSlackClient) OpenModal(ctx context.Context, triggerID string, modal *slack.Modal) error {
+	c.log("views.open", modal)
+	return nil
+}
+
+func (c *fakeSlackClient) UpdateModal(ctx context.Context, viewID string, modal *slack.Modal) error {
+	c.log("views.update", modal)
+	return nil
+}
+
+func (c *fakeSlackClient) UpdateModalWithHash(ctx context.Context, viewID, hash string, modal *slack.Modal) error {
+	c.log("views.update", modal)
+	return nil
+}
+
+func (c *fakeSlackClient) UpdateModalByExternalID(ctx context.Context, externalID string, modal *slack.Modal) error {
+	c.log("views.update", modal)
+	return nil
+}
+
+func (c *fakeSlackClient) PushModal(ctx context.Context, triggerID string, modal *slack.Modal) error {
+	c.log("views.push", modal)
+	return nil
+}
+
+func (c *fakeSlackClient) GetUserInfo(ctx context.Context, userID string) (*slack.UserInfo, error) {
+	return &slack.UserInfo{ID: userID, Name: userID, RealName: userID, TZ: "UTC"}, nil
+}
+
+func (c *fakeSlackClient) GetUserByEmail(ctx context.Context, email string) (*slack.UserInfo, error) {
+	return nil, fmt.Errorf("users_not_found")
+}
+
+func (c *fakeSlackClient) GetDNDStatus(ctx context.Context, userID string) (*slack.DNDStatus, error) {
+	return &slack.DNDStatus{}, nil
+}
+
+func (c *fakeSlackClient) ListUserGroupMembers(ctx context.Context, groupID string) ([]string, error) {
+	return nil, nil
+}
+
+func (c *fakeSlackClient) GetChannelInfo(ctx context.Context, channelID string) (*slack.ConversationInfo, error) {
+	return &slack.ConversationInfo{ID: channelID, Name: channelID, IsChannel: true}, nil
+}
+
+func (c *fakeSlackClient) ListChannelMembers(ctx context.Context, channelID string) ([]string, error) {
+	return nil, nil
+}
+
+func (c *fakeSlackClient) OpenDM(ctx context.Context, userID string) (string, error) {
+	return "D" + userID, nil
+}
+
+func (c *fakeSlackClient) AuthTest(ctx context.Context) (*slack.AuthIdentity, error) {
+	return &slack.AuthIdentity{Team: "devserver", User: "standup-bot", TeamID: "T0000000000", UserID: "U0000000000"}, nil
+}
+
+func (c *fakeSlackClient) BotUserID() string {
+	return "U0000000000"
+}
+
+func (c *fakeSlackClient) ListAuthorizedTeams(ctx context.Context) ([]slack.Team, error) {
+	return nil, nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"html"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	awslambda "github.com/aws/aws-lambda-go/lambda"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/install"
+	"github.com/synaptiq/standup-bot/internal/lambda"
+	"github.com/synaptiq/standup-bot/internal/metrics"
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+var (
+	// Global instances initialized in init().
+	botCtx      botcontext.BotContext
+	dataStore   store.Store
+	oauth       *slack.OAuthClient
+	redirectURI string
+	handlerFunc lambda.Handler
+)
+
+func init() {
+	// Initialize components
+	ctx := context.Background()
+	initConfig := lambda.DefaultInitConfig()
+
+	var err error
+	botCtx, dataStore, _, err = lambda.Initialize(ctx, initConfig)
+	if err != nil {
+		log.Fatalf("Failed to initialize: %v", err)
+	}
+
+	clientID := os.Getenv("SLACK_CLIENT_ID")
+	clientSecret := os.Getenv("SLACK_CLIENT_SECRET")
+	if clientID == "" || clientSecret == "" {
+		log.Fatal("SLACK_CLIENT_ID and SLACK_CLIENT_SECRET must be set")
+	}
+	oauth = slack.NewOAuthClient(clientID, clientSecret)
+
+	redirectURI = os.Getenv("SLACK_REDIRECT_URI")
+	if redirectURI == "" {
+		log.Fatal("SLACK_REDIRECT_URI not set")
+	}
+
+	// Create handler with middleware. Requests come from browsers, not
+	// Slack, so they aren't signed; the OAuth state protects the redirect.
+	handlerFunc = lambda.Chain(
+		lambda.StandardMiddleware(botCtx),
+		lambda.WithMetrics(metrics.Default()),
+	)(handler)
+}
+
+func main() {
+	awslambda.Start(lambda.Adapt(handlerFunc))
+}
+
+//nolint:gocritic // Lambda requires value types for request
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if request.HTTPMethod != http.MethodGet {
+		return lambda.Response(http.StatusMethodNotAllowed, map[string]string{
+			"error": "Method not allowed",
+		}), nil
+	}
+
+	switch request.Resource {
+	case "/slack/install":
+		return lambda.Redirect(oauth.AuthorizeURL(redirectURI, oauth.NewState(time.Now()))), nil
+	case "/slack/oauth_redirect":
+		return handleRedirect(ctx, request)
+	}
+
+	return lambda.NotFound("Unknown endpoint"), nil
+}
+
+// handleRedirect completes an install once the user approved it in Slack.
+//
+//nolint:gocritic // Lambda requires value types for request
+func handleRedirect(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	params := request.QueryStringParameters
+	if reason := params["error"]; reason != "" {
+		return page(http.StatusOK, "Installation cancelled", "The app was not installed ("+reason+")."), nil
+	}
+	if err := oauth.VerifyState(params["state"], time.Now()); err != nil {
+		return page(http.StatusBadRequest, "Installation failed",
+			"This install link has expired. Please start the installation again."), nil
+	}
+
+	logger := botCtx.Logger()
+
+	access, err := oauth.Exchange(ctx, params["code"], redirectURI)
+	if err != nil {
+		logger.Error(ctx, "Failed to exchange OAuth code", err)
+		return page(http.StatusBadGateway, "Installation failed", "Slack did not complete the installation."), nil
+	}
+
+	// Org-wide installs are granted to some of the grid's workspaces
+	var teams []slack.Team
+	if access.IsEnterpriseInstall {
+		teams, err = slack.NewClient(access.AccessToken).ListAuthorizedTeams(ctx)
+		if err != nil {
+			logger.Error(ctx, "Failed to list enterprise workspaces", err)
+		}
+	}
+
+	err = install.Save(ctx, dataStore, access, teams)
+	if errors.Is(err, install.ErrNoInstallID) {
+		return page(http.StatusBadGateway, "Installation failed", "Slack did not name the installed workspace."), nil
+	}
+	if err != nil {
+		logger.Error(ctx, "Failed to save install", err,
+			botcontext.Field{Key: "install_id", Value: security.SanitizeLogValue(access.InstallID())},
+		)
+		return page(http.StatusInternalServerError, "Installation failed", "The installation could not be saved."), nil
+	}
+
+	logger.Info(ctx, "App installed",
+		botcontext.Field{Key: "install_id", Value: security.SanitizeLogValue(access.InstallID())},
+		botcontext.Field{Key: "enterprise_install", Value: access.IsEnterpriseInstall},
+		botcontext.Field{Key: "teams", Value: len(teams)},
+		botcontext.Metric("Installs", 1),
+	)
+
+	return page(http.StatusOK, "Standup Bot installed", "You can close this window and return to Slack."), nil
+}
+
+// page renders a minimal HTML page for the browser finishing an install.
+func page(statusCode int, title, message string) events.APIGatewayProxyResponse {
+	return lambda.HTML(statusCode, "<!DOCTYPE html><html><head><title>"+html.EscapeString(title)+
+		"</title></head><body><h1>"+html.EscapeString(title)+"</h1><p>"+html.EscapeString(message)+
+		"</p></body></html>")
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	lambdautil "github.com/synaptiq/standup-bot/internal/lambda"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/standup"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+var (
+	// Global instances initialized in init().
+	botCtx  botcontext.BotContext
+	monitor *standup.Monitor
+)
+
+func init() {
+	// Initialize components
+	ctx := context.Background()
+	initConfig := lambdautil.DefaultInitConfig()
+
+	alertChannel := os.Getenv("MONITOR_ALERT_CHANNEL")
+	if alertChannel == "" {
+		log.Fatalf("MONITOR_ALERT_CHANNEL is required")
+	}
+
+	var (
+		dataStore   store.Store
+		slackClient slack.Client
+		err         error
+	)
+	botCtx, dataStore, slackClient, err = lambdautil.Initialize(ctx, initConfig)
+	if err != nil {
+		log.Fatalf("Failed to initialize: %v", err)
+	}
+
+	service := standup.NewService(botCtx, dataStore, slackClient)
+	monitor = standup.NewMonitor(service, botCtx, dataStore, os.Getenv("MONITOR_ALERT_TEAM_ID"), alertChannel,
+		standup.MonitorOptionsFromEnv()...)
+}
+
+func main() {
+	lambda.Start(handler)
+}
+
+// handler checks the scheduler's and summaries' heartbeats on a schedule of
+// its own, so a scheduler that stops running is still noticed.
+func handler(ctx context.Context, event events.CloudWatchEvent) error {
+	ctx = botCtx.WithRequestID(ctx, event.ID)
+
+	ctx, done := botCtx.Tracer().StartSpan(ctx, "monitor_handler")
+	defer done()
+
+	if err := monitor.Check(ctx, time.Now()); err != nil {
+		botCtx.Logger().Error(ctx, "Failed to check heartbeats", err)
+		return err
+	}
+	return nil
+}
@@ -1,16 +1,28 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
 
 	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/i18n"
 	lambdautil "github.com/synaptiq/standup-bot/internal/lambda"
+	"github.com/synaptiq/standup-bot/internal/notify"
+	"github.com/synaptiq/standup-bot/internal/outbound"
+	"github.com/synaptiq/standup-bot/internal/queue"
+	"github.com/synaptiq/standup-bot/internal/report"
 	"github.com/synaptiq/standup-bot/internal/security"
 	"github.com/synaptiq/standup-bot/internal/slack"
 	"github.com/synaptiq/standup-bot/internal/standup"
@@ -23,6 +35,8 @@ var (
 	dataStore   store.Store
 	slackClient slack.Client
 	service     *standup.Service
+	exporter    *report.Exporter
+	uploader    *report.S3Uploader // nil to upload exports to Slack instead
 )
 
 func init() {
@@ -36,26 +50,45 @@ func init() {
 		log.Fatalf("Failed to initialize: %v", err)
 	}
 
+	// Mirror reminders and summaries to email or webhooks if configured
+	mirrors, err := notify.FromEnv(ctx)
+	if err != nil {
+		log.Fatalf("Failed to configure notifiers: %v", err)
+	}
+
+	// Publish reminders to external systems if configured
+	events, err := outbound.FromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure outbound webhooks: %v", err)
+	}
+
 	// Create service
-	service = standup.NewService(botCtx, dataStore, slackClient)
+	opts := append(standup.ReminderOptionsFromEnv(),
+		standup.WithMirrors(mirrors...), standup.WithEventPublisher(events))
+	service = standup.NewService(botCtx, dataStore, slackClient, opts...)
+	exporter = report.NewExporter(dataStore)
+
+	// Exports are uploaded to S3 and shared via presigned URLs
+	if bucket := os.Getenv("EXPORT_BUCKET"); bucket != "" {
+		awsCfg, err := config.LoadDefaultConfig(ctx)
+		if err != nil {
+			log.Fatalf("Failed to load AWS config: %v", err)
+		}
+		uploader = report.NewS3Uploader(s3.NewFromConfig(awsCfg), bucket, report.DefaultLinkExpiry)
+	}
 }
 
 func main() {
 	lambda.Start(handler)
 }
 
-// TaskMessage represents an async task to process.
-type TaskMessage struct {
-	Type      string                 `json:"type"`
-	ChannelID string                 `json:"channel_id"`
-	UserID    string                 `json:"user_id"`
-	Payload   map[string]interface{} `json:"payload"`
-}
-
 // handler processes SQS messages for async tasks.
 func handler(ctx context.Context, event events.SQSEvent) error {
 	logger := botCtx.Logger()
 
+	// Finish delivering events before the function is frozen
+	defer service.FlushEvents()
+
 	// Process each message
 	for i := range event.Records {
 		record := &event.Records[i]
@@ -86,7 +119,7 @@ func handler(ctx context.Context, event events.SQSEvent) error {
 }
 
 func processMessage(ctx context.Context, body string) error {
-	var task TaskMessage
+	var task queue.Task
 	if err := json.Unmarshal([]byte(body), &task); err != nil {
 		// Bad message format - don't retry
 		botCtx.Logger().Error(ctx, "Invalid message format", err,
@@ -104,17 +137,31 @@ func processMessage(ctx context.Context, body string) error {
 	}
 
 	logger := botCtx.Logger()
+
+	ctx, ok := service.WithTeam(ctx, task.TeamID)
+	if !ok {
+		// The workspace is no longer served - don't retry
+		logger.Warn(ctx, "Ignoring task for unknown workspace",
+			botcontext.Field{Key: "team_id", Value: security.SanitizeLogValue(task.TeamID)},
+		)
+		return nil
+	}
+
 	logger.Info(ctx, "Processing task",
 		botcontext.Field{Key: "task_type", Value: security.SanitizeLogValue(task.Type)},
 	)
 
 	switch task.Type {
-	case "send_welcome":
+	case queue.TaskSendWelcome:
 		return processSendWelcome(ctx, task)
-	case "generate_report":
+	case queue.TaskGenerateReport:
 		return processGenerateReport(ctx, task)
-	case "bulk_reminder":
+	case queue.TaskBulkReminder:
 		return processBulkReminder(ctx, task)
+	case queue.TaskPrefillStandup:
+		return processPrefillStandup(ctx, task)
+	case queue.TaskPublishEvent:
+		return processPublishEvent(ctx, task)
 	default:
 		logger.Warn(ctx, "Unknown task type",
 			botcontext.Field{Key: "task_type", Value: security.SanitizeLogValue(task.Type)},
@@ -124,7 +171,7 @@ func processMessage(ctx context.Context, body string) error {
 	}
 }
 
-func processSendWelcome(ctx context.Context, task TaskMessage) error {
+func processSendWelcome(ctx context.Context, task queue.Task) error {
 	userID := task.UserID
 	channelID := task.ChannelID
 
@@ -133,7 +180,7 @@ func processSendWelcome(ctx context.Context, task TaskMessage) error {
 	}
 
 	// Get channel configuration
-	cfg := botCtx.Config()
+	cfg := service.Config(ctx)
 	channel, found := cfg.ChannelByID(channelID)
 	if !found {
 		return fmt.Errorf("channel %s not configured", security.SanitizeLogValue(channelID))
@@ -174,7 +221,7 @@ func processSendWelcome(ctx context.Context, task TaskMessage) error {
 	return nil
 }
 
-func processGenerateReport(ctx context.Context, task TaskMessage) error {
+func processGenerateReport(ctx context.Context, task queue.Task) error {
 	channelID := task.ChannelID
 	if channelID == "" {
 		return fmt.Errorf("missing channel ID for report")
@@ -188,6 +235,16 @@ func processGenerateReport(ctx context.Context, task TaskMessage) error {
 	endDate, _ := task.Payload["end_date"].(string)       //nolint:errcheck // optional parameter
 	reportType, _ := task.Payload["report_type"].(string) //nolint:errcheck // optional parameter
 
+	format, _ := task.Payload["format"].(string) //nolint:errcheck // optional parameter
+	switch reportType {
+	case "export":
+		return processExport(ctx, task, format, startDate, endDate)
+	case "tags":
+		tag, _ := task.Payload["tag"].(string)   //nolint:errcheck // checked by processTagReport
+		mode, _ := task.Payload["mode"].(string) //nolint:errcheck // optional parameter
+		return processTagReport(ctx, task, tag, mode, format, startDate, endDate)
+	}
+
 	// TODO: Implement report generation
 	// This would:
 	// 1. Query historical data from DynamoDB
@@ -201,10 +258,156 @@ func processGenerateReport(ctx context.Context, task TaskMessage) error {
 		botcontext.Field{Key: "end_date", Value: security.SanitizeLogValue(endDate)},
 	)
 
+	return respond(ctx, task, &slack.ResponseMessage{
+		ResponseType:    slack.ResponseEphemeral,
+		Text:            "Report generation isn't available yet.",
+		ReplaceOriginal: true,
+	})
+}
+
+// processExport exports the channel's responses in a date range and DMs the
+// requesting user the file: as a presigned S3 link when EXPORT_BUCKET is set,
+// otherwise as a Slack file upload.
+func processExport(ctx context.Context, task queue.Task, formatName, startDate, endDate string) error {
+	if task.UserID == "" {
+		return fmt.Errorf("missing user ID for export")
+	}
+
+	format, ok := report.ParseFormat(formatName)
+	if !ok {
+		format = report.FormatCSV
+	}
+
+	var questions []string
+	if channel, found := service.Config(ctx).ChannelByID(task.ChannelID); found {
+		questions = channel.Questions()
+	}
+
+	// Records are written as they're read rather than collected first
+	var buf bytes.Buffer
+	writer, err := report.NewWriter(&buf, format, questions)
+	if err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	if err := exporter.Each(ctx, task.ChannelID, questions, startDate, endDate, writer.Write); err != nil {
+		return fmt.Errorf("failed to collect export: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	summary := fmt.Sprintf("📦 Your standup export for <#%s> (%s to %s, %d responses) is ready.",
+		security.SanitizeLogValue(task.ChannelID), startDate, endDate, writer.Count())
+	filename := fmt.Sprintf("standup-%s-%s_%s.%s", task.ChannelID, startDate, endDate, format)
+	key := report.ExportKey(task.ChannelID, startDate, endDate, uuid.New().String(), format)
+	if err := deliverReport(ctx, task.UserID, summary, filename, key, format, buf.Bytes()); err != nil {
+		return err
+	}
+
+	botCtx.Logger().Info(ctx, "Exported standup history",
+		botcontext.Field{Key: "channel_id", Value: security.SanitizeLogValue(task.ChannelID)},
+		botcontext.Field{Key: "format", Value: string(format)},
+		botcontext.Field{Key: "records", Value: writer.Count()},
+	)
+
 	return nil
 }
 
-func processBulkReminder(ctx context.Context, task TaskMessage) error {
+// processTagReport rolls up the standups of the workspace's channels by the
+// values of a tag and DMs the requesting user the report, like an export.
+func processTagReport(
+	ctx context.Context, task queue.Task, tag, modeName, formatName, startDate, endDate string,
+) error {
+	if task.UserID == "" {
+		return fmt.Errorf("missing user ID for report")
+	}
+	if tag == "" {
+		return fmt.Errorf("missing tag for report")
+	}
+
+	mode, ok := report.ParseTagMode(modeName)
+	if !ok {
+		mode = report.TagParticipation
+	}
+	format, ok := report.ParseFormat(formatName)
+	if !ok {
+		format = report.FormatCSV
+	}
+
+	rollup, err := exporter.RollupByTag(ctx, service.Config(ctx).Channels(), tag, startDate, endDate)
+	if err != nil {
+		return fmt.Errorf("failed to roll up standups: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := report.WriteTagReport(&buf, format, mode, rollup); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	summary := fmt.Sprintf("📊 Your standup %s report by %s (%s to %s, %d values) is ready.",
+		mode, security.SanitizeLogValue(tag), startDate, endDate, len(rollup.Groups))
+	filename := fmt.Sprintf("standup-%s-%s-%s_%s.%s", tag, mode, startDate, endDate, format)
+	key := report.TagReportKey(tag, startDate, endDate, uuid.New().String(), format)
+	if err := deliverReport(ctx, task.UserID, summary, filename, key, format, buf.Bytes()); err != nil {
+		return err
+	}
+
+	botCtx.Logger().Info(ctx, "Rolled up standups by tag",
+		botcontext.Field{Key: "tag", Value: security.SanitizeLogValue(tag)},
+		botcontext.Field{Key: "mode", Value: string(mode)},
+		botcontext.Field{Key: "values", Value: len(rollup.Groups)},
+	)
+
+	return nil
+}
+
+// deliverReport DMs a user a report file with a summary: as a presigned S3
+// link under key when EXPORT_BUCKET is set, otherwise as a Slack file upload.
+func deliverReport(
+	ctx context.Context, userID, summary, filename, key string, format report.Format, data []byte,
+) error {
+	dmChannel, err := slackClient.OpenDM(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to open DM: %w", err)
+	}
+
+	if uploader == nil {
+		if _, err := slackClient.UploadFile(ctx, []string{dmChannel}, filename, data,
+			slack.WithInitialComment(summary)); err != nil {
+			return fmt.Errorf("failed to upload report: %w", err)
+		}
+		return nil
+	}
+
+	url, err := uploader.Upload(ctx, key, format.ContentType(), data)
+	if err != nil {
+		return err
+	}
+
+	blocks := slack.NewMessageBuilder().
+		AddSection(summary).
+		AddSection(fmt.Sprintf("<%s|Download %s> — link expires in %d hours.",
+			url, filename, int(uploader.Expiry()/time.Hour))).
+		Build()
+	if _, err := slackClient.PostMessage(ctx, dmChannel, slack.WithBlocks(blocks...)); err != nil {
+		return fmt.Errorf("failed to send report link: %w", err)
+	}
+	return nil
+}
+
+// respond sends a delayed response for tasks started by a slash command.
+func respond(ctx context.Context, task queue.Task, message *slack.ResponseMessage) error {
+	if task.ResponseURL == "" {
+		return nil
+	}
+
+	if err := slackClient.PostToResponseURL(ctx, task.ResponseURL, message); err != nil {
+		return fmt.Errorf("failed to post to response URL: %w", err)
+	}
+
+	return nil
+}
+
+func processBulkReminder(ctx context.Context, task queue.Task) error {
 	channelID := task.ChannelID
 	if channelID == "" {
 		return fmt.Errorf("missing channel ID for bulk reminder")
@@ -214,27 +417,68 @@ func processBulkReminder(ctx context.Context, task TaskMessage) error {
 	reminderTime, _ := task.Payload["reminder_time"].(string) //nolint:errcheck // optional parameter
 
 	// Send reminders
-	if err := service.SendReminders(ctx, channelID, reminderTime); err != nil {
+	result, err := service.SendReminders(ctx, channelID, reminderTime)
+	if err != nil {
 		return fmt.Errorf("failed to send bulk reminders: %w", err)
 	}
 
+	// Retry the task only if nobody could be reminded
+	if result.Sent == 0 && result.Failed > 0 {
+		return fmt.Errorf("failed to send bulk reminders: %w", result.Err())
+	}
+
 	return nil
 }
 
-// SendAsyncTask sends a task to the processor queue.
-//
-//nolint:unparam // ctx will be used when SQS implementation is added
-func SendAsyncTask(ctx context.Context, taskType, channelID, userID string, payload map[string]interface{}) error {
-	task := TaskMessage{
-		Type:      taskType,
-		ChannelID: channelID,
-		UserID:    userID,
-		Payload:   payload,
+func processPrefillStandup(ctx context.Context, task queue.Task) error {
+	externalID, _ := task.Payload["external_id"].(string) //nolint:errcheck // checked below
+	if externalID == "" || task.ChannelID == "" || task.UserID == "" {
+		return fmt.Errorf("missing required fields for standup prefill")
+	}
+
+	sessionID, _ := task.Payload["session_id"].(string) //nolint:errcheck // optional parameter
+	date, _ := task.Payload["date"].(string)            //nolint:errcheck // optional parameter
+	locale, _ := task.Payload["locale"].(string)        //nolint:errcheck // optional parameter
+	if date == "" {
+		date = service.Today(ctx, task.ChannelID)
 	}
 
-	// TODO: Send to SQS queue
-	// This would use AWS SDK to send the message to the processor queue
-	_ = task // Temporarily suppress unused variable warning
+	err := service.PrefillStandupModal(ctx, externalID, &slack.StandupModalMetadata{
+		ChannelID: task.ChannelID,
+		Date:      date,
+		SessionID: sessionID,
+		UserID:    task.UserID,
+		Timestamp: time.Now(),
+		Locale:    i18n.Locale(locale),
+	})
+	if err != nil {
+		// The user has likely submitted or closed the modal by a retry - don't retry
+		botCtx.Logger().Error(ctx, "Failed to prefill standup from GitHub", err)
+	}
+
+	return nil
+}
+
+func processPublishEvent(ctx context.Context, task queue.Task) error {
+	body, err := json.Marshal(task.Payload["event"])
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	var event outbound.Event
+	err = json.Unmarshal(body, &event)
+	if err == nil && event.Type == "" {
+		err = errors.New("event has no type")
+	}
+	if err != nil {
+		// Bad event - don't retry
+		botCtx.Logger().Error(ctx, "Invalid event", err,
+			botcontext.Field{Key: "body", Value: security.SanitizeLogValue(string(body))},
+		)
+		return nil
+	}
 
+	// Deliveries were already retried - failures are logged, not retried again
+	service.DeliverEvent(ctx, &event)
 	return nil
 }
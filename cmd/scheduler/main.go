@@ -2,13 +2,19 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 
 	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/archive"
 	lambdautil "github.com/synaptiq/standup-bot/internal/lambda"
+	"github.com/synaptiq/standup-bot/internal/notify"
+	"github.com/synaptiq/standup-bot/internal/outbound"
+	"github.com/synaptiq/standup-bot/internal/schedules"
 	"github.com/synaptiq/standup-bot/internal/security"
 	"github.com/synaptiq/standup-bot/internal/slack"
 	"github.com/synaptiq/standup-bot/internal/standup"
@@ -22,6 +28,7 @@ var (
 	slackClient slack.Client
 	service     *standup.Service
 	scheduler   *standup.Scheduler
+	polling     bool // Every channel is checked each minute, without schedules
 )
 
 func init() {
@@ -35,8 +42,36 @@ func init() {
 		log.Fatalf("Failed to initialize: %v", err)
 	}
 
+	// Mirror reminders and summaries to email or webhooks if configured
+	mirrors, err := notify.FromEnv(ctx)
+	if err != nil {
+		log.Fatalf("Failed to configure notifiers: %v", err)
+	}
+
+	// Publish summaries and reminders to external systems if configured
+	events, err := outbound.FromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure outbound webhooks: %v", err)
+	}
+
+	// Channels run on their own schedules when they're configured
+	channelSchedules, err := schedules.FromEnv(ctx)
+	if err != nil {
+		log.Fatalf("Failed to configure schedules: %v", err)
+	}
+	polling = channelSchedules == nil
+
+	// Snapshots of posted summaries go to S3 if configured
+	archiver, err := archive.FromEnv(ctx)
+	if err != nil {
+		log.Fatalf("Failed to configure summary archive: %v", err)
+	}
+
 	// Create service and scheduler
-	service = standup.NewService(botCtx, dataStore, slackClient)
+	opts := append(standup.ReminderOptionsFromEnv(),
+		standup.WithMirrors(mirrors...), standup.WithSchedules(channelSchedules), standup.WithEventPublisher(events),
+		standup.WithArchiver(archiver))
+	service = standup.NewService(botCtx, dataStore, slackClient, opts...)
 	scheduler = standup.NewScheduler(service, botCtx, dataStore)
 }
 
@@ -44,8 +79,23 @@ func main() {
 	lambda.Start(handler)
 }
 
-// handler processes EventBridge scheduled events.
-func handler(ctx context.Context, event *events.CloudWatchEvent) error {
+// handler processes scheduled events: a channel's own schedule runs that
+// channel's tasks, and the EventBridge rule either polls every channel or,
+// with schedules, keeps them in sync.
+func handler(ctx context.Context, payload json.RawMessage) error {
+	// Finish delivering events before the function is frozen
+	defer service.FlushEvents()
+
+	var target schedules.Event
+	if err := json.Unmarshal(payload, &target); err == nil && target.ChannelID != "" {
+		return handleChannel(ctx, &target)
+	}
+
+	var event events.CloudWatchEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("failed to parse event: %w", err)
+	}
+
 	logger := botCtx.Logger()
 
 	// Add request ID from event
@@ -61,6 +111,14 @@ func handler(ctx context.Context, event *events.CloudWatchEvent) error {
 	ctx, done := tracer.StartSpan(ctx, "scheduler_handler")
 	defer done()
 
+	if !polling {
+		if err := scheduler.SyncSchedules(ctx); err != nil {
+			logger.Error(ctx, "Failed to sync schedules", err)
+			return err
+		}
+		return nil
+	}
+
 	// Process scheduled tasks
 	if err := scheduler.ProcessScheduledTasks(ctx); err != nil {
 		logger.Error(ctx, "Failed to process scheduled tasks", err)
@@ -70,3 +128,23 @@ func handler(ctx context.Context, event *events.CloudWatchEvent) error {
 	logger.Info(ctx, "Scheduler completed successfully")
 	return nil
 }
+
+// handleChannel runs the tasks of the channel whose schedule fired.
+func handleChannel(ctx context.Context, target *schedules.Event) error {
+	logger := botCtx.Logger()
+
+	ctx, done := botCtx.Tracer().StartSpan(ctx, "scheduler_channel")
+	defer done()
+
+	logger.Info(ctx, "Channel schedule triggered",
+		botcontext.Field{Key: "channel_id", Value: security.SanitizeLogValue(target.ChannelID)},
+	)
+
+	if err := scheduler.ProcessChannel(ctx, target.TeamID, target.ChannelID); err != nil {
+		logger.Error(ctx, "Failed to process channel tasks", err,
+			botcontext.Field{Key: "channel_id", Value: security.SanitizeLogValue(target.ChannelID)},
+		)
+		return err
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -24,10 +26,92 @@ type Config interface {
 	// Feature flags
 	IsFeatureEnabled(feature string) bool
 
+	// BlockersChannel is where blockers are cross-posted with blockers_routing
+	BlockersChannel() string
+
+	// SummaryGroupBy is how summaries group answers with
+	// summary_include_answers: SummaryByUser (the default) or SummaryByQuestion
+	SummaryGroupBy() string
+
+	// Integrations configures the third-party services the bot works with
+	Integrations() Integrations
+
 	// Reload configuration from source
 	Reload() error
 }
 
+// Integrations configures third-party services. Each is nil when not
+// configured.
+type Integrations struct {
+	Jira     *JiraIntegration
+	GitHub   *GitHubIntegration
+	Calendar *CalendarIntegration
+}
+
+// JiraIntegration links Jira issues mentioned in standup answers.
+type JiraIntegration struct {
+	BaseURL  string   // Site URL, e.g. https://example.atlassian.net
+	Email    string   // Account the API token belongs to
+	APIToken string   // Only needed to comment
+	Projects []string // Project keys to link; empty links issues of any project
+	Comment  bool     // Comment on mentioned issues with a link to the standup
+}
+
+// String formats the integration with its API token redacted, so it can be
+// logged.
+func (j JiraIntegration) String() string {
+	type plain JiraIntegration // Without this method, so formatting doesn't recurse
+	j.APIToken = redact(j.APIToken)
+	return fmt.Sprintf("%+v", plain(j))
+}
+
+// GitHubIntegration prefills the standup modal with users' GitHub activity
+// since their last standup.
+type GitHubIntegration struct {
+	Token string            // Token that can read the repos
+	Repos []string          // Repos to look in, as owner/name
+	Users map[string]string // GitHub logins keyed by Slack user ID
+}
+
+// String formats the integration with its token redacted.
+func (g GitHubIntegration) String() string {
+	type plain GitHubIntegration
+	g.Token = redact(g.Token)
+	return fmt.Sprintf("%+v", plain(g))
+}
+
+// DefaultCalendarSyncTime is when out-of-office events are synced by default.
+const DefaultCalendarSyncTime = "00:30"
+
+// CalendarIntegration skips the standups of users who are out of office
+// according to Google Calendar.
+type CalendarIntegration struct {
+	Credentials string // Service account JSON key with domain-wide delegation
+	SyncTime    string // HH:MM in each channel's timezone
+}
+
+// String formats the integration with its credentials redacted.
+func (c CalendarIntegration) String() string {
+	type plain CalendarIntegration
+	c.Credentials = redact(c.Credentials)
+	return fmt.Sprintf("%+v", plain(c))
+}
+
+// redact hides a secret when a config is formatted, while showing whether
+// it's set.
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+// Ways summaries can group answers
+const (
+	SummaryByUser     = "user"
+	SummaryByQuestion = "question"
+)
+
 // ChannelConfig represents per-channel configuration
 type ChannelConfig interface {
 	ID() string
@@ -39,17 +123,178 @@ type ChannelConfig interface {
 	SummaryTime() time.Time
 	ReminderTimes() []time.Time
 	IsActiveDay(day time.Weekday) bool
+	Holidays() Holidays
+	// Escalation is what happens to users who ignore their reminders, or
+	// nil for nothing
+	Escalation() *Escalation
+	// WeeklyDigest and MonthlyDigest schedule digests of the channel's
+	// stats, or are nil when they aren't posted
+	WeeklyDigest() *Digest
+	MonthlyDigest() *Digest
 
 	// User management
 	Users() []UserConfig
 	UserByID(id string) (UserConfig, bool)
 	IsUserRequired(userID string) bool
+	// UserGroups are Slack user groups (S...) whose members are required
+	// too, looked up when each day's standup starts
+	UserGroups() []string
+	// Participants is ParticipantsChannelMembers when the channel's members
+	// are required too, refreshed when each day's standup starts
+	Participants() Participants
+	// ExcludedUsers are channel or user group members who aren't required,
+	// e.g. managers
+	ExcludedUsers() []string
+
+	// Locale is the language of the channel's messages, one of Locales, or
+	// empty for English. Users' own and Slack locales take precedence in
+	// messages to them
+	Locale() string
+
+	// ReviewAnswers shows users their answers to review before the standup
+	// is submitted
+	ReviewAnswers() bool
+
+	// Mentions is how users are shown in the channel's summaries
+	Mentions() MentionStyle
+
+	// Admins may change the channel's settings, export reports and force
+	// summaries, as may workspace admins
+	Admins() []string
+	IsAdmin(userID string) bool
+
+	// SummaryRecipients get a copy of each daily summary besides the
+	// channel: users (U...) by DM, e.g. managers, and other channels (C...
+	// or G...)
+	SummaryRecipients() []string
 
 	// Templates
 	Templates() TemplateConfig
 
 	// Questions
 	Questions() []string
+	TypedQuestions() []Question
+	// QuestionsFor returns the questions asked on day: the day's own set if
+	// one is configured, otherwise TypedQuestions
+	QuestionsFor(day time.Weekday) []Question
+}
+
+// Holidays lists days a channel skips standups
+type Holidays struct {
+	Dates  []string // YYYY-MM-DD in the channel's timezone
+	ICSURL string   // Optional HTTPS iCalendar feed of further holidays
+}
+
+// Escalation escalates a user's missing standup once they've been sent
+// AfterReminders reminders
+type Escalation struct {
+	AfterReminders int
+	Action         EscalationAction
+	ManagerID      string // User DMed by EscalateNotifyManager
+}
+
+// EscalationAction is how a missing standup is escalated
+type EscalationAction string
+
+// Supported escalation actions
+const (
+	// EscalateNotifyManager DMs the channel's manager
+	EscalateNotifyManager EscalationAction = "notify_manager"
+	// EscalatePublicNudge mentions the user in the standup thread
+	EscalatePublicNudge EscalationAction = "public_nudge"
+)
+
+// Digest schedules a periodic digest of a channel's standup stats
+type Digest struct {
+	Day           string    // Mon..Sun for weekly digests; 1-28 or "last" for monthly ones
+	Time          time.Time // In the channel's timezone
+	TargetChannel string    // Channel posted to; defaults to the standup channel
+}
+
+// Participants selects who is required to submit in a channel
+type Participants string
+
+// Supported participants modes
+const (
+	// ParticipantsUsers requires the configured users and user groups
+	ParticipantsUsers Participants = "users"
+	// ParticipantsChannelMembers requires the channel's members too, except
+	// bots and excluded users
+	ParticipantsChannelMembers Participants = "channel_members"
+)
+
+// MentionStyle selects how summaries show users
+type MentionStyle string
+
+// Supported mention styles
+const (
+	// MentionsMention shows users as Slack mentions, which notify them
+	MentionsMention MentionStyle = "mention"
+	// MentionsName shows users' names without notifying them, for large
+	// channels
+	MentionsName MentionStyle = "name"
+	// MentionsBoth shows users' names followed by a mention
+	MentionsBoth MentionStyle = "both"
+)
+
+// MentionStyles are the supported mention styles
+var MentionStyles = []string{string(MentionsMention), string(MentionsName), string(MentionsBoth)}
+
+// Locales are the languages messages can be in
+var Locales = []string{"en", "es", "de", "fr"}
+
+// QuestionType identifies how a standup question is answered
+type QuestionType string
+
+// Supported question types
+const (
+	QuestionText        QuestionType = "text"
+	QuestionSelect      QuestionType = "select"
+	QuestionMultiSelect QuestionType = "multi_select"
+	QuestionYesNo       QuestionType = "yes_no"
+	QuestionDate        QuestionType = "date"
+	QuestionNumber      QuestionType = "number"
+)
+
+// MaxAnswerLength is the most characters Slack accepts in a text input
+const MaxAnswerLength = 3000
+
+// Question represents a standup question and how it is answered
+type Question struct {
+	ID       string // Optional; lets later questions depend on this one
+	Text     string
+	Type     QuestionType
+	Options  []string // Choices for select and multi_select questions
+	Optional bool
+	ShowIf   *Condition // Only ask the question when the condition holds
+
+	// Rules for text answers, checked when the standup is submitted
+	MinLength      int    // Minimum characters; 0 for no minimum
+	MaxLength      int    // Maximum characters; 0 for no maximum
+	Pattern        string // Regular expression answers must match
+	PatternMessage string // Shown when an answer doesn't match Pattern
+
+	// Tags group the question's answers in reports across channels, e.g.
+	// project: payments
+	Tags map[string]string
+}
+
+// Condition makes a question depend on the answer to an earlier question
+type Condition struct {
+	Question string   // ID of an earlier select, multi_select or yes_no question
+	Equals   []string // Answers that show the question
+}
+
+// Matches reports whether any of an answer's values is one of the condition's
+func (c *Condition) Matches(values ...string) bool {
+	for _, value := range values {
+		for _, want := range c.Equals {
+			if strings.EqualFold(value, want) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // UserConfig represents a user configuration
@@ -57,6 +302,22 @@ type UserConfig interface {
 	ID() string
 	Name() string
 	Timezone() *time.Location
+	// IsWorkingDay reports whether the user works on day, e.g. false on a
+	// part-timer's days off. Users without working days of their own work
+	// every day the channel's standups run.
+	IsWorkingDay(day time.Weekday) bool
+	// Tags group the user's standups in reports across channels, e.g.
+	// project: payments
+	Tags() map[string]string
+}
+
+// TemplateVariables are the variables each message template is rendered
+// with, used as {{.Name}}.
+var TemplateVariables = map[string][]string{
+	"reminder":       {"UserName", "ChannelName"},
+	"summary_header": {"Date"},
+	"user_completed": {"UserName", "Time"},
+	"user_missing":   {"UserName"},
 }
 
 // TemplateConfig represents message templates
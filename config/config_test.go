@@ -1,8 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -33,13 +36,21 @@ channels:
         - "08:30"
         - "08:50"
       active_days: ["Mon", "Tue", "Wed", "Thu", "Fri"]
+      holidays:
+        dates: ["2024-12-25", "2025-01-01"]
+        ics_url: "https://calendar.example.com/holidays.ics"
     users:
       - id: "U1234567890"
         name: "alice"
         timezone: "America/New_York"
+        tags:
+          project: "payments"
       - id: "U0987654321"
         name: "bob"
         timezone: "America/Chicago"
+        active_days: ["Mon", "Tue", "Wed"]
+    admins: ["U1234567890"]
+    summary_recipients: ["U5555555555", "C5555555555"]
     templates:
       reminder: "Hey {{.UserName}}! Don't forget to submit your standup update for #{{.ChannelName}}"
       summary_header: "📊 Daily Standup Summary for {{.Date}}"
@@ -144,6 +155,16 @@ features:
 		t.Error("Expected Saturday to be inactive")
 	}
 
+	// Test holidays
+	holidays := ch.Holidays()
+	if len(holidays.Dates) != 2 || holidays.Dates[0] != "2024-12-25" {
+		t.Errorf("Expected 2 holiday dates starting with 2024-12-25, got %v", holidays.Dates)
+	}
+
+	if holidays.ICSURL != "https://calendar.example.com/holidays.ics" {
+		t.Errorf("Unexpected holiday feed URL: %s", holidays.ICSURL)
+	}
+
 	// Test users
 	users := ch.Users()
 	if len(users) != 2 {
@@ -164,10 +185,34 @@ features:
 		t.Error("Expected user U1234567890 to be required")
 	}
 
+	// Users work every day unless they have working days of their own
+	if !user.IsWorkingDay(time.Friday) {
+		t.Error("Expected alice to work on Friday")
+	}
+
+	bob, _ := ch.UserByID("U0987654321")
+	if !bob.IsWorkingDay(time.Wednesday) || bob.IsWorkingDay(time.Thursday) {
+		t.Error("Expected bob to work Monday to Wednesday only")
+	}
+
+	if user.Tags()["project"] != "payments" || bob.Tags() != nil {
+		t.Errorf("Expected alice to be tagged project: payments and bob untagged, got %v and %v",
+			user.Tags(), bob.Tags())
+	}
+
+	if got := ch.SummaryRecipients(); len(got) != 2 || got[0] != "U5555555555" || got[1] != "C5555555555" {
+		t.Errorf("Expected summary recipients U5555555555 and C5555555555, got %v", got)
+	}
+
 	if ch.IsUserRequired("U9999999999") {
 		t.Error("Expected user U9999999999 to not be required")
 	}
 
+	// Test admins
+	if !ch.IsAdmin("U1234567890") || ch.IsAdmin("U0987654321") {
+		t.Errorf("Expected only U1234567890 to be an admin, got %v", ch.Admins())
+	}
+
 	// Test templates
 	tmpl := ch.Templates()
 	if tmpl.Reminder() != "Hey {{.UserName}}! Don't forget to submit your standup update for #{{.ChannelName}}" {
@@ -179,6 +224,13 @@ features:
 	if len(questions) != 3 {
 		t.Fatalf("Expected 3 questions, got %d", len(questions))
 	}
+
+	// Plain string questions default to free text
+	for _, q := range ch.TypedQuestions() {
+		if q.Type != QuestionText {
+			t.Errorf("Expected question %q to be text, got %s", q.Text, q.Type)
+		}
+	}
 }
 
 func TestYAMLConfigWithEnvironmentVariables(t *testing.T) {
@@ -392,7 +444,7 @@ channels:
 			errMsg:  "reminder time .* must be before summary time",
 		},
 		{
-			name: "missing template variables",
+			name: "invalid admin ID",
 			config: `version: "1.0"
 bot:
   token: "xoxb-test"
@@ -406,55 +458,1503 @@ channels:
     schedule:
       timezone: "UTC"
       summary_time: "09:00"
-      reminder_times: []
+      reminder_times: ["08:30"]
       active_days: ["Mon"]
     users:
       - id: "U123"
         name: "test"
+    admins: ["alice"]
     templates:
-      reminder: "Missing variables"
+      reminder: "{{.UserName}} {{.ChannelName}}"
       summary_header: "{{.Date}}"
       user_completed: "{{.UserName}} {{.Time}}"
       user_missing: "{{.UserName}}"
     questions: ["Q1"]
 `,
 			wantErr: true,
-			errMsg:  "reminder template must contain {{.UserName}}",
+			errMsg:  "admin ID must start with 'U'",
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tmpDir := t.TempDir()
-			configPath := filepath.Join(tmpDir, "config.yaml")
-
-			if err := os.WriteFile(configPath, []byte(tt.config), 0o644); err != nil {
-				t.Fatalf("Failed to write test config: %v", err)
-			}
-
-			provider := NewYAMLProvider(configPath)
-			cfg, err := provider.Load()
-			if err != nil {
-				if !tt.wantErr {
-					t.Fatalf("Failed to load config: %v", err)
-				}
-				return
-			}
-
-			err = validator.Validate(cfg)
-			if tt.wantErr {
-				if err == nil {
-					t.Error("Expected validation error, got none")
-				}
-				// Optionally check error message contains expected text
-				// if tt.errMsg != "" && !strings.Contains(err.Error(), tt.errMsg) {
-				// 	t.Errorf("Expected error containing %q, got %q", tt.errMsg, err.Error())
-				// }
-			} else {
-				if err != nil {
-					t.Errorf("Unexpected validation error: %v", err)
-				}
-			}
-		})
+		{
+			name: "user working on none of the active days",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon", "Tue"]
+    users:
+      - id: "U123"
+        name: "test"
+        active_days: ["Thu", "Fri"]
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "works on none of the channel's active days",
+		},
+		{
+			name: "invalid summary recipient",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+    users:
+      - id: "U123"
+        name: "test"
+    summary_recipients: ["S123"]
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "summary recipient must be a user",
+		},
+		{
+			name: "tag without a value",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions:
+      - text: "Q1"
+        tags:
+          project: ""
+`,
+			wantErr: true,
+			errMsg:  "tag project must have a value",
+		},
+		{
+			name: "user groups instead of users",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    user_groups: ["S0123456789"]
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: false,
+		},
+		{
+			name: "invalid user group ID",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    user_groups: ["@engineering"]
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "user group ID must start with 'S'",
+		},
+		{
+			name: "channel members as participants",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    participants: channel_members
+    exclude_users: ["U0123456789"]
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: false,
+		},
+		{
+			name: "unknown participants",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    participants: everyone
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "participants must be",
+		},
+		{
+			name: "channel locale",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    locale: es
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: false,
+		},
+		{
+			name: "unsupported locale",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    locale: ja
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "locale must be one of",
+		},
+		{
+			name: "unknown mention style",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    mentions: everyone
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "mentions must be one of",
+		},
+		{
+			name: "missing template variables",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: []
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "Missing variables"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "reminder template must contain {{.UserName}}",
+		},
+		{
+			name: "unknown template variable",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: []
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}} {{if .Team}}for {{.Team}}{{end}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "summary_header template can only use Date",
+		},
+		{
+			name: "invalid template syntax",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: []
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "summary_header template is invalid",
+		},
+		{
+			name: "template conditionals",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: []
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "Standup {{ .Date }}{{if eq .Date \"2024-12-25\"}} 🎄{{end}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: false,
+		},
+		{
+			name: "typed questions",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions:
+      - "What did you do?"
+      - text: "Mood?"
+        type: select
+        options: ["Great", "Okay", "Rough"]
+      - text: "Blocked?"
+        type: yes_no
+      - text: "Release date?"
+        type: date
+        optional: true
+`,
+			wantErr: false,
+		},
+		{
+			name: "day questions with invalid question",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon", "Fri"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+    day_questions:
+      Fri:
+        - text: "Mood?"
+          type: select
+`,
+			wantErr: true,
+			errMsg:  "question validation failed for Friday",
+		},
+		{
+			name: "jira comments without credentials",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+integrations:
+  jira:
+    base_url: "https://example.atlassian.net"
+    comment: true
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "jira.email and jira.api_token are required",
+		},
+		{
+			name: "jira without https base URL",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+integrations:
+  jira:
+    base_url: "example.atlassian.net"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "jira.base_url must be an https URL",
+		},
+		{
+			name: "github repo without owner",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+integrations:
+  github:
+    token: "ghp_test"
+    repos: ["api"]
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "isn't a repo like owner/name",
+		},
+		{
+			name: "select without options",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions:
+      - text: "Mood?"
+        type: select
+`,
+			wantErr: true,
+			errMsg:  "requires options",
+		},
+		{
+			name: "unknown question type",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions:
+      - text: "Mood?"
+        type: slider
+`,
+			wantErr: true,
+			errMsg:  "unknown type",
+		},
+		{
+			name: "conditional question",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions:
+      - id: blocked
+        text: "Blocked?"
+        type: yes_no
+      - text: "Blocker details?"
+        show_if:
+          question: blocked
+          equals: "yes"
+`,
+			wantErr: false,
+		},
+		{
+			name: "condition on later question",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions:
+      - text: "Blocker details?"
+        show_if:
+          question: blocked
+          equals: "yes"
+      - id: blocked
+        text: "Blocked?"
+        type: yes_no
+`,
+			wantErr: true,
+			errMsg:  "unknown question id",
+		},
+		{
+			name: "condition on text question",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions:
+      - id: yesterday
+        text: "What did you do?"
+      - text: "Anything else?"
+        show_if:
+          question: yesterday
+          equals: ["nothing"]
+`,
+			wantErr: true,
+			errMsg:  "must be select, multi_select or yes_no",
+		},
+		{
+			name: "condition on unknown option",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions:
+      - id: mood
+        text: "Mood?"
+        type: select
+        options: ["Great", "Rough"]
+      - text: "What's wrong?"
+        show_if:
+          question: mood
+          equals: ["Terrible"]
+`,
+			wantErr: true,
+			errMsg:  "is not an answer",
+		},
+		{
+			name: "invalid answer pattern",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions:
+      - text: "Ticket?"
+        pattern: "PROJ-[0-9+"
+`,
+			wantErr: true,
+			errMsg:  "pattern is invalid",
+		},
+		{
+			name: "min length greater than max length",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions:
+      - text: "Yesterday?"
+        min_length: 50
+        max_length: 10
+`,
+			wantErr: true,
+			errMsg:  "min_length can't be greater than max_length",
+		},
+		{
+			name: "length rules on choice question",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions:
+      - text: "Mood?"
+        type: select
+        options: ["Great", "Rough"]
+        min_length: 5
+`,
+			wantErr: true,
+			errMsg:  "can't have length or pattern rules",
+		},
+		{
+			name: "invalid holiday date",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+      holidays:
+        dates: ["2024-13-01"]
+    users:
+      - id: "U123"
+        name: "test"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "invalid holiday date",
+		},
+		{
+			name: "holiday feed over http",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+      holidays:
+        ics_url: "http://calendar.example.com/holidays.ics"
+    users:
+      - id: "U123"
+        name: "test"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "invalid holiday feed URL",
+		},
+		{
+			name: "escalation to a manager",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+      escalation:
+        after_reminders: 2
+        action: "notify_manager"
+        manager_id: "U999"
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: false,
+		},
+		{
+			name: "escalation to a manager without manager_id",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+      escalation:
+        after_reminders: 2
+        action: "notify_manager"
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "manager_id is required",
+		},
+		{
+			name: "escalation without after_reminders",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+      escalation:
+        action: "public_nudge"
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "after_reminders must be at least 1",
+		},
+		{
+			name: "unknown escalation action",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+      escalation:
+        after_reminders: 2
+        action: "email"
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "escalation action must be",
+		},
+		{
+			name: "weekly and monthly digests",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+      weekly_digest:
+        day: "Fri"
+        time: "17:00"
+      monthly_digest:
+        day: "last"
+        time: "17:00"
+        target_channel: "C456"
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: false,
+		},
+		{
+			name: "weekly digest on an unknown day",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+      weekly_digest:
+        day: "Someday"
+        time: "17:00"
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "weekly_digest day must be a weekday",
+		},
+		{
+			name: "monthly digest on a day not every month has",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+      monthly_digest:
+        day: "31"
+        time: "17:00"
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "monthly_digest day must be 1-28 or last",
+		},
+		{
+			name: "digest without a time",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+      weekly_digest:
+        day: "Fri"
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "invalid weekly_digest time",
+		},
+		{
+			name: "digest posted to a user",
+			config: `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon"]
+      weekly_digest:
+        day: "Fri"
+        time: "17:00"
+        target_channel: "U123"
+    users:
+      - id: "U123"
+        name: "test"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Q1"]
+`,
+			wantErr: true,
+			errMsg:  "target_channel must be a channel ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			configPath := filepath.Join(tmpDir, "config.yaml")
+
+			if err := os.WriteFile(configPath, []byte(tt.config), 0o644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			provider := NewYAMLProvider(configPath)
+			cfg, err := provider.Load()
+			if err != nil {
+				if !tt.wantErr {
+					t.Fatalf("Failed to load config: %v", err)
+				}
+				return
+			}
+
+			err = validator.Validate(cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected validation error, got none")
+				}
+				// Optionally check error message contains expected text
+				// if tt.errMsg != "" && !strings.Contains(err.Error(), tt.errMsg) {
+				// 	t.Errorf("Expected error containing %q, got %q", tt.errMsg, err.Error())
+				// }
+			} else {
+				if err != nil {
+					t.Errorf("Unexpected validation error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestQuestionTemplatesAndDayQuestions(t *testing.T) {
+	base := `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+question_templates:
+  standard:
+    - "What did you do yesterday?"
+    - "What will you do today?"
+  retro:
+    - "What went well this week?"
+channels:
+  - id: "C123"
+    name: "test"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+      reminder_times: ["08:30"]
+      active_days: ["Mon", "Fri"]
+    users:
+      - id: "U123"
+        name: "test"
+    questions:
+      - template: standard
+      - "Any blockers?"
+    day_questions:
+      Fri:
+        - template: standard
+        - template: retro
+`
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte(base), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := NewYAMLProvider(configPath).Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	ch, _ := cfg.ChannelByID("C123")
+
+	texts := func(questions []Question) []string {
+		out := make([]string, len(questions))
+		for i, q := range questions {
+			out[i] = q.Text
+		}
+		return out
+	}
+
+	want := []string{"What did you do yesterday?", "What will you do today?", "Any blockers?"}
+	if got := texts(ch.TypedQuestions()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected questions %v, got %v", want, got)
+	}
+	if got := texts(ch.QuestionsFor(time.Monday)); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected Monday questions %v, got %v", want, got)
+	}
+
+	wantFriday := []string{"What did you do yesterday?", "What will you do today?", "What went well this week?"}
+	if got := texts(ch.QuestionsFor(time.Friday)); !reflect.DeepEqual(got, wantFriday) {
+		t.Errorf("Expected Friday questions %v, got %v", wantFriday, got)
+	}
+
+	// Unknown templates and bad days fail to load
+	for name, broken := range map[string]string{
+		"unknown template": strings.Replace(base, "template: retro", "template: missing", 1),
+		"invalid day":      strings.Replace(base, "      Fri:", "      Someday:", 1),
+	} {
+		if err := os.WriteFile(configPath, []byte(broken), 0o644); err != nil {
+			t.Fatalf("Failed to write test config: %v", err)
+		}
+		if _, err := NewYAMLProvider(configPath).Load(); err == nil {
+			t.Errorf("%s: expected load error, got none", name)
+		}
+	}
+}
+
+func TestYAMLProviderWatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configTemplate := `version: "%s"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels: []
+features: {}
+`
+
+	if err := os.WriteFile(configPath, []byte(fmt.Sprintf(configTemplate, "1.0")), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	provider := &yamlProvider{path: configPath, interval: 10 * time.Millisecond}
+	cfg, err := provider.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	reloaded := make(chan Config, 1)
+	if err := provider.Watch(func(c Config) {
+		select {
+		case reloaded <- c:
+		default:
+		}
+	}); err != nil {
+		t.Fatalf("Failed to watch config: %v", err)
+	}
+
+	if err := os.WriteFile(configPath, []byte(fmt.Sprintf(configTemplate, "2.0.0")), 0o644); err != nil {
+		t.Fatalf("Failed to update test config: %v", err)
+	}
+
+	select {
+	case c := <-reloaded:
+		if c.Version() != "2.0.0" {
+			t.Errorf("Expected reloaded version 2.0.0, got %s", c.Version())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for config reload")
+	}
+
+	// The originally loaded config can reload itself in place
+	if err := cfg.Reload(); err != nil {
+		t.Fatalf("Failed to reload config: %v", err)
+	}
+	if cfg.Version() != "2.0.0" {
+		t.Errorf("Expected version 2.0.0 after reload, got %s", cfg.Version())
+	}
+}
+
+func TestYAMLProviderReusesUnchangedConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(version string) {
+		t.Helper()
+		content := fmt.Sprintf("version: %q\nchannels: []\n", version)
+		if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write test config: %v", err)
+		}
+	}
+	write("1.0")
+
+	provider := NewYAMLProvider(configPath)
+	first, err := provider.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	// An unchanged file isn't parsed again
+	again, err := provider.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if again != first {
+		t.Error("Expected the unchanged config to be reused")
+	}
+
+	write("2.0.0")
+	changed, err := provider.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if changed == first || changed.Version() != "2.0.0" {
+		t.Errorf("Expected the changed config to be parsed, got version %s", changed.Version())
+	}
+}
+
+func TestIntegrationsRedactSecrets(t *testing.T) {
+	jira := JiraIntegration{BaseURL: "https://example.atlassian.net", APIToken: "jira-secret"}
+	github := &GitHubIntegration{Token: "ghp_secret", Repos: []string{"acme/api"}}
+	calendar := CalendarIntegration{Credentials: `{"private_key": "secret"}`}
+
+	for _, formatted := range []string{
+		fmt.Sprintf("%v", jira), fmt.Sprintf("%+v", github), fmt.Sprint(calendar),
+	} {
+		if strings.Contains(formatted, "secret") {
+			t.Errorf("Secrets should be redacted, got %s", formatted)
+		}
+		if !strings.Contains(formatted, "[REDACTED]") {
+			t.Errorf("Redacted secrets should show they're set, got %s", formatted)
+		}
+	}
+	if formatted := fmt.Sprint(jira); !strings.Contains(formatted, "https://example.atlassian.net") {
+		t.Errorf("Other fields should be kept, got %s", formatted)
+	}
+	if formatted := fmt.Sprint(JiraIntegration{}); strings.Contains(formatted, "[REDACTED]") {
+		t.Errorf("Unset secrets should stay empty, got %s", formatted)
 	}
 }
@@ -2,10 +2,24 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
 )
 
+// jiraProjectPattern matches Jira project keys.
+var jiraProjectPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]+$`)
+
+// TagNamePattern matches the names of user and question tags, e.g. project.
+var TagNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// githubRepoPattern matches GitHub repos written as owner/name.
+var githubRepoPattern = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+
 // Validator validates configuration
 type Validator interface {
 	Validate(cfg Config) error
@@ -39,6 +53,59 @@ func (v *validator) Validate(cfg Config) error {
 		return fmt.Errorf("channel validation failed: %w", err)
 	}
 
+	if cfg.IsFeatureEnabled("blockers_routing") && cfg.BlockersChannel() == "" {
+		return fmt.Errorf("blockers.channel is required when blockers_routing is enabled")
+	}
+
+	if err := v.validateIntegrations(cfg.Integrations()); err != nil {
+		return fmt.Errorf("integrations validation failed: %w", err)
+	}
+
+	if groupBy := cfg.SummaryGroupBy(); groupBy != SummaryByUser && groupBy != SummaryByQuestion {
+		return fmt.Errorf("summary.group_by must be %q or %q, got %q", SummaryByUser, SummaryByQuestion, groupBy)
+	}
+
+	return nil
+}
+
+func (v *validator) validateIntegrations(integrations Integrations) error {
+	if jira := integrations.Jira; jira != nil {
+		if u, err := url.Parse(jira.BaseURL); err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("jira.base_url must be an https URL, got %q", jira.BaseURL)
+		}
+		if jira.Comment && (jira.Email == "" || jira.APIToken == "") {
+			return fmt.Errorf("jira.email and jira.api_token are required to comment on issues")
+		}
+		for _, project := range jira.Projects {
+			if !jiraProjectPattern.MatchString(project) {
+				return fmt.Errorf("jira.projects: %q isn't a project key like ABC", project)
+			}
+		}
+	}
+
+	if github := integrations.GitHub; github != nil {
+		if github.Token == "" {
+			return fmt.Errorf("github.token is required")
+		}
+		if len(github.Repos) == 0 {
+			return fmt.Errorf("github.repos must list at least one repo")
+		}
+		for _, repo := range github.Repos {
+			if !githubRepoPattern.MatchString(repo) {
+				return fmt.Errorf("github.repos: %q isn't a repo like owner/name", repo)
+			}
+		}
+	}
+
+	if calendar := integrations.Calendar; calendar != nil {
+		if calendar.Credentials == "" {
+			return fmt.Errorf("calendar.credentials is required")
+		}
+		if _, err := time.Parse("15:04", calendar.SyncTime); err != nil {
+			return fmt.Errorf("calendar.sync_time must be HH:MM, got %q", calendar.SyncTime)
+		}
+	}
+
 	return nil
 }
 
@@ -128,21 +195,156 @@ func (v *validator) validateChannel(ch ChannelConfig) error {
 		return fmt.Errorf("schedule validation failed: %w", err)
 	}
 
+	if err := v.validateEscalation(ch.Escalation()); err != nil {
+		return fmt.Errorf("escalation validation failed: %w", err)
+	}
+
+	if err := v.validateDigests(ch); err != nil {
+		return fmt.Errorf("digest validation failed: %w", err)
+	}
+
 	// Validate users
 	if err := v.validateUsers(ch); err != nil {
 		return fmt.Errorf("user validation failed: %w", err)
 	}
 
+	// Validate admins
+	if err := v.validateAdmins(ch.Admins()); err != nil {
+		return fmt.Errorf("admin validation failed: %w", err)
+	}
+
+	if err := v.validateSummaryRecipients(ch); err != nil {
+		return fmt.Errorf("summary recipient validation failed: %w", err)
+	}
+
 	// Validate templates
 	if err := v.validateTemplates(ch.Templates()); err != nil {
 		return fmt.Errorf("template validation failed: %w", err)
 	}
 
-	// Validate questions
-	if len(ch.Questions()) == 0 {
+	if locale := ch.Locale(); locale != "" && !slices.Contains(Locales, locale) {
+		return fmt.Errorf("locale must be one of %s: %s", strings.Join(Locales, ", "), locale)
+	}
+
+	if mentions := string(ch.Mentions()); !slices.Contains(MentionStyles, mentions) {
+		return fmt.Errorf("mentions must be one of %s: %s", strings.Join(MentionStyles, ", "), mentions)
+	}
+
+	// Validate questions, including each day's own set
+	if err := v.validateQuestions(ch.TypedQuestions()); err != nil {
+		return fmt.Errorf("question validation failed: %w", err)
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if err := v.validateQuestions(ch.QuestionsFor(day)); err != nil {
+			return fmt.Errorf("question validation failed for %s: %w", day, err)
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) validateQuestions(questions []Question) error {
+	if len(questions) == 0 {
 		return fmt.Errorf("at least one question is required")
 	}
 
+	byID := make(map[string]Question)
+	for i, q := range questions {
+		if q.Text == "" {
+			return fmt.Errorf("question[%d] text is required", i)
+		}
+
+		switch q.Type {
+		case QuestionText, QuestionYesNo, QuestionDate, QuestionNumber:
+		case QuestionSelect, QuestionMultiSelect:
+			if len(q.Options) == 0 {
+				return fmt.Errorf("question[%d] of type %s requires options", i, q.Type)
+			}
+		default:
+			return fmt.Errorf("question[%d] has unknown type: %s", i, q.Type)
+		}
+
+		if err := v.validateAnswerRules(q); err != nil {
+			return fmt.Errorf("question[%d] %w", i, err)
+		}
+
+		if err := v.validateTags(q.Tags); err != nil {
+			return fmt.Errorf("question[%d] %w", i, err)
+		}
+
+		// Conditions can only refer to earlier questions
+		if q.ShowIf != nil {
+			if err := v.validateCondition(q.ShowIf, byID); err != nil {
+				return fmt.Errorf("question[%d] show_if: %w", i, err)
+			}
+		}
+
+		if q.ID != "" {
+			if _, exists := byID[q.ID]; exists {
+				return fmt.Errorf("duplicate question id: %s", q.ID)
+			}
+			byID[q.ID] = q
+		}
+	}
+
+	return nil
+}
+
+// validateAnswerRules checks the length and pattern rules of a text question
+func (v *validator) validateAnswerRules(q Question) error {
+	if q.MinLength == 0 && q.MaxLength == 0 && q.Pattern == "" && q.PatternMessage == "" {
+		return nil
+	}
+	if q.Type != QuestionText {
+		return fmt.Errorf("of type %s can't have length or pattern rules", q.Type)
+	}
+	if q.MinLength < 0 || q.MaxLength < 0 {
+		return fmt.Errorf("min_length and max_length can't be negative")
+	}
+	if q.MaxLength > 0 && q.MinLength > q.MaxLength {
+		return fmt.Errorf("min_length can't be greater than max_length")
+	}
+	if q.MaxLength > MaxAnswerLength {
+		return fmt.Errorf("max_length can't be greater than %d", MaxAnswerLength)
+	}
+	if q.PatternMessage != "" && q.Pattern == "" {
+		return fmt.Errorf("pattern_message requires a pattern")
+	}
+	if q.Pattern != "" {
+		if _, err := regexp.Compile(q.Pattern); err != nil {
+			return fmt.Errorf("pattern is invalid: %w", err)
+		}
+	}
+	return nil
+}
+
+func (v *validator) validateCondition(cond *Condition, earlier map[string]Question) error {
+	source, ok := earlier[cond.Question]
+	if !ok {
+		return fmt.Errorf("unknown question id: %q", cond.Question)
+	}
+	if len(cond.Equals) == 0 {
+		return fmt.Errorf("at least one answer is required in equals")
+	}
+
+	// Only choice questions update the modal as they are answered
+	var answers []string
+	switch source.Type {
+	case QuestionYesNo:
+		answers = []string{"yes", "no"}
+	case QuestionSelect, QuestionMultiSelect:
+		answers = source.Options
+	default:
+		return fmt.Errorf("question %s must be select, multi_select or yes_no", cond.Question)
+	}
+
+	allowed := &Condition{Equals: answers}
+	for _, want := range cond.Equals {
+		if !allowed.Matches(want) {
+			return fmt.Errorf("%q is not an answer to question %s", want, cond.Question)
+		}
+	}
+
 	return nil
 }
 
@@ -176,10 +378,88 @@ func (v *validator) validateSchedule(ch ChannelConfig) error {
 	return nil
 }
 
+// validateEscalation checks the escalation, if any, has what its action needs
+func (v *validator) validateEscalation(escalation *Escalation) error {
+	if escalation == nil {
+		return nil
+	}
+
+	if escalation.AfterReminders < 1 {
+		return fmt.Errorf("after_reminders must be at least 1: %d", escalation.AfterReminders)
+	}
+
+	switch escalation.Action {
+	case EscalateNotifyManager:
+		if escalation.ManagerID == "" {
+			return fmt.Errorf("manager_id is required for %s", EscalateNotifyManager)
+		}
+		if !strings.HasPrefix(escalation.ManagerID, "U") && !strings.HasPrefix(escalation.ManagerID, "W") {
+			return fmt.Errorf("manager_id must be a user ID (U... or W...): %s", escalation.ManagerID)
+		}
+	case EscalatePublicNudge:
+	default:
+		return fmt.Errorf("escalation action must be %q or %q: %s",
+			EscalateNotifyManager, EscalatePublicNudge, escalation.Action)
+	}
+
+	return nil
+}
+
+// validateDigests checks the days and target channels of the digests
+func (v *validator) validateDigests(ch ChannelConfig) error {
+	if weekly := ch.WeeklyDigest(); weekly != nil {
+		if _, err := parseWeekday(weekly.Day); err != nil {
+			return fmt.Errorf("weekly_digest day must be a weekday like Fri: %s", weekly.Day)
+		}
+		if err := v.validateDigestTarget(weekly.TargetChannel); err != nil {
+			return fmt.Errorf("weekly_digest %w", err)
+		}
+	}
+
+	if monthly := ch.MonthlyDigest(); monthly != nil {
+		// Later days don't come every month
+		day, err := strconv.Atoi(monthly.Day)
+		if !strings.EqualFold(monthly.Day, "last") && (err != nil || day < 1 || day > 28) {
+			return fmt.Errorf("monthly_digest day must be 1-28 or last: %s", monthly.Day)
+		}
+		if err := v.validateDigestTarget(monthly.TargetChannel); err != nil {
+			return fmt.Errorf("monthly_digest %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) validateDigestTarget(target string) error {
+	if target != "" && !strings.HasPrefix(target, "C") && !strings.HasPrefix(target, "G") {
+		return fmt.Errorf("target_channel must be a channel ID (C... or G...): %s", target)
+	}
+	return nil
+}
+
 func (v *validator) validateUsers(ch ChannelConfig) error {
 	users := ch.Users()
-	if len(users) == 0 {
-		return fmt.Errorf("at least one user must be configured")
+	switch ch.Participants() {
+	case ParticipantsUsers:
+		if len(users) == 0 && len(ch.UserGroups()) == 0 {
+			return fmt.Errorf("at least one user or user group must be configured")
+		}
+	case ParticipantsChannelMembers:
+	default:
+		return fmt.Errorf("participants must be %q or %q: %s",
+			ParticipantsUsers, ParticipantsChannelMembers, ch.Participants())
+	}
+
+	for _, id := range ch.ExcludedUsers() {
+		if !strings.HasPrefix(id, "U") {
+			return fmt.Errorf("excluded user ID must start with 'U': %s", id)
+		}
+	}
+
+	for _, id := range ch.UserGroups() {
+		if !strings.HasPrefix(id, "S") {
+			return fmt.Errorf("user group ID must start with 'S': %s", id)
+		}
 	}
 
 	seenIDs := make(map[string]bool)
@@ -202,6 +482,59 @@ func (v *validator) validateUsers(ch ChannelConfig) error {
 		if u.Name() == "" {
 			return fmt.Errorf("user name is required for %s", u.ID())
 		}
+
+		// Part-timers must work on at least one of the channel's days
+		worksOnActiveDay := false
+		for i := 0; i < 7; i++ {
+			if ch.IsActiveDay(time.Weekday(i)) && u.IsWorkingDay(time.Weekday(i)) {
+				worksOnActiveDay = true
+				break
+			}
+		}
+		if !worksOnActiveDay {
+			return fmt.Errorf("user %s works on none of the channel's active days", u.ID())
+		}
+
+		if err := v.validateTags(u.Tags()); err != nil {
+			return fmt.Errorf("user %s %w", u.ID(), err)
+		}
+	}
+
+	return nil
+}
+
+// validateTags checks that tags have a name like project and a value
+func (v *validator) validateTags(tags map[string]string) error {
+	for name, value := range tags {
+		if !TagNamePattern.MatchString(name) {
+			return fmt.Errorf("tag name %q may only have letters, digits, _ and -", name)
+		}
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("tag %s must have a value", name)
+		}
+	}
+	return nil
+}
+
+func (v *validator) validateAdmins(admins []string) error {
+	for _, id := range admins {
+		if !strings.HasPrefix(id, "U") {
+			return fmt.Errorf("admin ID must start with 'U': %s", id)
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) validateSummaryRecipients(ch ChannelConfig) error {
+	for _, id := range ch.SummaryRecipients() {
+		if id == ch.ID() {
+			return fmt.Errorf("the channel itself can't be a summary recipient: %s", id)
+		}
+		if !strings.HasPrefix(id, "U") && !strings.HasPrefix(id, "W") &&
+			!strings.HasPrefix(id, "C") && !strings.HasPrefix(id, "G") {
+			return fmt.Errorf("summary recipient must be a user (U... or W...) or channel (C... or G...) ID: %s", id)
+		}
 	}
 
 	return nil
@@ -239,9 +572,24 @@ func (v *validator) validateTemplates(tmpl TemplateConfig) error {
 		"user_missing":   tmpl.UserMissing(),
 	}
 
-	for name, template := range templates {
+	for name, text := range templates {
+		// Render each variable as itself, so the output shows which are used
+		vars := make(map[string]string, len(TemplateVariables[name]))
+		for _, variable := range TemplateVariables[name] {
+			vars[variable] = "{{." + variable + "}}"
+		}
+
+		parsed, err := template.New(name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return fmt.Errorf("%s template is invalid: %w", name, err)
+		}
+		var rendered strings.Builder
+		if err := parsed.Execute(&rendered, vars); err != nil {
+			return fmt.Errorf("%s template can only use %s: %w", name, strings.Join(TemplateVariables[name], ", "), err)
+		}
+
 		for _, required := range requiredVars[name] {
-			if !strings.Contains(template, required) {
+			if !strings.Contains(rendered.String(), required) {
 				return fmt.Errorf("%s template must contain %s", name, required)
 			}
 		}
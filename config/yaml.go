@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
@@ -13,6 +14,8 @@ import (
 // yamlConfig implements Config interface
 type yamlConfig struct {
 	mu       sync.RWMutex
+	provider *yamlProvider // Source file, nil when parsed from raw YAML
+	stamp    fileStamp     // Of the source file when it was parsed
 	raw      *yamlSchema
 	channels map[string]ChannelConfig
 	features map[string]bool
@@ -25,6 +28,44 @@ type yamlSchema struct {
 	Database databaseSchema  `yaml:"database"`
 	Channels []channelSchema `yaml:"channels"`
 	Features map[string]bool `yaml:"features"`
+	Blockers blockersSchema  `yaml:"blockers"`
+	Summary  summarySchema   `yaml:"summary"`
+	// QuestionTemplates are named question lists channels can include
+	QuestionTemplates map[string][]questionSchema `yaml:"question_templates"`
+	Integrations      integrationsSchema          `yaml:"integrations"`
+}
+
+type integrationsSchema struct {
+	Jira     *jiraSchema     `yaml:"jira"`
+	GitHub   *githubSchema   `yaml:"github"`
+	Calendar *calendarSchema `yaml:"calendar"`
+}
+
+type jiraSchema struct {
+	BaseURL  string   `yaml:"base_url"`
+	Email    string   `yaml:"email"`
+	APIToken string   `yaml:"api_token"`
+	Projects []string `yaml:"projects"`
+	Comment  bool     `yaml:"comment"`
+}
+
+type githubSchema struct {
+	Token string            `yaml:"token"`
+	Repos []string          `yaml:"repos"`
+	Users map[string]string `yaml:"users"`
+}
+
+type calendarSchema struct {
+	Credentials string `yaml:"credentials"`
+	SyncTime    string `yaml:"sync_time"`
+}
+
+type blockersSchema struct {
+	Channel string `yaml:"channel"`
+}
+
+type summarySchema struct {
+	GroupBy string `yaml:"group_by"`
 }
 
 type botSchema struct {
@@ -38,26 +79,121 @@ type databaseSchema struct {
 }
 
 type channelSchema struct {
-	ID        string         `yaml:"id"`
-	Name      string         `yaml:"name"`
-	Enabled   bool           `yaml:"enabled"`
-	Schedule  scheduleSchema `yaml:"schedule"`
-	Users     []userSchema   `yaml:"users"`
-	Templates templateSchema `yaml:"templates"`
-	Questions []string       `yaml:"questions"`
+	ID        string           `yaml:"id"`
+	Name      string           `yaml:"name"`
+	Enabled   bool             `yaml:"enabled"`
+	Schedule  scheduleSchema   `yaml:"schedule"`
+	Users     []userSchema     `yaml:"users"`
+	Groups    []string         `yaml:"user_groups"`
+	Admins    []string         `yaml:"admins"`
+	Templates templateSchema   `yaml:"templates"`
+	Questions []questionSchema `yaml:"questions"`
+	// DayQuestions replace Questions on the given weekdays, e.g. "Fri"
+	DayQuestions map[string][]questionSchema `yaml:"day_questions"`
+	// Participants is "users" (the default) or "channel_members"
+	Participants string   `yaml:"participants"`
+	Exclude      []string `yaml:"exclude_users"`
+	Locale       string   `yaml:"locale"`
+	Review       bool     `yaml:"review_answers"`
+	// Mentions is "mention" (the default), "name" or "both"
+	Mentions string `yaml:"mentions"`
+	// SummaryRecipients get a copy of each summary: users (U...) by DM,
+	// channels (C... or G...) as a message
+	SummaryRecipients []string `yaml:"summary_recipients"`
+}
+
+// questionSchema accepts either a plain question string or a typed question.
+// A question with a template stands for that template's questions.
+type questionSchema struct {
+	Template       string           `yaml:"template"`
+	ID             string           `yaml:"id"`
+	Text           string           `yaml:"text"`
+	Type           string           `yaml:"type"`
+	Options        []string         `yaml:"options"`
+	Optional       bool             `yaml:"optional"`
+	ShowIf         *conditionSchema `yaml:"show_if"`
+	MinLength      int              `yaml:"min_length"`
+	MaxLength      int              `yaml:"max_length"`
+	Pattern        string           `yaml:"pattern"`
+	PatternMessage string           `yaml:"pattern_message"`
+	// Tags group answers in reports, e.g. project: payments
+	Tags map[string]string `yaml:"tags"`
+}
+
+// conditionSchema accepts a single answer or a list of answers in equals
+type conditionSchema struct {
+	Question string   `yaml:"question"`
+	Equals   []string `yaml:"equals"`
+}
+
+func (c *conditionSchema) UnmarshalYAML(node *yaml.Node) error {
+	var raw struct {
+		Question string    `yaml:"question"`
+		Equals   yaml.Node `yaml:"equals"`
+	}
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+
+	c.Question = raw.Question
+	switch raw.Equals.Kind {
+	case 0:
+		return nil
+	case yaml.ScalarNode:
+		c.Equals = []string{raw.Equals.Value}
+		return nil
+	default:
+		return raw.Equals.Decode(&c.Equals)
+	}
+}
+
+func (q *questionSchema) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		q.Text = node.Value
+		return nil
+	}
+
+	type plain questionSchema
+	return node.Decode((*plain)(q))
 }
 
 type scheduleSchema struct {
-	Timezone      string   `yaml:"timezone"`
-	SummaryTime   string   `yaml:"summary_time"`
-	ReminderTimes []string `yaml:"reminder_times"`
-	ActiveDays    []string `yaml:"active_days"`
+	Timezone      string         `yaml:"timezone"`
+	SummaryTime   string         `yaml:"summary_time"`
+	ReminderTimes []string       `yaml:"reminder_times"`
+	ActiveDays    []string       `yaml:"active_days"`
+	Holidays      holidaysSchema `yaml:"holidays"`
+	// Escalation is optional; without it nobody is escalated
+	Escalation *escalationSchema `yaml:"escalation"`
+	// Digests are optional too
+	WeeklyDigest  *digestSchema `yaml:"weekly_digest"`
+	MonthlyDigest *digestSchema `yaml:"monthly_digest"`
+}
+
+type digestSchema struct {
+	Day           string `yaml:"day"`
+	Time          string `yaml:"time"`
+	TargetChannel string `yaml:"target_channel"`
+}
+
+type escalationSchema struct {
+	AfterReminders int    `yaml:"after_reminders"`
+	Action         string `yaml:"action"`
+	ManagerID      string `yaml:"manager_id"`
+}
+
+type holidaysSchema struct {
+	Dates  []string `yaml:"dates"`
+	ICSURL string   `yaml:"ics_url"`
 }
 
 type userSchema struct {
-	ID       string `yaml:"id"`
-	Name     string `yaml:"name"`
-	Timezone string `yaml:"timezone"`
+	ID         string   `yaml:"id"`
+	Name       string   `yaml:"name"`
+	Timezone   string   `yaml:"timezone"`
+	ActiveDays []string `yaml:"active_days"` // Working days, for part-timers; defaults to the channel's
+	// Tags group the user's standups in reports, e.g. project: payments
+	Tags map[string]string `yaml:"tags"`
 }
 
 type templateSchema struct {
@@ -67,21 +203,123 @@ type templateSchema struct {
 	UserMissing   string `yaml:"user_missing"`
 }
 
+// DefaultWatchInterval is how often Watch polls the config file for changes
+const DefaultWatchInterval = 30 * time.Second
+
 // NewYAMLProvider creates a new YAML configuration provider
 func NewYAMLProvider(path string) Provider {
-	return &yamlProvider{path: path}
+	return &yamlProvider{path: path, interval: DefaultWatchInterval}
 }
 
 type yamlProvider struct {
-	path string
+	path     string
+	interval time.Duration
+
+	mu     sync.Mutex
+	loaded *yamlConfig // Reused while the file is unchanged
+	stamp  fileStamp
 }
 
+// fileStamp identifies a version of a file by its modification time and
+// size.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func statFile(path string) (fileStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+func (s fileStamp) equal(other fileStamp) bool {
+	return s.modTime.Equal(other.modTime) && s.size == other.size
+}
+
+// Load parses the config file, or returns the configuration it last parsed
+// while the file's modification time and size are unchanged, so a warm
+// container reloading its config doesn't parse it again.
 func (p *yamlProvider) Load() (Config, error) {
+	stamp, err := statFile(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.loaded != nil && p.stamp.equal(stamp) {
+		return p.loaded, nil
+	}
+
+	cfg, err := p.parse(stamp)
+	if err != nil {
+		return nil, err
+	}
+	p.loaded, p.stamp = cfg, stamp
+
+	return cfg, nil
+}
+
+// parse reads and parses the config file, whose stamp was just taken.
+func (p *yamlProvider) parse(stamp fileStamp) (*yamlConfig, error) {
 	data, err := os.ReadFile(p.path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	cfg, err := parseYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	cfg.provider = p
+	cfg.stamp = stamp
+
+	return cfg, nil
+}
+
+// Watch polls the config file's modification time and size, calling
+// callback with the reloaded configuration whenever the file changes.
+// Invalid files are skipped until they are fixed.
+func (p *yamlProvider) Watch(callback func(Config)) error {
+	last, err := statFile(p.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat config file: %w", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			stamp, err := statFile(p.path)
+			if err != nil || stamp.equal(last) {
+				continue
+			}
+			last = stamp
+
+			cfg, err := p.Load()
+			if err != nil {
+				continue
+			}
+			callback(cfg)
+		}
+	}()
+
+	return nil
+}
+
+// ParseYAML parses configuration from YAML content, expanding environment
+// variables. It lets providers backed by other sources (e.g. S3) share the
+// YAML format.
+func ParseYAML(data []byte) (Config, error) {
+	return parseYAML(data)
+}
+
+func parseYAML(data []byte) (*yamlConfig, error) {
 	// Expand environment variables
 	content := os.ExpandEnv(string(data))
 
@@ -98,7 +336,7 @@ func (p *yamlProvider) Load() (Config, error) {
 
 	// Parse and validate channels
 	for _, ch := range schema.Channels {
-		channelCfg, err := parseChannelConfig(ch)
+		channelCfg, err := parseChannelConfig(ch, schema.QuestionTemplates)
 		if err != nil {
 			return nil, fmt.Errorf("invalid channel config for %s: %w", ch.ID, err)
 		}
@@ -108,11 +346,6 @@ func (p *yamlProvider) Load() (Config, error) {
 	return cfg, nil
 }
 
-func (p *yamlProvider) Watch(callback func(Config)) error {
-	// TODO: Implement file watching
-	return fmt.Errorf("watch not implemented")
-}
-
 // Config interface implementation
 func (c *yamlConfig) Version() string {
 	c.mu.RLock()
@@ -171,13 +404,91 @@ func (c *yamlConfig) IsFeatureEnabled(feature string) bool {
 	return ok && enabled
 }
 
+func (c *yamlConfig) BlockersChannel() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.raw.Blockers.Channel
+}
+
+func (c *yamlConfig) SummaryGroupBy() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.raw.Summary.GroupBy == "" {
+		return SummaryByUser
+	}
+	return c.raw.Summary.GroupBy
+}
+
+func (c *yamlConfig) Integrations() Integrations {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var integrations Integrations
+	if jira := c.raw.Integrations.Jira; jira != nil {
+		integrations.Jira = &JiraIntegration{
+			BaseURL:  strings.TrimSuffix(jira.BaseURL, "/"),
+			Email:    jira.Email,
+			APIToken: jira.APIToken,
+			Projects: jira.Projects,
+			Comment:  jira.Comment,
+		}
+	}
+	if github := c.raw.Integrations.GitHub; github != nil {
+		integrations.GitHub = &GitHubIntegration{
+			Token: github.Token,
+			Repos: github.Repos,
+			Users: github.Users,
+		}
+	}
+	if calendar := c.raw.Integrations.Calendar; calendar != nil {
+		integrations.Calendar = &CalendarIntegration{
+			Credentials: calendar.Credentials,
+			SyncTime:    calendar.SyncTime,
+		}
+		if integrations.Calendar.SyncTime == "" {
+			integrations.Calendar.SyncTime = DefaultCalendarSyncTime
+		}
+	}
+	return integrations
+}
+
+// Reload parses the source file again if it changed since it was parsed.
 func (c *yamlConfig) Reload() error {
-	// TODO: Implement reload logic
-	return fmt.Errorf("reload not implemented")
+	if c.provider == nil {
+		return fmt.Errorf("reload not supported: configuration has no source file")
+	}
+
+	stamp, err := statFile(c.provider.path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	c.mu.RLock()
+	unchanged := c.stamp.equal(stamp)
+	c.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	fresh, err := c.provider.parse(stamp)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stamp = stamp
+	c.raw = fresh.raw
+	c.channels = fresh.channels
+	c.features = fresh.features
+
+	return nil
 }
 
-// parseChannelConfig creates a ChannelConfig from schema
-func parseChannelConfig(schema channelSchema) (ChannelConfig, error) {
+// parseChannelConfig creates a ChannelConfig from schema, expanding question
+// templates
+func parseChannelConfig(schema channelSchema, questionTemplates map[string][]questionSchema) (ChannelConfig, error) {
 	// Parse timezone
 	tz, err := time.LoadLocation(schema.Schedule.Timezone)
 	if err != nil {
@@ -210,6 +521,12 @@ func parseChannelConfig(schema channelSchema) (ChannelConfig, error) {
 		activeDays[weekday] = true
 	}
 
+	// Parse holidays
+	holidays, err := parseHolidays(schema.Schedule.Holidays)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse users
 	users := make(map[string]UserConfig)
 	for _, u := range schema.Users {
@@ -220,6 +537,56 @@ func parseChannelConfig(schema channelSchema) (ChannelConfig, error) {
 		users[u.ID] = userCfg
 	}
 
+	// Parse questions
+	questions, err := parseQuestions(schema.Questions, questionTemplates)
+	if err != nil {
+		return nil, err
+	}
+
+	var escalation *Escalation
+	if e := schema.Schedule.Escalation; e != nil {
+		escalation = &Escalation{
+			AfterReminders: e.AfterReminders,
+			Action:         EscalationAction(e.Action),
+			ManagerID:      e.ManagerID,
+		}
+	}
+
+	weeklyDigest, err := parseDigest("weekly_digest", schema.Schedule.WeeklyDigest)
+	if err != nil {
+		return nil, err
+	}
+	monthlyDigest, err := parseDigest("monthly_digest", schema.Schedule.MonthlyDigest)
+	if err != nil {
+		return nil, err
+	}
+
+	participants := Participants(schema.Participants)
+	if participants == "" {
+		participants = ParticipantsUsers
+	}
+
+	mentions := MentionStyle(schema.Mentions)
+	if mentions == "" {
+		mentions = MentionsMention
+	}
+
+	var dayQuestions map[time.Weekday][]Question
+	for day, daySchema := range schema.DayQuestions {
+		weekday, err := parseWeekday(day)
+		if err != nil {
+			return nil, fmt.Errorf("invalid day_questions day %s: %w", day, err)
+		}
+		parsed, err := parseQuestions(daySchema, questionTemplates)
+		if err != nil {
+			return nil, fmt.Errorf("invalid questions for %s: %w", day, err)
+		}
+		if dayQuestions == nil {
+			dayQuestions = make(map[time.Weekday][]Question)
+		}
+		dayQuestions[weekday] = parsed
+	}
+
 	return &channelConfig{
 		id:            schema.ID,
 		name:          schema.Name,
@@ -228,12 +595,105 @@ func parseChannelConfig(schema channelSchema) (ChannelConfig, error) {
 		summaryTime:   summaryTime,
 		reminderTimes: reminderTimes,
 		activeDays:    activeDays,
+		holidays:      holidays,
+		escalation:    escalation,
+		weeklyDigest:  weeklyDigest,
+		monthlyDigest: monthlyDigest,
 		users:         users,
+		userGroups:    schema.Groups,
+		participants:  participants,
+		excludedUsers: schema.Exclude,
+		locale:        schema.Locale,
+		reviewAnswers: schema.Review,
+		mentions:      mentions,
+		admins:        schema.Admins,
+		recipients:    schema.SummaryRecipients,
 		templates:     &templateConfig{schema: schema.Templates},
-		questions:     schema.Questions,
+		questions:     questions,
+		dayQuestions:  dayQuestions,
 	}, nil
 }
 
+// parseQuestions converts question schemas, replacing template references
+// with the template's questions. Templates can't include other templates.
+func parseQuestions(schemas []questionSchema, questionTemplates map[string][]questionSchema) ([]Question, error) {
+	questions := make([]Question, 0, len(schemas))
+	for _, q := range schemas {
+		if q.Template == "" {
+			questions = append(questions, parseQuestion(q))
+			continue
+		}
+
+		template, ok := questionTemplates[q.Template]
+		if !ok {
+			return nil, fmt.Errorf("unknown question template: %s", q.Template)
+		}
+		for _, tq := range template {
+			if tq.Template != "" {
+				return nil, fmt.Errorf("question template %s can't include template %s", q.Template, tq.Template)
+			}
+			questions = append(questions, parseQuestion(tq))
+		}
+	}
+	return questions, nil
+}
+
+// parseQuestion converts a single question schema
+func parseQuestion(q questionSchema) Question {
+	qType := QuestionType(strings.ToLower(q.Type))
+	if qType == "" {
+		qType = QuestionText
+	}
+	question := Question{
+		ID:             q.ID,
+		Text:           q.Text,
+		Type:           qType,
+		Options:        q.Options,
+		Optional:       q.Optional,
+		MinLength:      q.MinLength,
+		MaxLength:      q.MaxLength,
+		Pattern:        q.Pattern,
+		PatternMessage: q.PatternMessage,
+		Tags:           q.Tags,
+	}
+	if q.ShowIf != nil {
+		question.ShowIf = &Condition{Question: q.ShowIf.Question, Equals: q.ShowIf.Equals}
+	}
+	return question
+}
+
+// parseHolidays checks holiday dates and the feed URL
+func parseHolidays(schema holidaysSchema) (Holidays, error) {
+	for _, date := range schema.Dates {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return Holidays{}, fmt.Errorf("invalid holiday date %s: %w", date, err)
+		}
+	}
+
+	if schema.ICSURL != "" {
+		u, err := url.Parse(schema.ICSURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return Holidays{}, fmt.Errorf("invalid holiday feed URL %s: must be an https URL", schema.ICSURL)
+		}
+	}
+
+	return Holidays{Dates: schema.Dates, ICSURL: schema.ICSURL}, nil
+}
+
+// parseDigest parses the time of a digest schedule, if there is one
+func parseDigest(name string, schema *digestSchema) (*Digest, error) {
+	if schema == nil {
+		return nil, nil
+	}
+
+	digestTime, err := time.Parse("15:04", schema.Time)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s time %s: %w", name, schema.Time, err)
+	}
+
+	return &Digest{Day: schema.Day, Time: digestTime, TargetChannel: schema.TargetChannel}, nil
+}
+
 // parseUserConfig creates a UserConfig from schema
 func parseUserConfig(schema userSchema) (UserConfig, error) {
 	var tz *time.Location
@@ -245,10 +705,24 @@ func parseUserConfig(schema userSchema) (UserConfig, error) {
 		tz = loc
 	}
 
+	var activeDays map[time.Weekday]bool
+	for _, day := range schema.ActiveDays {
+		weekday, err := parseWeekday(day)
+		if err != nil {
+			return nil, fmt.Errorf("invalid active day %s: %w", day, err)
+		}
+		if activeDays == nil {
+			activeDays = make(map[time.Weekday]bool)
+		}
+		activeDays[weekday] = true
+	}
+
 	return &userConfig{
-		id:       schema.ID,
-		name:     schema.Name,
-		timezone: tz,
+		id:         schema.ID,
+		name:       schema.Name,
+		timezone:   tz,
+		activeDays: activeDays,
+		tags:       schema.Tags,
 	}, nil
 }
 
@@ -283,9 +757,22 @@ type channelConfig struct {
 	summaryTime   time.Time
 	reminderTimes []time.Time
 	activeDays    map[time.Weekday]bool
+	holidays      Holidays
+	escalation    *Escalation
+	weeklyDigest  *Digest
+	monthlyDigest *Digest
 	users         map[string]UserConfig
+	userGroups    []string
+	participants  Participants
+	excludedUsers []string
+	locale        string
+	reviewAnswers bool
+	mentions      MentionStyle
+	admins        []string
+	recipients    []string
 	templates     TemplateConfig
-	questions     []string
+	questions     []Question
+	dayQuestions  map[time.Weekday][]Question
 }
 
 func (c *channelConfig) ID() string                        { return c.id }
@@ -295,8 +782,35 @@ func (c *channelConfig) Timezone() *time.Location          { return c.timezone }
 func (c *channelConfig) SummaryTime() time.Time            { return c.summaryTime }
 func (c *channelConfig) ReminderTimes() []time.Time        { return c.reminderTimes }
 func (c *channelConfig) IsActiveDay(day time.Weekday) bool { return c.activeDays[day] }
+func (c *channelConfig) Holidays() Holidays                { return c.holidays }
+func (c *channelConfig) Escalation() *Escalation           { return c.escalation }
+func (c *channelConfig) WeeklyDigest() *Digest             { return c.weeklyDigest }
+func (c *channelConfig) MonthlyDigest() *Digest            { return c.monthlyDigest }
 func (c *channelConfig) Templates() TemplateConfig         { return c.templates }
-func (c *channelConfig) Questions() []string               { return c.questions }
+func (c *channelConfig) TypedQuestions() []Question        { return c.questions }
+func (c *channelConfig) Admins() []string                  { return c.admins }
+func (c *channelConfig) UserGroups() []string              { return c.userGroups }
+func (c *channelConfig) Participants() Participants        { return c.participants }
+func (c *channelConfig) ExcludedUsers() []string           { return c.excludedUsers }
+func (c *channelConfig) Locale() string                    { return c.locale }
+func (c *channelConfig) ReviewAnswers() bool               { return c.reviewAnswers }
+func (c *channelConfig) Mentions() MentionStyle            { return c.mentions }
+func (c *channelConfig) SummaryRecipients() []string       { return c.recipients }
+
+func (c *channelConfig) QuestionsFor(day time.Weekday) []Question {
+	if questions, ok := c.dayQuestions[day]; ok {
+		return questions
+	}
+	return c.questions
+}
+
+func (c *channelConfig) Questions() []string {
+	questions := make([]string, 0, len(c.questions))
+	for _, q := range c.questions {
+		questions = append(questions, q.Text)
+	}
+	return questions
+}
 
 func (c *channelConfig) Users() []UserConfig {
 	users := make([]UserConfig, 0, len(c.users))
@@ -316,16 +830,32 @@ func (c *channelConfig) IsUserRequired(userID string) bool {
 	return ok
 }
 
+func (c *channelConfig) IsAdmin(userID string) bool {
+	for _, id := range c.admins {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
 // userConfig implements UserConfig
 type userConfig struct {
-	id       string
-	name     string
-	timezone *time.Location
+	id         string
+	name       string
+	timezone   *time.Location
+	activeDays map[time.Weekday]bool // Nil for every day
+	tags       map[string]string
 }
 
 func (u *userConfig) ID() string               { return u.id }
 func (u *userConfig) Name() string             { return u.name }
 func (u *userConfig) Timezone() *time.Location { return u.timezone }
+func (u *userConfig) Tags() map[string]string  { return u.tags }
+
+func (u *userConfig) IsWorkingDay(day time.Weekday) bool {
+	return u.activeDays == nil || u.activeDays[day]
+}
 
 // templateConfig implements TemplateConfig
 type templateConfig struct {
@@ -19,6 +19,9 @@ const (
 
 	// ChannelIDKey is the context key for channel ID
 	ChannelIDKey contextKey = "channel_id"
+
+	// TeamIDKey is the context key for the Slack workspace (team) ID
+	TeamIDKey contextKey = "team_id"
 )
 
 // BotContext provides shared state across the application
@@ -26,6 +29,7 @@ type BotContext interface {
 	// Configuration access
 	Config() config.Config
 	ReloadConfig() error
+	SetConfig(cfg config.Config) error
 
 	// AWS service clients
 	DynamoDB() DynamoDBClient
@@ -49,6 +53,10 @@ type BotContext interface {
 	// Channel-scoped data
 	WithChannelID(ctx context.Context, channelID string) context.Context
 	ChannelID(ctx context.Context) string
+
+	// Workspace-scoped data
+	WithTeamID(ctx context.Context, teamID string) context.Context
+	TeamID(ctx context.Context) string
 }
 
 // DynamoDBClient interface for DynamoDB operations
@@ -132,7 +140,7 @@ func New(opts Options) (BotContext, error) {
 
 	// Use default implementations if not provided
 	if ctx.logger == nil {
-		ctx.logger = &defaultLogger{}
+		ctx.logger = newDefaultLogger()
 	}
 
 	if ctx.tracer == nil {
@@ -167,6 +175,20 @@ func (c *botContext) ReloadConfig() error {
 	return nil
 }
 
+// SetConfig replaces the configuration with cfg, e.g. one already loaded
+// and validated by a watcher
+func (c *botContext) SetConfig(cfg config.Config) error {
+	if cfg == nil {
+		return ErrConfigRequired
+	}
+
+	c.mu.Lock()
+	c.cfg = cfg
+	c.mu.Unlock()
+
+	return nil
+}
+
 // DynamoDB returns the DynamoDB client
 func (c *botContext) DynamoDB() DynamoDBClient {
 	return c.dynamoDB
@@ -207,8 +229,9 @@ func (c *botContext) RequestID(ctx context.Context) string {
 	return ""
 }
 
-// WithUserID adds a user ID to the context
+// WithUserID adds a user ID to the context and annotates the current span
 func (c *botContext) WithUserID(ctx context.Context, userID string) context.Context {
+	c.tracer.AddAnnotation(ctx, string(UserIDKey), userID)
 	return context.WithValue(ctx, UserIDKey, userID)
 }
 
@@ -222,8 +245,9 @@ func (c *botContext) UserID(ctx context.Context) string {
 	return ""
 }
 
-// WithChannelID adds a channel ID to the context
+// WithChannelID adds a channel ID to the context and annotates the current span
 func (c *botContext) WithChannelID(ctx context.Context, channelID string) context.Context {
+	c.tracer.AddAnnotation(ctx, string(ChannelIDKey), channelID)
 	return context.WithValue(ctx, ChannelIDKey, channelID)
 }
 
@@ -236,3 +260,19 @@ func (c *botContext) ChannelID(ctx context.Context) string {
 	}
 	return ""
 }
+
+// WithTeamID adds a workspace ID to the context and annotates the current span
+func (c *botContext) WithTeamID(ctx context.Context, teamID string) context.Context {
+	c.tracer.AddAnnotation(ctx, string(TeamIDKey), teamID)
+	return context.WithValue(ctx, TeamIDKey, teamID)
+}
+
+// TeamID retrieves the workspace ID from the context
+func (c *botContext) TeamID(ctx context.Context) string {
+	if v := ctx.Value(TeamIDKey); v != nil {
+		if id, ok := v.(string); ok {
+			return id
+		}
+	}
+	return ""
+}
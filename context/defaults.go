@@ -2,57 +2,223 @@ package context
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"log"
+	"io"
+	"os"
+	"regexp"
 	"strings"
+	"sync"
+	"time"
 	"unicode"
 )
 
-// defaultLogger is a simple logger implementation
-type defaultLogger struct{}
+// Level is a log severity
+type Level int
+
+// Log levels, in increasing severity
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the level name used in log output
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return "INFO"
+	}
+}
+
+// ParseLevel parses a level name such as "debug" or "WARN", defaulting to info
+func ParseLevel(name string) Level {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return LevelDebug
+	case "warn", "warning":
+		return LevelWarn
+	case "error":
+		return LevelError
+	default:
+		return LevelInfo
+	}
+}
+
+// DefaultMetricsNamespace is the CloudWatch namespace for logged metrics
+const DefaultMetricsNamespace = "StandupBot"
+
+// metricValue is the value of a field created by Metric
+type metricValue float64
+
+// Metric returns a field that also records a CloudWatch metric when logged.
+// Log lines carrying metrics are written in CloudWatch embedded metric format.
+func Metric(name string, value float64) Field {
+	return Field{Key: name, Value: metricValue(value)}
+}
+
+// defaultLogger writes one JSON object per log entry
+type defaultLogger struct {
+	mu        sync.Mutex
+	out       io.Writer
+	level     Level
+	namespace string
+}
+
+// NewLogger creates a JSON logger that writes entries at or above level to out.
+// Metrics are published under namespace.
+func NewLogger(out io.Writer, level Level, namespace string) Logger {
+	if namespace == "" {
+		namespace = DefaultMetricsNamespace
+	}
+	return &defaultLogger{out: out, level: level, namespace: namespace}
+}
+
+// newDefaultLogger creates the logger used when none is provided, configured
+// by the LOG_LEVEL and METRICS_NAMESPACE environment variables
+func newDefaultLogger() Logger {
+	return NewLogger(os.Stdout, ParseLevel(os.Getenv("LOG_LEVEL")), os.Getenv("METRICS_NAMESPACE"))
+}
 
 func (l *defaultLogger) Debug(ctx context.Context, msg string, fields ...Field) {
-	l.log("DEBUG", ctx, msg, fields...)
+	l.log(LevelDebug, ctx, msg, fields...)
 }
 
 func (l *defaultLogger) Info(ctx context.Context, msg string, fields ...Field) {
-	l.log("INFO", ctx, msg, fields...)
+	l.log(LevelInfo, ctx, msg, fields...)
 }
 
 func (l *defaultLogger) Warn(ctx context.Context, msg string, fields ...Field) {
-	l.log("WARN", ctx, msg, fields...)
+	l.log(LevelWarn, ctx, msg, fields...)
 }
 
 func (l *defaultLogger) Error(ctx context.Context, msg string, err error, fields ...Field) {
 	if err != nil {
 		fields = append(fields, Field{Key: "error", Value: err.Error()})
 	}
-	l.log("ERROR", ctx, msg, fields...)
+	l.log(LevelError, ctx, msg, fields...)
 }
 
-func (l *defaultLogger) log(level string, ctx context.Context, msg string, fields ...Field) {
-	// Add request ID if present
-	if requestID := ctx.Value(RequestIDKey); requestID != nil {
-		fields = append([]Field{{Key: "request_id", Value: requestID}}, fields...)
-	}
+func (l *defaultLogger) log(level Level, ctx context.Context, msg string, fields ...Field) {
+	now := time.Now().UTC()
+	entry := make(map[string]interface{}, len(fields)+6)
 
-	// Format fields
-	fieldStr := ""
+	var metrics []map[string]string
 	for _, f := range fields {
-		// Sanitize field values to prevent log injection
-		sanitizedValue := sanitizeForLog(fmt.Sprintf("%v", f.Value))
-		fieldStr += fmt.Sprintf(" %s=%s", f.Key, sanitizedValue)
+		if value, ok := f.Value.(metricValue); ok {
+			metrics = append(metrics, map[string]string{"Name": f.Key, "Unit": "Count"})
+			entry[f.Key] = float64(value)
+			continue
+		}
+		if isSecretKey(f.Key) && f.Value != nil && f.Value != "" {
+			entry[f.Key] = redacted
+			continue
+		}
+		entry[f.Key] = logValue(f.Value)
+	}
+
+	// Entries below the level are dropped unless they carry metrics
+	if level < l.level && len(metrics) == 0 {
+		return
 	}
 
-	log.Printf("[%s] %s%s", level, msg, fieldStr)
+	for key, ctxKey := range map[string]contextKey{
+		"request_id": RequestIDKey,
+		"user_id":    UserIDKey,
+		"channel_id": ChannelIDKey,
+	} {
+		if value, ok := ctx.Value(ctxKey).(string); ok && value != "" {
+			entry[key] = sanitizeForLog(value)
+		}
+	}
+
+	entry["ts"] = now.Format(time.RFC3339Nano)
+	entry["level"] = level.String()
+	entry["msg"] = redactSecrets(msg)
+
+	if len(metrics) > 0 {
+		entry["_aws"] = map[string]interface{}{
+			"Timestamp": now.UnixMilli(),
+			"CloudWatchMetrics": []map[string]interface{}{{
+				"Namespace":  l.namespace,
+				"Dimensions": [][]string{{}},
+				"Metrics":    metrics,
+			}},
+		}
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		line = []byte(fmt.Sprintf(`{"level":%q,"msg":%q}`, level.String(), msg))
+	}
+
+	out := l.out
+	if out == nil {
+		out = os.Stdout
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = out.Write(append(line, '\n')) //nolint:errcheck // nowhere to report logging failures
 }
 
-// sanitizeForLog removes control characters and newlines from log values
+// logValue converts a field value to something safe to encode as JSON
+func logValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+		return v
+	case string:
+		return sanitizeForLog(v)
+	case error:
+		return sanitizeForLog(v.Error())
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return sanitizeForLog(fmt.Sprintf("%v", v))
+	}
+}
+
+// redacted replaces secrets in log output
+const redacted = "[REDACTED]"
+
+// secretPattern matches Slack tokens, such as bot (xoxb-), user (xoxp-) and
+// app-level (xapp-) tokens, keeping the prefix to tell them apart
+var secretPattern = regexp.MustCompile(`\b(xox[a-z]|xapp)-[A-Za-z0-9-]+`)
+
+// redactSecrets replaces the Slack tokens in value
+func redactSecrets(value string) string {
+	return secretPattern.ReplaceAllString(value, "${1}-"+redacted)
+}
+
+// isSecretKey reports whether a field's key names a secret, such as
+// "bot_token" or "client_secret", whose value is never logged
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, word := range []string{"token", "secret", "password", "credential"} {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeForLog removes control characters and newlines from log values,
+// and redacts any Slack tokens in them
 func sanitizeForLog(value string) string {
 	if value == "" {
 		return ""
 	}
 
+	// Redact before truncating, so no part of a token is left behind
+	value = redactSecrets(value)
+
 	// Remove newlines, carriage returns, and other control characters
 	sanitized := strings.Map(func(r rune) rune {
 		if unicode.IsControl(r) || r == '\n' || r == '\r' {
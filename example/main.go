@@ -5,9 +5,14 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
+
+	"github.com/google/uuid"
 
 	botconfig "github.com/synaptiq/standup-bot/config"
 	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/store/memory"
 )
 
 func main() {
@@ -42,12 +47,20 @@ func main() {
 		log.Fatalf("Failed to create bot context: %v", err)
 	}
 
+	// The in-memory store needs no AWS credentials
+	dataStore := memory.NewStore()
+
 	// Example: Using the context in a request handler
 	ctx := context.Background()
-	handleStandupRequest(ctx, botCtx, "U1234567890", "C1234567890") // pragma: allowlist secret
+	handleStandupRequest(ctx, botCtx, dataStore, "U1234567890", "C1234567890") // pragma: allowlist secret
 }
 
-func handleStandupRequest(ctx context.Context, botCtx botcontext.BotContext, userID, channelID string) {
+func handleStandupRequest(
+	ctx context.Context,
+	botCtx botcontext.BotContext,
+	dataStore store.Store,
+	userID, channelID string,
+) {
 	// Add request context
 	ctx = botCtx.WithRequestID(ctx, generateRequestID())
 	ctx = botCtx.WithUserID(ctx, userID)
@@ -101,11 +114,40 @@ func handleStandupRequest(ctx context.Context, botCtx botcontext.BotContext, use
 		fmt.Println("\n(Responses will be posted in a thread)")
 	}
 
-	// In a real application, you would:
-	// 1. Open a Slack modal with the questions
-	// 2. Collect user responses
-	// 3. Store in DynamoDB
-	// 4. Post to channel if needed
+	// In a real application, you would open a Slack modal with the questions
+	// and collect the user's answers before storing them
+	responses := make(map[string]string)
+	for i, question := range channel.Questions() {
+		responses[fmt.Sprintf("q%d", i+1)] = "Example answer to: " + question
+	}
+
+	now := time.Now()
+	session := &store.Session{
+		SessionID: uuid.New().String(),
+		ChannelID: channelID,
+		Date:      now.Format("2006-01-02"),
+		Status:    store.SessionInProgress,
+		CreatedAt: now,
+	}
+	if err := dataStore.SubmitUserResponse(ctx, session, &store.UserResponse{
+		SessionID:   session.SessionID,
+		ChannelID:   channelID,
+		Date:        session.Date,
+		UserID:      userID,
+		UserName:    user.Name(),
+		Responses:   responses,
+		SubmittedAt: now,
+	}); err != nil {
+		logger.Error(ctx, "Failed to store response", err)
+		return
+	}
+
+	stored, err := dataStore.GetSession(ctx, channelID, session.Date)
+	if err != nil {
+		logger.Error(ctx, "Failed to load session", err)
+		return
+	}
+	fmt.Printf("\nStored response; %d response(s) today\n", stored.ResponseCount)
 
 	logger.Info(ctx, "Standup request handled successfully")
 }
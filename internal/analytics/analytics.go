@@ -0,0 +1,388 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// DefaultWindowDays is the rolling window used when none is given.
+const DefaultWindowDays = 30
+
+// MaxWindowDays caps how far back a single stats request may look.
+const MaxWindowDays = 90
+
+// Engine computes participation analytics from stored standup data.
+type Engine struct {
+	store store.Store
+}
+
+// NewEngine creates a new analytics engine.
+func NewEngine(store store.Store) *Engine {
+	return &Engine{store: store}
+}
+
+// DayResponses holds the responses and skips collected for one active standup day.
+type DayResponses struct {
+	Date      string
+	Responses []*store.UserResponse
+	Skips     []*store.SkippedResponse
+}
+
+// BlockerCount is a normalized blocker answer and how often it was reported.
+type BlockerCount struct {
+	Text  string
+	Count int
+}
+
+// UserStats contains participation statistics for a single user.
+type UserStats struct {
+	UserID            string
+	UserName          string
+	ActiveDays        int
+	Submissions       int
+	Skips             int     // Days skipped with /standup skip instead of submitting
+	Late              int     // Submissions made after the day's summary
+	SubmissionRate    float64 // 0..1
+	CurrentStreak     int
+	LongestStreak     int
+	AverageSubmitTime time.Duration // Offset from local midnight
+	TopBlockers       []BlockerCount
+}
+
+// ChannelStats contains participation statistics for a channel.
+type ChannelStats struct {
+	ChannelID         string
+	StartDate         string
+	EndDate           string
+	ActiveDays        int
+	Submissions       int
+	Skips             int
+	Late              int
+	SubmissionRate    float64 // 0..1
+	AverageSubmitTime time.Duration
+	Users             []*UserStats
+	TopBlockers       []BlockerCount
+	Daily             []DayStats // Completion trend, oldest first
+	// Reviews is nil unless leads acknowledge the channel's summaries
+	Reviews *ReviewStats
+}
+
+// ReviewStats counts the daily summaries leads acknowledged.
+type ReviewStats struct {
+	Summaries int            // Summaries posted in the period
+	Reviewed  int            // Summaries at least one lead reviewed
+	Reviewers map[string]int // Summaries each lead reviewed
+}
+
+// DayStats contains the completion for a single standup day.
+type DayStats struct {
+	Date        string
+	Submissions int
+	Skips       int
+	Expected    int
+}
+
+// Rate returns the fraction of expected users who submitted.
+func (d DayStats) Rate() float64 {
+	if d.Expected == 0 {
+		return 0
+	}
+	return float64(d.Submissions) / float64(d.Expected)
+}
+
+// MissedDays returns how many active days the user neither submitted nor skipped.
+func (u *UserStats) MissedDays() int {
+	return u.ActiveDays - u.Submissions - u.Skips
+}
+
+// ChannelStats computes statistics for a channel over the window of days ending at end.
+func (e *Engine) ChannelStats(
+	ctx context.Context,
+	config *store.ChannelConfig,
+	end time.Time,
+	windowDays int,
+) (*ChannelStats, error) {
+	days, err := e.collectDays(ctx, config, end, windowDays)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := channelLocation(config)
+	return Compute(config.ChannelID, config.Users, config.Questions, days, loc), nil
+}
+
+// collectDays loads responses and skips for each active day in the window, oldest first.
+func (e *Engine) collectDays(
+	ctx context.Context,
+	config *store.ChannelConfig,
+	end time.Time,
+	windowDays int,
+) ([]DayResponses, error) {
+	if windowDays <= 0 {
+		windowDays = DefaultWindowDays
+	}
+	if windowDays > MaxWindowDays {
+		windowDays = MaxWindowDays
+	}
+
+	loc := channelLocation(config)
+	end = end.In(loc)
+
+	active := make(map[time.Weekday]bool)
+	for _, day := range config.Schedule.ActiveDays {
+		if weekday, ok := ParseWeekday(day); ok {
+			active[weekday] = true
+		}
+	}
+
+	days := make([]DayResponses, 0, windowDays)
+	for offset := windowDays - 1; offset >= 0; offset-- {
+		day := end.AddDate(0, 0, -offset)
+		if !active[day.Weekday()] {
+			continue
+		}
+
+		date := day.Format("2006-01-02")
+		responses, err := e.store.ListUserResponses(ctx, config.ChannelID, date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list responses for %s: %w", date, err)
+		}
+
+		skips, err := e.store.ListSkippedResponses(ctx, config.ChannelID, date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list skips for %s: %w", date, err)
+		}
+
+		days = append(days, DayResponses{Date: date, Responses: responses, Skips: skips})
+	}
+
+	return days, nil
+}
+
+// Compute derives channel and per-user statistics from daily responses.
+// Days must be ordered oldest first and include only active standup days.
+// A skipped day is counted separately: it is not a submission, but it
+// doesn't break a streak either.
+func Compute(
+	channelID string,
+	userIDs, questions []string,
+	days []DayResponses,
+	loc *time.Location,
+) *ChannelStats {
+	stats := &ChannelStats{
+		ChannelID:  channelID,
+		ActiveDays: len(days),
+	}
+	if len(days) > 0 {
+		stats.StartDate = days[0].Date
+		stats.EndDate = days[len(days)-1].Date
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	required := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		required[userID] = true
+	}
+	for _, day := range days {
+		dayStats := DayStats{Date: day.Date, Expected: len(userIDs)}
+		for _, resp := range day.Responses {
+			if required[resp.UserID] {
+				dayStats.Submissions++
+			}
+		}
+		for _, skip := range day.Skips {
+			if required[skip.UserID] && findResponse(day.Responses, skip.UserID) == nil {
+				dayStats.Skips++
+			}
+		}
+		stats.Daily = append(stats.Daily, dayStats)
+	}
+
+	blockerKey := BlockerQuestionKey(questions)
+	channelBlockers := make(map[string]int)
+	var channelSubmitTotal time.Duration
+
+	for _, userID := range userIDs {
+		user := &UserStats{UserID: userID, ActiveDays: len(days)}
+		userBlockers := make(map[string]int)
+		var submitTotal time.Duration
+		streak := 0
+
+		for _, day := range days {
+			resp := findResponse(day.Responses, userID)
+			if resp == nil {
+				if skipped(day.Skips, userID) {
+					user.Skips++
+				} else {
+					streak = 0
+				}
+				continue
+			}
+
+			if resp.UserName != "" {
+				user.UserName = resp.UserName
+			}
+			user.Submissions++
+			if resp.Late {
+				user.Late++
+			}
+			streak++
+			if streak > user.LongestStreak {
+				user.LongestStreak = streak
+			}
+
+			submitTotal += sinceMidnight(resp.SubmittedAt.In(loc))
+
+			if blocker := NormalizeBlocker(resp.Responses[blockerKey]); blockerKey != "" && blocker != "" {
+				userBlockers[blocker]++
+				channelBlockers[blocker]++
+			}
+		}
+
+		user.CurrentStreak = streak
+		if user.ActiveDays > 0 {
+			user.SubmissionRate = float64(user.Submissions) / float64(user.ActiveDays)
+		}
+		if user.Submissions > 0 {
+			user.AverageSubmitTime = submitTotal / time.Duration(user.Submissions)
+		}
+		user.TopBlockers = topBlockers(userBlockers, 3)
+
+		stats.Submissions += user.Submissions
+		stats.Skips += user.Skips
+		stats.Late += user.Late
+		channelSubmitTotal += submitTotal
+		stats.Users = append(stats.Users, user)
+	}
+
+	if expected := len(days) * len(userIDs); expected > 0 {
+		stats.SubmissionRate = float64(stats.Submissions) / float64(expected)
+	}
+	if stats.Submissions > 0 {
+		stats.AverageSubmitTime = channelSubmitTotal / time.Duration(stats.Submissions)
+	}
+	stats.TopBlockers = topBlockers(channelBlockers, 5)
+
+	// Most engaged users first
+	sort.SliceStable(stats.Users, func(i, j int) bool {
+		if stats.Users[i].Submissions != stats.Users[j].Submissions {
+			return stats.Users[i].Submissions > stats.Users[j].Submissions
+		}
+		return stats.Users[i].CurrentStreak > stats.Users[j].CurrentStreak
+	})
+
+	return stats
+}
+
+// UserByID returns the statistics for a single user, if present.
+func (s *ChannelStats) UserByID(userID string) (*UserStats, bool) {
+	for _, user := range s.Users {
+		if user.UserID == userID {
+			return user, true
+		}
+	}
+	return nil, false
+}
+
+// BlockerQuestionKey returns the response key of the question asking about blockers.
+func BlockerQuestionKey(questions []string) string {
+	for i, question := range questions {
+		if strings.Contains(strings.ToLower(question), "blocker") {
+			return fmt.Sprintf("question_%d", i)
+		}
+	}
+	return ""
+}
+
+// NormalizeBlocker lowercases and trims a blocker answer, returning "" for
+// answers that mean "no blockers".
+func NormalizeBlocker(answer string) string {
+	normalized := strings.ToLower(strings.TrimSpace(answer))
+	normalized = strings.TrimRight(normalized, ".!")
+
+	switch normalized {
+	case "", "none", "no", "nope", "n/a", "na", "-", "nothing", "no blockers", "none so far", "all good":
+		return ""
+	}
+
+	return normalized
+}
+
+// ParseWeekday parses the short weekday names used in schedules.
+func ParseWeekday(day string) (time.Weekday, bool) {
+	switch strings.ToLower(day) {
+	case "sun", "sunday":
+		return time.Sunday, true
+	case "mon", "monday":
+		return time.Monday, true
+	case "tue", "tuesday":
+		return time.Tuesday, true
+	case "wed", "wednesday":
+		return time.Wednesday, true
+	case "thu", "thursday":
+		return time.Thursday, true
+	case "fri", "friday":
+		return time.Friday, true
+	case "sat", "saturday":
+		return time.Saturday, true
+	default:
+		return 0, false
+	}
+}
+
+func findResponse(responses []*store.UserResponse, userID string) *store.UserResponse {
+	for _, resp := range responses {
+		if resp.UserID == userID {
+			return resp
+		}
+	}
+	return nil
+}
+
+func skipped(skips []*store.SkippedResponse, userID string) bool {
+	for _, skip := range skips {
+		if skip.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return t.Sub(midnight)
+}
+
+func topBlockers(counts map[string]int, limit int) []BlockerCount {
+	blockers := make([]BlockerCount, 0, len(counts))
+	for text, count := range counts {
+		blockers = append(blockers, BlockerCount{Text: text, Count: count})
+	}
+
+	sort.Slice(blockers, func(i, j int) bool {
+		if blockers[i].Count != blockers[j].Count {
+			return blockers[i].Count > blockers[j].Count
+		}
+		return blockers[i].Text < blockers[j].Text
+	})
+
+	if len(blockers) > limit {
+		blockers = blockers[:limit]
+	}
+	return blockers
+}
+
+func channelLocation(config *store.ChannelConfig) *time.Location {
+	loc, err := time.LoadLocation(config.Schedule.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,146 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func response(userID, date string, hour, minute int, blocker string) *store.UserResponse {
+	day, _ := time.Parse("2006-01-02", date)
+	return &store.UserResponse{
+		UserID:      userID,
+		Date:        date,
+		SubmittedAt: day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute),
+		Responses: map[string]string{
+			"question_0": "Worked on things",
+			"question_1": blocker,
+		},
+	}
+}
+
+func TestCompute(t *testing.T) {
+	questions := []string{"What did you do?", "Any blockers?"}
+	users := []string{"U1111111111", "U2222222222"}
+
+	days := []DayResponses{
+		{Date: "2024-01-15", Responses: []*store.UserResponse{
+			response("U1111111111", "2024-01-15", 9, 0, "CI is flaky"),
+			response("U2222222222", "2024-01-15", 9, 30, "none"),
+		}},
+		{Date: "2024-01-16", Responses: []*store.UserResponse{
+			response("U1111111111", "2024-01-16", 9, 0, "ci is flaky."),
+		}},
+		{Date: "2024-01-17", Responses: []*store.UserResponse{
+			response("U1111111111", "2024-01-17", 10, 0, ""),
+			response("U2222222222", "2024-01-17", 8, 30, "Waiting on review"),
+		}},
+	}
+
+	days[2].Responses[1].Late = true
+
+	stats := Compute("C1234567890", users, questions, days, time.UTC)
+
+	assert.Equal(t, 3, stats.ActiveDays)
+	assert.Equal(t, "2024-01-15", stats.StartDate)
+	assert.Equal(t, "2024-01-17", stats.EndDate)
+	assert.Equal(t, 5, stats.Submissions)
+	assert.InDelta(t, 5.0/6.0, stats.SubmissionRate, 0.001)
+	assert.Equal(t, 1, stats.Late)
+
+	alice, ok := stats.UserByID("U1111111111")
+	require.True(t, ok)
+	assert.Equal(t, 3, alice.Submissions)
+	assert.Equal(t, 3, alice.CurrentStreak)
+	assert.Equal(t, 3, alice.LongestStreak)
+	assert.Equal(t, 9*time.Hour+20*time.Minute, alice.AverageSubmitTime)
+	assert.Equal(t, []BlockerCount{{Text: "ci is flaky", Count: 2}}, alice.TopBlockers)
+
+	bob, ok := stats.UserByID("U2222222222")
+	require.True(t, ok)
+	assert.Equal(t, 2, bob.Submissions)
+	assert.Equal(t, 1, bob.CurrentStreak)
+	assert.Equal(t, 1, bob.LongestStreak)
+	assert.Equal(t, 1, bob.Late)
+
+	require.Len(t, stats.Daily, 3)
+	assert.Equal(t, DayStats{Date: "2024-01-16", Submissions: 1, Expected: 2}, stats.Daily[1])
+	assert.InDelta(t, 0.5, stats.Daily[1].Rate(), 0.001)
+	assert.Equal(t, 1, bob.MissedDays())
+
+	assert.Equal(t, "ci is flaky", stats.TopBlockers[0].Text)
+	assert.Equal(t, "U1111111111", stats.Users[0].UserID)
+}
+
+func TestComputeSkips(t *testing.T) {
+	users := []string{"U1111111111"}
+	skip := &store.SkippedResponse{UserID: "U1111111111", Date: "2024-01-16", Reason: "Out sick"}
+
+	days := []DayResponses{
+		{Date: "2024-01-15", Responses: []*store.UserResponse{response("U1111111111", "2024-01-15", 9, 0, "")}},
+		{Date: "2024-01-16", Skips: []*store.SkippedResponse{skip}},
+		{Date: "2024-01-17", Responses: []*store.UserResponse{response("U1111111111", "2024-01-17", 9, 0, "")}},
+		{Date: "2024-01-18"},
+	}
+
+	stats := Compute("C1234567890", users, nil, days, time.UTC)
+
+	user, ok := stats.UserByID("U1111111111")
+	require.True(t, ok)
+	assert.Equal(t, 2, user.Submissions)
+	assert.Equal(t, 1, user.Skips)
+	assert.Equal(t, 1, user.MissedDays())
+	assert.Equal(t, 2, user.LongestStreak) // The skip doesn't break the streak
+	assert.Equal(t, 0, user.CurrentStreak)
+	assert.Equal(t, 1, stats.Skips)
+	assert.Equal(t, DayStats{Date: "2024-01-16", Skips: 1, Expected: 1}, stats.Daily[1])
+}
+
+func TestComputeNoDays(t *testing.T) {
+	stats := Compute("C1234567890", []string{"U1111111111"}, nil, nil, nil)
+
+	assert.Equal(t, 0, stats.ActiveDays)
+	assert.Zero(t, stats.SubmissionRate)
+	assert.Empty(t, stats.TopBlockers)
+}
+
+func TestNormalizeBlocker(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   string
+	}{
+		{"", ""},
+		{"None", ""},
+		{"N/A", ""},
+		{"  no blockers.  ", ""},
+		{"Waiting on API keys", "waiting on api keys"},
+		{"Flaky tests!", "flaky tests"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.answer, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeBlocker(tt.answer))
+		})
+	}
+}
+
+func TestBlockerQuestionKey(t *testing.T) {
+	assert.Equal(t, "question_2", BlockerQuestionKey([]string{"Yesterday?", "Today?", "Any blockers?"}))
+	assert.Equal(t, "", BlockerQuestionKey([]string{"Yesterday?", "Today?"}))
+}
+
+func TestFormatReviews(t *testing.T) {
+	reviews := &ReviewStats{
+		Summaries: 5,
+		Reviewed:  4,
+		Reviewers: map[string]int{"U2222222222": 1, "U1111111111": 3, "U3333333333": 3},
+	}
+	assert.Equal(t, "👀 *Reviewed:* 4 of 5 summaries — <@U1111111111> (3), <@U3333333333> (3), <@U2222222222> (1)",
+		formatReviews(reviews))
+
+	assert.Equal(t, "👀 *Reviewed:* 0 of 2 summaries", formatReviews(&ReviewStats{Summaries: 2}))
+}
@@ -0,0 +1,272 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// BuildStatsMessage builds the /standup-stats response for a channel.
+func BuildStatsMessage(stats *ChannelStats) []slack.Block {
+	builder := slack.NewMessageBuilder().
+		AddHeader("📈 Standup Stats").
+		AddSection(fmt.Sprintf("<#%s> from %s to %s (%d active days)",
+			security.SanitizeLogValue(stats.ChannelID), stats.StartDate, stats.EndDate, stats.ActiveDays))
+
+	if stats.ActiveDays == 0 {
+		builder.AddSection("No active standup days in this window.")
+		return builder.Build()
+	}
+
+	builder.AddSection("*Channel*").
+		AddFields(
+			"Submission rate", formatRate(stats.SubmissionRate),
+			"Avg. submission time", formatTimeOfDay(stats.AverageSubmitTime, stats.Submissions),
+			"Skipped", fmt.Sprintf("%d", stats.Skips),
+			"Late", fmt.Sprintf("%d", stats.Late),
+		)
+
+	if len(stats.Users) > 0 {
+		lines := make([]string, 0, len(stats.Users))
+		for _, user := range stats.Users {
+			lines = append(lines, formatUserLine(user))
+		}
+		builder.AddDivider().
+			AddSection("*Participation*\n" + strings.Join(lines, "\n"))
+	}
+
+	if len(stats.TopBlockers) > 0 {
+		builder.AddDivider().
+			AddSection("*Most common blockers*\n" + formatBlockers(stats.TopBlockers))
+	}
+
+	return builder.Build()
+}
+
+// BuildUserStatsMessage builds the /standup-stats response for a single user.
+func BuildUserStatsMessage(stats *ChannelStats, user *UserStats) []slack.Block {
+	builder := slack.NewMessageBuilder().
+		AddHeader("📈 Your Standup Stats").
+		AddSection(fmt.Sprintf("<#%s> from %s to %s",
+			security.SanitizeLogValue(stats.ChannelID), stats.StartDate, stats.EndDate)).
+		AddFields(
+			"Submitted", fmt.Sprintf("%d of %d days", user.Submissions, user.ActiveDays),
+			"Skipped", fmt.Sprintf("%d days", user.Skips),
+			"Late", fmt.Sprintf("%d days", user.Late),
+			"Submission rate", formatRate(user.SubmissionRate),
+			"Current streak", fmt.Sprintf("%d days", user.CurrentStreak),
+			"Longest streak", fmt.Sprintf("%d days", user.LongestStreak),
+			"Avg. submission time", formatTimeOfDay(user.AverageSubmitTime, user.Submissions),
+		)
+
+	if len(user.TopBlockers) > 0 {
+		builder.AddSection("*Your most common blockers*\n" + formatBlockers(user.TopBlockers))
+	}
+
+	return builder.Build()
+}
+
+// BuildDigestMessage builds a periodic digest covering who missed standups,
+// the most common blockers, and the daily completion trend.
+func BuildDigestMessage(title string, stats *ChannelStats) []slack.Block {
+	builder := slack.NewMessageBuilder().
+		AddHeader(title).
+		AddSection(fmt.Sprintf("<#%s> from %s to %s (%d active days)",
+			security.SanitizeLogValue(stats.ChannelID), stats.StartDate, stats.EndDate, stats.ActiveDays))
+
+	if stats.ActiveDays == 0 {
+		builder.AddSection("No active standup days in this period.")
+		return builder.Build()
+	}
+
+	builder.AddFields(
+		"Submission rate", formatRate(stats.SubmissionRate),
+		"Avg. submission time", formatTimeOfDay(stats.AverageSubmitTime, stats.Submissions),
+	)
+
+	var perfect, missed, skipped []string
+	for _, user := range stats.Users {
+		mention := fmt.Sprintf("<@%s>", security.SanitizeLogValue(user.UserID))
+		switch {
+		case user.MissedDays() == 0 && user.Skips == 0:
+			perfect = append(perfect, mention)
+		case user.MissedDays() > 0:
+			missed = append(missed, fmt.Sprintf("• %s — missed %d of %d", mention, user.MissedDays(), user.ActiveDays))
+		}
+		if user.Skips > 0 {
+			skipped = append(skipped, fmt.Sprintf("• %s — skipped %d", mention, user.Skips))
+		}
+	}
+	if len(perfect) > 0 {
+		builder.AddSection("🏆 *Perfect attendance:* " + strings.Join(perfect, ", "))
+	}
+	if len(missed) > 0 {
+		builder.AddSection("⏳ *Missed standups*\n" + strings.Join(missed, "\n"))
+	}
+	if len(skipped) > 0 {
+		builder.AddSection("⏭️ *Skipped standups*\n" + strings.Join(skipped, "\n"))
+	}
+
+	if len(stats.Daily) > 0 {
+		builder.AddDivider().
+			AddSection("*Completion trend*\n" + formatTrend(stats.Daily))
+	}
+
+	if len(stats.TopBlockers) > 0 {
+		builder.AddSection("*Top blockers*\n" + formatBlockers(stats.TopBlockers))
+	}
+
+	if stats.Reviews != nil && stats.Reviews.Summaries > 0 {
+		builder.AddSection(formatReviews(stats.Reviews))
+	}
+
+	return builder.Build()
+}
+
+// formatReviews shows how many summaries were reviewed and by whom, the most
+// active reviewers first.
+func formatReviews(reviews *ReviewStats) string {
+	line := fmt.Sprintf("👀 *Reviewed:* %d of %d summaries", reviews.Reviewed, reviews.Summaries)
+
+	reviewers := make([]string, 0, len(reviews.Reviewers))
+	for userID := range reviews.Reviewers {
+		reviewers = append(reviewers, userID)
+	}
+	sort.Slice(reviewers, func(i, j int) bool {
+		if reviews.Reviewers[reviewers[i]] != reviews.Reviewers[reviewers[j]] {
+			return reviews.Reviewers[reviewers[i]] > reviews.Reviewers[reviewers[j]]
+		}
+		return reviewers[i] < reviewers[j]
+	})
+
+	mentions := make([]string, 0, len(reviewers))
+	for _, userID := range reviewers {
+		mentions = append(mentions, fmt.Sprintf("<@%s> (%d)", security.SanitizeLogValue(userID), reviews.Reviewers[userID]))
+	}
+	if len(mentions) > 0 {
+		line += " — " + strings.Join(mentions, ", ")
+	}
+	return line
+}
+
+func formatUserLine(user *UserStats) string {
+	line := fmt.Sprintf("• <@%s> — %d/%d (%s)",
+		security.SanitizeLogValue(user.UserID), user.Submissions, user.ActiveDays, formatRate(user.SubmissionRate))
+	if user.Skips > 0 {
+		line += fmt.Sprintf(", %d skipped", user.Skips)
+	}
+	if user.Late > 0 {
+		line += fmt.Sprintf(", %d late", user.Late)
+	}
+	if user.CurrentStreak > 1 {
+		line += fmt.Sprintf(" 🔥 %d", user.CurrentStreak)
+	}
+	return line
+}
+
+func formatBlockers(blockers []BlockerCount) string {
+	lines := make([]string, 0, len(blockers))
+	for _, blocker := range blockers {
+		lines = append(lines, fmt.Sprintf("• %s (%d)", security.SanitizeLogValue(blocker.Text), blocker.Count))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatTrend renders one bar per day, e.g. "Mon 01/15 ▇▇▇▇▁ 80%".
+func formatTrend(daily []DayStats) string {
+	const width = 5
+
+	lines := make([]string, 0, len(daily))
+	for _, day := range daily {
+		label := day.Date
+		if t, err := time.Parse("2006-01-02", day.Date); err == nil {
+			label = t.Format("Mon 01/02")
+		}
+
+		filled := int(day.Rate()*width + 0.5)
+		bar := strings.Repeat("▇", filled) + strings.Repeat("▁", width-filled)
+		lines = append(lines, fmt.Sprintf("`%s` %s %s", label, bar, formatRate(day.Rate())))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatRate(rate float64) string {
+	return fmt.Sprintf("%.0f%%", rate*100)
+}
+
+func formatTimeOfDay(offset time.Duration, samples int) string {
+	if samples == 0 {
+		return "—"
+	}
+	return time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Add(offset).Format("3:04 PM")
+}
+
+// maxLeaderboardUsers caps how many users a leaderboard ranks.
+const maxLeaderboardUsers = 10
+
+// BuildLeaderboardMessage builds the monthly leaderboard: the users who
+// submitted the most standups in the period, ties going to the longer current
+// streak, and the longest streak anyone has kept.
+func BuildLeaderboardMessage(title string, stats *ChannelStats, streaks []*store.Streak) []slack.Block {
+	builder := slack.NewMessageBuilder().
+		AddHeader(title).
+		AddSection(fmt.Sprintf("<#%s> from %s to %s (%d active days)",
+			security.SanitizeLogValue(stats.ChannelID), stats.StartDate, stats.EndDate, stats.ActiveDays))
+
+	current := make(map[string]int, len(streaks))
+	var longest *store.Streak
+	for _, streak := range streaks {
+		current[streak.UserID] = streak.Current
+		if longest == nil || streak.Longest > longest.Longest {
+			longest = streak
+		}
+	}
+
+	ranked := make([]*UserStats, 0, len(stats.Users))
+	for _, user := range stats.Users {
+		if user.Submissions > 0 {
+			ranked = append(ranked, user)
+		}
+	}
+	if len(ranked) == 0 {
+		builder.AddSection("No standups were submitted in this period.")
+		return builder.Build()
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Submissions != ranked[j].Submissions {
+			return ranked[i].Submissions > ranked[j].Submissions
+		}
+		return current[ranked[i].UserID] > current[ranked[j].UserID]
+	})
+	if len(ranked) > maxLeaderboardUsers {
+		ranked = ranked[:maxLeaderboardUsers]
+	}
+
+	medals := []string{"🥇", "🥈", "🥉"}
+	lines := make([]string, 0, len(ranked))
+	for i, user := range ranked {
+		rank := fmt.Sprintf("%d.", i+1)
+		if i < len(medals) {
+			rank = medals[i]
+		}
+		line := fmt.Sprintf("%s <@%s> — %d of %d standups",
+			rank, security.SanitizeLogValue(user.UserID), user.Submissions, user.ActiveDays)
+		if streak := current[user.UserID]; streak > 1 {
+			line += fmt.Sprintf(" 🔥 %d in a row", streak)
+		}
+		lines = append(lines, line)
+	}
+	builder.AddSection("*Most consistent*\n" + strings.Join(lines, "\n"))
+
+	if longest != nil && longest.Longest > 1 {
+		builder.AddSection(fmt.Sprintf("🏅 *Longest streak:* <@%s> — %d standups in a row",
+			security.SanitizeLogValue(longest.UserID), longest.Longest))
+	}
+
+	return builder.Build()
+}
@@ -0,0 +1,49 @@
+package analytics
+
+import (
+	"slices"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// Outcome is how a user took part in one standup day.
+type Outcome int
+
+// Outcomes of a standup day.
+const (
+	Missed Outcome = iota
+	Submitted
+	Skipped
+)
+
+// CountStreak counts a user's outcome of the standup on date in their
+// streak, and reports whether the streak changed. Each day is counted once,
+// except that submitting late on a day counted as missed restores the run
+// the miss ended. Skipped days leave the run as it is.
+func CountStreak(streak *store.Streak, date string, outcome Outcome) bool {
+	switch {
+	case date > streak.LastDate:
+		streak.LastDate, streak.Missed, streak.Broken = date, false, 0
+		switch outcome {
+		case Submitted:
+			streak.Current++
+		case Missed:
+			streak.Missed, streak.Broken, streak.Current = true, streak.Current, 0
+		}
+	case date == streak.LastDate && streak.Missed && outcome == Submitted:
+		streak.Current = streak.Broken + 1
+		streak.Missed, streak.Broken = false, 0
+	default:
+		return false
+	}
+
+	streak.Longest = max(streak.Longest, streak.Current)
+	return true
+}
+
+// Milestone reports whether submitting on date brought the streak to one of
+// milestones.
+func Milestone(streak *store.Streak, date string, outcome Outcome, milestones []int) bool {
+	return outcome == Submitted && streak.LastDate == date && !streak.Missed &&
+		slices.Contains(milestones, streak.Current)
+}
@@ -0,0 +1,86 @@
+package analytics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestCountStreak(t *testing.T) {
+	streak := &store.Streak{}
+	milestones := []int{2, 4}
+
+	for _, day := range []struct {
+		date      string
+		outcome   Outcome
+		current   int
+		milestone bool
+	}{
+		{"2024-01-15", Submitted, 1, false},
+		{"2024-01-16", Submitted, 2, true},
+		{"2024-01-17", Skipped, 2, false},
+		{"2024-01-18", Submitted, 3, false},
+		{"2024-01-19", Missed, 0, false},
+		{"2024-01-22", Submitted, 1, false},
+	} {
+		assert.True(t, CountStreak(streak, day.date, day.outcome), day.date)
+		assert.Equal(t, day.current, streak.Current, day.date)
+		assert.Equal(t, day.milestone, Milestone(streak, day.date, day.outcome, milestones), day.date)
+	}
+	assert.Equal(t, 3, streak.Longest)
+
+	// Days are counted once
+	assert.False(t, CountStreak(streak, "2024-01-22", Submitted))
+	assert.False(t, CountStreak(streak, "2024-01-19", Submitted))
+	assert.Equal(t, 1, streak.Current)
+
+	// Submitting late on a missed day picks the run up where it left off
+	assert.True(t, CountStreak(streak, "2024-01-23", Missed))
+	assert.True(t, CountStreak(streak, "2024-01-23", Submitted))
+	assert.Equal(t, 2, streak.Current)
+	assert.True(t, Milestone(streak, "2024-01-23", Submitted, milestones))
+	assert.False(t, CountStreak(streak, "2024-01-23", Submitted))
+}
+
+func TestBuildLeaderboardMessage(t *testing.T) {
+	stats := &ChannelStats{
+		ChannelID:  "C1234567890",
+		ActiveDays: 20,
+		Users: []*UserStats{
+			{UserID: "U1111111111", Submissions: 18, ActiveDays: 20},
+			{UserID: "U2222222222", Submissions: 20, ActiveDays: 20},
+			{UserID: "U3333333333", Submissions: 18, ActiveDays: 20},
+			{UserID: "U4444444444", ActiveDays: 20},
+		},
+	}
+	streaks := []*store.Streak{
+		{UserID: "U1111111111", Current: 2, Longest: 30},
+		{UserID: "U3333333333", Current: 9, Longest: 9},
+	}
+
+	text := blockText(BuildLeaderboardMessage("Leaderboard", stats, streaks))
+	assert.Contains(t, text, "🥇 <@U2222222222> — 20 of 20 standups\n"+
+		"🥈 <@U3333333333> — 18 of 20 standups 🔥 9 in a row\n"+
+		"🥉 <@U1111111111> — 18 of 20 standups 🔥 2 in a row")
+	assert.NotContains(t, text, "U4444444444")
+	assert.Contains(t, text, "*Longest streak:* <@U1111111111> — 30 standups in a row")
+
+	// Without submissions there's no one to rank
+	stats.Users = stats.Users[3:]
+	assert.Contains(t, blockText(BuildLeaderboardMessage("Leaderboard", stats, nil)), "No standups were submitted")
+}
+
+// blockText joins the text of a message's sections.
+func blockText(blocks []slack.Block) string {
+	var texts []string
+	for _, block := range blocks {
+		if section, ok := block.(*slack.SectionBlock); ok && section.Text != nil {
+			texts = append(texts, section.Text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
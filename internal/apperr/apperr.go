@@ -0,0 +1,96 @@
+// Package apperr classifies errors by what the user who ran into them can do
+// about it, so handlers can tell Slack users more than "Please try again."
+package apperr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Kind is a class of error, as far as the user is concerned.
+type Kind int
+
+// Error kinds. Only KindInternal errors are the bot's fault; the others are
+// explained to the user.
+const (
+	KindInternal      Kind = iota // A bug or an outage; only the logs can tell
+	KindNotConfigured             // The channel has no standup set up
+	KindNotFound                  // What was asked for doesn't exist
+	KindInvalid                   // The request can't be carried out as given
+	KindForbidden                 // The user lacks the role it takes
+	KindConflict                  // Something else changed it at the same time
+	KindNoAccess                  // The bot isn't in the channel it needs
+	KindUnavailable               // Slack or AWS is throttling or timed out
+)
+
+// String returns the kind's name as logged.
+func (k Kind) String() string {
+	switch k {
+	case KindInternal:
+		return "internal"
+	case KindNotConfigured:
+		return "not_configured"
+	case KindNotFound:
+		return "not_found"
+	case KindInvalid:
+		return "invalid"
+	case KindForbidden:
+		return "forbidden"
+	case KindConflict:
+		return "conflict"
+	case KindNoAccess:
+		return "no_access"
+	case KindUnavailable:
+		return "unavailable"
+	default:
+		return fmt.Sprintf("kind %d", int(k))
+	}
+}
+
+// Error is an error of a known kind.
+type Error struct {
+	Kind Kind
+	Err  error
+}
+
+// New returns an error of kind with text as its message. Packages declare
+// their sentinel errors with it to have them classified.
+func New(kind Kind, text string) error {
+	return &Error{Kind: kind, Err: errors.New(text)}
+}
+
+// Wrap marks err as being of kind. A nil err stays nil.
+func Wrap(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrorKind returns e.Kind.
+func (e *Error) ErrorKind() Kind {
+	return e.Kind
+}
+
+// KindOf returns the kind of the first error in err's chain that has one:
+// an *Error, or any error with an ErrorKind method, like store and Slack API
+// errors. Timeouts are KindUnavailable; anything else is KindInternal.
+func KindOf(err error) Kind {
+	var classified interface{ ErrorKind() Kind }
+	if errors.As(err, &classified) {
+		return classified.ErrorKind()
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return KindUnavailable
+	}
+	return KindInternal
+}
@@ -0,0 +1,39 @@
+package apperr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type codedError struct{ code string }
+
+func (e *codedError) Error() string { return e.code }
+
+func (e *codedError) ErrorKind() Kind {
+	if e.code == "missing" {
+		return KindNotFound
+	}
+	return KindInternal
+}
+
+func TestKindOf(t *testing.T) {
+	errLocked := New(KindConflict, "locked")
+	wrapped := fmt.Errorf("failed to save: %w", errLocked)
+	assert.Equal(t, KindConflict, KindOf(wrapped))
+	assert.ErrorIs(t, wrapped, errLocked)
+	assert.Equal(t, "failed to save: locked", wrapped.Error())
+
+	// The outermost kind wins
+	assert.Equal(t, KindNotConfigured, KindOf(Wrap(KindNotConfigured, wrapped)))
+
+	assert.Equal(t, KindNotFound, KindOf(fmt.Errorf("lookup: %w", &codedError{code: "missing"})))
+	assert.Equal(t, KindUnavailable, KindOf(fmt.Errorf("slack: %w", context.DeadlineExceeded)))
+	assert.Equal(t, KindInternal, KindOf(errors.New("boom")))
+
+	assert.NoError(t, Wrap(KindInvalid, nil))
+	assert.Equal(t, "not_configured", KindNotConfigured.String())
+}
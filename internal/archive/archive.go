@@ -0,0 +1,121 @@
+// Package archive keeps machine-readable snapshots of daily standups in S3,
+// partitioned by date and channel, so they can be queried with Athena long
+// after the store's TTL has expired them.
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// DefaultPrefix is the key prefix snapshots are written under unless
+// another is configured.
+const DefaultPrefix = "standups"
+
+// Snapshot is a day's standup in a channel as the summary was posted.
+type Snapshot struct {
+	TeamID       string        `json:"team_id,omitempty"`
+	ChannelID    string        `json:"channel_id"`
+	ChannelName  string        `json:"channel_name"`
+	Date         string        `json:"date"` // YYYY-MM-DD
+	SessionID    string        `json:"session_id"`
+	SummaryTS    string        `json:"summary_ts"`
+	ArchivedAt   time.Time     `json:"archived_at"`
+	Questions    []string      `json:"questions"`
+	Participants []Participant `json:"participants"`
+}
+
+// Participant is a user's part in a standup: their answers if they
+// submitted, otherwise whether they skipped.
+type Participant struct {
+	UserID      string     `json:"user_id"`
+	UserName    string     `json:"user_name"`
+	Submitted   bool       `json:"submitted"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+	Late        bool       `json:"late,omitempty"`
+	Skipped     bool       `json:"skipped,omitempty"`
+	SkipReason  string     `json:"skip_reason,omitempty"`
+	Answers     []Answer   `json:"answers,omitempty"`
+}
+
+// Answer pairs a question with a user's answer.
+type Answer struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// S3Client defines the S3 operations used by Archiver.
+type S3Client interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput,
+		optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// Archiver writes snapshots to an S3 bucket.
+type Archiver struct {
+	client S3Client
+	bucket string
+	prefix string
+}
+
+// NewArchiver creates an archiver writing under prefix in bucket, or under
+// DefaultPrefix if prefix is empty.
+func NewArchiver(client S3Client, bucket, prefix string) *Archiver {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	return &Archiver{client: client, bucket: bucket, prefix: prefix}
+}
+
+// FromEnv creates an archiver when ARCHIVE_BUCKET names the bucket to write
+// to, under ARCHIVE_PREFIX if set. It returns nil when archiving isn't
+// configured.
+func FromEnv(ctx context.Context) (*Archiver, error) {
+	bucket := os.Getenv("ARCHIVE_BUCKET")
+	if bucket == "" {
+		return nil, nil
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	return NewArchiver(s3.NewFromConfig(awsCfg), bucket, os.Getenv("ARCHIVE_PREFIX")), nil
+}
+
+// Key returns where a snapshot is written, as Hive-style partitions:
+// <prefix>/date=YYYY-MM-DD/channel=C.../standup.json. Archiving a day again,
+// e.g. when its summary is posted again, replaces the snapshot.
+func (a *Archiver) Key(snapshot *Snapshot) string {
+	return fmt.Sprintf("%s/date=%s/channel=%s/standup.json", a.prefix, snapshot.Date, snapshot.ChannelID)
+}
+
+// Archive writes a snapshot as a single line of JSON, as Athena reads it.
+func (a *Archiver) Archive(ctx context.Context, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	data = append(data, '\n')
+
+	_, err = a.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(a.bucket),
+		Key:         aws.String(a.Key(snapshot)),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 keeps the objects put to it by key.
+type fakeS3 struct {
+	objects map[string][]byte
+}
+
+func (f *fakeS3) PutObject(ctx context.Context, params *s3.PutObjectInput,
+	optFns ...func(*s3.Options),
+) (*s3.PutObjectOutput, error) {
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.objects[*params.Bucket+"/"+*params.Key] = data
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestArchive(t *testing.T) {
+	client := &fakeS3{objects: make(map[string][]byte)}
+	submittedAt := time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+	snapshot := &Snapshot{
+		ChannelID: "C1234567890",
+		Date:      "2024-01-15",
+		Questions: []string{"What did you do?"},
+		Participants: []Participant{
+			{UserID: "U1111111111", UserName: "alice", Submitted: true, SubmittedAt: &submittedAt,
+				Answers: []Answer{{Question: "What did you do?", Answer: "Shipped\nthe export"}}},
+			{UserID: "U2222222222", UserName: "bob", Skipped: true, SkipReason: "out sick"},
+		},
+	}
+
+	archiver := NewArchiver(client, "lake", "/standup-bot/")
+	require.NoError(t, archiver.Archive(context.Background(), snapshot))
+
+	data, ok := client.objects["lake/standup-bot/date=2024-01-15/channel=C1234567890/standup.json"]
+	require.True(t, ok, client.objects)
+	// One line per snapshot, as Athena reads JSON
+	assert.Equal(t, 1, bytes.Count(data, []byte("\n")))
+
+	var decoded Snapshot
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, *snapshot, decoded)
+
+	assert.Equal(t, "standups/date=2024-01-15/channel=C1234567890/standup.json",
+		NewArchiver(client, "lake", "").Key(snapshot))
+}
@@ -0,0 +1,83 @@
+// Package authz decides who may run admin commands: changing a channel's
+// settings, exporting reports and forcing summaries.
+package authz
+
+import (
+	"context"
+	"fmt"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/apperr"
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+// ErrForbidden is returned when a user lacks the role an action requires.
+var ErrForbidden = apperr.New(apperr.KindForbidden, "forbidden")
+
+// Role is what a user may do in a channel. Each role includes the ones
+// below it.
+type Role int
+
+// Roles, from least to most privileged.
+const (
+	RoleMember         Role = iota // Anyone in the workspace
+	RoleChannelAdmin               // Listed in the channel's admins
+	RoleWorkspaceAdmin             // A Slack workspace admin or owner
+)
+
+// String returns the role's name as shown to users.
+func (r Role) String() string {
+	switch r {
+	case RoleMember:
+		return "member"
+	case RoleChannelAdmin:
+		return "channel admin"
+	case RoleWorkspaceAdmin:
+		return "workspace admin"
+	default:
+		return fmt.Sprintf("role %d", int(r))
+	}
+}
+
+// ConfigSource returns the configuration of the workspace ctx is scoped to.
+// *standup.Service implements it.
+type ConfigSource interface {
+	Config(ctx context.Context) botconfig.Config
+}
+
+// Authorizer resolves users' roles from the channel config's admins list
+// and Slack's workspace admin flags.
+type Authorizer struct {
+	config ConfigSource
+	slack  slack.Client
+}
+
+// NewAuthorizer creates an authorizer.
+func NewAuthorizer(config ConfigSource, slackClient slack.Client) *Authorizer {
+	return &Authorizer{config: config, slack: slackClient}
+}
+
+// Require returns ErrForbidden unless userID has at least role in
+// channelID. Slack is only asked about workspace admins when the channel's
+// admins list doesn't settle it.
+func (a *Authorizer) Require(ctx context.Context, channelID, userID string, role Role) error {
+	if role <= RoleMember {
+		return nil
+	}
+
+	if role <= RoleChannelAdmin {
+		if ch, ok := a.config.Config(ctx).ChannelByID(channelID); ok && ch.IsAdmin(userID) {
+			return nil
+		}
+	}
+
+	user, err := a.slack.GetUserInfo(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user info: %w", err)
+	}
+	if user.IsAdmin || user.IsOwner {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s required", ErrForbidden, role)
+}
@@ -0,0 +1,68 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+type staticConfig struct {
+	cfg botconfig.Config
+}
+
+func (s staticConfig) Config(context.Context) botconfig.Config { return s.cfg }
+
+// fakeSlack answers user lookups from a fixed set of users.
+type fakeSlack struct {
+	slack.Client
+	users   map[string]*slack.UserInfo
+	lookups int
+}
+
+func (f *fakeSlack) GetUserInfo(_ context.Context, userID string) (*slack.UserInfo, error) {
+	f.lookups++
+	if user, ok := f.users[userID]; ok {
+		return user, nil
+	}
+	return &slack.UserInfo{ID: userID}, nil
+}
+
+func TestRequire(t *testing.T) {
+	cfg, err := botconfig.ParseYAML([]byte(`
+channels:
+  - id: "C1234567890"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+    admins: ["U0000000001"]
+`))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	fake := &fakeSlack{users: map[string]*slack.UserInfo{
+		"U0000000002": {ID: "U0000000002", IsAdmin: true},
+		"U0000000003": {ID: "U0000000003", IsOwner: true},
+	}}
+	a := NewAuthorizer(staticConfig{cfg}, fake)
+
+	// Anyone is a member
+	assert.NoError(t, a.Require(ctx, "C1234567890", "U0000000004", RoleMember))
+	assert.Zero(t, fake.lookups)
+
+	// Channel admins don't need a Slack lookup
+	assert.NoError(t, a.Require(ctx, "C1234567890", "U0000000001", RoleChannelAdmin))
+	assert.Zero(t, fake.lookups)
+	assert.ErrorIs(t, a.Require(ctx, "C1234567890", "U0000000001", RoleWorkspaceAdmin), ErrForbidden)
+
+	// Workspace admins and owners are admins of every channel
+	assert.NoError(t, a.Require(ctx, "C1234567890", "U0000000002", RoleChannelAdmin))
+	assert.NoError(t, a.Require(ctx, "C0987654321", "U0000000003", RoleWorkspaceAdmin))
+
+	assert.ErrorIs(t, a.Require(ctx, "C1234567890", "U0000000004", RoleChannelAdmin), ErrForbidden)
+	assert.ErrorIs(t, a.Require(ctx, "C0987654321", "U0000000001", RoleChannelAdmin), ErrForbidden)
+}
@@ -0,0 +1,302 @@
+// Package blockkit models Slack's Block Kit: the blocks and interactive
+// elements messages and modals are made of, and builders that assemble them.
+// Types marshal to the JSON Slack's API expects.
+package blockkit
+
+// Modal represents a Slack modal view.
+type Modal struct {
+	Type            string     `json:"type"`
+	Title           *TextBlock `json:"title"`
+	Submit          *TextBlock `json:"submit,omitempty"`
+	Close           *TextBlock `json:"close,omitempty"`
+	Blocks          []Block    `json:"blocks"`
+	PrivateMetadata string     `json:"private_metadata,omitempty"`
+	CallbackID      string     `json:"callback_id,omitempty"`
+	ExternalID      string     `json:"external_id,omitempty"` // Unique in the workspace; lets the modal be updated without its view ID
+	ClearOnClose    bool       `json:"clear_on_close,omitempty"`
+	NotifyOnClose   bool       `json:"notify_on_close,omitempty"`
+}
+
+// Block is an interface for Slack blocks.
+type Block interface {
+	BlockType() string
+}
+
+// TextBlock represents a text object.
+type TextBlock struct {
+	Type  string `json:"type"`
+	Text  string `json:"text"`
+	Emoji bool   `json:"emoji,omitempty"`
+}
+
+// SectionBlock represents a section block.
+type SectionBlock struct {
+	Type      string      `json:"type"`
+	Text      *TextBlock  `json:"text,omitempty"`
+	BlockID   string      `json:"block_id,omitempty"`
+	Fields    []TextBlock `json:"fields,omitempty"`
+	Accessory interface{} `json:"accessory,omitempty"`
+}
+
+func (s *SectionBlock) BlockType() string { return "section" }
+
+// HeaderBlock represents a header block.
+type HeaderBlock struct {
+	Type    string     `json:"type"`
+	Text    *TextBlock `json:"text"`
+	BlockID string     `json:"block_id,omitempty"`
+}
+
+func (h HeaderBlock) BlockType() string { return "header" }
+
+// InputBlock represents an input block.
+type InputBlock struct {
+	Type     string      `json:"type"`
+	BlockID  string      `json:"block_id"`
+	Label    *TextBlock  `json:"label"`
+	Element  interface{} `json:"element"`
+	Optional bool        `json:"optional,omitempty"`
+	Hint     *TextBlock  `json:"hint,omitempty"`
+	// DispatchAction sends block_actions as soon as the value changes
+	DispatchAction bool `json:"dispatch_action,omitempty"`
+}
+
+func (i InputBlock) BlockType() string { return "input" }
+
+// DividerBlock represents a divider block.
+type DividerBlock struct {
+	Type string `json:"type"`
+}
+
+func (d DividerBlock) BlockType() string { return "divider" }
+
+// ActionsBlock represents an actions block holding interactive elements.
+type ActionsBlock struct {
+	Type     string        `json:"type"`
+	BlockID  string        `json:"block_id,omitempty"`
+	Elements []interface{} `json:"elements"`
+}
+
+func (a ActionsBlock) BlockType() string { return "actions" }
+
+// ContextBlock represents a context block: small text, or images, shown
+// under a message's main content.
+type ContextBlock struct {
+	Type     string        `json:"type"`
+	BlockID  string        `json:"block_id,omitempty"`
+	Elements []interface{} `json:"elements"` // TextBlocks and ImageElements, at most 10
+}
+
+func (c ContextBlock) BlockType() string { return "context" }
+
+// ImageBlock represents an image block.
+type ImageBlock struct {
+	Type     string     `json:"type"`
+	ImageURL string     `json:"image_url"`
+	AltText  string     `json:"alt_text"`
+	Title    *TextBlock `json:"title,omitempty"`
+	BlockID  string     `json:"block_id,omitempty"`
+}
+
+func (i ImageBlock) BlockType() string { return "image" }
+
+// ImageElement represents an image shown inline in a context block, e.g. a
+// user's avatar.
+type ImageElement struct {
+	Type     string `json:"type"`
+	ImageURL string `json:"image_url"`
+	AltText  string `json:"alt_text"`
+}
+
+// ButtonElement represents a button element.
+type ButtonElement struct {
+	Type     string     `json:"type"`
+	ActionID string     `json:"action_id"`
+	Text     *TextBlock `json:"text"`
+	Value    string     `json:"value,omitempty"`
+	Style    string     `json:"style,omitempty"` // primary or danger
+	URL      string     `json:"url,omitempty"`
+	Confirm  *Confirm   `json:"confirm,omitempty"`
+}
+
+// OverflowElement represents an overflow menu: a "⋯" button listing up to
+// five options, for actions too minor for buttons of their own.
+type OverflowElement struct {
+	Type     string   `json:"type"`
+	ActionID string   `json:"action_id"`
+	Options  []Option `json:"options"`
+	Confirm  *Confirm `json:"confirm,omitempty"`
+}
+
+// Confirm represents a confirmation dialog shown before an element's action
+// is sent.
+type Confirm struct {
+	Title   *TextBlock `json:"title"`
+	Text    *TextBlock `json:"text"`
+	Confirm *TextBlock `json:"confirm"`
+	Deny    *TextBlock `json:"deny"`
+	Style   string     `json:"style,omitempty"` // primary or danger
+}
+
+// PlainTextInputElement represents a plain text input.
+type PlainTextInputElement struct {
+	Type         string     `json:"type"`
+	ActionID     string     `json:"action_id"`
+	Placeholder  *TextBlock `json:"placeholder,omitempty"`
+	InitialValue string     `json:"initial_value,omitempty"`
+	Multiline    bool       `json:"multiline,omitempty"`
+	MinLength    int        `json:"min_length,omitempty"`
+	MaxLength    int        `json:"max_length,omitempty"`
+}
+
+// StaticSelectElement represents a single select menu with static options.
+type StaticSelectElement struct {
+	Type          string     `json:"type"`
+	ActionID      string     `json:"action_id"`
+	Placeholder   *TextBlock `json:"placeholder,omitempty"`
+	Options       []Option   `json:"options"`
+	InitialOption *Option    `json:"initial_option,omitempty"`
+	Confirm       *Confirm   `json:"confirm,omitempty"`
+}
+
+// ConversationsSelectElement represents a select menu listing the
+// workspace's conversations.
+type ConversationsSelectElement struct {
+	Type                string              `json:"type"`
+	ActionID            string              `json:"action_id"`
+	Placeholder         *TextBlock          `json:"placeholder,omitempty"`
+	InitialConversation string              `json:"initial_conversation,omitempty"`
+	Filter              *ConversationFilter `json:"filter,omitempty"`
+}
+
+// ConversationFilter limits the conversations a conversations select lists.
+type ConversationFilter struct {
+	Include                       []string `json:"include,omitempty"` // im, mpim, private, public
+	ExcludeBotUsers               bool     `json:"exclude_bot_users,omitempty"`
+	ExcludeExternalSharedChannels bool     `json:"exclude_external_shared_channels,omitempty"`
+}
+
+// CheckboxesElement represents a group of checkboxes.
+type CheckboxesElement struct {
+	Type           string   `json:"type"`
+	ActionID       string   `json:"action_id"`
+	Options        []Option `json:"options"`
+	InitialOptions []Option `json:"initial_options,omitempty"`
+}
+
+// RadioButtonsElement represents a group of radio buttons.
+type RadioButtonsElement struct {
+	Type          string   `json:"type"`
+	ActionID      string   `json:"action_id"`
+	Options       []Option `json:"options"`
+	InitialOption *Option  `json:"initial_option,omitempty"`
+}
+
+// DatePickerElement represents a date picker.
+type DatePickerElement struct {
+	Type        string     `json:"type"`
+	ActionID    string     `json:"action_id"`
+	Placeholder *TextBlock `json:"placeholder,omitempty"`
+	InitialDate string     `json:"initial_date,omitempty"` // YYYY-MM-DD
+}
+
+// NumberInputElement represents a number input.
+type NumberInputElement struct {
+	Type             string     `json:"type"`
+	ActionID         string     `json:"action_id"`
+	IsDecimalAllowed bool       `json:"is_decimal_allowed"`
+	Placeholder      *TextBlock `json:"placeholder,omitempty"`
+	MinValue         string     `json:"min_value,omitempty"`
+	MaxValue         string     `json:"max_value,omitempty"`
+	InitialValue     string     `json:"initial_value,omitempty"`
+}
+
+// Option represents a select option.
+type Option struct {
+	Text  *TextBlock `json:"text"`
+	Value string     `json:"value"`
+}
+
+// PlainText creates a plain text object.
+func PlainText(text string) *TextBlock {
+	return &TextBlock{Type: "plain_text", Text: text}
+}
+
+// Markdown creates a mrkdwn text object.
+func Markdown(text string) *TextBlock {
+	return &TextBlock{Type: "mrkdwn", Text: text}
+}
+
+// NewOptions creates plain text options whose values match their labels.
+func NewOptions(labels ...string) []Option {
+	options := make([]Option, 0, len(labels))
+	for _, label := range labels {
+		options = append(options, Option{
+			Text:  &TextBlock{Type: "plain_text", Text: label},
+			Value: label,
+		})
+	}
+	return options
+}
+
+// NewButton creates a plain text button element.
+func NewButton(actionID, text, value string) ButtonElement {
+	return ButtonElement{
+		Type:     "button",
+		ActionID: actionID,
+		Text: &TextBlock{
+			Type:  "plain_text",
+			Text:  text,
+			Emoji: true,
+		},
+		Value: value,
+	}
+}
+
+// NewOverflow creates an overflow menu of options.
+func NewOverflow(actionID string, options ...Option) OverflowElement {
+	return OverflowElement{
+		Type:     "overflow",
+		ActionID: actionID,
+		Options:  options,
+	}
+}
+
+// NewStaticSelect creates a select menu of options, showing placeholder
+// until one is chosen.
+func NewStaticSelect(actionID, placeholder string, options ...Option) StaticSelectElement {
+	return StaticSelectElement{
+		Type:        "static_select",
+		ActionID:    actionID,
+		Placeholder: PlainText(placeholder),
+		Options:     options,
+	}
+}
+
+// NewConfirm creates a confirmation dialog with mrkdwn text.
+func NewConfirm(title, text, confirm, deny string) *Confirm {
+	return &Confirm{
+		Title:   PlainText(title),
+		Text:    Markdown(text),
+		Confirm: PlainText(confirm),
+		Deny:    PlainText(deny),
+	}
+}
+
+// NewImage creates an image element for a context block.
+func NewImage(imageURL, altText string) ImageElement {
+	return ImageElement{Type: "image", ImageURL: imageURL, AltText: altText}
+}
+
+// NewContext creates a context block of mrkdwn texts.
+func NewContext(blockID string, texts ...string) ContextBlock {
+	elements := make([]interface{}, 0, len(texts))
+	for _, text := range texts {
+		elements = append(elements, Markdown(text))
+	}
+	return ContextBlock{
+		Type:     "context",
+		BlockID:  blockID,
+		Elements: elements,
+	}
+}
@@ -0,0 +1,53 @@
+package blockkit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElementsJSON(t *testing.T) {
+	snooze := NewButton("snooze", "Snooze", "30m")
+	snooze.Confirm = NewConfirm("Snooze?", "You'll be reminded in *30 minutes*.", "Snooze", "Cancel")
+
+	blocks := NewMessageBuilder().
+		AddActionElements("actions",
+			snooze,
+			NewOverflow("more", NewOptions("Skip today", "Mute")...),
+			NewStaticSelect("when", "Pick a time", NewOptions("09:00", "10:00")...),
+		).
+		AddBlocks(NewContext("footer", "Posted by the standup bot")).
+		Build()
+
+	data, err := json.Marshal(blocks)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"type": "actions", "block_id": "actions", "elements": [
+			{"type": "button", "action_id": "snooze", "value": "30m",
+				"text": {"type": "plain_text", "text": "Snooze", "emoji": true},
+				"confirm": {
+					"title": {"type": "plain_text", "text": "Snooze?"},
+					"text": {"type": "mrkdwn", "text": "You'll be reminded in *30 minutes*."},
+					"confirm": {"type": "plain_text", "text": "Snooze"},
+					"deny": {"type": "plain_text", "text": "Cancel"}
+				}},
+			{"type": "overflow", "action_id": "more", "options": [
+				{"text": {"type": "plain_text", "text": "Skip today"}, "value": "Skip today"},
+				{"text": {"type": "plain_text", "text": "Mute"}, "value": "Mute"}
+			]},
+			{"type": "static_select", "action_id": "when",
+				"placeholder": {"type": "plain_text", "text": "Pick a time"},
+				"options": [
+					{"text": {"type": "plain_text", "text": "09:00"}, "value": "09:00"},
+					{"text": {"type": "plain_text", "text": "10:00"}, "value": "10:00"}
+				]}
+		]},
+		{"type": "context", "block_id": "footer", "elements": [
+			{"type": "mrkdwn", "text": "Posted by the standup bot"}
+		]}
+	]`, string(data))
+
+	assert.Equal(t, "context", blocks[1].BlockType())
+}
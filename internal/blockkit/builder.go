@@ -0,0 +1,336 @@
+package blockkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ModalBuilder helps build Slack modals. It is safe for concurrent use.
+type ModalBuilder struct {
+	mu    sync.Mutex
+	modal *Modal
+}
+
+// NewModalBuilder creates a new modal builder.
+func NewModalBuilder(title, callbackID string) *ModalBuilder {
+	return &ModalBuilder{
+		modal: &Modal{
+			Type:       "modal",
+			CallbackID: callbackID,
+			Title: &TextBlock{
+				Type: "plain_text",
+				Text: title,
+			},
+			Blocks: []Block{},
+		},
+	}
+}
+
+// SetSubmit sets the submit button text.
+func (b *ModalBuilder) SetSubmit(text string) *ModalBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.modal.Submit = &TextBlock{
+		Type: "plain_text",
+		Text: text,
+	}
+	return b
+}
+
+// SetClose sets the close button text.
+func (b *ModalBuilder) SetClose(text string) *ModalBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.modal.Close = &TextBlock{
+		Type: "plain_text",
+		Text: text,
+	}
+	return b
+}
+
+// SetPrivateMetadata sets private metadata.
+func (b *ModalBuilder) SetPrivateMetadata(metadata interface{}) *ModalBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return b
+	}
+	b.modal.PrivateMetadata = string(data)
+	return b
+}
+
+// AddHeader adds a header block.
+func (b *ModalBuilder) AddHeader(text string) *ModalBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.modal.Blocks = append(b.modal.Blocks, HeaderBlock{
+		Type: "header",
+		Text: &TextBlock{
+			Type: "plain_text",
+			Text: text,
+		},
+	})
+	return b
+}
+
+// AddSection adds a section block.
+func (b *ModalBuilder) AddSection(text string) *ModalBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.modal.Blocks = append(b.modal.Blocks, &SectionBlock{
+		Type: "section",
+		Text: &TextBlock{
+			Type: "mrkdwn",
+			Text: text,
+		},
+	})
+	return b
+}
+
+// AddTextInput adds a text input block.
+func (b *ModalBuilder) AddTextInput(blockID, actionID, label, placeholder string, multiline bool) *ModalBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	input := InputBlock{
+		Type:    "input",
+		BlockID: blockID,
+		Label: &TextBlock{
+			Type: "plain_text",
+			Text: label,
+		},
+		Element: PlainTextInputElement{
+			Type:      "plain_text_input",
+			ActionID:  actionID,
+			Multiline: multiline,
+		},
+	}
+
+	if placeholder != "" {
+		if element, ok := input.Element.(PlainTextInputElement); ok {
+			element.Placeholder = &TextBlock{
+				Type: "plain_text",
+				Text: placeholder,
+			}
+			input.Element = element
+		}
+	}
+
+	b.modal.Blocks = append(b.modal.Blocks, input)
+	return b
+}
+
+// AddInput adds an input block wrapping the given element.
+func (b *ModalBuilder) AddInput(blockID, label string, element interface{}, optional bool) *ModalBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.modal.Blocks = append(b.modal.Blocks, InputBlock{
+		Type:    "input",
+		BlockID: blockID,
+		Label: &TextBlock{
+			Type: "plain_text",
+			Text: label,
+		},
+		Element:  element,
+		Optional: optional,
+	})
+	return b
+}
+
+// AddActions adds an actions block containing the given buttons.
+func (b *ModalBuilder) AddActions(blockID string, buttons ...ButtonElement) *ModalBuilder {
+	return b.AddActionElements(blockID, buttonElements(buttons)...)
+}
+
+// AddActionElements adds an actions block containing the given interactive
+// elements, such as buttons, overflow menus and selects.
+func (b *ModalBuilder) AddActionElements(blockID string, elements ...interface{}) *ModalBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.modal.Blocks = append(b.modal.Blocks, ActionsBlock{
+		Type:     "actions",
+		BlockID:  blockID,
+		Elements: elements,
+	})
+	return b
+}
+
+// AddDispatchInput adds an input block that sends block_actions whenever its
+// value changes, so the modal can be updated before it is submitted.
+func (b *ModalBuilder) AddDispatchInput(blockID, label string, element interface{}, optional bool) *ModalBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.modal.Blocks = append(b.modal.Blocks, InputBlock{
+		Type:    "input",
+		BlockID: blockID,
+		Label: &TextBlock{
+			Type: "plain_text",
+			Text: label,
+		},
+		Element:        element,
+		Optional:       optional,
+		DispatchAction: true,
+	})
+	return b
+}
+
+// Build returns the built modal. Blocks added afterwards don't change it.
+func (b *ModalBuilder) Build() *Modal {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	modal := *b.modal
+	modal.Blocks = slices.Clone(b.modal.Blocks)
+	return &modal
+}
+
+// MessageBuilder helps build Slack messages. It is safe for concurrent use,
+// e.g. by goroutines each adding a section.
+type MessageBuilder struct {
+	mu     sync.Mutex
+	blocks []Block
+}
+
+// NewMessageBuilder creates a new message builder.
+func NewMessageBuilder() *MessageBuilder {
+	return &MessageBuilder{
+		blocks: []Block{},
+	}
+}
+
+// AddHeader adds a header to the message.
+func (b *MessageBuilder) AddHeader(text string) *MessageBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.blocks = append(b.blocks, HeaderBlock{
+		Type: "header",
+		Text: &TextBlock{
+			Type:  "plain_text",
+			Text:  text,
+			Emoji: true,
+		},
+	})
+	return b
+}
+
+// AddSection adds a section to the message.
+func (b *MessageBuilder) AddSection(text string) *MessageBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.blocks = append(b.blocks, &SectionBlock{
+		Type: "section",
+		Text: &TextBlock{
+			Type: "mrkdwn",
+			Text: text,
+		},
+	})
+	return b
+}
+
+// AddFields adds fields to the last section.
+func (b *MessageBuilder) AddFields(fields ...string) *MessageBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.blocks) == 0 || len(fields)%2 != 0 {
+		return b
+	}
+
+	// Find the last section block
+	for i := len(b.blocks) - 1; i >= 0; i-- {
+		if section, ok := b.blocks[i].(*SectionBlock); ok {
+			for j := 0; j < len(fields); j += 2 {
+				section.Fields = append(section.Fields, TextBlock{
+					Type: "mrkdwn",
+					Text: fmt.Sprintf("*%s*\n%s", fields[j], fields[j+1]),
+				})
+			}
+			// No need to reassign as we're modifying the pointer
+			break
+		}
+	}
+
+	return b
+}
+
+// AddActions adds an actions block containing the given buttons.
+func (b *MessageBuilder) AddActions(blockID string, buttons ...ButtonElement) *MessageBuilder {
+	return b.AddActionElements(blockID, buttonElements(buttons)...)
+}
+
+// AddActionElements adds an actions block containing the given interactive
+// elements, such as buttons, overflow menus and selects.
+func (b *MessageBuilder) AddActionElements(blockID string, elements ...interface{}) *MessageBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.blocks = append(b.blocks, ActionsBlock{
+		Type:     "actions",
+		BlockID:  blockID,
+		Elements: elements,
+	})
+	return b
+}
+
+// AddContext adds a context block of mrkdwn texts, such as who posted a
+// message or when.
+func (b *MessageBuilder) AddContext(texts ...string) *MessageBuilder {
+	return b.AddBlocks(NewContext("", texts...))
+}
+
+// AddImage adds an image block. altText describes the image to screen
+// readers and is required by Slack.
+func (b *MessageBuilder) AddImage(imageURL, altText string) *MessageBuilder {
+	return b.AddBlocks(ImageBlock{
+		Type:     "image",
+		ImageURL: imageURL,
+		AltText:  altText,
+	})
+}
+
+// AddBlocks adds blocks built elsewhere.
+func (b *MessageBuilder) AddBlocks(blocks ...Block) *MessageBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.blocks = append(b.blocks, blocks...)
+	return b
+}
+
+// AddDivider adds a divider block.
+func (b *MessageBuilder) AddDivider() *MessageBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.blocks = append(b.blocks, DividerBlock{Type: "divider"})
+	return b
+}
+
+// Build returns the built blocks. Blocks added afterwards don't change them.
+func (b *MessageBuilder) Build() []Block {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return slices.Clone(b.blocks)
+}
+
+func buttonElements(buttons []ButtonElement) []interface{} {
+	elements := make([]interface{}, 0, len(buttons))
+	for _, button := range buttons {
+		elements = append(elements, button)
+	}
+	return elements
+}
@@ -0,0 +1,65 @@
+package blockkit
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageBuilderConcurrentUse(t *testing.T) {
+	builder := NewMessageBuilder().AddHeader("Standup")
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			builder.AddSection("An answer")
+		}()
+	}
+	wg.Wait()
+
+	blocks := builder.Build()
+	assert.Len(t, blocks, 21)
+
+	// Built blocks don't change as more are added
+	builder.AddDivider()
+	assert.Len(t, blocks, 21)
+	assert.Len(t, builder.Build(), 22)
+}
+
+func TestMessageBuilderContextAndImage(t *testing.T) {
+	blocks := NewMessageBuilder().
+		AddSection("*Weekly standup*").
+		AddImage("https://example.com/chart.png", "Participation this week").
+		AddContext("Posted by <@U0000000000>", "Mon, Jan 15").
+		AddActions("summary_actions", NewButton("ack", "Got it", "2024-01-15")).
+		Build()
+
+	data, err := json.Marshal(blocks[1:])
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"type": "image", "image_url": "https://example.com/chart.png", "alt_text": "Participation this week"},
+		{"type": "context", "elements": [
+			{"type": "mrkdwn", "text": "Posted by <@U0000000000>"},
+			{"type": "mrkdwn", "text": "Mon, Jan 15"}
+		]},
+		{"type": "actions", "block_id": "summary_actions", "elements": [
+			{"type": "button", "action_id": "ack", "value": "2024-01-15",
+				"text": {"type": "plain_text", "text": "Got it", "emoji": true}}
+		]}
+	]`, string(data))
+}
+
+func TestModalBuilderBuildIsASnapshot(t *testing.T) {
+	builder := NewModalBuilder("Standup", "standup").AddSection("Hello")
+	modal := builder.Build()
+
+	builder.AddActions("actions", NewButton("submit", "Submit", ""))
+	assert.Len(t, modal.Blocks, 1)
+	assert.Len(t, builder.Build().Blocks, 2)
+	assert.Equal(t, "actions", builder.Build().Blocks[1].BlockType())
+}
@@ -0,0 +1,110 @@
+// Package cache keeps lookups that are repeated within a Lambda container,
+// such as channel configs and Slack user info, for a short while so bulk
+// reminder runs don't repeat the same DynamoDB and Slack calls.
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTTL is how long a cached lookup is reused.
+	DefaultTTL = 5 * time.Minute
+
+	// maxEntries caps how many lookups a cache holds. Expired entries are
+	// dropped when it fills, and everything if none have expired.
+	maxEntries = 10000
+)
+
+// Cache holds values for a fixed time after they're set. It is safe for
+// concurrent use.
+type Cache[K comparable, V any] struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[K]entry[V]
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// New creates a cache whose values expire after ttl, or DefaultTTL when
+// ttl isn't positive.
+func New[K comparable, V any](ttl time.Duration) *Cache[K, V] {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &Cache[K, V]{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[K]entry[V]),
+	}
+}
+
+// Get returns the value set for key, if it hasn't expired.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if !c.now().Before(cached.expiresAt) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	return cached.value, true
+}
+
+// Set caches value for key.
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxEntries {
+		for k, cached := range c.entries {
+			if !now.Before(cached.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxEntries {
+			clear(c.entries)
+		}
+	}
+
+	c.entries[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+// Delete drops the value cached for key.
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// Clear drops every cached value.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+}
+
+// Len returns how many values are cached, including expired ones not yet
+// dropped.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	c := New[string, int](time.Minute)
+	c.now = func() time.Time { return now }
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	c.Set("a", 1)
+	value, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	// Values expire after the TTL
+	now = now.Add(time.Minute)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Zero(t, c.Len())
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Delete("a")
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	_, ok = c.Get("b")
+	assert.True(t, ok)
+
+	c.Clear()
+	assert.Zero(t, c.Len())
+}
+
+func TestCacheLimit(t *testing.T) {
+	now := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	c := New[int, int](time.Minute)
+	c.now = func() time.Time { return now }
+
+	// A full cache drops expired values first
+	for i := range maxEntries - 1 {
+		c.Set(i, i)
+	}
+	now = now.Add(time.Minute)
+	c.Set(-1, -1)
+	c.Set(-2, -2)
+	assert.Equal(t, 2, c.Len())
+
+	// And everything when none have expired
+	for i := range maxEntries - 2 {
+		c.Set(i, i)
+	}
+	assert.Equal(t, maxEntries, c.Len())
+	c.Set(maxEntries, maxEntries)
+	assert.Equal(t, 1, c.Len())
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// SlackClient caches user and channel info fetched by another client.
+// Failed lookups aren't cached.
+type SlackClient struct {
+	slack.Client
+
+	users    *Cache[workspaceKey, *slack.UserInfo]
+	channels *Cache[workspaceKey, *slack.ConversationInfo]
+}
+
+// workspaceKey scopes a Slack ID by the workspace the request was made
+// for, as each workspace is looked up with its own token.
+type workspaceKey struct {
+	teamID string
+	id     string
+}
+
+// NewSlackClient wraps client, caching user and channel info for ttl.
+func NewSlackClient(client slack.Client, ttl time.Duration) *SlackClient {
+	return &SlackClient{
+		Client:   client,
+		users:    New[workspaceKey, *slack.UserInfo](ttl),
+		channels: New[workspaceKey, *slack.ConversationInfo](ttl),
+	}
+}
+
+// GetUserInfo returns the user's info, from the cache when it was fetched
+// recently. Callers get their own copy.
+func (c *SlackClient) GetUserInfo(ctx context.Context, userID string) (*slack.UserInfo, error) {
+	key := workspaceKey{store.TeamScope(ctx), userID}
+	if user, ok := c.users.Get(key); ok {
+		info := *user
+		return &info, nil
+	}
+
+	user, err := c.Client.GetUserInfo(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	info := *user
+	c.users.Set(key, &info)
+	return user, nil
+}
+
+// GetChannelInfo returns the channel's info, from the cache when it was
+// fetched recently. Callers get their own copy.
+func (c *SlackClient) GetChannelInfo(ctx context.Context, channelID string) (*slack.ConversationInfo, error) {
+	key := workspaceKey{store.TeamScope(ctx), channelID}
+	if channel, ok := c.channels.Get(key); ok {
+		info := *channel
+		return &info, nil
+	}
+
+	channel, err := c.Client.GetChannelInfo(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	info := *channel
+	c.channels.Set(key, &info)
+	return channel, nil
+}
+
+// Invalidate drops every cached user and channel.
+func (c *SlackClient) Invalidate() {
+	c.users.Clear()
+	c.channels.Clear()
+}
@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/slack/slacktest"
+)
+
+func TestSlackClientCachesLookups(t *testing.T) {
+	ctx := context.Background()
+	fake := slacktest.New()
+	fake.AddUser(&slack.UserInfo{ID: "U1234567890", Name: "alice"})
+	fake.Channels["C1234567890"] = &slack.ConversationInfo{ID: "C1234567890", Name: "engineering"}
+	client := NewSlackClient(fake, time.Minute)
+
+	for range 3 {
+		user, err := client.GetUserInfo(ctx, "U1234567890")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", user.Name)
+		user.Name = "changed"
+
+		channel, err := client.GetChannelInfo(ctx, "C1234567890")
+		require.NoError(t, err)
+		assert.Equal(t, "engineering", channel.Name)
+		channel.Name = "changed"
+	}
+	assert.Len(t, fake.Calls("users.info"), 1)
+	assert.Len(t, fake.Calls("conversations.info"), 1)
+
+	// Each workspace looks users up separately
+	teamCtx := context.WithValue(ctx, botcontext.TeamIDKey, "T1234567890")
+	_, err := client.GetUserInfo(teamCtx, "U1234567890")
+	require.NoError(t, err)
+	assert.Len(t, fake.Calls("users.info"), 2)
+
+	// Failures aren't cached
+	fake.FailNextWithCode("conversations.info", "ratelimited")
+	client.Invalidate()
+	_, err = client.GetChannelInfo(ctx, "C1234567890")
+	require.Error(t, err)
+	_, err = client.GetChannelInfo(ctx, "C1234567890")
+	require.NoError(t, err)
+	assert.Len(t, fake.Calls("conversations.info"), 3)
+}
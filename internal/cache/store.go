@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// Store caches channel configs read from another store. Saving a config
+// through it drops the cached copy; configs saved by other containers are
+// seen once the cached copy expires.
+type Store struct {
+	store.Store
+
+	configs *Cache[channelKey, *store.ChannelConfig]
+}
+
+type channelKey struct {
+	teamID    string
+	channelID string
+}
+
+// NewStore wraps s, caching its channel configs for ttl.
+func NewStore(s store.Store, ttl time.Duration) *Store {
+	return &Store{
+		Store:   s,
+		configs: New[channelKey, *store.ChannelConfig](ttl),
+	}
+}
+
+// GetChannelConfig returns the channel's config, from the cache when it was
+// read recently. Callers get their own copy, so they may change it.
+func (s *Store) GetChannelConfig(ctx context.Context, teamID, channelID string) (*store.ChannelConfig, error) {
+	key := channelKey{teamID, channelID}
+	if config, ok := s.configs.Get(key); ok {
+		return config.Clone(), nil
+	}
+
+	config, err := s.Store.GetChannelConfig(ctx, teamID, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.configs.Set(key, config.Clone())
+	return config, nil
+}
+
+// SaveChannelConfig saves the config and drops the cached copy.
+func (s *Store) SaveChannelConfig(ctx context.Context, config *store.ChannelConfig) error {
+	err := s.Store.SaveChannelConfig(ctx, config)
+	s.InvalidateChannelConfig(config.TeamID, config.ChannelID)
+	return err
+}
+
+// DisableChannelConfig disables the channel and drops the cached copy.
+func (s *Store) DisableChannelConfig(ctx context.Context, teamID, channelID string) error {
+	err := s.Store.DisableChannelConfig(ctx, teamID, channelID)
+	s.InvalidateChannelConfig(teamID, channelID)
+	return err
+}
+
+// ArchiveChannelConfig archives the channel and drops the cached copy.
+func (s *Store) ArchiveChannelConfig(ctx context.Context, teamID, channelID string, archivedAt time.Time) error {
+	err := s.Store.ArchiveChannelConfig(ctx, teamID, channelID, archivedAt)
+	s.InvalidateChannelConfig(teamID, channelID)
+	return err
+}
+
+// UnarchiveChannelConfig unarchives the channel and drops the cached copy.
+func (s *Store) UnarchiveChannelConfig(ctx context.Context, teamID, channelID string) error {
+	err := s.Store.UnarchiveChannelConfig(ctx, teamID, channelID)
+	s.InvalidateChannelConfig(teamID, channelID)
+	return err
+}
+
+// InvalidateChannelConfig drops the cached config of a channel.
+func (s *Store) InvalidateChannelConfig(teamID, channelID string) {
+	s.configs.Delete(channelKey{teamID, channelID})
+}
+
+// Invalidate drops every cached config, e.g. after the bot's configuration
+// is reloaded.
+func (s *Store) Invalidate() {
+	s.configs.Clear()
+}
@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/store/memory"
+	"github.com/synaptiq/standup-bot/internal/store/storetest"
+)
+
+func TestStoreContract(t *testing.T) {
+	storetest.TestStore(t, func(t *testing.T) store.Store {
+		return NewStore(memory.NewStore(), time.Minute)
+	})
+}
+
+// countingStore counts channel config reads.
+type countingStore struct {
+	store.Store
+	reads int
+}
+
+func (s *countingStore) GetChannelConfig(ctx context.Context, teamID, channelID string) (*store.ChannelConfig, error) {
+	s.reads++
+	return s.Store.GetChannelConfig(ctx, teamID, channelID)
+}
+
+func TestStoreCachesChannelConfigs(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingStore{Store: memory.NewStore()}
+	s := NewStore(inner, time.Minute)
+	require.NoError(t, s.SaveChannelConfig(ctx, &store.ChannelConfig{
+		TeamID:    "T1234567890",
+		ChannelID: "C1234567890",
+		Users:     []string{"U1111111111"},
+	}))
+
+	// Repeated reads hit the cache, and callers' changes don't leak into it
+	config, err := s.GetChannelConfig(ctx, "T1234567890", "C1234567890")
+	require.NoError(t, err)
+	config.Users[0] = "U2222222222"
+	config, err = s.GetChannelConfig(ctx, "T1234567890", "C1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U1111111111"}, config.Users)
+	assert.Equal(t, 1, inner.reads)
+
+	// Saving drops the cached copy
+	config.Users = append(config.Users, "U2222222222")
+	require.NoError(t, s.SaveChannelConfig(ctx, config))
+	config, err = s.GetChannelConfig(ctx, "T1234567890", "C1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U1111111111", "U2222222222"}, config.Users)
+	assert.Equal(t, 2, inner.reads)
+
+	// As does invalidating it
+	s.InvalidateChannelConfig("T1234567890", "C1234567890")
+	_, err = s.GetChannelConfig(ctx, "T1234567890", "C1234567890")
+	require.NoError(t, err)
+	s.Invalidate()
+	_, err = s.GetChannelConfig(ctx, "T1234567890", "C1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, 4, inner.reads)
+
+	// Missing configs aren't cached
+	for range 2 {
+		_, err = s.GetChannelConfig(ctx, "T1234567890", "C0987654321")
+		assert.ErrorIs(t, err, store.ErrNotFound)
+	}
+	assert.Equal(t, 6, inner.reads)
+}
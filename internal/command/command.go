@@ -0,0 +1,230 @@
+// Package command parses slash command text into subcommands, arguments and
+// flags, and generates usage and help text for them.
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// ErrUnknownCommand is returned when no command is registered for a name.
+var ErrUnknownCommand = errors.New("unknown command")
+
+// Handler runs a parsed command.
+type Handler func(ctx context.Context, inv *Invocation) (events.APIGatewayProxyResponse, error)
+
+// Command is a slash command or one of its subcommands.
+type Command struct {
+	Name        string
+	Summary     string // One line shown in help
+	Args        []Arg
+	Flags       []Flag
+	Subcommands []*Command
+	Run         Handler // nil for commands that only group subcommands
+
+	parent *Command
+}
+
+// Arg is a positional argument. An optional argument that doesn't accept a
+// value is skipped, so the value can fill a later argument.
+type Arg struct {
+	Name     string
+	Optional bool
+	Choices  []string                 // Accepted values, matched case-insensitively; any value if empty
+	Validate func(value string) error // Further checks on the value
+	Rest     bool                     // Takes the rest of the text as typed; must be last
+}
+
+// Flag is a --name value, --name=value or boolean --name option.
+type Flag struct {
+	Name  string
+	Usage string
+	Bool  bool // Takes no value
+}
+
+// Set holds the slash commands an app handles.
+type Set struct {
+	commands map[string]*Command
+}
+
+// NewSet creates a set of commands, keyed by their names, e.g. "/standup".
+func NewSet(commands ...*Command) *Set {
+	s := &Set{commands: make(map[string]*Command, len(commands))}
+	for _, cmd := range commands {
+		cmd.link(nil)
+		s.commands[cmd.Name] = cmd
+	}
+	return s
+}
+
+// link sets the parent of cmd and its subcommands.
+func (c *Command) link(parent *Command) {
+	c.parent = parent
+	for _, sub := range c.Subcommands {
+		sub.link(c)
+	}
+}
+
+// Path returns the full command line of c, e.g. "/standup config set".
+func (c *Command) Path() string {
+	if c.parent == nil {
+		return c.Name
+	}
+	return c.parent.Path() + " " + c.Name
+}
+
+// Usage returns the synopsis of c, e.g. "/standup skip [reason...]".
+func (c *Command) Usage() string {
+	parts := []string{c.Path()}
+	if c.Run == nil && len(c.Subcommands) > 0 {
+		parts = append(parts, "<command>")
+	}
+	for _, flag := range c.Flags {
+		if flag.Bool {
+			parts = append(parts, fmt.Sprintf("[--%s]", flag.Name))
+		} else {
+			parts = append(parts, fmt.Sprintf("[--%s <%s>]", flag.Name, flag.Name))
+		}
+	}
+	for _, arg := range c.Args {
+		name := arg.Name
+		if len(arg.Choices) > 0 {
+			name = strings.Join(arg.Choices, "|")
+		}
+		if arg.Rest {
+			name += "..."
+		}
+		if arg.Optional {
+			parts = append(parts, "["+name+"]")
+		} else {
+			parts = append(parts, "<"+name+">")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Help returns help for c in Slack mrkdwn: its usage, flags and subcommands.
+func (c *Command) Help() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%s*", c.Path())
+	if c.Summary != "" {
+		fmt.Fprintf(&b, " — %s", c.Summary)
+	}
+	if c.Run != nil {
+		fmt.Fprintf(&b, "\nUsage: `%s`", c.Usage())
+	}
+
+	if len(c.Flags) > 0 {
+		b.WriteString("\n\n*Flags:*")
+		for _, flag := range c.Flags {
+			fmt.Fprintf(&b, "\n• `--%s` — %s", flag.Name, flag.Usage)
+		}
+	}
+
+	if len(c.Subcommands) > 0 {
+		b.WriteString("\n\n*Commands:*")
+		for _, sub := range c.Subcommands {
+			fmt.Fprintf(&b, "\n• `%s`", sub.Usage())
+			if sub.Summary != "" {
+				fmt.Fprintf(&b, " — %s", sub.Summary)
+			}
+		}
+		fmt.Fprintf(&b, "\n\nUse `%s help <command>` for details.", c.root().Name)
+	}
+
+	return b.String()
+}
+
+// root returns the slash command c belongs to.
+func (c *Command) root() *Command {
+	if c.parent == nil {
+		return c
+	}
+	return c.parent.root()
+}
+
+// subcommand returns the subcommand called name, ignoring case.
+func (c *Command) subcommand(name string) *Command {
+	for _, sub := range c.Subcommands {
+		if strings.EqualFold(sub.Name, name) {
+			return sub
+		}
+	}
+	return nil
+}
+
+// flag returns the flag called name.
+func (c *Command) flag(name string) *Flag {
+	for i := range c.Flags {
+		if c.Flags[i].Name == name {
+			return &c.Flags[i]
+		}
+	}
+	return nil
+}
+
+// accept checks value against the argument and returns it, normalized to
+// the matching choice.
+func (a *Arg) accept(value string) (string, error) {
+	if len(a.Choices) > 0 {
+		choice := ""
+		for _, c := range a.Choices {
+			if strings.EqualFold(c, value) {
+				choice = c
+			}
+		}
+		if choice == "" {
+			return "", fmt.Errorf("%s must be one of %s", a.Name, strings.Join(a.Choices, ", "))
+		}
+		value = choice
+	}
+
+	if a.Validate != nil {
+		if err := a.Validate(value); err != nil {
+			return "", err
+		}
+	}
+	return value, nil
+}
+
+// Invocation is a parsed command line.
+type Invocation struct {
+	Command *Command
+	Help    bool // Help was asked for with "help", --help or -h
+
+	args  map[string]string
+	flags map[string]string
+}
+
+// Arg returns the value of a positional argument, or "" if it was omitted.
+func (inv *Invocation) Arg(name string) string {
+	return inv.args[name]
+}
+
+// Flag returns the value of a flag, or "" if it was omitted.
+func (inv *Invocation) Flag(name string) string {
+	return inv.flags[name]
+}
+
+// Bool reports whether a boolean flag was given.
+func (inv *Invocation) Bool(name string) bool {
+	return inv.flags[name] == "true"
+}
+
+// UsageError reports command text that doesn't fit the command.
+type UsageError struct {
+	Command *Command
+	Message string
+}
+
+func (e *UsageError) Error() string {
+	return fmt.Sprintf("%s\nUsage: `%s`", e.Message, e.Command.Usage())
+}
+
+func usageError(cmd *Command, format string, args ...interface{}) *UsageError {
+	return &UsageError{Command: cmd, Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,143 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func run(context.Context, *Invocation) (events.APIGatewayProxyResponse, error) {
+	return events.APIGatewayProxyResponse{}, nil
+}
+
+func testSet() *Set {
+	return NewSet(
+		&Command{
+			Name: "/standup",
+			Run:  run,
+			Subcommands: []*Command{
+				{Name: "skip", Summary: "Skip today", Args: []Arg{{Name: "reason", Optional: true, Rest: true}}, Run: run},
+				{
+					Name: "config",
+					Subcommands: []*Command{
+						{Name: "set", Args: []Arg{{Name: "key", Choices: []string{"summary_time", "timezone"}}, {Name: "value", Rest: true}}, Run: run},
+					},
+				},
+			},
+		},
+		&Command{
+			Name: "/standup-stats",
+			Args: []Arg{
+				{Name: "days", Optional: true, Validate: func(value string) error {
+					if value != "7" && value != "30" {
+						return errors.New("days must be 7 or 30")
+					}
+					return nil
+				}},
+				{Name: "me", Optional: true, Choices: []string{"me"}},
+			},
+			Flags: []Flag{{Name: "format", Usage: "Output format"}, {Name: "verbose", Usage: "More detail", Bool: true}},
+			Run:   run,
+		},
+	)
+}
+
+func TestParse(t *testing.T) {
+	set := testSet()
+
+	inv, err := set.Parse("/standup", "")
+	require.NoError(t, err)
+	assert.Equal(t, "/standup", inv.Command.Path())
+	assert.False(t, inv.Help)
+
+	// Rest arguments keep the text as typed
+	inv, err = set.Parse("/standup", "SKIP  out  sick --today")
+	require.NoError(t, err)
+	assert.Equal(t, "/standup skip", inv.Command.Path())
+	assert.Equal(t, "out  sick --today", inv.Arg("reason"))
+
+	inv, err = set.Parse("/standup", "config set Timezone “America/New_York”")
+	require.NoError(t, err)
+	assert.Equal(t, "timezone", inv.Arg("key"))
+	assert.Equal(t, "“America/New_York”", inv.Arg("value"))
+
+	// Optional arguments that don't take a value are skipped
+	inv, err = set.Parse("/standup-stats", "me")
+	require.NoError(t, err)
+	assert.Empty(t, inv.Arg("days"))
+	assert.Equal(t, "me", inv.Arg("me"))
+
+	inv, err = set.Parse("/standup-stats", "--verbose 30 --format=csv me")
+	require.NoError(t, err)
+	assert.Equal(t, "30", inv.Arg("days"))
+	assert.Equal(t, "csv", inv.Flag("format"))
+	assert.True(t, inv.Bool("verbose"))
+
+	_, err = set.Parse("/standup-report", "")
+	assert.ErrorIs(t, err, ErrUnknownCommand)
+}
+
+func TestParseErrors(t *testing.T) {
+	set := testSet()
+
+	tests := []struct {
+		name    string
+		command string
+		text    string
+		message string
+	}{
+		{"typo", "/standup", "sikp", "Unknown command `/standup sikp`. Did you mean `/standup skip`?"},
+		{"no suggestion", "/standup", "dance", "Unknown command `/standup dance`."},
+		{"missing", "/standup", "config set timezone", "Missing value."},
+		{"choices", "/standup", "config set color blue", "Key must be one of summary_time, timezone."},
+		{"invalid", "/standup-stats", "12", "Days must be 7 or 30."},
+		{"extra", "/standup-stats", "7 me please", "Unexpected argument `please`."},
+		{"flag typo", "/standup-stats", "--fromat csv", "Unknown flag `--fromat`. Did you mean `--format`?"},
+		{"flag value", "/standup-stats", "--format", "`--format` needs a value."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := set.Parse(tt.command, tt.text)
+			var usageErr *UsageError
+			require.ErrorAs(t, err, &usageErr)
+			assert.Equal(t, tt.message, usageErr.Message)
+		})
+	}
+}
+
+func TestHelp(t *testing.T) {
+	set := testSet()
+
+	for _, text := range []string{"help", "--help", "config set -h"} {
+		inv, err := set.Parse("/standup", text)
+		require.NoError(t, err)
+		assert.True(t, inv.Help, text)
+	}
+
+	// Commands without a handler show their help
+	inv, err := set.Parse("/standup", "config")
+	require.NoError(t, err)
+	assert.True(t, inv.Help)
+
+	inv, err = set.Parse("/standup", "help config set")
+	require.NoError(t, err)
+	assert.True(t, inv.Help)
+	assert.Equal(t, "/standup config set <summary_time|timezone> <value...>", inv.Command.Usage())
+
+	// "help" as a reason is still a reason
+	inv, err = set.Parse("/standup", "skip help desk duty")
+	require.NoError(t, err)
+	assert.Equal(t, "help desk duty", inv.Arg("reason"))
+
+	inv, err = set.Parse("/standup", "help")
+	require.NoError(t, err)
+	assert.Equal(t, "*/standup*\nUsage: `/standup`\n\n*Commands:*\n"+
+		"• `/standup skip [reason...]` — Skip today\n"+
+		"• `/standup config <command>`\n\n"+
+		"Use `/standup help <command>` for details.", inv.Command.Help())
+}
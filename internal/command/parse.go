@@ -0,0 +1,262 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/synaptiq/standup-bot/internal/security"
+)
+
+// Parse parses the text of the slash command called name.
+func (s *Set) Parse(name, text string) (*Invocation, error) {
+	cmd, ok := s.commands[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCommand, security.SanitizeLogValue(name))
+	}
+
+	tokens := tokenize(text)
+
+	// Find the subcommand
+	i := 0
+	for i < len(tokens) && len(cmd.Subcommands) > 0 && !tokens[i].quoted {
+		sub := cmd.subcommand(tokens[i].value)
+		if sub == nil {
+			break
+		}
+		cmd = sub
+		i++
+	}
+
+	// "help" names the command to explain, or ends the command line
+	if i < len(tokens) && !tokens[i].quoted && strings.EqualFold(tokens[i].value, "help") &&
+		(len(cmd.Subcommands) > 0 || i == len(tokens)-1) {
+		return parseHelp(cmd, tokens[i+1:])
+	}
+
+	inv := &Invocation{
+		Command: cmd,
+		args:    make(map[string]string),
+		flags:   make(map[string]string),
+	}
+
+	next := 0 // Index in cmd.Args of the next argument to fill
+	for ; i < len(tokens); i++ {
+		tok := tokens[i]
+		inRest := next < len(cmd.Args) && cmd.Args[next].Rest
+
+		if !tok.quoted && !inRest {
+			switch {
+			case tok.value == "--help" || tok.value == "-h":
+				inv.Help = true
+				return inv, nil
+			case strings.HasPrefix(tok.value, "--") && len(tok.value) > 2:
+				consumed, err := parseFlag(inv, tokens[i:])
+				if err != nil {
+					return nil, err
+				}
+				i += consumed - 1
+				continue
+			}
+		}
+
+		if next >= len(cmd.Args) {
+			if len(cmd.Subcommands) > 0 && next == 0 {
+				return nil, unknownSubcommand(cmd, tok.value)
+			}
+			return nil, usageError(cmd, "Unexpected argument `%s`.", security.SanitizeLogValue(tok.value))
+		}
+
+		// Skip optional arguments that don't take the value
+		var firstErr error
+		for ; next < len(cmd.Args); next++ {
+			arg := &cmd.Args[next]
+			if arg.Rest {
+				inv.args[arg.Name] = strings.TrimSpace(text[tok.start:])
+				i = len(tokens)
+				next++
+				break
+			}
+
+			value, err := arg.accept(tok.value)
+			if err == nil {
+				inv.args[arg.Name] = value
+				next++
+				break
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			if !arg.Optional || next == len(cmd.Args)-1 {
+				return nil, usageError(cmd, "%s.", capitalize(firstErr.Error()))
+			}
+		}
+	}
+
+	for ; next < len(cmd.Args); next++ {
+		if arg := cmd.Args[next]; !arg.Optional {
+			return nil, usageError(cmd, "Missing %s.", arg.Name)
+		}
+	}
+
+	// Commands that only group subcommands show their help
+	if cmd.Run == nil {
+		inv.Help = true
+	}
+
+	return inv, nil
+}
+
+// parseHelp parses the path of the command "help" was asked for.
+func parseHelp(cmd *Command, tokens []token) (*Invocation, error) {
+	for _, tok := range tokens {
+		sub := cmd.subcommand(tok.value)
+		if sub == nil {
+			return nil, unknownSubcommand(cmd, tok.value)
+		}
+		cmd = sub
+	}
+	return &Invocation{Command: cmd, Help: true}, nil
+}
+
+// parseFlag parses the flag at the start of tokens and returns how many
+// tokens it took.
+func parseFlag(inv *Invocation, tokens []token) (int, error) {
+	cmd := inv.Command
+	name, value, hasValue := strings.Cut(tokens[0].value[2:], "=")
+
+	flag := cmd.flag(name)
+	if flag == nil {
+		candidates := make([]string, 0, len(cmd.Flags))
+		for _, f := range cmd.Flags {
+			candidates = append(candidates, f.Name)
+		}
+		msg := fmt.Sprintf("Unknown flag `--%s`.", security.SanitizeLogValue(name))
+		if suggestion := suggest(name, candidates); suggestion != "" {
+			msg += fmt.Sprintf(" Did you mean `--%s`?", suggestion)
+		}
+		return 0, usageError(cmd, "%s", msg)
+	}
+
+	if flag.Bool {
+		if hasValue {
+			return 0, usageError(cmd, "`--%s` doesn't take a value.", flag.Name)
+		}
+		inv.flags[flag.Name] = "true"
+		return 1, nil
+	}
+
+	if hasValue {
+		inv.flags[flag.Name] = value
+		return 1, nil
+	}
+	if len(tokens) < 2 {
+		return 0, usageError(cmd, "`--%s` needs a value.", flag.Name)
+	}
+	inv.flags[flag.Name] = tokens[1].value
+	return 2, nil
+}
+
+// unknownSubcommand reports a subcommand that doesn't exist, suggesting the
+// closest one.
+func unknownSubcommand(cmd *Command, name string) *UsageError {
+	candidates := make([]string, 0, len(cmd.Subcommands)+1)
+	for _, sub := range cmd.Subcommands {
+		candidates = append(candidates, sub.Name)
+	}
+	candidates = append(candidates, "help")
+
+	msg := fmt.Sprintf("Unknown command `%s %s`.", cmd.Path(), security.SanitizeLogValue(name))
+	if suggestion := suggest(name, candidates); suggestion != "" {
+		msg += fmt.Sprintf(" Did you mean `%s %s`?", cmd.Path(), suggestion)
+	}
+	return usageError(cmd, "%s", msg)
+}
+
+// token is a word of command text. start is its offset in the text.
+type token struct {
+	value  string
+	start  int
+	quoted bool
+}
+
+// tokenize splits text into words. Double quotes, including the curly quotes
+// Slack clients may substitute, group words with spaces.
+func tokenize(text string) []token {
+	var tokens []token
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if unicode.IsSpace(r) {
+			i += size
+			continue
+		}
+
+		start := i
+		if r == '"' || r == '“' {
+			i += size
+			end := strings.IndexAny(text[i:], "\"”")
+			if end < 0 {
+				end = len(text) - i
+			}
+			tokens = append(tokens, token{value: text[i : i+end], start: start, quoted: true})
+			i += end
+			if i < len(text) {
+				_, size = utf8.DecodeRuneInString(text[i:])
+				i += size
+			}
+			continue
+		}
+
+		end := strings.IndexFunc(text[i:], unicode.IsSpace)
+		if end < 0 {
+			end = len(text) - i
+		}
+		tokens = append(tokens, token{value: text[i : i+end], start: start})
+		i += end
+	}
+	return tokens
+}
+
+// suggest returns the candidate closest to a mistyped name, or "" if none
+// is close enough to be a likely typo.
+func suggest(name string, candidates []string) string {
+	name = strings.ToLower(name)
+	best, bestDistance := "", 3
+	for _, candidate := range candidates {
+		distance := levenshtein(name, strings.ToLower(candidate))
+		if distance < bestDistance && distance < len(candidate) {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// capitalize upper-cases the first letter of s.
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,150 @@
+// Package configprovider contains config.Provider implementations backed by
+// AWS services, so configuration can change without redeploying the Lambdas.
+package configprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+)
+
+// DefaultPollInterval is how often providers check their source for changes.
+const DefaultPollInterval = time.Minute
+
+// requestTimeout bounds each call made while loading or polling.
+const requestTimeout = 10 * time.Second
+
+// S3Client defines the S3 operations used by S3Provider.
+type S3Client interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput,
+		optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	HeadObject(ctx context.Context, params *s3.HeadObjectInput,
+		optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+}
+
+// S3Provider loads YAML configuration from an S3 object.
+type S3Provider struct {
+	client   S3Client
+	bucket   string
+	key      string
+	interval time.Duration
+
+	mu         sync.Mutex
+	loaded     botconfig.Config // Reused while the object's ETag is unchanged
+	loadedETag string
+}
+
+// NewS3Provider creates a provider for the YAML config stored at s3://bucket/key.
+func NewS3Provider(client S3Client, bucket, key string, interval time.Duration) *S3Provider {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	return &S3Provider{
+		client:   client,
+		bucket:   bucket,
+		key:      key,
+		interval: interval,
+	}
+}
+
+// Load fetches and parses the config object. Once loaded, the object is
+// only fetched if its ETag changed, and the parsed config is reused
+// otherwise.
+func (p *S3Provider) Load() (botconfig.Config, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(p.key),
+	}
+	if p.loaded != nil {
+		input.IfNoneMatch = aws.String(p.loadedETag)
+	}
+	result, err := p.client.GetObject(ctx, input)
+	if isNotModified(err) {
+		return p.loaded, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config object: %w", err)
+	}
+	defer result.Body.Close()
+
+	data, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config object: %w", err)
+	}
+
+	cfg, err := botconfig.ParseYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	p.loaded, p.loadedETag = cfg, aws.ToString(result.ETag)
+
+	return cfg, nil
+}
+
+// isNotModified reports whether err is S3 answering a conditional request
+// with 304 Not Modified.
+func isNotModified(err error) bool {
+	var respErr interface{ HTTPStatusCode() int }
+	return errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotModified
+}
+
+// Watch polls the object's ETag and calls callback with the reloaded
+// configuration whenever it changes. Invalid objects are skipped until fixed.
+func (p *S3Provider) Watch(callback func(botconfig.Config)) error {
+	lastETag, err := p.etag()
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			etag, err := p.etag()
+			if err != nil || etag == lastETag {
+				continue
+			}
+			lastETag = etag
+
+			cfg, err := p.Load()
+			if err != nil {
+				continue
+			}
+			callback(cfg)
+		}
+	}()
+
+	return nil
+}
+
+func (p *S3Provider) etag() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	result, err := p.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(p.key),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to head config object: %w", err)
+	}
+
+	return aws.ToString(result.ETag), nil
+}
@@ -0,0 +1,64 @@
+package configprovider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 serves one config object, answering requests for an unchanged
+// ETag with 304 Not Modified as S3 does, and counts full downloads.
+type fakeS3 struct {
+	body      string
+	etag      string
+	downloads int
+}
+
+func (f *fakeS3) GetObject(ctx context.Context, params *s3.GetObjectInput,
+	optFns ...func(*s3.Options),
+) (*s3.GetObjectOutput, error) {
+	if aws.ToString(params.IfNoneMatch) == f.etag {
+		return nil, &awshttp.ResponseError{ResponseError: &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusNotModified}},
+			Err:      errors.New("not modified"),
+		}}
+	}
+	f.downloads++
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(f.body)), ETag: aws.String(f.etag)}, nil
+}
+
+func (f *fakeS3) HeadObject(ctx context.Context, params *s3.HeadObjectInput,
+	optFns ...func(*s3.Options),
+) (*s3.HeadObjectOutput, error) {
+	return &s3.HeadObjectOutput{ETag: aws.String(f.etag)}, nil
+}
+
+func TestS3ProviderReusesUnchangedConfig(t *testing.T) {
+	client := &fakeS3{body: "version: \"1.0\"\nchannels: []\n", etag: `"v1"`}
+	provider := NewS3Provider(client, "config", "config.yaml", 0)
+
+	first, err := provider.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "1.0", first.Version())
+
+	again, err := provider.Load()
+	require.NoError(t, err)
+	assert.Same(t, first, again)
+	assert.Equal(t, 1, client.downloads)
+
+	client.body, client.etag = "version: \"2.0\"\nchannels: []\n", `"v2"`
+	changed, err := provider.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "2.0", changed.Version())
+	assert.Equal(t, 2, client.downloads)
+}
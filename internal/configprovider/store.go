@@ -0,0 +1,778 @@
+package configprovider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// defaultTemplates are used for channels stored without message templates.
+var defaultTemplates = map[string]string{
+	"reminder":       "Hey {{.UserName}}! 👋 Don't forget to submit your standup update for #{{.ChannelName}}",
+	"summary_header": "📊 Daily Standup Summary for {{.Date}}",
+	"user_completed": "✅ {{.UserName}} - submitted at {{.Time}}",
+	"user_missing":   "❌ {{.UserName}} - No update",
+}
+
+// ErrUnstorableQuestions is returned for channels whose questions need more
+// than their text, which is all the store keeps of them.
+var ErrUnstorableQuestions = errors.New("questions can't be stored")
+
+// StoreProviderOptions configures a StoreProvider.
+type StoreProviderOptions struct {
+	TeamID    string
+	TableName string
+	Region    string
+	// Seed optionally supplies bot settings, feature flags and initial
+	// channels. Channels saved in the store take precedence.
+	Seed     botconfig.Provider
+	Interval time.Duration
+}
+
+// StoreProvider loads configuration from the data store, so changes made at
+// runtime (e.g. via /standup-config) are the source of truth.
+type StoreProvider struct {
+	store store.Store
+	opts  StoreProviderOptions
+}
+
+// NewStoreProvider creates a provider backed by the workspace and channel
+// configs saved in the store.
+func NewStoreProvider(dataStore store.Store, opts StoreProviderOptions) *StoreProvider {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultPollInterval
+	}
+
+	return &StoreProvider{store: dataStore, opts: opts}
+}
+
+// Seed saves seed channels that don't exist in the store yet. Channels with
+// questions the store can't hold aren't saved, and are reported with
+// ErrUnstorableQuestions once the others are.
+func (p *StoreProvider) Seed(ctx context.Context) error {
+	if p.opts.Seed == nil {
+		return nil
+	}
+
+	seedCfg, err := p.opts.Seed.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load seed config: %w", err)
+	}
+
+	existing, err := p.store.ListChannelConfigs(ctx, p.opts.TeamID)
+	if err != nil {
+		return fmt.Errorf("failed to list channel configs: %w", err)
+	}
+
+	stored := make(map[string]bool, len(existing))
+	for _, ch := range existing {
+		stored[ch.ChannelID] = true
+	}
+
+	var unstorable []error
+	for _, ch := range seedCfg.Channels() {
+		if stored[ch.ID()] {
+			continue
+		}
+		config, err := toStoreChannelConfig(p.opts.TeamID, ch)
+		if err != nil {
+			unstorable = append(unstorable, fmt.Errorf("channel %s: %w", ch.ID(), err))
+			continue
+		}
+		if err := p.store.SaveChannelConfig(ctx, config); err != nil {
+			return fmt.Errorf("failed to seed channel %s: %w", ch.ID(), err)
+		}
+	}
+
+	return errors.Join(unstorable...)
+}
+
+// Load builds the configuration from the store, layered over the seed.
+func (p *StoreProvider) Load() (botconfig.Config, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	cfg, err := p.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (p *StoreProvider) load(ctx context.Context) (*storeConfig, error) {
+	cfg := &storeConfig{
+		provider:  p,
+		version:   "dynamodb",
+		tableName: p.opts.TableName,
+		region:    p.opts.Region,
+		channels:  make(map[string]botconfig.ChannelConfig),
+	}
+
+	if p.opts.Seed != nil {
+		seedCfg, err := p.opts.Seed.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load seed config: %w", err)
+		}
+
+		cfg.version = seedCfg.Version()
+		cfg.botToken = seedCfg.BotToken()
+		cfg.appToken = seedCfg.AppToken()
+		if cfg.tableName == "" {
+			cfg.tableName = seedCfg.DatabaseTable()
+		}
+		if cfg.region == "" {
+			cfg.region = seedCfg.DatabaseRegion()
+		}
+		for _, ch := range seedCfg.Channels() {
+			cfg.channels[ch.ID()] = ch
+		}
+		cfg.integrations = seedCfg.Integrations()
+		cfg.seed = seedCfg
+	}
+
+	workspace, err := p.store.GetWorkspaceConfig(ctx, p.opts.TeamID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get workspace config: %w", err)
+	}
+	org, err := p.enterpriseInstall(ctx, workspace)
+	if err != nil {
+		return nil, err
+	}
+	if workspace != nil {
+		if workspace.BotToken != "" {
+			cfg.botToken = workspace.BotToken
+			cfg.workspaceToken = workspace.BotToken
+		} else if org != nil && org.BotToken != "" {
+			cfg.botToken = org.BotToken
+			cfg.workspaceToken = org.BotToken
+		}
+		if workspace.AppToken != "" {
+			cfg.appToken = workspace.AppToken
+		}
+		if jira := workspace.Integrations.Jira; jira != nil {
+			cfg.integrations.Jira = &botconfig.JiraIntegration{
+				BaseURL:  strings.TrimSuffix(jira.BaseURL, "/"),
+				Email:    jira.Email,
+				APIToken: jira.APIToken,
+				Projects: jira.Projects,
+				Comment:  jira.Comment,
+			}
+		}
+		if github := workspace.Integrations.GitHub; github != nil {
+			cfg.integrations.GitHub = &botconfig.GitHubIntegration{
+				Token: github.Token,
+				Repos: github.Repos,
+				Users: github.Users,
+			}
+		}
+		if calendar := workspace.Integrations.Calendar; calendar != nil {
+			cfg.integrations.Calendar = &botconfig.CalendarIntegration{
+				Credentials: calendar.Credentials,
+				SyncTime:    calendar.SyncTime,
+			}
+			if calendar.SyncTime == "" {
+				cfg.integrations.Calendar.SyncTime = botconfig.DefaultCalendarSyncTime
+			}
+		}
+	}
+
+	channels, err := p.store.ListChannelConfigs(ctx, p.opts.TeamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channel configs: %w", err)
+	}
+	for _, ch := range channels {
+		channelCfg, err := fromStoreChannelConfig(ch)
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel config for %s: %w", ch.ChannelID, err)
+		}
+		cfg.channels[ch.ChannelID] = channelCfg
+	}
+
+	cfg.fingerprint, err = fingerprint(channels, workspace, org)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// enterpriseInstall returns the org-wide install of the Enterprise Grid a
+// workspace belongs to, whose bot token the workspace uses unless it has its
+// own. It returns nil for workspaces outside a grid or without an org install.
+func (p *StoreProvider) enterpriseInstall(ctx context.Context, workspace *store.WorkspaceConfig) (*store.WorkspaceConfig, error) {
+	if workspace == nil || workspace.EnterpriseID == "" || workspace.IsEnterpriseInstall {
+		return nil, nil
+	}
+
+	org, err := p.store.GetWorkspaceConfig(ctx, workspace.EnterpriseID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enterprise install: %w", err)
+	}
+	if !org.IsEnterpriseInstall {
+		return nil, nil
+	}
+	return org, nil
+}
+
+// Watch polls the store and calls callback with the reloaded configuration
+// whenever the workspace or any channel config changes.
+func (p *StoreProvider) Watch(callback func(botconfig.Config)) error {
+	initial, err := p.load(context.Background())
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		last := initial.fingerprint
+
+		ticker := time.NewTicker(p.opts.Interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			cfg, err := p.load(ctx)
+			cancel()
+			if err != nil || cfg.fingerprint == last {
+				continue
+			}
+			last = cfg.fingerprint
+
+			callback(cfg)
+		}
+	}()
+
+	return nil
+}
+
+// fingerprint hashes the stored config, so any change to it is detected,
+// even one that leaves UpdatedAt alone.
+func fingerprint(channels []*store.ChannelConfig, workspaces ...*store.WorkspaceConfig) (string, error) {
+	sorted := slices.Clone(channels)
+	slices.SortFunc(sorted, func(a, b *store.ChannelConfig) int {
+		return strings.Compare(a.ChannelID, b.ChannelID)
+	})
+
+	data, err := json.Marshal(struct {
+		Channels   []*store.ChannelConfig
+		Workspaces []*store.WorkspaceConfig
+	}{sorted, workspaces})
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint config: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// toStoreChannelConfig converts a YAML channel into its stored form. It fails
+// for channels whose questions can't be stored.
+func toStoreChannelConfig(teamID string, ch botconfig.ChannelConfig) (*store.ChannelConfig, error) {
+	questions, err := storedQuestions(ch)
+	if err != nil {
+		return nil, err
+	}
+
+	reminderTimes := make([]string, 0, len(ch.ReminderTimes()))
+	for _, rt := range ch.ReminderTimes() {
+		reminderTimes = append(reminderTimes, rt.Format("15:04"))
+	}
+
+	var activeDays []string
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if ch.IsActiveDay(day) {
+			activeDays = append(activeDays, day.String()[:3])
+		}
+	}
+
+	users := make([]string, 0, len(ch.Users()))
+	var userActiveDays map[string][]string
+	var userTags map[string]map[string]string
+	for _, u := range ch.Users() {
+		users = append(users, u.ID())
+		if len(u.Tags()) > 0 {
+			if userTags == nil {
+				userTags = make(map[string]map[string]string)
+			}
+			userTags[u.ID()] = u.Tags()
+		}
+
+		var workingDays []string
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if u.IsWorkingDay(day) {
+				workingDays = append(workingDays, day.String()[:3])
+			}
+		}
+		if len(workingDays) < 7 {
+			if userActiveDays == nil {
+				userActiveDays = make(map[string][]string)
+			}
+			userActiveDays[u.ID()] = workingDays
+		}
+	}
+
+	var holidays *store.HolidayCalendar
+	if h := ch.Holidays(); len(h.Dates) > 0 || h.ICSURL != "" {
+		holidays = &store.HolidayCalendar{Dates: h.Dates, ICSURL: h.ICSURL}
+	}
+
+	var participants *store.ParticipantsPolicy
+	if ch.Participants() == botconfig.ParticipantsChannelMembers || len(ch.ExcludedUsers()) > 0 {
+		participants = &store.ParticipantsPolicy{
+			ChannelMembers: ch.Participants() == botconfig.ParticipantsChannelMembers,
+			Exclude:        ch.ExcludedUsers(),
+		}
+	}
+
+	var escalation *store.EscalationPolicy
+	if e := ch.Escalation(); e != nil {
+		escalation = &store.EscalationPolicy{
+			AfterReminders: e.AfterReminders,
+			Action:         store.EscalationAction(e.Action),
+			ManagerID:      e.ManagerID,
+		}
+	}
+
+	weeklyDigest := toStoreDigest(ch.WeeklyDigest())
+	monthlyDigest := toStoreDigest(ch.MonthlyDigest())
+
+	tmpl := ch.Templates()
+
+	return &store.ChannelConfig{
+		TeamID:      teamID,
+		ChannelID:   ch.ID(),
+		ChannelName: ch.Name(),
+		Enabled:     ch.IsEnabled(),
+		Schedule: store.ScheduleConfig{
+			Timezone:       ch.Timezone().String(),
+			SummaryTime:    ch.SummaryTime().Format("15:04"),
+			ReminderTimes:  reminderTimes,
+			ActiveDays:     activeDays,
+			UserActiveDays: userActiveDays,
+			Holidays:       holidays,
+			WeeklyDigest:   weeklyDigest,
+			MonthlyDigest:  monthlyDigest,
+			Escalation:     escalation,
+			Participants:   participants,
+			Locale:         ch.Locale(),
+			ReviewAnswers:  ch.ReviewAnswers(),
+			Mentions:       string(ch.Mentions()),
+
+			SummaryRecipients: ch.SummaryRecipients(),
+			UserTags:          userTags,
+		},
+		Users:      users,
+		Admins:     ch.Admins(),
+		UserGroups: ch.UserGroups(),
+		Templates: map[string]string{
+			"reminder":       tmpl.Reminder(),
+			"summary_header": tmpl.SummaryHeader(),
+			"user_completed": tmpl.UserCompleted(),
+			"user_missing":   tmpl.UserMissing(),
+		},
+		Questions: questions,
+	}, nil
+}
+
+// toStoreDigest converts a YAML digest schedule into its stored form.
+func toStoreDigest(digest *botconfig.Digest) *store.DigestSchedule {
+	if digest == nil {
+		return nil
+	}
+	return &store.DigestSchedule{
+		Day:           digest.Day,
+		Time:          digest.Time.Format("15:04"),
+		TargetChannel: digest.TargetChannel,
+	}
+}
+
+// storedQuestions returns the text of a channel's questions, which is all the
+// store keeps of them. Questions that need more, e.g. a select question's
+// options, or questions that differ by weekday, are an error rather than
+// stored as plain text.
+func storedQuestions(ch botconfig.ChannelConfig) ([]string, error) {
+	questions := ch.TypedQuestions()
+	for _, q := range questions {
+		if !isPlainQuestion(q) {
+			return nil, fmt.Errorf("%w: %q isn't a required text question without an id, rules or tags",
+				ErrUnstorableQuestions, q.Text)
+		}
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if !slices.EqualFunc(ch.QuestionsFor(day), questions, func(a, b botconfig.Question) bool {
+			return a.Text == b.Text && isPlainQuestion(a)
+		}) {
+			return nil, fmt.Errorf("%w: the questions for %s differ from the other days'", ErrUnstorableQuestions, day)
+		}
+	}
+
+	texts := make([]string, 0, len(questions))
+	for _, q := range questions {
+		texts = append(texts, q.Text)
+	}
+	return texts, nil
+}
+
+// isPlainQuestion reports whether a question is required text with nothing
+// but its text set, so storing the text alone loses nothing.
+func isPlainQuestion(q botconfig.Question) bool {
+	return (q.Type == "" || q.Type == botconfig.QuestionText) && q.ID == "" &&
+		len(q.Options) == 0 && !q.Optional && q.ShowIf == nil &&
+		q.MinLength == 0 && q.MaxLength == 0 && q.Pattern == "" && len(q.Tags) == 0
+}
+
+// fromStoreChannelConfig adapts a stored channel config to config.ChannelConfig.
+func fromStoreChannelConfig(ch *store.ChannelConfig) (botconfig.ChannelConfig, error) {
+	tz, err := time.LoadLocation(ch.Schedule.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %s: %w", ch.Schedule.Timezone, err)
+	}
+
+	summaryTime, err := time.Parse("15:04", ch.Schedule.SummaryTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid summary time %s: %w", ch.Schedule.SummaryTime, err)
+	}
+
+	reminderTimes := make([]time.Time, 0, len(ch.Schedule.ReminderTimes))
+	for _, rt := range ch.Schedule.ReminderTimes {
+		t, err := time.Parse("15:04", rt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reminder time %s: %w", rt, err)
+		}
+		reminderTimes = append(reminderTimes, t)
+	}
+
+	activeDays := make(map[time.Weekday]bool)
+	for _, day := range ch.Schedule.ActiveDays {
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if weekday.String()[:3] == day {
+				activeDays[weekday] = true
+			}
+		}
+	}
+
+	templates := make(map[string]string, len(defaultTemplates))
+	for name, tmpl := range defaultTemplates {
+		templates[name] = tmpl
+	}
+	for name, tmpl := range ch.Templates {
+		if tmpl != "" {
+			templates[name] = tmpl
+		}
+	}
+
+	questions := make([]botconfig.Question, 0, len(ch.Questions))
+	for _, q := range ch.Questions {
+		questions = append(questions, botconfig.Question{Text: q, Type: botconfig.QuestionText})
+	}
+
+	weeklyDigest, err := fromStoreDigest("weekly", ch.Schedule.WeeklyDigest)
+	if err != nil {
+		return nil, err
+	}
+	monthlyDigest, err := fromStoreDigest("monthly", ch.Schedule.MonthlyDigest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &channelConfig{
+		stored:        ch,
+		timezone:      tz,
+		summaryTime:   summaryTime,
+		reminderTimes: reminderTimes,
+		activeDays:    activeDays,
+		templates:     templateConfig(templates),
+		questions:     questions,
+		weeklyDigest:  weeklyDigest,
+		monthlyDigest: monthlyDigest,
+	}, nil
+}
+
+// fromStoreDigest converts a stored digest schedule, if there is one.
+func fromStoreDigest(period string, schedule *store.DigestSchedule) (*botconfig.Digest, error) {
+	if schedule == nil {
+		return nil, nil
+	}
+
+	digestTime, err := time.Parse("15:04", schedule.Time)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s digest time %s: %w", period, schedule.Time, err)
+	}
+
+	return &botconfig.Digest{Day: schedule.Day, Time: digestTime, TargetChannel: schedule.TargetChannel}, nil
+}
+
+// storeConfig implements config.Config on top of stored configuration.
+type storeConfig struct {
+	mu       sync.RWMutex
+	provider *StoreProvider
+	seed     botconfig.Config
+	version  string
+	botToken string
+	// workspaceToken is the bot token saved for the workspace, or for the
+	// org-wide install of its Enterprise Grid, if any
+	workspaceToken string
+	appToken       string
+	tableName      string
+	region         string
+	channels       map[string]botconfig.ChannelConfig
+	integrations   botconfig.Integrations
+	fingerprint    string
+}
+
+func (c *storeConfig) Version() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.version
+}
+
+func (c *storeConfig) BotToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.botToken
+}
+
+// storedToken returns the bot token saved for the workspace, without falling
+// back to the seed's.
+func (c *storeConfig) storedToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.workspaceToken
+}
+
+func (c *storeConfig) AppToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.appToken
+}
+
+func (c *storeConfig) DatabaseTable() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tableName
+}
+
+func (c *storeConfig) DatabaseRegion() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.region
+}
+
+func (c *storeConfig) Channels() []botconfig.ChannelConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	channels := make([]botconfig.ChannelConfig, 0, len(c.channels))
+	for _, ch := range c.channels {
+		channels = append(channels, ch)
+	}
+	return channels
+}
+
+func (c *storeConfig) ChannelByID(id string) (botconfig.ChannelConfig, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ch, ok := c.channels[id]
+	return ch, ok
+}
+
+func (c *storeConfig) IsFeatureEnabled(feature string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	// Feature flags aren't stored per workspace yet, so they come from the seed
+	if c.seed == nil {
+		return false
+	}
+	return c.seed.IsFeatureEnabled(feature)
+}
+
+func (c *storeConfig) BlockersChannel() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	// Like feature flags, the blockers channel comes from the seed
+	if c.seed == nil {
+		return ""
+	}
+	return c.seed.BlockersChannel()
+}
+
+func (c *storeConfig) SummaryGroupBy() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.seed == nil {
+		return botconfig.SummaryByUser
+	}
+	return c.seed.SummaryGroupBy()
+}
+
+// Integrations returns the workspace's stored integrations, falling back to
+// the seed's for those it doesn't configure.
+func (c *storeConfig) Integrations() botconfig.Integrations {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.integrations
+}
+
+func (c *storeConfig) Reload() error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	fresh, err := c.provider.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.seed = fresh.seed
+	c.version = fresh.version
+	c.botToken = fresh.botToken
+	c.workspaceToken = fresh.workspaceToken
+	c.appToken = fresh.appToken
+	c.tableName = fresh.tableName
+	c.region = fresh.region
+	c.channels = fresh.channels
+	c.integrations = fresh.integrations
+	c.fingerprint = fresh.fingerprint
+
+	return nil
+}
+
+// channelConfig implements config.ChannelConfig for a stored channel.
+type channelConfig struct {
+	stored        *store.ChannelConfig
+	timezone      *time.Location
+	summaryTime   time.Time
+	reminderTimes []time.Time
+	activeDays    map[time.Weekday]bool
+	templates     templateConfig
+	questions     []botconfig.Question
+	weeklyDigest  *botconfig.Digest
+	monthlyDigest *botconfig.Digest
+}
+
+func (c *channelConfig) ID() string                                     { return c.stored.ChannelID }
+func (c *channelConfig) Name() string                                   { return c.stored.ChannelName }
+func (c *channelConfig) IsEnabled() bool                                { return c.stored.Active() }
+func (c *channelConfig) Timezone() *time.Location                       { return c.timezone }
+func (c *channelConfig) SummaryTime() time.Time                         { return c.summaryTime }
+func (c *channelConfig) ReminderTimes() []time.Time                     { return c.reminderTimes }
+func (c *channelConfig) IsActiveDay(day time.Weekday) bool              { return c.activeDays[day] }
+func (c *channelConfig) Templates() botconfig.TemplateConfig            { return c.templates }
+func (c *channelConfig) TypedQuestions() []botconfig.Question           { return c.questions }
+func (c *channelConfig) Questions() []string                            { return c.stored.Questions }
+func (c *channelConfig) Admins() []string                               { return c.stored.Admins }
+func (c *channelConfig) UserGroups() []string                           { return c.stored.UserGroups }
+func (c *channelConfig) Locale() string                                 { return c.stored.Schedule.Locale }
+func (c *channelConfig) ReviewAnswers() bool                            { return c.stored.Schedule.ReviewAnswers }
+func (c *channelConfig) SummaryRecipients() []string                    { return c.stored.Schedule.SummaryRecipients }
+func (c *channelConfig) QuestionsFor(time.Weekday) []botconfig.Question { return c.questions }
+func (c *channelConfig) IsAdmin(userID string) bool                     { return slices.Contains(c.stored.Admins, userID) }
+func (c *channelConfig) WeeklyDigest() *botconfig.Digest                { return c.weeklyDigest }
+func (c *channelConfig) MonthlyDigest() *botconfig.Digest               { return c.monthlyDigest }
+
+func (c *channelConfig) Mentions() botconfig.MentionStyle {
+	if mentions := c.stored.Schedule.Mentions; mentions != "" {
+		return botconfig.MentionStyle(mentions)
+	}
+	return botconfig.MentionsMention
+}
+
+func (c *channelConfig) Participants() botconfig.Participants {
+	if policy := c.stored.Schedule.Participants; policy != nil && policy.ChannelMembers {
+		return botconfig.ParticipantsChannelMembers
+	}
+	return botconfig.ParticipantsUsers
+}
+
+func (c *channelConfig) ExcludedUsers() []string {
+	if policy := c.stored.Schedule.Participants; policy != nil {
+		return policy.Exclude
+	}
+	return nil
+}
+
+func (c *channelConfig) Holidays() botconfig.Holidays {
+	if c.stored.Schedule.Holidays == nil {
+		return botconfig.Holidays{}
+	}
+	return botconfig.Holidays{
+		Dates:  c.stored.Schedule.Holidays.Dates,
+		ICSURL: c.stored.Schedule.Holidays.ICSURL,
+	}
+}
+
+func (c *channelConfig) Escalation() *botconfig.Escalation {
+	policy := c.stored.Schedule.Escalation
+	if policy == nil {
+		return nil
+	}
+	return &botconfig.Escalation{
+		AfterReminders: policy.AfterReminders,
+		Action:         botconfig.EscalationAction(policy.Action),
+		ManagerID:      policy.ManagerID,
+	}
+}
+
+func (c *channelConfig) UserByID(id string) (botconfig.UserConfig, bool) {
+	if slices.Contains(c.stored.Users, id) && !slices.Contains(c.stored.DeactivatedUsers, id) {
+		return userConfig{id: id, schedule: &c.stored.Schedule}, true
+	}
+	return nil, false
+}
+
+// Users leaves out deactivated users, so they aren't reminded or reported
+// missing.
+func (c *channelConfig) Users() []botconfig.UserConfig {
+	users := make([]botconfig.UserConfig, 0, len(c.stored.Users))
+	for _, userID := range c.stored.Users {
+		if !slices.Contains(c.stored.DeactivatedUsers, userID) {
+			users = append(users, userConfig{id: userID, schedule: &c.stored.Schedule})
+		}
+	}
+	return users
+}
+
+func (c *channelConfig) IsUserRequired(userID string) bool {
+	_, ok := c.UserByID(userID)
+	return ok
+}
+
+// userConfig implements config.UserConfig for a stored user ID. Names and
+// timezones aren't stored, so the ID doubles as the name.
+type userConfig struct {
+	id       string
+	schedule *store.ScheduleConfig
+}
+
+func (u userConfig) ID() string               { return u.id }
+func (u userConfig) Name() string             { return u.id }
+func (u userConfig) Timezone() *time.Location { return nil }
+func (u userConfig) Tags() map[string]string  { return u.schedule.UserTags[u.id] }
+
+func (u userConfig) IsWorkingDay(day time.Weekday) bool {
+	return u.schedule.IsWorkingDay(u.id, day)
+}
+
+// templateConfig implements config.TemplateConfig from a stored template map.
+type templateConfig map[string]string
+
+func (t templateConfig) Reminder() string      { return t["reminder"] }
+func (t templateConfig) SummaryHeader() string { return t["summary_header"] }
+func (t templateConfig) UserCompleted() string { return t["user_completed"] }
+func (t templateConfig) UserMissing() string   { return t["user_missing"] }
@@ -0,0 +1,243 @@
+package configprovider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/store/memory"
+)
+
+const seedYAML = `version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    enabled: true
+    schedule:
+      timezone: "America/New_York"
+      summary_time: "09:00"
+      reminder_times: ["08:30", "08:50"]
+      active_days: ["Mon", "Wed", "Fri"]
+      escalation:
+        after_reminders: 2
+        action: "notify_manager"
+        manager_id: "U5555555555"
+      weekly_digest:
+        day: "Fri"
+        time: "17:00"
+        target_channel: "C5555555555"
+    users:
+      - id: "U1234567890"
+        name: "alice"
+      - id: "U0987654321"
+        name: "bob"
+        active_days: ["Mon", "Wed"]
+        tags:
+          project: "payments"
+    admins: ["U1234567890"]
+    summary_recipients: ["U5555555555"]
+    templates:
+      reminder: "Hi {{.UserName}} in #{{.ChannelName}}"
+      summary_header: "Summary {{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Yesterday?", "Today?"]
+features: {}
+`
+
+func TestChannelConfigRoundTrip(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(seedYAML), 0o644))
+
+	cfg, err := botconfig.NewYAMLProvider(configPath).Load()
+	require.NoError(t, err)
+
+	seed, ok := cfg.ChannelByID("C1234567890")
+	require.True(t, ok)
+
+	stored, err := toStoreChannelConfig("T1234567890", seed)
+	require.NoError(t, err)
+	assert.Equal(t, "T1234567890", stored.TeamID)
+	assert.Equal(t, []string{"Mon", "Wed", "Fri"}, stored.Schedule.ActiveDays)
+	assert.Equal(t, []string{"08:30", "08:50"}, stored.Schedule.ReminderTimes)
+	assert.ElementsMatch(t, []string{"U1234567890", "U0987654321"}, stored.Users)
+	assert.Equal(t, map[string][]string{"U0987654321": {"Mon", "Wed"}}, stored.Schedule.UserActiveDays)
+	assert.Equal(t, []string{"U5555555555"}, stored.Schedule.SummaryRecipients)
+	assert.Equal(t, map[string]map[string]string{"U0987654321": {"project": "payments"}}, stored.Schedule.UserTags)
+	assert.Equal(t, &store.EscalationPolicy{
+		AfterReminders: 2, Action: store.EscalateNotifyManager, ManagerID: "U5555555555",
+	}, stored.Schedule.Escalation)
+	assert.Equal(t, &store.DigestSchedule{Day: "Fri", Time: "17:00", TargetChannel: "C5555555555"},
+		stored.Schedule.WeeklyDigest)
+	assert.Nil(t, stored.Schedule.MonthlyDigest)
+
+	ch, err := fromStoreChannelConfig(stored)
+	require.NoError(t, err)
+
+	assert.Equal(t, seed.ID(), ch.ID())
+	assert.Equal(t, seed.Timezone().String(), ch.Timezone().String())
+	assert.Equal(t, seed.SummaryTime(), ch.SummaryTime())
+	assert.True(t, ch.IsActiveDay(time.Wednesday))
+	assert.False(t, ch.IsActiveDay(time.Tuesday))
+	assert.True(t, ch.IsUserRequired("U1234567890"))
+	bob, ok := ch.UserByID("U0987654321")
+	require.True(t, ok)
+	assert.True(t, bob.IsWorkingDay(time.Wednesday))
+	assert.False(t, bob.IsWorkingDay(time.Friday))
+	assert.Equal(t, "payments", bob.Tags()["project"])
+	assert.True(t, ch.IsAdmin("U1234567890"))
+	assert.Equal(t, []string{"U5555555555"}, ch.SummaryRecipients())
+	assert.Equal(t, seed.Escalation(), ch.Escalation())
+	assert.Equal(t, seed.WeeklyDigest(), ch.WeeklyDigest())
+	assert.Nil(t, ch.MonthlyDigest())
+	assert.Equal(t, seed.Templates().Reminder(), ch.Templates().Reminder())
+	assert.Equal(t, seed.Questions(), ch.Questions())
+}
+
+func TestFromStoreChannelConfigDefaultsTemplates(t *testing.T) {
+	ch, err := fromStoreChannelConfig(&store.ChannelConfig{
+		ChannelID: "C1234567890",
+		Schedule: store.ScheduleConfig{
+			Timezone:    "UTC",
+			SummaryTime: "09:00",
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, defaultTemplates["summary_header"], ch.Templates().SummaryHeader())
+}
+
+func TestFromStoreChannelConfigSkipsDeactivatedUsers(t *testing.T) {
+	ch, err := fromStoreChannelConfig(&store.ChannelConfig{
+		ChannelID: "C1234567890",
+		Schedule: store.ScheduleConfig{
+			Timezone:    "UTC",
+			SummaryTime: "09:00",
+		},
+		Users:            []string{"U1234567890", "U0987654321"},
+		DeactivatedUsers: []string{"U0987654321"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, ch.Users(), 1)
+	assert.Equal(t, "U1234567890", ch.Users()[0].ID())
+	assert.True(t, ch.IsUserRequired("U1234567890"))
+	assert.False(t, ch.IsUserRequired("U0987654321"))
+}
+
+func TestToStoreChannelConfigRejectsQuestionsItCantStore(t *testing.T) {
+	for name, questions := range map[string]string{
+		"select": `
+    questions:
+      - text: "Mood?"
+        type: "select"
+        options: ["Good", "Bad"]`,
+		"rules": `
+    questions:
+      - text: "Yesterday?"
+        min_length: 5`,
+		"optional": `
+    questions:
+      - text: "Anything else?"
+        optional: true`,
+		"day questions": `
+    questions: ["Yesterday?"]
+    day_questions:
+      Fri: ["Wins this week?"]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := botconfig.ParseYAML([]byte(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"` + questions + "\n"))
+			require.NoError(t, err)
+			ch, ok := cfg.ChannelByID("C1234567890")
+			require.True(t, ok)
+
+			_, err = toStoreChannelConfig("T1234567890", ch)
+			assert.ErrorIs(t, err, ErrUnstorableQuestions)
+		})
+	}
+}
+
+func TestSeedSkipsChannelsItCantStore(t *testing.T) {
+	ctx := context.Background()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+    questions: ["Yesterday?"]
+    day_questions:
+      Fri: ["Wins this week?"]
+  - id: "C0987654321"
+    name: "design"
+    schedule:
+      timezone: "UTC"
+      summary_time: "09:00"
+    questions: ["Yesterday?"]
+`), 0o644))
+
+	dataStore := memory.NewStore()
+	err := NewStoreProvider(dataStore, StoreProviderOptions{
+		TeamID: "T1234567890",
+		Seed:   botconfig.NewYAMLProvider(configPath),
+	}).Seed(ctx)
+	require.ErrorIs(t, err, ErrUnstorableQuestions)
+	assert.Contains(t, err.Error(), "C1234567890")
+
+	channels, err := dataStore.ListChannelConfigs(ctx, "T1234567890")
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	assert.Equal(t, "C0987654321", channels[0].ChannelID)
+}
+
+func TestFingerprint(t *testing.T) {
+	updatedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	channels := func() []*store.ChannelConfig {
+		return []*store.ChannelConfig{
+			{ChannelID: "C0000000001", Users: []string{"U1111111111"}, UpdatedAt: updatedAt},
+			{ChannelID: "C0000000002", UpdatedAt: updatedAt},
+		}
+	}
+	workspace := &store.WorkspaceConfig{TeamID: "T1234567890", BotToken: "xoxb-old"}
+
+	before, err := fingerprint(channels(), workspace)
+	require.NoError(t, err)
+
+	// Listing order doesn't matter
+	reversed := channels()
+	reversed[0], reversed[1] = reversed[1], reversed[0]
+	after, err := fingerprint(reversed, workspace)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	// Changes are detected even when UpdatedAt and the count stay the same
+	changed := channels()
+	changed[0].Users = []string{"U2222222222"}
+	after, err = fingerprint(changed, workspace)
+	require.NoError(t, err)
+	assert.NotEqual(t, before, after)
+
+	after, err = fingerprint(channels(), &store.WorkspaceConfig{TeamID: "T1234567890", BotToken: "xoxb-new"})
+	require.NoError(t, err)
+	assert.NotEqual(t, before, after)
+}
@@ -0,0 +1,310 @@
+package configprovider
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// TeamConfigs is implemented by configuration covering several Slack
+// workspaces. Code that knows which workspace a request belongs to should use
+// that team's configuration instead of the merged one.
+type TeamConfigs interface {
+	// TeamIDs returns the served workspaces in a stable order.
+	TeamIDs() []string
+
+	// ForTeam returns the configuration of a single workspace.
+	ForTeam(teamID string) (botconfig.Config, bool)
+}
+
+// TeamsProvider loads the stored configuration of several workspaces, so one
+// deployment can serve them all. Each team's channels and bot token come from
+// its own workspace and channel configs; the seed only supplies shared bot
+// settings and feature flags, since its channels can't be attributed to a team.
+type TeamsProvider struct {
+	teamIDs   []string
+	providers map[string]*StoreProvider
+	interval  time.Duration
+
+	mu      sync.RWMutex
+	current *teamsConfig
+}
+
+// NewTeamsProvider creates a provider for the given workspaces. The TeamID in
+// opts is ignored.
+func NewTeamsProvider(dataStore store.Store, teamIDs []string, opts StoreProviderOptions) *TeamsProvider {
+	if opts.Seed != nil {
+		opts.Seed = settingsOnly{opts.Seed}
+	}
+
+	p := &TeamsProvider{
+		providers: make(map[string]*StoreProvider, len(teamIDs)),
+		interval:  opts.Interval,
+	}
+	for _, teamID := range teamIDs {
+		if _, ok := p.providers[teamID]; ok {
+			continue
+		}
+		teamOpts := opts
+		teamOpts.TeamID = teamID
+		p.teamIDs = append(p.teamIDs, teamID)
+		p.providers[teamID] = NewStoreProvider(dataStore, teamOpts)
+	}
+	if p.interval <= 0 {
+		p.interval = DefaultPollInterval
+	}
+
+	return p
+}
+
+// ParseTeamIDs splits a comma-separated list of team IDs, e.g. the value of
+// SLACK_TEAM_IDS.
+func ParseTeamIDs(value string) []string {
+	var teamIDs []string
+	for _, teamID := range strings.Split(value, ",") {
+		if teamID = strings.TrimSpace(teamID); teamID != "" {
+			teamIDs = append(teamIDs, teamID)
+		}
+	}
+	return teamIDs
+}
+
+// Load builds the configuration of every workspace from the store.
+func (p *TeamsProvider) Load() (botconfig.Config, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	cfg, err := p.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (p *TeamsProvider) load(ctx context.Context) (*teamsConfig, error) {
+	cfg := &teamsConfig{
+		teamIDs: p.teamIDs,
+		teams:   make(map[string]*storeConfig, len(p.teamIDs)),
+	}
+
+	fingerprints := make([]string, 0, len(p.teamIDs))
+	for _, teamID := range p.teamIDs {
+		teamCfg, err := p.providers[teamID].load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		cfg.teams[teamID] = teamCfg
+		fingerprints = append(fingerprints, teamCfg.fingerprint)
+	}
+	cfg.fingerprint = strings.Join(fingerprints, ",")
+
+	p.mu.Lock()
+	p.current = cfg
+	p.mu.Unlock()
+
+	return cfg, nil
+}
+
+// Watch polls the store and calls callback with the reloaded configuration
+// whenever any workspace's configuration changes.
+func (p *TeamsProvider) Watch(callback func(botconfig.Config)) error {
+	initial, err := p.load(context.Background())
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		last := initial.fingerprint
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			cfg, err := p.load(ctx)
+			cancel()
+			if err != nil || cfg.fingerprint == last {
+				continue
+			}
+			last = cfg.fingerprint
+
+			callback(cfg)
+		}
+	}()
+
+	return nil
+}
+
+// TokenSource returns a token source that uses the bot token saved for the
+// workspace a request is scoped to (see store.TeamScope), falling back to
+// fallback for unscoped requests and workspaces without a stored token.
+func (p *TeamsProvider) TokenSource(fallback slack.TokenSource) slack.TokenSource {
+	return &teamTokenSource{provider: p, fallback: fallback}
+}
+
+// team returns the most recently loaded configuration of a workspace.
+func (p *TeamsProvider) team(teamID string) (*storeConfig, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.current == nil {
+		return nil, false
+	}
+	cfg, ok := p.current.teams[teamID]
+	return cfg, ok
+}
+
+// teamTokenSource implements slack.TokenSource for TeamsProvider.
+type teamTokenSource struct {
+	provider *TeamsProvider
+	fallback slack.TokenSource
+}
+
+func (s *teamTokenSource) Token(ctx context.Context) (string, error) {
+	if cfg, ok := s.provider.team(store.TeamScope(ctx)); ok {
+		if token := cfg.storedToken(); token != "" {
+			return token, nil
+		}
+	}
+	return s.fallback.Token(ctx)
+}
+
+// Refresh reloads the workspace's configuration, in case the app was
+// reinstalled with a new token since it was loaded.
+func (s *teamTokenSource) Refresh(ctx context.Context, stale string) (string, error) {
+	cfg, ok := s.provider.team(store.TeamScope(ctx))
+	if !ok || cfg.storedToken() == "" {
+		return s.fallback.Refresh(ctx, stale)
+	}
+
+	if cfg.storedToken() == stale {
+		if err := cfg.Reload(); err != nil {
+			return "", err
+		}
+	}
+	return cfg.storedToken(), nil
+}
+
+// teamsConfig implements config.Config and TeamConfigs over the stored
+// configuration of each workspace. Shared settings come from the seed, which
+// every team's configuration is layered over.
+type teamsConfig struct {
+	teamIDs     []string
+	teams       map[string]*storeConfig
+	fingerprint string
+}
+
+func (c *teamsConfig) TeamIDs() []string {
+	return c.teamIDs
+}
+
+func (c *teamsConfig) ForTeam(teamID string) (botconfig.Config, bool) {
+	cfg, ok := c.teams[teamID]
+	if !ok {
+		return nil, false
+	}
+	return cfg, true
+}
+
+// shared returns the configuration supplying settings common to all teams.
+func (c *teamsConfig) shared() *storeConfig {
+	if len(c.teamIDs) == 0 {
+		return &storeConfig{}
+	}
+	return c.teams[c.teamIDs[0]]
+}
+
+func (c *teamsConfig) Version() string        { return c.shared().Version() }
+func (c *teamsConfig) AppToken() string       { return c.shared().AppToken() }
+func (c *teamsConfig) DatabaseTable() string  { return c.shared().DatabaseTable() }
+func (c *teamsConfig) DatabaseRegion() string { return c.shared().DatabaseRegion() }
+
+// BotToken returns the seed's token; each workspace's own token is used for
+// requests scoped to it.
+func (c *teamsConfig) BotToken() string {
+	cfg := c.shared()
+	cfg.mu.RLock()
+	defer cfg.mu.RUnlock()
+
+	if cfg.seed == nil {
+		return cfg.botToken
+	}
+	return cfg.seed.BotToken()
+}
+
+// Channels returns the channels of every workspace. A channel shared between
+// workspaces is listed once.
+func (c *teamsConfig) Channels() []botconfig.ChannelConfig {
+	var channels []botconfig.ChannelConfig
+	seen := make(map[string]bool)
+	for _, teamID := range c.teamIDs {
+		for _, ch := range c.teams[teamID].Channels() {
+			if !seen[ch.ID()] {
+				seen[ch.ID()] = true
+				channels = append(channels, ch)
+			}
+		}
+	}
+	return channels
+}
+
+func (c *teamsConfig) ChannelByID(id string) (botconfig.ChannelConfig, bool) {
+	for _, teamID := range c.teamIDs {
+		if ch, ok := c.teams[teamID].ChannelByID(id); ok {
+			return ch, true
+		}
+	}
+	return nil, false
+}
+
+func (c *teamsConfig) IsFeatureEnabled(feature string) bool {
+	return c.shared().IsFeatureEnabled(feature)
+}
+
+func (c *teamsConfig) BlockersChannel() string {
+	return c.shared().BlockersChannel()
+}
+
+func (c *teamsConfig) Integrations() botconfig.Integrations {
+	return c.shared().Integrations()
+}
+
+func (c *teamsConfig) SummaryGroupBy() string {
+	return c.shared().SummaryGroupBy()
+}
+
+func (c *teamsConfig) Reload() error {
+	for _, teamID := range c.teamIDs {
+		if err := c.teams[teamID].Reload(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// settingsOnly hides the channels of a seed provider's configuration.
+type settingsOnly struct {
+	botconfig.Provider
+}
+
+func (p settingsOnly) Load() (botconfig.Config, error) {
+	cfg, err := p.Provider.Load()
+	if err != nil {
+		return nil, err
+	}
+	return withoutChannels{cfg}, nil
+}
+
+// withoutChannels is a configuration with its channels removed.
+type withoutChannels struct {
+	botconfig.Config
+}
+
+func (withoutChannels) Channels() []botconfig.ChannelConfig { return nil }
+
+func (withoutChannels) ChannelByID(string) (botconfig.ChannelConfig, bool) { return nil, false }
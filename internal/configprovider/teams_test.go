@@ -0,0 +1,101 @@
+package configprovider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/store/memory"
+)
+
+func TestTeamsProvider(t *testing.T) {
+	ctx := context.Background()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(seedYAML), 0o644))
+
+	dataStore := memory.NewStore()
+	require.NoError(t, dataStore.SaveWorkspaceConfig(ctx, &store.WorkspaceConfig{
+		TeamID: "T0000000001", BotToken: "xoxb-team-one",
+	}))
+	for _, ch := range []*store.ChannelConfig{
+		{TeamID: "T0000000001", ChannelID: "C0000000001", Enabled: true},
+		{TeamID: "T0000000002", ChannelID: "C0000000002", Enabled: true},
+	} {
+		ch.Schedule = store.ScheduleConfig{Timezone: "UTC", SummaryTime: "09:00"}
+		require.NoError(t, dataStore.SaveChannelConfig(ctx, ch))
+	}
+
+	provider := NewTeamsProvider(dataStore, ParseTeamIDs(" T0000000001, T0000000002,"), StoreProviderOptions{
+		Seed: botconfig.NewYAMLProvider(configPath),
+	})
+	cfg, err := provider.Load()
+	require.NoError(t, err)
+
+	teams, ok := cfg.(TeamConfigs)
+	require.True(t, ok)
+	assert.Equal(t, []string{"T0000000001", "T0000000002"}, teams.TeamIDs())
+
+	// Each workspace only sees its own channels; the seed's belong to neither
+	teamCfg, ok := teams.ForTeam("T0000000001")
+	require.True(t, ok)
+	_, found := teamCfg.ChannelByID("C0000000002")
+	assert.False(t, found)
+	_, found = cfg.ChannelByID("C1234567890")
+	assert.False(t, found)
+	assert.Len(t, cfg.Channels(), 2)
+
+	_, ok = teams.ForTeam("T0000000003")
+	assert.False(t, ok)
+
+	// Requests use the token saved for their workspace
+	assert.Equal(t, "xoxb-test", cfg.BotToken())
+	tokens := provider.TokenSource(slack.StaticTokenSource("xoxb-fallback"))
+
+	token, err := tokens.Token(context.WithValue(ctx, botcontext.TeamIDKey, "T0000000001"))
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-team-one", token)
+
+	token, err = tokens.Token(context.WithValue(ctx, botcontext.TeamIDKey, "T0000000002"))
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-fallback", token)
+
+	token, err = tokens.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-fallback", token)
+}
+
+func TestTeamsProviderEnterpriseInstall(t *testing.T) {
+	ctx := context.Background()
+	dataStore := memory.NewStore()
+	for _, workspace := range []*store.WorkspaceConfig{
+		{TeamID: "E0000000001", EnterpriseID: "E0000000001", IsEnterpriseInstall: true, BotToken: "xoxb-org"},
+		{TeamID: "T0000000001", EnterpriseID: "E0000000001"},
+		{TeamID: "T0000000002", EnterpriseID: "E0000000001", BotToken: "xoxb-team-two"},
+	} {
+		require.NoError(t, dataStore.SaveWorkspaceConfig(ctx, workspace))
+	}
+
+	provider := NewTeamsProvider(dataStore, []string{"T0000000001", "T0000000002", "T0000000003"}, StoreProviderOptions{})
+	_, err := provider.Load()
+	require.NoError(t, err)
+	tokens := provider.TokenSource(slack.StaticTokenSource("xoxb-fallback"))
+
+	// Workspaces of the grid use the org-wide install's token unless they have their own
+	for teamID, want := range map[string]string{
+		"T0000000001": "xoxb-org",
+		"T0000000002": "xoxb-team-two",
+		"T0000000003": "xoxb-fallback",
+	} {
+		token, err := tokens.Token(context.WithValue(ctx, botcontext.TeamIDKey, teamID))
+		require.NoError(t, err)
+		assert.Equal(t, want, token, teamID)
+	}
+}
@@ -0,0 +1,226 @@
+// Package gcal reads out-of-office events from Google Calendar with a
+// service account that has domain-wide delegation.
+package gcal
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/security"
+)
+
+const (
+	// DefaultBaseURL is the Google Calendar API.
+	DefaultBaseURL = "https://www.googleapis.com/calendar/v3"
+
+	// defaultTokenURI is used when the credentials don't name one.
+	defaultTokenURI = "https://oauth2.googleapis.com/token"
+
+	// scope only allows reading events.
+	scope = "https://www.googleapis.com/auth/calendar.events.readonly"
+)
+
+// credentials is the part of a service account key file the client needs.
+type credentials struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// Client reads users' calendars by impersonating them.
+type Client struct {
+	baseURL     string
+	clientEmail string
+	tokenURI    string
+	key         *rsa.PrivateKey
+	httpClient  *http.Client
+}
+
+// ClientOption is a function that modifies a client.
+type ClientOption func(*Client)
+
+// WithBaseURL sets the Calendar API URL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// NewClient creates a client from a service account's JSON key.
+func NewClient(credentialsJSON string, opts ...ClientOption) (*Client, error) {
+	var creds credentials
+	if err := json.Unmarshal([]byte(credentialsJSON), &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials: %w", err)
+	}
+	if creds.ClientEmail == "" || creds.PrivateKey == "" {
+		return nil, fmt.Errorf("credentials need client_email and private_key")
+	}
+	if creds.TokenURI == "" {
+		creds.TokenURI = defaultTokenURI
+	}
+
+	block, _ := pem.Decode([]byte(creds.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode private key")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not an RSA key")
+	}
+
+	c := &Client{
+		baseURL:     DefaultBaseURL,
+		clientEmail: creds.ClientEmail,
+		tokenURI:    creds.TokenURI,
+		key:         key,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// IsOutOfOffice reports whether the user with the given email has an
+// out-of-office event covering t in their primary calendar.
+func (c *Client) IsOutOfOffice(ctx context.Context, email string, t time.Time) (bool, error) {
+	token, err := c.accessToken(ctx, email)
+	if err != nil {
+		return false, err
+	}
+
+	query := url.Values{
+		"eventTypes":   {"outOfOffice"},
+		"singleEvents": {"true"},
+		"timeMin":      {t.UTC().Format(time.RFC3339)},
+		"timeMax":      {t.Add(time.Minute).UTC().Format(time.RFC3339)},
+		"maxResults":   {"1"},
+	}
+	endpoint := c.baseURL + "/calendars/" + url.PathEscape(email) + "/events?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, http.NoBody)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var result struct {
+		Items []struct {
+			Status string `json:"status"`
+		} `json:"items"`
+	}
+	if err := c.do(req, &result); err != nil {
+		return false, err
+	}
+
+	for _, event := range result.Items {
+		if event.Status != "cancelled" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// accessToken gets a token to read the calendar of the user with the given
+// email, signing the request with the service account's key.
+func (c *Client) accessToken(ctx context.Context, email string) (string, error) {
+	assertion, err := c.assertion(email)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := c.do(req, &result); err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	if result.AccessToken == "" {
+		return "", fmt.Errorf("failed to get access token: empty token")
+	}
+
+	return result.AccessToken, nil
+}
+
+// assertion builds the signed JWT exchanged for an access token.
+func (c *Client) assertion(email string) (string, error) {
+	now := time.Now()
+	header := map[string]string{"alg": "RS256", "typ": "JWT"}
+	claims := map[string]interface{}{
+		"iss":   c.clientEmail,
+		"sub":   email,
+		"scope": scope,
+		"aud":   c.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+
+	var parts []string
+	for _, part := range []interface{}{header, claims} {
+		data, err := json.Marshal(part)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal token: %w", err)
+		}
+		parts = append(parts, base64.RawURLEncoding.EncodeToString(data))
+	}
+
+	signingInput := strings.Join(parts, ".")
+	hash := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// do sends req and decodes the JSON response into v.
+func (c *Client) do(req *http.Request, v interface{}) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code: %d, body: %s",
+			resp.StatusCode, security.SanitizeLogValue(string(respBody)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
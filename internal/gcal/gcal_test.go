@@ -0,0 +1,87 @@
+package gcal
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsOutOfOffice(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			require.NoError(t, r.ParseForm())
+			parts := strings.Split(r.PostForm.Get("assertion"), ".")
+			require.Len(t, parts, 3)
+			signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+			require.NoError(t, err)
+			hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature))
+
+			claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+			require.NoError(t, err)
+			var decoded map[string]interface{}
+			require.NoError(t, json.Unmarshal(claims, &decoded))
+			assert.Equal(t, "bot@project.iam.gserviceaccount.com", decoded["iss"])
+			assert.Equal(t, server.URL+"/token", decoded["aud"])
+
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]string{"access_token": "token-" + decoded["sub"].(string)}))
+		case "/calendars/alice@example.com/events":
+			assert.Equal(t, "Bearer token-alice@example.com", r.Header.Get("Authorization"))
+			assert.Equal(t, "outOfOffice", r.URL.Query().Get("eventTypes"))
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"items": []map[string]string{{"status": "confirmed"}},
+			}))
+		case "/calendars/bob@example.com/events":
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"items": []interface{}{}}))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	creds, err := json.Marshal(map[string]string{
+		"client_email": "bot@project.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":    server.URL + "/token",
+	})
+	require.NoError(t, err)
+
+	client, err := NewClient(string(creds), WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	out, err := client.IsOutOfOffice(ctx, "alice@example.com", time.Now())
+	require.NoError(t, err)
+	assert.True(t, out)
+
+	out, err = client.IsOutOfOffice(ctx, "bob@example.com", time.Now())
+	require.NoError(t, err)
+	assert.False(t, out)
+
+	_, err = client.IsOutOfOffice(ctx, "carol@example.com", time.Now())
+	assert.ErrorContains(t, err, "404")
+
+	_, err = NewClient(`{"client_email": "bot@project.iam.gserviceaccount.com"}`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,150 @@
+// Package github reads users' recent activity from the GitHub REST API.
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/security"
+)
+
+// DefaultBaseURL is the API of github.com.
+const DefaultBaseURL = "https://api.github.com"
+
+// maxResults caps how many pull requests or commits are read per request.
+const maxResults = 50
+
+// Item is a merged pull request or a commit.
+type Item struct {
+	Repo  string // owner/name
+	Title string // Pull request title or first line of the commit message
+	URL   string
+}
+
+// Client talks to the GitHub API.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// ClientOption is a function that modifies a client.
+type ClientOption func(*Client)
+
+// WithBaseURL sets the API URL, for GitHub Enterprise Server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// NewClient creates a client authenticating with token.
+func NewClient(token string, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL: DefaultBaseURL,
+		token:   token,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// MergedPullRequests returns the pull requests login authored in repos that
+// were merged since the given time.
+func (c *Client) MergedPullRequests(ctx context.Context, login string, repos []string, since time.Time) ([]Item, error) {
+	terms := []string{"is:pr", "is:merged", "author:" + login, "merged:>=" + since.UTC().Format(time.RFC3339)}
+	for _, repo := range repos {
+		terms = append(terms, "repo:"+repo)
+	}
+
+	query := url.Values{
+		"q":        {strings.Join(terms, " ")},
+		"per_page": {fmt.Sprint(maxResults)},
+	}
+
+	var result struct {
+		Items []struct {
+			Title         string `json:"title"`
+			HTMLURL       string `json:"html_url"`
+			RepositoryURL string `json:"repository_url"`
+		} `json:"items"`
+	}
+	if err := c.get(ctx, "/search/issues?"+query.Encode(), &result); err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0, len(result.Items))
+	for _, pr := range result.Items {
+		// repository_url is <api>/repos/owner/name
+		_, repo, _ := strings.Cut(pr.RepositoryURL, "/repos/")
+		items = append(items, Item{Repo: repo, Title: pr.Title, URL: pr.HTMLURL})
+	}
+	return items, nil
+}
+
+// Commits returns the commits login authored in repo since the given time,
+// newest first.
+func (c *Client) Commits(ctx context.Context, repo, login string, since time.Time) ([]Item, error) {
+	query := url.Values{
+		"author":   {login},
+		"since":    {since.UTC().Format(time.RFC3339)},
+		"per_page": {fmt.Sprint(maxResults)},
+	}
+
+	var result []struct {
+		HTMLURL string `json:"html_url"`
+		Commit  struct {
+			Message string `json:"message"`
+		} `json:"commit"`
+	}
+	if err := c.get(ctx, "/repos/"+repo+"/commits?"+query.Encode(), &result); err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0, len(result))
+	for _, commit := range result {
+		title, _, _ := strings.Cut(commit.Commit.Message, "\n")
+		items = append(items, Item{Repo: repo, Title: title, URL: commit.HTMLURL})
+	}
+	return items, nil
+}
+
+// get fetches path and decodes the JSON response into v.
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code: %d, body: %s",
+			resp.StatusCode, security.SanitizeLogValue(string(respBody)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
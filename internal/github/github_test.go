@@ -0,0 +1,68 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergedPullRequests(t *testing.T) {
+	since := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/search/issues", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t,
+			"is:pr is:merged author:octocat merged:>=2024-03-04T00:00:00Z repo:acme/api repo:acme/web",
+			r.URL.Query().Get("q"))
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"items": []map[string]string{{
+				"title":          "Add rate limiting",
+				"html_url":       "https://github.com/acme/api/pull/42",
+				"repository_url": "https://api.github.com/repos/acme/api",
+			}},
+		}))
+	}))
+	defer server.Close()
+
+	c := NewClient("secret", WithBaseURL(server.URL+"/"))
+	items, err := c.MergedPullRequests(context.Background(), "octocat", []string{"acme/api", "acme/web"}, since)
+	require.NoError(t, err)
+	assert.Equal(t, []Item{{
+		Repo:  "acme/api",
+		Title: "Add rate limiting",
+		URL:   "https://github.com/acme/api/pull/42",
+	}}, items)
+}
+
+func TestCommits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/acme/api/commits", r.URL.Path)
+		assert.Equal(t, "octocat", r.URL.Query().Get("author"))
+		assert.NoError(t, json.NewEncoder(w).Encode([]map[string]interface{}{{
+			"html_url": "https://github.com/acme/api/commit/abc123",
+			"commit":   map[string]string{"message": "Fix flaky test\n\nIt raced with the cleanup."},
+		}}))
+	}))
+	defer server.Close()
+
+	items, err := NewClient("secret", WithBaseURL(server.URL)).
+		Commits(context.Background(), "acme/api", "octocat", time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Fix flaky test", items[0].Title)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+	}))
+	defer failing.Close()
+
+	_, err = NewClient("wrong", WithBaseURL(failing.URL)).
+		Commits(context.Background(), "acme/api", "octocat", time.Now())
+	assert.ErrorContains(t, err, "401")
+}
@@ -0,0 +1,277 @@
+// Package holiday decides whether a channel's standup falls on a holiday.
+package holiday
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+const (
+	// DefaultRefreshInterval is how long a fetched holiday feed is reused.
+	DefaultRefreshInterval = 12 * time.Hour
+
+	// maxFeedSize caps how much of a feed is read.
+	maxFeedSize = 1 << 20
+
+	// maxEventDays caps how many days a single event can cover.
+	maxEventDays = 31
+)
+
+// ErrMalformedEvents is returned, along with the dates of the other events,
+// for feeds with events whose dates can't be read.
+var ErrMalformedEvents = errors.New("malformed holiday events")
+
+// Calendar checks holidays, caching ICS feeds between checks.
+type Calendar struct {
+	client  *http.Client
+	refresh time.Duration
+	now     func() time.Time
+
+	mu       sync.Mutex
+	feeds    map[string]*feed
+	fetching map[string]*fetchCall // Fetches in progress by URL
+}
+
+type feed struct {
+	dates     map[string]bool
+	fetchedAt time.Time
+}
+
+// fetchCall is a fetch of a feed that other checks of it wait for.
+type fetchCall struct {
+	done  chan struct{} // Closed once dates and err are set
+	dates map[string]bool
+	err   error
+}
+
+// NewCalendar creates a calendar. A nil client uses one with a 10 second timeout.
+func NewCalendar(client *http.Client, refresh time.Duration) *Calendar {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if refresh <= 0 {
+		refresh = DefaultRefreshInterval
+	}
+
+	return &Calendar{
+		client:   client,
+		refresh:  refresh,
+		now:      time.Now,
+		feeds:    make(map[string]*feed),
+		fetching: make(map[string]*fetchCall),
+	}
+}
+
+// IsHoliday reports whether date (YYYY-MM-DD) is a holiday in cal. If the
+// feed cannot be fetched, the last fetched copy is used; without one, only
+// the explicit dates are checked and the fetch error is returned too. When a
+// fetch skips malformed events, the result comes with ErrMalformedEvents.
+func (c *Calendar) IsHoliday(ctx context.Context, cal *store.HolidayCalendar, date string) (bool, error) {
+	if cal == nil {
+		return false, nil
+	}
+
+	for _, holiday := range cal.Dates {
+		if holiday == date {
+			return true, nil
+		}
+	}
+
+	if cal.ICSURL == "" {
+		return false, nil
+	}
+
+	dates, err := c.feedDates(ctx, cal.ICSURL)
+	return dates[date], err
+}
+
+// feedDates returns the holidays in a feed, fetching it when the cached
+// copy is missing or older than the refresh interval. Feeds are fetched
+// without holding the lock, once however many checks need them; checks with
+// a cached copy use it meanwhile.
+func (c *Calendar) feedDates(ctx context.Context, url string) (map[string]bool, error) {
+	c.mu.Lock()
+	cached := c.feeds[url]
+	if cached != nil && c.now().Sub(cached.fetchedAt) < c.refresh {
+		c.mu.Unlock()
+		return cached.dates, nil
+	}
+	call, fetching := c.fetching[url]
+	if fetching && cached != nil {
+		c.mu.Unlock()
+		return cached.dates, nil
+	}
+	if !fetching {
+		call = &fetchCall{done: make(chan struct{})}
+		c.fetching[url] = call
+	}
+	c.mu.Unlock()
+
+	if fetching {
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	} else {
+		call.dates, call.err = c.fetch(ctx, url)
+
+		c.mu.Lock()
+		if call.dates != nil {
+			c.feeds[url] = &feed{dates: call.dates, fetchedAt: c.now()}
+		}
+		delete(c.fetching, url)
+		c.mu.Unlock()
+		close(call.done)
+	}
+
+	if call.dates == nil && cached != nil {
+		return cached.dates, nil
+	}
+	return call.dates, call.err
+}
+
+func (c *Calendar) fetch(ctx context.Context, url string) (map[string]bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch holiday feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code fetching holiday feed: %d", resp.StatusCode)
+	}
+
+	dates, err := ParseICS(io.LimitReader(resp.Body, maxFeedSize))
+	if errors.Is(err, ErrMalformedEvents) {
+		return dates, fmt.Errorf("holiday feed %s: %w", security.SanitizeLogValue(url), err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse holiday feed %s: %w", security.SanitizeLogValue(url), err)
+	}
+
+	return dates, nil
+}
+
+// ParseICS returns the dates (YYYY-MM-DD) covered by the events in an
+// iCalendar feed. All-day events cover every day up to their exclusive end
+// date; timed events cover the day they start. Recurrence rules are not
+// expanded. Events whose dates can't be read are skipped, and reported with
+// ErrMalformedEvents once the others are parsed.
+func ParseICS(r io.Reader) (map[string]bool, error) {
+	dates := make(map[string]bool)
+
+	var (
+		inEvent    bool
+		start, end string
+		malformed  []error
+	)
+
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		// Drop parameters such as ";VALUE=DATE"
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";")
+
+		switch name {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, start, end = true, "", ""
+			}
+		case "DTSTART":
+			start = value
+		case "DTEND":
+			end = value
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+
+			days, err := eventDays(start, end)
+			if err != nil {
+				malformed = append(malformed, err)
+				continue
+			}
+			for _, day := range days {
+				dates[day] = true
+			}
+		}
+	}
+
+	if len(malformed) > 0 {
+		return dates, fmt.Errorf("%w: skipped %d, the first with %w", ErrMalformedEvents, len(malformed), malformed[0])
+	}
+	return dates, nil
+}
+
+// eventDays lists the days an event covers.
+func eventDays(start, end string) ([]string, error) {
+	if len(start) < 8 {
+		return nil, fmt.Errorf("invalid event start %q", start)
+	}
+
+	first, err := time.Parse("20060102", start[:8])
+	if err != nil {
+		return nil, fmt.Errorf("invalid event start %q", start)
+	}
+
+	// Timed events and events without an end cover a single day
+	if len(start) > 8 || len(end) < 8 {
+		return []string{first.Format("2006-01-02")}, nil
+	}
+
+	last, err := time.Parse("20060102", end[:8])
+	if err != nil {
+		return nil, fmt.Errorf("invalid event end %q", end)
+	}
+
+	var days []string
+	for day := first; day.Before(last) && len(days) < maxEventDays; day = day.AddDate(0, 0, 1) {
+		days = append(days, day.Format("2006-01-02"))
+	}
+	if len(days) == 0 {
+		days = append(days, first.Format("2006-01-02"))
+	}
+
+	return days, nil
+}
+
+// unfold joins iCalendar content lines that continue on the next line.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
@@ -0,0 +1,148 @@
+package holiday
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+const testFeed = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20241225\r\n" +
+	"DTEND;VALUE=DATE:20241226\r\n" +
+	"SUMMARY:Christmas Day\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20241230\r\n" +
+	"DTEND;VALUE=DATE:20250102\r\n" +
+	"SUMMARY:Company shutdown, folded\r\n" +
+	"  across lines\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20240704T000000Z\r\n" +
+	"SUMMARY:Independence Day\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICS(t *testing.T) {
+	dates, err := ParseICS(strings.NewReader(testFeed))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]bool{
+		"2024-07-04": true,
+		"2024-12-25": true,
+		"2024-12-30": true,
+		"2024-12-31": true,
+		"2025-01-01": true,
+	}, dates)
+
+	// Malformed events are skipped rather than failing the feed
+	dates, err = ParseICS(strings.NewReader("BEGIN:VEVENT\nDTSTART:soon\nEND:VEVENT\n" +
+		"BEGIN:VEVENT\nDTSTART;VALUE=DATE:20241225\nEND:VEVENT\n"))
+	assert.ErrorIs(t, err, ErrMalformedEvents)
+	assert.Equal(t, map[string]bool{"2024-12-25": true}, dates)
+}
+
+func TestCalendarIsHoliday(t *testing.T) {
+	fetches := 0
+	available := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(testFeed))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	calendar := NewCalendar(server.Client(), time.Hour)
+	now := time.Date(2024, 12, 1, 9, 0, 0, 0, time.UTC)
+	calendar.now = func() time.Time { return now }
+
+	cal := &store.HolidayCalendar{Dates: []string{"2024-11-28"}, ICSURL: server.URL}
+
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{date: "2024-11-28", want: true}, // Explicit date
+		{date: "2024-12-25", want: true}, // From the feed
+		{date: "2024-12-24", want: false},
+	}
+	for _, tt := range tests {
+		got, err := calendar.IsHoliday(ctx, cal, tt.date)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.date)
+	}
+	assert.Equal(t, 1, fetches)
+
+	// A failed refresh keeps the last copy of the feed
+	available = false
+	now = now.Add(2 * time.Hour)
+	got, err := calendar.IsHoliday(ctx, cal, "2024-12-31")
+	require.NoError(t, err)
+	assert.True(t, got)
+	assert.Equal(t, 2, fetches)
+
+	// Without a cached copy the error is returned
+	got, err = NewCalendar(server.Client(), time.Hour).IsHoliday(ctx, cal, "2024-12-31")
+	assert.Error(t, err)
+	assert.False(t, got)
+
+	got, err = calendar.IsHoliday(ctx, nil, "2024-12-25")
+	assert.NoError(t, err)
+	assert.False(t, got)
+}
+
+func TestCalendarFetchesOutsideLock(t *testing.T) {
+	release := make(chan struct{})
+	var slowFetches atomic.Int32
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slowFetches.Add(1)
+		<-release
+		_, _ = w.Write([]byte(testFeed))
+	}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testFeed))
+	}))
+	defer fast.Close()
+
+	ctx := context.Background()
+	calendar := NewCalendar(nil, time.Hour)
+
+	// Checks of the slow feed share one fetch
+	var wg sync.WaitGroup
+	results := make([]bool, 3)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = calendar.IsHoliday(ctx, &store.HolidayCalendar{ICSURL: slow.URL}, "2024-12-25")
+		}()
+	}
+	require.Eventually(t, func() bool { return slowFetches.Load() == 1 }, time.Second, time.Millisecond)
+
+	// Other feeds aren't held up meanwhile
+	got, err := calendar.IsHoliday(ctx, &store.HolidayCalendar{ICSURL: fast.URL}, "2024-12-25")
+	require.NoError(t, err)
+	assert.True(t, got)
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, []bool{true, true, true}, results)
+	assert.EqualValues(t, 1, slowFetches.Load())
+}
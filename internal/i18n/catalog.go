@@ -0,0 +1,282 @@
+package i18n
+
+// Key identifies a message in the catalogs.
+type Key string
+
+// Reminder messages.
+const (
+	ReminderQuestions Key = "reminder.questions"
+	ReminderProgress  Key = "reminder.progress" // Submitted, total
+	ReminderSubmit    Key = "reminder.submit"
+	ReminderSkip      Key = "reminder.skip"
+	ReminderSnooze    Key = "reminder.snooze"
+	ReminderOpen      Key = "reminder.open" // Channel name
+)
+
+// Standup modal labels.
+const (
+	ModalTitle        Key = "modal.title"
+	ModalSubmit       Key = "modal.submit"
+	ModalHeader       Key = "modal.header"
+	ModalIntro        Key = "modal.intro"
+	ModalChoose       Key = "modal.choose"
+	ModalYes          Key = "modal.yes"
+	ModalNo           Key = "modal.no"
+	ModalSelectDate   Key = "modal.select_date"
+	ModalAnswerPrompt Key = "modal.answer_prompt"
+	ModalReview       Key = "modal.review"
+	ModalNoAnswer     Key = "modal.no_answer"
+	ModalEdit         Key = "modal.edit"
+	ModalPlan         Key = "modal.plan" // Date of the earlier standup
+)
+
+// Errors shown next to standup answers that break a question's rules.
+const (
+	AnswerRequired Key = "answer.required"
+	AnswerTooShort Key = "answer.too_short" // Minimum characters
+	AnswerTooLong  Key = "answer.too_long"  // Maximum characters
+	AnswerMismatch Key = "answer.mismatch"
+)
+
+// Summary messages.
+const (
+	SummaryEmpty     Key = "summary.empty"
+	SummarySubmitted Key = "summary.submitted"
+	SummarySkipped   Key = "summary.skipped"
+	SummaryPending   Key = "summary.pending"
+	SummaryMore      Key = "summary.more"    // Hidden sections
+	SummaryThread    Key = "summary.thread"  // Thread link
+	SummaryStreaks   Key = "summary.streaks" // Milestones reached
+	SummaryStreak    Key = "summary.streak"  // User, days
+)
+
+// Ephemeral replies to commands.
+const (
+	ErrorNotConfigured     Key = "error.not_configured"
+	ErrorStandupClosed     Key = "error.standup_closed"
+	ErrorStandupChanged    Key = "error.standup_changed"
+	ErrorOpenStandup       Key = "error.open_standup"
+	ErrorSkip              Key = "error.skip"
+	ErrorForbidden         Key = "error.forbidden" // Command
+	ErrorCheckPermissions  Key = "error.check_permissions"
+	ErrorNotAllowed        Key = "error.not_allowed"
+	ErrorNotFound          Key = "error.not_found"
+	ErrorConflict          Key = "error.conflict"
+	ErrorNoAccess          Key = "error.no_access"
+	ErrorUnavailable       Key = "error.unavailable"
+	ErrorInternal          Key = "error.internal" // Reference
+	SkippedToday           Key = "skip.done"
+	SkippedTodayWithReason Key = "skip.done_reason" // Reason
+)
+
+// catalogs are the messages of each locale.
+var catalogs = map[Locale]map[Key]string{
+	English: {
+		ReminderQuestions: "*Today's questions:*",
+		ReminderProgress:  "👥 *%d of %d* submitted so far",
+		ReminderSubmit:    "Submit now",
+		ReminderSkip:      "Skip today",
+		ReminderSnooze:    "Snooze 1h",
+		ReminderOpen:      "Open #%s",
+
+		ModalTitle:        "Daily Standup",
+		ModalSubmit:       "Submit",
+		ModalHeader:       "📝 Daily Standup Update",
+		ModalIntro:        "Please answer the following questions:",
+		ModalChoose:       "Choose an option",
+		ModalYes:          "Yes",
+		ModalNo:           "No",
+		ModalSelectDate:   "Select a date",
+		ModalAnswerPrompt: "Type your answer here...",
+		ModalReview:       "📋 Review your update",
+		ModalNoAnswer:     "No answer",
+		ModalEdit:         "✏️ Edit answers",
+		ModalPlan:         "🗓️ *Your plan on %s:*",
+
+		AnswerRequired: "This question needs an answer.",
+		AnswerTooShort: "Please write at least %d characters.",
+		AnswerTooLong:  "Please keep this under %d characters.",
+		AnswerMismatch: "This answer isn't in the expected format.",
+
+		SummaryEmpty:     "No responses yet today.",
+		SummarySubmitted: "✅ *Submitted:*",
+		SummarySkipped:   "⏭️ *Skipped:*",
+		SummaryPending:   "⏳ *Pending:*",
+		SummaryMore:      "_…and %d more_",
+		SummaryThread:    "🧵 <%s|View thread> for the full updates.",
+		SummaryStreaks:   "🔥 *Streaks:*",
+		SummaryStreak:    "• <@%s> — %d standups in a row",
+
+		ErrorNotConfigured:     "Standups aren't configured for this channel. Run `/standup-config setup` to set them up.",
+		ErrorStandupClosed:     "Today's standup has closed. Your update can go in tomorrow's.",
+		ErrorStandupChanged:    "Your standup was submitted elsewhere since this form opened. Reopen it to see the latest.",
+		ErrorOpenStandup:       "Failed to open the standup form.",
+		ErrorSkip:              "Failed to skip today's standup.",
+		ErrorForbidden:         "🔒 Only channel admins and workspace admins can use `%s`.",
+		ErrorCheckPermissions:  "Failed to check your permissions.",
+		ErrorNotAllowed:        "🔒 Only channel admins and workspace admins can do that.",
+		ErrorNotFound:          "It doesn't exist anymore.",
+		ErrorConflict:          "Someone else changed it at the same time. Please try again.",
+		ErrorNoAccess:          "I can't reach this channel. Invite me to it and try again.",
+		ErrorUnavailable:       "Slack is busy right now. Please try again in a minute.",
+		ErrorInternal:          "Something went wrong on our side. If it keeps happening, pass on the reference `%s`.",
+		SkippedToday:           "⏭️ Skipped today's standup. See you next time!",
+		SkippedTodayWithReason: "⏭️ Skipped today's standup (%s). See you next time!",
+	},
+	Spanish: {
+		ReminderQuestions: "*Preguntas de hoy:*",
+		ReminderProgress:  "👥 *%d de %d* enviados hasta ahora",
+		ReminderSubmit:    "Enviar ahora",
+		ReminderSkip:      "Saltar hoy",
+		ReminderSnooze:    "Posponer 1 h",
+		ReminderOpen:      "Abrir #%s",
+
+		ModalTitle:        "Standup diario",
+		ModalSubmit:       "Enviar",
+		ModalHeader:       "📝 Actualización del standup diario",
+		ModalIntro:        "Responde a las siguientes preguntas:",
+		ModalChoose:       "Elige una opción",
+		ModalYes:          "Sí",
+		ModalNo:           "No",
+		ModalSelectDate:   "Selecciona una fecha",
+		ModalAnswerPrompt: "Escribe tu respuesta aquí...",
+		ModalReview:       "📋 Revisa tu actualización",
+		ModalNoAnswer:     "Sin respuesta",
+		ModalEdit:         "✏️ Editar respuestas",
+		ModalPlan:         "🗓️ *Tu plan del %s:*",
+
+		AnswerRequired: "Esta pregunta necesita una respuesta.",
+		AnswerTooShort: "Escribe al menos %d caracteres.",
+		AnswerTooLong:  "Escribe como máximo %d caracteres.",
+		AnswerMismatch: "Esta respuesta no tiene el formato esperado.",
+
+		SummaryEmpty:     "Todavía no hay respuestas hoy.",
+		SummarySubmitted: "✅ *Enviados:*",
+		SummarySkipped:   "⏭️ *Omitidos:*",
+		SummaryPending:   "⏳ *Pendientes:*",
+		SummaryMore:      "_…y %d más_",
+		SummaryThread:    "🧵 <%s|Ver el hilo> para las actualizaciones completas.",
+		SummaryStreaks:   "🔥 *Rachas:*",
+		SummaryStreak:    "• <@%s> — %d standups seguidos",
+
+		ErrorNotConfigured:     "Los standups no están configurados aquí. Configúralos con `/standup-config setup`.",
+		ErrorStandupClosed:     "El standup de hoy ya se cerró. Tu actualización puede ir en el de mañana.",
+		ErrorStandupChanged:    "Tu standup se envió desde otro lugar. Vuelve a abrirlo para ver lo último.",
+		ErrorOpenStandup:       "No se pudo abrir el formulario del standup.",
+		ErrorSkip:              "No se pudo saltar el standup de hoy.",
+		ErrorForbidden:         "🔒 Solo los administradores del canal y del espacio de trabajo pueden usar `%s`.",
+		ErrorCheckPermissions:  "No se pudieron comprobar tus permisos.",
+		ErrorNotAllowed:        "🔒 Solo los administradores del canal y del espacio de trabajo pueden hacer eso.",
+		ErrorNotFound:          "Ya no existe.",
+		ErrorConflict:          "Otra persona lo cambió al mismo tiempo. Inténtalo de nuevo.",
+		ErrorNoAccess:          "No puedo acceder a este canal. Invítame y vuelve a intentarlo.",
+		ErrorUnavailable:       "Slack está ocupado en este momento. Inténtalo de nuevo en un minuto.",
+		ErrorInternal:          "Algo salió mal por nuestra parte. Si sigue pasando, comparte la referencia `%s`.",
+		SkippedToday:           "⏭️ Saltaste el standup de hoy. ¡Hasta la próxima!",
+		SkippedTodayWithReason: "⏭️ Saltaste el standup de hoy (%s). ¡Hasta la próxima!",
+	},
+	German: {
+		ReminderQuestions: "*Heutige Fragen:*",
+		ReminderProgress:  "👥 *%d von %d* bisher eingereicht",
+		ReminderSubmit:    "Jetzt einreichen",
+		ReminderSkip:      "Heute aussetzen",
+		ReminderSnooze:    "1 Std. schlummern",
+		ReminderOpen:      "#%s öffnen",
+
+		ModalTitle:        "Tägliches Standup",
+		ModalSubmit:       "Absenden",
+		ModalHeader:       "📝 Tägliches Standup-Update",
+		ModalIntro:        "Bitte beantworte die folgenden Fragen:",
+		ModalChoose:       "Option auswählen",
+		ModalYes:          "Ja",
+		ModalNo:           "Nein",
+		ModalSelectDate:   "Datum auswählen",
+		ModalAnswerPrompt: "Antwort hier eingeben...",
+		ModalReview:       "📋 Überprüfe dein Update",
+		ModalNoAnswer:     "Keine Antwort",
+		ModalEdit:         "✏️ Antworten bearbeiten",
+		ModalPlan:         "🗓️ *Dein Plan vom %s:*",
+
+		AnswerRequired: "Diese Frage braucht eine Antwort.",
+		AnswerTooShort: "Bitte schreibe mindestens %d Zeichen.",
+		AnswerTooLong:  "Bitte schreibe höchstens %d Zeichen.",
+		AnswerMismatch: "Diese Antwort hat nicht das erwartete Format.",
+
+		SummaryEmpty:     "Heute gibt es noch keine Antworten.",
+		SummarySubmitted: "✅ *Eingereicht:*",
+		SummarySkipped:   "⏭️ *Ausgesetzt:*",
+		SummaryPending:   "⏳ *Ausstehend:*",
+		SummaryMore:      "_…und %d weitere_",
+		SummaryThread:    "🧵 <%s|Thread ansehen> für die vollständigen Updates.",
+		SummaryStreaks:   "🔥 *Serien:*",
+		SummaryStreak:    "• <@%s> — %d Standups in Folge",
+
+		ErrorNotConfigured:     "In diesem Channel gibt es keine Standups. Richte sie mit `/standup-config setup` ein.",
+		ErrorStandupClosed:     "Das heutige Standup ist geschlossen. Dein Update kann ins morgige.",
+		ErrorStandupChanged:    "Dein Standup wurde woanders eingereicht. Öffne es erneut, um den neuesten Stand zu sehen.",
+		ErrorOpenStandup:       "Das Standup-Formular konnte nicht geöffnet werden.",
+		ErrorSkip:              "Das heutige Standup konnte nicht ausgesetzt werden.",
+		ErrorForbidden:         "🔒 Nur Channel-Admins und Workspace-Admins können `%s` verwenden.",
+		ErrorCheckPermissions:  "Deine Berechtigungen konnten nicht geprüft werden.",
+		ErrorNotAllowed:        "🔒 Nur Channel- und Workspace-Admins können das tun.",
+		ErrorNotFound:          "Das gibt es nicht mehr.",
+		ErrorConflict:          "Jemand anderes hat es gleichzeitig geändert. Bitte versuche es erneut.",
+		ErrorNoAccess:          "Ich kann diesen Channel nicht erreichen. Lade mich ein und versuche es erneut.",
+		ErrorUnavailable:       "Slack ist gerade ausgelastet. Bitte versuche es in einer Minute erneut.",
+		ErrorInternal:          "Bei uns ist etwas schiefgelaufen. Falls das öfter passiert, nenne die Referenz `%s`.",
+		SkippedToday:           "⏭️ Heutiges Standup ausgesetzt. Bis zum nächsten Mal!",
+		SkippedTodayWithReason: "⏭️ Heutiges Standup ausgesetzt (%s). Bis zum nächsten Mal!",
+	},
+	French: {
+		ReminderQuestions: "*Questions du jour :*",
+		ReminderProgress:  "👥 *%d sur %d* envoyés pour l'instant",
+		ReminderSubmit:    "Envoyer maintenant",
+		ReminderSkip:      "Passer aujourd'hui",
+		ReminderSnooze:    "Reporter d'1 h",
+		ReminderOpen:      "Ouvrir #%s",
+
+		ModalTitle:        "Standup quotidien",
+		ModalSubmit:       "Envoyer",
+		ModalHeader:       "📝 Point du standup quotidien",
+		ModalIntro:        "Merci de répondre aux questions suivantes :",
+		ModalChoose:       "Choisissez une option",
+		ModalYes:          "Oui",
+		ModalNo:           "Non",
+		ModalSelectDate:   "Choisissez une date",
+		ModalAnswerPrompt: "Saisissez votre réponse ici...",
+		ModalReview:       "📋 Vérifiez votre point",
+		ModalNoAnswer:     "Pas de réponse",
+		ModalEdit:         "✏️ Modifier les réponses",
+		ModalPlan:         "🗓️ *Votre plan du %s :*",
+
+		AnswerRequired: "Cette question nécessite une réponse.",
+		AnswerTooShort: "Veuillez écrire au moins %d caractères.",
+		AnswerTooLong:  "Veuillez écrire au plus %d caractères.",
+		AnswerMismatch: "Cette réponse n'est pas au format attendu.",
+
+		SummaryEmpty:     "Aucune réponse pour l'instant aujourd'hui.",
+		SummarySubmitted: "✅ *Envoyés :*",
+		SummarySkipped:   "⏭️ *Passés :*",
+		SummaryPending:   "⏳ *En attente :*",
+		SummaryMore:      "_…et %d de plus_",
+		SummaryThread:    "🧵 <%s|Voir le fil> pour les points complets.",
+		SummaryStreaks:   "🔥 *Séries :*",
+		SummaryStreak:    "• <@%s> — %d standups d'affilée",
+
+		ErrorNotConfigured:     "Les standups ne sont pas configurés ici. Configurez-les avec `/standup-config setup`.",
+		ErrorStandupClosed:     "Le standup du jour est clos. Votre point pourra figurer dans celui de demain.",
+		ErrorStandupChanged:    "Votre standup a été envoyé ailleurs. Rouvrez-le pour voir la dernière version.",
+		ErrorOpenStandup:       "Impossible d'ouvrir le formulaire du standup.",
+		ErrorSkip:              "Impossible de passer le standup du jour.",
+		ErrorForbidden:         "🔒 Seuls les admins du canal et de l'espace de travail peuvent utiliser `%s`.",
+		ErrorCheckPermissions:  "Impossible de vérifier vos autorisations.",
+		ErrorNotAllowed:        "🔒 Seuls les administrateurs du canal et de l'espace de travail peuvent faire cela.",
+		ErrorNotFound:          "Cela n'existe plus.",
+		ErrorConflict:          "Quelqu'un d'autre l'a modifié en même temps. Veuillez réessayer.",
+		ErrorNoAccess:          "Je ne peux pas accéder à ce canal. Invitez-moi puis réessayez.",
+		ErrorUnavailable:       "Slack est occupé pour le moment. Veuillez réessayer dans une minute.",
+		ErrorInternal:          "Un problème est survenu de notre côté. Si cela se reproduit, indiquez la référence `%s`.",
+		SkippedToday:           "⏭️ Standup du jour passé. À la prochaine !",
+		SkippedTodayWithReason: "⏭️ Standup du jour passé (%s). À la prochaine !",
+	},
+}
@@ -0,0 +1,66 @@
+// Package i18n translates the bot's messages. Each supported locale has a
+// catalog of messages keyed by Key; messages missing from a catalog fall back
+// to English.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Locale is a supported language, such as "es".
+type Locale string
+
+// Supported locales.
+const (
+	English Locale = "en"
+	Spanish Locale = "es"
+	German  Locale = "de"
+	French  Locale = "fr"
+)
+
+// Default is the locale of messages with no other locale chosen.
+const Default = English
+
+// Locales lists the supported locales.
+var Locales = []Locale{English, Spanish, German, French}
+
+// Parse returns the supported locale of a language tag, such as Slack's
+// "es-ES" or "fr-CA".
+func Parse(tag string) (Locale, bool) {
+	language, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	locale := Locale(strings.ToLower(strings.TrimSpace(language)))
+	if _, ok := catalogs[locale]; !ok {
+		return "", false
+	}
+	return locale, true
+}
+
+// Pick returns the locale of the first supported tag, or Default if none are.
+// Tags are given from most to least preferred, e.g. a user's own setting,
+// their Slack locale, then the channel's locale.
+func Pick(tags ...string) Locale {
+	for _, tag := range tags {
+		if locale, ok := Parse(tag); ok {
+			return locale
+		}
+	}
+	return Default
+}
+
+// T returns the message for key in the locale, formatted with args like
+// fmt.Sprintf.
+func (l Locale) T(key Key, args ...interface{}) string {
+	message, ok := catalogs[l][key]
+	if !ok {
+		message, ok = catalogs[English][key]
+	}
+	if !ok {
+		message = string(key)
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
@@ -0,0 +1,57 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+)
+
+func TestCatalogs(t *testing.T) {
+	for _, locale := range Locales {
+		catalog := catalogs[locale]
+		assert.Len(t, catalog, len(catalogs[English]), "locale %s", locale)
+		for key, message := range catalogs[English] {
+			translated, ok := catalog[key]
+			if assert.True(t, ok, "locale %s is missing %s", locale, key) {
+				assert.Equal(t, strings.Count(message, "%"), strings.Count(translated, "%"),
+					"locale %s formats %s differently", locale, key)
+			}
+		}
+	}
+
+	// Configs can only choose supported locales
+	assert.Len(t, botconfig.Locales, len(Locales))
+	for _, tag := range botconfig.Locales {
+		_, ok := Parse(tag)
+		assert.True(t, ok, tag)
+	}
+}
+
+func TestParse(t *testing.T) {
+	for tag, want := range map[string]Locale{"es-ES": Spanish, "fr-CA": French, "de_DE": German, "EN": English} {
+		locale, ok := Parse(tag)
+		assert.True(t, ok, tag)
+		assert.Equal(t, want, locale, tag)
+	}
+
+	_, ok := Parse("ja-JP")
+	assert.False(t, ok)
+	_, ok = Parse("")
+	assert.False(t, ok)
+}
+
+func TestPick(t *testing.T) {
+	assert.Equal(t, German, Pick("", "de-DE", "fr"))
+	assert.Equal(t, French, Pick("ja-JP", "fr"))
+	assert.Equal(t, Default, Pick("ja-JP", ""))
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "👥 *2 de 5* enviados hasta ahora", Spanish.T(ReminderProgress, 2, 5))
+
+	// Unset locales are English
+	assert.Equal(t, "Submit now", Locale("").T(ReminderSubmit))
+}
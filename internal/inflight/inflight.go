@@ -0,0 +1,78 @@
+// Package inflight records the downstream calls a request is waiting on, so a
+// request that runs out of time can report what it was stuck on.
+package inflight
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+type callsKey struct{}
+
+// Calls is the set of calls in flight for one request.
+type Calls struct {
+	mu     sync.Mutex
+	next   int
+	active map[int]string
+}
+
+// WithCalls returns a context whose calls are recorded in the returned set.
+func WithCalls(ctx context.Context) (context.Context, *Calls) {
+	calls := &Calls{active: make(map[int]string)}
+	return context.WithValue(ctx, callsKey{}, calls), calls
+}
+
+// Start records a call named name as in flight until the returned function
+// is called. Contexts without a set of calls record nothing.
+func Start(ctx context.Context, name string) (done func()) {
+	calls, ok := ctx.Value(callsKey{}).(*Calls)
+	if !ok {
+		return func() {}
+	}
+
+	calls.mu.Lock()
+	id := calls.next
+	calls.next++
+	calls.active[id] = name
+	calls.mu.Unlock()
+
+	return func() {
+		calls.mu.Lock()
+		delete(calls.active, id)
+		calls.mu.Unlock()
+	}
+}
+
+// Active returns the names of the calls in flight, oldest first.
+func (c *Calls) Active() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.active))
+	for id := 0; id < c.next; id++ {
+		if name, ok := c.active[id]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// InstrumentAWS records every AWS SDK call made with cfg as in flight, named
+// like "DynamoDB.Query".
+func InstrumentAWS(cfg *aws.Config) {
+	cfg.APIOptions = append(cfg.APIOptions, func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("InFlight",
+			func(
+				ctx context.Context,
+				in middleware.InitializeInput,
+				next middleware.InitializeHandler,
+			) (middleware.InitializeOutput, middleware.Metadata, error) {
+				defer Start(ctx, awsmiddleware.GetServiceID(ctx)+"."+awsmiddleware.GetOperationName(ctx))()
+				return next.HandleInitialize(ctx, in)
+			}), middleware.Before)
+	})
+}
@@ -0,0 +1,25 @@
+package inflight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalls(t *testing.T) {
+	ctx, calls := WithCalls(context.Background())
+	assert.Empty(t, calls.Active())
+
+	doneQuery := Start(ctx, "DynamoDB.Query")
+	donePost := Start(ctx, "slack chat.postMessage")
+	assert.Equal(t, []string{"DynamoDB.Query", "slack chat.postMessage"}, calls.Active())
+
+	doneQuery()
+	assert.Equal(t, []string{"slack chat.postMessage"}, calls.Active())
+	donePost()
+	assert.Empty(t, calls.Active())
+
+	// Untracked contexts record nothing
+	Start(context.Background(), "DynamoDB.Query")()
+}
@@ -0,0 +1,117 @@
+// Package install records Slack app installs in the store, including
+// Enterprise Grid org-wide installs and the workspaces they're granted to.
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// ErrNoInstallID is returned for installs naming neither a team nor an
+// enterprise.
+var ErrNoInstallID = errors.New("install has no team or enterprise ID")
+
+// Save records an install completed through OAuth. Org-wide installs are
+// saved under their enterprise ID, and the workspaces of the grid they're
+// granted to (teams) are linked to them. Integrations of a reinstalled
+// workspace are kept.
+func Save(ctx context.Context, dataStore store.Store, access *slack.OAuthAccess, teams []slack.Team) error {
+	installID := access.InstallID()
+	if installID == "" {
+		return ErrNoInstallID
+	}
+
+	config, err := workspaceConfig(ctx, dataStore, installID)
+	if err != nil {
+		return err
+	}
+
+	config.BotToken = access.AccessToken
+	config.IsEnterpriseInstall = access.IsEnterpriseInstall
+	if access.Team != nil && access.Team.Name != "" {
+		config.TeamName = access.Team.Name
+	}
+	if access.Enterprise != nil {
+		config.EnterpriseID = access.Enterprise.ID
+		if access.IsEnterpriseInstall && access.Enterprise.Name != "" {
+			config.TeamName = access.Enterprise.Name
+		}
+	}
+
+	if err := dataStore.SaveWorkspaceConfig(ctx, config); err != nil {
+		return fmt.Errorf("failed to save install: %w", err)
+	}
+
+	if !access.IsEnterpriseInstall {
+		return nil
+	}
+	return GrantTeams(ctx, dataStore, installID, teams)
+}
+
+// GrantTeams links workspaces of an Enterprise Grid to the org-wide install,
+// so they use its bot token unless they have their own.
+func GrantTeams(ctx context.Context, dataStore store.Store, enterpriseID string, teams []slack.Team) error {
+	var errs []error
+	for _, team := range teams {
+		config, err := workspaceConfig(ctx, dataStore, team.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if config.EnterpriseID == enterpriseID && (team.Name == "" || config.TeamName == team.Name) {
+			continue
+		}
+
+		config.EnterpriseID = enterpriseID
+		if team.Name != "" {
+			config.TeamName = team.Name
+		}
+		if err := dataStore.SaveWorkspaceConfig(ctx, config); err != nil {
+			errs = append(errs, fmt.Errorf("failed to link workspace %s: %w", team.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RevokeTeams unlinks workspaces the org-wide install lost access to.
+func RevokeTeams(ctx context.Context, dataStore store.Store, enterpriseID string, teamIDs []string) error {
+	var errs []error
+	for _, teamID := range teamIDs {
+		config, err := dataStore.GetWorkspaceConfig(ctx, teamID)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get workspace %s: %w", teamID, err))
+			continue
+		}
+		if config.EnterpriseID != enterpriseID || config.BotToken != "" {
+			// Workspaces with their own install still belong to the grid
+			continue
+		}
+
+		config.EnterpriseID = ""
+		if err := dataStore.SaveWorkspaceConfig(ctx, config); err != nil {
+			errs = append(errs, fmt.Errorf("failed to unlink workspace %s: %w", teamID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// workspaceConfig returns the saved config of a workspace or install, or a
+// new one if there is none.
+func workspaceConfig(ctx context.Context, dataStore store.Store, id string) (*store.WorkspaceConfig, error) {
+	config, err := dataStore.GetWorkspaceConfig(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return &store.WorkspaceConfig{TeamID: id, InstalledAt: time.Now()}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace %s: %w", id, err)
+	}
+	return config, nil
+}
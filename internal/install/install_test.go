@@ -0,0 +1,67 @@
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/store/memory"
+)
+
+func TestSave(t *testing.T) {
+	ctx := context.Background()
+	dataStore := memory.NewStore()
+
+	// Reinstalling a workspace keeps its integrations
+	require.NoError(t, dataStore.SaveWorkspaceConfig(ctx, &store.WorkspaceConfig{
+		TeamID:       "T0000000001",
+		BotToken:     "xoxb-old",
+		Integrations: store.Integrations{GitHub: &store.GitHubIntegration{Token: "ghp"}},
+	}))
+	require.NoError(t, Save(ctx, dataStore, &slack.OAuthAccess{
+		AccessToken: "xoxb-new",
+		Team:        &slack.Team{ID: "T0000000001", Name: "Engineering"},
+	}, nil))
+
+	workspace, err := dataStore.GetWorkspaceConfig(ctx, "T0000000001")
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-new", workspace.BotToken)
+	assert.Equal(t, "Engineering", workspace.TeamName)
+	assert.NotNil(t, workspace.Integrations.GitHub)
+
+	assert.ErrorIs(t, Save(ctx, dataStore, &slack.OAuthAccess{AccessToken: "xoxb"}, nil), ErrNoInstallID)
+}
+
+func TestSaveEnterpriseInstall(t *testing.T) {
+	ctx := context.Background()
+	dataStore := memory.NewStore()
+
+	require.NoError(t, Save(ctx, dataStore, &slack.OAuthAccess{
+		AccessToken:         "xoxb-org",
+		Enterprise:          &slack.Enterprise{ID: "E0000000001", Name: "Acme"},
+		IsEnterpriseInstall: true,
+	}, []slack.Team{{ID: "T0000000001", Name: "Engineering"}, {ID: "T0000000002", Name: "Sales"}}))
+
+	org, err := dataStore.GetWorkspaceConfig(ctx, "E0000000001")
+	require.NoError(t, err)
+	assert.True(t, org.IsEnterpriseInstall)
+	assert.Equal(t, "xoxb-org", org.BotToken)
+	assert.Equal(t, "Acme", org.TeamName)
+
+	for _, teamID := range []string{"T0000000001", "T0000000002"} {
+		workspace, err := dataStore.GetWorkspaceConfig(ctx, teamID)
+		require.NoError(t, err)
+		assert.Equal(t, "E0000000001", workspace.EnterpriseID)
+		assert.Empty(t, workspace.BotToken)
+	}
+
+	// Revoked workspaces are unlinked
+	require.NoError(t, RevokeTeams(ctx, dataStore, "E0000000001", []string{"T0000000002", "T0000000003"}))
+	workspace, err := dataStore.GetWorkspaceConfig(ctx, "T0000000002")
+	require.NoError(t, err)
+	assert.Empty(t, workspace.EnterpriseID)
+}
@@ -0,0 +1,96 @@
+// Package jira finds Jira issue keys in text and comments on the issues
+// through the Jira Cloud REST API.
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/security"
+)
+
+// issueKeyPattern matches issue keys like ABC-123.
+var issueKeyPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9_]+-[1-9][0-9]*\b`)
+
+// FindIssueKeys returns the distinct issue keys mentioned in text, in the
+// order they first appear. When projects isn't empty, only keys of those
+// projects are returned.
+func FindIssueKeys(text string, projects []string) []string {
+	var keys []string
+	for _, key := range issueKeyPattern.FindAllString(text, -1) {
+		if slices.Contains(keys, key) {
+			continue
+		}
+		project, _, _ := strings.Cut(key, "-")
+		if len(projects) > 0 && !slices.Contains(projects, project) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Client talks to a Jira site.
+type Client struct {
+	baseURL    string
+	email      string
+	apiToken   string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the Jira site at baseURL, authenticating
+// with an Atlassian account's email and API token.
+func NewClient(baseURL, email, apiToken string) *Client {
+	return &Client{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		email:    email,
+		apiToken: apiToken,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// IssueURL returns the browser URL of an issue.
+func (c *Client) IssueURL(key string) string {
+	return c.baseURL + "/browse/" + url.PathEscape(key)
+}
+
+// AddComment adds a plain text comment to an issue.
+func (c *Client) AddComment(ctx context.Context, key, text string) error {
+	body, err := json.Marshal(map[string]string{"body": text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal comment: %w", err)
+	}
+
+	endpoint := c.baseURL + "/rest/api/2/issue/" + url.PathEscape(key) + "/comment"
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.email, c.apiToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code: %d, body: %s",
+			resp.StatusCode, security.SanitizeLogValue(string(respBody)))
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindIssueKeys(t *testing.T) {
+	text := "Finished ABC-123, reviewing XY-9 and ABC-123 again. Not keys: abc-1, ABC-0, UTF-8x, A-1"
+	assert.Equal(t, []string{"ABC-123", "XY-9"}, FindIssueKeys(text, nil))
+	assert.Equal(t, []string{"XY-9"}, FindIssueKeys(text, []string{"XY"}))
+	assert.Empty(t, FindIssueKeys("Nothing to link", nil))
+}
+
+func TestAddComment(t *testing.T) {
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/issue/ABC-123/comment", r.URL.Path)
+		email, token, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "bot@example.com", email)
+		assert.Equal(t, "secret", token)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL+"/", "bot@example.com", "secret")
+	require.NoError(t, c.AddComment(context.Background(), "ABC-123", "Mentioned in standup"))
+	assert.Equal(t, "Mentioned in standup", received["body"])
+	assert.Equal(t, server.URL+"/browse/ABC-123", c.IssueURL("ABC-123"))
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Issue does not exist", http.StatusNotFound)
+	}))
+	defer failing.Close()
+
+	err := NewClient(failing.URL, "bot@example.com", "secret").AddComment(context.Background(), "ABC-999", "hi")
+	assert.ErrorContains(t, err, "404")
+}
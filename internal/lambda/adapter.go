@@ -0,0 +1,149 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// payloadV2 is the version field of API Gateway HTTP API (payload format
+// 2.0) and Lambda Function URL events.
+const payloadV2 = "2.0"
+
+// EventHandler is a Lambda entry point that takes any supported API event.
+type EventHandler func(ctx context.Context, event json.RawMessage) (interface{}, error)
+
+// Adapt lets h serve API Gateway REST APIs, HTTP APIs and Lambda Function
+// URLs alike. The event format is detected per invocation: payload v2 events
+// are converted to the REST API form h expects, and its response back.
+func Adapt(h Handler) EventHandler {
+	return func(ctx context.Context, event json.RawMessage) (interface{}, error) {
+		var probe struct {
+			Version string `json:"version"`
+		}
+		if err := json.Unmarshal(event, &probe); err != nil {
+			return nil, fmt.Errorf("failed to parse event: %w", err)
+		}
+
+		if probe.Version != payloadV2 {
+			var request events.APIGatewayProxyRequest
+			if err := json.Unmarshal(event, &request); err != nil {
+				return nil, fmt.Errorf("failed to parse REST API event: %w", err)
+			}
+			return h(ctx, request)
+		}
+
+		var request events.APIGatewayV2HTTPRequest
+		if err := json.Unmarshal(event, &request); err != nil {
+			return nil, fmt.Errorf("failed to parse HTTP API event: %w", err)
+		}
+
+		response, err := h(ctx, fromV2Request(&request))
+		if err != nil {
+			return nil, err
+		}
+		return toV2Response(&response), nil
+	}
+}
+
+// fromV2Request converts a payload v2 request to the REST API form.
+func fromV2Request(request *events.APIGatewayV2HTTPRequest) events.APIGatewayProxyRequest {
+	headers := make(map[string]string, len(request.Headers)+1)
+	multiHeaders := make(map[string][]string, len(request.Headers)+1)
+	for name, value := range request.Headers {
+		headers[name] = value
+		multiHeaders[name] = strings.Split(value, ",")
+	}
+	// Payload v2 moves cookies out of the headers
+	if len(request.Cookies) > 0 {
+		headers["cookie"] = strings.Join(request.Cookies, "; ")
+		multiHeaders["cookie"] = request.Cookies
+	}
+
+	var query map[string]string
+	var multiQuery map[string][]string
+	if values, err := url.ParseQuery(request.RawQueryString); err == nil && len(values) > 0 {
+		query = make(map[string]string, len(values))
+		multiQuery = make(map[string][]string, len(values))
+		for name, vals := range values {
+			query[name] = vals[len(vals)-1]
+			multiQuery[name] = vals
+		}
+	} else {
+		query = request.QueryStringParameters
+	}
+
+	// Route keys are "METHOD /path", or "$default" for catch-all routes and
+	// Function URLs
+	resource := request.RawPath
+	if _, path, ok := strings.Cut(request.RouteKey, " "); ok {
+		resource = path
+	}
+
+	httpCtx := request.RequestContext.HTTP
+	return events.APIGatewayProxyRequest{
+		Resource:                        resource,
+		Path:                            request.RawPath,
+		HTTPMethod:                      httpCtx.Method,
+		Headers:                         headers,
+		MultiValueHeaders:               multiHeaders,
+		QueryStringParameters:           query,
+		MultiValueQueryStringParameters: multiQuery,
+		PathParameters:                  request.PathParameters,
+		StageVariables:                  request.StageVariables,
+		RequestContext: events.APIGatewayProxyRequestContext{
+			AccountID:    request.RequestContext.AccountID,
+			ResourcePath: resource,
+			Stage:        request.RequestContext.Stage,
+			RequestID:    request.RequestContext.RequestID,
+			DomainName:   request.RequestContext.DomainName,
+			APIID:        request.RequestContext.APIID,
+			HTTPMethod:   httpCtx.Method,
+			Path:         httpCtx.Path,
+			Protocol:     httpCtx.Protocol,
+			Identity: events.APIGatewayRequestIdentity{
+				SourceIP:  httpCtx.SourceIP,
+				UserAgent: httpCtx.UserAgent,
+			},
+		},
+		Body:            request.Body,
+		IsBase64Encoded: request.IsBase64Encoded,
+	}
+}
+
+// toV2Response converts a REST API response to payload v2. Multi-value
+// headers are folded into single comma-separated headers, except cookies,
+// which payload v2 returns separately.
+func toV2Response(response *events.APIGatewayProxyResponse) events.APIGatewayV2HTTPResponse {
+	headers := make(map[string]string, len(response.Headers)+len(response.MultiValueHeaders))
+	var cookies []string
+	for name, value := range response.Headers {
+		if strings.EqualFold(name, "Set-Cookie") {
+			cookies = append(cookies, value)
+			continue
+		}
+		headers[name] = value
+	}
+	for name, values := range response.MultiValueHeaders {
+		if strings.EqualFold(name, "Set-Cookie") {
+			cookies = append(cookies, values...)
+			continue
+		}
+		if existing, ok := headers[name]; ok {
+			values = append([]string{existing}, values...)
+		}
+		headers[name] = strings.Join(values, ",")
+	}
+
+	return events.APIGatewayV2HTTPResponse{
+		StatusCode:      response.StatusCode,
+		Headers:         headers,
+		Body:            response.Body,
+		IsBase64Encoded: response.IsBase64Encoded,
+		Cookies:         cookies,
+	}
+}
@@ -0,0 +1,73 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdapt(t *testing.T) {
+	var received events.APIGatewayProxyRequest
+	handler := Adapt(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		received = request
+		return events.APIGatewayProxyResponse{
+			StatusCode:        http.StatusOK,
+			Headers:           map[string]string{"Content-Type": "application/json"},
+			MultiValueHeaders: map[string][]string{"Set-Cookie": {"a=1", "b=2"}, "Vary": {"Origin"}},
+			Body:              "{}",
+		}, nil
+	})
+
+	t.Run("REST API event", func(t *testing.T) {
+		event := `{"resource": "/slack/events", "path": "/slack/events", "httpMethod": "POST",
+			"headers": {"Content-Type": "application/json"}, "body": "{}"}`
+
+		response, err := handler(context.Background(), json.RawMessage(event))
+		require.NoError(t, err)
+		require.IsType(t, events.APIGatewayProxyResponse{}, response)
+		assert.Equal(t, "/slack/events", received.Resource)
+		assert.Equal(t, http.MethodPost, received.HTTPMethod)
+	})
+
+	t.Run("HTTP API event", func(t *testing.T) {
+		event := `{"version": "2.0", "routeKey": "GET /channels/{id}/sessions", "rawPath": "/channels/C1/sessions",
+			"rawQueryString": "from=2024-01-01&team_id=T1", "cookies": ["session=abc"],
+			"headers": {"content-type": "application/json"}, "pathParameters": {"id": "C1"},
+			"requestContext": {"requestId": "req-1", "http": {"method": "GET", "sourceIp": "192.0.2.1"}},
+			"body": "e30=", "isBase64Encoded": true}`
+
+		response, err := handler(context.Background(), json.RawMessage(event))
+		require.NoError(t, err)
+
+		assert.Equal(t, "/channels/{id}/sessions", received.Resource)
+		assert.Equal(t, "/channels/C1/sessions", received.Path)
+		assert.Equal(t, http.MethodGet, received.HTTPMethod)
+		assert.Equal(t, "C1", received.PathParameters["id"])
+		assert.Equal(t, "T1", received.QueryStringParameters["team_id"])
+		assert.Equal(t, "session=abc", Header(&received, "Cookie"))
+		assert.Equal(t, "application/json", Header(&received, "Content-Type"))
+		assert.Equal(t, "192.0.2.1", received.RequestContext.Identity.SourceIP)
+		assert.True(t, received.IsBase64Encoded)
+
+		v2, ok := response.(events.APIGatewayV2HTTPResponse)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusOK, v2.StatusCode)
+		assert.Equal(t, "application/json", v2.Headers["Content-Type"])
+		assert.Equal(t, "Origin", v2.Headers["Vary"])
+		assert.Equal(t, []string{"a=1", "b=2"}, v2.Cookies)
+	})
+
+	t.Run("Function URL event", func(t *testing.T) {
+		event := `{"version": "2.0", "routeKey": "$default", "rawPath": "/slack/commands",
+			"requestContext": {"http": {"method": "POST"}}}`
+
+		_, err := handler(context.Background(), json.RawMessage(event))
+		require.NoError(t, err)
+		assert.Equal(t, "/slack/commands", received.Resource)
+	})
+}
@@ -0,0 +1,81 @@
+package lambda
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Capability is a dependency a function can start without when it is listed
+// in OPTIONAL_CAPABILITIES. Initialize logs the failure and carries on
+// degraded instead of failing, so a cold start survives an outage of
+// something the function can do without.
+type Capability string
+
+const (
+	// CapabilitySecrets reads the bot token from Secrets Manager. Without it
+	// the token comes from SLACK_BOT_TOKEN or the config file, and Secrets
+	// Manager is tried again whenever the token is needed.
+	CapabilitySecrets Capability = "secrets"
+	// CapabilityTracing traces requests with the configured tracer.
+	CapabilityTracing Capability = "tracing"
+	// CapabilitySlackAuth requires Slack to accept the bot token at startup.
+	CapabilitySlackAuth Capability = "slack_auth"
+	// CapabilityConfigWatch reloads the config when it changes. It is always
+	// optional.
+	CapabilityConfigWatch Capability = "config_watch"
+)
+
+var capabilities = []Capability{CapabilitySecrets, CapabilityTracing, CapabilitySlackAuth, CapabilityConfigWatch}
+
+// ParseCapabilities splits a comma-separated list of capabilities, e.g. the
+// value of OPTIONAL_CAPABILITIES.
+func ParseCapabilities(value string) ([]Capability, error) {
+	var parsed []Capability
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		capability := Capability(name)
+		if !slices.Contains(capabilities, capability) {
+			return nil, fmt.Errorf("unknown capability: %s", name)
+		}
+		parsed = append(parsed, capability)
+	}
+	return parsed, nil
+}
+
+// degraded records the capabilities Initialize started without, with why.
+var degraded = struct {
+	mu   sync.Mutex
+	byID map[Capability]string
+}{byID: make(map[Capability]string)}
+
+// Degraded returns the capabilities the function started without, keyed by
+// name, with the error that made it do without them. It is empty when
+// everything initialized.
+func Degraded() map[string]string {
+	degraded.mu.Lock()
+	defer degraded.mu.Unlock()
+
+	reasons := make(map[string]string, len(degraded.byID))
+	for capability, reason := range degraded.byID {
+		reasons[string(capability)] = reason
+	}
+	return reasons
+}
+
+func setDegraded(capability Capability, err error) {
+	degraded.mu.Lock()
+	defer degraded.mu.Unlock()
+	degraded.byID[capability] = err.Error()
+}
+
+func resetDegraded() {
+	degraded.mu.Lock()
+	defer degraded.mu.Unlock()
+	clear(degraded.byID)
+}
@@ -0,0 +1,32 @@
+package lambda
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCapabilities(t *testing.T) {
+	capabilities, err := ParseCapabilities(" secrets, tracing,")
+	require.NoError(t, err)
+	assert.Equal(t, []Capability{CapabilitySecrets, CapabilityTracing}, capabilities)
+
+	capabilities, err = ParseCapabilities("")
+	require.NoError(t, err)
+	assert.Empty(t, capabilities)
+
+	_, err = ParseCapabilities("secrets,store")
+	assert.EqualError(t, err, "unknown capability: store")
+}
+
+func TestDegraded(t *testing.T) {
+	t.Cleanup(resetDegraded)
+
+	setDegraded(CapabilitySecrets, errors.New("access denied"))
+	assert.Equal(t, map[string]string{"secrets": "access denied"}, Degraded())
+
+	resetDegraded()
+	assert.Empty(t, Degraded())
+}
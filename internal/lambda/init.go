@@ -2,46 +2,185 @@ package lambda
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"sync"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 
 	botconfig "github.com/synaptiq/standup-bot/config"
 	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/cache"
+	"github.com/synaptiq/standup-bot/internal/configprovider"
+	"github.com/synaptiq/standup-bot/internal/inflight"
 	"github.com/synaptiq/standup-bot/internal/slack"
 	"github.com/synaptiq/standup-bot/internal/store"
 	dynamodbstore "github.com/synaptiq/standup-bot/internal/store/dynamodb"
+	postgresstore "github.com/synaptiq/standup-bot/internal/store/postgres"
+	"github.com/synaptiq/standup-bot/internal/tracing"
 )
 
 // InitConfig contains initialization configuration.
 type InitConfig struct {
-	ConfigPath    string
-	TableName     string
-	TTLDays       int
-	SlackTokenEnv string
+	ConfigPath     string
+	ConfigSource   string   // "dynamodb" to load config from the store
+	TeamID         string   // Workspace whose config is loaded from the store
+	TeamIDs        []string // Workspaces served by one deployment; overrides TeamID
+	ConfigBucket   string   // Load config from S3 instead of ConfigPath when set
+	ConfigKey      string
+	WatchConfig    bool // Reload config when the source changes
+	TableName      string
+	DynamoEndpoint string // DynamoDB Local URL to use instead of AWS, for development
+	TTLDays        int
+	DatabaseDriver string // "postgres" to store data in PostgreSQL instead of DynamoDB
+	DatabaseURL    string // PostgreSQL connection string
+	SlackTokenEnv  string
+	SlackSecretARN string        // Read the bot token from Secrets Manager when set
+	Tracer         string        // "xray" to trace with AWS X-Ray
+	CacheTTL       time.Duration // How long channel configs and Slack lookups are cached; 0 disables
+	Prewarm        bool          // Open the store's connection at startup rather than on first use
+	// Capabilities to start without when they fail, from OPTIONAL_CAPABILITIES
+	OptionalCapabilities string
 }
 
 // DefaultInitConfig returns default initialization config.
 func DefaultInitConfig() InitConfig {
 	return InitConfig{
-		ConfigPath:    os.Getenv("CONFIG_PATH"),
-		TableName:     os.Getenv("DYNAMODB_TABLE"),
-		TTLDays:       30,
-		SlackTokenEnv: "SLACK_BOT_TOKEN",
+		ConfigPath:     os.Getenv("CONFIG_PATH"),
+		ConfigSource:   os.Getenv("CONFIG_SOURCE"),
+		TeamID:         os.Getenv("SLACK_TEAM_ID"),
+		TeamIDs:        configprovider.ParseTeamIDs(os.Getenv("SLACK_TEAM_IDS")),
+		ConfigBucket:   os.Getenv("CONFIG_S3_BUCKET"),
+		ConfigKey:      os.Getenv("CONFIG_S3_KEY"),
+		WatchConfig:    os.Getenv("CONFIG_WATCH") == "true",
+		TableName:      os.Getenv("DYNAMODB_TABLE"),
+		DynamoEndpoint: os.Getenv("DYNAMO_ENDPOINT"),
+		TTLDays:        30,
+		DatabaseDriver: os.Getenv("DATABASE_DRIVER"),
+		DatabaseURL:    os.Getenv("DATABASE_URL"),
+		SlackTokenEnv:  "SLACK_BOT_TOKEN",
+		SlackSecretARN: os.Getenv("SLACK_SECRET_ARN"),
+		Tracer:         os.Getenv("TRACER"),
+		CacheTTL:       parseCacheTTL(os.Getenv("CACHE_TTL")),
+
+		OptionalCapabilities: os.Getenv("OPTIONAL_CAPABILITIES"),
+		Prewarm: os.Getenv("PREWARM") == "true" ||
+			os.Getenv("AWS_LAMBDA_INITIALIZATION_TYPE") == "provisioned-concurrency",
 	}
 }
 
-// Initialize initializes all components for Lambda.
+// Initialize initializes all components for Lambda. Failures of optional
+// capabilities are logged and reported by Degraded rather than returned.
 func Initialize(ctx context.Context, initCfg InitConfig) (botcontext.BotContext, store.Store, slack.Client, error) {
+	optional, err := ParseCapabilities(initCfg.OptionalCapabilities)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid OPTIONAL_CAPABILITIES: %w", err)
+	}
+	resetDegraded()
+	// tolerate returns err unless capability is optional, in which case the
+	// function goes on without it
+	tolerate := func(capability Capability, err error) error {
+		if !slices.Contains(optional, capability) {
+			return err
+		}
+		setDegraded(capability, err)
+		return nil
+	}
+
+	// Load AWS configuration
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	// Record AWS calls so requests that time out can report them
+	inflight.InstrumentAWS(&awsCfg)
+
+	// Trace AWS and Slack calls when a tracer is configured
+	var (
+		tracer       botcontext.Tracer
+		tokens       slack.TokenSource
+		slackOptions []slack.ClientOption
+	)
+	switch initCfg.Tracer {
+	case "":
+	case tracing.XRay:
+		xrayTracer, err := tracing.NewXRayTracer("")
+		if err != nil {
+			if err := tolerate(CapabilityTracing, err); err != nil {
+				return nil, nil, nil, err
+			}
+			break
+		}
+		xrayTracer.InstrumentAWS(&awsCfg)
+		tracer = xrayTracer
+		slackOptions = append(slackOptions, slack.WithTransport(xrayTracer.Transport(slack.DefaultTransport)))
+	default:
+		return nil, nil, nil, fmt.Errorf("unknown tracer: %s", initCfg.Tracer)
+	}
+
+	// Read the bot token from Secrets Manager. It is exported to the token
+	// env var because config files reference it there. Functions that
+	// started without the secret keep trying it, using the token from the
+	// env var or the config file meanwhile.
+	var (
+		secretsClient botcontext.SecretsClient
+		secretTokens  *slack.SecretTokenSource
+	)
+	if initCfg.SlackSecretARN != "" {
+		secretsClient = &awsSecretsClient{client: secretsmanager.NewFromConfig(awsCfg)}
+		secretTokens = slack.NewSecretTokenSource(secretsClient, initCfg.SlackSecretARN, slack.DefaultTokenTTL)
+		token, err := secretTokens.Token(ctx)
+		switch {
+		case err != nil:
+			if err := tolerate(CapabilitySecrets, err); err != nil {
+				return nil, nil, nil, err
+			}
+		default:
+			if err := os.Setenv(initCfg.SlackTokenEnv, token); err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to set %s: %w", initCfg.SlackTokenEnv, err)
+			}
+			tokens = secretTokens
+		}
+	}
+
+	// The DynamoDB client is only created if the config or the store use it
+	dynamoClient := sync.OnceValue(func() *dynamodb.Client {
+		return dynamodb.NewFromConfig(awsCfg, func(o *dynamodb.Options) {
+			if initCfg.DynamoEndpoint != "" {
+				o.BaseEndpoint = aws.String(initCfg.DynamoEndpoint)
+			}
+		})
+	})
+
 	// Load configuration
 	if initCfg.ConfigPath == "" {
 		initCfg.ConfigPath = "config.yaml"
 	}
 
-	provider := botconfig.NewYAMLProvider(initCfg.ConfigPath)
+	provider, err := newConfigProvider(ctx, &initCfg, awsCfg, dynamoClient)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Seed channels whose questions can't be stored are left out rather than
+	// failing every start; the config still has them from the file
+	var unseeded error
+	if seeded, ok := provider.(*configprovider.StoreProvider); ok {
+		unseeded = seeded.Seed(ctx)
+		if unseeded != nil && !errors.Is(unseeded, configprovider.ErrUnstorableQuestions) {
+			return nil, nil, nil, fmt.Errorf("failed to seed config: %w", unseeded)
+		}
+	}
+
 	cfg, err := provider.Load()
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to load config: %w", err)
@@ -53,31 +192,44 @@ func Initialize(ctx context.Context, initCfg InitConfig) (botcontext.BotContext,
 		return nil, nil, nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	// Load AWS configuration
-	awsCfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to load AWS config: %w", err)
-	}
-
-	// Create DynamoDB client
-	dynamoClient := dynamodb.NewFromConfig(awsCfg)
-
 	// Create store
 	if initCfg.TableName == "" {
 		initCfg.TableName = cfg.DatabaseTable()
 	}
-	dataStore := dynamodbstore.NewStore(dynamoClient, initCfg.TableName, initCfg.TTLDays)
+	dataStore, err := newStore(ctx, &initCfg, dynamoClient)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Reuse channel configs across the requests a container serves
+	var configCache *cache.Store
+	if initCfg.CacheTTL > 0 {
+		configCache = cache.NewStore(dataStore, initCfg.CacheTTL)
+		dataStore = configCache
+	}
 
 	// Create Slack client
 	slackToken := os.Getenv(initCfg.SlackTokenEnv)
 	if slackToken == "" {
 		slackToken = cfg.BotToken()
 	}
-	slackClient := slack.NewClient(slackToken)
+	if secretTokens != nil && tokens == nil {
+		tokens = slack.FallbackTokenSource(secretTokens, slackToken)
+	}
 
-	// Create secrets client
-	secretsClient := &awsSecretsClient{
-		client: secretsmanager.NewFromConfig(awsCfg),
+	// Each workspace's requests use the bot token saved for it
+	if teams, ok := provider.(*configprovider.TeamsProvider); ok {
+		if tokens == nil {
+			tokens = slack.StaticTokenSource(slackToken)
+		}
+		tokens = teams.TokenSource(tokens)
+	}
+	if tokens != nil {
+		slackOptions = append(slackOptions, slack.WithTokenSource(tokens))
+	}
+	slackClient := slack.NewClient(slackToken, slackOptions...)
+	if initCfg.CacheTTL > 0 {
+		slackClient = cache.NewSlackClient(slackClient, initCfg.CacheTTL)
 	}
 
 	// Create bot context
@@ -87,14 +239,205 @@ func Initialize(ctx context.Context, initCfg InitConfig) (botcontext.BotContext,
 		DynamoDB:       &dynamoDBClient{store: dataStore},
 		SecretsManager: secretsClient,
 		SlackClient:    &slackClientWrapper{client: slackClient},
+		Tracer:         tracer,
 	})
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to create bot context: %w", err)
 	}
 
+	if unseeded != nil {
+		botCtx.Logger().Warn(ctx, "Seed channels weren't stored, their questions can't be",
+			botcontext.Field{Key: "error", Value: unseeded.Error()},
+		)
+	}
+
+	// Check the bot token now rather than on the first request that needs it
+	identity, err := slackClient.AuthTest(ctx)
+	switch {
+	case slack.IsInvalidToken(err):
+		if err := tolerate(CapabilitySlackAuth, fmt.Errorf("slack rejected the bot token: %w", err)); err != nil {
+			return nil, nil, nil, err
+		}
+	case err != nil:
+		// Such as Slack being unreachable; requests may still succeed later
+		botCtx.Logger().Warn(ctx, "Failed to verify the Slack bot token",
+			botcontext.Field{Key: "error", Value: err.Error()},
+		)
+	default:
+		botCtx.Logger().Info(ctx, "Verified the Slack bot token",
+			botcontext.Field{Key: "bot_user_id", Value: identity.UserID},
+			botcontext.Field{Key: "team_id", Value: identity.TeamID},
+			botcontext.Field{Key: "team", Value: identity.Team},
+		)
+	}
+
+	// Provisioned containers start ahead of their traffic, so their first
+	// request needn't connect to the store either; Slack's connection was
+	// opened by auth.test
+	if initCfg.Prewarm {
+		prewarmStore(ctx, botCtx, dataStore)
+	}
+
+	// Pick up schedule and channel changes without redeploying
+	if initCfg.WatchConfig {
+		if err := provider.Watch(func(newCfg botconfig.Config) {
+			if configCache != nil {
+				configCache.Invalidate()
+			}
+			reloadConfig(ctx, botCtx, validator, newCfg)
+		}); err != nil {
+			botCtx.Logger().Error(ctx, "Failed to watch configuration", err)
+			setDegraded(CapabilityConfigWatch, err)
+		}
+	}
+
+	reasons := Degraded()
+	for _, capability := range slices.Sorted(maps.Keys(reasons)) {
+		botCtx.Logger().Warn(ctx, "Started without a capability",
+			botcontext.Field{Key: "capability", Value: capability},
+			botcontext.Field{Key: "error", Value: reasons[capability]},
+			botcontext.Metric("DegradedCapabilities", 1),
+		)
+	}
+
 	return botCtx, dataStore, slackClient, nil
 }
 
+// newConfigProvider selects the configuration source. DynamoDB takes
+// precedence over S3, which takes precedence over the bundled YAML file.
+func newConfigProvider(
+	ctx context.Context,
+	initCfg *InitConfig,
+	awsCfg aws.Config,
+	dynamoClient func() *dynamodb.Client,
+) (botconfig.Provider, error) {
+	switch {
+	case initCfg.ConfigSource == "dynamodb" && len(initCfg.TeamIDs) > 0:
+		if initCfg.TableName == "" {
+			return nil, fmt.Errorf("DYNAMODB_TABLE is required for the dynamodb config source")
+		}
+
+		// Seed channels can't be attributed to a workspace, so only the bot
+		// settings and feature flags are taken from the YAML file
+		var seed botconfig.Provider
+		if _, err := os.Stat(initCfg.ConfigPath); err == nil {
+			seed = botconfig.NewYAMLProvider(initCfg.ConfigPath)
+		}
+
+		initCfg.WatchConfig = true
+		return configprovider.NewTeamsProvider(
+			dynamodbstore.NewStore(dynamoClient(), initCfg.TableName, initCfg.TTLDays),
+			initCfg.TeamIDs,
+			configprovider.StoreProviderOptions{
+				TableName: initCfg.TableName,
+				Region:    awsCfg.Region,
+				Seed:      seed,
+			},
+		), nil
+
+	case initCfg.ConfigSource == "dynamodb":
+		if initCfg.TableName == "" || initCfg.TeamID == "" {
+			return nil, fmt.Errorf("DYNAMODB_TABLE and SLACK_TEAM_ID are required for the dynamodb config source")
+		}
+
+		// The YAML file is optional seed data for this source
+		var seed botconfig.Provider
+		if _, err := os.Stat(initCfg.ConfigPath); err == nil {
+			seed = botconfig.NewYAMLProvider(initCfg.ConfigPath)
+		}
+
+		provider := configprovider.NewStoreProvider(
+			dynamodbstore.NewStore(dynamoClient(), initCfg.TableName, initCfg.TTLDays),
+			configprovider.StoreProviderOptions{
+				TeamID:    initCfg.TeamID,
+				TableName: initCfg.TableName,
+				Region:    awsCfg.Region,
+				Seed:      seed,
+			},
+		)
+		initCfg.WatchConfig = true
+		return provider, nil
+
+	case initCfg.ConfigBucket != "":
+		initCfg.WatchConfig = true
+		return configprovider.NewS3Provider(
+			s3.NewFromConfig(awsCfg), initCfg.ConfigBucket, initCfg.ConfigKey, configprovider.DefaultPollInterval), nil
+
+	default:
+		return botconfig.NewYAMLProvider(initCfg.ConfigPath), nil
+	}
+}
+
+// newStore selects the data store from DATABASE_DRIVER, defaulting to DynamoDB.
+func newStore(ctx context.Context, initCfg *InitConfig, dynamoClient func() *dynamodb.Client) (store.Store, error) {
+	switch initCfg.DatabaseDriver {
+	case "", "dynamodb":
+		return dynamodbstore.NewStore(dynamoClient(), initCfg.TableName, initCfg.TTLDays), nil
+
+	case postgresstore.Driver:
+		if initCfg.DatabaseURL == "" {
+			return nil, fmt.Errorf("DATABASE_URL is required for the postgres driver")
+		}
+
+		db, err := postgresstore.Open(ctx, initCfg.DatabaseURL)
+		if err != nil {
+			return nil, err
+		}
+		return postgresstore.NewStore(db), nil
+
+	default:
+		return nil, fmt.Errorf("unknown database driver: %s", initCfg.DatabaseDriver)
+	}
+}
+
+// prewarmStore opens the store's connection with a cheap read. Failures are
+// only logged, as the first request to use the store tries again.
+func prewarmStore(ctx context.Context, botCtx botcontext.BotContext, dataStore store.Store) {
+	start := time.Now()
+	_, err := dataStore.GetHeartbeat(ctx, "", store.HeartbeatScheduler, "")
+	if err != nil && err != store.ErrNotFound {
+		botCtx.Logger().Warn(ctx, "Failed to prewarm the store",
+			botcontext.Field{Key: "error", Value: err.Error()},
+		)
+		return
+	}
+
+	botCtx.Logger().Info(ctx, "Prewarmed the store",
+		botcontext.Field{Key: "duration_ms", Value: time.Since(start).Milliseconds()},
+	)
+}
+
+// parseCacheTTL reads CACHE_TTL, a duration like "5m". Empty or invalid
+// values use the default; "0" turns caching off.
+func parseCacheTTL(value string) time.Duration {
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return cache.DefaultTTL
+	}
+	return ttl
+}
+
+// reloadConfig swaps in a changed configuration once it passes validation.
+func reloadConfig(ctx context.Context, botCtx botcontext.BotContext, validator botconfig.Validator, newCfg botconfig.Config) {
+	logger := botCtx.Logger()
+
+	if err := validator.Validate(newCfg); err != nil {
+		logger.Error(ctx, "Ignoring invalid configuration change", err)
+		return
+	}
+
+	// Install the config that was validated; loading it again could pick up
+	// a later, unvalidated change
+	if err := botCtx.SetConfig(newCfg); err != nil {
+		logger.Error(ctx, "Failed to reload configuration", err)
+		return
+	}
+
+	logger.Info(ctx, "Reloaded configuration",
+		botcontext.Field{Key: "version", Value: newCfg.Version()},
+	)
+}
+
 // dynamoDBClient wraps the store to implement botcontext.DynamoDBClient.
 type dynamoDBClient struct {
 	store store.Store
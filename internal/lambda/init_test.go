@@ -0,0 +1,77 @@
+package lambda
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	botcontext "github.com/synaptiq/standup-bot/context"
+)
+
+// staticProvider loads the same config every time.
+type staticProvider struct {
+	cfg botconfig.Config
+}
+
+func (p *staticProvider) Load() (botconfig.Config, error)    { return p.cfg, nil }
+func (p *staticProvider) Watch(func(botconfig.Config)) error { return nil }
+
+// parseConfig parses a valid config of the given version, followed by extra.
+func parseConfig(t *testing.T, version, extra string) botconfig.Config {
+	t.Helper()
+	cfg, err := botconfig.ParseYAML([]byte(`version: "` + version + `"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "standups"
+  region: "us-east-1"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+      reminder_times: ["09:00"]
+      active_days: ["Mon", "Tue", "Wed", "Thu", "Fri"]
+    users:
+      - id: "U1111111111"
+        name: "alice"
+    templates:
+      reminder: "{{.UserName}} {{.ChannelName}}"
+      summary_header: "{{.Date}}"
+      user_completed: "{{.UserName}} {{.Time}}"
+      user_missing: "{{.UserName}}"
+    questions: ["Yesterday?"]
+` + extra))
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestReloadConfig(t *testing.T) {
+	current := parseConfig(t, "1.0", "")
+	valid := parseConfig(t, "1.1", "")
+	invalid := parseConfig(t, "1.2", `features:
+  blockers_routing: true
+`)
+	validator := botconfig.NewValidator()
+	require.NoError(t, validator.Validate(valid))
+	require.Error(t, validator.Validate(invalid))
+
+	// The source has changed again since the watcher loaded its config
+	botCtx, err := botcontext.New(botcontext.Options{
+		Config:         current,
+		ConfigProvider: &staticProvider{cfg: invalid},
+		Logger:         botcontext.NewLogger(io.Discard, botcontext.LevelError, ""),
+	})
+	require.NoError(t, err)
+
+	reloadConfig(context.Background(), botCtx, validator, invalid)
+	assert.Same(t, current, botCtx.Config(), "an invalid config replaced the current one")
+
+	reloadConfig(context.Background(), botCtx, validator, valid)
+	assert.Same(t, valid, botCtx.Config(), "the config installed isn't the one validated")
+}
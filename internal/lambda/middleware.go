@@ -2,16 +2,26 @@ package lambda
 
 import (
 	"context"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"mime"
 	"runtime/debug"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/google/uuid"
 
 	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/inflight"
+	"github.com/synaptiq/standup-bot/internal/metrics"
 	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
 )
 
 // Handler is a function that processes Lambda requests.
@@ -37,7 +47,7 @@ func WithRequestID(botCtx botcontext.BotContext) Middleware {
 			ctx context.Context,
 			request events.APIGatewayProxyRequest,
 		) (events.APIGatewayProxyResponse, error) {
-			requestID := request.Headers["X-Request-ID"]
+			requestID := Header(&request, "X-Request-ID")
 			if requestID == "" {
 				requestID = uuid.New().String()
 			}
@@ -113,6 +123,35 @@ func WithTracing(botCtx botcontext.BotContext) Middleware {
 	}
 }
 
+// WithMetrics records each request's latency and status code to sink, by API
+// resource. Handler errors and 5xx responses are also counted as errors.
+func WithMetrics(sink metrics.Sink) Middleware {
+	return func(next Handler) Handler {
+		return func(
+			ctx context.Context,
+			request events.APIGatewayProxyRequest,
+		) (events.APIGatewayProxyResponse, error) {
+			start := time.Now()
+			response, err := next(ctx, request)
+
+			status := response.StatusCode
+			if err != nil && status == 0 {
+				status = 500
+			}
+
+			resource := metrics.Dimension{Name: "Resource", Value: request.Resource}
+			metrics.Timing(ctx, sink, "RequestLatency", time.Since(start), resource)
+			metrics.Count(ctx, sink, "Requests", 1, resource,
+				metrics.Dimension{Name: "StatusCode", Value: strconv.Itoa(status)})
+			if err != nil || status >= 500 {
+				metrics.Count(ctx, sink, "RequestErrors", 1, resource)
+			}
+
+			return response, err
+		}
+	}
+}
+
 // WithRecovery recovers from panics and returns 500.
 func WithRecovery(botCtx botcontext.BotContext) Middleware {
 	return func(next Handler) Handler {
@@ -136,6 +175,64 @@ func WithRecovery(botCtx botcontext.BotContext) Middleware {
 	}
 }
 
+// DefaultDeadlineMargin is how long before the Lambda timeout requests are
+// cut off, leaving time to log and respond.
+const DefaultDeadlineMargin = time.Second
+
+// WithDeadline cuts requests off margin before the invocation's deadline,
+// cancelling their Slack and AWS calls and returning 504 instead of letting
+// the Lambda time out. The calls still in flight are logged. Requests without
+// a deadline, as served locally, run unchanged.
+func WithDeadline(botCtx botcontext.BotContext, margin time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(
+			ctx context.Context,
+			request events.APIGatewayProxyRequest,
+		) (events.APIGatewayProxyResponse, error) {
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				return next(ctx, request)
+			}
+
+			ctx, cancel := context.WithDeadline(ctx, deadline.Add(-margin))
+			defer cancel()
+			ctx, calls := inflight.WithCalls(ctx)
+
+			type result struct {
+				response events.APIGatewayProxyResponse
+				err      error
+				panic    interface{}
+			}
+			done := make(chan result, 1)
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						done <- result{panic: r}
+					}
+				}()
+				response, err := next(ctx, request)
+				done <- result{response: response, err: err}
+			}()
+
+			select {
+			case r := <-done:
+				if r.panic != nil {
+					// Re-panic on the request's goroutine for WithRecovery
+					panic(r.panic)
+				}
+				return r.response, r.err
+			case <-ctx.Done():
+				botCtx.Logger().Error(ctx, "Request timed out", ctx.Err(),
+					botcontext.Field{Key: "path", Value: request.Path},
+					botcontext.Field{Key: "in_flight", Value: calls.Active()},
+					botcontext.Metric("RequestTimeouts", 1),
+				)
+				return GatewayTimeout("Request timed out"), nil
+			}
+		}
+	}
+}
+
 // WithCORS adds CORS headers.
 func WithCORS(allowedOrigins []string) Middleware {
 	return func(next Handler) Handler {
@@ -150,7 +247,7 @@ func WithCORS(allowedOrigins []string) Middleware {
 				response.Headers = make(map[string]string)
 			}
 
-			origin := request.Headers["Origin"]
+			origin := Header(&request, "Origin")
 			allowed := false
 			for _, allowedOrigin := range allowedOrigins {
 				if allowedOrigin == "*" || allowedOrigin == origin {
@@ -171,6 +268,169 @@ func WithCORS(allowedOrigins []string) Middleware {
 	}
 }
 
+// WithBearerAuth rejects requests whose Authorization header doesn't carry
+// the given bearer token.
+func WithBearerAuth(token string) Middleware {
+	return func(next Handler) Handler {
+		return func(
+			ctx context.Context,
+			request events.APIGatewayProxyRequest,
+		) (events.APIGatewayProxyResponse, error) {
+			provided, found := strings.CutPrefix(Header(&request, "Authorization"), "Bearer ")
+			if !found || token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				return Unauthorized("Invalid or missing API token"), nil
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// WithSlackVerification rejects requests without a valid Slack signature.
+// Base64-encoded bodies are decoded first, as the signature covers the raw
+// body, and handlers further down receive the decoded body. Failures are
+// counted in sink by reason. A nil verifier skips the signature check; local
+// development only.
+func WithSlackVerification(
+	botCtx botcontext.BotContext,
+	verifier *slack.RequestVerifier,
+	sink metrics.Sink,
+) Middleware {
+	return func(next Handler) Handler {
+		return func(
+			ctx context.Context,
+			request events.APIGatewayProxyRequest,
+		) (events.APIGatewayProxyResponse, error) {
+			logger := botCtx.Logger()
+
+			if request.IsBase64Encoded {
+				body, err := base64.StdEncoding.DecodeString(request.Body)
+				if err != nil {
+					logger.Warn(ctx, "Slack request verification failed",
+						botcontext.Field{Key: "reason", Value: "encoding"},
+					)
+					metrics.Count(ctx, sink, "SignatureVerificationFailures", 1,
+						metrics.Dimension{Name: "Reason", Value: "encoding"})
+					return BadRequest("Invalid request body"), nil
+				}
+				request.Body = string(body)
+				request.IsBase64Encoded = false
+			}
+
+			if verifier == nil {
+				return next(ctx, request)
+			}
+
+			timestamp := Header(&request, "X-Slack-Request-Timestamp")
+			signature := Header(&request, "X-Slack-Signature")
+			if err := verifier.VerifyRequest(timestamp, signature, request.Body); err != nil {
+				reason := verificationFailureReason(err)
+				logger.Warn(ctx, "Slack request verification failed",
+					botcontext.Field{Key: "reason", Value: reason},
+					botcontext.Field{Key: "source_ip", Value: request.RequestContext.Identity.SourceIP},
+				)
+				metrics.Count(ctx, sink, "SignatureVerificationFailures", 1,
+					metrics.Dimension{Name: "Reason", Value: reason})
+				return Unauthorized("Invalid request signature"), nil
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// verificationFailureReason names why a signature was rejected, for logs and
+// metrics.
+func verificationFailureReason(err error) string {
+	switch {
+	case errors.Is(err, slack.ErrMissingSignature):
+		return "missing"
+	case errors.Is(err, slack.ErrStaleTimestamp):
+		return "stale"
+	case errors.Is(err, slack.ErrUnsupportedVersion):
+		return "version"
+	case errors.Is(err, slack.ErrInvalidSignature):
+		return "mismatch"
+	default:
+		return "malformed"
+	}
+}
+
+// Header returns the value of a request header. Names are matched without
+// regard to case, as API Gateway HTTP APIs deliver them lowercased.
+func Header(request *events.APIGatewayProxyRequest, name string) string {
+	if value, ok := request.Headers[name]; ok {
+		return value
+	}
+	for key, value := range request.Headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// WithIdempotency drops Slack event redeliveries. Events API callbacks are
+// claimed by event_id before they're processed, and those already claimed are
+// acknowledged without being processed again. The claim is released when
+// processing fails, so Slack's retries of failed deliveries are processed.
+func WithIdempotency(botCtx botcontext.BotContext, dataStore store.Store) Middleware {
+	return func(next Handler) Handler {
+		return func(
+			ctx context.Context,
+			request events.APIGatewayProxyRequest,
+		) (events.APIGatewayProxyResponse, error) {
+			var envelope struct {
+				Type    string `json:"type"`
+				TeamID  string `json:"team_id"`
+				EventID string `json:"event_id"`
+				Event   struct {
+					Type string `json:"type"`
+				} `json:"event"`
+			}
+			mediaType, _, err := mime.ParseMediaType(Header(&request, "Content-Type"))
+			if err != nil || mediaType != "application/json" ||
+				json.Unmarshal([]byte(request.Body), &envelope) != nil ||
+				envelope.Type != "event_callback" || envelope.EventID == "" {
+				return next(ctx, request)
+			}
+
+			logger := botCtx.Logger()
+			fields := []botcontext.Field{
+				{Key: "event_id", Value: security.SanitizeLogValue(envelope.EventID)},
+				{Key: "retry_num", Value: security.SanitizeLogValue(Header(&request, "X-Slack-Retry-Num"))},
+				{Key: "retry_reason", Value: security.SanitizeLogValue(Header(&request, "X-Slack-Retry-Reason"))},
+			}
+
+			event := &store.ProcessedEvent{
+				EventID:     envelope.EventID,
+				TeamID:      envelope.TeamID,
+				EventType:   envelope.Event.Type,
+				ProcessedAt: time.Now(),
+			}
+			err = dataStore.SaveProcessedEvent(ctx, event)
+			if errors.Is(err, store.ErrAlreadyExists) {
+				logger.Info(ctx, "Dropping duplicate event delivery", fields...)
+				return OK(""), nil
+			}
+			claimed := err == nil
+			if !claimed {
+				// Fail open: processing twice beats dropping an event
+				logger.Error(ctx, "Failed to claim event", err, fields...)
+			}
+
+			response, err := next(ctx, request)
+			if claimed && (err != nil || response.StatusCode >= 300) {
+				if err := dataStore.DeleteProcessedEvent(ctx, envelope.EventID); err != nil {
+					logger.Error(ctx, "Failed to release event", err, fields...)
+				}
+			}
+
+			return response, err
+		}
+	}
+}
+
 // ParseBody parses the request body into the given interface.
 //
 //nolint:gocritic // hugeParam: consistent with handler signatures
@@ -199,7 +459,7 @@ func ExtractUserID(request *events.APIGatewayProxyRequest) string {
 	}
 
 	// Check headers (for authenticated requests)
-	if userID := request.Headers["X-User-ID"]; userID != "" {
+	if userID := Header(request, "X-User-ID"); userID != "" {
 		return userID
 	}
 
@@ -213,5 +473,6 @@ func StandardMiddleware(botCtx botcontext.BotContext) Middleware {
 		WithRequestID(botCtx),
 		WithLogging(botCtx),
 		WithTracing(botCtx),
+		WithDeadline(botCtx, DefaultDeadlineMargin),
 	)
 }
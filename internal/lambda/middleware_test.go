@@ -0,0 +1,229 @@
+package lambda
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/inflight"
+	"github.com/synaptiq/standup-bot/internal/metrics"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store/memory"
+)
+
+func newTestBotContext(t *testing.T) botcontext.BotContext {
+	t.Helper()
+	cfg, err := botconfig.ParseYAML([]byte(`version: "1.0"
+bot:
+  token: "xoxb-test"
+database:
+  table_name: "test"
+  region: "us-east-1"
+`))
+	require.NoError(t, err)
+
+	botCtx, err := botcontext.New(botcontext.Options{
+		Config: cfg,
+		Logger: botcontext.NewLogger(io.Discard, botcontext.LevelError, "test"),
+	})
+	require.NoError(t, err)
+	return botCtx
+}
+
+func sign(secret, timestamp, body string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte("v0:" + timestamp + ":" + body))
+	return "v0=" + hex.EncodeToString(h.Sum(nil))
+}
+
+func TestWithSlackVerification(t *testing.T) {
+	const secret = "test-secret"
+	const body = "command=%2Fstandup&text=help"
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	var received string
+	sink := &recordingSink{}
+	handler := WithSlackVerification(newTestBotContext(t), slack.NewRequestVerifier(secret), sink)(
+		func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			received = request.Body
+			return OK(""), nil
+		})
+
+	t.Run("lowercase headers and base64 body", func(t *testing.T) {
+		received = ""
+		response, err := handler(context.Background(), events.APIGatewayProxyRequest{
+			Headers: map[string]string{
+				"x-slack-request-timestamp": timestamp,
+				"x-slack-signature":         sign(secret, timestamp, body),
+			},
+			Body:            base64.StdEncoding.EncodeToString([]byte(body)),
+			IsBase64Encoded: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, body, received)
+	})
+
+	for name, headers := range map[string]map[string]string{
+		"missing headers": {},
+		"wrong signature": {
+			"X-Slack-Request-Timestamp": timestamp,
+			"X-Slack-Signature":         sign("other-secret", timestamp, body),
+		},
+		"stale timestamp": {
+			"X-Slack-Request-Timestamp": "1000000000",
+			"X-Slack-Signature":         sign(secret, "1000000000", body),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			received = ""
+			response, err := handler(context.Background(), events.APIGatewayProxyRequest{Headers: headers, Body: body})
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			assert.Empty(t, received)
+		})
+	}
+
+	assert.Equal(t, []string{
+		"SignatureVerificationFailures Reason=mismatch",
+		"SignatureVerificationFailures Reason=missing",
+		"SignatureVerificationFailures Reason=stale",
+	}, sink.sortedNames())
+}
+
+func TestWithMetrics(t *testing.T) {
+	sink := &recordingSink{}
+	handler := WithMetrics(sink)(
+		func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			if request.Resource == "/fail" {
+				return events.APIGatewayProxyResponse{}, errors.New("boom")
+			}
+			return OK(""), nil
+		})
+
+	_, err := handler(context.Background(), events.APIGatewayProxyRequest{Resource: "/ok"})
+	require.NoError(t, err)
+	_, err = handler(context.Background(), events.APIGatewayProxyRequest{Resource: "/fail"})
+	require.Error(t, err)
+
+	assert.Equal(t, []string{
+		"RequestErrors Resource=/fail",
+		"RequestLatency Resource=/fail",
+		"RequestLatency Resource=/ok",
+		"Requests Resource=/fail StatusCode=500",
+		"Requests Resource=/ok StatusCode=200",
+	}, sink.sortedNames())
+}
+
+func TestWithIdempotency(t *testing.T) {
+	const body = `{"type":"event_callback","team_id":"T1234567890","event_id":"Ev0123ABCD","event":{"type":"message"}}`
+
+	handled := 0
+	fail := true
+	handler := WithIdempotency(newTestBotContext(t), memory.NewStore())(
+		func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			handled++
+			if fail {
+				return InternalServerError("boom"), nil
+			}
+			return OK(""), nil
+		})
+	deliver := func() {
+		t.Helper()
+		_, err := handler(context.Background(), events.APIGatewayProxyRequest{
+			Headers: map[string]string{"Content-Type": "application/json; charset=utf-8"},
+			Body:    body,
+		})
+		require.NoError(t, err)
+	}
+
+	// A failed delivery is processed again when Slack retries it
+	deliver()
+	fail = false
+	deliver()
+	assert.Equal(t, 2, handled)
+
+	// Once processed, redeliveries are dropped
+	deliver()
+	assert.Equal(t, 2, handled)
+}
+
+func TestWithDeadline(t *testing.T) {
+	handler := WithDeadline(newTestBotContext(t), 50*time.Millisecond)(
+		func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			if request.Path == "/slow" {
+				defer inflight.Start(ctx, "DynamoDB.Query")()
+				<-ctx.Done()
+				return events.APIGatewayProxyResponse{}, ctx.Err()
+			}
+			return OK(""), nil
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	response, err := handler(ctx, events.APIGatewayProxyRequest{Path: "/slow"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusGatewayTimeout, response.StatusCode)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	response, err = handler(ctx, events.APIGatewayProxyRequest{Path: "/fast"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	// Requests without a deadline aren't cut off
+	response, err = handler(context.Background(), events.APIGatewayProxyRequest{Path: "/fast"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+// recordingSink records the name and dimensions of each metric.
+type recordingSink struct {
+	names []string
+}
+
+func (s *recordingSink) Record(_ context.Context, name string, _ float64, _ metrics.Unit, dims ...metrics.Dimension) {
+	for _, d := range dims {
+		name += " " + d.Name + "=" + d.Value
+	}
+	s.names = append(s.names, name)
+}
+
+func (s *recordingSink) sortedNames() []string {
+	return slices.Sorted(slices.Values(s.names))
+}
+
+func TestVerificationFailureReason(t *testing.T) {
+	verifier := slack.NewRequestVerifier("test-secret")
+
+	assert.Equal(t, "missing", verificationFailureReason(verifier.VerifyRequest("", "", "")))
+	assert.Equal(t, "malformed", verificationFailureReason(verifier.VerifyRequest("soon", "v0=00", "")))
+	assert.Equal(t, "stale", verificationFailureReason(verifier.VerifyRequest("1000000000", "v0=00", "")))
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	assert.Equal(t, "version", verificationFailureReason(verifier.VerifyRequest(now, "v1=00", "")))
+	assert.Equal(t, "mismatch", verificationFailureReason(verifier.VerifyRequest(now, "v0=00", "")))
+}
+
+func TestHeader(t *testing.T) {
+	request := &events.APIGatewayProxyRequest{Headers: map[string]string{"content-type": "application/json"}}
+
+	assert.Equal(t, "application/json", Header(request, "Content-Type"))
+	assert.Empty(t, Header(request, "X-Request-ID"))
+}
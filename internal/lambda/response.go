@@ -83,6 +83,13 @@ func InternalServerError(message string) events.APIGatewayProxyResponse {
 	})
 }
 
+// GatewayTimeout returns a 504 Gateway Timeout response.
+func GatewayTimeout(message string) events.APIGatewayProxyResponse {
+	return Response(http.StatusGatewayTimeout, map[string]string{
+		"error": message,
+	})
+}
+
 // SlackResponse returns a response formatted for Slack.
 func SlackResponse(text string) events.APIGatewayProxyResponse {
 	return OK(map[string]interface{}{
@@ -106,3 +113,59 @@ func SlackBlockResponse(blocks interface{}) events.APIGatewayProxyResponse {
 		"blocks":        blocks,
 	})
 }
+
+// SlackEphemeralBlockResponse returns an ephemeral block-formatted response for Slack.
+func SlackEphemeralBlockResponse(blocks interface{}) events.APIGatewayProxyResponse {
+	return OK(map[string]interface{}{
+		"response_type": "ephemeral",
+		"blocks":        blocks,
+	})
+}
+
+// SlackViewErrors answers a modal submission with errors to show next to its
+// inputs, keyed by block ID, keeping the modal open.
+func SlackViewErrors(errors map[string]string) events.APIGatewayProxyResponse {
+	return OK(map[string]interface{}{
+		"response_action": "errors",
+		"errors":          errors,
+	})
+}
+
+// SlackViewUpdate answers a modal submission by replacing the modal with view,
+// e.g. to show the next step of a form.
+func SlackViewUpdate(view interface{}) events.APIGatewayProxyResponse {
+	return OK(map[string]interface{}{
+		"response_action": "update",
+		"view":            view,
+	})
+}
+
+// SlackViewPush answers a modal submission by pushing view on top of the
+// modal, e.g. a confirmation screen. Closing it returns to the modal.
+func SlackViewPush(view interface{}) events.APIGatewayProxyResponse {
+	return OK(map[string]interface{}{
+		"response_action": "push",
+		"view":            view,
+	})
+}
+
+// Redirect returns a 302 Found response redirecting to location.
+func Redirect(location string) events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusFound,
+		Headers: map[string]string{
+			"Location": location,
+		},
+	}
+}
+
+// HTML returns a response with an HTML page, for requests from a browser.
+func HTML(statusCode int, body string) events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Headers: map[string]string{
+			"Content-Type": "text/html; charset=utf-8",
+		},
+		Body: body,
+	}
+}
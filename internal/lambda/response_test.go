@@ -0,0 +1,42 @@
+package lambda
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlackViewResponses(t *testing.T) {
+	view := map[string]string{"type": "modal", "callback_id": "confirm"}
+
+	tests := []struct {
+		name     string
+		response events.APIGatewayProxyResponse
+		want     string
+	}{
+		{
+			name:     "errors",
+			response: SlackViewErrors(map[string]string{"question_0": "Required"}),
+			want:     `{"response_action": "errors", "errors": {"question_0": "Required"}}`,
+		},
+		{
+			name:     "update",
+			response: SlackViewUpdate(view),
+			want:     `{"response_action": "update", "view": {"type": "modal", "callback_id": "confirm"}}`,
+		},
+		{
+			name:     "push",
+			response: SlackViewPush(view),
+			want:     `{"response_action": "push", "view": {"type": "modal", "callback_id": "confirm"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, http.StatusOK, tt.response.StatusCode)
+			assert.JSONEq(t, tt.want, tt.response.Body)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Package metrics records counters and timings, published to CloudWatch by
+// default.
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+)
+
+// Unit is the unit of a metric value.
+type Unit string
+
+// Metric units, as CloudWatch names them.
+const (
+	UnitCount        Unit = "Count"
+	UnitMilliseconds Unit = "Milliseconds"
+)
+
+// Dimension narrows a metric, e.g. to one API path.
+type Dimension struct {
+	Name  string
+	Value string
+}
+
+// Sink records metrics.
+type Sink interface {
+	Record(ctx context.Context, name string, value float64, unit Unit, dimensions ...Dimension)
+}
+
+// Count records value occurrences of name.
+func Count(ctx context.Context, sink Sink, name string, value float64, dimensions ...Dimension) {
+	sink.Record(ctx, name, value, UnitCount, dimensions...)
+}
+
+// Timing records how long something named name took.
+func Timing(ctx context.Context, sink Sink, name string, d time.Duration, dimensions ...Dimension) {
+	sink.Record(ctx, name, float64(d.Microseconds())/1000, UnitMilliseconds, dimensions...)
+}
+
+// Discard is a sink that drops every metric.
+var Discard Sink = discard{}
+
+type discard struct{}
+
+func (discard) Record(context.Context, string, float64, Unit, ...Dimension) {}
+
+// EMFSink writes metrics to CloudWatch Logs in embedded metric format, which
+// CloudWatch turns into metrics without any API calls.
+type EMFSink struct {
+	mu        sync.Mutex
+	out       io.Writer
+	namespace string
+}
+
+// NewEMFSink creates a sink writing to out under namespace, or under
+// botcontext.DefaultMetricsNamespace if namespace is empty.
+func NewEMFSink(out io.Writer, namespace string) *EMFSink {
+	if namespace == "" {
+		namespace = botcontext.DefaultMetricsNamespace
+	}
+	return &EMFSink{out: out, namespace: namespace}
+}
+
+// Default returns an EMF sink writing to stdout, under the namespace set by
+// METRICS_NAMESPACE as for logged metrics.
+func Default() Sink {
+	return NewEMFSink(os.Stdout, os.Getenv("METRICS_NAMESPACE"))
+}
+
+// Record writes one EMF entry for the metric.
+func (s *EMFSink) Record(_ context.Context, name string, value float64, unit Unit, dimensions ...Dimension) {
+	entry := make(map[string]interface{}, len(dimensions)+2)
+	names := make([]string, 0, len(dimensions))
+	for _, d := range dimensions {
+		entry[d.Name] = d.Value
+		names = append(names, d.Name)
+	}
+	entry[name] = value
+	entry["_aws"] = map[string]interface{}{
+		"Timestamp": time.Now().UnixMilli(),
+		"CloudWatchMetrics": []map[string]interface{}{{
+			"Namespace":  s.namespace,
+			"Dimensions": [][]string{names},
+			"Metrics":    []map[string]string{{"Name": name, "Unit": string(unit)}},
+		}},
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.out.Write(append(line, '\n')) //nolint:errcheck // nowhere to report metric failures
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEMFSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewEMFSink(&buf, "")
+
+	Timing(context.Background(), sink, "RequestLatency", 1500*time.Microsecond,
+		Dimension{Name: "Resource", Value: "/slack/events"})
+
+	var entry struct {
+		Resource       string  `json:"Resource"`
+		RequestLatency float64 `json:"RequestLatency"`
+		AWS            struct {
+			CloudWatchMetrics []struct {
+				Namespace  string              `json:"Namespace"`
+				Dimensions [][]string          `json:"Dimensions"`
+				Metrics    []map[string]string `json:"Metrics"`
+			} `json:"CloudWatchMetrics"`
+		} `json:"_aws"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+	assert.Equal(t, "/slack/events", entry.Resource)
+	assert.InDelta(t, 1.5, entry.RequestLatency, 0.001)
+	require.Len(t, entry.AWS.CloudWatchMetrics, 1)
+	directive := entry.AWS.CloudWatchMetrics[0]
+	assert.Equal(t, "StandupBot", directive.Namespace)
+	assert.Equal(t, [][]string{{"Resource"}}, directive.Dimensions)
+	assert.Equal(t, []map[string]string{{"Name": "RequestLatency", "Unit": "Milliseconds"}}, directive.Metrics)
+}
@@ -0,0 +1,80 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+// SESClient defines the SES operations used by EmailNotifier.
+type SESClient interface {
+	SendEmail(ctx context.Context, params *sesv2.SendEmailInput,
+		optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error)
+}
+
+// EmailNotifier sends summaries to a list of addresses through SES, and
+// reminders to each user's email address.
+type EmailNotifier struct {
+	client     SESClient
+	from       string
+	recipients []string
+}
+
+// NewEmailNotifier creates a notifier sending from the verified address from
+// to recipients, e.g. a team mailing list.
+func NewEmailNotifier(client SESClient, from string, recipients []string) *EmailNotifier {
+	return &EmailNotifier{
+		client:     client,
+		from:       from,
+		recipients: recipients,
+	}
+}
+
+// Name returns "email".
+func (n *EmailNotifier) Name() string {
+	return "email"
+}
+
+// NotifyReminder emails the reminder to the user, if their address is known.
+func (n *EmailNotifier) NotifyReminder(ctx context.Context, reminder *Reminder) (string, error) {
+	if reminder.UserEmail == "" {
+		return "", nil
+	}
+
+	subject := fmt.Sprintf("Standup reminder for #%s", reminder.ChannelName)
+	return n.send(ctx, []string{reminder.UserEmail}, subject, reminder.Text())
+}
+
+// NotifySummary emails the summary to the recipients.
+func (n *EmailNotifier) NotifySummary(ctx context.Context, summary *Summary) (string, error) {
+	subject := summary.Title()
+	if summary.ChannelName != "" {
+		subject = fmt.Sprintf("#%s: %s", summary.ChannelName, subject)
+	}
+
+	return n.send(ctx, n.recipients, subject, summary.Text())
+}
+
+// send sends a plain text email and returns its SES message ID.
+func (n *EmailNotifier) send(ctx context.Context, to []string, subject, body string) (string, error) {
+	out, err := n.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(n.from),
+		Destination:      &types.Destination{ToAddresses: to},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Subject: &types.Content{Data: aws.String(subject), Charset: aws.String("UTF-8")},
+				Body: &types.Body{
+					Text: &types.Content{Data: aws.String(body), Charset: aws.String("UTF-8")},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return aws.ToString(out.MessageId), nil
+}
@@ -0,0 +1,214 @@
+// Package notify delivers standup reminders and summaries. Slack is the
+// default destination; email and webhook notifiers mirror deliveries to
+// mailing lists and other chat systems.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+
+	"github.com/synaptiq/standup-bot/internal/i18n"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/templates"
+)
+
+// Notifier delivers standup reminders and summaries.
+type Notifier interface {
+	// Name identifies the notifier in logs.
+	Name() string
+
+	// NotifyReminder reminds a user to submit their standup. It returns an
+	// ID of the delivered message, if the notifier can update it later.
+	NotifyReminder(ctx context.Context, reminder *Reminder) (string, error)
+
+	// NotifySummary delivers a channel's daily summary. It returns an ID of
+	// the delivered message, if the notifier can update it later.
+	NotifySummary(ctx context.Context, summary *Summary) (string, error)
+}
+
+// Reminder asks a user to submit today's standup.
+type Reminder struct {
+	UserID      string
+	UserName    string
+	UserEmail   string // From the user's Slack profile, if known
+	ChannelID   string
+	ChannelName string
+	Template    string // The channel's reminder template
+	Status      slack.ReminderStatus
+}
+
+// Text renders the reminder's template.
+func (r *Reminder) Text() string {
+	return templates.Render(r.Template, templates.Vars{"UserName": r.UserName, "ChannelName": r.ChannelName})
+}
+
+// Blocks renders the reminder as a Slack message.
+func (r *Reminder) Blocks() []slack.Block {
+	return slack.BuildReminderMessage(r.UserName, r.ChannelName, r.ChannelID, r.Template, r.Status)
+}
+
+// Summary is a channel's daily standup summary.
+type Summary struct {
+	ChannelID   string
+	ChannelName string
+	Date        string
+	Header      string // The channel's summary header template
+	Users       []*slack.UserResponseSummary
+
+	// Grouping lays out answers with summary_include_answers; empty lists
+	// names only
+	Grouping   slack.SummaryGrouping
+	ThreadLink string // Permalink to the daily thread, if there is one
+
+	// Review adds a button for leads to mark the summary reviewed, with
+	// summary_reviews
+	Review     bool
+	ReviewedBy []string
+
+	// Streaks are the users who reached a streak milestone today
+	Streaks []slack.StreakMilestone
+
+	Locale i18n.Locale // The channel's locale
+}
+
+// Title renders the summary's header template.
+func (s *Summary) Title() string {
+	return templates.Render(s.Header, templates.Vars{"Date": s.Date})
+}
+
+// Blocks renders the summary as a Slack message.
+func (s *Summary) Blocks() []slack.Block {
+	var blocks []slack.Block
+	if s.Grouping != "" {
+		blocks = slack.BuildDigestMessage(s.Date, s.Header, s.Users, s.Grouping, s.ThreadLink, s.Locale)
+	} else {
+		blocks = slack.BuildSummaryMessage(s.Date, s.Header, s.Users, s.Locale)
+	}
+	if len(s.Streaks) > 0 {
+		blocks = append(blocks, slack.BuildStreakMilestones(s.Streaks, s.Locale)...)
+	}
+	if s.Review {
+		blocks = append(blocks, slack.BuildSummaryReview(s.ChannelID, s.Date, s.ReviewedBy)...)
+	}
+	return blocks
+}
+
+// Text renders the summary as plain text, using names instead of Slack
+// mentions so it reads well outside Slack.
+func (s *Summary) Text() string {
+	var submitted, skipped, missing, streaks []string
+	for _, user := range s.Users {
+		switch {
+		case user.Submitted:
+			line := fmt.Sprintf("- %s - %s", userName(user), user.Time)
+			for _, answer := range user.Answers {
+				line += fmt.Sprintf("\n  %s %s", answer.Question, strings.Join(strings.Fields(answer.Text), " "))
+			}
+			submitted = append(submitted, line)
+		case user.Skipped:
+			line := "- " + userName(user)
+			if user.SkipReason != "" {
+				line += " - " + user.SkipReason
+			}
+			skipped = append(skipped, line)
+		default:
+			missing = append(missing, "- "+userName(user))
+		}
+	}
+
+	for _, milestone := range s.Streaks {
+		name := milestone.UserName
+		if name == "" {
+			name = milestone.UserID
+		}
+		streaks = append(streaks, fmt.Sprintf("- %s - %d standups in a row", name, milestone.Days))
+	}
+
+	sections := []string{s.Title()}
+	if len(s.Users) == 0 {
+		sections = append(sections, "No responses yet today.")
+	}
+	for _, section := range []struct {
+		title string
+		lines []string
+	}{
+		{"Submitted:", submitted},
+		{"Skipped:", skipped},
+		{"Pending:", missing},
+		{"Streaks:", streaks},
+	} {
+		if len(section.lines) > 0 {
+			sections = append(sections, section.title+"\n"+strings.Join(section.lines, "\n"))
+		}
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+func userName(user *slack.UserResponseSummary) string {
+	if user.UserName != "" {
+		return user.UserName
+	}
+	return user.UserID
+}
+
+// summariesOnly is a notifier that ignores reminders.
+type summariesOnly struct {
+	Notifier
+}
+
+// SummariesOnly wraps a notifier so it only receives summaries.
+func SummariesOnly(n Notifier) Notifier {
+	return summariesOnly{n}
+}
+
+func (summariesOnly) NotifyReminder(context.Context, *Reminder) (string, error) {
+	return "", nil
+}
+
+// FromEnv creates the notifiers mirroring deliveries besides Slack:
+//   - NOTIFY_EMAIL_FROM and NOTIFY_EMAIL_TO (comma-separated) email summaries
+//     through SES
+//   - NOTIFY_WEBHOOK_URL posts summaries to a webhook
+//
+// Mirrors only receive reminders too with NOTIFY_REMINDERS=true.
+func FromEnv(ctx context.Context) ([]Notifier, error) {
+	var notifiers []Notifier
+
+	from, to := os.Getenv("NOTIFY_EMAIL_FROM"), splitList(os.Getenv("NOTIFY_EMAIL_TO"))
+	if from != "" && len(to) > 0 {
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		notifiers = append(notifiers, NewEmailNotifier(sesv2.NewFromConfig(awsCfg), from, to))
+	}
+
+	if webhookURL := os.Getenv("NOTIFY_WEBHOOK_URL"); webhookURL != "" {
+		notifiers = append(notifiers, NewWebhookNotifier(webhookURL))
+	}
+
+	if os.Getenv("NOTIFY_REMINDERS") != "true" {
+		for i, n := range notifiers {
+			notifiers[i] = SummariesOnly(n)
+		}
+	}
+
+	return notifiers, nil
+}
+
+// splitList splits a comma-separated list, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+func testSummary() *Summary {
+	return &Summary{
+		ChannelID:   "C1234567890",
+		ChannelName: "engineering",
+		Date:        "2024-01-15",
+		Header:      "Daily Standup Summary for {{.Date}}",
+		Users: []*slack.UserResponseSummary{
+			{UserID: "U0000000001", UserName: "alice", Submitted: true, Time: "9:05 AM"},
+			{UserID: "U0000000002", UserName: "bob", Skipped: true, SkipReason: "Out sick"},
+			{UserID: "U0000000003"},
+		},
+	}
+}
+
+func TestSummaryText(t *testing.T) {
+	assert.Equal(t, "Daily Standup Summary for 2024-01-15\n\n"+
+		"Submitted:\n- alice - 9:05 AM\n\n"+
+		"Skipped:\n- bob - Out sick\n\n"+
+		"Pending:\n- U0000000003", testSummary().Text())
+
+	// Answers are listed under their author
+	summary := testSummary()
+	summary.Users[0].Answers = []slack.SummaryAnswer{{Question: "Today?", Text: "Release\nprep"}}
+	assert.Contains(t, summary.Text(), "- alice - 9:05 AM\n  Today? Release prep\n")
+
+	// Streak milestones come last
+	summary.Streaks = []slack.StreakMilestone{{UserID: "U0000000001", UserName: "alice", Days: 10}}
+	assert.True(t, strings.HasSuffix(summary.Text(), "\n\nStreaks:\n- alice - 10 standups in a row"))
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var received webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	n := NewWebhookNotifier(server.URL)
+	_, err := n.NotifySummary(context.Background(), testSummary())
+	require.NoError(t, err)
+
+	assert.Equal(t, EventSummary, received.Event)
+	assert.Equal(t, testSummary().Text(), received.Text)
+	require.Len(t, received.Users, 3)
+	assert.Equal(t, "submitted", received.Users[0].Status)
+	assert.Equal(t, "skipped", received.Users[1].Status)
+	assert.Equal(t, "pending", received.Users[2].Status)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer failing.Close()
+	_, err = NewWebhookNotifier(failing.URL).NotifySummary(context.Background(), testSummary())
+	assert.Error(t, err)
+}
+
+// fakeSES records sent emails.
+type fakeSES struct {
+	sent []*sesv2.SendEmailInput
+}
+
+func (f *fakeSES) SendEmail(ctx context.Context, params *sesv2.SendEmailInput,
+	optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error) {
+	f.sent = append(f.sent, params)
+	return &sesv2.SendEmailOutput{MessageId: aws.String("msg-1")}, nil
+}
+
+func TestEmailNotifier(t *testing.T) {
+	ctx := context.Background()
+	ses := &fakeSES{}
+	n := NewEmailNotifier(ses, "standup@example.com", []string{"eng@example.com"})
+
+	id, err := n.NotifySummary(ctx, testSummary())
+	require.NoError(t, err)
+	assert.Equal(t, "msg-1", id)
+	require.Len(t, ses.sent, 1)
+	assert.Equal(t, []string{"eng@example.com"}, ses.sent[0].Destination.ToAddresses)
+	assert.Equal(t, "#engineering: Daily Standup Summary for 2024-01-15",
+		aws.ToString(ses.sent[0].Content.Simple.Subject.Data))
+
+	// Reminders go to the user, and only when their address is known
+	reminder := &Reminder{UserName: "alice", ChannelName: "engineering",
+		Template: "Hey {{.UserName}}! Don't forget #{{.ChannelName}}"}
+	id, err = n.NotifyReminder(ctx, reminder)
+	require.NoError(t, err)
+	assert.Empty(t, id)
+	assert.Len(t, ses.sent, 1)
+
+	reminder.UserEmail = "alice@example.com"
+	id, err = n.NotifyReminder(ctx, reminder)
+	require.NoError(t, err)
+	assert.Equal(t, "msg-1", id)
+	require.Len(t, ses.sent, 2)
+	assert.Equal(t, []string{"alice@example.com"}, ses.sent[1].Destination.ToAddresses)
+	assert.Equal(t, "Hey alice! Don't forget #engineering", aws.ToString(ses.sent[1].Content.Simple.Body.Text.Data))
+
+	// Mirrors ignore reminders unless asked for them
+	_, err = SummariesOnly(n).NotifyReminder(ctx, reminder)
+	require.NoError(t, err)
+	assert.Len(t, ses.sent, 2)
+}
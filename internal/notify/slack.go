@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+// SlackNotifier delivers reminders as DMs and summaries to the standup
+// channel.
+type SlackNotifier struct {
+	client slack.Client
+}
+
+// NewSlackNotifier creates a notifier posting with client.
+func NewSlackNotifier(client slack.Client) *SlackNotifier {
+	return &SlackNotifier{client: client}
+}
+
+// Name returns "slack".
+func (n *SlackNotifier) Name() string {
+	return "slack"
+}
+
+// NotifyReminder DMs the reminder and returns the message timestamp.
+func (n *SlackNotifier) NotifyReminder(ctx context.Context, reminder *Reminder) (string, error) {
+	dmChannel, err := n.client.OpenDM(ctx, reminder.UserID)
+	if err != nil {
+		return "", fmt.Errorf("failed to open DM: %w", err)
+	}
+
+	msgTS, err := n.client.PostMessage(ctx, dmChannel, slack.WithBlocks(reminder.Blocks()...))
+	if err != nil {
+		return "", fmt.Errorf("failed to send reminder: %w", err)
+	}
+
+	return msgTS, nil
+}
+
+// NotifySummary posts the summary to the standup channel and returns the
+// message timestamp.
+func (n *SlackNotifier) NotifySummary(ctx context.Context, summary *Summary) (string, error) {
+	msgTS, err := n.client.PostMessage(ctx, summary.ChannelID, slack.WithBlocks(summary.Blocks()...))
+	if err != nil {
+		return "", fmt.Errorf("failed to post summary: %w", err)
+	}
+	return msgTS, nil
+}
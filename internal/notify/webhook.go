@@ -0,0 +1,130 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/security"
+)
+
+// Webhook event types.
+const (
+	EventReminder = "reminder"
+	EventSummary  = "summary"
+)
+
+// WebhookNotifier posts deliveries as JSON to a URL. The rendered message is
+// in the top-level "text" field, which incoming webhooks of Microsoft Teams,
+// Mattermost and similar chat systems display as is.
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier creates a notifier posting to url.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url: url,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// webhookPayload is the JSON body of a webhook delivery.
+type webhookPayload struct {
+	Event       string        `json:"event"`
+	Text        string        `json:"text"`
+	ChannelID   string        `json:"channel_id"`
+	ChannelName string        `json:"channel_name,omitempty"`
+	Date        string        `json:"date,omitempty"`
+	UserID      string        `json:"user_id,omitempty"`
+	Users       []webhookUser `json:"users,omitempty"`
+}
+
+// webhookUser is a user's status in a summary delivery.
+type webhookUser struct {
+	UserID     string `json:"user_id"`
+	UserName   string `json:"user_name,omitempty"`
+	Status     string `json:"status"` // submitted, skipped or pending
+	Time       string `json:"time,omitempty"`
+	SkipReason string `json:"skip_reason,omitempty"`
+}
+
+// Name returns "webhook".
+func (n *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+// NotifyReminder posts a reminder event.
+func (n *WebhookNotifier) NotifyReminder(ctx context.Context, reminder *Reminder) (string, error) {
+	return "", n.post(ctx, &webhookPayload{
+		Event:       EventReminder,
+		Text:        reminder.Text(),
+		ChannelID:   reminder.ChannelID,
+		ChannelName: reminder.ChannelName,
+		UserID:      reminder.UserID,
+	})
+}
+
+// NotifySummary posts a summary event.
+func (n *WebhookNotifier) NotifySummary(ctx context.Context, summary *Summary) (string, error) {
+	users := make([]webhookUser, 0, len(summary.Users))
+	for _, user := range summary.Users {
+		status := "pending"
+		switch {
+		case user.Submitted:
+			status = "submitted"
+		case user.Skipped:
+			status = "skipped"
+		}
+		users = append(users, webhookUser{
+			UserID:     user.UserID,
+			UserName:   user.UserName,
+			Status:     status,
+			Time:       user.Time,
+			SkipReason: user.SkipReason,
+		})
+	}
+
+	return "", n.post(ctx, &webhookPayload{
+		Event:       EventSummary,
+		Text:        summary.Text(),
+		ChannelID:   summary.ChannelID,
+		ChannelName: summary.ChannelName,
+		Date:        summary.Date,
+		Users:       users,
+	})
+}
+
+func (n *WebhookNotifier) post(ctx context.Context, payload *webhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code: %d, body: %s",
+			resp.StatusCode, security.SanitizeLogValue(string(respBody)))
+	}
+
+	return nil
+}
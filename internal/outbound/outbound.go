@@ -0,0 +1,302 @@
+// Package outbound publishes standup events to external systems, such as
+// dashboards and data warehouses, as signed JSON webhooks.
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/synaptiq/standup-bot/internal/security"
+)
+
+// Event types.
+const (
+	EventResponseSubmitted = "response.submitted"
+	EventSummaryPosted     = "summary.posted"
+	EventReminderSent      = "reminder.sent"
+)
+
+// EventTypes lists every event type.
+var EventTypes = []string{EventResponseSubmitted, EventSummaryPosted, EventReminderSent}
+
+// Headers sent with each delivery.
+const (
+	HeaderEvent     = "X-Standup-Event"
+	HeaderDelivery  = "X-Standup-Delivery" // The event's ID, the same on each retry
+	HeaderTimestamp = "X-Standup-Request-Timestamp"
+	HeaderSignature = "X-Standup-Signature"
+)
+
+// Defaults for delivery retries.
+const (
+	DefaultMaxAttempts = 3
+	DefaultRetryDelay  = 500 * time.Millisecond // Doubled after each attempt
+)
+
+// Event is the JSON body of a delivery.
+type Event struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	TeamID    string    `json:"team_id,omitempty"`
+	ChannelID string    `json:"channel_id"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"` // One of the *Data types below, by Type
+}
+
+// ResponseSubmittedData is the data of a response.submitted event.
+// Answers are left out for private channels.
+type ResponseSubmittedData struct {
+	UserID      string   `json:"user_id"`
+	UserName    string   `json:"user_name,omitempty"`
+	Date        string   `json:"date"`
+	SubmittedAt string   `json:"submitted_at"`
+	Late        bool     `json:"late,omitempty"`
+	Answers     []Answer `json:"answers,omitempty"`
+}
+
+// Answer is a user's answer to one of the day's questions.
+type Answer struct {
+	Question string `json:"question"`
+	Text     string `json:"text"`
+}
+
+// SummaryPostedData is the data of a summary.posted event.
+type SummaryPostedData struct {
+	Date  string        `json:"date"`
+	Users []SummaryUser `json:"users"`
+}
+
+// SummaryUser is a user's status in a posted summary.
+type SummaryUser struct {
+	UserID     string `json:"user_id"`
+	UserName   string `json:"user_name,omitempty"`
+	Status     string `json:"status"` // submitted, skipped or pending
+	SkipReason string `json:"skip_reason,omitempty"`
+}
+
+// ReminderSentData is the data of a reminder.sent event.
+type ReminderSentData struct {
+	UserID       string `json:"user_id"`
+	Date         string `json:"date"`
+	ReminderTime string `json:"reminder_time"` // HH:MM in the channel's timezone
+}
+
+// Endpoint is a URL events are delivered to.
+type Endpoint struct {
+	URL    string
+	Events []string // Event types delivered; empty delivers all
+}
+
+// wants reports whether the endpoint subscribes to eventType.
+func (e *Endpoint) wants(eventType string) bool {
+	return len(e.Events) == 0 || slices.Contains(e.Events, eventType)
+}
+
+// Publisher delivers events to endpoints, signing each with a shared secret.
+type Publisher struct {
+	endpoints   []Endpoint
+	secret      string
+	httpClient  *http.Client
+	maxAttempts int
+	retryDelay  time.Duration
+	now         func() time.Time
+}
+
+// Option configures a Publisher.
+type Option func(*Publisher)
+
+// WithRetries sets how many times a delivery is attempted and the delay
+// before the first retry, which doubles after each attempt.
+func WithRetries(maxAttempts int, delay time.Duration) Option {
+	return func(p *Publisher) {
+		p.maxAttempts = max(maxAttempts, 1)
+		p.retryDelay = delay
+	}
+}
+
+// WithHTTPClient sets the client deliveries are made with.
+func WithHTTPClient(client *http.Client) Option {
+	return func(p *Publisher) {
+		p.httpClient = client
+	}
+}
+
+// NewPublisher creates a publisher delivering to endpoints, signed with
+// secret.
+func NewPublisher(endpoints []Endpoint, secret string, opts ...Option) *Publisher {
+	p := &Publisher{
+		endpoints: endpoints,
+		secret:    secret,
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+		maxAttempts: DefaultMaxAttempts,
+		retryDelay:  DefaultRetryDelay,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// FromEnv creates a publisher from the environment, or returns nil if
+// OUTBOUND_WEBHOOK_URLS isn't set:
+//   - OUTBOUND_WEBHOOK_URLS (comma-separated) are the endpoints
+//   - OUTBOUND_WEBHOOK_SECRET signs deliveries and is required with URLs
+//   - OUTBOUND_WEBHOOK_EVENTS (comma-separated) limits the event types sent
+func FromEnv() (*Publisher, error) {
+	urls := splitList(os.Getenv("OUTBOUND_WEBHOOK_URLS"))
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	secret := os.Getenv("OUTBOUND_WEBHOOK_SECRET")
+	if secret == "" {
+		return nil, fmt.Errorf("OUTBOUND_WEBHOOK_SECRET is required with OUTBOUND_WEBHOOK_URLS")
+	}
+
+	events := splitList(os.Getenv("OUTBOUND_WEBHOOK_EVENTS"))
+	for _, event := range events {
+		if !slices.Contains(EventTypes, event) {
+			return nil, fmt.Errorf("unknown event type %q, expected one of %s", event, strings.Join(EventTypes, ", "))
+		}
+	}
+
+	endpoints := make([]Endpoint, 0, len(urls))
+	for _, url := range urls {
+		if !strings.HasPrefix(url, "https://") {
+			return nil, fmt.Errorf("webhook URL must be https: %s", security.SanitizeLogValue(url))
+		}
+		endpoints = append(endpoints, Endpoint{URL: url, Events: events})
+	}
+
+	return NewPublisher(endpoints, secret), nil
+}
+
+// Publish delivers an event of eventType to every endpoint subscribed to
+// it. Failed deliveries are retried; the errors of those that still fail
+// are returned together.
+func (p *Publisher) Publish(ctx context.Context, eventType, teamID, channelID string, data any) error {
+	return p.Deliver(ctx, p.NewEvent(eventType, teamID, channelID, data))
+}
+
+// NewEvent creates an event of eventType to deliver later with Deliver.
+func (p *Publisher) NewEvent(eventType, teamID, channelID string, data any) *Event {
+	return &Event{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		TeamID:    teamID,
+		ChannelID: channelID,
+		CreatedAt: p.now().UTC(),
+		Data:      data,
+	}
+}
+
+// Deliver delivers event to every endpoint subscribed to its type, as
+// Publish does.
+func (p *Publisher) Deliver(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	var errs []error
+	for _, endpoint := range p.endpoints {
+		if !endpoint.wants(event.Type) {
+			continue
+		}
+		if err := p.deliver(ctx, endpoint.URL, event, body); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", security.SanitizeLogValue(endpoint.URL), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliver posts body to url, retrying failures that may be temporary:
+// network errors, 429s and 5xx responses.
+func (p *Publisher) deliver(ctx context.Context, url string, event *Event, body []byte) error {
+	delay := p.retryDelay
+	for attempt := 1; ; attempt++ {
+		retry, err := p.post(ctx, url, event, body)
+		if err == nil || !retry || attempt >= p.maxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w after %d attempts: %w", ctx.Err(), attempt, err)
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// post makes one delivery attempt. It reports whether a failed attempt may
+// succeed if retried.
+func (p *Publisher) post(ctx context.Context, url string, event *Event, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(p.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, event.Type)
+	req.Header.Set(HeaderDelivery, event.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(p.secret, timestamp, body))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, fmt.Errorf("unexpected status code: %d, body: %s",
+			resp.StatusCode, security.SanitizeLogValue(string(respBody)))
+	}
+
+	return false, nil
+}
+
+// Sign returns the signature of a delivery's body sent at timestamp (Unix
+// seconds), as sent in the X-Standup-Signature header: "v1=" and the hex
+// HMAC-SHA256 of "v1:<timestamp>:<body>" keyed with the shared secret.
+// Receivers compute it the same way and compare, and should reject old
+// timestamps to prevent replays.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v1:" + timestamp + ":"))
+	mac.Write(body)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// splitList splits a comma-separated list, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
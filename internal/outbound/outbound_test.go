@@ -0,0 +1,130 @@
+package outbound
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is an endpoint answering each delivery with the next status.
+type recorder struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func TestPublish(t *testing.T) {
+	endpoint := &recorder{}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	p := NewPublisher([]Endpoint{
+		{URL: server.URL},
+		{URL: server.URL + "/summaries", Events: []string{EventSummaryPosted}},
+	}, "secret", WithRetries(3, time.Millisecond))
+
+	data := &ReminderSentData{UserID: "U1234567890", Date: "2026-10-16", ReminderTime: "09:00"}
+	require.NoError(t, p.Publish(context.Background(), EventReminderSent, "T1234567890", "C1234567890", data))
+
+	// Only endpoints subscribed to the event get it, signed
+	require.Len(t, endpoint.requests, 1)
+	req, body := endpoint.requests[0], endpoint.bodies[0]
+	assert.Equal(t, "/", req.URL.Path)
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, EventReminderSent, req.Header.Get(HeaderEvent))
+	assert.Equal(t, Sign("secret", req.Header.Get(HeaderTimestamp), body), req.Header.Get(HeaderSignature))
+	assert.NotEqual(t, Sign("other", req.Header.Get(HeaderTimestamp), body), req.Header.Get(HeaderSignature))
+
+	var event struct {
+		ID        string           `json:"id"`
+		Type      string           `json:"type"`
+		TeamID    string           `json:"team_id"`
+		ChannelID string           `json:"channel_id"`
+		Data      ReminderSentData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(body, &event))
+	assert.Equal(t, req.Header.Get(HeaderDelivery), event.ID)
+	assert.Equal(t, EventReminderSent, event.Type)
+	assert.Equal(t, "T1234567890", event.TeamID)
+	assert.Equal(t, "C1234567890", event.ChannelID)
+	assert.Equal(t, *data, event.Data)
+}
+
+func TestPublishRetries(t *testing.T) {
+	endpoint := &recorder{}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+	p := NewPublisher([]Endpoint{{URL: server.URL}}, "secret", WithRetries(3, time.Millisecond))
+	ctx := context.Background()
+
+	// Temporary failures are retried with the same delivery ID
+	endpoint.statuses = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}
+	require.NoError(t, p.Publish(ctx, EventSummaryPosted, "", "C1234567890", &SummaryPostedData{}))
+	require.Len(t, endpoint.requests, 3)
+	assert.Equal(t, endpoint.requests[0].Header.Get(HeaderDelivery), endpoint.requests[2].Header.Get(HeaderDelivery))
+
+	// Until the attempts run out
+	endpoint.requests = nil
+	endpoint.statuses = []int{500, 500, 500, 500}
+	err := p.Publish(ctx, EventSummaryPosted, "", "C1234567890", &SummaryPostedData{})
+	require.ErrorContains(t, err, "unexpected status code: 500")
+	assert.Len(t, endpoint.requests, 3)
+
+	// Rejected deliveries aren't retried
+	endpoint.requests = nil
+	endpoint.statuses = []int{http.StatusBadRequest}
+	err = p.Publish(ctx, EventSummaryPosted, "", "C1234567890", &SummaryPostedData{})
+	require.ErrorContains(t, err, "unexpected status code: 400")
+	assert.Len(t, endpoint.requests, 1)
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("OUTBOUND_WEBHOOK_URLS", "")
+	p, err := FromEnv()
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	t.Setenv("OUTBOUND_WEBHOOK_URLS", "https://example.com/a, https://example.com/b")
+	_, err = FromEnv()
+	require.ErrorContains(t, err, "OUTBOUND_WEBHOOK_SECRET is required")
+
+	t.Setenv("OUTBOUND_WEBHOOK_SECRET", "secret")
+	t.Setenv("OUTBOUND_WEBHOOK_EVENTS", "summary.posted,response.deleted")
+	_, err = FromEnv()
+	require.ErrorContains(t, err, `unknown event type "response.deleted"`)
+
+	t.Setenv("OUTBOUND_WEBHOOK_EVENTS", "summary.posted")
+	p, err = FromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, []Endpoint{
+		{URL: "https://example.com/a", Events: []string{EventSummaryPosted}},
+		{URL: "https://example.com/b", Events: []string{EventSummaryPosted}},
+	}, p.endpoints)
+
+	t.Setenv("OUTBOUND_WEBHOOK_URLS", "http://example.com/a")
+	_, err = FromEnv()
+	require.ErrorContains(t, err, "must be https")
+}
@@ -0,0 +1,68 @@
+// Package queue sends async tasks to the processor Lambda over SQS.
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+// Task types handled by the processor.
+const (
+	TaskSendWelcome    = "send_welcome"
+	TaskGenerateReport = "generate_report"
+	TaskBulkReminder   = "bulk_reminder"
+	TaskPrefillStandup = "prefill_standup"
+	TaskPublishEvent   = "publish_event"
+)
+
+// Task represents an async task to process.
+type Task struct {
+	Type        string                 `json:"type"`
+	TeamID      string                 `json:"team_id,omitempty"` // Workspace the task runs as
+	ChannelID   string                 `json:"channel_id"`
+	UserID      string                 `json:"user_id"`
+	ResponseURL string                 `json:"response_url,omitempty"` // Set for tasks started by slash commands
+	Payload     map[string]interface{} `json:"payload"`
+}
+
+// SQSClient defines the SQS operations used by Sender.
+type SQSClient interface {
+	SendMessage(ctx context.Context, params *sqs.SendMessageInput,
+		optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)
+}
+
+// Sender sends tasks to the processor queue.
+type Sender struct {
+	client   SQSClient
+	queueURL string
+}
+
+// NewSender creates a sender for the queue at queueURL.
+func NewSender(client SQSClient, queueURL string) *Sender {
+	return &Sender{
+		client:   client,
+		queueURL: queueURL,
+	}
+}
+
+// Send enqueues a task.
+func (s *Sender) Send(ctx context.Context, task *Task) error {
+	body, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task: %w", err)
+	}
+
+	_, err = s.client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:    aws.String(s.queueURL),
+		MessageBody: aws.String(string(body)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send task: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package report
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Key prefixes of the report files uploaded to the export bucket.
+const (
+	exportPrefix    = "exports"
+	tagReportPrefix = "reports"
+)
+
+// Link is a download link to an uploaded report, as described by its URL.
+// Exports name their channel and tag reports their tag.
+type Link struct {
+	ChannelID string
+	Tag       string
+	StartDate string
+	EndDate   string
+	Format    Format
+	ExpiresAt time.Time // Zero if the URL doesn't say
+}
+
+// ExportKey returns where an export of a channel is uploaded. id keeps
+// exports of the same range apart.
+func ExportKey(channelID, startDate, endDate, id string, format Format) string {
+	return reportKey(exportPrefix, channelID, startDate, endDate, id, format)
+}
+
+// TagReportKey returns where a report by tag is uploaded. id keeps reports
+// of the same range apart.
+func TagReportKey(tag, startDate, endDate, id string, format Format) string {
+	return reportKey(tagReportPrefix, tag, startDate, endDate, id, format)
+}
+
+func reportKey(prefix, subject, startDate, endDate, id string, format Format) string {
+	return fmt.Sprintf("%s/%s/%s_%s-%s.%s", prefix, subject, startDate, endDate, id, format)
+}
+
+// ParseLink parses a presigned download link to a report uploaded to bucket.
+// It reports false for any other URL.
+func ParseLink(rawURL, bucket string) (*Link, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || bucket == "" {
+		return nil, false
+	}
+	// Presigned URLs use virtual-hosted style, e.g. bucket.s3.us-east-1.amazonaws.com
+	if !strings.HasPrefix(u.Host, bucket+".s3.") || !strings.HasSuffix(u.Host, ".amazonaws.com") {
+		return nil, false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+	if len(parts) != 3 {
+		return nil, false
+	}
+	ext := path.Ext(parts[2])
+	format, ok := ParseFormat(strings.TrimPrefix(ext, "."))
+	if !ok {
+		return nil, false
+	}
+	// YYYY-MM-DD_YYYY-MM-DD-<id>
+	name := strings.TrimSuffix(parts[2], ext)
+	if len(name) < 22 || name[10] != '_' || name[21] != '-' {
+		return nil, false
+	}
+	link := &Link{StartDate: name[:10], EndDate: name[11:21], Format: format}
+	if _, _, err := ParseRange(link.StartDate, link.EndDate); err != nil {
+		return nil, false
+	}
+
+	switch parts[0] {
+	case exportPrefix:
+		link.ChannelID = parts[1]
+	case tagReportPrefix:
+		link.Tag = parts[1]
+	default:
+		return nil, false
+	}
+
+	query := u.Query()
+	signedAt, err := time.Parse("20060102T150405Z", query.Get("X-Amz-Date"))
+	seconds, expiresErr := strconv.Atoi(query.Get("X-Amz-Expires"))
+	if err == nil && expiresErr == nil {
+		link.ExpiresAt = signedAt.Add(time.Duration(seconds) * time.Second)
+	}
+
+	return link, true
+}
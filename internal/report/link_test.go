@@ -0,0 +1,45 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLink(t *testing.T) {
+	key := ExportKey("C1234567890", "2024-01-01", "2024-01-31", "0f8e6b3c-1d2a-4e5f-9a8b-7c6d5e4f3a2b", FormatCSV)
+	assert.Equal(t, "exports/C1234567890/2024-01-01_2024-01-31-0f8e6b3c-1d2a-4e5f-9a8b-7c6d5e4f3a2b.csv", key)
+
+	link, ok := ParseLink("https://exports-123.s3.us-east-1.amazonaws.com/"+key+
+		"?X-Amz-Algorithm=AWS4-HMAC-SHA256&X-Amz-Date=20240201T090000Z&X-Amz-Expires=86400&X-Amz-Signature=abc",
+		"exports-123")
+	require.True(t, ok)
+	assert.Equal(t, &Link{
+		ChannelID: "C1234567890",
+		StartDate: "2024-01-01",
+		EndDate:   "2024-01-31",
+		Format:    FormatCSV,
+		ExpiresAt: time.Date(2024, 2, 2, 9, 0, 0, 0, time.UTC),
+	}, link)
+
+	key = TagReportKey("project", "2024-01-01", "2024-01-31", "0f8e6b3c", FormatJSON)
+	link, ok = ParseLink("https://exports-123.s3.amazonaws.com/"+key, "exports-123")
+	require.True(t, ok)
+	assert.Equal(t, "project", link.Tag)
+	assert.Equal(t, FormatJSON, link.Format)
+	assert.True(t, link.ExpiresAt.IsZero())
+
+	for _, rawURL := range []string{
+		"https://other-bucket.s3.amazonaws.com/" + key,
+		"https://exports-123.s3.amazonaws.com.example.org/" + key,
+		"http://exports-123.s3.amazonaws.com/" + key,
+		"https://exports-123.s3.amazonaws.com/standups/date=2024-01-15/channel=C1234567890/standup.json",
+		"https://exports-123.s3.amazonaws.com/exports/C1234567890/2024-01-31_2024-01-01-0f8e6b3c.csv",
+		"https://exports-123.s3.amazonaws.com/exports/C1234567890/2024-01-01_2024-01-31-0f8e6b3c.xlsx",
+	} {
+		_, ok := ParseLink(rawURL, "exports-123")
+		assert.False(t, ok, rawURL)
+	}
+}
@@ -0,0 +1,270 @@
+// Package report exports standup history for use outside Slack.
+package report
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// MaxRangeDays caps how many days a single export may cover.
+const MaxRangeDays = 366
+
+// Format is an export file format.
+type Format string
+
+// Supported export formats.
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// ParseFormat parses a format name, case-insensitively.
+func ParseFormat(name string) (Format, bool) {
+	switch Format(strings.ToLower(name)) {
+	case FormatCSV:
+		return FormatCSV, true
+	case FormatJSON:
+		return FormatJSON, true
+	default:
+		return "", false
+	}
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	if f == FormatJSON {
+		return "application/json"
+	}
+	return "text/csv"
+}
+
+// Record is a single exported standup response.
+type Record struct {
+	Date        string    `json:"date"`
+	ChannelID   string    `json:"channel_id"`
+	UserID      string    `json:"user_id"`
+	UserName    string    `json:"user_name"`
+	SubmittedAt time.Time `json:"submitted_at"`
+	Answers     []Answer  `json:"answers"`
+}
+
+// Answer pairs a question with the user's answer.
+type Answer struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// Exporter collects standup responses for export.
+type Exporter struct {
+	store store.Store
+}
+
+// NewExporter creates a new exporter.
+func NewExporter(store store.Store) *Exporter {
+	return &Exporter{store: store}
+}
+
+// Collect returns every response in a channel between start and end
+// (inclusive, YYYY-MM-DD), oldest first.
+func (e *Exporter) Collect(
+	ctx context.Context,
+	channelID string,
+	questions []string,
+	start, end string,
+) ([]Record, error) {
+	var records []Record
+	err := e.Each(ctx, channelID, questions, start, end, func(record Record) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// Each calls fn with every response in a channel between start and end
+// (inclusive, YYYY-MM-DD), oldest first. Responses are read a page at a
+// time, so large channels needn't be held in memory; an error from fn stops
+// the export and is returned.
+func (e *Exporter) Each(
+	ctx context.Context,
+	channelID string,
+	questions []string,
+	start, end string,
+	fn func(Record) error,
+) error {
+	startDay, endDay, err := ParseRange(start, end)
+	if err != nil {
+		return err
+	}
+
+	for day := startDay; !day.After(endDay); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		token := ""
+		for {
+			page, err := e.store.ListUserResponsesPage(ctx, channelID, date, store.DefaultPageSize, token)
+			if err != nil {
+				return fmt.Errorf("failed to list responses for %s: %w", date, err)
+			}
+
+			for _, resp := range page.Responses {
+				if err := fn(newRecord(resp, questions)); err != nil {
+					return err
+				}
+			}
+
+			if page.NextToken == "" {
+				break
+			}
+			token = page.NextToken
+		}
+	}
+
+	return nil
+}
+
+// ParseRange parses and checks an export date range.
+func ParseRange(start, end string) (startDay, endDay time.Time, err error) {
+	startDay, err = time.Parse("2006-01-02", start)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start date %q", start)
+	}
+	endDay, err = time.Parse("2006-01-02", end)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end date %q", end)
+	}
+
+	if endDay.Before(startDay) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end date is before start date")
+	}
+	if endDay.Sub(startDay) >= MaxRangeDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("date range exceeds %d days", MaxRangeDays)
+	}
+
+	return startDay, endDay, nil
+}
+
+// Write encodes records in the given format. CSV output has one column per question.
+func Write(w io.Writer, format Format, questions []string, records []Record) error {
+	writer, err := NewWriter(w, format, questions)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// Writer encodes records one at a time, so an export can be written while
+// its responses are read. Close must be called after the last record.
+type Writer struct {
+	w      io.Writer
+	format Format
+	csv    *csv.Writer
+	count  int
+}
+
+// NewWriter creates a writer for the given format, writing the CSV header
+// right away.
+func NewWriter(w io.Writer, format Format, questions []string) (*Writer, error) {
+	writer := &Writer{w: w, format: format}
+
+	switch format {
+	case FormatJSON:
+	case FormatCSV:
+		writer.csv = csv.NewWriter(w)
+		header := append([]string{"date", "channel_id", "user_id", "user_name", "submitted_at"}, questions...)
+		if err := writer.csv.Write(header); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	return writer, nil
+}
+
+// Write encodes a record.
+func (w *Writer) Write(record Record) error {
+	w.count++
+
+	if w.format == FormatJSON {
+		// Indented like an encoded slice of records
+		data, err := json.MarshalIndent(record, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		separator := ",\n  "
+		if w.count == 1 {
+			separator = "[\n  "
+		}
+		_, err = io.WriteString(w.w, separator+string(data))
+		return err
+	}
+
+	row := []string{
+		record.Date,
+		record.ChannelID,
+		record.UserID,
+		record.UserName,
+		record.SubmittedAt.UTC().Format(time.RFC3339),
+	}
+	for _, answer := range record.Answers {
+		row = append(row, answer.Answer)
+	}
+	return w.csv.Write(row)
+}
+
+// Count returns how many records were written.
+func (w *Writer) Count() int {
+	return w.count
+}
+
+// Close finishes the output.
+func (w *Writer) Close() error {
+	if w.format == FormatJSON {
+		end := "\n]\n"
+		if w.count == 0 {
+			end = "[]\n"
+		}
+		_, err := io.WriteString(w.w, end)
+		return err
+	}
+
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func newRecord(resp *store.UserResponse, questions []string) Record {
+	record := Record{
+		Date:        resp.Date,
+		ChannelID:   resp.ChannelID,
+		UserID:      resp.UserID,
+		UserName:    resp.UserName,
+		SubmittedAt: resp.SubmittedAt,
+		Answers:     make([]Answer, 0, len(questions)),
+	}
+
+	for i, question := range questions {
+		record.Answers = append(record.Answers, Answer{
+			Question: question,
+			Answer:   resp.Responses[fmt.Sprintf("question_%d", i)],
+		})
+	}
+
+	return record
+}
@@ -0,0 +1,149 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/store/memory"
+)
+
+func TestWrite(t *testing.T) {
+	questions := []string{"What did you do?", "Any blockers?"}
+	records := []Record{
+		newRecord(&store.UserResponse{
+			Date:        "2024-01-15",
+			ChannelID:   "C1234567890",
+			UserID:      "U1234567890",
+			UserName:    "alice",
+			SubmittedAt: time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC),
+			Responses: map[string]string{
+				"question_0": "Shipped the export, finally",
+				"question_1": "none",
+			},
+		}, questions),
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, FormatCSV, questions, records))
+
+		assert.Equal(t,
+			"date,channel_id,user_id,user_name,submitted_at,What did you do?,Any blockers?\n"+
+				"2024-01-15,C1234567890,U1234567890,alice,2024-01-15T09:30:00Z,\"Shipped the export, finally\",none\n",
+			buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, FormatJSON, questions, records))
+
+		var decoded []Record
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		require.Len(t, decoded, 1)
+		assert.Equal(t, "alice", decoded[0].UserName)
+		assert.Equal(t, Answer{Question: "Any blockers?", Answer: "none"}, decoded[0].Answers[1])
+	})
+
+	t.Run("empty json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, FormatJSON, questions, nil))
+		assert.Equal(t, "[]\n", buf.String())
+	})
+}
+
+func TestWriterMatchesEncodedSlice(t *testing.T) {
+	records := []Record{
+		{Date: "2024-01-15", UserID: "U1234567890", Answers: []Answer{{Question: "Q", Answer: "<b>done</b>"}}},
+		{Date: "2024-01-16", UserID: "U0987654321", Answers: []Answer{}},
+	}
+
+	var want bytes.Buffer
+	encoder := json.NewEncoder(&want)
+	encoder.SetIndent("", "  ")
+	require.NoError(t, encoder.Encode(records))
+
+	var got bytes.Buffer
+	writer, err := NewWriter(&got, FormatJSON, nil)
+	require.NoError(t, err)
+	for _, record := range records {
+		require.NoError(t, writer.Write(record))
+	}
+	require.NoError(t, writer.Close())
+
+	assert.Equal(t, want.String(), got.String())
+	assert.Equal(t, 2, writer.Count())
+}
+
+func TestExporterEach(t *testing.T) {
+	ctx := context.Background()
+	s := memory.NewStore()
+
+	// More responses in a day than fit on a page
+	for _, date := range []string{"2024-01-15", "2024-01-16"} {
+		session := &store.Session{SessionID: "3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f", ChannelID: "C1234567890", Date: date}
+		for i := range store.DefaultPageSize + 20 {
+			require.NoError(t, s.SubmitUserResponse(ctx, session, &store.UserResponse{
+				ChannelID: "C1234567890",
+				Date:      date,
+				UserID:    fmt.Sprintf("U%010d", i),
+				Responses: map[string]string{"question_0": "Shipped"},
+			}))
+		}
+	}
+
+	var dates []string
+	err := NewExporter(s).Each(ctx, "C1234567890", []string{"Yesterday?"}, "2024-01-14", "2024-01-16",
+		func(record Record) error {
+			dates = append(dates, record.Date)
+			return nil
+		})
+	require.NoError(t, err)
+	require.Len(t, dates, 2*(store.DefaultPageSize+20))
+	assert.Equal(t, "2024-01-15", dates[0])
+	assert.Equal(t, "2024-01-16", dates[len(dates)-1])
+
+	// Errors from the callback stop the export
+	stop := errors.New("stop")
+	calls := 0
+	err = NewExporter(s).Each(ctx, "C1234567890", nil, "2024-01-15", "2024-01-16", func(Record) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   string
+		end     string
+		wantErr bool
+	}{
+		{name: "single day", start: "2024-01-15", end: "2024-01-15"},
+		{name: "full year", start: "2024-01-01", end: "2024-12-31"},
+		{name: "too long", start: "2024-01-01", end: "2025-01-01", wantErr: true},
+		{name: "reversed", start: "2024-01-15", end: "2024-01-14", wantErr: true},
+		{name: "bad date", start: "yesterday", end: "2024-01-14", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseRange(tt.start, tt.end)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// DefaultLinkExpiry is how long export download links stay valid.
+const DefaultLinkExpiry = 24 * time.Hour
+
+// S3Client defines the S3 operations used by S3Uploader.
+type S3Client interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput,
+		optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// S3Presigner defines the presigning operation used by S3Uploader.
+type S3Presigner interface {
+	PresignGetObject(ctx context.Context, params *s3.GetObjectInput,
+		optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+}
+
+// S3Uploader stores exports in S3 and shares them via presigned URLs.
+type S3Uploader struct {
+	client    S3Client
+	presigner S3Presigner
+	bucket    string
+	expiry    time.Duration
+}
+
+// NewS3Uploader creates an uploader for the given bucket.
+func NewS3Uploader(client *s3.Client, bucket string, expiry time.Duration) *S3Uploader {
+	if expiry <= 0 {
+		expiry = DefaultLinkExpiry
+	}
+
+	return &S3Uploader{
+		client:    client,
+		presigner: s3.NewPresignClient(client),
+		bucket:    bucket,
+		expiry:    expiry,
+	}
+}
+
+// Upload stores the export under key and returns a presigned download URL.
+func (u *S3Uploader) Upload(ctx context.Context, key, contentType string, data []byte) (string, error) {
+	_, err := u.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(u.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload export: %w", err)
+	}
+
+	request, err := u.presigner.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(u.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(u.expiry))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign export URL: %w", err)
+	}
+
+	return request.URL, nil
+}
+
+// Expiry returns how long download links stay valid.
+func (u *S3Uploader) Expiry() time.Duration {
+	return u.expiry
+}
@@ -0,0 +1,272 @@
+package report
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// TagMode is what a tag report rolls up.
+type TagMode string
+
+// Supported tag report modes.
+const (
+	// TagParticipation counts the standups tagged users were due and
+	// submitted
+	TagParticipation TagMode = "participation"
+	// TagResponses lists tagged users' answers and answers to tagged
+	// questions
+	TagResponses TagMode = "responses"
+)
+
+// ParseTagMode parses a tag report mode, case-insensitively.
+func ParseTagMode(name string) (TagMode, bool) {
+	switch TagMode(strings.ToLower(name)) {
+	case TagParticipation:
+		return TagParticipation, true
+	case TagResponses:
+		return TagResponses, true
+	default:
+		return "", false
+	}
+}
+
+// TagReport rolls standups up by the values of a tag across channels, e.g.
+// by project for program managers.
+type TagReport struct {
+	Tag       string     `json:"tag"`
+	StartDate string     `json:"start_date"`
+	EndDate   string     `json:"end_date"`
+	Groups    []TagGroup `json:"groups"` // By value
+}
+
+// TagGroup is the standups of the users and questions tagged with one
+// value.
+type TagGroup struct {
+	Value    string   `json:"value"`
+	Channels []string `json:"channels"`
+	Users    []string `json:"users"` // Tagged user IDs
+	// Expected counts the standups tagged users were due: on their working
+	// days that were channel active days, other than holidays
+	Expected  int         `json:"expected"`
+	Submitted int         `json:"submitted"`
+	Answers   []TagAnswer `json:"answers,omitempty"`
+}
+
+// ParticipationRate returns the share of expected standups submitted, from
+// 0 to 1.
+func (g *TagGroup) ParticipationRate() float64 {
+	if g.Expected == 0 {
+		return 0
+	}
+	return float64(g.Submitted) / float64(g.Expected)
+}
+
+// TagAnswer is an answer rolled up under a tag value.
+type TagAnswer struct {
+	Date      string `json:"date"`
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	UserName  string `json:"user_name"`
+	Question  string `json:"question"`
+	Answer    string `json:"answer"`
+}
+
+// RollupByTag rolls up the standups of channels between start and end
+// (inclusive, YYYY-MM-DD) by the values of tag. Users tagged with a value
+// count toward its participation and all their answers go under it;
+// answers to questions tagged with a value go under it whoever gave them.
+// Days after today aren't counted.
+func (e *Exporter) RollupByTag(
+	ctx context.Context,
+	channels []botconfig.ChannelConfig,
+	tag, start, end string,
+) (*TagReport, error) {
+	startDay, endDay, err := ParseRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]*TagGroup)
+	group := func(value, channelID string) *TagGroup {
+		g, ok := groups[value]
+		if !ok {
+			g = &TagGroup{Value: value}
+			groups[value] = g
+		}
+		if !slices.Contains(g.Channels, channelID) {
+			g.Channels = append(g.Channels, channelID)
+		}
+		return g
+	}
+
+	// Only channels with tagged users or questions are read
+	var channelIDs []string
+	byID := make(map[string]botconfig.ChannelConfig)
+	for _, channel := range channels {
+		tagged := false
+		for _, user := range channel.Users() {
+			if value := user.Tags()[tag]; value != "" {
+				g := group(value, channel.ID())
+				if !slices.Contains(g.Users, user.ID()) {
+					g.Users = append(g.Users, user.ID())
+				}
+				tagged = true
+			}
+		}
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			for _, question := range channel.QuestionsFor(day) {
+				if value := question.Tags[tag]; value != "" {
+					group(value, channel.ID())
+					tagged = true
+				}
+			}
+		}
+		if tagged {
+			channelIDs = append(channelIDs, channel.ID())
+			byID[channel.ID()] = channel
+		}
+	}
+	// So answers come out in the same order each time
+	slices.Sort(channelIDs)
+
+	today := time.Now().Format("2006-01-02")
+	for day := startDay; !day.After(endDay); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		if len(channelIDs) == 0 || date > today {
+			break
+		}
+
+		responses, err := e.store.ListChannelsUserResponses(ctx, channelIDs, date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list responses for %s: %w", date, err)
+		}
+
+		for _, channelID := range channelIDs {
+			channel := byID[channelID]
+			byUser := make(map[string]*store.UserResponse)
+			for _, resp := range responses[channelID] {
+				byUser[resp.UserID] = resp
+			}
+
+			if channel.IsActiveDay(day.Weekday()) && !slices.Contains(channel.Holidays().Dates, date) {
+				for _, user := range channel.Users() {
+					value := user.Tags()[tag]
+					if value == "" || !user.IsWorkingDay(day.Weekday()) {
+						continue
+					}
+					groups[value].Expected++
+					if _, ok := byUser[user.ID()]; ok {
+						groups[value].Submitted++
+					}
+				}
+			}
+
+			questions := channel.QuestionsFor(day.Weekday())
+			for _, resp := range responses[channelID] {
+				userValue := ""
+				if user, ok := channel.UserByID(resp.UserID); ok {
+					userValue = user.Tags()[tag]
+				}
+				for i, question := range questions {
+					answer := resp.Responses[fmt.Sprintf("question_%d", i)]
+					if answer == "" {
+						continue
+					}
+					tagAnswer := TagAnswer{
+						Date:      date,
+						ChannelID: channelID,
+						UserID:    resp.UserID,
+						UserName:  resp.UserName,
+						Question:  question.Text,
+						Answer:    answer,
+					}
+					if userValue != "" {
+						groups[userValue].Answers = append(groups[userValue].Answers, tagAnswer)
+					}
+					if value := question.Tags[tag]; value != "" && value != userValue {
+						groups[value].Answers = append(groups[value].Answers, tagAnswer)
+					}
+				}
+			}
+		}
+	}
+
+	report := &TagReport{Tag: tag, StartDate: start, EndDate: end, Groups: []TagGroup{}}
+	for _, value := range slices.Sorted(maps.Keys(groups)) {
+		g := groups[value]
+		slices.Sort(g.Channels)
+		slices.Sort(g.Users)
+		report.Groups = append(report.Groups, *g)
+	}
+	return report, nil
+}
+
+// WriteTagReport encodes a tag report in the given format: each value's
+// participation, or each of their answers with TagResponses.
+func WriteTagReport(w io.Writer, format Format, mode TagMode, report *TagReport) error {
+	switch format {
+	case FormatJSON:
+		if mode != TagResponses {
+			summary := *report
+			summary.Groups = make([]TagGroup, len(report.Groups))
+			for i, g := range report.Groups {
+				g.Answers = nil
+				summary.Groups[i] = g
+			}
+			report = &summary
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case FormatCSV:
+	default:
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	writer := csv.NewWriter(w)
+	if mode == TagResponses {
+		if err := writer.Write([]string{report.Tag, "date", "channel_id", "user_id", "user_name",
+			"question", "answer"}); err != nil {
+			return err
+		}
+		for _, g := range report.Groups {
+			for _, a := range g.Answers {
+				if err := writer.Write([]string{g.Value, a.Date, a.ChannelID, a.UserID, a.UserName,
+					a.Question, a.Answer}); err != nil {
+					return err
+				}
+			}
+		}
+	} else {
+		if err := writer.Write([]string{report.Tag, "channels", "users", "expected", "submitted",
+			"participation"}); err != nil {
+			return err
+		}
+		for _, g := range report.Groups {
+			if err := writer.Write([]string{
+				g.Value,
+				strings.Join(g.Channels, " "),
+				strconv.Itoa(len(g.Users)),
+				strconv.Itoa(g.Expected),
+				strconv.Itoa(g.Submitted),
+				strconv.FormatFloat(g.ParticipationRate()*100, 'f', 1, 64) + "%",
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,118 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/store/memory"
+)
+
+func TestRollupByTag(t *testing.T) {
+	ctx := context.Background()
+	cfg, err := botconfig.ParseYAML([]byte(`version: "1.0"
+channels:
+  - id: "C1111111111"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+      active_days: ["Mon", "Tue", "Wed", "Thu", "Fri"]
+    users:
+      - id: "U1111111111"
+        name: "alice"
+        tags: {project: "payments"}
+      - id: "U2222222222"
+        name: "bob"
+        tags: {project: "search"}
+    questions:
+      - "What did you do?"
+      - text: "Anything blocking the payments launch?"
+        tags: {project: "payments"}
+  - id: "C2222222222"
+    name: "design"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+      active_days: ["Mon"]
+    users:
+      - id: "U3333333333"
+        name: "carol"
+        tags: {project: "payments"}
+    questions: ["What did you do?"]
+  - id: "C3333333333"
+    name: "sales"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+    users:
+      - id: "U4444444444"
+        name: "dave"
+    questions: ["What did you do?"]
+`))
+	require.NoError(t, err)
+
+	s := memory.NewStore()
+	for _, resp := range []*store.UserResponse{
+		{ChannelID: "C1111111111", Date: "2024-01-15", UserID: "U1111111111", UserName: "alice",
+			Responses: map[string]string{"question_0": "Refunds"}},
+		{ChannelID: "C1111111111", Date: "2024-01-16", UserID: "U1111111111", UserName: "alice",
+			Responses: map[string]string{"question_0": "Payouts"}},
+		{ChannelID: "C1111111111", Date: "2024-01-15", UserID: "U2222222222", UserName: "bob",
+			Responses: map[string]string{"question_0": "Indexing", "question_1": "Waiting on the search API"}},
+		{ChannelID: "C2222222222", Date: "2024-01-15", UserID: "U3333333333", UserName: "carol",
+			Responses: map[string]string{"question_0": "Checkout mockups"}},
+		{ChannelID: "C3333333333", Date: "2024-01-15", UserID: "U4444444444", UserName: "dave",
+			Responses: map[string]string{"question_0": "Calls"}},
+	} {
+		session := &store.Session{SessionID: resp.ChannelID + resp.Date, ChannelID: resp.ChannelID, Date: resp.Date}
+		require.NoError(t, s.SubmitUserResponse(ctx, session, resp))
+	}
+
+	report, err := NewExporter(s).RollupByTag(ctx, cfg.Channels(), "project", "2024-01-15", "2024-01-16")
+	require.NoError(t, err)
+	require.Len(t, report.Groups, 2)
+
+	payments := report.Groups[0]
+	assert.Equal(t, "payments", payments.Value)
+	assert.ElementsMatch(t, []string{"C1111111111", "C2222222222"}, payments.Channels)
+	assert.ElementsMatch(t, []string{"U1111111111", "U3333333333"}, payments.Users)
+	assert.Equal(t, 3, payments.Expected)
+	assert.Equal(t, 3, payments.Submitted)
+	// Bob's answer to the payments question is rolled up under payments too
+	require.Len(t, payments.Answers, 4)
+	assert.Contains(t, payments.Answers, TagAnswer{
+		Date: "2024-01-15", ChannelID: "C1111111111", UserID: "U2222222222", UserName: "bob",
+		Question: "Anything blocking the payments launch?", Answer: "Waiting on the search API",
+	})
+
+	search := report.Groups[1]
+	assert.Equal(t, "search", search.Value)
+	assert.Equal(t, 2, search.Expected)
+	assert.Equal(t, 1, search.Submitted)
+	assert.Len(t, search.Answers, 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTagReport(&buf, FormatCSV, TagParticipation, report))
+	assert.Equal(t,
+		"project,channels,users,expected,submitted,participation\n"+
+			"payments,C1111111111 C2222222222,2,3,3,100.0%\n"+
+			"search,C1111111111,1,2,1,50.0%\n",
+		buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteTagReport(&buf, FormatCSV, TagResponses, report))
+	assert.Contains(t, buf.String(), "project,date,channel_id,user_id,user_name,question,answer\n")
+	assert.Contains(t, buf.String(), "search,2024-01-15,C1111111111,U2222222222,bob,What did you do?,Indexing\n")
+
+	// Participation reports leave the answers out
+	buf.Reset()
+	require.NoError(t, WriteTagReport(&buf, FormatJSON, TagParticipation, report))
+	assert.NotContains(t, buf.String(), "answers")
+	assert.NotEmpty(t, report.Groups[0].Answers)
+}
@@ -0,0 +1,289 @@
+// Package schedules keeps an EventBridge Scheduler schedule for each channel,
+// so the scheduler function is only invoked when a channel has work instead
+// of scanning every channel each minute.
+package schedules
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/synaptiq/standup-bot/internal/security"
+)
+
+// DefaultGroup is the schedule group used when none is configured.
+const DefaultGroup = "default"
+
+// signingName is the service name EventBridge Scheduler requests are signed for.
+const signingName = "scheduler"
+
+// ErrNoTimes is returned by Expression when there are no valid times to
+// schedule.
+var ErrNoTimes = errors.New("no times to schedule")
+
+// Event is the input of scheduled invocations, naming the channel to process.
+type Event struct {
+	TeamID    string `json:"team_id,omitempty"`
+	ChannelID string `json:"channel_id"`
+}
+
+// Schedule is a schedule invoking the target with an Event.
+type Schedule struct {
+	Name       string
+	Expression string // cron(...) or at(...)
+	Timezone   string // IANA zone the expression is in
+	Event      Event
+	// DeleteAfterRun removes one-time schedules once they've run.
+	DeleteAfterRun bool
+}
+
+// invalidNameChars are the characters schedule names can't contain.
+var invalidNameChars = regexp.MustCompile(`[^0-9A-Za-z_.-]`)
+
+// Name returns the name of a channel's schedule.
+func Name(teamID, channelID string) string {
+	parts := []string{"standup"}
+	if teamID != "" {
+		parts = append(parts, teamID)
+	}
+	parts = append(parts, channelID)
+	return invalidNameChars.ReplaceAllString(strings.Join(parts, "-"), "_")
+}
+
+// Expression returns a cron expression firing daily at each of times (HH:MM).
+// Cron can't list arbitrary times, so it fires at every combination of their
+// hours and minutes; the scheduler only runs the tasks that are due.
+func Expression(times []string) (string, error) {
+	var hours, minutes []int
+	for _, clock := range times {
+		t, err := time.Parse("15:04", clock)
+		if err != nil {
+			continue
+		}
+		hours = append(hours, t.Hour())
+		minutes = append(minutes, t.Minute())
+	}
+	if len(hours) == 0 {
+		return "", ErrNoTimes
+	}
+
+	return fmt.Sprintf("cron(%s %s * * ? *)", joinSorted(minutes), joinSorted(hours)), nil
+}
+
+// At returns a one-time expression firing at t, in UTC.
+func At(t time.Time) string {
+	return "at(" + t.UTC().Format("2006-01-02T15:04:05") + ")"
+}
+
+// joinSorted lists the distinct values in ascending order, comma separated.
+func joinSorted(values []int) string {
+	slices.Sort(values)
+	values = slices.Compact(values)
+
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Client creates, updates and deletes schedules through the EventBridge
+// Scheduler API.
+type Client struct {
+	endpoint    string
+	region      string
+	group       string
+	targetARN   string
+	roleARN     string
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	httpClient  *http.Client
+}
+
+// ClientOption is a function that modifies a client.
+type ClientOption func(*Client)
+
+// WithGroup sets the schedule group schedules are kept in.
+func WithGroup(group string) ClientOption {
+	return func(c *Client) {
+		c.group = group
+	}
+}
+
+// WithEndpoint sets the EventBridge Scheduler API URL.
+func WithEndpoint(endpoint string) ClientOption {
+	return func(c *Client) {
+		c.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+}
+
+// NewClient creates a client whose schedules invoke targetARN, assuming
+// roleARN to do so.
+func NewClient(awsCfg aws.Config, targetARN, roleARN string, opts ...ClientOption) *Client {
+	c := &Client{
+		endpoint:    fmt.Sprintf("https://scheduler.%s.amazonaws.com", awsCfg.Region),
+		region:      awsCfg.Region,
+		group:       DefaultGroup,
+		targetARN:   targetARN,
+		roleARN:     roleARN,
+		credentials: awsCfg.Credentials,
+		signer:      v4.NewSigner(),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// FromEnv creates a client when SCHEDULE_TARGET_ARN names the function
+// schedules invoke. SCHEDULE_ROLE_ARN is the role EventBridge Scheduler
+// assumes to invoke it, and SCHEDULE_GROUP the schedule group. It returns nil
+// when schedules aren't configured, leaving the scheduler polling.
+func FromEnv(ctx context.Context) (*Client, error) {
+	targetARN := os.Getenv("SCHEDULE_TARGET_ARN")
+	if targetARN == "" {
+		return nil, nil
+	}
+
+	roleARN := os.Getenv("SCHEDULE_ROLE_ARN")
+	if roleARN == "" {
+		return nil, fmt.Errorf("SCHEDULE_ROLE_ARN is required with SCHEDULE_TARGET_ARN")
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	var opts []ClientOption
+	if group := os.Getenv("SCHEDULE_GROUP"); group != "" {
+		opts = append(opts, WithGroup(group))
+	}
+
+	return NewClient(awsCfg, targetARN, roleARN, opts...), nil
+}
+
+// scheduleRequest is the body of CreateSchedule and UpdateSchedule.
+type scheduleRequest struct {
+	ActionAfterCompletion      string             `json:"ActionAfterCompletion,omitempty"`
+	FlexibleTimeWindow         flexibleTimeWindow `json:"FlexibleTimeWindow"`
+	GroupName                  string             `json:"GroupName"`
+	ScheduleExpression         string             `json:"ScheduleExpression"`
+	ScheduleExpressionTimezone string             `json:"ScheduleExpressionTimezone,omitempty"`
+	State                      string             `json:"State"`
+	Target                     target             `json:"Target"`
+}
+
+type flexibleTimeWindow struct {
+	Mode string `json:"Mode"`
+}
+
+type target struct {
+	Arn     string `json:"Arn"`
+	RoleArn string `json:"RoleArn"`
+	Input   string `json:"Input"`
+}
+
+// Put creates the schedule, or replaces it if it exists.
+func (c *Client) Put(ctx context.Context, schedule *Schedule) error {
+	input, err := json.Marshal(schedule.Event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	request := scheduleRequest{
+		FlexibleTimeWindow:         flexibleTimeWindow{Mode: "OFF"},
+		GroupName:                  c.group,
+		ScheduleExpression:         schedule.Expression,
+		ScheduleExpressionTimezone: schedule.Timezone,
+		State:                      "ENABLED",
+		Target: target{
+			Arn:     c.targetARN,
+			RoleArn: c.roleARN,
+			Input:   string(input),
+		},
+	}
+	if schedule.DeleteAfterRun {
+		request.ActionAfterCompletion = "DELETE"
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal schedule: %w", err)
+	}
+
+	path := "/schedules/" + url.PathEscape(schedule.Name)
+	status, err := c.do(ctx, http.MethodPut, path, body)
+	if status == http.StatusNotFound {
+		status, err = c.do(ctx, http.MethodPost, path, body)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to put schedule %s: %w", schedule.Name, err)
+	}
+	return nil
+}
+
+// Delete deletes the schedule with the given name, if it exists.
+func (c *Client) Delete(ctx context.Context, name string) error {
+	path := "/schedules/" + url.PathEscape(name) + "?" + url.Values{"groupName": {c.group}}.Encode()
+	status, err := c.do(ctx, http.MethodDelete, path, nil)
+	if status == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule %s: %w", name, err)
+	}
+	return nil
+}
+
+// do sends a signed request, returning the response's status code.
+func (c *Client) do(ctx context.Context, method, path string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	creds, err := c.credentials.Retrieve(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+	hash := sha256.Sum256(body)
+	if err := c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), signingName, c.region, time.Now()); err != nil {
+		return 0, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d, body: %s",
+			resp.StatusCode, security.SanitizeLogValue(string(respBody)))
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,102 @@
+package schedules
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "standup-T123-C456", Name("T123", "C456"))
+	assert.Equal(t, "standup-C456", Name("", "C456"))
+	assert.Equal(t, "standup-C4_56", Name("", "C4#56"))
+}
+
+func TestExpression(t *testing.T) {
+	expr, err := Expression([]string{"09:30", "09:00", "17:00", "09:00", "later"})
+	require.NoError(t, err)
+	assert.Equal(t, "cron(0,30 9,17 * * ? *)", expr)
+
+	_, err = Expression([]string{"", "later"})
+	assert.ErrorIs(t, err, ErrNoTimes)
+
+	assert.Equal(t, "at(2024-03-11T14:30:00)", At(time.Date(2024, 3, 11, 10, 30, 0, 0, time.FixedZone("EDT", -4*3600))))
+}
+
+func TestClient(t *testing.T) {
+	var requests []string
+	schedules := map[string]scheduleRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+
+		name := strings.TrimPrefix(r.URL.Path, "/schedules/")
+		switch r.Method {
+		case http.MethodPut, http.MethodPost:
+			if _, ok := schedules[name]; !ok && r.Method == http.MethodPut {
+				http.Error(w, `{"Message":"Schedule not found"}`, http.StatusNotFound)
+				return
+			}
+			var request scheduleRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			schedules[name] = request
+		case http.MethodDelete:
+			assert.Equal(t, "standup", r.URL.Query().Get("groupName"))
+			if _, ok := schedules[name]; !ok {
+				http.Error(w, `{"Message":"Schedule not found"}`, http.StatusNotFound)
+				return
+			}
+			delete(schedules, name)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	awsCfg := aws.Config{
+		Region: "us-east-1",
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}, nil
+		}),
+	}
+	client := NewClient(awsCfg, "arn:aws:lambda:us-east-1:123456789012:function:scheduler",
+		"arn:aws:iam::123456789012:role/scheduler", WithGroup("standup"), WithEndpoint(server.URL))
+
+	ctx := context.Background()
+	schedule := &Schedule{
+		Name:       "standup-T123-C456",
+		Expression: "cron(0 9 * * ? *)",
+		Timezone:   "America/New_York",
+		Event:      Event{TeamID: "T123", ChannelID: "C456"},
+	}
+
+	// New schedules are created, and existing ones updated
+	require.NoError(t, client.Put(ctx, schedule))
+	schedule.Expression = "cron(0,30 9 * * ? *)"
+	require.NoError(t, client.Put(ctx, schedule))
+	assert.Equal(t, []string{
+		"PUT /schedules/standup-T123-C456",
+		"POST /schedules/standup-T123-C456",
+		"PUT /schedules/standup-T123-C456",
+	}, requests)
+
+	saved := schedules["standup-T123-C456"]
+	assert.Equal(t, "cron(0,30 9 * * ? *)", saved.ScheduleExpression)
+	assert.Equal(t, "America/New_York", saved.ScheduleExpressionTimezone)
+	assert.Equal(t, "standup", saved.GroupName)
+	assert.Empty(t, saved.ActionAfterCompletion)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/scheduler", saved.Target.RoleArn)
+	assert.JSONEq(t, `{"team_id":"T123","channel_id":"C456"}`, saved.Target.Input)
+
+	// Deleting twice is fine
+	require.NoError(t, client.Delete(ctx, "standup-T123-C456"))
+	require.NoError(t, client.Delete(ctx, "standup-T123-C456"))
+	assert.Empty(t, schedules)
+}
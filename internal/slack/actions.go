@@ -0,0 +1,112 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/synaptiq/standup-bot/internal/security"
+)
+
+// Action IDs for interactive reminder buttons.
+const (
+	ActionSubmitNow = "reminder_submit_now"
+	ActionSkipToday = "reminder_skip_today"
+	ActionSnooze    = "reminder_snooze"
+	// ActionOpenChannel links to the channel; Slack still reports the click
+	ActionOpenChannel = "reminder_open_channel"
+)
+
+// Action IDs for the buttons of the reminder preferences message. Their
+// values are the preference's new value.
+const (
+	ActionPrefsReminders = "prefs_reminders" // "on" or "off"
+	ActionPrefsDelivery  = "prefs_delivery"  // "dm" or "channel"
+)
+
+// Action IDs for the prompt asking a channel admin to add a new member.
+const (
+	ActionAddMember     = "member_add"
+	ActionDismissMember = "member_dismiss"
+)
+
+// MemberActionValue encodes the channel and user a member prompt is about.
+func MemberActionValue(channelID, userID string) string {
+	return channelID + ":" + userID
+}
+
+// ParseMemberActionValue decodes a value built by MemberActionValue.
+func ParseMemberActionValue(value string) (channelID, userID string, err error) {
+	channelID, userID, ok := strings.Cut(value, ":")
+	if !ok || channelID == "" || userID == "" {
+		return "", "", fmt.Errorf("invalid member action value: %s", security.SanitizeLogValue(value))
+	}
+	return channelID, userID, nil
+}
+
+// Action IDs for the prompt to set up standups in a channel the bot was
+// added to. Their values are the channel's ID.
+const (
+	ActionSetupChannel = "channel_setup"
+	ActionDismissSetup = "channel_setup_dismiss"
+)
+
+// ActionReviewSummary marks a daily summary as reviewed by the lead who
+// clicked it. Its value is built by SummaryActionValue.
+const ActionReviewSummary = "summary_review"
+
+// SummaryActionValue encodes the channel and date of a summary.
+func SummaryActionValue(channelID, date string) string {
+	return channelID + ":" + date
+}
+
+// ParseSummaryActionValue decodes a value built by SummaryActionValue.
+func ParseSummaryActionValue(value string) (channelID, date string, err error) {
+	channelID, date, ok := strings.Cut(value, ":")
+	if !ok || channelID == "" || date == "" {
+		return "", "", fmt.Errorf("invalid summary action value: %s", security.SanitizeLogValue(value))
+	}
+	return channelID, date, nil
+}
+
+// ErrUnknownAction is returned when no handler is registered for an action ID.
+var ErrUnknownAction = errors.New("unknown action")
+
+// ActionHandler handles a single block action from an interaction payload.
+type ActionHandler func(ctx context.Context, payload *InteractionCallback, action *Action) error
+
+// ActionRouter dispatches block actions to handlers keyed on action_id.
+type ActionRouter struct {
+	handlers map[string]ActionHandler
+}
+
+// NewActionRouter creates an empty action router.
+func NewActionRouter() *ActionRouter {
+	return &ActionRouter{
+		handlers: make(map[string]ActionHandler),
+	}
+}
+
+// Handle registers a handler for an action ID.
+func (r *ActionRouter) Handle(actionID string, handler ActionHandler) {
+	r.handlers[actionID] = handler
+}
+
+// Dispatch routes every action in the payload to its registered handler.
+func (r *ActionRouter) Dispatch(ctx context.Context, payload *InteractionCallback) error {
+	for i := range payload.Actions {
+		action := &payload.Actions[i]
+
+		handler, ok := r.handlers[action.ActionID]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownAction, security.SanitizeLogValue(action.ActionID))
+		}
+
+		if err := handler(ctx, payload, action); err != nil {
+			return fmt.Errorf("action %s failed: %w", security.SanitizeLogValue(action.ActionID), err)
+		}
+	}
+
+	return nil
+}
@@ -3,291 +3,884 @@ package slack
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/blockkit"
+	"github.com/synaptiq/standup-bot/internal/i18n"
 	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/templates"
 )
 
-// ModalBuilder helps build Slack modals.
-type ModalBuilder struct {
-	modal *Modal
-}
+// ModalBuilder and MessageBuilder build Block Kit surfaces; see package
+// blockkit.
+type (
+	ModalBuilder   = blockkit.ModalBuilder
+	MessageBuilder = blockkit.MessageBuilder
+)
 
 // NewModalBuilder creates a new modal builder.
 func NewModalBuilder(title, callbackID string) *ModalBuilder {
-	return &ModalBuilder{
-		modal: &Modal{
-			Type:       "modal",
-			CallbackID: callbackID,
-			Title: &TextBlock{
-				Type: "plain_text",
-				Text: title,
-			},
-			Blocks: []Block{},
-		},
+	return blockkit.NewModalBuilder(title, callbackID)
+}
+
+// NewMessageBuilder creates a new message builder.
+func NewMessageBuilder() *MessageBuilder {
+	return blockkit.NewMessageBuilder()
+}
+
+// StandupCallbackID identifies the standup submission modal.
+const StandupCallbackID = "standup_submission"
+
+// StandupReviewCallbackID identifies the screen users review their answers
+// on before submitting, in channels that review answers.
+const StandupReviewCallbackID = "standup_review"
+
+// StandupEditActionID is the review screen's button back to the answers.
+const StandupEditActionID = "standup_edit"
+
+// maxPrivateMetadataLength is Slack's limit for a view's private metadata.
+const maxPrivateMetadataLength = 3000
+
+// QuestionBlockID returns the block ID of the question at index i. Answers
+// are keyed by it.
+func QuestionBlockID(i int) string {
+	return fmt.Sprintf("question_%d", i)
+}
+
+// BuildStandupModal builds a standup submission modal in the user's locale.
+func BuildStandupModal(channelID, sessionID string, questions []botconfig.Question, locale i18n.Locale) *Modal {
+	metadata := &StandupModalMetadata{
+		ChannelID: channelID,
+		SessionID: sessionID,
+		Date:      time.Now().Format("2006-01-02"),
+		Timestamp: time.Now(),
+		Locale:    locale,
 	}
+
+	return BuildStandupModalWithAnswers(metadata, questions, nil)
 }
 
-// SetSubmit sets the submit button text.
-func (b *ModalBuilder) SetSubmit(text string) *ModalBuilder {
-	b.modal.Submit = &TextBlock{
-		Type: "plain_text",
-		Text: text,
+// BuildStandupModalWithAnswers builds the standup modal for answers given so
+// far, keyed by question block ID. Conditional questions are only included
+// when the answers they depend on match, so the modal is rebuilt with this as
+// those questions are answered.
+func BuildStandupModalWithAnswers(
+	metadata *StandupModalMetadata,
+	questions []botconfig.Question,
+	answers map[string]Answer,
+) *Modal {
+	return buildStandupModal(metadata, questions, answers, nil)
+}
+
+// BuildPrefilledStandupModal builds the standup modal with suggested answers
+// for text questions, keyed by question block ID. Slack keeps what was typed
+// into inputs whose action IDs don't change, so prefilled inputs get new ones
+// to show the suggestions; other inputs keep what the user typed.
+func BuildPrefilledStandupModal(
+	metadata *StandupModalMetadata,
+	questions []botconfig.Question,
+	prefill map[string]string,
+) *Modal {
+	answers := make(map[string]Answer, len(prefill))
+	for blockID, text := range prefill {
+		answers[blockID] = Answer{ElementType: "plain_text_input", Value: text}
 	}
-	return b
+	return buildStandupModal(metadata, questions, answers, prefill)
 }
 
-// SetClose sets the close button text.
-func (b *ModalBuilder) SetClose(text string) *ModalBuilder {
-	b.modal.Close = &TextBlock{
-		Type: "plain_text",
-		Text: text,
+func buildStandupModal(
+	metadata *StandupModalMetadata,
+	questions []botconfig.Question,
+	answers map[string]Answer,
+	prefill map[string]string,
+) *Modal {
+	locale := metadata.Locale
+	builder := NewModalBuilder(locale.T(i18n.ModalTitle), StandupCallbackID).
+		SetSubmit(locale.T(i18n.ModalSubmit)).
+		SetPrivateMetadata(metadata).
+		AddHeader(locale.T(i18n.ModalHeader)).
+		AddSection(locale.T(i18n.ModalIntro))
+
+	// Questions others depend on update the modal as soon as they're answered
+	dependedOn := make(map[string]bool)
+	for _, question := range questions {
+		if question.ShowIf != nil {
+			dependedOn[question.ShowIf.Question] = true
+		}
 	}
-	return b
+
+	shown := ShownQuestions(questions, answers)
+
+	// Add input for each question
+	for i, question := range questions {
+		if !shown[i] {
+			continue
+		}
+
+		blockID := QuestionBlockID(i)
+		actionID := fmt.Sprintf("answer_%d", i)
+
+		if plan := metadata.Plan; plan != nil && plan.BlockID == blockID {
+			builder.AddSection(locale.T(i18n.ModalPlan, plan.Date) + "\n" + quote(plan.Text))
+		}
+
+		addInput := builder.AddInput
+		if question.ID != "" && dependedOn[question.ID] {
+			addInput = builder.AddDispatchInput
+		}
+
+		// Choices keep earlier answers, e.g. after editing a reviewed standup
+		answer := answers[blockID]
+
+		switch question.Type {
+		case botconfig.QuestionSelect:
+			options := NewOptions(question.Options...)
+			addInput(blockID, question.Text, StaticSelectElement{
+				Type:          "static_select",
+				ActionID:      actionID,
+				Placeholder:   &TextBlock{Type: "plain_text", Text: locale.T(i18n.ModalChoose)},
+				Options:       options,
+				InitialOption: selectedOption(options, answer.Value),
+			}, question.Optional)
+		case botconfig.QuestionMultiSelect:
+			options := NewOptions(question.Options...)
+			addInput(blockID, question.Text, CheckboxesElement{
+				Type:           "checkboxes",
+				ActionID:       actionID,
+				Options:        options,
+				InitialOptions: selectedOptions(options, answer.Values...),
+			}, question.Optional)
+		case botconfig.QuestionYesNo:
+			options := []Option{
+				{Text: &TextBlock{Type: "plain_text", Text: locale.T(i18n.ModalYes)}, Value: "yes"},
+				{Text: &TextBlock{Type: "plain_text", Text: locale.T(i18n.ModalNo)}, Value: "no"},
+			}
+			addInput(blockID, question.Text, RadioButtonsElement{
+				Type:          "radio_buttons",
+				ActionID:      actionID,
+				Options:       options,
+				InitialOption: selectedOption(options, answer.Value),
+			}, question.Optional)
+		case botconfig.QuestionDate:
+			addInput(blockID, question.Text, DatePickerElement{
+				Type:        "datepicker",
+				ActionID:    actionID,
+				Placeholder: &TextBlock{Type: "plain_text", Text: locale.T(i18n.ModalSelectDate)},
+				InitialDate: answer.Value,
+			}, question.Optional)
+		case botconfig.QuestionNumber:
+			addInput(blockID, question.Text, NumberInputElement{
+				Type:             "number_input",
+				ActionID:         actionID,
+				IsDecimalAllowed: true,
+				InitialValue:     answer.Value,
+			}, question.Optional)
+		default:
+			if _, ok := prefill[blockID]; ok {
+				actionID += "_prefill"
+			}
+			builder.AddInput(blockID, question.Text, PlainTextInputElement{
+				Type:         "plain_text_input",
+				ActionID:     actionID,
+				Placeholder:  &TextBlock{Type: "plain_text", Text: locale.T(i18n.ModalAnswerPrompt)},
+				InitialValue: answer.Value,
+				Multiline:    true,
+				MinLength:    question.MinLength,
+				MaxLength:    question.MaxLength,
+			}, question.Optional)
+		}
+	}
+
+	return builder.Build()
 }
 
-// SetPrivateMetadata sets private metadata.
-func (b *ModalBuilder) SetPrivateMetadata(metadata interface{}) *ModalBuilder {
-	data, err := json.Marshal(metadata)
-	if err != nil {
-		return b
-	}
-	b.modal.PrivateMetadata = string(data)
-	return b
-}
-
-// AddHeader adds a header block.
-func (b *ModalBuilder) AddHeader(text string) *ModalBuilder {
-	b.modal.Blocks = append(b.modal.Blocks, HeaderBlock{
-		Type: "header",
-		Text: &TextBlock{
-			Type: "plain_text",
-			Text: text,
-		},
-	})
-	return b
-}
-
-// AddSection adds a section block.
-func (b *ModalBuilder) AddSection(text string) *ModalBuilder {
-	b.modal.Blocks = append(b.modal.Blocks, &SectionBlock{
-		Type: "section",
-		Text: &TextBlock{
-			Type: "mrkdwn",
-			Text: text,
-		},
-	})
-	return b
-}
-
-// AddTextInput adds a text input block.
-func (b *ModalBuilder) AddTextInput(blockID, actionID, label, placeholder string, multiline bool) *ModalBuilder {
-	input := InputBlock{
-		Type:    "input",
-		BlockID: blockID,
-		Label: &TextBlock{
-			Type: "plain_text",
-			Text: label,
-		},
-		Element: PlainTextInputElement{
-			Type:      "plain_text_input",
-			ActionID:  actionID,
-			Multiline: multiline,
-		},
-	}
-
-	if placeholder != "" {
-		if element, ok := input.Element.(PlainTextInputElement); ok {
-			element.Placeholder = &TextBlock{
-				Type: "plain_text",
-				Text: placeholder,
+// selectedOption returns the option with value, or nil if there is none.
+func selectedOption(options []Option, value string) *Option {
+	if selected := selectedOptions(options, value); len(selected) > 0 {
+		return &selected[0]
+	}
+	return nil
+}
+
+// selectedOptions returns the options with any of values, in option order.
+func selectedOptions(options []Option, values ...string) []Option {
+	var selected []Option
+	for _, option := range options {
+		if slices.Contains(values, option.Value) {
+			selected = append(selected, option)
+		}
+	}
+	return selected
+}
+
+// BuildStandupReview builds the screen showing a user their answers before
+// their standup is submitted, with a button back to the answers. The answers
+// are kept in the view's private metadata; false is returned when they don't
+// fit in it, and the standup should be submitted without a review.
+func BuildStandupReview(
+	metadata *StandupModalMetadata,
+	questions []botconfig.Question,
+	answers map[string]Answer,
+) (*Modal, bool) {
+	locale := metadata.Locale
+	review := *metadata
+	review.Answers = make(map[string]Answer, len(answers))
+
+	builder := NewModalBuilder(locale.T(i18n.ModalTitle), StandupReviewCallbackID).
+		SetSubmit(locale.T(i18n.ModalSubmit)).
+		AddHeader(locale.T(i18n.ModalReview))
+
+	for i, shown := range ShownQuestions(questions, answers) {
+		if !shown {
+			continue
+		}
+
+		blockID := QuestionBlockID(i)
+		text := "_" + locale.T(i18n.ModalNoAnswer) + "_"
+		if answer, ok := answers[blockID]; ok {
+			review.Answers[blockID] = answer
+			if answer.Text() != "" {
+				text = answer.Text()
 			}
-			input.Element = element
 		}
+		builder.AddSection(fmt.Sprintf("*%s*\n%s", questions[i].Text, text))
+	}
+
+	data, err := json.Marshal(&review)
+	if err != nil || len(data) > maxPrivateMetadataLength {
+		return nil, false
 	}
 
-	b.modal.Blocks = append(b.modal.Blocks, input)
-	return b
+	modal := builder.
+		AddActions("", NewButton(StandupEditActionID, locale.T(i18n.ModalEdit), "")).
+		Build()
+	modal.PrivateMetadata = string(data)
+	return modal, true
 }
 
-// Build returns the built modal.
-func (b *ModalBuilder) Build() *Modal {
-	return b.modal
+// BuildStandupNotice builds a standup screen with only a message, e.g. to
+// replace the review screen when the standup can't be submitted.
+func BuildStandupNotice(text string, locale i18n.Locale) *Modal {
+	return NewModalBuilder(locale.T(i18n.ModalTitle), StandupReviewCallbackID).
+		AddSection(text).
+		Build()
 }
 
-// MessageBuilder helps build Slack messages.
-type MessageBuilder struct {
-	blocks []Block
+// ShownQuestions reports, for each question, whether it is asked given the
+// answers so far, keyed by question block ID. A conditional question is
+// hidden when the question it depends on is hidden or its answer doesn't match.
+func ShownQuestions(questions []botconfig.Question, answers map[string]Answer) []bool {
+	shown := make([]bool, len(questions))
+	indexByID := make(map[string]int)
+
+	for i, question := range questions {
+		shown[i] = true
+		if cond := question.ShowIf; cond != nil {
+			j, ok := indexByID[cond.Question]
+			if ok && shown[j] {
+				answer := answers[QuestionBlockID(j)]
+				shown[i] = cond.Matches(append([]string{answer.Value}, answer.Values...)...)
+			} else {
+				shown[i] = false
+			}
+		}
+
+		if question.ID != "" {
+			indexByID[question.ID] = i
+		}
+	}
+
+	return shown
 }
 
-// NewMessageBuilder creates a new message builder.
-func NewMessageBuilder() *MessageBuilder {
-	return &MessageBuilder{
-		blocks: []Block{},
-	}
-}
-
-// AddHeader adds a header to the message.
-func (b *MessageBuilder) AddHeader(text string) *MessageBuilder {
-	b.blocks = append(b.blocks, HeaderBlock{
-		Type: "header",
-		Text: &TextBlock{
-			Type:  "plain_text",
-			Text:  text,
-			Emoji: true,
-		},
-	})
-	return b
-}
-
-// AddSection adds a section to the message.
-func (b *MessageBuilder) AddSection(text string) *MessageBuilder {
-	b.blocks = append(b.blocks, &SectionBlock{
-		Type: "section",
-		Text: &TextBlock{
-			Type: "mrkdwn",
-			Text: text,
-		},
-	})
-	return b
-}
-
-// AddFields adds fields to the last section.
-func (b *MessageBuilder) AddFields(fields ...string) *MessageBuilder {
-	if len(b.blocks) == 0 || len(fields)%2 != 0 {
-		return b
-	}
-
-	// Find the last section block
-	for i := len(b.blocks) - 1; i >= 0; i-- {
-		if section, ok := b.blocks[i].(*SectionBlock); ok {
-			for j := 0; j < len(fields); j += 2 {
-				section.Fields = append(section.Fields, TextBlock{
-					Type: "mrkdwn",
-					Text: fmt.Sprintf("*%s*\n%s", fields[j], fields[j+1]),
-				})
+// ValidateAnswers checks answers against their questions' rules, returning
+// an error message for each answer that breaks one, keyed by question block
+// ID. Hidden questions aren't checked. Slack shows the messages in the modal.
+func ValidateAnswers(questions []botconfig.Question, answers map[string]Answer, locale i18n.Locale) map[string]string {
+	errs := make(map[string]string)
+	shown := ShownQuestions(questions, answers)
+
+	for i, question := range questions {
+		if !shown[i] {
+			continue
+		}
+
+		blockID := QuestionBlockID(i)
+		answer := answers[blockID]
+		if strings.TrimSpace(answer.Value) == "" && len(answer.Values) == 0 {
+			if !question.Optional {
+				errs[blockID] = locale.T(i18n.AnswerRequired)
+			}
+			continue
+		}
+
+		if question.Type != botconfig.QuestionText && question.Type != "" {
+			continue
+		}
+
+		length := utf8.RuneCountInString(strings.TrimSpace(answer.Value))
+		switch {
+		case question.MinLength > 0 && length < question.MinLength:
+			errs[blockID] = locale.T(i18n.AnswerTooShort, question.MinLength)
+		case question.MaxLength > 0 && length > question.MaxLength:
+			errs[blockID] = locale.T(i18n.AnswerTooLong, question.MaxLength)
+		case question.Pattern != "" && !matchesPattern(question.Pattern, answer.Value):
+			errs[blockID] = question.PatternMessage
+			if errs[blockID] == "" {
+				errs[blockID] = locale.T(i18n.AnswerMismatch)
 			}
-			// No need to reassign as we're modifying the pointer
-			break
 		}
 	}
 
-	return b
+	return errs
 }
 
-// AddDivider adds a divider block.
-func (b *MessageBuilder) AddDivider() *MessageBuilder {
-	b.blocks = append(b.blocks, DividerBlock{Type: "divider"})
-	return b
+// matchesPattern reports whether an answer matches a question's pattern.
+// Patterns are checked when the config loads, so a bad one lets answers through.
+func matchesPattern(pattern, answer string) bool {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return true
+	}
+	return re.MatchString(answer)
 }
 
-// Build returns the built blocks.
-func (b *MessageBuilder) Build() []Block {
-	return b.blocks
+// NewOptions creates plain text options whose values match their labels.
+func NewOptions(labels ...string) []Option {
+	return blockkit.NewOptions(labels...)
 }
 
-// BuildStandupModal builds a standup submission modal.
-func BuildStandupModal(channelID, sessionID string, questions []string) *Modal {
-	metadata := StandupModalMetadata{
-		ChannelID: channelID,
-		SessionID: sessionID,
-		Date:      time.Now().Format("2006-01-02"),
-		Timestamp: time.Now(),
+// NewButton creates a plain text button element.
+func NewButton(actionID, text, value string) ButtonElement {
+	return blockkit.NewButton(actionID, text, value)
+}
+
+// ReminderStatus is the live standup status shown in a reminder DM.
+type ReminderStatus struct {
+	Questions []string // Questions the channel asks
+	Submitted int      // Users who have submitted today
+	Total     int      // Users expected to submit
+	TeamID    string   // Workspace of the channel, if known, for the channel link
+	Locale    i18n.Locale
+}
+
+// ChannelURL returns a link that opens a channel in the Slack client.
+func ChannelURL(teamID, channelID string) string {
+	query := url.Values{"channel": {channelID}}
+	if teamID != "" {
+		query.Set("team", teamID)
 	}
+	return "https://slack.com/app_redirect?" + query.Encode()
+}
 
-	builder := NewModalBuilder("Daily Standup", "standup_submission").
-		SetSubmit("Submit").
-		SetPrivateMetadata(metadata).
-		AddHeader("📝 Daily Standup Update").
-		AddSection("Please answer the following questions:")
+// BuildReminderMessage builds a reminder message with the channel's questions,
+// how many teammates have submitted, and quick action buttons.
+func BuildReminderMessage(userName, channelName, channelID, template string, status ReminderStatus) []Block {
+	text := templates.Render(template, templates.Vars{"UserName": userName, "ChannelName": channelName})
 
-	// Add input for each question
-	for i, question := range questions {
-		blockID := fmt.Sprintf("question_%d", i)
-		actionID := fmt.Sprintf("answer_%d", i)
-		builder.AddTextInput(blockID, actionID, question, "Type your answer here...", true)
+	builder := NewMessageBuilder().AddSection(text)
+
+	if len(status.Questions) > 0 {
+		lines := make([]string, 0, len(status.Questions))
+		for _, question := range status.Questions {
+			lines = append(lines, "• "+question)
+		}
+		builder.AddSection(status.Locale.T(i18n.ReminderQuestions) + "\n" + strings.Join(lines, "\n"))
+	}
+
+	if status.Total > 0 {
+		builder.AddSection(status.Locale.T(i18n.ReminderProgress, status.Submitted, status.Total))
 	}
 
+	submit := NewButton(ActionSubmitNow, status.Locale.T(i18n.ReminderSubmit), channelID)
+	submit.Style = "primary"
+
+	open := NewButton(ActionOpenChannel, status.Locale.T(i18n.ReminderOpen, channelName), channelID)
+	open.URL = ChannelURL(status.TeamID, channelID)
+
+	return builder.
+		AddActions("reminder_actions",
+			submit,
+			NewButton(ActionSkipToday, status.Locale.T(i18n.ReminderSkip), channelID),
+			NewButton(ActionSnooze, status.Locale.T(i18n.ReminderSnooze), channelID),
+			open,
+		).
+		Build()
+}
+
+// BuildTestReminderMessage builds a reminder sent only to the admin who
+// asked for it, marked as a test.
+func BuildTestReminderMessage(userName, channelName, channelID, template string, status ReminderStatus) []Block {
+	notice := NewMessageBuilder().
+		AddSection(fmt.Sprintf("🧪 *Test reminder for <#%s>* — only you received it. "+
+			"This is what your team sees at the reminder times.", channelID)).
+		Build()
+	return append(notice, BuildReminderMessage(userName, channelName, channelID, template, status)...)
+}
+
+// BuildReminderSubmittedMessage replaces a reminder DM once the user has
+// submitted. The time is shown in the reader's timezone.
+func BuildReminderSubmittedMessage(channelID string, submittedAt time.Time) []Block {
+	return NewMessageBuilder().
+		AddSection(fmt.Sprintf("✅ Submitted at <!date^%d^{time}|%s> — thanks! Your update is in <#%s>.",
+			submittedAt.Unix(), submittedAt.UTC().Format("3:04 PM UTC"), channelID)).
+		Build()
+}
+
+// BuildManagerEscalationMessage builds the DM sent to a manager when a user
+// hasn't submitted after their reminders.
+func BuildManagerEscalationMessage(userID, channelID string, reminderCount int) []Block {
+	return NewMessageBuilder().
+		AddSection(fmt.Sprintf("⚠️ <@%s> hasn't submitted today's standup in <#%s> after %d reminders.",
+			userID, channelID, reminderCount)).
+		Build()
+}
+
+// BuildMemberApprovalMessage builds the DM asking a channel admin whether to
+// add someone who joined the channel to its standup.
+func BuildMemberApprovalMessage(userID, channelID string) []Block {
+	value := MemberActionValue(channelID, userID)
+
+	add := NewButton(ActionAddMember, "Add to standup", value)
+	add.Style = "primary"
+
+	return NewMessageBuilder().
+		AddSection(fmt.Sprintf("👋 <@%s> just joined <#%s>. Add them to the daily standup?", userID, channelID)).
+		AddActions("member_approval",
+			add,
+			NewButton(ActionDismissMember, "Not now", value),
+		).
+		Build()
+}
+
+// BuildSummaryReview builds the blocks added to a summary leads acknowledge:
+// who reviewed it so far and a button to mark it reviewed.
+func BuildSummaryReview(channelID, date string, reviewedBy []string) []Block {
+	builder := NewMessageBuilder()
+	if len(reviewedBy) > 0 {
+		mentions := make([]string, 0, len(reviewedBy))
+		for _, userID := range reviewedBy {
+			mentions = append(mentions, fmt.Sprintf("<@%s>", security.SanitizeLogValue(userID)))
+		}
+		builder.AddSection("👀 Reviewed by " + strings.Join(mentions, ", "))
+	}
+
+	review := NewButton(ActionReviewSummary, "👀 Mark as reviewed", SummaryActionValue(channelID, date))
+	return builder.AddActions("summary_review", review).Build()
+}
+
+// BuildStreakMilestones builds the blocks added to a summary celebrating the
+// users who reached a streak milestone that day.
+func BuildStreakMilestones(milestones []StreakMilestone, locale i18n.Locale) []Block {
+	lines := make([]string, 0, len(milestones))
+	for _, milestone := range milestones {
+		lines = append(lines, locale.T(i18n.SummaryStreak, security.SanitizeLogValue(milestone.UserID), milestone.Days))
+	}
+	return NewMessageBuilder().
+		AddSection(locale.T(i18n.SummaryStreaks) + "\n" + strings.Join(lines, "\n")).
+		Build()
+}
+
+// BuildNudgeMessage builds the gentle public nudge posted in the channel.
+func BuildNudgeMessage(userID string) []Block {
+	return NewMessageBuilder().
+		AddSection(fmt.Sprintf("👋 <@%s>, we'd love to hear your update today! Use `/standup` when you have a minute.",
+			userID)).
+		Build()
+}
+
+// BuildChannelReminderMessage builds a reminder mentioning a user in the
+// standup channel, for users who prefer that to a DM.
+func BuildChannelReminderMessage(userID, channelID string) []Block {
+	submit := NewButton(ActionSubmitNow, "Submit now", channelID)
+	submit.Style = "primary"
+
+	return NewMessageBuilder().
+		AddSection(fmt.Sprintf("🔔 <@%s>, time for your standup update!", userID)).
+		AddActions("reminder_actions", submit).
+		Build()
+}
+
+// BuildPreferencesMessage builds the message showing a user's reminder
+// preferences, with buttons to change them. An empty reminderTime means the
+// channel's reminder times, and an empty language the user's Slack language.
+func BuildPreferencesMessage(remindersOff bool, reminderTime string, inChannel bool, language string) []Block {
+	reminders, toggle := "on", NewButton(ActionPrefsReminders, "Turn reminders off", "off")
+	if remindersOff {
+		reminders, toggle = "off", NewButton(ActionPrefsReminders, "Turn reminders on", "on")
+	}
+	if reminderTime == "" {
+		reminderTime = "the channel's reminder times"
+	}
+	delivery, move := "direct message", NewButton(ActionPrefsDelivery, "Remind me in the channel", "channel")
+	if inChannel {
+		delivery, move = "mention in the standup channel", NewButton(ActionPrefsDelivery, "Remind me by DM", "dm")
+	}
+
+	if language == "" {
+		language = "your Slack language"
+	}
+
+	return NewMessageBuilder().
+		AddSection(fmt.Sprintf("*Your reminder preferences*\n• Reminders: %s\n• Time: %s\n• Delivery: %s"+
+			"\n• Language: %s", reminders, reminderTime, delivery, language)).
+		AddActions("prefs_actions", toggle, move).
+		AddSection("Change your reminder time with `/standup prefs set reminder_time HH:MM`, " +
+			"or `default` for the channel's times, and your language with `/standup prefs set language es`.").
+		Build()
+}
+
+// BuildBlockerMessage builds the cross-post of a reported blocker. The link
+// points at the standup update and is left out when empty.
+func BuildBlockerMessage(userID, channelID, blocker, link string) []Block {
+	builder := NewMessageBuilder().
+		AddSection(fmt.Sprintf("🚧 <@%s> reported a blocker in <#%s>:\n%s", userID, channelID, quote(blocker)))
+	if link != "" {
+		builder.AddSection(fmt.Sprintf("<%s|View standup update>", link))
+	}
 	return builder.Build()
 }
 
-// BuildReminderMessage builds a reminder message.
-func BuildReminderMessage(userName, channelName, template string) []Block {
-	// Replace template variables
-	text := strings.ReplaceAll(template, "{{.UserName}}", userName)
-	text = strings.ReplaceAll(text, "{{.ChannelName}}", channelName)
+// quote formats text as a block quote.
+func quote(text string) string {
+	return "> " + strings.ReplaceAll(strings.TrimSpace(text), "\n", "\n> ")
+}
+
+// BuildStandupAnchorMessage builds the daily thread anchor message.
+func BuildStandupAnchorMessage(date string, submitted, total int) []Block {
+	status := fmt.Sprintf("*%d of %d* submitted", submitted, total)
+	if total > 0 && submitted >= total {
+		status = fmt.Sprintf("✅ *All %d* submitted", total)
+	}
 
 	return NewMessageBuilder().
-		AddSection(text).
+		AddHeader(fmt.Sprintf("🧵 Daily Standup — %s", date)).
+		AddSection("Updates are posted as replies in this thread. Use `/standup` to submit yours.").
+		AddSection(status).
 		Build()
 }
 
-// BuildSummaryMessage builds a daily summary message.
-func BuildSummaryMessage(date, headerTemplate string, responses []*UserResponseSummary) []Block {
-	// Replace template variables
-	header := strings.ReplaceAll(headerTemplate, "{{.Date}}", date)
+// BuildSummaryMessage builds a daily summary message in the channel's locale.
+func BuildSummaryMessage(date, headerTemplate string, responses []*UserResponseSummary, locale i18n.Locale) []Block {
+	header := templates.Render(headerTemplate, templates.Vars{"Date": date})
 
 	builder := NewMessageBuilder().
 		AddHeader(header)
 
 	// Add submitted users
 	if len(responses) == 0 {
-		builder.AddSection("No responses yet today.")
+		builder.AddSection(locale.T(i18n.SummaryEmpty))
 		return builder.Build()
 	}
 
 	var submitted []string
+	var skipped []string
 	var missing []string
 
 	for _, resp := range responses {
-		if resp.Submitted {
-			userID := security.SanitizeLogValue(resp.UserID)
-			submitted = append(submitted, fmt.Sprintf("• <@%s> - %s", userID, resp.Time))
-		} else {
-			missing = append(missing, fmt.Sprintf("• <@%s>", security.SanitizeLogValue(resp.UserID)))
+		switch {
+		case resp.Submitted:
+			submitted = append(submitted, fmt.Sprintf("• %s - %s", resp.Label(), resp.Time))
+		case resp.Skipped:
+			line := "• " + resp.Label()
+			if reason := security.SanitizeLogValue(resp.SkipReason); reason != "" {
+				line += " - " + reason
+			}
+			skipped = append(skipped, line)
+		default:
+			missing = append(missing, "• "+resp.Label())
 		}
 	}
 
 	if len(submitted) > 0 {
-		builder.AddSection("✅ *Submitted:*\n" + strings.Join(submitted, "\n"))
+		builder.AddSection(locale.T(i18n.SummarySubmitted) + "\n" + strings.Join(submitted, "\n"))
+	}
+
+	if len(skipped) > 0 {
+		builder.AddSection(locale.T(i18n.SummarySkipped) + "\n" + strings.Join(skipped, "\n"))
 	}
 
 	if len(missing) > 0 {
 		builder.AddDivider()
-		builder.AddSection("⏳ *Pending:*\n" + strings.Join(missing, "\n"))
+		builder.AddSection(locale.T(i18n.SummaryPending) + "\n" + strings.Join(missing, "\n"))
 	}
 
 	return builder.Build()
 }
 
+// SummaryGrouping is how BuildDigestMessage lays out answers.
+type SummaryGrouping string
+
+// Summary groupings.
+const (
+	GroupByUser     SummaryGrouping = "user"     // Each user's answers together
+	GroupByQuestion SummaryGrouping = "question" // Everyone's answer to each question together
+)
+
+// Limits keeping digests readable and within Slack's block limits.
+const (
+	maxDigestAnswerLength  = 280  // Characters of an answer shown before it's cut off
+	maxDigestSections      = 40   // Answer sections; Slack allows 50 blocks per message
+	maxSectionTextLength   = 3000 // Slack's limit for section text
+	digestTruncationMarker = "…"
+)
+
+// BuildDigestMessage builds a daily summary that includes submitted answers,
+// grouped by user or by question, in the channel's locale. Long answers are
+// cut off; threadLink, if set, points readers to the full updates.
+func BuildDigestMessage(
+	date, headerTemplate string,
+	responses []*UserResponseSummary,
+	grouping SummaryGrouping,
+	threadLink string,
+	locale i18n.Locale,
+) []Block {
+	header := templates.Render(headerTemplate, templates.Vars{"Date": date})
+	builder := NewMessageBuilder().AddHeader(header)
+
+	if len(responses) == 0 {
+		builder.AddSection(locale.T(i18n.SummaryEmpty))
+		return builder.Build()
+	}
+
+	var submitted []*UserResponseSummary
+	var skipped, missing []string
+	for _, resp := range responses {
+		switch {
+		case resp.Submitted:
+			submitted = append(submitted, resp)
+		case resp.Skipped:
+			line := resp.Label()
+			if reason := security.SanitizeLogValue(resp.SkipReason); reason != "" {
+				line += " (" + reason + ")"
+			}
+			skipped = append(skipped, line)
+		default:
+			missing = append(missing, resp.Label())
+		}
+	}
+
+	var sections []string
+	if grouping == GroupByQuestion {
+		sections = digestByQuestion(submitted, locale)
+	} else {
+		sections = digestByUser(submitted)
+	}
+	if len(sections) > maxDigestSections {
+		hidden := len(sections) - maxDigestSections + 1
+		sections = append(sections[:maxDigestSections-1], locale.T(i18n.SummaryMore, hidden))
+	}
+	for _, section := range sections {
+		builder.AddSection(section)
+	}
+
+	if len(skipped) > 0 {
+		builder.AddSection(locale.T(i18n.SummarySkipped) + " " + strings.Join(skipped, ", "))
+	}
+
+	if len(missing) > 0 {
+		builder.AddDivider()
+		builder.AddSection(locale.T(i18n.SummaryPending) + " " + strings.Join(missing, ", "))
+	}
+
+	if threadLink != "" {
+		builder.AddSection(locale.T(i18n.SummaryThread, threadLink))
+	}
+
+	return builder.Build()
+}
+
+// digestByUser returns a section per submitted user with their answers.
+func digestByUser(submitted []*UserResponseSummary) []string {
+	sections := make([]string, 0, len(submitted))
+	for _, resp := range submitted {
+		lines := []string{fmt.Sprintf("✅ *%s* · %s", resp.Label(), resp.Time)}
+		for _, answer := range resp.Answers {
+			lines = append(lines, fmt.Sprintf("*%s*\n%s", answer.Question, truncateAnswer(answer.Text)))
+		}
+		sections = append(sections, truncateSection(strings.Join(lines, "\n")))
+	}
+	return sections
+}
+
+// digestByQuestion returns a section per question with everyone's answers,
+// in the order the questions were asked.
+func digestByQuestion(submitted []*UserResponseSummary, locale i18n.Locale) []string {
+	var questions []string
+	answers := make(map[string][]string)
+	for _, resp := range submitted {
+		for _, answer := range resp.Answers {
+			if _, ok := answers[answer.Question]; !ok {
+				questions = append(questions, answer.Question)
+			}
+			text := strings.Join(strings.Fields(truncateAnswer(answer.Text)), " ")
+			answers[answer.Question] = append(answers[answer.Question], fmt.Sprintf("• %s: %s", resp.Label(), text))
+		}
+	}
+
+	sections := make([]string, 0, len(questions)+1)
+	if len(submitted) > 0 {
+		names := make([]string, 0, len(submitted))
+		for _, resp := range submitted {
+			names = append(names, resp.Label())
+		}
+		sections = append(sections, locale.T(i18n.SummarySubmitted)+" "+strings.Join(names, ", "))
+	}
+	for _, question := range questions {
+		sections = append(sections, truncateSection(fmt.Sprintf("*%s*\n%s", question, strings.Join(answers[question], "\n"))))
+	}
+	return sections
+}
+
+// truncateAnswer cuts off answers too long for a digest.
+func truncateAnswer(text string) string {
+	return truncateRunes(strings.TrimSpace(text), maxDigestAnswerLength)
+}
+
+// truncateSection keeps section text within Slack's limit.
+func truncateSection(text string) string {
+	return truncateRunes(text, maxSectionTextLength)
+}
+
+// truncateRunes cuts s to at most n runes, marking the cut.
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	marker := []rune(digestTruncationMarker)
+	return strings.TrimSpace(string(runes[:n-len(marker)])) + digestTruncationMarker
+}
+
 // UserResponseSummary contains summary info for a user's response.
 type UserResponseSummary struct {
-	UserID    string
-	UserName  string
-	Submitted bool
-	Time      string
+	UserID   string
+	UserName string
+	// Display is how summaries show the user, as mrkdwn; empty for a
+	// mention
+	Display    string
+	Submitted  bool
+	Time       string
+	Skipped    bool            // Skipped with /standup skip; ignored if Submitted
+	SkipReason string          // Optional reason given when skipping
+	Answers    []SummaryAnswer // Submitted answers in question order, for digests
 }
 
-// ParseModalSubmission parses the submission data from a modal.
-func ParseModalSubmission(view *View) (map[string]string, error) {
+// Label returns how summaries show the user: their Display, or else a
+// mention.
+func (r *UserResponseSummary) Label() string {
+	if r.Display != "" {
+		return r.Display
+	}
+	return fmt.Sprintf("<@%s>", security.SanitizeLogValue(r.UserID))
+}
+
+// mrkdwnEscaper escapes the characters Slack reads as markup.
+var mrkdwnEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// EscapeText escapes text shown in a message, such as a user's name, so
+// Slack doesn't read it as a mention or link.
+func EscapeText(text string) string {
+	return mrkdwnEscaper.Replace(text)
+}
+
+// StreakMilestone is a user who reached a streak milestone, celebrated in
+// the daily summary.
+type StreakMilestone struct {
+	UserID   string
+	UserName string
+	Days     int
+}
+
+// SummaryAnswer is a submitted answer shown in a digest.
+type SummaryAnswer struct {
+	Question string
+	Text     string
+}
+
+// Answer is a single answer parsed from a modal submission.
+type Answer struct {
+	ElementType string   `json:"type"`             // Slack element type, e.g. static_select
+	Value       string   `json:"value,omitempty"`  // Raw value: text, option value, date or number
+	Values      []string `json:"values,omitempty"` // Option values for multi-choice elements
+	Label       string   `json:"label,omitempty"`  // Human readable form of the answer
+}
+
+// Text returns the answer formatted for display.
+func (a Answer) Text() string {
+	if a.Label != "" {
+		return a.Label
+	}
+	return a.Value
+}
+
+// ParseModalAnswers parses the typed answers from a modal, keyed by question block ID.
+func ParseModalAnswers(view *View) (map[string]Answer, error) {
 	if view == nil || view.State == nil {
 		return nil, fmt.Errorf("invalid view state")
 	}
 
-	responses := make(map[string]string)
+	answers := make(map[string]Answer)
 
 	for blockID, actions := range view.State.Values {
+		if !strings.HasPrefix(blockID, "question_") {
+			continue
+		}
+
 		for _, value := range actions {
-			if value.Type == "plain_text_input" {
-				// Extract question number from block ID
-				if strings.HasPrefix(blockID, "question_") {
-					responses[blockID] = value.Value
+			answer := Answer{ElementType: value.Type}
+
+			switch value.Type {
+			case "plain_text_input", "number_input":
+				answer.Value = value.Value
+			case "static_select", "radio_buttons":
+				if value.SelectedOption != nil {
+					answer.Value = value.SelectedOption.Value
+					if value.SelectedOption.Text != nil {
+						answer.Label = value.SelectedOption.Text.Text
+					}
+				}
+			case "checkboxes", "multi_static_select":
+				labels := make([]string, 0, len(value.SelectedOptions))
+				for _, option := range value.SelectedOptions {
+					answer.Values = append(answer.Values, option.Value)
+					if option.Text != nil {
+						labels = append(labels, option.Text.Text)
+					}
 				}
+				answer.Label = strings.Join(labels, ", ")
+			case "datepicker":
+				answer.Value = value.SelectedDate
+			default:
+				continue
 			}
+
+			answers[blockID] = answer
 		}
 	}
 
+	return answers, nil
+}
+
+// ParseModalSubmission parses the submission data from a modal.
+// Typed answers are flattened to their display text.
+func ParseModalSubmission(view *View) (map[string]string, error) {
+	answers, err := ParseModalAnswers(view)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make(map[string]string, len(answers))
+	for blockID, answer := range answers {
+		responses[blockID] = answer.Text()
+	}
+
 	return responses, nil
 }
 
@@ -0,0 +1,264 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/i18n"
+)
+
+func TestBuildStandupModalWithAnswers(t *testing.T) {
+	questions := []botconfig.Question{
+		{ID: "blocked", Text: "Blocked?", Type: botconfig.QuestionYesNo},
+		{ID: "details", Text: "Blocker details?", Type: botconfig.QuestionText,
+			ShowIf: &botconfig.Condition{Question: "blocked", Equals: []string{"yes"}}},
+		{Text: "Who can help?", Type: botconfig.QuestionText,
+			ShowIf: &botconfig.Condition{Question: "details", Equals: []string{"anything"}}},
+		{Text: "Today?", Type: botconfig.QuestionText},
+	}
+	metadata := &StandupModalMetadata{ChannelID: "C1234567890", Date: "2024-01-15"}
+
+	inputs := func(modal *Modal) []InputBlock {
+		var blocks []InputBlock
+		for _, block := range modal.Blocks {
+			if input, ok := block.(InputBlock); ok {
+				blocks = append(blocks, input)
+			}
+		}
+		return blocks
+	}
+
+	// Conditional questions are hidden until their answer matches
+	blocks := inputs(BuildStandupModalWithAnswers(metadata, questions, nil))
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "question_0", blocks[0].BlockID)
+	assert.True(t, blocks[0].DispatchAction)
+	assert.Equal(t, "question_3", blocks[1].BlockID)
+	assert.False(t, blocks[1].DispatchAction)
+
+	answers := map[string]Answer{"question_0": {ElementType: "radio_buttons", Value: "yes", Label: "Yes"}}
+	blocks = inputs(BuildStandupModalWithAnswers(metadata, questions, answers))
+	require.Len(t, blocks, 3)
+	assert.Equal(t, "question_1", blocks[1].BlockID)
+
+	// Questions depending on hidden questions stay hidden
+	answers = map[string]Answer{
+		"question_0": {Value: "no"},
+		"question_1": {Value: "anything"},
+	}
+	assert.Equal(t, []bool{true, false, false, true}, ShownQuestions(questions, answers))
+
+	// Prefilled inputs get new action IDs so Slack shows the suggestion
+	blocks = inputs(BuildPrefilledStandupModal(metadata, questions, map[string]string{"question_3": "Shipped it"}))
+	require.Len(t, blocks, 2)
+	element := blocks[1].Element.(PlainTextInputElement)
+	assert.Equal(t, "answer_3_prefill", element.ActionID)
+	assert.Equal(t, "Shipped it", element.InitialValue)
+	assert.Equal(t, "answer_0", blocks[0].Element.(RadioButtonsElement).ActionID)
+}
+
+func TestBuildStandupReview(t *testing.T) {
+	questions := []botconfig.Question{
+		{ID: "blocked", Text: "Blocked?", Type: botconfig.QuestionYesNo},
+		{Text: "Blocker details?", Type: botconfig.QuestionText,
+			ShowIf: &botconfig.Condition{Question: "blocked", Equals: []string{"yes"}}},
+		{Text: "Areas?", Type: botconfig.QuestionMultiSelect, Options: []string{"API", "Web"}},
+		{Text: "Notes?", Type: botconfig.QuestionText, Optional: true},
+	}
+	metadata := &StandupModalMetadata{ChannelID: "C1234567890", Date: "2024-01-15"}
+	answers := map[string]Answer{
+		"question_0": {ElementType: "radio_buttons", Value: "no", Label: "No"},
+		"question_1": {ElementType: "plain_text_input", Value: "left over"},
+		"question_2": {ElementType: "checkboxes", Values: []string{"Web"}, Label: "Web"},
+	}
+
+	modal, ok := BuildStandupReview(metadata, questions, answers)
+	require.True(t, ok)
+	assert.Equal(t, StandupReviewCallbackID, modal.CallbackID)
+
+	var sections []string
+	for _, block := range modal.Blocks {
+		if section, ok := block.(*SectionBlock); ok {
+			sections = append(sections, section.Text.Text)
+		}
+	}
+	assert.Equal(t, []string{"*Blocked?*\nNo", "*Areas?*\nWeb", "*Notes?*\n_No answer_"}, sections)
+	assert.Equal(t, StandupEditActionID, modal.Blocks[len(modal.Blocks)-1].(ActionsBlock).Elements[0].(ButtonElement).ActionID)
+
+	// Answers to hidden questions aren't kept
+	review, err := ParseModalMetadata(modal.PrivateMetadata)
+	require.NoError(t, err)
+	assert.Equal(t, "C1234567890", review.ChannelID)
+	assert.Equal(t, map[string]Answer{"question_0": answers["question_0"], "question_2": answers["question_2"]}, review.Answers)
+
+	// Editing shows the answers again
+	edit := BuildStandupModalWithAnswers(metadata, questions, review.Answers)
+	var checkboxes CheckboxesElement
+	for _, block := range edit.Blocks {
+		if input, ok := block.(InputBlock); ok && input.BlockID == "question_2" {
+			checkboxes = input.Element.(CheckboxesElement)
+		}
+	}
+	assert.Equal(t, NewOptions("Web"), checkboxes.InitialOptions)
+
+	// Answers too long to keep with the screen are submitted without a review
+	answers["question_3"] = Answer{ElementType: "plain_text_input", Value: strings.Repeat("a", 3000)}
+	_, ok = BuildStandupReview(metadata, questions, answers)
+	assert.False(t, ok)
+}
+
+func TestValidateAnswers(t *testing.T) {
+	questions := []botconfig.Question{
+		{ID: "blocked", Text: "Blocked?", Type: botconfig.QuestionYesNo},
+		{Text: "Blocker details?", Type: botconfig.QuestionText, MinLength: 10,
+			ShowIf: &botconfig.Condition{Question: "blocked", Equals: []string{"yes"}}},
+		{Text: "Ticket?", Type: botconfig.QuestionText, Pattern: `^PROJ-\d+$`, PatternMessage: "Use a PROJ ticket"},
+		{Text: "Today?", Type: botconfig.QuestionText, MaxLength: 5},
+		{Text: "Anything else?", Type: botconfig.QuestionText, Optional: true, MinLength: 3},
+	}
+
+	// Hidden and optional questions can be left blank
+	errs := ValidateAnswers(questions, map[string]Answer{
+		"question_0": {Value: "no"},
+		"question_2": {Value: "PROJ-12"},
+		"question_3": {Value: "  Tests  "},
+	}, i18n.English)
+	assert.Empty(t, errs)
+
+	errs = ValidateAnswers(questions, map[string]Answer{
+		"question_0": {Value: "yes"},
+		"question_1": {Value: "Waiting"},
+		"question_2": {Value: "JIRA-12"},
+		"question_3": {Value: "   "},
+		"question_4": {Value: "ok"},
+	}, i18n.English)
+	assert.Equal(t, map[string]string{
+		"question_1": "Please write at least 10 characters.",
+		"question_2": "Use a PROJ ticket",
+		"question_3": "This question needs an answer.",
+		"question_4": "Please write at least 3 characters.",
+	}, errs)
+
+	errs = ValidateAnswers(questions, map[string]Answer{
+		"question_0": {Value: "no"},
+		"question_2": {Value: "PROJ-1"},
+		"question_3": {Value: "Écrire"},
+	}, i18n.French)
+	assert.Equal(t, map[string]string{"question_3": "Veuillez écrire au plus 5 caractères."}, errs)
+}
+
+func TestBuildReminderMessage(t *testing.T) {
+	blocks := BuildReminderMessage("alice", "engineering", "C1234567890", "Hi {{.UserName}} in #{{.ChannelName}}",
+		ReminderStatus{Questions: []string{"Yesterday?", "Today?"}, Submitted: 2, Total: 5, TeamID: "T1234567890"})
+	require.Len(t, blocks, 4)
+
+	assert.Equal(t, "Hi alice in #engineering", blocks[0].(*SectionBlock).Text.Text)
+	assert.Equal(t, "*Today's questions:*\n• Yesterday?\n• Today?", blocks[1].(*SectionBlock).Text.Text)
+	assert.Equal(t, "👥 *2 of 5* submitted so far", blocks[2].(*SectionBlock).Text.Text)
+
+	actions := blocks[3].(ActionsBlock)
+	require.Len(t, actions.Elements, 4)
+	open := actions.Elements[3].(ButtonElement)
+	assert.Equal(t, ActionOpenChannel, open.ActionID)
+	assert.Equal(t, "https://slack.com/app_redirect?channel=C1234567890&team=T1234567890", open.URL)
+
+	// Reminders are in the user's locale
+	blocks = BuildReminderMessage("alice", "engineering", "C1234567890", "Hola {{.UserName}}",
+		ReminderStatus{Submitted: 2, Total: 5, Locale: i18n.Spanish})
+	require.Len(t, blocks, 3)
+	assert.Equal(t, "👥 *2 de 5* enviados hasta ahora", blocks[1].(*SectionBlock).Text.Text)
+	assert.Equal(t, "Abrir #engineering", blocks[2].(ActionsBlock).Elements[3].(ButtonElement).Text.Text)
+}
+
+func TestBuildBlockerMessage(t *testing.T) {
+	blocks := BuildBlockerMessage("U1234567890", "C1234567890", "Flaky CI\nNo staging access", "https://example.slack.com/p1")
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "🚧 <@U1234567890> reported a blocker in <#C1234567890>:\n> Flaky CI\n> No staging access",
+		blocks[0].(*SectionBlock).Text.Text)
+	assert.Equal(t, "<https://example.slack.com/p1|View standup update>", blocks[1].(*SectionBlock).Text.Text)
+
+	assert.Len(t, BuildBlockerMessage("U1234567890", "C1234567890", "Flaky CI", ""), 1)
+}
+
+func TestBuildMemberApprovalMessage(t *testing.T) {
+	blocks := BuildMemberApprovalMessage("U1234567890", "C1234567890")
+	require.Len(t, blocks, 2)
+
+	actions := blocks[1].(ActionsBlock)
+	require.Len(t, actions.Elements, 2)
+	add := actions.Elements[0].(ButtonElement)
+	assert.Equal(t, ActionAddMember, add.ActionID)
+
+	channelID, userID, err := ParseMemberActionValue(add.Value)
+	require.NoError(t, err)
+	assert.Equal(t, "C1234567890", channelID)
+	assert.Equal(t, "U1234567890", userID)
+
+	_, _, err = ParseMemberActionValue("C1234567890")
+	assert.Error(t, err)
+}
+
+func TestBuildSummaryReview(t *testing.T) {
+	blocks := BuildSummaryReview("C1234567890", "2024-01-15", nil)
+	require.Len(t, blocks, 1)
+
+	button := blocks[0].(ActionsBlock).Elements[0].(ButtonElement)
+	assert.Equal(t, ActionReviewSummary, button.ActionID)
+
+	channelID, date, err := ParseSummaryActionValue(button.Value)
+	require.NoError(t, err)
+	assert.Equal(t, "C1234567890", channelID)
+	assert.Equal(t, "2024-01-15", date)
+
+	blocks = BuildSummaryReview("C1234567890", "2024-01-15", []string{"U1234567890", "U0987654321"})
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "👀 Reviewed by <@U1234567890>, <@U0987654321>", blocks[0].(*SectionBlock).Text.Text)
+}
+
+func TestBuildDigestMessage(t *testing.T) {
+	responses := []*UserResponseSummary{
+		{UserID: "U0000000001", Submitted: true, Time: "9:05 AM", Answers: []SummaryAnswer{
+			{Question: "Yesterday?", Text: "Reviewed PRs"},
+			{Question: "Today?", Text: "Release\nprep"},
+		}},
+		{UserID: "U0000000002", Submitted: true, Time: "9:10 AM", Answers: []SummaryAnswer{
+			{Question: "Today?", Text: strings.Repeat("a", 300)},
+		}},
+		{UserID: "U0000000003", Skipped: true, SkipReason: "Out sick"},
+		{UserID: "U0000000004"},
+	}
+	text := func(block Block) string { return block.(*SectionBlock).Text.Text }
+
+	blocks := BuildDigestMessage("2024-01-15", "Standup {{.Date}}", responses, GroupByUser, "https://example.slack.com/p1",
+		i18n.English)
+	require.Len(t, blocks, 7)
+	assert.Equal(t, "Standup 2024-01-15", blocks[0].(HeaderBlock).Text.Text)
+	assert.Equal(t, "✅ *<@U0000000001>* · 9:05 AM\n*Yesterday?*\nReviewed PRs\n*Today?*\nRelease\nprep", text(blocks[1]))
+	assert.Equal(t, "✅ *<@U0000000002>* · 9:10 AM\n*Today?*\n"+strings.Repeat("a", 279)+"…", text(blocks[2]))
+	assert.Equal(t, "⏭️ *Skipped:* <@U0000000003> (Out sick)", text(blocks[3]))
+	assert.Equal(t, "⏳ *Pending:* <@U0000000004>", text(blocks[5]))
+	assert.Equal(t, "🧵 <https://example.slack.com/p1|View thread> for the full updates.", text(blocks[6]))
+
+	blocks = BuildDigestMessage("2024-01-15", "Standup {{.Date}}", responses[:1], GroupByQuestion, "", i18n.English)
+	require.Len(t, blocks, 4)
+	assert.Equal(t, "✅ *Submitted:* <@U0000000001>", text(blocks[1]))
+	assert.Equal(t, "*Yesterday?*\n• <@U0000000001>: Reviewed PRs", text(blocks[2]))
+	assert.Equal(t, "*Today?*\n• <@U0000000001>: Release prep", text(blocks[3]))
+
+	// Digests stay within Slack's block limit
+	many := make([]*UserResponseSummary, 60)
+	for i := range many {
+		many[i] = &UserResponseSummary{UserID: "U0000000001", Submitted: true, Time: "9:05 AM"}
+	}
+	blocks = BuildDigestMessage("2024-01-15", "Standup {{.Date}}", many, GroupByUser, "", i18n.English)
+	require.Len(t, blocks, 1+maxDigestSections)
+	assert.Equal(t, "_…and 21 more_", text(blocks[maxDigestSections]))
+
+	// Digests are in the channel's locale
+	blocks = BuildDigestMessage("2024-01-15", "Standup {{.Date}}", responses[2:], GroupByUser, "", i18n.German)
+	assert.Equal(t, "⏭️ *Ausgesetzt:* <@U0000000003> (Out sick)", text(blocks[1]))
+}
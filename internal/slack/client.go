@@ -4,15 +4,57 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/synaptiq/standup-bot/internal/apperr"
+	"github.com/synaptiq/standup-bot/internal/inflight"
 	"github.com/synaptiq/standup-bot/internal/security"
 )
 
+// ErrUserNotFound is returned when Slack has no user with the given ID, as
+// happens once a user is removed from the workspace.
+var ErrUserNotFound = errors.New("user not found")
+
+// APIError is an error returned by the Slack Web API, such as
+// "channel_not_found" or "cannot_dm_bot".
+type APIError struct {
+	Code string
+}
+
+func (e *APIError) Error() string {
+	return "slack API error: " + security.SanitizeLogValue(e.Code)
+}
+
+// ErrorKind classifies e for the user by its code.
+func (e *APIError) ErrorKind() apperr.Kind {
+	switch e.Code {
+	case "not_in_channel", "channel_not_found", "is_archived":
+		return apperr.KindNoAccess
+	case "ratelimited":
+		return apperr.KindUnavailable
+	default:
+		return apperr.KindInternal
+	}
+}
+
+// ErrorCode returns the Slack error code of err, or "" when err didn't come
+// from the Slack API.
+func ErrorCode(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
 // Client interface defines Slack API operations.
 type Client interface {
 	// Message operations
@@ -20,15 +62,36 @@ type Client interface {
 	PostEphemeral(ctx context.Context, channel, userID string, opts ...MessageOption) (string, error)
 	UpdateMessage(ctx context.Context, channel, timestamp string, opts ...MessageOption) error
 	DeleteMessage(ctx context.Context, channel, timestamp string) error
+	GetPermalink(ctx context.Context, channel, timestamp string) (string, error)
+	GetReactions(ctx context.Context, channel, timestamp string) ([]Reaction, error)
+	PostToResponseURL(ctx context.Context, responseURL string, message *ResponseMessage) error
+	// UnfurlLinks previews the links of a message with blocks, keyed by URL
+	UnfurlLinks(ctx context.Context, target UnfurlTarget, unfurls map[string][]Block) error
+	// ScheduleMessage has Slack post the message at postAt, returning the
+	// scheduled message's ID
+	ScheduleMessage(ctx context.Context, channel string, postAt time.Time, opts ...MessageOption) (string, error)
+	// ListScheduledMessages lists the messages the bot scheduled in a
+	// channel, or in every channel if channel is empty
+	ListScheduledMessages(ctx context.Context, channel string) ([]ScheduledMessage, error)
+	DeleteScheduledMessage(ctx context.Context, channel, scheduledMessageID string) error
+
+	// File operations
+	UploadFile(ctx context.Context, channels []string, filename string, content []byte, opts ...FileOption) (string, error)
 
 	// Modal operations
 	OpenModal(ctx context.Context, triggerID string, modal *Modal) error
 	UpdateModal(ctx context.Context, viewID string, modal *Modal) error
+	// UpdateModalWithHash fails with ErrorCode "hash_conflict" if the view
+	// changed since the hash was read
+	UpdateModalWithHash(ctx context.Context, viewID, hash string, modal *Modal) error
+	UpdateModalByExternalID(ctx context.Context, externalID string, modal *Modal) error
 	PushModal(ctx context.Context, triggerID string, modal *Modal) error
 
 	// User operations
 	GetUserInfo(ctx context.Context, userID string) (*UserInfo, error)
 	GetUserByEmail(ctx context.Context, email string) (*UserInfo, error)
+	ListUserGroupMembers(ctx context.Context, groupID string) ([]string, error)
+	GetDNDStatus(ctx context.Context, userID string) (*DNDStatus, error)
 
 	// Channel operations
 	GetChannelInfo(ctx context.Context, channelID string) (*ConversationInfo, error)
@@ -36,24 +99,86 @@ type Client interface {
 
 	// DM operations
 	OpenDM(ctx context.Context, userID string) (string, error)
+
+	// Auth operations
+	AuthTest(ctx context.Context) (*AuthIdentity, error)
+	// BotUserID returns the bot user found by the last successful AuthTest,
+	// or "" before one succeeded
+	BotUserID() string
+
+	// Enterprise Grid operations
+	ListAuthorizedTeams(ctx context.Context) ([]Team, error)
+}
+
+// DefaultTransport is the HTTP transport clients use unless given another.
+// Its connections to Slack are kept alive between requests, including across
+// the invocations a warm Lambda container serves, so only the first call
+// pays for the TLS handshake. Enough are kept for reminders sent
+// concurrently.
+var DefaultTransport http.RoundTripper = newTransport()
+
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = 16
+	return transport
 }
 
 // client implements the Client interface.
 type client struct {
 	token      string
+	tokens     TokenSource
 	httpClient *http.Client
 	baseURL    string
+	limiter    *RateLimiter // nil disables client-side rate limiting
+
+	identityMu sync.Mutex
+	identity   *AuthIdentity // From the last successful auth.test
+}
+
+// ClientOption is a function that modifies a client.
+type ClientOption func(*client)
+
+// WithTransport sets the HTTP transport used for Slack requests.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *client) {
+		c.httpClient.Transport = transport
+	}
+}
+
+// WithTokenSource fetches the bot token from tokens instead of using a
+// fixed token. Calls rejected with invalid_auth are retried once with a
+// refreshed token, so rotation does not need a redeploy.
+func WithTokenSource(tokens TokenSource) ClientOption {
+	return func(c *client) {
+		c.tokens = tokens
+	}
+}
+
+// WithRateLimiter sets the limiter API calls wait on. Clients use a limiter
+// with Slack's default tiers unless given another; nil disables limiting.
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *client) {
+		c.limiter = limiter
+	}
 }
 
 // NewClient creates a new Slack client.
-func NewClient(token string) Client {
-	return &client{
+func NewClient(token string, opts ...ClientOption) Client {
+	c := &client{
 		token: token,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Transport: DefaultTransport,
+			Timeout:   30 * time.Second,
 		},
 		baseURL: "https://slack.com/api",
+		limiter: NewRateLimiter(),
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // MessageOption is a function that modifies a message.
@@ -90,6 +215,30 @@ func WithMetadata(eventType string, payload map[string]interface{}) MessageOptio
 	}
 }
 
+// FileOption is a function that modifies a file upload.
+type FileOption func(*FileUpload)
+
+// WithFileTitle sets the title shown for the file.
+func WithFileTitle(title string) FileOption {
+	return func(f *FileUpload) {
+		f.Title = title
+	}
+}
+
+// WithInitialComment sets the message posted with the file.
+func WithInitialComment(comment string) FileOption {
+	return func(f *FileUpload) {
+		f.InitialComment = comment
+	}
+}
+
+// WithFileThreadTS shares the file as a reply in a thread.
+func WithFileThreadTS(threadTS string) FileOption {
+	return func(f *FileUpload) {
+		f.ThreadTS = threadTS
+	}
+}
+
 // PostMessage posts a message to a channel.
 func (c *client) PostMessage(ctx context.Context, channel string, opts ...MessageOption) (string, error) {
 	msg := &Message{
@@ -117,7 +266,7 @@ func (c *client) PostMessage(ctx context.Context, channel string, opts ...Messag
 	}
 
 	if !result.OK {
-		return "", fmt.Errorf("slack API error: %s", security.SanitizeLogValue(result.Error))
+		return "", &APIError{Code: result.Error}
 	}
 
 	return result.TS, nil
@@ -153,12 +302,134 @@ func (c *client) PostEphemeral(ctx context.Context, channel, userID string, opts
 	}
 
 	if !result.OK {
-		return "", fmt.Errorf("slack API error: %s", security.SanitizeLogValue(result.Error))
+		return "", &APIError{Code: result.Error}
 	}
 
 	return result.MessageTS, nil
 }
 
+// ScheduleMessage schedules a message to be posted at postAt, which must be
+// in the future.
+func (c *client) ScheduleMessage(
+	ctx context.Context,
+	channel string,
+	postAt time.Time,
+	opts ...MessageOption,
+) (string, error) {
+	msg := &struct {
+		*Message
+		PostAt int64 `json:"post_at"`
+	}{
+		Message: &Message{Channel: channel},
+		PostAt:  postAt.Unix(),
+	}
+
+	for _, opt := range opts {
+		opt(msg.Message)
+	}
+
+	resp, err := c.callAPI(ctx, "chat.scheduleMessage", msg)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		OK                 bool   `json:"ok"`
+		Error              string `json:"error,omitempty"`
+		ScheduledMessageID string `json:"scheduled_message_id"`
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !result.OK {
+		return "", &APIError{Code: result.Error}
+	}
+
+	return result.ScheduledMessageID, nil
+}
+
+// ListScheduledMessages lists the messages scheduled in a channel, or in
+// every channel if channel is empty, that Slack hasn't posted yet.
+func (c *client) ListScheduledMessages(ctx context.Context, channel string) ([]ScheduledMessage, error) {
+	var messages []ScheduledMessage
+	cursor := ""
+
+	for {
+		params := map[string]string{
+			"limit": "100",
+		}
+
+		if channel != "" {
+			params["channel"] = channel
+		}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+
+		resp, err := c.callAPIWithParams(ctx, "chat.scheduledMessages.list", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			OK                bool               `json:"ok"`
+			Error             string             `json:"error,omitempty"`
+			ScheduledMessages []ScheduledMessage `json:"scheduled_messages"`
+			ResponseMetadata  struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"response_metadata"`
+		}
+
+		if err := json.Unmarshal(resp, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if !result.OK {
+			return nil, &APIError{Code: result.Error}
+		}
+
+		messages = append(messages, result.ScheduledMessages...)
+
+		if result.ResponseMetadata.NextCursor == "" {
+			break
+		}
+
+		cursor = result.ResponseMetadata.NextCursor
+	}
+
+	return messages, nil
+}
+
+// DeleteScheduledMessage cancels a scheduled message before Slack posts it.
+func (c *client) DeleteScheduledMessage(ctx context.Context, channel, scheduledMessageID string) error {
+	params := map[string]interface{}{
+		"channel":              channel,
+		"scheduled_message_id": scheduledMessageID,
+	}
+
+	resp, err := c.callAPI(ctx, "chat.deleteScheduledMessage", params)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error,omitempty"`
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !result.OK {
+		return &APIError{Code: result.Error}
+	}
+
+	return nil
+}
+
 // UpdateMessage updates an existing message.
 func (c *client) UpdateMessage(ctx context.Context, channel, timestamp string, opts ...MessageOption) error {
 	msg := &struct {
@@ -188,7 +459,7 @@ func (c *client) UpdateMessage(ctx context.Context, channel, timestamp string, o
 	}
 
 	if !result.OK {
-		return fmt.Errorf("slack API error: %s", security.SanitizeLogValue(result.Error))
+		return &APIError{Code: result.Error}
 	}
 
 	return nil
@@ -216,7 +487,46 @@ func (c *client) DeleteMessage(ctx context.Context, channel, timestamp string) e
 	}
 
 	if !result.OK {
-		return fmt.Errorf("slack API error: %s", security.SanitizeLogValue(result.Error))
+		return &APIError{Code: result.Error}
+	}
+
+	return nil
+}
+
+// UnfurlLinks previews links shared in a message with blocks, keyed by URL.
+// Messages still being composed are identified by unfurl ID and source,
+// posted ones by channel and timestamp.
+func (c *client) UnfurlLinks(ctx context.Context, target UnfurlTarget, unfurls map[string][]Block) error {
+	previews := make(map[string]interface{}, len(unfurls))
+	for url, blocks := range unfurls {
+		previews[url] = map[string]interface{}{"blocks": blocks}
+	}
+
+	params := map[string]interface{}{"unfurls": previews}
+	if target.UnfurlID != "" {
+		params["unfurl_id"] = target.UnfurlID
+		params["source"] = target.Source
+	} else {
+		params["channel"] = target.Channel
+		params["ts"] = target.TS
+	}
+
+	resp, err := c.callAPI(ctx, "chat.unfurl", params)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error,omitempty"`
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !result.OK {
+		return &APIError{Code: result.Error}
 	}
 
 	return nil
@@ -244,7 +554,7 @@ func (c *client) OpenModal(ctx context.Context, triggerID string, modal *Modal)
 	}
 
 	if !result.OK {
-		return fmt.Errorf("slack API error: %s", security.SanitizeLogValue(result.Error))
+		return &APIError{Code: result.Error}
 	}
 
 	return nil
@@ -252,11 +562,35 @@ func (c *client) OpenModal(ctx context.Context, triggerID string, modal *Modal)
 
 // UpdateModal updates an existing modal.
 func (c *client) UpdateModal(ctx context.Context, viewID string, modal *Modal) error {
+	return c.updateModal(ctx, map[string]interface{}{
+		"view_id": viewID,
+		"view":    modal,
+	})
+}
+
+// UpdateModalWithHash updates an existing modal unless it changed since it
+// had the given hash. An empty hash always updates it.
+func (c *client) UpdateModalWithHash(ctx context.Context, viewID, hash string, modal *Modal) error {
 	params := map[string]interface{}{
 		"view_id": viewID,
 		"view":    modal,
 	}
+	if hash != "" {
+		params["hash"] = hash
+	}
+	return c.updateModal(ctx, params)
+}
+
+// UpdateModalByExternalID updates an open modal by the external ID it was
+// opened with.
+func (c *client) UpdateModalByExternalID(ctx context.Context, externalID string, modal *Modal) error {
+	return c.updateModal(ctx, map[string]interface{}{
+		"external_id": externalID,
+		"view":        modal,
+	})
+}
 
+func (c *client) updateModal(ctx context.Context, params map[string]interface{}) error {
 	resp, err := c.callAPI(ctx, "views.update", params)
 	if err != nil {
 		return err
@@ -272,7 +606,7 @@ func (c *client) UpdateModal(ctx context.Context, viewID string, modal *Modal) e
 	}
 
 	if !result.OK {
-		return fmt.Errorf("slack API error: %s", security.SanitizeLogValue(result.Error))
+		return &APIError{Code: result.Error}
 	}
 
 	return nil
@@ -300,7 +634,7 @@ func (c *client) PushModal(ctx context.Context, triggerID string, modal *Modal)
 	}
 
 	if !result.OK {
-		return fmt.Errorf("slack API error: %s", security.SanitizeLogValue(result.Error))
+		return &APIError{Code: result.Error}
 	}
 
 	return nil
@@ -309,7 +643,8 @@ func (c *client) PushModal(ctx context.Context, triggerID string, modal *Modal)
 // GetUserInfo gets information about a user.
 func (c *client) GetUserInfo(ctx context.Context, userID string) (*UserInfo, error) {
 	params := map[string]string{
-		"user": userID,
+		"user":           userID,
+		"include_locale": "true",
 	}
 
 	resp, err := c.callAPIWithParams(ctx, "users.info", params)
@@ -328,7 +663,10 @@ func (c *client) GetUserInfo(ctx context.Context, userID string) (*UserInfo, err
 	}
 
 	if !result.OK {
-		return nil, fmt.Errorf("slack API error: %s", security.SanitizeLogValue(result.Error))
+		if result.Error == "user_not_found" || result.Error == "users_not_found" {
+			return nil, fmt.Errorf("%w: %s", ErrUserNotFound, security.SanitizeLogValue(userID))
+		}
+		return nil, &APIError{Code: result.Error}
 	}
 
 	return &result.User, nil
@@ -356,12 +694,40 @@ func (c *client) GetUserByEmail(ctx context.Context, email string) (*UserInfo, e
 	}
 
 	if !result.OK {
-		return nil, fmt.Errorf("slack API error: %s", security.SanitizeLogValue(result.Error))
+		return nil, &APIError{Code: result.Error}
 	}
 
 	return &result.User, nil
 }
 
+// GetDNDStatus gets a user's Do Not Disturb status.
+func (c *client) GetDNDStatus(ctx context.Context, userID string) (*DNDStatus, error) {
+	params := map[string]string{
+		"user": userID,
+	}
+
+	resp, err := c.callAPIWithParams(ctx, "dnd.info", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error,omitempty"`
+		DNDStatus
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !result.OK {
+		return nil, &APIError{Code: result.Error}
+	}
+
+	return &result.DNDStatus, nil
+}
+
 // GetChannelInfo gets information about a channel.
 func (c *client) GetChannelInfo(ctx context.Context, channelID string) (*ConversationInfo, error) {
 	params := map[string]string{
@@ -384,12 +750,74 @@ func (c *client) GetChannelInfo(ctx context.Context, channelID string) (*Convers
 	}
 
 	if !result.OK {
-		return nil, fmt.Errorf("slack API error: %s", security.SanitizeLogValue(result.Error))
+		return nil, &APIError{Code: result.Error}
 	}
 
 	return &result.Channel, nil
 }
 
+// GetPermalink returns a link to a message.
+func (c *client) GetPermalink(ctx context.Context, channel, timestamp string) (string, error) {
+	params := map[string]string{
+		"channel":    channel,
+		"message_ts": timestamp,
+	}
+
+	resp, err := c.callAPIWithParams(ctx, "chat.getPermalink", params)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		OK        bool   `json:"ok"`
+		Error     string `json:"error,omitempty"`
+		Permalink string `json:"permalink"`
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !result.OK {
+		return "", &APIError{Code: result.Error}
+	}
+
+	return result.Permalink, nil
+}
+
+// GetReactions lists the reactions to a message, with every user who
+// reacted.
+func (c *client) GetReactions(ctx context.Context, channel, timestamp string) ([]Reaction, error) {
+	params := map[string]string{
+		"channel":   channel,
+		"timestamp": timestamp,
+		"full":      "true",
+	}
+
+	resp, err := c.callAPIWithParams(ctx, "reactions.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		OK      bool   `json:"ok"`
+		Error   string `json:"error,omitempty"`
+		Message struct {
+			Reactions []Reaction `json:"reactions"`
+		} `json:"message"`
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !result.OK {
+		return nil, &APIError{Code: result.Error}
+	}
+
+	return result.Message.Reactions, nil
+}
+
 // ListChannelMembers lists members of a channel.
 func (c *client) ListChannelMembers(ctx context.Context, channelID string) ([]string, error) {
 	var members []string
@@ -424,7 +852,7 @@ func (c *client) ListChannelMembers(ctx context.Context, channelID string) ([]st
 		}
 
 		if !result.OK {
-			return nil, fmt.Errorf("slack API error: %s", security.SanitizeLogValue(result.Error))
+			return nil, &APIError{Code: result.Error}
 		}
 
 		members = append(members, result.Members...)
@@ -439,6 +867,120 @@ func (c *client) ListChannelMembers(ctx context.Context, channelID string) ([]st
 	return members, nil
 }
 
+// ListUserGroupMembers lists the IDs of the users in a user group (@subteam).
+func (c *client) ListUserGroupMembers(ctx context.Context, groupID string) ([]string, error) {
+	resp, err := c.callAPIWithParams(ctx, "usergroups.users.list", map[string]string{
+		"usergroup": groupID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		OK    bool     `json:"ok"`
+		Error string   `json:"error,omitempty"`
+		Users []string `json:"users"`
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !result.OK {
+		return nil, &APIError{Code: result.Error}
+	}
+
+	return result.Users, nil
+}
+
+// AuthTest checks the bot token and returns who it belongs to. The result
+// is kept for BotUserID.
+func (c *client) AuthTest(ctx context.Context) (*AuthIdentity, error) {
+	resp, err := c.callAPI(ctx, "auth.test", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error,omitempty"`
+		AuthIdentity
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !result.OK {
+		return nil, &APIError{Code: result.Error}
+	}
+
+	c.identityMu.Lock()
+	defer c.identityMu.Unlock()
+	c.identity = &result.AuthIdentity
+	return &result.AuthIdentity, nil
+}
+
+// BotUserID returns the bot user found by the last successful AuthTest, or
+// "" before one succeeded.
+func (c *client) BotUserID() string {
+	c.identityMu.Lock()
+	defer c.identityMu.Unlock()
+	if c.identity == nil {
+		return ""
+	}
+	return c.identity.UserID
+}
+
+// ListAuthorizedTeams lists the workspaces of an Enterprise Grid an org-wide
+// install is granted access to.
+func (c *client) ListAuthorizedTeams(ctx context.Context) ([]Team, error) {
+	var teams []Team
+	cursor := ""
+
+	for {
+		params := map[string]string{
+			"limit": "200",
+		}
+
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+
+		resp, err := c.callAPIWithParams(ctx, "auth.teams.list", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			OK               bool   `json:"ok"`
+			Error            string `json:"error,omitempty"`
+			Teams            []Team `json:"teams"`
+			ResponseMetadata struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"response_metadata"`
+		}
+
+		if err := json.Unmarshal(resp, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if !result.OK {
+			return nil, &APIError{Code: result.Error}
+		}
+
+		teams = append(teams, result.Teams...)
+
+		if result.ResponseMetadata.NextCursor == "" {
+			break
+		}
+
+		cursor = result.ResponseMetadata.NextCursor
+	}
+
+	return teams, nil
+}
+
 // OpenDM opens a direct message channel with a user.
 func (c *client) OpenDM(ctx context.Context, userID string) (string, error) {
 	params := map[string]interface{}{
@@ -463,44 +1005,196 @@ func (c *client) OpenDM(ctx context.Context, userID string) (string, error) {
 	}
 
 	if !result.OK {
-		return "", fmt.Errorf("slack API error: %s", security.SanitizeLogValue(result.Error))
+		return "", &APIError{Code: result.Error}
 	}
 
 	return result.Channel.ID, nil
 }
 
-// callAPI makes an API call with JSON body.
-func (c *client) callAPI(ctx context.Context, method string, params interface{}) ([]byte, error) {
-	body, err := json.Marshal(params)
+// PostToResponseURL sends a delayed response to an interaction's response_url.
+// Slack accepts up to five responses within 30 minutes of the interaction.
+func (c *client) PostToResponseURL(ctx context.Context, responseURL string, message *ResponseMessage) error {
+	u, err := url.Parse(responseURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal params: %w", err)
+		return fmt.Errorf("failed to parse response URL: %w", err)
+	}
+	// Only post to Slack so a forged payload can't redirect the request
+	if u.Scheme != "https" || (u.Host != "hooks.slack.com" && !strings.HasSuffix(u.Host, ".slack.com")) {
+		return fmt.Errorf("invalid response URL host: %s", security.SanitizeLogValue(u.Host))
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/"+method, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
-	req.Header.Set("Authorization", "Bearer "+c.token)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		body := security.SanitizeLogValue(string(respBody))
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, body)
 	}
 
-	return respBody, nil
+	// Response URLs reply with either a bare "ok" or a JSON result
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error,omitempty"`
+	}
+	if err := json.Unmarshal(respBody, &result); err == nil && !result.OK {
+		return &APIError{Code: result.Error}
+	}
+
+	return nil
+}
+
+// UploadFile uploads a file and shares it to the given channels, returning
+// the file ID. It uses Slack's external upload flow: reserve an upload URL,
+// send the content, then complete the upload to share it.
+func (c *client) UploadFile(
+	ctx context.Context,
+	channels []string,
+	filename string,
+	content []byte,
+	opts ...FileOption,
+) (string, error) {
+	upload := &FileUpload{Title: filename}
+	for _, opt := range opts {
+		opt(upload)
+	}
+
+	// Reserve an upload URL
+	resp, err := c.callAPIWithParams(ctx, "files.getUploadURLExternal", map[string]string{
+		"filename": filename,
+		"length":   strconv.Itoa(len(content)),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var reserved struct {
+		OK        bool   `json:"ok"`
+		Error     string `json:"error,omitempty"`
+		UploadURL string `json:"upload_url"`
+		FileID    string `json:"file_id"`
+	}
+
+	if err := json.Unmarshal(resp, &reserved); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !reserved.OK {
+		return "", &APIError{Code: reserved.Error}
+	}
+
+	// Send the content
+	if err := c.sendFileContent(ctx, reserved.UploadURL, content); err != nil {
+		return "", err
+	}
+
+	// Complete the upload and share the file
+	params := map[string]interface{}{
+		"files": []map[string]string{
+			{"id": reserved.FileID, "title": upload.Title},
+		},
+	}
+	if len(channels) > 0 {
+		params["channels"] = strings.Join(channels, ",")
+	}
+	if upload.InitialComment != "" {
+		params["initial_comment"] = upload.InitialComment
+	}
+	if upload.ThreadTS != "" {
+		params["thread_ts"] = upload.ThreadTS
+	}
+
+	resp, err = c.callAPI(ctx, "files.completeUploadExternal", params)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error,omitempty"`
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !result.OK {
+		return "", &APIError{Code: result.Error}
+	}
+
+	return reserved.FileID, nil
+}
+
+// sendFileContent posts file content to an upload URL reserved with
+// files.getUploadURLExternal.
+func (c *client) sendFileContent(ctx context.Context, uploadURL string, content []byte) error {
+	u, err := url.Parse(uploadURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse upload URL: %w", err)
+	}
+	// Only send content to Slack
+	if u.Scheme != "https" || !strings.HasSuffix(u.Host, ".slack.com") {
+		return fmt.Errorf("invalid upload URL host: %s", security.SanitizeLogValue(u.Host))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body) //nolint:errcheck // best effort for the error message
+		body := security.SanitizeLogValue(string(respBody))
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, body)
+	}
+
+	return nil
+}
+
+// callAPI makes an API call with JSON body.
+func (c *client) callAPI(ctx context.Context, method string, params interface{}) ([]byte, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal params: %w", err)
+	}
+
+	return c.do(ctx, method, func(token string) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/"+method, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		req.Header.Set("Authorization", "Bearer "+token)
+		return req, nil
+	})
 }
 
 // callAPIWithParams makes an API call with URL parameters.
@@ -516,12 +1210,67 @@ func (c *client) callAPIWithParams(ctx context.Context, method string, params ma
 	}
 	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), http.NoBody)
+	return c.do(ctx, method, func(token string) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", u.String(), http.NoBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+token)
+		return req, nil
+	})
+}
+
+// do sends a request built for the current token. When the token comes from
+// a TokenSource and Slack rejects it, the request is retried once with a
+// refreshed token.
+func (c *client) do(ctx context.Context, method string, newRequest func(token string) (*http.Request, error)) ([]byte, error) {
+	if c.tokens == nil {
+		return c.send(ctx, method, newRequest, c.token)
+	}
+
+	token, err := c.tokens.Token(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
+	}
+
+	respBody, err := c.send(ctx, method, newRequest, token)
+	if err != nil || !isAuthError(respBody) {
+		return respBody, err
+	}
+
+	// The token may have been rotated since it was cached
+	fresh, err := c.tokens.Refresh(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if fresh == token {
+		return respBody, nil
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
+	return c.send(ctx, method, newRequest, fresh)
+}
+
+// send makes a single API request, once the rate limiter allows it, and
+// returns the response body.
+func (c *client) send(
+	ctx context.Context,
+	method string,
+	newRequest func(token string) (*http.Request, error),
+	token string,
+) ([]byte, error) {
+	defer inflight.Start(ctx, "Slack."+method)()
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx, method); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := newRequest(token)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -534,6 +1283,14 @@ func (c *client) callAPIWithParams(ctx context.Context, method string, params ma
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		delay := retryAfter(resp)
+		if c.limiter != nil {
+			c.limiter.Pause(method, delay)
+		}
+		return nil, fmt.Errorf("%w: %s, retry after %s", ErrRateLimited, security.SanitizeLogValue(method), delay)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body := security.SanitizeLogValue(string(respBody))
 		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, body)
@@ -0,0 +1,128 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ConfigShortcutCallbackID identifies the global shortcut that opens the
+// channel settings modal. It must match the callback ID of the shortcut
+// created in the Slack app's "Interactivity & Shortcuts" settings.
+const ConfigShortcutCallbackID = "configure_standup"
+
+// ChannelConfigCallbackID identifies the channel settings modal.
+const ChannelConfigCallbackID = "channel_config"
+
+// ChannelConfigChannelBlockID is the block ID of the channel select in the
+// channel settings modal.
+const ChannelConfigChannelBlockID = "config_channel"
+
+// settingBlockPrefix prefixes the block IDs of the setting inputs.
+const settingBlockPrefix = "setting:"
+
+// SettingBlockID returns the block ID of the input for the setting key of a
+// channel. Slack keeps what was typed into inputs whose block IDs don't
+// change, so the channel is part of it to show a newly picked channel's values.
+func SettingBlockID(channelID, key string) string {
+	return settingBlockPrefix + channelID + ":" + key
+}
+
+// ChannelSetting is a setting shown in the channel settings modal.
+type ChannelSetting struct {
+	Key      string
+	Label    string
+	Value    string
+	Optional bool // Can be left empty
+}
+
+// ChannelConfigForm is what the channel settings modal shows: the selected
+// channel, and either its settings or a notice explaining why there are none.
+type ChannelConfigForm struct {
+	ChannelID string
+	Settings  []ChannelSetting
+	Version   int64 // Of the config the settings were read from
+	Notice    string
+}
+
+// channelConfigMetadata is kept in the settings modal's private metadata.
+type channelConfigMetadata struct {
+	Version int64 `json:"version"`
+}
+
+// BuildChannelConfigModal builds the modal for changing a channel's settings.
+// Picking a channel sends block_actions, so the modal is rebuilt with the
+// channel's settings once one is chosen.
+func BuildChannelConfigModal(form *ChannelConfigForm) *Modal {
+	builder := NewModalBuilder("Standup Settings", ChannelConfigCallbackID).
+		SetClose("Cancel").
+		AddDispatchInput(ChannelConfigChannelBlockID, "Channel", ConversationsSelectElement{
+			Type:                "conversations_select",
+			ActionID:            "channel",
+			Placeholder:         &TextBlock{Type: "plain_text", Text: "Pick a channel"},
+			InitialConversation: form.ChannelID,
+			Filter: &ConversationFilter{
+				Include:                       []string{"public", "private"},
+				ExcludeBotUsers:               true,
+				ExcludeExternalSharedChannels: true,
+			},
+		}, false)
+
+	if form.Notice != "" {
+		builder.AddSection(form.Notice)
+	}
+
+	if len(form.Settings) == 0 {
+		return builder.Build()
+	}
+
+	builder.SetSubmit("Save").
+		SetPrivateMetadata(channelConfigMetadata{Version: form.Version}).
+		AddSection("Times are HH:MM in the channel's timezone. Separate reminder times with commas. " +
+			"Leave the grace period empty to accept late submissions all day.")
+	for _, setting := range form.Settings {
+		builder.AddInput(SettingBlockID(form.ChannelID, setting.Key), setting.Label, PlainTextInputElement{
+			Type:         "plain_text_input",
+			ActionID:     "value",
+			InitialValue: setting.Value,
+		}, setting.Optional)
+	}
+
+	return builder.Build()
+}
+
+// ParseChannelConfigSubmission returns the channel picked in the channel
+// settings modal, the values entered for its settings, keyed by setting, and
+// the version of the config they were shown from.
+func ParseChannelConfigSubmission(
+	view *View,
+) (channelID string, values map[string]string, version int64, err error) {
+	if view == nil || view.State == nil {
+		return "", nil, 0, fmt.Errorf("invalid view state")
+	}
+
+	// Modals without settings have no metadata
+	if view.PrivateMetadata != "" {
+		var metadata channelConfigMetadata
+		if err := json.Unmarshal([]byte(view.PrivateMetadata), &metadata); err != nil {
+			return "", nil, 0, fmt.Errorf("invalid settings metadata")
+		}
+		version = metadata.Version
+	}
+
+	values = make(map[string]string)
+	for blockID, actions := range view.State.Values {
+		for _, value := range actions {
+			switch {
+			case blockID == ChannelConfigChannelBlockID:
+				channelID = value.SelectedConversation
+			case strings.HasPrefix(blockID, settingBlockPrefix):
+				if _, key, ok := strings.Cut(strings.TrimPrefix(blockID, settingBlockPrefix), ":"); ok {
+					values[key] = value.Value
+				}
+			}
+		}
+	}
+
+	return channelID, values, version, nil
+}
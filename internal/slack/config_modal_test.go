@@ -0,0 +1,42 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelConfigModal(t *testing.T) {
+	// Without a channel only the channel select is shown
+	modal := BuildChannelConfigModal(&ChannelConfigForm{})
+	require.Len(t, modal.Blocks, 1)
+	assert.Equal(t, ChannelConfigCallbackID, modal.CallbackID)
+	assert.Nil(t, modal.Submit)
+	assert.True(t, modal.Blocks[0].(InputBlock).DispatchAction)
+
+	modal = BuildChannelConfigModal(&ChannelConfigForm{
+		ChannelID: "C1234567890",
+		Settings: []ChannelSetting{
+			{Key: "start_time", Label: "Start time", Value: "09:00"},
+			{Key: "timezone", Label: "Timezone", Value: "UTC"},
+		},
+		Version: 3,
+	})
+	require.NotNil(t, modal.Submit)
+	require.Len(t, modal.Blocks, 4)
+	input := modal.Blocks[2].(InputBlock)
+	assert.Equal(t, "setting:C1234567890:start_time", input.BlockID)
+	assert.Equal(t, "09:00", input.Element.(PlainTextInputElement).InitialValue)
+
+	view := &View{PrivateMetadata: modal.PrivateMetadata, State: &ViewState{Values: map[string]map[string]ViewStateValue{
+		ChannelConfigChannelBlockID:                 {"channel": {Type: "conversations_select", SelectedConversation: "C1234567890"}},
+		SettingBlockID("C1234567890", "start_time"): {"value": {Type: "plain_text_input", Value: "9:30"}},
+		SettingBlockID("C1234567890", "timezone"):   {"value": {Type: "plain_text_input", Value: "UTC"}},
+	}}}
+	channelID, values, version, err := ParseChannelConfigSubmission(view)
+	require.NoError(t, err)
+	assert.Equal(t, "C1234567890", channelID)
+	assert.Equal(t, map[string]string{"start_time": "9:30", "timezone": "UTC"}, values)
+	assert.Equal(t, int64(3), version)
+}
@@ -0,0 +1,173 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/contract")
+
+// TestClientContract checks the requests the client sends Slack against
+// golden files, so changes to payloads are deliberate. Run with -update to
+// rewrite them.
+func TestClientContract(t *testing.T) {
+	var request []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		request = describeRequest(t, r, body)
+
+		_, _ = w.Write([]byte(`{"ok": true, "ts": "1700000000.000100", "channel": {"id": "D1234567890"},
+			"user": {"id": "U1234567890"}}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("xoxb-test", WithRateLimiter(nil)).(*client)
+	c.baseURL = server.URL
+	ctx := context.Background()
+
+	questions := []botconfig.Question{
+		{Text: "What did you do yesterday?", Type: botconfig.QuestionText, MaxLength: 500},
+		{ID: "blocked", Text: "Blocked?", Type: botconfig.QuestionYesNo},
+	}
+	modal := BuildStandupModalWithAnswers(&StandupModalMetadata{
+		ChannelID: "C1234567890",
+		Date:      "2024-01-15",
+		SessionID: "sess-123",
+		Timestamp: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+	}, questions, nil)
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "post_message",
+			call: func() error {
+				_, err := c.PostMessage(ctx, "C1234567890", WithText("Standup time"),
+					WithBlocks(BuildReminderMessage("alice", "engineering", "C1234567890", "", ReminderStatus{
+						Questions: []string{"What did you do yesterday?"},
+						Submitted: 2,
+						Total:     5,
+					})...))
+				return err
+			},
+		},
+		{
+			name: "post_ephemeral",
+			call: func() error {
+				_, err := c.PostEphemeral(ctx, "C1234567890", "U1234567890", WithText("Skipped"))
+				return err
+			},
+		},
+		{
+			name: "update_message",
+			call: func() error {
+				return c.UpdateMessage(ctx, "C1234567890", "1700000000.000100", WithText("Edited"),
+					WithThreadTS("1700000000.000001"))
+			},
+		},
+		{
+			name: "open_modal",
+			call: func() error { return c.OpenModal(ctx, "trigger-123", modal) },
+		},
+		{
+			name: "update_modal_with_hash",
+			call: func() error { return c.UpdateModalWithHash(ctx, "V1234567890", "hash-123", modal) },
+		},
+		{
+			name: "get_user_info",
+			call: func() error {
+				_, err := c.GetUserInfo(ctx, "U1234567890")
+				return err
+			},
+		},
+		{
+			name: "schedule_message",
+			call: func() error {
+				_, err := c.ScheduleMessage(ctx, "D1234567890", time.Unix(1700003600, 0), WithText("Standup time"))
+				return err
+			},
+		},
+		{
+			name: "list_scheduled_messages",
+			call: func() error {
+				_, err := c.ListScheduledMessages(ctx, "D1234567890")
+				return err
+			},
+		},
+		{
+			name: "delete_scheduled_message",
+			call: func() error { return c.DeleteScheduledMessage(ctx, "D1234567890", "Q1234567890") },
+		},
+		{
+			name: "get_dnd_status",
+			call: func() error {
+				_, err := c.GetDNDStatus(ctx, "U1234567890")
+				return err
+			},
+		},
+		{
+			name: "unfurl_links",
+			call: func() error {
+				return c.UnfurlLinks(ctx, UnfurlTarget{Channel: "C1234567890", TS: "1700000000.000100"},
+					map[string][]Block{
+						"https://exports.s3.amazonaws.com/exports/C1234567890/2024-01-01_2024-01-31-id.csv": BuildReportUnfurl(
+							&ReportPreview{Title: "📦 Standup export for <#C1234567890>", StartDate: "2024-01-01",
+								EndDate: "2024-01-31", Format: "csv", ExpiresAt: time.Unix(1700086400, 0)},
+							time.Unix(1700000000, 0)),
+					})
+			},
+		},
+		{
+			name: "open_dm",
+			call: func() error {
+				_, err := c.OpenDM(ctx, "U1234567890")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request = nil
+			require.NoError(t, tt.call())
+
+			golden := filepath.Join("testdata", "contract", tt.name+".golden")
+			if *update {
+				require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+				require.NoError(t, os.WriteFile(golden, request, 0o600))
+			}
+
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err, "run with -update to create the golden file")
+			assert.Equal(t, string(want), string(request))
+		})
+	}
+}
+
+// describeRequest formats a request as its method, path and query, then its
+// body, with JSON indented so golden file diffs are readable.
+func describeRequest(t *testing.T, r *http.Request, body []byte) []byte {
+	var out bytes.Buffer
+	out.WriteString(r.Method + " " + r.URL.RequestURI() + "\n")
+	if len(body) > 0 {
+		require.NoError(t, json.Indent(&out, body, "", "  "))
+		out.WriteString("\n")
+	}
+	return out.Bytes()
+}
@@ -0,0 +1,150 @@
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BotScopes are the bot token scopes the app is installed with.
+var BotScopes = []string{
+	"chat:write", "chat:write.public", "im:write", "users:read", "users:read.email",
+	"channels:read", "groups:read", "files:write", "usergroups:read", "reactions:read",
+	"dnd:read", "links:read", "links:write", "commands",
+}
+
+// oauthStateTTL is how long an install link stays valid.
+const oauthStateTTL = 10 * time.Minute
+
+// ErrInvalidOAuthState is returned for OAuth redirects whose state wasn't
+// issued by NewState, or has expired.
+var ErrInvalidOAuthState = errors.New("invalid OAuth state")
+
+// OAuthAccess is the result of an install, as returned by oauth.v2.access.
+type OAuthAccess struct {
+	AccessToken string      `json:"access_token"`
+	BotUserID   string      `json:"bot_user_id"`
+	AppID       string      `json:"app_id"`
+	Team        *Team       `json:"team"`
+	Enterprise  *Enterprise `json:"enterprise"`
+	// IsEnterpriseInstall is set for org-wide installs, whose token works
+	// in every workspace of the grid the app is granted to. Team is nil for
+	// them.
+	IsEnterpriseInstall bool `json:"is_enterprise_install"`
+}
+
+// InstallID returns the ID the install is saved under: the enterprise ID for
+// org-wide installs, otherwise the team ID.
+func (a *OAuthAccess) InstallID() string {
+	if a.IsEnterpriseInstall && a.Enterprise != nil {
+		return a.Enterprise.ID
+	}
+	if a.Team != nil {
+		return a.Team.ID
+	}
+	return ""
+}
+
+// OAuthClient runs Slack's OAuth v2 install flow.
+type OAuthClient struct {
+	clientID     string
+	clientSecret string
+	client       *client
+}
+
+// NewOAuthClient creates an OAuth client for the app with the given
+// credentials.
+func NewOAuthClient(clientID, clientSecret string, opts ...ClientOption) *OAuthClient {
+	return &OAuthClient{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		client:       NewClient("", opts...).(*client),
+	}
+}
+
+// AuthorizeURL returns the URL that starts an install, redirecting back to
+// redirectURI with the given state.
+func (o *OAuthClient) AuthorizeURL(redirectURI, state string) string {
+	params := url.Values{
+		"client_id":    {o.clientID},
+		"scope":        {strings.Join(BotScopes, ",")},
+		"redirect_uri": {redirectURI},
+		"state":        {state},
+	}
+	return "https://slack.com/oauth/v2/authorize?" + params.Encode()
+}
+
+// NewState returns an OAuth state parameter signed with the client secret,
+// so redirects can be checked to come from an install started here.
+func (o *OAuthClient) NewState(now time.Time) string {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	return timestamp + "." + o.sign(timestamp)
+}
+
+// VerifyState checks a state returned by Slack was issued by NewState within
+// the last ten minutes.
+func (o *OAuthClient) VerifyState(state string, now time.Time) error {
+	timestamp, signature, ok := strings.Cut(state, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(o.sign(timestamp))) {
+		return ErrInvalidOAuthState
+	}
+
+	issued, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || now.Sub(time.Unix(issued, 0)) > oauthStateTTL {
+		return ErrInvalidOAuthState
+	}
+	return nil
+}
+
+func (o *OAuthClient) sign(value string) string {
+	mac := hmac.New(sha256.New, []byte(o.clientSecret))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Exchange trades the code Slack redirected with for the install's bot token.
+func (o *OAuthClient) Exchange(ctx context.Context, code, redirectURI string) (*OAuthAccess, error) {
+	body := url.Values{
+		"code":         {code},
+		"redirect_uri": {redirectURI},
+	}.Encode()
+
+	resp, err := o.client.send(ctx, "oauth.v2.access", func(string) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", o.client.baseURL+"/oauth.v2.access", strings.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth(o.clientID, o.clientSecret)
+		return req, nil
+	}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error,omitempty"`
+		OAuthAccess
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !result.OK {
+		return nil, &APIError{Code: result.Error}
+	}
+
+	return &result.OAuthAccess, nil
+}
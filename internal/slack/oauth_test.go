@@ -0,0 +1,59 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthState(t *testing.T) {
+	oauth := NewOAuthClient("client", "secret")
+	now := time.Unix(1700000000, 0)
+
+	state := oauth.NewState(now)
+	assert.NoError(t, oauth.VerifyState(state, now.Add(time.Minute)))
+	assert.ErrorIs(t, oauth.VerifyState(state, now.Add(time.Hour)), ErrInvalidOAuthState)
+	assert.ErrorIs(t, NewOAuthClient("client", "other").VerifyState(state, now), ErrInvalidOAuthState)
+	assert.ErrorIs(t, oauth.VerifyState("1700000000", now), ErrInvalidOAuthState)
+
+	authorize, err := url.Parse(oauth.AuthorizeURL("https://example.com/slack/oauth_redirect", state))
+	require.NoError(t, err)
+	assert.Equal(t, "client", authorize.Query().Get("client_id"))
+	assert.Equal(t, state, authorize.Query().Get("state"))
+}
+
+func TestOAuthExchange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "client", user)
+		assert.Equal(t, "secret", password)
+		require.NoError(t, r.ParseForm())
+
+		if r.PostForm.Get("code") != "good" {
+			_, _ = w.Write([]byte(`{"ok": false, "error": "invalid_code"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "access_token": "xoxb-org", "team": null,
+			"enterprise": {"id": "E0000000001", "name": "Acme"}, "is_enterprise_install": true}`))
+	}))
+	defer server.Close()
+
+	oauth := NewOAuthClient("client", "secret")
+	oauth.client.baseURL = server.URL
+
+	access, err := oauth.Exchange(context.Background(), "good", "https://example.com/slack/oauth_redirect")
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-org", access.AccessToken)
+	assert.True(t, access.IsEnterpriseInstall)
+	assert.Equal(t, "E0000000001", access.InstallID())
+
+	_, err = oauth.Exchange(context.Background(), "bad", "https://example.com/slack/oauth_redirect")
+	assert.Equal(t, "invalid_code", ErrorCode(err))
+}
@@ -0,0 +1,253 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/apperr"
+	"github.com/synaptiq/standup-bot/internal/security"
+)
+
+// ErrRateLimited is returned when a call can't be made within its context's
+// deadline, or when Slack rejects it with HTTP 429.
+var ErrRateLimited = apperr.New(apperr.KindUnavailable, "slack rate limit exceeded")
+
+// defaultRetryAfter is how long a method is paused after a 429 response
+// without a usable Retry-After header.
+const defaultRetryAfter = 30 * time.Second
+
+// RateTier is one of Slack's Web API rate limit tiers.
+type RateTier int
+
+// Slack's rate limit tiers. See https://api.slack.com/apis/rate-limits.
+const (
+	Tier1 RateTier = iota + 1 // 1+ per minute
+	Tier2                     // 20+ per minute
+	Tier3                     // 50+ per minute
+	Tier4                     // 100+ per minute
+
+	// TierSpecial is for methods with their own limits, like chat.postMessage's
+	// one message per second per channel.
+	TierSpecial
+)
+
+// Rate is the sustained rate and burst allowed for a tier.
+type Rate struct {
+	PerMinute int
+	Burst     int
+}
+
+// DefaultTierRates are the documented minimums of Slack's tiers.
+var DefaultTierRates = map[RateTier]Rate{
+	Tier1:       {PerMinute: 1, Burst: 1},
+	Tier2:       {PerMinute: 20, Burst: 3},
+	Tier3:       {PerMinute: 50, Burst: 5},
+	Tier4:       {PerMinute: 100, Burst: 10},
+	TierSpecial: {PerMinute: 60, Burst: 10},
+}
+
+// DefaultMethodTiers are the tiers of the methods the client calls. Methods
+// not listed are limited as Tier3.
+var DefaultMethodTiers = map[string]RateTier{
+	"chat.postMessage":             TierSpecial,
+	"chat.postEphemeral":           TierSpecial,
+	"chat.getPermalink":            TierSpecial,
+	"chat.scheduleMessage":         Tier3,
+	"chat.scheduledMessages.list":  Tier3,
+	"chat.deleteScheduledMessage":  Tier3,
+	"reactions.get":                Tier3,
+	"chat.update":                  Tier3,
+	"chat.delete":                  Tier3,
+	"views.open":                   Tier4,
+	"views.update":                 Tier4,
+	"views.push":                   Tier4,
+	"users.info":                   Tier4,
+	"users.lookupByEmail":          Tier3,
+	"dnd.info":                     Tier3,
+	"usergroups.users.list":        Tier2,
+	"conversations.info":           Tier3,
+	"conversations.members":        Tier4,
+	"conversations.open":           Tier3,
+	"files.getUploadURLExternal":   Tier4,
+	"files.completeUploadExternal": Tier4,
+	"auth.teams.list":              Tier2,
+	"auth.test":                    Tier4,
+	"oauth.v2.access":              Tier4,
+}
+
+// RateLimiter throttles API calls with a token bucket per method, refilled at
+// the rate of the method's tier. One limiter is shared by all of a client's
+// methods. Limits apply per process, not across Lambda instances.
+type RateLimiter struct {
+	rates   map[RateTier]Rate
+	methods map[string]RateTier
+	now     func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// RateLimiterOption is a function that modifies a rate limiter.
+type RateLimiterOption func(*RateLimiter)
+
+// WithTierRate overrides the rate of a tier.
+func WithTierRate(tier RateTier, rate Rate) RateLimiterOption {
+	return func(l *RateLimiter) {
+		l.rates[tier] = rate
+	}
+}
+
+// WithMethodTier sets the tier of a method.
+func WithMethodTier(method string, tier RateTier) RateLimiterOption {
+	return func(l *RateLimiter) {
+		l.methods[method] = tier
+	}
+}
+
+// NewRateLimiter creates a limiter using the default tiers and rates.
+func NewRateLimiter(opts ...RateLimiterOption) *RateLimiter {
+	l := &RateLimiter{
+		rates:   make(map[RateTier]Rate, len(DefaultTierRates)),
+		methods: make(map[string]RateTier, len(DefaultMethodTiers)),
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+	for tier, rate := range DefaultTierRates {
+		l.rates[tier] = rate
+	}
+	for method, tier := range DefaultMethodTiers {
+		l.methods[method] = tier
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// Wait blocks until method may be called. It fails straight away with
+// ErrRateLimited if the wait would outlast ctx's deadline.
+func (l *RateLimiter) Wait(ctx context.Context, method string) error {
+	delay := l.reserve(method)
+	if delay <= 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && delay > time.Until(deadline) {
+		l.cancel(method)
+		return fmt.Errorf("%w: %s", ErrRateLimited, security.SanitizeLogValue(method))
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.cancel(method)
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Pause holds back calls to method for d, e.g. after Slack responds with 429.
+func (l *RateLimiter) Pause(method string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b := l.bucket(method, now)
+	b.refill(now)
+	if until := now.Add(d); until.After(b.last) {
+		b.last = until
+	}
+	b.tokens = math.Min(b.tokens, 0)
+}
+
+// reserve takes a token for method and returns how long to wait before
+// using it.
+func (l *RateLimiter) reserve(method string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b := l.bucket(method, now)
+	b.refill(now)
+	b.tokens--
+
+	// last is in the future while the method is paused
+	wait := b.last.Sub(now)
+	if b.tokens < 0 {
+		wait += time.Duration(-b.tokens / b.perSecond * float64(time.Second))
+	}
+	return wait
+}
+
+// cancel returns a token reserved for a call that was given up on.
+func (l *RateLimiter) cancel(method string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[method]; ok {
+		b.tokens = math.Min(b.burst, b.tokens+1)
+	}
+}
+
+// bucket returns the bucket of method, creating a full one on first use.
+// The caller must hold l.mu.
+func (l *RateLimiter) bucket(method string, now time.Time) *bucket {
+	if b, ok := l.buckets[method]; ok {
+		return b
+	}
+
+	tier, ok := l.methods[method]
+	if !ok {
+		tier = Tier3
+	}
+	rate := l.rates[tier]
+	if rate.PerMinute <= 0 {
+		rate = DefaultTierRates[Tier3]
+	}
+	burst := math.Max(1, float64(rate.Burst))
+
+	b := &bucket{
+		tokens:    burst,
+		burst:     burst,
+		perSecond: float64(rate.PerMinute) / 60,
+		last:      now,
+	}
+	l.buckets[method] = b
+	return b
+}
+
+// bucket is a token bucket. Tokens go negative for calls waiting their turn.
+type bucket struct {
+	tokens    float64
+	burst     float64
+	perSecond float64
+	last      time.Time // Last refill, or the end of a pause
+}
+
+// refill adds the tokens earned since the last refill.
+func (b *bucket) refill(now time.Time) {
+	if !now.After(b.last) {
+		return
+	}
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.perSecond)
+	b.last = now
+}
+
+// retryAfter reads the Retry-After header of a 429 response.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return defaultRetryAfter
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,64 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(WithTierRate(TierSpecial, Rate{PerMinute: 60, Burst: 2}))
+	now := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	// The burst is free, then calls queue up at the tier's rate
+	assert.Zero(t, limiter.reserve("chat.postMessage"))
+	assert.Zero(t, limiter.reserve("chat.postMessage"))
+	assert.Equal(t, time.Second, limiter.reserve("chat.postMessage"))
+	assert.Equal(t, 2*time.Second, limiter.reserve("chat.postMessage"))
+
+	// Methods have their own buckets
+	assert.Zero(t, limiter.reserve("conversations.open"))
+
+	now = now.Add(3 * time.Second)
+	assert.Zero(t, limiter.reserve("chat.postMessage"))
+
+	// A 429 pauses the method
+	limiter.Pause("chat.postMessage", 30*time.Second)
+	assert.Equal(t, 31*time.Second, limiter.reserve("chat.postMessage"))
+	limiter.cancel("chat.postMessage")
+
+	// Waits that would outlast the deadline fail straight away
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := limiter.Wait(ctx, "chat.postMessage")
+	assert.ErrorIs(t, err, ErrRateLimited)
+}
+
+func TestClientPausesAfterTooManyRequests(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := NewClient("xoxb-test").(*client)
+	c.baseURL = server.URL
+
+	_, err := c.PostMessage(context.Background(), "C1234567890", WithText("hello"))
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	// The next call isn't sent while the method is paused
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = c.PostMessage(ctx, "C1234567890", WithText("hello"))
+	require.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, 1, calls)
+}
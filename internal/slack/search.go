@@ -0,0 +1,47 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/synaptiq/standup-bot/internal/security"
+)
+
+// SearchResult is a standup answer matching a search.
+type SearchResult struct {
+	Date     string
+	UserID   string
+	Question string
+	Snippet  string // Part of the answer around the match
+	Link     string // Permalink to the day's standup thread, if any
+}
+
+// BuildSearchResults builds the reply to a standup search.
+func BuildSearchResults(query string, days int, results []SearchResult, limited bool) []Block {
+	query = security.SanitizeLogValue(query)
+	if len(results) == 0 {
+		return NewMessageBuilder().
+			AddSection(fmt.Sprintf("🔎 No standup answers in the last %d days match *%s*.", days, query)).
+			Build()
+	}
+
+	count := fmt.Sprintf("%d", len(results))
+	if limited {
+		count = "The latest " + count
+	}
+	builder := NewMessageBuilder().
+		AddSection(fmt.Sprintf("🔎 %s answers from the last %d days matching *%s*:", count, days, query))
+
+	for _, result := range results {
+		heading := fmt.Sprintf("*%s* · <@%s>", result.Date, security.SanitizeLogValue(result.UserID))
+		if result.Link != "" {
+			heading += fmt.Sprintf(" · <%s|View thread>", result.Link)
+		}
+		if result.Question != "" {
+			heading += "\n_" + result.Question + "_"
+		}
+		builder.AddSection(heading + "\n> " + strings.ReplaceAll(result.Snippet, "\n", "\n> "))
+	}
+
+	return builder.Build()
+}
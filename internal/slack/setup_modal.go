@@ -0,0 +1,108 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ChannelSetupCallbackID identifies the modal that sets up standups in a
+// channel the bot was added to.
+const ChannelSetupCallbackID = "channel_setup"
+
+// ChannelSetupQuestionsBlockID is the block ID of the questions input in the
+// setup modal. Settings use SettingBlockID.
+const ChannelSetupQuestionsBlockID = "setup_questions"
+
+// ChannelSetupForm is what the setup modal asks for: the channel's first
+// settings and its questions, one per line.
+type ChannelSetupForm struct {
+	ChannelID string
+	Settings  []ChannelSetting
+	Questions []string
+}
+
+// channelSetupMetadata is kept in the setup modal's private metadata.
+type channelSetupMetadata struct {
+	ChannelID string `json:"channel_id"`
+}
+
+// BuildChannelSetupPrompt builds the message offering to set up standups in
+// a channel the bot was just added to.
+func BuildChannelSetupPrompt(channelID string) []Block {
+	setup := NewButton(ActionSetupChannel, "Set up standups", channelID)
+	setup.Style = "primary"
+
+	return NewMessageBuilder().
+		AddSection(fmt.Sprintf("👋 Thanks for adding me to <#%s>! Want to run daily standups here? "+
+			"I'll suggest a schedule and questions you can change before anything is sent.", channelID)).
+		AddActions("channel_setup",
+			setup,
+			NewButton(ActionDismissSetup, "Not now", channelID),
+		).
+		Build()
+}
+
+// BuildChannelSetupModal builds the modal that sets up standups in a channel,
+// filled in with form's suggestions.
+func BuildChannelSetupModal(form *ChannelSetupForm) *Modal {
+	builder := NewModalBuilder("Set Up Standups", ChannelSetupCallbackID).
+		SetSubmit("Start standups").
+		SetClose("Cancel").
+		SetPrivateMetadata(channelSetupMetadata{ChannelID: form.ChannelID}).
+		AddSection(fmt.Sprintf("Standups in <#%s> run on weekdays. Times are HH:MM in the channel's timezone; "+
+			"separate reminder times with commas. You'll be the channel's standup admin.", form.ChannelID))
+
+	for _, setting := range form.Settings {
+		builder.AddInput(SettingBlockID(form.ChannelID, setting.Key), setting.Label, PlainTextInputElement{
+			Type:         "plain_text_input",
+			ActionID:     "value",
+			InitialValue: setting.Value,
+		}, setting.Optional)
+	}
+
+	return builder.
+		AddInput(ChannelSetupQuestionsBlockID, "Questions, one per line", PlainTextInputElement{
+			Type:         "plain_text_input",
+			ActionID:     "value",
+			InitialValue: strings.Join(form.Questions, "\n"),
+			Multiline:    true,
+		}, false).
+		Build()
+}
+
+// ParseChannelSetupSubmission returns the channel the setup modal is for,
+// the values entered for its settings, keyed by setting, and its questions.
+// Blank lines between questions are dropped.
+func ParseChannelSetupSubmission(
+	view *View,
+) (channelID string, values map[string]string, questions []string, err error) {
+	if view == nil || view.State == nil {
+		return "", nil, nil, fmt.Errorf("invalid view state")
+	}
+
+	var metadata channelSetupMetadata
+	if err := json.Unmarshal([]byte(view.PrivateMetadata), &metadata); err != nil || metadata.ChannelID == "" {
+		return "", nil, nil, fmt.Errorf("invalid setup metadata")
+	}
+
+	values = make(map[string]string)
+	for blockID, actions := range view.State.Values {
+		for _, value := range actions {
+			switch {
+			case blockID == ChannelSetupQuestionsBlockID:
+				for _, line := range strings.Split(value.Value, "\n") {
+					if line = strings.TrimSpace(line); line != "" {
+						questions = append(questions, line)
+					}
+				}
+			case strings.HasPrefix(blockID, settingBlockPrefix):
+				if _, key, ok := strings.Cut(strings.TrimPrefix(blockID, settingBlockPrefix), ":"); ok {
+					values[key] = value.Value
+				}
+			}
+		}
+	}
+
+	return metadata.ChannelID, values, questions, nil
+}
@@ -0,0 +1,36 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelSetupModal(t *testing.T) {
+	modal := BuildChannelSetupModal(&ChannelSetupForm{
+		ChannelID: "C1234567890",
+		Settings:  []ChannelSetting{{Key: "timezone", Label: "Timezone", Value: "UTC"}},
+		Questions: []string{"Yesterday?", "Today?"},
+	})
+	assert.Equal(t, ChannelSetupCallbackID, modal.CallbackID)
+	require.Len(t, modal.Blocks, 3)
+	assert.Equal(t, "setting:C1234567890:timezone", modal.Blocks[1].(InputBlock).BlockID)
+	questions := modal.Blocks[2].(InputBlock)
+	assert.Equal(t, "Yesterday?\nToday?", questions.Element.(PlainTextInputElement).InitialValue)
+
+	view := &View{PrivateMetadata: modal.PrivateMetadata, State: &ViewState{Values: map[string]map[string]ViewStateValue{
+		SettingBlockID("C1234567890", "timezone"): {"value": {Type: "plain_text_input", Value: "Europe/Berlin"}},
+		ChannelSetupQuestionsBlockID:              {"value": {Type: "plain_text_input", Value: " Done?\n\nNext? \n"}},
+	}}}
+	channelID, values, parsed, err := ParseChannelSetupSubmission(view)
+	require.NoError(t, err)
+	assert.Equal(t, "C1234567890", channelID)
+	assert.Equal(t, map[string]string{"timezone": "Europe/Berlin"}, values)
+	assert.Equal(t, []string{"Done?", "Next?"}, parsed)
+
+	// The channel comes from the modal, not from what was submitted
+	view.PrivateMetadata = ""
+	_, _, _, err = ParseChannelSetupSubmission(view)
+	assert.Error(t, err)
+}
@@ -0,0 +1,454 @@
+// Package slacktest provides an in-memory slack.Client for tests of code
+// that calls Slack, such as the standup service and scheduler.
+package slacktest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+// Call is a recorded Slack API call.
+type Call struct {
+	Method    string                 // Web API method, e.g. "chat.postMessage"
+	Channel   string                 // Channel or conversation, if any
+	User      string                 // User, if any
+	Timestamp string                 // Message timestamp, or Unix time a message is scheduled for, if any
+	ID        string                 // Trigger, view, external, group, file name, email, scheduled message or unfurl
+	Message   *slack.Message         // Message with its options applied
+	Modal     *slack.Modal           // View opened, updated or pushed
+	Response  *slack.ResponseMessage // Message posted to a response URL
+	Content   []byte                 // Uploaded file content
+}
+
+// Client is an in-memory slack.Client. It records every call and answers
+// from the users, channels and groups it was given. Calls fail with the
+// errors queued for their method, one error per call.
+type Client struct {
+	// Users, Channels and their members are looked up by ID. Missing users
+	// fail with slack.ErrUserNotFound and missing channels with
+	// channel_not_found, as Slack does.
+	Users            map[string]*slack.UserInfo
+	Channels         map[string]*slack.ConversationInfo
+	ChannelMembers   map[string][]string
+	UserGroupMembers map[string][]string
+	Reactions        map[string][]slack.Reaction // Keyed by message timestamp
+	Teams            []slack.Team
+	DND              map[string]*slack.DNDStatus // Users not listed aren't in Do Not Disturb
+	Identity         slack.AuthIdentity          // Returned by AuthTest
+
+	mu    sync.Mutex
+	calls []Call
+	errs  map[string][]error
+	seq   int
+
+	scheduled []slack.ScheduledMessage // Until they're deleted
+}
+
+var _ slack.Client = (*Client)(nil)
+
+// New creates a client with no users or channels.
+func New() *Client {
+	return &Client{
+		Users:            make(map[string]*slack.UserInfo),
+		Channels:         make(map[string]*slack.ConversationInfo),
+		ChannelMembers:   make(map[string][]string),
+		UserGroupMembers: make(map[string][]string),
+		Reactions:        make(map[string][]slack.Reaction),
+		DND:              make(map[string]*slack.DNDStatus),
+		errs:             make(map[string][]error),
+	}
+}
+
+// AddUser adds a user that can be looked up by ID or email.
+func (c *Client) AddUser(user *slack.UserInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Users[user.ID] = user
+}
+
+// FailNext makes the next call to method fail with err. Errors queue up, so
+// calling it twice fails the next two calls.
+func (c *Client) FailNext(method string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs[method] = append(c.errs[method], err)
+}
+
+// FailNextWithCode makes the next call to method fail with a Slack API error
+// such as "channel_not_found".
+func (c *Client) FailNextWithCode(method, code string) {
+	c.FailNext(method, &slack.APIError{Code: code})
+}
+
+// RateLimit makes the next n calls to method fail as if Slack answered them
+// with HTTP 429.
+func (c *Client) RateLimit(method string, n int) {
+	for range n {
+		c.FailNext(method, fmt.Errorf("%w: %s", slack.ErrRateLimited, method))
+	}
+}
+
+// Calls returns the recorded calls to the given methods, or all calls when
+// none are given, in the order they were made.
+func (c *Client) Calls(methods ...string) []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var calls []Call
+	for _, call := range c.calls {
+		if len(methods) == 0 || slices.Contains(methods, call.Method) {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the recorded calls and queued errors.
+func (c *Client) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = nil
+	c.errs = make(map[string][]error)
+}
+
+// record records a call and returns the error queued for its method, if any.
+func (c *Client) record(call Call) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = append(c.calls, call)
+	if errs := c.errs[call.Method]; len(errs) > 0 {
+		c.errs[call.Method] = errs[1:]
+		return errs[0]
+	}
+	return nil
+}
+
+// timestamp returns a unique message timestamp in Slack's format.
+func (c *Client) timestamp() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	return fmt.Sprintf("1700000000.%06d", c.seq)
+}
+
+func buildMessage(channel string, opts []slack.MessageOption) *slack.Message {
+	msg := &slack.Message{Channel: channel}
+	for _, opt := range opts {
+		opt(msg)
+	}
+	return msg
+}
+
+// PostMessage records a chat.postMessage call.
+func (c *Client) PostMessage(ctx context.Context, channel string, opts ...slack.MessageOption) (string, error) {
+	msg := buildMessage(channel, opts)
+	if err := c.record(Call{Method: "chat.postMessage", Channel: channel, Message: msg}); err != nil {
+		return "", err
+	}
+	return c.timestamp(), nil
+}
+
+// PostEphemeral records a chat.postEphemeral call.
+func (c *Client) PostEphemeral(
+	ctx context.Context,
+	channel, userID string,
+	opts ...slack.MessageOption,
+) (string, error) {
+	msg := buildMessage(channel, opts)
+	if err := c.record(Call{Method: "chat.postEphemeral", Channel: channel, User: userID, Message: msg}); err != nil {
+		return "", err
+	}
+	return c.timestamp(), nil
+}
+
+// ScheduleMessage records a chat.scheduleMessage call, with the time it's
+// scheduled for in Timestamp. The message is listed by ListScheduledMessages
+// until it's deleted; it's never posted.
+func (c *Client) ScheduleMessage(
+	ctx context.Context,
+	channel string,
+	postAt time.Time,
+	opts ...slack.MessageOption,
+) (string, error) {
+	msg := buildMessage(channel, opts)
+	call := Call{Method: "chat.scheduleMessage", Channel: channel, Timestamp: strconv.FormatInt(postAt.Unix(), 10),
+		Message: msg}
+	if err := c.record(call); err != nil {
+		return "", err
+	}
+
+	id := "Q" + c.timestamp()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.scheduled = append(c.scheduled, slack.ScheduledMessage{
+		ID:          id,
+		ChannelID:   channel,
+		PostAt:      postAt.Unix(),
+		DateCreated: time.Now().Unix(),
+		Text:        msg.Text,
+	})
+	return id, nil
+}
+
+// ListScheduledMessages records a chat.scheduledMessages.list call and
+// returns the messages scheduled in the channel, or in every channel.
+func (c *Client) ListScheduledMessages(ctx context.Context, channel string) ([]slack.ScheduledMessage, error) {
+	if err := c.record(Call{Method: "chat.scheduledMessages.list", Channel: channel}); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var messages []slack.ScheduledMessage
+	for _, message := range c.scheduled {
+		if channel == "" || message.ChannelID == channel {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
+
+// DeleteScheduledMessage records a chat.deleteScheduledMessage call and
+// forgets the message. Unknown messages fail with
+// invalid_scheduled_message_id, as Slack does.
+func (c *Client) DeleteScheduledMessage(ctx context.Context, channel, scheduledMessageID string) error {
+	if err := c.record(Call{Method: "chat.deleteScheduledMessage", Channel: channel, ID: scheduledMessageID}); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, message := range c.scheduled {
+		if message.ID == scheduledMessageID && message.ChannelID == channel {
+			c.scheduled = slices.Delete(c.scheduled, i, i+1)
+			return nil
+		}
+	}
+	return &slack.APIError{Code: "invalid_scheduled_message_id"}
+}
+
+// UpdateMessage records a chat.update call.
+func (c *Client) UpdateMessage(ctx context.Context, channel, timestamp string, opts ...slack.MessageOption) error {
+	return c.record(Call{Method: "chat.update", Channel: channel, Timestamp: timestamp,
+		Message: buildMessage(channel, opts)})
+}
+
+// DeleteMessage records a chat.delete call.
+func (c *Client) DeleteMessage(ctx context.Context, channel, timestamp string) error {
+	return c.record(Call{Method: "chat.delete", Channel: channel, Timestamp: timestamp})
+}
+
+// UnfurlLinks records a chat.unfurl call. Its message has the blocks of
+// every URL, in URL order, and its ID is the unfurl ID, if any.
+func (c *Client) UnfurlLinks(ctx context.Context, target slack.UnfurlTarget, unfurls map[string][]slack.Block) error {
+	message := &slack.Message{Channel: target.Channel}
+	for _, url := range slices.Sorted(maps.Keys(unfurls)) {
+		message.Blocks = append(message.Blocks, unfurls[url]...)
+	}
+	return c.record(Call{Method: "chat.unfurl", Channel: target.Channel, Timestamp: target.TS, ID: target.UnfurlID,
+		Message: message})
+}
+
+// GetPermalink records a chat.getPermalink call.
+func (c *Client) GetPermalink(ctx context.Context, channel, timestamp string) (string, error) {
+	if err := c.record(Call{Method: "chat.getPermalink", Channel: channel, Timestamp: timestamp}); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("https://example.slack.com/archives/%s/p%s", channel, timestamp), nil
+}
+
+// GetReactions records a reactions.get call and returns the message's
+// reactions.
+func (c *Client) GetReactions(ctx context.Context, channel, timestamp string) ([]slack.Reaction, error) {
+	if err := c.record(Call{Method: "reactions.get", Channel: channel, Timestamp: timestamp}); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Reactions[timestamp], nil
+}
+
+// PostToResponseURL records a post to an interaction's response URL.
+func (c *Client) PostToResponseURL(ctx context.Context, responseURL string, message *slack.ResponseMessage) error {
+	return c.record(Call{Method: "response_url", ID: responseURL, Response: message})
+}
+
+// UploadFile records a files.completeUploadExternal call.
+func (c *Client) UploadFile(
+	ctx context.Context,
+	channels []string,
+	filename string,
+	content []byte,
+	opts ...slack.FileOption,
+) (string, error) {
+	upload := &slack.FileUpload{}
+	for _, opt := range opts {
+		opt(upload)
+	}
+
+	call := Call{Method: "files.completeUploadExternal", ID: filename, Content: content,
+		Message: &slack.Message{Text: upload.InitialComment, ThreadTS: upload.ThreadTS}}
+	if len(channels) > 0 {
+		call.Channel = channels[0]
+	}
+	if err := c.record(call); err != nil {
+		return "", err
+	}
+	return "F" + c.timestamp(), nil
+}
+
+// OpenModal records a views.open call.
+func (c *Client) OpenModal(ctx context.Context, triggerID string, modal *slack.Modal) error {
+	return c.record(Call{Method: "views.open", ID: triggerID, Modal: modal})
+}
+
+// UpdateModal records a views.update call.
+func (c *Client) UpdateModal(ctx context.Context, viewID string, modal *slack.Modal) error {
+	return c.record(Call{Method: "views.update", ID: viewID, Modal: modal})
+}
+
+// UpdateModalWithHash records a views.update call. Queue a "hash_conflict"
+// error to simulate a view that changed.
+func (c *Client) UpdateModalWithHash(ctx context.Context, viewID, hash string, modal *slack.Modal) error {
+	return c.record(Call{Method: "views.update", ID: viewID, Modal: modal})
+}
+
+// UpdateModalByExternalID records a views.update call.
+func (c *Client) UpdateModalByExternalID(ctx context.Context, externalID string, modal *slack.Modal) error {
+	return c.record(Call{Method: "views.update", ID: externalID, Modal: modal})
+}
+
+// PushModal records a views.push call.
+func (c *Client) PushModal(ctx context.Context, triggerID string, modal *slack.Modal) error {
+	return c.record(Call{Method: "views.push", ID: triggerID, Modal: modal})
+}
+
+// GetUserInfo records a users.info call and returns the user.
+func (c *Client) GetUserInfo(ctx context.Context, userID string) (*slack.UserInfo, error) {
+	if err := c.record(Call{Method: "users.info", User: userID}); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	user, ok := c.Users[userID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", slack.ErrUserNotFound, userID)
+	}
+	return user, nil
+}
+
+// GetUserByEmail records a users.lookupByEmail call and returns the user
+// with the email.
+func (c *Client) GetUserByEmail(ctx context.Context, email string) (*slack.UserInfo, error) {
+	if err := c.record(Call{Method: "users.lookupByEmail", ID: email}); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, user := range c.Users {
+		if user.Profile.Email == email {
+			return user, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", slack.ErrUserNotFound, email)
+}
+
+// GetDNDStatus records a dnd.info call and returns the user's status.
+func (c *Client) GetDNDStatus(ctx context.Context, userID string) (*slack.DNDStatus, error) {
+	if err := c.record(Call{Method: "dnd.info", User: userID}); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if status, ok := c.DND[userID]; ok {
+		return status, nil
+	}
+	return &slack.DNDStatus{}, nil
+}
+
+// ListUserGroupMembers records a usergroups.users.list call and returns the
+// group's members.
+func (c *Client) ListUserGroupMembers(ctx context.Context, groupID string) ([]string, error) {
+	if err := c.record(Call{Method: "usergroups.users.list", ID: groupID}); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	members, ok := c.UserGroupMembers[groupID]
+	if !ok {
+		return nil, &slack.APIError{Code: "no_such_subteam"}
+	}
+	return members, nil
+}
+
+// GetChannelInfo records a conversations.info call and returns the channel.
+func (c *Client) GetChannelInfo(ctx context.Context, channelID string) (*slack.ConversationInfo, error) {
+	if err := c.record(Call{Method: "conversations.info", Channel: channelID}); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	channel, ok := c.Channels[channelID]
+	if !ok {
+		return nil, &slack.APIError{Code: "channel_not_found"}
+	}
+	return channel, nil
+}
+
+// ListChannelMembers records a conversations.members call and returns the
+// channel's members.
+func (c *Client) ListChannelMembers(ctx context.Context, channelID string) ([]string, error) {
+	if err := c.record(Call{Method: "conversations.members", Channel: channelID}); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	members, ok := c.ChannelMembers[channelID]
+	if !ok {
+		return nil, &slack.APIError{Code: "channel_not_found"}
+	}
+	return members, nil
+}
+
+// OpenDM records a conversations.open call and returns a DM channel ID
+// derived from the user's.
+func (c *Client) OpenDM(ctx context.Context, userID string) (string, error) {
+	if err := c.record(Call{Method: "conversations.open", User: userID}); err != nil {
+		return "", err
+	}
+	return "D" + userID, nil
+}
+
+// AuthTest records an auth.test call and returns Identity.
+func (c *Client) AuthTest(ctx context.Context) (*slack.AuthIdentity, error) {
+	if err := c.record(Call{Method: "auth.test"}); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	identity := c.Identity
+	return &identity, nil
+}
+
+// BotUserID returns Identity's user.
+func (c *Client) BotUserID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Identity.UserID
+}
+
+// ListAuthorizedTeams records an auth.teams.list call and returns Teams.
+func (c *Client) ListAuthorizedTeams(ctx context.Context) ([]slack.Team, error) {
+	if err := c.record(Call{Method: "auth.teams.list"}); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Teams, nil
+}
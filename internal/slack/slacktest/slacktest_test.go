@@ -0,0 +1,88 @@
+package slacktest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+	c.AddUser(&slack.UserInfo{ID: "U1234567890", Name: "alice", Profile: slack.UserProfile{Email: "alice@example.com"}})
+
+	ts, err := c.PostMessage(ctx, "C1234567890", slack.WithText("hello"))
+	require.NoError(t, err)
+	require.NoError(t, c.UpdateMessage(ctx, "C1234567890", ts, slack.WithText("edited")))
+
+	calls := c.Calls("chat.postMessage", "chat.update")
+	require.Len(t, calls, 2)
+	assert.Equal(t, "hello", calls[0].Message.Text)
+	assert.Equal(t, ts, calls[1].Timestamp)
+	assert.Equal(t, "edited", calls[1].Message.Text)
+
+	user, err := c.GetUserByEmail(ctx, "alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user.Name)
+
+	_, err = c.GetUserInfo(ctx, "U0000000000")
+	assert.ErrorIs(t, err, slack.ErrUserNotFound)
+	_, err = c.GetChannelInfo(ctx, "C0000000000")
+	assert.Equal(t, "channel_not_found", slack.ErrorCode(err))
+}
+
+func TestClientScheduledMessages(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+
+	postAt := time.Unix(1700003600, 0)
+	id, err := c.ScheduleMessage(ctx, "D1234567890", postAt, slack.WithText("reminder"))
+	require.NoError(t, err)
+	_, err = c.ScheduleMessage(ctx, "C1234567890", postAt, slack.WithText("announcement"))
+	require.NoError(t, err)
+
+	messages, err := c.ListScheduledMessages(ctx, "D1234567890")
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, id, messages[0].ID)
+	assert.Equal(t, postAt.Unix(), messages[0].PostAt)
+	assert.Equal(t, "reminder", messages[0].Text)
+
+	require.NoError(t, c.DeleteScheduledMessage(ctx, "D1234567890", id))
+	messages, err = c.ListScheduledMessages(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "C1234567890", messages[0].ChannelID)
+
+	err = c.DeleteScheduledMessage(ctx, "D1234567890", id)
+	assert.Equal(t, "invalid_scheduled_message_id", slack.ErrorCode(err))
+}
+
+func TestClientErrors(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+
+	// Queued errors fail one call each, in order
+	c.RateLimit("chat.postMessage", 1)
+	c.FailNextWithCode("chat.postMessage", "channel_not_found")
+
+	_, err := c.PostMessage(ctx, "C1234567890", slack.WithText("hello"))
+	assert.ErrorIs(t, err, slack.ErrRateLimited)
+	_, err = c.PostMessage(ctx, "C1234567890", slack.WithText("hello"))
+	assert.Equal(t, "channel_not_found", slack.ErrorCode(err))
+	_, err = c.PostMessage(ctx, "C1234567890", slack.WithText("hello"))
+	assert.NoError(t, err)
+
+	// Failed calls are recorded too
+	assert.Len(t, c.Calls(), 3)
+
+	c.FailNextWithCode("views.update", "hash_conflict")
+	c.Reset()
+	assert.Empty(t, c.Calls())
+	assert.NoError(t, c.UpdateModalWithHash(ctx, "V1234567890", "hash", &slack.Modal{}))
+}
@@ -0,0 +1,175 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTokenTTL is how long a token read from Secrets Manager is cached.
+const DefaultTokenTTL = 15 * time.Minute
+
+// TokenSource supplies the bot token for API calls.
+type TokenSource interface {
+	// Token returns the current token.
+	Token(ctx context.Context) (string, error)
+
+	// Refresh returns a token other than stale, fetching it again if the
+	// cached token is stale. It is called when Slack rejects a token.
+	Refresh(ctx context.Context, stale string) (string, error)
+}
+
+// StaticTokenSource is a TokenSource for a fixed token.
+type StaticTokenSource string
+
+// Token returns the token.
+func (s StaticTokenSource) Token(ctx context.Context) (string, error) {
+	return string(s), nil
+}
+
+// Refresh returns the token; a fixed token can't be refreshed.
+func (s StaticTokenSource) Refresh(ctx context.Context, stale string) (string, error) {
+	return string(s), nil
+}
+
+// FallbackTokenSource returns a TokenSource that uses fallback while tokens
+// fails, e.g. while Secrets Manager is unreachable, and tokens again once it
+// recovers. An empty fallback leaves the failures to the caller.
+func FallbackTokenSource(tokens TokenSource, fallback string) TokenSource {
+	return &fallbackTokenSource{tokens: tokens, fallback: fallback}
+}
+
+type fallbackTokenSource struct {
+	tokens   TokenSource
+	fallback string
+}
+
+func (s *fallbackTokenSource) Token(ctx context.Context) (string, error) {
+	return s.orFallback(s.tokens.Token(ctx))
+}
+
+func (s *fallbackTokenSource) Refresh(ctx context.Context, stale string) (string, error) {
+	return s.orFallback(s.tokens.Refresh(ctx, stale))
+}
+
+func (s *fallbackTokenSource) orFallback(token string, err error) (string, error) {
+	if err != nil && s.fallback != "" {
+		return s.fallback, nil
+	}
+	return token, err
+}
+
+// SecretGetter reads secret values. botcontext.SecretsClient satisfies it.
+type SecretGetter interface {
+	GetSecret(ctx context.Context, secretID string) (string, error)
+}
+
+// SecretTokenSource reads the bot token from Secrets Manager. The secret is
+// either the token itself or a JSON object with a bot_token key.
+type SecretTokenSource struct {
+	secrets  SecretGetter
+	secretID string
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	fetchedAt time.Time
+}
+
+// NewSecretTokenSource creates a token source for the given secret.
+func NewSecretTokenSource(secrets SecretGetter, secretID string, ttl time.Duration) *SecretTokenSource {
+	if ttl <= 0 {
+		ttl = DefaultTokenTTL
+	}
+
+	return &SecretTokenSource{
+		secrets:  secrets,
+		secretID: secretID,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+// Token returns the cached token, fetching it when it has expired.
+func (s *SecretTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Sub(s.fetchedAt) < s.ttl {
+		return s.token, nil
+	}
+
+	return s.fetch(ctx)
+}
+
+// Refresh fetches the token again unless another caller already replaced stale.
+func (s *SecretTokenSource) Refresh(ctx context.Context, stale string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.token != stale {
+		return s.token, nil
+	}
+
+	return s.fetch(ctx)
+}
+
+func (s *SecretTokenSource) fetch(ctx context.Context) (string, error) {
+	value, err := s.secrets.GetSecret(ctx, s.secretID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Slack token secret: %w", err)
+	}
+
+	token := strings.TrimSpace(value)
+	if strings.HasPrefix(token, "{") {
+		var secret struct {
+			BotToken string `json:"bot_token"`
+		}
+		if err := json.Unmarshal([]byte(token), &secret); err != nil {
+			return "", fmt.Errorf("failed to parse Slack token secret: %w", err)
+		}
+		token = secret.BotToken
+	}
+
+	if token == "" {
+		return "", fmt.Errorf("slack token secret is empty")
+	}
+
+	s.token = token
+	s.fetchedAt = s.now()
+	return token, nil
+}
+
+// IsInvalidToken reports whether err is Slack rejecting the token itself,
+// as opposed to a call it may not make.
+func IsInvalidToken(err error) bool {
+	switch ErrorCode(err) {
+	case "invalid_auth", "not_authed", "account_inactive", "token_expired", "token_revoked":
+		return true
+	default:
+		return false
+	}
+}
+
+// isAuthError reports whether a response rejected the token in a way a
+// rotated token could fix.
+func isAuthError(body []byte) bool {
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil || result.OK {
+		return false
+	}
+
+	switch result.Error {
+	case "invalid_auth", "token_expired", "token_revoked":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,134 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSecrets returns the current value of a secret, or err, and counts
+// reads.
+type fakeSecrets struct {
+	value string
+	err   error
+	reads int
+}
+
+func (f *fakeSecrets) GetSecret(ctx context.Context, secretID string) (string, error) {
+	f.reads++
+	return f.value, f.err
+}
+
+func TestSecretTokenSource(t *testing.T) {
+	ctx := context.Background()
+	secrets := &fakeSecrets{value: `{"bot_token": "xoxb-old"}`}
+	tokens := NewSecretTokenSource(secrets, "arn:aws:secretsmanager:us-east-1:123456789012:secret:slack", time.Minute)
+
+	now := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	tokens.now = func() time.Time { return now }
+
+	token, err := tokens.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-old", token)
+
+	// Cached until the TTL passes
+	secrets.value = "xoxb-new"
+	token, err = tokens.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-old", token)
+	assert.Equal(t, 1, secrets.reads)
+
+	now = now.Add(time.Minute)
+	token, err = tokens.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-new", token)
+	assert.Equal(t, 2, secrets.reads)
+
+	// Refreshing a token that was already replaced does not fetch again
+	token, err = tokens.Refresh(ctx, "xoxb-old")
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-new", token)
+	assert.Equal(t, 2, secrets.reads)
+
+	secrets.value = "  "
+	_, err = tokens.Refresh(ctx, "xoxb-new")
+	assert.Error(t, err)
+}
+
+func TestFallbackTokenSource(t *testing.T) {
+	ctx := context.Background()
+	secrets := &fakeSecrets{err: errors.New("connection refused")}
+	tokens := FallbackTokenSource(NewSecretTokenSource(secrets, "slack", time.Hour), "xoxb-env")
+
+	token, err := tokens.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-env", token)
+
+	// The secret is used once it can be read
+	secrets.value, secrets.err = "xoxb-secret", nil
+	token, err = tokens.Refresh(ctx, "xoxb-env")
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-secret", token)
+
+	// Without a fallback, the failure is returned
+	_, err = FallbackTokenSource(NewSecretTokenSource(&fakeSecrets{err: errors.New("denied")}, "slack", time.Hour), "").
+		Token(ctx)
+	assert.Error(t, err)
+}
+
+func TestClientRefreshesRotatedToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xoxb-new" {
+			_, _ = w.Write([]byte(`{"ok": false, "error": "invalid_auth"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "channel": "C1234567890", "ts": "1700000000.000100"}`))
+	}))
+	defer server.Close()
+
+	secrets := &fakeSecrets{value: "xoxb-old"}
+	c := NewClient("", WithTokenSource(NewSecretTokenSource(secrets, "slack", time.Hour))).(*client)
+	c.baseURL = server.URL
+
+	// Cache the old token, then rotate it
+	_, err := c.tokens.Token(context.Background())
+	require.NoError(t, err)
+	secrets.value = "xoxb-new"
+
+	ts, err := c.PostMessage(context.Background(), "C1234567890", WithText("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "1700000000.000100", ts)
+	assert.Equal(t, 2, secrets.reads)
+}
+
+func TestClientAuthTest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xoxb-valid" {
+			_, _ = w.Write([]byte(`{"ok": false, "error": "invalid_auth"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "url": "https://acme.slack.com/", "team": "Acme", "user": "standup-bot",
+			"team_id": "T1234567890", "user_id": "U0000000000", "bot_id": "B0000000000"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("xoxb-revoked", WithRateLimiter(nil)).(*client)
+	c.baseURL = server.URL
+	_, err := c.AuthTest(context.Background())
+	assert.True(t, IsInvalidToken(err))
+	assert.Empty(t, c.BotUserID())
+
+	c.token = "xoxb-valid"
+	identity, err := c.AuthTest(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "T1234567890", identity.TeamID)
+	assert.Equal(t, "U0000000000", c.BotUserID())
+
+	assert.False(t, IsInvalidToken(&APIError{Code: "missing_scope"}))
+}
@@ -2,82 +2,36 @@ package slack
 
 import (
 	"time"
-)
-
-// Modal represents a Slack modal view.
-type Modal struct {
-	Type            string     `json:"type"`
-	Title           *TextBlock `json:"title"`
-	Submit          *TextBlock `json:"submit,omitempty"`
-	Close           *TextBlock `json:"close,omitempty"`
-	Blocks          []Block    `json:"blocks"`
-	PrivateMetadata string     `json:"private_metadata,omitempty"`
-	CallbackID      string     `json:"callback_id,omitempty"`
-	ClearOnClose    bool       `json:"clear_on_close,omitempty"`
-	NotifyOnClose   bool       `json:"notify_on_close,omitempty"`
-}
-
-// Block is an interface for Slack blocks.
-type Block interface {
-	BlockType() string
-}
-
-// TextBlock represents a text object.
-type TextBlock struct {
-	Type  string `json:"type"`
-	Text  string `json:"text"`
-	Emoji bool   `json:"emoji,omitempty"`
-}
-
-// SectionBlock represents a section block.
-type SectionBlock struct {
-	Type      string      `json:"type"`
-	Text      *TextBlock  `json:"text,omitempty"`
-	BlockID   string      `json:"block_id,omitempty"`
-	Fields    []TextBlock `json:"fields,omitempty"`
-	Accessory interface{} `json:"accessory,omitempty"`
-}
-
-func (s *SectionBlock) BlockType() string { return "section" }
-
-// HeaderBlock represents a header block.
-type HeaderBlock struct {
-	Type    string     `json:"type"`
-	Text    *TextBlock `json:"text"`
-	BlockID string     `json:"block_id,omitempty"`
-}
-
-func (h HeaderBlock) BlockType() string { return "header" }
-
-// InputBlock represents an input block.
-type InputBlock struct {
-	Type     string      `json:"type"`
-	BlockID  string      `json:"block_id"`
-	Label    *TextBlock  `json:"label"`
-	Element  interface{} `json:"element"`
-	Optional bool        `json:"optional,omitempty"`
-	Hint     *TextBlock  `json:"hint,omitempty"`
-}
-
-func (i InputBlock) BlockType() string { return "input" }
 
-// DividerBlock represents a divider block.
-type DividerBlock struct {
-	Type string `json:"type"`
-}
-
-func (d DividerBlock) BlockType() string { return "divider" }
+	"github.com/synaptiq/standup-bot/internal/blockkit"
+	"github.com/synaptiq/standup-bot/internal/i18n"
+)
 
-// PlainTextInputElement represents a plain text input.
-type PlainTextInputElement struct {
-	Type         string     `json:"type"`
-	ActionID     string     `json:"action_id"`
-	Placeholder  *TextBlock `json:"placeholder,omitempty"`
-	InitialValue string     `json:"initial_value,omitempty"`
-	Multiline    bool       `json:"multiline,omitempty"`
-	MinLength    int        `json:"min_length,omitempty"`
-	MaxLength    int        `json:"max_length,omitempty"`
-}
+// Block Kit types, defined in package blockkit.
+type (
+	Modal                      = blockkit.Modal
+	Block                      = blockkit.Block
+	TextBlock                  = blockkit.TextBlock
+	SectionBlock               = blockkit.SectionBlock
+	HeaderBlock                = blockkit.HeaderBlock
+	InputBlock                 = blockkit.InputBlock
+	DividerBlock               = blockkit.DividerBlock
+	ActionsBlock               = blockkit.ActionsBlock
+	ContextBlock               = blockkit.ContextBlock
+	ImageBlock                 = blockkit.ImageBlock
+	ImageElement               = blockkit.ImageElement
+	ButtonElement              = blockkit.ButtonElement
+	OverflowElement            = blockkit.OverflowElement
+	PlainTextInputElement      = blockkit.PlainTextInputElement
+	StaticSelectElement        = blockkit.StaticSelectElement
+	ConversationsSelectElement = blockkit.ConversationsSelectElement
+	ConversationFilter         = blockkit.ConversationFilter
+	CheckboxesElement          = blockkit.CheckboxesElement
+	RadioButtonsElement        = blockkit.RadioButtonsElement
+	DatePickerElement          = blockkit.DatePickerElement
+	NumberInputElement         = blockkit.NumberInputElement
+	Option                     = blockkit.Option
+)
 
 // Message represents a Slack message.
 type Message struct {
@@ -97,6 +51,29 @@ type Message struct {
 	Metadata    *Metadata    `json:"metadata,omitempty"`
 }
 
+// Response types for slash command and interaction responses.
+const (
+	ResponseEphemeral = "ephemeral"
+	ResponseInChannel = "in_channel"
+)
+
+// ResponseMessage is a message sent to an interaction's response_url.
+type ResponseMessage struct {
+	ResponseType    string  `json:"response_type,omitempty"` // ephemeral (default) or in_channel
+	Text            string  `json:"text,omitempty"`
+	Blocks          []Block `json:"blocks,omitempty"`
+	ThreadTS        string  `json:"thread_ts,omitempty"`
+	ReplaceOriginal bool    `json:"replace_original,omitempty"`
+	DeleteOriginal  bool    `json:"delete_original,omitempty"`
+}
+
+// FileUpload holds the optional settings for an uploaded file.
+type FileUpload struct {
+	Title          string
+	InitialComment string
+	ThreadTS       string
+}
+
 // Attachment represents a message attachment.
 type Attachment struct {
 	Color      string   `json:"color,omitempty"`
@@ -135,9 +112,29 @@ type InteractionCallback struct {
 	Team        Team                   `json:"team"`
 	Channel     Channel                `json:"channel"`
 	ResponseURL string                 `json:"response_url"`
+	Container   *Container             `json:"container,omitempty"`
 	View        *View                  `json:"view,omitempty"`
 	Actions     []Action               `json:"actions,omitempty"`
 	Submission  map[string]interface{} `json:"submission,omitempty"`
+	// Enterprise is set for workspaces in an Enterprise Grid
+	Enterprise          *Enterprise `json:"enterprise,omitempty"`
+	IsEnterpriseInstall bool        `json:"is_enterprise_install"`
+}
+
+// WorkspaceID returns the workspace the interaction happened in. Payloads of
+// org-wide installs have no team when the interaction isn't tied to a
+// workspace, e.g. in a channel shared across the grid, so the user's or the
+// view's workspace is used instead.
+func (p *InteractionCallback) WorkspaceID() string {
+	switch {
+	case p.Team.ID != "":
+		return p.Team.ID
+	case p.User.TeamID != "":
+		return p.User.TeamID
+	case p.View != nil:
+		return p.View.TeamID
+	}
+	return ""
 }
 
 // User represents a Slack user.
@@ -148,6 +145,33 @@ type User struct {
 	TeamID   string `json:"team_id"`
 }
 
+// Reaction is an emoji reaction to a message.
+type Reaction struct {
+	Name  string   `json:"name"` // Emoji name without colons, e.g. "eyes"
+	Count int      `json:"count"`
+	Users []string `json:"users"`
+}
+
+// ScheduledMessage is a message waiting to be posted by Slack, as listed by
+// chat.scheduledMessages.list.
+type ScheduledMessage struct {
+	ID          string `json:"id"`
+	ChannelID   string `json:"channel_id"`
+	PostAt      int64  `json:"post_at"` // Unix seconds
+	DateCreated int64  `json:"date_created"`
+	Text        string `json:"text"`
+}
+
+// AuthIdentity is who a token belongs to, as returned by auth.test.
+type AuthIdentity struct {
+	URL    string `json:"url"` // The workspace's URL
+	Team   string `json:"team"`
+	User   string `json:"user"`
+	TeamID string `json:"team_id"`
+	UserID string `json:"user_id"`
+	BotID  string `json:"bot_id"`
+}
+
 // Team represents a Slack team.
 type Team struct {
 	ID     string `json:"id"`
@@ -155,6 +179,21 @@ type Team struct {
 	Name   string `json:"name"`
 }
 
+// Enterprise identifies an Enterprise Grid organization.
+type Enterprise struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Container identifies the message or view an interaction originated from.
+type Container struct {
+	Type        string `json:"type"`
+	MessageTS   string `json:"message_ts,omitempty"`
+	ChannelID   string `json:"channel_id,omitempty"`
+	IsEphemeral bool   `json:"is_ephemeral,omitempty"`
+	ViewID      string `json:"view_id,omitempty"`
+}
+
 // Channel represents a Slack channel.
 type Channel struct {
 	ID   string `json:"id"`
@@ -168,6 +207,7 @@ type View struct {
 	Type            string     `json:"type"`
 	PrivateMetadata string     `json:"private_metadata"`
 	CallbackID      string     `json:"callback_id"`
+	ExternalID      string     `json:"external_id"`
 	State           *ViewState `json:"state"`
 	Hash            string     `json:"hash"`
 	Title           *TextBlock `json:"title"`
@@ -189,12 +229,8 @@ type ViewStateValue struct {
 	SelectedOptions []Option `json:"selected_options,omitempty"`
 	SelectedDate    string   `json:"selected_date,omitempty"`
 	SelectedTime    string   `json:"selected_time,omitempty"`
-}
-
-// Option represents a select option.
-type Option struct {
-	Text  *TextBlock `json:"text"`
-	Value string     `json:"value"`
+	// SelectedConversation is set by conversations selects
+	SelectedConversation string `json:"selected_conversation,omitempty"`
 }
 
 // Action represents an interactive action.
@@ -221,6 +257,9 @@ type SlashCommand struct {
 	Text        string `json:"text"`
 	ResponseURL string `json:"response_url"`
 	TriggerID   string `json:"trigger_id"`
+	// EnterpriseID is set for workspaces in an Enterprise Grid
+	EnterpriseID        string `json:"enterprise_id,omitempty"`
+	IsEnterpriseInstall bool   `json:"is_enterprise_install"`
 }
 
 // Event represents a Slack event.
@@ -234,6 +273,41 @@ type Event struct {
 	ThreadTS string `json:"thread_ts,omitempty"`
 	Subtype  string `json:"subtype,omitempty"`
 	BotID    string `json:"bot_id,omitempty"`
+	// Inviter is who added the user of a member_joined_channel event, if
+	// anyone did
+	Inviter string `json:"inviter,omitempty"`
+	// TeamIDs lists the workspaces of team_access_granted and
+	// team_access_revoked events
+	TeamIDs []string `json:"team_ids,omitempty"`
+	// MessageTS, UnfurlID, Source and Links describe the message of a
+	// link_shared event and the links shared in it
+	MessageTS string       `json:"message_ts,omitempty"`
+	UnfurlID  string       `json:"unfurl_id,omitempty"`
+	Source    string       `json:"source,omitempty"`
+	Links     []SharedLink `json:"links,omitempty"`
+}
+
+// SharedLink is a link of a link_shared event, in a domain registered for
+// unfurling in the Slack app.
+type SharedLink struct {
+	Domain string `json:"domain"`
+	URL    string `json:"url"`
+}
+
+// UnfurlTarget identifies the message whose links are unfurled: a posted
+// message by channel and timestamp, or one still being composed by its
+// unfurl ID and source.
+type UnfurlTarget struct {
+	Channel  string
+	TS       string
+	UnfurlID string
+	Source   string
+}
+
+// UnfurlTarget returns the message of a link_shared event to unfurl its
+// links in.
+func (e *Event) UnfurlTarget() UnfurlTarget {
+	return UnfurlTarget{Channel: e.Channel, TS: e.MessageTS, UnfurlID: e.UnfurlID, Source: e.Source}
 }
 
 // EventWrapper wraps Slack events.
@@ -246,6 +320,38 @@ type EventWrapper struct {
 	EventID   string `json:"event_id"`
 	EventTime int64  `json:"event_time"`
 	Challenge string `json:"challenge,omitempty"`
+	// EnterpriseID is set for events from workspaces in an Enterprise Grid
+	EnterpriseID   string          `json:"enterprise_id,omitempty"`
+	Authorizations []Authorization `json:"authorizations,omitempty"`
+}
+
+// Authorization is an installation an event is delivered for.
+type Authorization struct {
+	EnterpriseID        string `json:"enterprise_id"`
+	TeamID              string `json:"team_id"`
+	UserID              string `json:"user_id"`
+	IsBot               bool   `json:"is_bot"`
+	IsEnterpriseInstall bool   `json:"is_enterprise_install"`
+}
+
+// WorkspaceID returns the workspace the event happened in, falling back to
+// the authorized installation's for org-wide events without a team.
+func (w *EventWrapper) WorkspaceID() string {
+	if w.TeamID != "" || len(w.Authorizations) == 0 {
+		return w.TeamID
+	}
+	return w.Authorizations[0].TeamID
+}
+
+// BotUserID returns the bot user of the installation the event is delivered
+// for, or "" if the event doesn't say.
+func (w *EventWrapper) BotUserID() string {
+	for _, auth := range w.Authorizations {
+		if auth.IsBot {
+			return auth.UserID
+		}
+	}
+	return ""
 }
 
 // ConversationInfo represents channel information.
@@ -284,6 +390,7 @@ type UserInfo struct {
 	IsOwner  bool        `json:"is_owner"`
 	IsBot    bool        `json:"is_bot"`
 	Updated  int64       `json:"updated"`
+	Locale   string      `json:"locale"` // IETF language tag, e.g. "es-ES"
 }
 
 // UserProfile represents user profile information.
@@ -307,6 +414,30 @@ type UserProfile struct {
 	StatusExpiration      int64  `json:"status_expiration"`
 }
 
+// DNDStatus is a user's Do Not Disturb status, as returned by dnd.info.
+// Times are Unix seconds.
+type DNDStatus struct {
+	DNDEnabled     bool  `json:"dnd_enabled"` // The user has Do Not Disturb hours
+	NextDNDStartTS int64 `json:"next_dnd_start_ts"`
+	NextDNDEndTS   int64 `json:"next_dnd_end_ts"`
+	SnoozeEnabled  bool  `json:"snooze_enabled"` // Notifications are paused
+	SnoozeEndTime  int64 `json:"snooze_endtime"`
+}
+
+// AvailableAt returns when the user's notifications resume, which is now
+// unless they're paused or the user is in their Do Not Disturb hours.
+func (d *DNDStatus) AvailableAt(now time.Time) time.Time {
+	available := now
+	if d.SnoozeEnabled && d.SnoozeEndTime > available.Unix() {
+		available = time.Unix(d.SnoozeEndTime, 0)
+	}
+	// Do Not Disturb hours may begin before the pause ends
+	if d.DNDEnabled && d.NextDNDStartTS <= available.Unix() && d.NextDNDEndTS > available.Unix() {
+		available = time.Unix(d.NextDNDEndTS, 0)
+	}
+	return available
+}
+
 // StandupModalMetadata contains metadata for standup modals.
 type StandupModalMetadata struct {
 	ChannelID string    `json:"channel_id"`
@@ -314,4 +445,19 @@ type StandupModalMetadata struct {
 	SessionID string    `json:"session_id"`
 	UserID    string    `json:"user_id"`
 	Timestamp time.Time `json:"timestamp"`
+	// Locale is the language the modal is shown in
+	Locale i18n.Locale `json:"locale,omitempty"`
+	// Answers are kept on the review screen until the standup is submitted
+	Answers map[string]Answer `json:"answers,omitempty"`
+	// Plan is the user's plan from their previous standup, shown above the
+	// question it's reconciled with
+	Plan *CarriedAnswer `json:"plan,omitempty"`
+}
+
+// CarriedAnswer is an answer from an earlier standup shown in the modal as
+// context for a question.
+type CarriedAnswer struct {
+	BlockID string `json:"block_id"` // The question it's shown above
+	Date    string `json:"date"`     // The standup it was given in
+	Text    string `json:"text"`
 }
@@ -0,0 +1,35 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ReportPreview describes a shared report download link for its unfurl.
+type ReportPreview struct {
+	Title     string // What the report is, e.g. "📦 Standup export for <#C1234567890>"
+	StartDate string
+	EndDate   string
+	Format    string
+	ExpiresAt time.Time // Zero if unknown
+}
+
+// BuildReportUnfurl builds the card a shared report link unfurls into: what
+// the report covers and when the link expires, as of now.
+func BuildReportUnfurl(preview *ReportPreview, now time.Time) []Block {
+	text := fmt.Sprintf("*%s*\n%s to %s · %s", preview.Title, preview.StartDate, preview.EndDate,
+		strings.ToUpper(preview.Format))
+
+	switch {
+	case preview.ExpiresAt.IsZero():
+	case !now.Before(preview.ExpiresAt):
+		text += "\n_This link has expired; run the report again for a new one._"
+	default:
+		expires := preview.ExpiresAt.Unix()
+		text += fmt.Sprintf("\n_Link expires <!date^%d^{date_short_pretty} at {time}|%s>._",
+			expires, preview.ExpiresAt.UTC().Format("2006-01-02 15:04 UTC"))
+	}
+
+	return NewMessageBuilder().AddSection(text).Build()
+}
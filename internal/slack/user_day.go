@@ -0,0 +1,45 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/synaptiq/standup-bot/internal/security"
+)
+
+// UserDayChannel is what a user reported in one channel on a day.
+type UserDayChannel struct {
+	ChannelID string
+	Late      bool
+	Answers   []SummaryAnswer // In question order
+}
+
+// BuildUserDayReport builds the reply to "/standup-report user", listing what
+// a user reported in each channel on a date. hidden counts the channels left
+// out because their standups are private.
+func BuildUserDayReport(userID, date string, channels []UserDayChannel, hidden int) []Block {
+	userID = security.SanitizeLogValue(userID)
+	builder := NewMessageBuilder()
+	if len(channels) == 0 {
+		builder.AddSection(fmt.Sprintf("📋 <@%s> didn't report in any standup on %s.", userID, date))
+	} else {
+		builder.AddSection(fmt.Sprintf("📋 What <@%s> reported on %s:", userID, date))
+	}
+
+	for _, channel := range channels {
+		text := fmt.Sprintf("*<#%s>*", security.SanitizeLogValue(channel.ChannelID))
+		if channel.Late {
+			text += " (late)"
+		}
+		for _, answer := range channel.Answers {
+			text += fmt.Sprintf("\n_%s_\n> %s", answer.Question, strings.ReplaceAll(answer.Text, "\n", "\n> "))
+		}
+		builder.AddSection(text)
+	}
+
+	if hidden > 0 {
+		builder.AddSection(fmt.Sprintf("_%d private standups aren't shown; only their report recipients see them._", hidden))
+	}
+
+	return builder.Build()
+}
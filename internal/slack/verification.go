@@ -4,45 +4,89 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// Request verification errors.
+var (
+	ErrMissingSignature   = errors.New("missing signature headers")
+	ErrInvalidTimestamp   = errors.New("invalid timestamp")
+	ErrStaleTimestamp     = errors.New("request timestamp too old")
+	ErrUnsupportedVersion = errors.New("unsupported signature version")
+	ErrInvalidSignature   = errors.New("invalid signature")
+)
+
+// MaxRequestAge is how far a request's timestamp may be from now, either
+// way, before the request is rejected as a possible replay.
+const MaxRequestAge = 5 * time.Minute
+
+// signatureVersion is the version of Slack's signing scheme, which prefixes
+// both the signed base string and the signature.
+const signatureVersion = "v0"
+
 // RequestVerifier verifies Slack request signatures.
 type RequestVerifier struct {
-	signingSecret string
+	signingSecrets []string
+	now            func() time.Time
 }
 
-// NewRequestVerifier creates a new request verifier.
-func NewRequestVerifier(signingSecret string) *RequestVerifier {
-	return &RequestVerifier{
-		signingSecret: signingSecret,
+// NewRequestVerifier creates a new request verifier. Requests signed with any
+// of signingSecrets are accepted, so a secret can be rotated without
+// downtime: pass the current secret first, then the one being rotated to.
+// Empty secrets are ignored.
+func NewRequestVerifier(signingSecrets ...string) *RequestVerifier {
+	v := &RequestVerifier{now: time.Now}
+	for _, secret := range signingSecrets {
+		if secret != "" {
+			v.signingSecrets = append(v.signingSecrets, secret)
+		}
 	}
+	return v
 }
 
-// VerifyRequest verifies a Slack request signature.
+// VerifyRequest verifies a Slack request signature. Each way a request can
+// fail has its own error, e.g. ErrStaleTimestamp for a replayed request and
+// ErrInvalidSignature for one signed with an unknown secret.
 func (v *RequestVerifier) VerifyRequest(timestamp, signature, body string) error {
+	if timestamp == "" || signature == "" {
+		return ErrMissingSignature
+	}
+
 	// Check timestamp to prevent replay attacks
 	ts, err := strconv.ParseInt(timestamp, 10, 64)
 	if err != nil {
-		return fmt.Errorf("invalid timestamp: %w", err)
+		return fmt.Errorf("%w: %w", ErrInvalidTimestamp, err)
+	}
+
+	age := v.now().Sub(time.Unix(ts, 0))
+	if age > MaxRequestAge || age < -MaxRequestAge {
+		return ErrStaleTimestamp
 	}
 
-	if time.Now().Unix()-ts > 60*5 {
-		return fmt.Errorf("request timestamp too old")
+	version, _, found := strings.Cut(signature, "=")
+	if !found {
+		return ErrInvalidSignature
+	}
+	if version != signatureVersion {
+		return ErrUnsupportedVersion
 	}
 
 	// Verify signature
-	baseString := fmt.Sprintf("v0:%s:%s", timestamp, body)
+	baseString := fmt.Sprintf("%s:%s:%s", signatureVersion, timestamp, body)
 
-	h := hmac.New(sha256.New, []byte(v.signingSecret))
-	h.Write([]byte(baseString))
-	computedSignature := "v0=" + hex.EncodeToString(h.Sum(nil))
+	for _, secret := range v.signingSecrets {
+		h := hmac.New(sha256.New, []byte(secret))
+		h.Write([]byte(baseString))
+		computedSignature := signatureVersion + "=" + hex.EncodeToString(h.Sum(nil))
 
-	if !hmac.Equal([]byte(signature), []byte(computedSignature)) {
-		return fmt.Errorf("invalid signature")
+		if hmac.Equal([]byte(signature), []byte(computedSignature)) {
+			return nil
+		}
 	}
 
-	return nil
+	return ErrInvalidSignature
 }
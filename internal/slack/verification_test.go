@@ -0,0 +1,51 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signRequest(secret, timestamp, body string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte("v0:" + timestamp + ":" + body))
+	return "v0=" + hex.EncodeToString(h.Sum(nil))
+}
+
+func TestRequestVerifier(t *testing.T) {
+	now := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := "token=x&command=%2Fstandup"
+
+	verifier := NewRequestVerifier("current-secret", "", "next-secret")
+	verifier.now = func() time.Time { return now }
+
+	// Either secret is accepted while rotating
+	assert.NoError(t, verifier.VerifyRequest(timestamp, signRequest("current-secret", timestamp, body), body))
+	assert.NoError(t, verifier.VerifyRequest(timestamp, signRequest("next-secret", timestamp, body), body))
+
+	// But not an unknown one, or a tampered body
+	assert.ErrorIs(t, verifier.VerifyRequest(timestamp, signRequest("old-secret", timestamp, body), body),
+		ErrInvalidSignature)
+	assert.ErrorIs(t, verifier.VerifyRequest(timestamp, signRequest("current-secret", timestamp, body), body+"x"),
+		ErrInvalidSignature)
+	assert.ErrorIs(t, verifier.VerifyRequest(timestamp, "garbage", body), ErrInvalidSignature)
+
+	assert.ErrorIs(t, verifier.VerifyRequest("", "v0=00", body), ErrMissingSignature)
+	assert.ErrorIs(t, verifier.VerifyRequest(timestamp, "", body), ErrMissingSignature)
+	assert.ErrorIs(t, verifier.VerifyRequest("yesterday", "v0=00", body), ErrInvalidTimestamp)
+	assert.ErrorIs(t, verifier.VerifyRequest(timestamp, "v1=00", body), ErrUnsupportedVersion)
+
+	// Timestamps outside the window are stale either way, even when signed
+	for _, offset := range []time.Duration{-MaxRequestAge - time.Second, MaxRequestAge + time.Second} {
+		ts := strconv.FormatInt(now.Add(offset).Unix(), 10)
+		assert.ErrorIs(t, verifier.VerifyRequest(ts, signRequest("current-secret", ts, body), body), ErrStaleTimestamp)
+	}
+	ts := strconv.FormatInt(now.Add(-MaxRequestAge).Unix(), 10)
+	assert.NoError(t, verifier.VerifyRequest(ts, signRequest("current-secret", ts, body), body))
+}
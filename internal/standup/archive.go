@@ -0,0 +1,88 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// ArchiveChannel pauses the standups of a channel that was archived in
+// Slack, deleting its schedule. Channels without standups are ignored.
+func (s *Service) ArchiveChannel(ctx context.Context, teamID, channelID string) error {
+	return s.changeChannelState(ctx, teamID, channelID, "Archived channel", func() error {
+		return s.store.ArchiveChannelConfig(ctx, teamID, channelID, time.Now())
+	})
+}
+
+// UnarchiveChannel resumes the standups of a channel that was unarchived in
+// Slack, unless they were disabled meanwhile.
+func (s *Service) UnarchiveChannel(ctx context.Context, teamID, channelID string) error {
+	return s.changeChannelState(ctx, teamID, channelID, "Unarchived channel", func() error {
+		return s.store.UnarchiveChannelConfig(ctx, teamID, channelID)
+	})
+}
+
+// DisableChannel turns off the standups of a channel, e.g. one deleted in
+// Slack, keeping its config and history.
+func (s *Service) DisableChannel(ctx context.Context, teamID, channelID string) error {
+	return s.changeChannelState(ctx, teamID, channelID, "Disabled channel", func() error {
+		return s.store.DisableChannelConfig(ctx, teamID, channelID)
+	})
+}
+
+// changeChannelState applies change to a channel's stored config and syncs
+// its schedule with the result.
+func (s *Service) changeChannelState(
+	ctx context.Context,
+	teamID, channelID, message string,
+	change func() error,
+) error {
+	if err := change(); err != nil {
+		if err == store.ErrNotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to update channel config: %w", err)
+	}
+
+	config, err := s.store.GetChannelConfig(ctx, teamID, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel config: %w", err)
+	}
+	if err := s.SyncSchedule(ctx, config); err != nil {
+		return fmt.Errorf("failed to sync schedule: %w", err)
+	}
+
+	s.botCtx.Logger().Info(ctx, message,
+		botcontext.Field{Key: "channel_id", Value: channelID},
+		botcontext.Field{Key: "active", Value: config.Active()},
+	)
+
+	return nil
+}
+
+// pauseIfArchived archives the standups of a channel found to be archived
+// in Slack, for channels archived while the bot didn't hear about it. It
+// reports whether the channel is archived; lookup failures are logged and
+// count as not archived.
+func (s *Service) pauseIfArchived(ctx context.Context, config *store.ChannelConfig) bool {
+	info, err := s.slackClient.GetChannelInfo(ctx, config.ChannelID)
+	if err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to get channel info", err,
+			botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		)
+		return false
+	}
+	if !info.IsArchived {
+		return false
+	}
+
+	if err := s.ArchiveChannel(ctx, config.TeamID, config.ChannelID); err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to archive channel", err,
+			botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		)
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package standup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestArchiveChannel(t *testing.T) {
+	s := newTestService(t)
+	ctx := s.ctx
+	require.NoError(t, s.data.SaveChannelConfig(ctx, &store.ChannelConfig{
+		TeamID:    "T1234567890",
+		ChannelID: "C1234567890",
+		Enabled:   true,
+		Schedule:  store.ScheduleConfig{Timezone: "UTC", ReminderTimes: []string{"09:00"}},
+	}))
+	activeChannels := func() int {
+		configs, err := s.data.ListActiveChannelConfigs(ctx)
+		require.NoError(t, err)
+		return len(configs)
+	}
+
+	// Channels without standups are ignored
+	require.NoError(t, s.ArchiveChannel(ctx, "T1234567890", "C9999999999"))
+
+	require.NoError(t, s.ArchiveChannel(ctx, "T1234567890", "C1234567890"))
+	assert.Zero(t, activeChannels())
+	require.NoError(t, s.UnarchiveChannel(ctx, "T1234567890", "C1234567890"))
+	assert.Equal(t, 1, activeChannels())
+
+	// Channels archived without an event are caught when looked up
+	config, err := s.data.GetChannelConfig(ctx, "T1234567890", "C1234567890")
+	require.NoError(t, err)
+	s.client.Channels["C1234567890"] = &slack.ConversationInfo{ID: "C1234567890"}
+	assert.False(t, s.pauseIfArchived(ctx, config))
+	s.client.Channels["C1234567890"].IsArchived = true
+	assert.True(t, s.pauseIfArchived(ctx, config))
+	assert.Zero(t, activeChannels())
+	config, err = s.data.GetChannelConfig(ctx, "T1234567890", "C1234567890")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), *config.ArchivedAt, time.Minute)
+	assert.True(t, config.Enabled)
+
+	// Deleted channels stay off even if unarchived
+	require.NoError(t, s.DisableChannel(ctx, "T1234567890", "C1234567890"))
+	require.NoError(t, s.UnarchiveChannel(ctx, "T1234567890", "C1234567890"))
+	assert.Zero(t, activeChannels())
+}
@@ -0,0 +1,117 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/gcal"
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// outOfOfficeReason is the reason of skips recorded from calendars.
+const outOfOfficeReason = "Out of office (Google Calendar)"
+
+// defaultStandupTime is checked against calendars when a channel has no
+// start or reminder times.
+const defaultStandupTime = "09:00"
+
+// standupTime returns when the channel's standup starts on date.
+func standupTime(config *store.ChannelConfig, date string) (time.Time, error) {
+	loc, err := time.LoadLocation(config.Schedule.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	start := sessionStartTime(&config.Schedule)
+	if start == "" {
+		start = defaultStandupTime
+	}
+
+	t, err := time.ParseInLocation("2006-01-02 15:04", date+" "+start, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid standup time: %w", err)
+	}
+	return t, nil
+}
+
+// SyncOutOfOffice records a skip on date for each of the channel's users who
+// have an out-of-office event in Google Calendar when the standup starts, so
+// they aren't counted missing. Users who already skipped are left alone.
+// It returns how many skips were recorded.
+func (s *Service) SyncOutOfOffice(ctx context.Context, config *store.ChannelConfig, date string) (int, error) {
+	integration := s.Config(ctx).Integrations().Calendar
+	if integration == nil {
+		return 0, nil
+	}
+
+	client, err := gcal.NewClient(integration.Credentials)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create calendar client: %w", err)
+	}
+
+	at, err := standupTime(config, date)
+	if err != nil {
+		return 0, err
+	}
+
+	skips, err := s.store.ListSkippedResponses(ctx, config.ChannelID, date)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list skipped responses: %w", err)
+	}
+	skipped := make(map[string]bool, len(skips))
+	for _, skip := range skips {
+		skipped[skip.UserID] = true
+	}
+
+	recorded := 0
+	var errs []error
+	for _, userID := range config.Users {
+		if skipped[userID] {
+			continue
+		}
+
+		user, err := s.slackClient.GetUserInfo(ctx, userID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get user %s: %w", security.SanitizeLogValue(userID), err))
+			continue
+		}
+		if user.Deleted || user.IsBot || user.Profile.Email == "" {
+			continue
+		}
+
+		out, err := client.IsOutOfOffice(ctx, user.Profile.Email, at)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to check calendar of %s: %w", security.SanitizeLogValue(userID), err))
+			continue
+		}
+		if !out {
+			continue
+		}
+
+		skip := &store.SkippedResponse{
+			ChannelID: config.ChannelID,
+			Date:      date,
+			UserID:    userID,
+			Reason:    outOfOfficeReason,
+			SkippedAt: time.Now(),
+		}
+		if err := s.store.SaveSkippedResponse(ctx, skip); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save skip: %w", err))
+			continue
+		}
+		recorded++
+	}
+
+	s.botCtx.Logger().Info(ctx, "Synced out-of-office calendars",
+		botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		botcontext.Field{Key: "date", Value: date},
+		botcontext.Field{Key: "skips", Value: recorded},
+		botcontext.Metric("OutOfOfficeSkips", float64(recorded)),
+	)
+
+	return recorded, errors.Join(errs...)
+}
@@ -0,0 +1,27 @@
+package standup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestStandupTime(t *testing.T) {
+	config := &store.ChannelConfig{Schedule: store.ScheduleConfig{
+		Timezone:      "America/New_York",
+		ReminderTimes: []string{"10:00", "09:30"},
+	}}
+
+	at, err := standupTime(config, "2024-03-04")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 4, 14, 30, 0, 0, time.UTC), at.UTC())
+
+	// Without a schedule the standup is assumed to start in the morning
+	at, err = standupTime(&store.ChannelConfig{}, "2024-03-04")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), at)
+}
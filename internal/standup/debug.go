@@ -0,0 +1,175 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// Diagnostics is a health check of the bot as seen from a channel, for
+// admins looking into reports such as reminders not going out.
+type Diagnostics struct {
+	ChannelID     string
+	ConfigVersion string
+	// Degraded are the capabilities the function started without, with why
+	Degraded      map[string]string
+	StoreLatency  time.Duration // Of a test query
+	StoreError    error
+	SlackIdentity *slack.AuthIdentity // Who the bot token belongs to
+	SlackError    error
+	Scheduler     *store.Heartbeat // nil if the scheduler never ran
+	Summary       *store.Heartbeat // The channel's daily summary; nil if never posted
+	// PendingTasks are the channel's scheduled tasks, soonest first
+	PendingTasks []*store.ScheduledRun
+}
+
+// WithDegraded reports the capabilities the function started without, keyed
+// by name with the reason, in diagnostics.
+func WithDegraded(degraded map[string]string) ServiceOption {
+	return func(s *Service) {
+		s.degraded = degraded
+	}
+}
+
+// Diagnose checks the bot's store and Slack connections, and reports how
+// the scheduler and the channel's scheduled tasks are doing. Failed checks
+// are reported in the result rather than returned.
+func (s *Service) Diagnose(ctx context.Context, channelID string) *Diagnostics {
+	d := &Diagnostics{ChannelID: channelID, ConfigVersion: s.Config(ctx).Version(), Degraded: s.degraded}
+
+	// Listing the channel's scheduled runs is the test query
+	start := time.Now()
+	runs, err := s.store.ListScheduledRuns(ctx, channelID)
+	d.StoreLatency, d.StoreError = time.Since(start), err
+	d.PendingTasks = slices.SortedFunc(slices.Values(runs), func(a, b *store.ScheduledRun) int {
+		return a.NextRunAt.Compare(b.NextRunAt)
+	})
+
+	d.SlackIdentity, d.SlackError = s.slackClient.AuthTest(ctx)
+
+	// The scheduler's heartbeat belongs to the deployment
+	d.Scheduler = s.diagnosticHeartbeat(ctx, "", store.HeartbeatScheduler, "")
+	d.Summary = s.diagnosticHeartbeat(ctx, store.TeamScope(ctx), store.HeartbeatSummary, channelID)
+
+	return d
+}
+
+// diagnosticHeartbeat returns a job's heartbeat, or nil if it has none or
+// it can't be read.
+func (s *Service) diagnosticHeartbeat(ctx context.Context, teamID, job, channelID string) *store.Heartbeat {
+	heartbeat, err := s.store.GetHeartbeat(ctx, teamID, job, channelID)
+	if err != nil {
+		if err != store.ErrNotFound {
+			s.botCtx.Logger().Error(ctx, "Failed to get heartbeat", err,
+				botcontext.Field{Key: "job", Value: job},
+			)
+		}
+		return nil
+	}
+	return heartbeat
+}
+
+// DiagnosticsText formats diagnostics as a Slack message, with times
+// relative to now.
+func DiagnosticsText(d *Diagnostics, now time.Time) string {
+	lines := []string{"*Standup bot diagnostics*"}
+
+	version := d.ConfigVersion
+	if version == "" {
+		version = "unset"
+	}
+	lines = append(lines, fmt.Sprintf("• Config version: `%s`", version))
+
+	for _, capability := range slices.Sorted(maps.Keys(d.Degraded)) {
+		lines = append(lines, fmt.Sprintf("• Degraded: :warning: started without `%s`: `%s`",
+			capability, d.Degraded[capability]))
+	}
+
+	if d.StoreError != nil {
+		lines = append(lines, fmt.Sprintf("• Store: :x: `%s`", d.StoreError))
+	} else {
+		lines = append(lines, fmt.Sprintf("• Store: :white_check_mark: answered in %s",
+			d.StoreLatency.Round(time.Millisecond)))
+	}
+
+	if d.SlackError != nil {
+		lines = append(lines, fmt.Sprintf("• Slack: :x: `%s`", d.SlackError))
+	} else {
+		lines = append(lines, fmt.Sprintf("• Slack: :white_check_mark: authenticated as %s (%s) in %s (%s)",
+			d.SlackIdentity.User, d.SlackIdentity.UserID, d.SlackIdentity.Team, d.SlackIdentity.TeamID))
+	}
+
+	lines = append(lines, "• Scheduler: "+heartbeatText(d.Scheduler, "hasn't run", now))
+	lines = append(lines, "• Daily summary: "+heartbeatText(d.Summary, "hasn't been posted", now))
+
+	lines = append(lines, "*Scheduled tasks*")
+	if len(d.PendingTasks) == 0 {
+		lines = append(lines, "None yet; the scheduler records them on its first run.")
+	} else {
+		for _, run := range d.PendingTasks {
+			line := fmt.Sprintf("• `%s` at %s (%s)", run.Task,
+				run.NextRunAt.In(runLocation(run)).Format("2006-01-02 15:04 MST"), relativeTime(run.NextRunAt, now))
+			// Runs move to their next occurrence as they happen, so a past
+			// one didn't run; daily tasks don't run on days off
+			if run.NextRunAt.Before(now.Add(-time.Minute)) {
+				line += " :warning: hasn't run (expected on days off)"
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// heartbeatText describes a job's last run, or never if it has none.
+func heartbeatText(heartbeat *store.Heartbeat, never string, now time.Time) string {
+	if heartbeat == nil {
+		return ":warning: " + never
+	}
+
+	lastSuccess := "never succeeded"
+	if !heartbeat.LastSuccessAt.IsZero() {
+		lastSuccess = "last succeeded " + relativeTime(heartbeat.LastSuccessAt, now)
+	}
+	if heartbeat.Failures > 0 {
+		return fmt.Sprintf(":x: %s, then failed %d times in a row: `%s`",
+			lastSuccess, heartbeat.Failures, heartbeat.LastError)
+	}
+	return ":white_check_mark: " + lastSuccess
+}
+
+// runLocation returns the timezone a run was scheduled in, or UTC if it
+// can't be loaded.
+func runLocation(run *store.ScheduledRun) *time.Location {
+	loc, err := time.LoadLocation(run.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// relativeTime describes t as a duration from now, e.g. "5m ago" or
+// "in 2h".
+func relativeTime(t, now time.Time) string {
+	d := t.Sub(now).Round(time.Minute)
+	switch {
+	case d > 0:
+		return "in " + shortDuration(d)
+	case d < 0:
+		return shortDuration(-d) + " ago"
+	default:
+		return "now"
+	}
+}
+
+// shortDuration formats d in whole minutes, e.g. "1h5m" or "12m".
+func shortDuration(d time.Duration) string {
+	return strings.TrimSuffix(d.String(), "0s")
+}
@@ -0,0 +1,70 @@
+package standup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestDiagnose(t *testing.T) {
+	s := newTestService(t, withConfig(`version: "1.2"
+channels: []
+`))
+	ctx := s.ctx
+	s.client.Identity = slack.AuthIdentity{Team: "Acme", User: "standup-bot", TeamID: "T1234567890", UserID: "U0000000000"}
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	// A deployment whose scheduler never ran
+	d := s.Diagnose(ctx, "C1234567890")
+	assert.Equal(t, "1.2", d.ConfigVersion)
+	assert.NoError(t, d.StoreError)
+	assert.Equal(t, "U0000000000", d.SlackIdentity.UserID)
+	assert.Nil(t, d.Scheduler)
+	text := DiagnosticsText(d, now)
+	assert.Contains(t, text, "Config version: `1.2`")
+	assert.Contains(t, text, "authenticated as standup-bot (U0000000000) in Acme (T1234567890)")
+	assert.Contains(t, text, "Scheduler: :warning: hasn't run")
+	assert.Contains(t, text, "None yet")
+	assert.NotContains(t, text, "Degraded")
+
+	require.NoError(t, s.data.SaveHeartbeat(ctx, &store.Heartbeat{
+		Job:           store.HeartbeatScheduler,
+		LastSuccessAt: now.Add(-2 * time.Minute),
+	}))
+	require.NoError(t, s.data.SaveHeartbeat(ctx, &store.Heartbeat{
+		Job:           store.HeartbeatSummary,
+		TeamID:        "T1234567890",
+		ChannelID:     "C1234567890",
+		LastSuccessAt: now.Add(-47 * time.Hour),
+		Failures:      2,
+		LastError:     "not_in_channel",
+	}))
+	for _, run := range []*store.ScheduledRun{
+		{ChannelID: "C1234567890", Task: "summary", Clock: "10:00", Timezone: "UTC", NextRunAt: now.Add(time.Hour)},
+		{ChannelID: "C1234567890", Task: "reminder#08:30", Clock: "08:30", Timezone: "UTC",
+			NextRunAt: now.Add(-30 * time.Minute)},
+	} {
+		require.NoError(t, s.data.SaveScheduledRun(ctx, run))
+	}
+	s.client.FailNextWithCode("auth.test", "invalid_auth")
+
+	d = s.Diagnose(ctx, "C1234567890")
+	require.Len(t, d.PendingTasks, 2)
+	assert.Equal(t, "reminder#08:30", d.PendingTasks[0].Task)
+	text = DiagnosticsText(d, now)
+	assert.Contains(t, text, "Slack: :x: `slack API error: invalid_auth`")
+	assert.Contains(t, text, "Scheduler: :white_check_mark: last succeeded 2m ago")
+	assert.Contains(t, text, "Daily summary: :x: last succeeded 47h0m ago, then failed 2 times in a row: `not_in_channel`")
+	assert.Contains(t, text, "• `reminder#08:30` at 2026-10-16 08:30 UTC (30m ago) :warning: hasn't run")
+	assert.Contains(t, text, "• `summary` at 2026-10-16 10:00 UTC (in 1h0m)")
+
+	// A function that started without Secrets Manager
+	s.Service = NewService(s.botCtx, s.data, s.client, WithDegraded(map[string]string{"secrets": "access denied"}))
+	text = DiagnosticsText(s.Diagnose(ctx, "C1234567890"), now)
+	assert.Contains(t, text, "• Degraded: :warning: started without `secrets`: `access denied`")
+}
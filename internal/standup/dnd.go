@@ -0,0 +1,113 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/notify"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// errReminderDeferred is returned when a user's reminder is put off until
+// their Do Not Disturb hours or paused notifications end.
+var errReminderDeferred = errors.New("reminder deferred")
+
+// deferForDND puts off a DM reminder to a user in Do Not Disturb, with the
+// respect_dnd feature. The reminder is sent by the scheduler once the user's
+// notifications resume, like a snoozed one, and isn't sent if they've
+// submitted by then. If that can't be saved, Slack is asked to post the
+// reminder then instead. Users unavailable for the rest of the day aren't
+// reminded. It returns errReminderDeferred unless the reminder is to be sent
+// now.
+func (s *Service) deferForDND(ctx context.Context, reminder *notify.Reminder, reminderTime string) error {
+	logger := s.botCtx.Logger()
+	userField := botcontext.Field{Key: "user_id", Value: reminder.UserID}
+
+	status, err := s.slackClient.GetDNDStatus(ctx, reminder.UserID)
+	if err != nil {
+		// Such as installs without the dnd:read scope; remind them anyway
+		logger.Warn(ctx, "Failed to get Do Not Disturb status", userField,
+			botcontext.Field{Key: "error", Value: err.Error()})
+		return nil
+	}
+
+	now := s.now()
+	availableAt := status.AvailableAt(now)
+	if !availableAt.After(now) {
+		return nil
+	}
+
+	today := s.channelDate(ctx, reminder.ChannelID, now)
+	if s.channelDate(ctx, reminder.ChannelID, availableAt) != today {
+		logger.Info(ctx, "Not reminding user in Do Not Disturb for the rest of the day", userField)
+		return errReminderDeferred
+	}
+
+	// Keyed on the reminder's own time slot, so deferring it again while
+	// the user is still unavailable replaces it
+	record := &store.Reminder{
+		ChannelID:    reminder.ChannelID,
+		Date:         today,
+		UserID:       reminder.UserID,
+		Time:         reminderTime,
+		SentAt:       now,
+		SnoozedUntil: &availableAt,
+	}
+	err = s.store.SaveReminder(ctx, record)
+	if err == nil {
+		if err := s.scheduleSnooze(ctx, reminder.ChannelID, reminder.UserID, availableAt); err != nil {
+			logger.Error(ctx, "Failed to schedule deferred reminder", err,
+				botcontext.Field{Key: "channel_id", Value: reminder.ChannelID},
+			)
+		}
+		return errReminderDeferred
+	}
+	logger.Error(ctx, "Failed to defer reminder, scheduling its message instead", err, userField)
+
+	dmChannel, err := s.slackClient.OpenDM(ctx, reminder.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to open DM: %w", err)
+	}
+	if _, err := s.slackClient.ScheduleMessage(ctx, dmChannel, availableAt,
+		slack.WithText(scheduledReminderText(reminder.ChannelID)), slack.WithBlocks(reminder.Blocks()...)); err != nil {
+		return fmt.Errorf("failed to schedule reminder: %w", err)
+	}
+	return errReminderDeferred
+}
+
+// scheduledReminderText is the text of reminders Slack is asked to post,
+// which tells them apart from other scheduled messages.
+func scheduledReminderText(channelID string) string {
+	return fmt.Sprintf("Standup reminder for <#%s>", channelID)
+}
+
+// cancelScheduledReminders deletes the reminders Slack was asked to post to
+// a user for a channel, once they've submitted or skipped, with the
+// respect_dnd feature.
+func (s *Service) cancelScheduledReminders(ctx context.Context, channelID, userID string) error {
+	if !s.Config(ctx).IsFeatureEnabled("respect_dnd") {
+		return nil
+	}
+
+	dmChannel, err := s.slackClient.OpenDM(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to open DM: %w", err)
+	}
+	messages, err := s.slackClient.ListScheduledMessages(ctx, dmChannel)
+	if err != nil {
+		return fmt.Errorf("failed to list scheduled messages: %w", err)
+	}
+
+	for _, message := range messages {
+		if message.Text != scheduledReminderText(channelID) {
+			continue
+		}
+		if err := s.slackClient.DeleteScheduledMessage(ctx, dmChannel, message.ID); err != nil {
+			return fmt.Errorf("failed to delete scheduled reminder: %w", err)
+		}
+	}
+	return nil
+}
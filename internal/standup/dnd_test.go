@@ -0,0 +1,103 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// unsavedRemindersStore fails to save reminders.
+type unsavedRemindersStore struct {
+	store.Store
+}
+
+func (s *unsavedRemindersStore) SaveReminder(ctx context.Context, reminder *store.Reminder) error {
+	return errors.New("table unavailable")
+}
+
+func TestDeferForDND(t *testing.T) {
+	s := newTestService(t, withConfig(`version: "1.0"
+features:
+  respect_dnd: true
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+`))
+	ctx := s.ctx
+	now := time.Now()
+	today := now.Format("2006-01-02")
+	snoozeEnd := now.Add(time.Minute).Truncate(time.Second)
+	if snoozeEnd.Format("2006-01-02") != today {
+		t.Skip("the snooze would end tomorrow")
+	}
+	config := &store.ChannelConfig{TeamID: "T1234567890", ChannelID: "C1234567890", ChannelName: "engineering"}
+
+	s.client.AddUser(&slack.UserInfo{ID: "U1111111111", Name: "alice"})
+
+	// Paused notifications defer the reminder until they resume
+	s.client.DND["U1111111111"] = &slack.DNDStatus{SnoozeEnabled: true, SnoozeEndTime: snoozeEnd.Unix()}
+	err := s.sendReminderToUser(ctx, "U1111111111", "C1234567890", "engineering", "09:30", store.DeliverDM, "")
+	assert.ErrorIs(t, err, errReminderDeferred)
+	assert.Empty(t, s.client.Calls("chat.postMessage"))
+	reminders, err := s.data.ListReminders(ctx, "C1234567890", today)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	require.NotNil(t, reminders[0].SnoozedUntil)
+	assert.True(t, reminders[0].SnoozedUntil.Equal(snoozeEnd))
+
+	// Still snoozed when due, it's deferred again rather than sent
+	require.NoError(t, s.SendDueSnoozedReminders(ctx, config, snoozeEnd))
+	assert.Empty(t, s.client.Calls("chat.postMessage"))
+
+	// Once they're back, the deferred reminder goes out
+	delete(s.client.DND, "U1111111111")
+	require.NoError(t, s.SendDueSnoozedReminders(ctx, config, snoozeEnd))
+	posted := s.client.Calls("chat.postMessage")
+	require.Len(t, posted, 1)
+	assert.Equal(t, "DU1111111111", posted[0].Channel)
+	reminders, err = s.data.ListReminders(ctx, "C1234567890", today)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Nil(t, reminders[0].SnoozedUntil)
+
+	// Without a reminder record, Slack is asked to post it when they're back
+	s.client.Reset()
+	s.client.DND["U1111111111"] = &slack.DNDStatus{
+		DNDEnabled:     true,
+		NextDNDStartTS: now.Add(-time.Hour).Unix(),
+		NextDNDEndTS:   snoozeEnd.Unix(),
+	}
+	s.Service = NewService(s.botCtx, &unsavedRemindersStore{Store: s.data}, s.client)
+	err = s.sendReminderToUser(ctx, "U1111111111", "C1234567890", "engineering", "11:00", store.DeliverDM, "")
+	assert.ErrorIs(t, err, errReminderDeferred)
+	scheduled := s.client.Calls("chat.scheduleMessage")
+	require.Len(t, scheduled, 1)
+	assert.Equal(t, "DU1111111111", scheduled[0].Channel)
+	assert.Equal(t, strconv.FormatInt(snoozeEnd.Unix(), 10), scheduled[0].Timestamp)
+	assert.Empty(t, s.client.Calls("chat.postMessage"))
+
+	// Skipping cancels it
+	require.NoError(t, s.SkipToday(ctx, "C1234567890", "U1111111111", "out sick"))
+	messages, err := s.client.ListScheduledMessages(ctx, "DU1111111111")
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+
+	// The reminder goes out as usual if the status can't be read
+	s.client.Reset()
+	s.client.FailNextWithCode("dnd.info", "missing_scope")
+	s.Service = NewService(s.botCtx, s.data, s.client)
+	err = s.sendReminderToUser(ctx, "U1111111111", "C1234567890", "engineering", "11:00", store.DeliverDM, "")
+	require.NoError(t, err)
+	assert.Len(t, s.client.Calls("chat.postMessage"), 1)
+}
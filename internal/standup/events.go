@@ -0,0 +1,136 @@
+package standup
+
+import (
+	"context"
+	"time"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/notify"
+	"github.com/synaptiq/standup-bot/internal/outbound"
+	"github.com/synaptiq/standup-bot/internal/queue"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// WithEventPublisher publishes submitted responses, posted summaries and
+// sent reminders to external systems. A nil publisher publishes nothing.
+func WithEventPublisher(publisher *outbound.Publisher) ServiceOption {
+	return func(s *Service) {
+		s.events = publisher
+	}
+}
+
+// eventDeliveryTimeout bounds delivering an event in the background,
+// retries included.
+const eventDeliveryTimeout = time.Minute
+
+// publish publishes an event about a channel without waiting on its
+// subscribers, which may be slow to answer or retried: the event is queued
+// for the processor when there's a task queue, and otherwise delivered in
+// the background.
+func (s *Service) publish(ctx context.Context, eventType, channelID string, data any) {
+	if s.events == nil {
+		return
+	}
+
+	event := s.events.NewEvent(eventType, store.TeamScope(ctx), channelID, data)
+	if s.tasks != nil {
+		err := s.tasks.Send(ctx, &queue.Task{
+			Type:      queue.TaskPublishEvent,
+			TeamID:    event.TeamID,
+			ChannelID: channelID,
+			Payload:   map[string]interface{}{"event": event},
+		})
+		if err == nil {
+			return
+		}
+		s.botCtx.Logger().Warn(ctx, "Failed to queue event, delivering it in the background",
+			botcontext.Field{Key: "event_type", Value: eventType},
+			botcontext.Field{Key: "error", Value: err.Error()},
+		)
+	}
+
+	s.delivering.Add(1)
+	go func() {
+		defer s.delivering.Done()
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), eventDeliveryTimeout)
+		defer cancel()
+		s.DeliverEvent(ctx, event)
+	}()
+}
+
+// DeliverEvent delivers an event to its subscribers, retrying failed
+// deliveries, e.g. one queued by another function. Failures are logged,
+// since what the event reports already happened.
+func (s *Service) DeliverEvent(ctx context.Context, event *outbound.Event) {
+	if s.events == nil {
+		s.botCtx.Logger().Warn(ctx, "Dropping event without outbound webhooks configured",
+			botcontext.Field{Key: "event_type", Value: event.Type},
+		)
+		return
+	}
+
+	if err := s.events.Deliver(ctx, event); err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to publish event", err,
+			botcontext.Field{Key: "event_type", Value: event.Type},
+			botcontext.Field{Key: "channel_id", Value: event.ChannelID},
+			botcontext.Metric("EventDeliveryFailures", 1),
+		)
+	}
+}
+
+// FlushEvents waits for the events being delivered in the background, so a
+// function can finish delivering them before it returns and is frozen.
+func (s *Service) FlushEvents() {
+	s.delivering.Wait()
+}
+
+// responseSubmittedData describes a saved submission. Answers are only
+// included for public channels.
+func (s *Service) responseSubmittedData(
+	ctx context.Context,
+	submission *Submission,
+	submittedAt time.Time,
+	late, public bool,
+) *outbound.ResponseSubmittedData {
+	data := &outbound.ResponseSubmittedData{
+		UserID:      submission.UserID,
+		UserName:    submission.UserName,
+		Date:        submission.Date,
+		SubmittedAt: submittedAt.UTC().Format(time.RFC3339),
+		Late:        late,
+	}
+
+	channel, found := s.Config(ctx).ChannelByID(submission.ChannelID)
+	if !public || !found {
+		return data
+	}
+	questions := questionTexts(questionsOn(channel, submission.Date))
+	for _, answer := range summaryAnswers(questions, submission.Responses) {
+		data.Answers = append(data.Answers, outbound.Answer{Question: answer.Question, Text: answer.Text})
+	}
+	return data
+}
+
+// summaryPostedData describes a posted summary, listing each user's status.
+func summaryPostedData(summary *notify.Summary) *outbound.SummaryPostedData {
+	data := &outbound.SummaryPostedData{
+		Date:  summary.Date,
+		Users: make([]outbound.SummaryUser, 0, len(summary.Users)),
+	}
+	for _, user := range summary.Users {
+		status := "pending"
+		switch {
+		case user.Submitted:
+			status = "submitted"
+		case user.Skipped:
+			status = "skipped"
+		}
+		data.Users = append(data.Users, outbound.SummaryUser{
+			UserID:     user.UserID,
+			UserName:   user.UserName,
+			Status:     status,
+			SkipReason: user.SkipReason,
+		})
+	}
+	return data
+}
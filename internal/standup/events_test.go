@@ -0,0 +1,163 @@
+package standup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/outbound"
+	"github.com/synaptiq/standup-bot/internal/queue"
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+// eventsConfig configures a channel with two users and two questions.
+const eventsConfig = `version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+    users:
+      - id: "U1111111111"
+        name: "alice"
+      - id: "U2222222222"
+        name: "bob"
+    questions: ["Yesterday?", "Today?"]
+`
+
+func TestPublishesEvents(t *testing.T) {
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		events = append(events, event)
+	}))
+	defer server.Close()
+
+	publisher := outbound.NewPublisher([]outbound.Endpoint{{URL: server.URL}}, "secret")
+	s := newTestService(t, withConfig(eventsConfig), withServiceOptions(WithEventPublisher(publisher)))
+	ctx := s.ctx
+	today := time.Now().Format("2006-01-02")
+
+	require.NoError(t, s.SubmitStandupResponse(ctx, &Submission{
+		ChannelID: "C1234567890",
+		Date:      today,
+		UserID:    "U1111111111",
+		UserName:  "alice",
+		Responses: map[string]string{"question_0": "Reviews", "question_1": "Releases"},
+		Answers: map[string]slack.Answer{
+			"question_0": {ElementType: "plain_text_input", Value: "Reviews"},
+			"question_1": {ElementType: "plain_text_input", Value: "Releases"},
+		},
+	}))
+	s.FlushEvents()
+	require.NoError(t, s.PostSummaryNow(ctx, "C1234567890", false))
+	s.FlushEvents()
+
+	require.Len(t, events, 2)
+	assert.Equal(t, outbound.EventResponseSubmitted, events[0]["type"])
+	assert.Equal(t, "T1234567890", events[0]["team_id"])
+	assert.Equal(t, "C1234567890", events[0]["channel_id"])
+	submitted := events[0]["data"].(map[string]any)
+	assert.Equal(t, "U1111111111", submitted["user_id"])
+	assert.Equal(t, []any{
+		map[string]any{"question": "Yesterday?", "text": "Reviews"},
+		map[string]any{"question": "Today?", "text": "Releases"},
+	}, submitted["answers"])
+
+	assert.Equal(t, outbound.EventSummaryPosted, events[1]["type"])
+	summary := events[1]["data"].(map[string]any)
+	assert.ElementsMatch(t, []any{
+		map[string]any{"user_id": "U1111111111", "user_name": "alice", "status": "submitted"},
+		map[string]any{"user_id": "U2222222222", "user_name": "bob", "status": "pending"},
+	}, summary["users"])
+}
+
+func TestPublishDoesNotWaitForSubscribers(t *testing.T) {
+	release := make(chan struct{})
+	var delivered atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		delivered.Add(1)
+	}))
+	defer server.Close()
+
+	publisher := outbound.NewPublisher([]outbound.Endpoint{{URL: server.URL}}, "secret")
+	s := newTestService(t, withConfig(eventsConfig), withServiceOptions(WithEventPublisher(publisher)))
+
+	start := time.Now()
+	require.NoError(t, s.SubmitStandupResponse(s.ctx, &Submission{
+		ChannelID: "C1234567890",
+		Date:      time.Now().Format("2006-01-02"),
+		UserID:    "U1111111111",
+		UserName:  "alice",
+		Responses: map[string]string{"question_0": "Reviews", "question_1": "Releases"},
+		Answers: map[string]slack.Answer{
+			"question_0": {ElementType: "plain_text_input", Value: "Reviews"},
+			"question_1": {ElementType: "plain_text_input", Value: "Releases"},
+		},
+	}))
+	assert.Less(t, time.Since(start), time.Second, "submitting waited on the subscriber")
+	assert.Zero(t, delivered.Load())
+
+	close(release)
+	s.FlushEvents()
+	assert.EqualValues(t, 1, delivered.Load())
+}
+
+// fakeSQS records the messages sent to it.
+type fakeSQS struct {
+	bodies []string
+}
+
+func (f *fakeSQS) SendMessage(
+	_ context.Context,
+	params *sqs.SendMessageInput,
+	_ ...func(*sqs.Options),
+) (*sqs.SendMessageOutput, error) {
+	f.bodies = append(f.bodies, *params.MessageBody)
+	return &sqs.SendMessageOutput{}, nil
+}
+
+func TestPublishQueuesEventsForProcessor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("event delivered instead of queued")
+	}))
+	defer server.Close()
+
+	sqsClient := &fakeSQS{}
+	publisher := outbound.NewPublisher([]outbound.Endpoint{{URL: server.URL}}, "secret")
+	s := newTestService(t, withConfig(eventsConfig), withServiceOptions(
+		WithEventPublisher(publisher), WithTaskQueue(queue.NewSender(sqsClient, "queue-url"))))
+
+	require.NoError(t, s.SubmitStandupResponse(s.ctx, &Submission{
+		ChannelID: "C1234567890",
+		Date:      time.Now().Format("2006-01-02"),
+		UserID:    "U1111111111",
+		UserName:  "alice",
+		Responses: map[string]string{"question_0": "Reviews", "question_1": "Releases"},
+		Answers: map[string]slack.Answer{
+			"question_0": {ElementType: "plain_text_input", Value: "Reviews"},
+			"question_1": {ElementType: "plain_text_input", Value: "Releases"},
+		},
+	}))
+	s.FlushEvents()
+
+	require.Len(t, sqsClient.bodies, 1)
+	var task queue.Task
+	require.NoError(t, json.Unmarshal([]byte(sqsClient.bodies[0]), &task))
+	assert.Equal(t, queue.TaskPublishEvent, task.Type)
+	event := task.Payload["event"].(map[string]any)
+	assert.Equal(t, outbound.EventResponseSubmitted, event["type"])
+	assert.Equal(t, "C1234567890", event["channel_id"])
+}
@@ -0,0 +1,197 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/github"
+	"github.com/synaptiq/standup-bot/internal/i18n"
+	"github.com/synaptiq/standup-bot/internal/queue"
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// maxActivityLines caps how many pull requests and commits are suggested.
+const maxActivityLines = 20
+
+// WithTaskQueue queues slow work started by interactions, like prefilling
+// the standup modal from GitHub, for the processor. Without it that work
+// is skipped, and events are delivered in the background instead.
+func WithTaskQueue(tasks *queue.Sender) ServiceOption {
+	return func(s *Service) {
+		s.tasks = tasks
+	}
+}
+
+// githubLogin returns the GitHub login of a user when the GitHub integration
+// is configured for them.
+func githubLogin(cfg botconfig.Config, userID string) (*botconfig.GitHubIntegration, string, bool) {
+	integration := cfg.Integrations().GitHub
+	if integration == nil {
+		return nil, "", false
+	}
+	login := integration.Users[userID]
+	return integration, login, login != ""
+}
+
+// activityQuestionIndex returns the index of the text question asking what
+// was done yesterday, or -1 when the channel doesn't ask one.
+func activityQuestionIndex(questions []botconfig.Question) int {
+	for i, question := range questions {
+		switch question.Type {
+		case "", botconfig.QuestionText:
+		default:
+			continue
+		}
+		if strings.Contains(strings.ToLower(question.Text), "yesterday") {
+			return i
+		}
+	}
+	return -1
+}
+
+// activitySince returns the start of the channel's previous standup day
+// before date, so Monday's standup covers Friday when weekends are off.
+func activitySince(channel botconfig.ChannelConfig, date string) (time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, channel.Timezone())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %w", err)
+	}
+
+	for i := 0; i < 7; i++ {
+		day = day.AddDate(0, 0, -1)
+		if channel.IsActiveDay(day.Weekday()) {
+			break
+		}
+	}
+	return day, nil
+}
+
+// formatActivity formats pull requests and commits as a suggested answer.
+// Commits with the same title as a merged pull request are left out.
+func formatActivity(pullRequests, commits []github.Item) string {
+	seen := make(map[string]bool)
+	var lines []string
+	for _, pr := range pullRequests {
+		seen[pr.Repo+"\x00"+pr.Title] = true
+		lines = append(lines, fmt.Sprintf("• Merged %s in %s (%s)", pr.Title, pr.Repo, pr.URL))
+	}
+	for _, commit := range commits {
+		if seen[commit.Repo+"\x00"+commit.Title] {
+			continue
+		}
+		seen[commit.Repo+"\x00"+commit.Title] = true
+		lines = append(lines, fmt.Sprintf("• %s in %s", commit.Title, commit.Repo))
+	}
+
+	if len(lines) > maxActivityLines {
+		more := len(lines) - maxActivityLines
+		lines = append(lines[:maxActivityLines], fmt.Sprintf("• …and %d more", more))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// queueGitHubPrefill queues prefilling the modal with the given external ID
+// from the user's GitHub activity, when the integration is set up for them.
+func (s *Service) queueGitHubPrefill(
+	ctx context.Context,
+	channel botconfig.ChannelConfig,
+	session *store.Session,
+	userID, externalID string,
+	locale i18n.Locale,
+) error {
+	if _, _, ok := githubLogin(s.Config(ctx), userID); !ok {
+		return nil
+	}
+	if activityQuestionIndex(questionsOn(channel, session.Date)) < 0 {
+		return nil
+	}
+
+	return s.tasks.Send(ctx, &queue.Task{
+		Type:      queue.TaskPrefillStandup,
+		TeamID:    store.TeamScope(ctx),
+		ChannelID: channel.ID(),
+		UserID:    userID,
+		Payload: map[string]interface{}{
+			"external_id": externalID,
+			"session_id":  session.SessionID,
+			"date":        session.Date,
+			"locale":      string(locale),
+		},
+	})
+}
+
+// PrefillStandupModal suggests an answer to "What did you do yesterday?" in
+// an open standup modal from the user's merged pull requests and commits in
+// the repos of the GitHub integration.
+func (s *Service) PrefillStandupModal(ctx context.Context, externalID string, metadata *slack.StandupModalMetadata) error {
+	cfg := s.Config(ctx)
+	integration, login, ok := githubLogin(cfg, metadata.UserID)
+	if !ok {
+		return nil
+	}
+
+	channel, found := cfg.ChannelByID(metadata.ChannelID)
+	if !found {
+		return fmt.Errorf("channel not configured: %s", security.SanitizeLogValue(metadata.ChannelID))
+	}
+
+	questions := questionsOn(channel, metadata.Date)
+	index := activityQuestionIndex(questions)
+	if index < 0 {
+		return nil
+	}
+
+	since, err := activitySince(channel, metadata.Date)
+	if err != nil {
+		return err
+	}
+
+	client := github.NewClient(integration.Token)
+	pullRequests, err := client.MergedPullRequests(ctx, login, integration.Repos, since)
+	if err != nil {
+		return fmt.Errorf("failed to get pull requests: %w", err)
+	}
+
+	var commits []github.Item
+	var errs []error
+	for _, repo := range integration.Repos {
+		items, err := client.Commits(ctx, repo, login, since)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get commits of %s: %w", repo, err))
+			continue
+		}
+		commits = append(commits, items...)
+	}
+	if len(errs) > 0 {
+		// Suggest what could be read
+		s.botCtx.Logger().Error(ctx, "Failed to get GitHub commits", errors.Join(errs...))
+	}
+
+	activity := formatActivity(pullRequests, commits)
+	if activity == "" {
+		return nil
+	}
+
+	// The task carries only the modal's identity, so its plan is found again
+	metadata.Plan = s.previousPlan(ctx, channel, metadata.UserID, metadata.Date)
+	modal := slack.BuildPrefilledStandupModal(metadata, questions, map[string]string{
+		slack.QuestionBlockID(index): activity,
+	})
+	modal.ExternalID = externalID
+	if err := s.slackClient.UpdateModalByExternalID(ctx, externalID, modal); err != nil {
+		return fmt.Errorf("failed to update modal: %w", err)
+	}
+
+	s.botCtx.Logger().Info(ctx, "Prefilled standup from GitHub",
+		botcontext.Field{Key: "pull_requests", Value: len(pullRequests)},
+		botcontext.Field{Key: "commits", Value: len(commits)},
+	)
+	return nil
+}
@@ -0,0 +1,41 @@
+package standup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/github"
+)
+
+func TestActivityQuestionIndex(t *testing.T) {
+	questions := []botconfig.Question{
+		{Text: "Were you blocked yesterday?", Type: botconfig.QuestionYesNo},
+		{Text: "What did you do yesterday?", Type: botconfig.QuestionText},
+		{Text: "What will you do today?"},
+	}
+	assert.Equal(t, 1, activityQuestionIndex(questions))
+	assert.Equal(t, -1, activityQuestionIndex(questions[2:]))
+}
+
+func TestFormatActivity(t *testing.T) {
+	pullRequests := []github.Item{{Repo: "acme/api", Title: "Add rate limiting", URL: "https://github.com/acme/api/pull/42"}}
+	commits := []github.Item{
+		{Repo: "acme/api", Title: "Add rate limiting"},
+		{Repo: "acme/web", Title: "Fix login redirect"},
+	}
+
+	assert.Equal(t, "• Merged Add rate limiting in acme/api (https://github.com/acme/api/pull/42)\n"+
+		"• Fix login redirect in acme/web", formatActivity(pullRequests, commits))
+	assert.Empty(t, formatActivity(nil, nil))
+
+	many := make([]github.Item, maxActivityLines+3)
+	for i := range many {
+		many[i] = github.Item{Repo: "acme/api", Title: strings.Repeat("x", i+1)}
+	}
+	lines := strings.Split(formatActivity(nil, many), "\n")
+	assert.Len(t, lines, maxActivityLines+1)
+	assert.Equal(t, "• …and 3 more", lines[maxActivityLines])
+}
@@ -0,0 +1,104 @@
+package standup
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/slack/slacktest"
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/store/memory"
+)
+
+// testTeamID is the workspace tests run in.
+const testTeamID = "T1234567890"
+
+// testConfig is the config services under test get unless they need their
+// own: one channel, without users or questions.
+const testConfig = `version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+`
+
+// testService is a Service backed by a memory store and a fake Slack client.
+type testService struct {
+	*Service
+	ctx    context.Context // Scoped to testTeamID
+	data   store.Store     // The memory store, before any wrapping
+	client *slacktest.Client
+	logs   *bytes.Buffer // Errors the service logged
+}
+
+// testOptions configure newTestService.
+type testOptions struct {
+	config    string
+	wrapStore func(store.Store) store.Store
+	service   []ServiceOption
+}
+
+// testOption configures newTestService.
+type testOption func(*testOptions)
+
+// withConfig replaces testConfig with a YAML config.
+func withConfig(yaml string) testOption {
+	return func(o *testOptions) {
+		o.config = yaml
+	}
+}
+
+// withStore wraps the memory store the service uses, e.g. to fail some
+// calls.
+func withStore(wrap func(store.Store) store.Store) testOption {
+	return func(o *testOptions) {
+		o.wrapStore = wrap
+	}
+}
+
+// withServiceOptions passes options to NewService.
+func withServiceOptions(opts ...ServiceOption) testOption {
+	return func(o *testOptions) {
+		o.service = append(o.service, opts...)
+	}
+}
+
+// newTestService creates a service for tests.
+func newTestService(t *testing.T, opts ...testOption) *testService {
+	t.Helper()
+
+	options := testOptions{config: testConfig}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	cfg, err := botconfig.ParseYAML([]byte(options.config))
+	require.NoError(t, err)
+	var logs bytes.Buffer
+	botCtx, err := botcontext.New(botcontext.Options{
+		Config: cfg,
+		Logger: botcontext.NewLogger(&logs, botcontext.LevelError, ""),
+	})
+	require.NoError(t, err)
+
+	data := memory.NewStore()
+	var dataStore store.Store = data
+	if options.wrapStore != nil {
+		dataStore = options.wrapStore(data)
+	}
+	client := slacktest.New()
+
+	return &testService{
+		Service: NewService(botCtx, dataStore, client, options.service...),
+		ctx:     context.WithValue(context.Background(), botcontext.TeamIDKey, testTeamID),
+		data:    data,
+		client:  client,
+		logs:    &logs,
+	}
+}
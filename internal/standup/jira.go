@@ -0,0 +1,79 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/jira"
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+// issueKeys returns the Jira issues mentioned in responses, in question order.
+func issueKeys(integration *botconfig.JiraIntegration, questions []botconfig.Question, responses map[string]string) []string {
+	answers := make([]string, 0, len(questions))
+	for i := range questions {
+		answers = append(answers, responses[slack.QuestionBlockID(i)])
+	}
+	return jira.FindIssueKeys(strings.Join(answers, "\n"), integration.Projects)
+}
+
+// issueLinks formats links to the Jira issues keys for a message.
+func issueLinks(integration *botconfig.JiraIntegration, keys []string) string {
+	client := jira.NewClient(integration.BaseURL, "", "")
+
+	links := make([]string, 0, len(keys))
+	for _, key := range keys {
+		links = append(links, fmt.Sprintf("<%s|%s>", client.IssueURL(key), key))
+	}
+	return "🎫 " + strings.Join(links, " · ")
+}
+
+// commentOnIssues comments on the Jira issues mentioned in a submission,
+// linking to its post in the channel when there is one. It does nothing
+// unless the Jira integration is set to comment.
+func (s *Service) commentOnIssues(ctx context.Context, submission *Submission, messageTS string) error {
+	cfg := s.Config(ctx)
+	integration := cfg.Integrations().Jira
+	if integration == nil || !integration.Comment {
+		return nil
+	}
+
+	channel, found := cfg.ChannelByID(submission.ChannelID)
+	if !found {
+		return nil
+	}
+
+	keys := issueKeys(integration, questionsOn(channel, submission.Date), submission.Responses)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	author := submission.UserName
+	if author == "" {
+		author = submission.UserID
+	}
+	text := fmt.Sprintf("%s mentioned this issue in their standup update in #%s on %s.",
+		author, channel.Name(), submission.Date)
+	if messageTS != "" {
+		permalink, err := s.slackClient.GetPermalink(ctx, submission.ChannelID, messageTS)
+		if err != nil {
+			// Still worth commenting without the link
+			s.botCtx.Logger().Error(ctx, "Failed to get standup permalink", err)
+		}
+		if permalink != "" {
+			text += "\n" + permalink
+		}
+	}
+
+	client := jira.NewClient(integration.BaseURL, integration.Email, integration.APIToken)
+	var errs []error
+	for _, key := range keys {
+		if err := client.AddComment(ctx, key, text); err != nil {
+			errs = append(errs, fmt.Errorf("failed to comment on %s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,24 @@
+package standup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+)
+
+func TestIssueLinks(t *testing.T) {
+	integration := &botconfig.JiraIntegration{BaseURL: "https://example.atlassian.net", Projects: []string{"ABC", "OPS"}}
+	questions := []botconfig.Question{{Text: "Yesterday?"}, {Text: "Today?"}}
+	responses := map[string]string{
+		"question_0": "Shipped ABC-12",
+		"question_1": "OPS-7 and ABC-12, then LUNCH-1",
+		"question_2": "ABC-99 from a question that no longer exists",
+	}
+
+	keys := issueKeys(integration, questions, responses)
+	assert.Equal(t, []string{"ABC-12", "OPS-7"}, keys)
+	assert.Equal(t, "🎫 <https://example.atlassian.net/browse/ABC-12|ABC-12> · "+
+		"<https://example.atlassian.net/browse/OPS-7|OPS-7>", issueLinks(integration, keys))
+}
@@ -0,0 +1,151 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// ErrStandupClosed is returned for submissions after the day's summary was
+// posted and its grace period is over.
+var ErrStandupClosed = errors.New("standup closed")
+
+// withinGracePeriod reports whether a submission at now is still accepted
+// for a summary posted at postedAt. An empty or invalid grace period accepts
+// late submissions all day.
+func withinGracePeriod(gracePeriod string, postedAt *time.Time, now time.Time) bool {
+	if gracePeriod == "" || postedAt == nil {
+		return true
+	}
+	grace, err := time.ParseDuration(gracePeriod)
+	if err != nil {
+		return true
+	}
+	return !now.After(postedAt.Add(grace))
+}
+
+// standupClosed reports whether the session's summary was posted and the
+// channel's grace period for late submissions is over.
+func (s *Service) standupClosed(ctx context.Context, session *store.Session) (bool, error) {
+	if !session.SummaryPosted {
+		return false, nil
+	}
+
+	config, err := s.store.GetChannelConfig(ctx, store.TeamScope(ctx), session.ChannelID)
+	if err == store.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get channel config: %w", err)
+	}
+
+	return !withinGracePeriod(config.Schedule.GracePeriod, session.CompletedAt, time.Now()), nil
+}
+
+// lateSubmission reports whether a submission comes after the day's summary
+// was posted, returning ErrStandupClosed once the grace period is over. Edits
+// keep the lateness of the response they replace. The session is returned
+// only for a user's first late response, which is added to the summary.
+func (s *Service) lateSubmission(ctx context.Context, submission *Submission) (*store.Session, bool, error) {
+	session, err := s.store.GetSession(ctx, submission.ChannelID, submission.Date)
+	if err == store.ErrNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get session: %w", err)
+	}
+	if !session.SummaryPosted {
+		return nil, false, nil
+	}
+
+	closed, err := s.standupClosed(ctx, session)
+	if err != nil {
+		return nil, false, err
+	}
+	if closed {
+		return nil, false, ErrStandupClosed
+	}
+
+	existing, err := s.store.GetUserResponse(ctx, submission.ChannelID, submission.Date, submission.UserID)
+	if err != nil && err != store.ErrNotFound {
+		return nil, false, fmt.Errorf("failed to get response: %w", err)
+	}
+	if existing != nil {
+		return nil, existing.Late, nil
+	}
+
+	return session, true, nil
+}
+
+// addLateSubmission replies to the day's summary with a late submission and
+// updates the summary's counts. Answers in private channels stay out of the
+// reply.
+func (s *Service) addLateSubmission(ctx context.Context, session *store.Session, submission *Submission, public bool) error {
+	if session.SummaryTS == "" {
+		// The summary went somewhere other than Slack
+		return nil
+	}
+
+	blocks := slack.NewMessageBuilder().
+		AddSection(fmt.Sprintf("*Late addition from <@%s>*", security.SanitizeLogValue(submission.UserID))).
+		Build()
+	if public {
+		answers, err := s.responseBlocks(ctx, submission, "Late addition")
+		if err != nil {
+			return err
+		}
+		blocks = answers
+	}
+
+	if _, err := s.slackClient.PostMessage(ctx, submission.ChannelID,
+		slack.WithBlocks(blocks...),
+		slack.WithThreadTS(session.SummaryTS),
+	); err != nil {
+		return fmt.Errorf("failed to post late addition: %w", err)
+	}
+
+	if err := s.updateDailySummary(ctx, submission.ChannelID, submission.Date); err != nil {
+		return err
+	}
+
+	s.botCtx.Logger().Info(ctx, "Added late submission to summary",
+		botcontext.Field{Key: "channel_id", Value: submission.ChannelID},
+		botcontext.Field{Key: "user_id", Value: submission.UserID},
+		botcontext.Metric("LateSubmissions", 1),
+	)
+	return nil
+}
+
+// updateDailySummary rebuilds a posted summary from the day's responses.
+func (s *Service) updateDailySummary(ctx context.Context, channelID, date string) error {
+	channel, found := s.Config(ctx).ChannelByID(channelID)
+	if !found {
+		return fmt.Errorf("channel not configured: %s", security.SanitizeLogValue(channelID))
+	}
+
+	session, responses, err := s.store.GetSessionWithResponses(ctx, channelID, date)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	private, err := s.privateChannelConfig(ctx, channelID)
+	if err != nil {
+		return err
+	}
+
+	summary, _, err := s.dailySummary(ctx, channel, session, responses, private)
+	if err != nil {
+		return err
+	}
+
+	if err := s.slackClient.UpdateMessage(ctx, channelID, session.SummaryTS, slack.WithBlocks(summary.Blocks()...)); err != nil {
+		return fmt.Errorf("failed to update summary: %w", err)
+	}
+	return nil
+}
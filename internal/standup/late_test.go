@@ -0,0 +1,31 @@
+package standup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestWithinGracePeriod(t *testing.T) {
+	postedAt := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	assert.True(t, withinGracePeriod("2h", &postedAt, postedAt.Add(2*time.Hour)))
+	assert.False(t, withinGracePeriod("2h", &postedAt, postedAt.Add(2*time.Hour+time.Second)))
+	assert.False(t, withinGracePeriod("0", &postedAt, postedAt.Add(time.Second)))
+
+	// Without a grace period late submissions are accepted all day
+	assert.True(t, withinGracePeriod("", &postedAt, postedAt.Add(12*time.Hour)))
+	assert.True(t, withinGracePeriod("soon", &postedAt, postedAt.Add(12*time.Hour)))
+	assert.True(t, withinGracePeriod("2h", nil, postedAt))
+}
+
+func TestSubmittedTime(t *testing.T) {
+	response := &store.UserResponse{SubmittedAt: time.Date(2024, 3, 4, 14, 5, 0, 0, time.UTC)}
+	assert.Equal(t, "2:05 PM", submittedTime(response))
+
+	response.Late = true
+	assert.Equal(t, "2:05 PM (late)", submittedTime(response))
+}
@@ -0,0 +1,53 @@
+package standup
+
+import (
+	"context"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/i18n"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// UserLocale returns the locale of messages to a user about a channel: the
+// one they chose with "/standup prefs", else their Slack locale, else the
+// channel's. Lookups that fail are skipped.
+func (s *Service) UserLocale(ctx context.Context, channelID, userID string) i18n.Locale {
+	var preferred string
+	prefs, err := s.store.GetUserPreferences(ctx, userID)
+	if err == nil {
+		preferred = prefs.Locale
+	} else if err != store.ErrNotFound {
+		s.botCtx.Logger().Error(ctx, "Failed to get user preferences", err)
+	}
+
+	var info *slack.UserInfo
+	if preferred == "" {
+		info, err = s.slackClient.GetUserInfo(ctx, userID)
+		if err != nil {
+			s.botCtx.Logger().Error(ctx, "Failed to get user locale", err)
+		}
+	}
+
+	channel, _ := s.Config(ctx).ChannelByID(channelID)
+	return messageLocale(preferred, info, channel)
+}
+
+// channelLocale returns the locale of a channel's messages.
+func (s *Service) channelLocale(ctx context.Context, channelID string) i18n.Locale {
+	channel, _ := s.Config(ctx).ChannelByID(channelID)
+	return messageLocale("", nil, channel)
+}
+
+// messageLocale picks the locale of a message to a user from the locale they
+// chose, their Slack user info and the channel, each of which may be unset.
+func messageLocale(preferred string, info *slack.UserInfo, channel botconfig.ChannelConfig) i18n.Locale {
+	var slackLocale, channelLocale string
+	if info != nil {
+		slackLocale = info.Locale
+	}
+	if channel != nil {
+		channelLocale = channel.Locale()
+	}
+	return i18n.Pick(preferred, slackLocale, channelLocale)
+}
@@ -0,0 +1,33 @@
+package standup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/i18n"
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+func TestMessageLocale(t *testing.T) {
+	cfg, err := botconfig.ParseYAML([]byte(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+    locale: "de"
+`))
+	require.NoError(t, err)
+	channel, ok := cfg.ChannelByID("C1234567890")
+	require.True(t, ok)
+
+	assert.Equal(t, i18n.Spanish, messageLocale("es", &slack.UserInfo{Locale: "fr-FR"}, channel))
+	assert.Equal(t, i18n.French, messageLocale("", &slack.UserInfo{Locale: "fr-FR"}, channel))
+	// Unsupported Slack locales fall back to the channel's
+	assert.Equal(t, i18n.German, messageLocale("", &slack.UserInfo{Locale: "ja-JP"}, channel))
+	assert.Equal(t, i18n.Default, messageLocale("", nil, nil))
+}
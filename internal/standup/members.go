@@ -0,0 +1,170 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// PromptMemberApproval asks the channel's admin whether to add a user who
+// joined the channel to its standup. Nothing is sent unless the channel's
+// onboarding policy auto-adds members and the user isn't required already.
+func (s *Service) PromptMemberApproval(ctx context.Context, teamID, channelID, userID string) error {
+	config, err := s.store.GetChannelConfig(ctx, teamID, channelID)
+	if err == store.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get channel config: %w", err)
+	}
+
+	policy := config.Schedule.Onboarding
+	required := slices.Contains(config.Users, userID) && !slices.Contains(config.DeactivatedUsers, userID)
+	if policy == nil || !policy.AutoAdd || policy.AdminID == "" || required {
+		return nil
+	}
+
+	dmChannel, err := s.slackClient.OpenDM(ctx, policy.AdminID)
+	if err != nil {
+		return fmt.Errorf("failed to open admin DM: %w", err)
+	}
+
+	blocks := slack.BuildMemberApprovalMessage(userID, channelID)
+	text := fmt.Sprintf("<@%s> joined <#%s>. Add them to the daily standup?", userID, channelID)
+	if _, err := s.slackClient.PostMessage(ctx, dmChannel, slack.WithText(text), slack.WithBlocks(blocks...)); err != nil {
+		return fmt.Errorf("failed to send member prompt: %w", err)
+	}
+
+	return nil
+}
+
+// AddRequiredUser adds a user to the channel's required users, as approved
+// from a member prompt. Callers check the approver is a channel admin.
+// Adding a deactivated user reactivates them; adding a user already required
+// is a no-op.
+func (s *Service) AddRequiredUser(ctx context.Context, teamID, channelID, userID string) error {
+	config, err := s.store.GetChannelConfig(ctx, teamID, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel config: %w", err)
+	}
+
+	deactivated := slices.Contains(config.DeactivatedUsers, userID)
+	if slices.Contains(config.Users, userID) && !deactivated {
+		return nil
+	}
+
+	config.DeactivatedUsers = slices.DeleteFunc(config.DeactivatedUsers, func(id string) bool { return id == userID })
+	if !slices.Contains(config.Users, userID) {
+		config.Users = append(config.Users, userID)
+	}
+	if err := s.store.SaveChannelConfig(ctx, config); err != nil {
+		return fmt.Errorf("failed to save channel config: %w", err)
+	}
+
+	s.botCtx.Logger().Info(ctx, "Added required user",
+		botcontext.Field{Key: "channel_id", Value: channelID},
+		botcontext.Field{Key: "user_id", Value: userID},
+	)
+
+	return nil
+}
+
+// errUserDeactivated is returned when a user can't be reminded because they
+// were deactivated or removed from the workspace.
+var errUserDeactivated = errors.New("user deactivated")
+
+// DeactivateUsers flags required users who were deactivated or removed from
+// the workspace, so they're no longer reminded or reported missing, and tells
+// the channel's admins. Users already flagged are ignored.
+func (s *Service) DeactivateUsers(ctx context.Context, teamID, channelID string, userIDs []string) error {
+	config, err := s.store.GetChannelConfig(ctx, teamID, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel config: %w", err)
+	}
+
+	var flagged []string
+	for _, userID := range userIDs {
+		if slices.Contains(config.Users, userID) && !slices.Contains(config.DeactivatedUsers, userID) {
+			config.DeactivatedUsers = append(config.DeactivatedUsers, userID)
+			flagged = append(flagged, userID)
+		}
+	}
+	if len(flagged) == 0 {
+		return nil
+	}
+
+	if err := s.store.SaveChannelConfig(ctx, config); err != nil {
+		return fmt.Errorf("failed to save channel config: %w", err)
+	}
+
+	s.botCtx.Logger().Info(ctx, "Deactivated users",
+		botcontext.Field{Key: "channel_id", Value: channelID},
+		botcontext.Field{Key: "user_ids", Value: flagged},
+	)
+
+	return s.notifyDeactivatedUsers(ctx, config, flagged)
+}
+
+// notifyDeactivatedUsers tells the channel's admins which users were taken
+// off the standup.
+func (s *Service) notifyDeactivatedUsers(ctx context.Context, config *store.ChannelConfig, userIDs []string) error {
+	mentions := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		mentions = append(mentions, fmt.Sprintf("<@%s>", userID))
+	}
+	text := fmt.Sprintf("%s left the workspace or was deactivated, so they've been taken off the daily standup "+
+		"in <#%s>. If they come back, approve them again when they rejoin the channel.",
+		strings.Join(mentions, ", "), config.ChannelID)
+
+	return s.notifyAdmins(ctx, config, text)
+}
+
+// notifyAdmins DMs text to the channel's admins, or posts it in the channel
+// itself if it has none.
+func (s *Service) notifyAdmins(ctx context.Context, config *store.ChannelConfig, text string) error {
+	admins := config.Admins
+	if len(admins) == 0 && config.Schedule.Onboarding != nil && config.Schedule.Onboarding.AdminID != "" {
+		admins = []string{config.Schedule.Onboarding.AdminID}
+	}
+	if len(admins) == 0 {
+		if _, err := s.slackClient.PostMessage(ctx, config.ChannelID, slack.WithText(text)); err != nil {
+			return fmt.Errorf("failed to post admin notice: %w", err)
+		}
+		return nil
+	}
+
+	var errs []error
+	for _, adminID := range admins {
+		dmChannel, err := s.slackClient.OpenDM(ctx, adminID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to open admin DM: %w", err))
+			continue
+		}
+		if _, err := s.slackClient.PostMessage(ctx, dmChannel, slack.WithText(text)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send admin notice: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deactivatedUsers returns the users flagged as deactivated in the channel's
+// stored config. Failures are logged rather than returned, since the caller
+// can carry on without them.
+func (s *Service) deactivatedUsers(ctx context.Context, channelID string) []string {
+	config, err := s.store.GetChannelConfig(ctx, store.TeamScope(ctx), channelID)
+	if err != nil {
+		if err != store.ErrNotFound {
+			s.botCtx.Logger().Error(ctx, "Failed to get deactivated users", err,
+				botcontext.Field{Key: "channel_id", Value: channelID},
+			)
+		}
+		return nil
+	}
+	return config.DeactivatedUsers
+}
@@ -0,0 +1,60 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+// labelUsers sets how a channel's summary shows each user. Channels whose
+// mention style is "name" or "both" show names, so a summary in a large
+// channel doesn't notify everyone in it.
+func (s *Service) labelUsers(ctx context.Context, channel botconfig.ChannelConfig, users []*slack.UserResponseSummary) {
+	style := channel.Mentions()
+	if style != botconfig.MentionsName && style != botconfig.MentionsBoth {
+		return
+	}
+
+	realNames := s.Config(ctx).IsFeatureEnabled("display_real_names")
+	for _, user := range users {
+		label := slack.EscapeText(s.displayName(ctx, user.UserID, user.UserName, realNames))
+		if style == botconfig.MentionsBoth {
+			label += fmt.Sprintf(" (<@%s>)", user.UserID)
+		}
+		user.Display = label
+	}
+}
+
+// displayName returns the name Slack shows for a user: their display name,
+// falling back to their full name. With realNames, set when the workspace
+// shows full names instead, the full name comes first. Users are looked up
+// with users.info, which the Slack client caches when caching is on; if
+// that fails, the name in the config is used.
+func (s *Service) displayName(ctx context.Context, userID, configuredName string, realNames bool) string {
+	info, err := s.slackClient.GetUserInfo(ctx, userID)
+	if err != nil {
+		s.botCtx.Logger().Warn(ctx, "Failed to get user name",
+			botcontext.Field{Key: "user_id", Value: userID},
+			botcontext.Field{Key: "error", Value: err.Error()},
+		)
+	} else {
+		names := []string{info.Profile.DisplayName, info.Profile.RealName, info.RealName}
+		if realNames {
+			names = []string{info.Profile.RealName, info.RealName, info.Profile.DisplayName}
+		}
+		for _, name := range names {
+			if name = strings.TrimSpace(name); name != "" {
+				return name
+			}
+		}
+	}
+
+	if configuredName != "" {
+		return configuredName
+	}
+	return userID
+}
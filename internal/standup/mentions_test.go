@@ -0,0 +1,65 @@
+package standup
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestSummaryMentionStyles(t *testing.T) {
+	s := newTestService(t, withConfig(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+      active_days: ["Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"]
+    users:
+      - id: "U1111111111"
+        name: "alice"
+      - id: "U2222222222"
+        name: "bob"
+    mentions: both
+    questions: ["What did you do?"]
+`))
+	ctx := s.ctx
+	s.client.AddUser(&slack.UserInfo{ID: "U1111111111", RealName: "Alice Smith",
+		Profile: slack.UserProfile{DisplayName: "ali<ce", RealName: "Alice Smith"}})
+
+	session, err := s.StartStandupSession(ctx, "C1234567890")
+	require.NoError(t, err)
+	require.NoError(t, s.data.SubmitUserResponse(ctx, session, &store.UserResponse{
+		ChannelID:   "C1234567890",
+		Date:        session.Date,
+		UserID:      "U1111111111",
+		UserName:    "alice",
+		Responses:   map[string]string{"question_0": "Shipped the export"},
+		SubmittedAt: time.Now(),
+	}))
+	s.client.Reset()
+
+	require.NoError(t, s.PostSummaryNow(ctx, "C1234567890", false))
+
+	posts := s.client.Calls("chat.postMessage")
+	require.NotEmpty(t, posts)
+	var texts []string
+	for _, block := range posts[len(posts)-1].Message.Blocks {
+		if section, ok := block.(*slack.SectionBlock); ok && section.Text != nil {
+			texts = append(texts, section.Text.Text)
+		}
+	}
+	text := strings.Join(texts, "\n")
+	// Display names are escaped; users Slack can't find keep their configured name
+	assert.Contains(t, text, "• ali&lt;ce (<@U1111111111>) - ")
+	assert.Contains(t, text, "• bob (<@U2222222222>)")
+
+	// Workspaces showing full names prefer them
+	assert.Equal(t, "Alice Smith", s.displayName(ctx, "U1111111111", "alice", true))
+}
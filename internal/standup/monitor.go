@@ -0,0 +1,227 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// Monitor defaults.
+const (
+	// DefaultSchedulerMaxAge is how long the scheduler may go without a
+	// successful run before the monitor alerts. The scheduler runs every
+	// minute when it polls channels.
+	DefaultSchedulerMaxAge = 15 * time.Minute
+
+	// DefaultSummaryFailureThreshold is how many times in a row a channel's
+	// daily summary may fail before the monitor alerts.
+	DefaultSummaryFailureThreshold = 2
+)
+
+// recordHeartbeat records a run of job, in channelID for per-channel jobs,
+// so the monitor can tell when it stops running or keeps failing. A nil
+// runErr records a success. Errors are logged rather than returned, so the
+// bookkeeping never fails the job itself.
+func (s *Service) recordHeartbeat(ctx context.Context, job, channelID string, runErr error) {
+	teamID := store.TeamScope(ctx)
+	now := time.Now()
+
+	heartbeat, err := s.store.GetHeartbeat(ctx, teamID, job, channelID)
+	if err == store.ErrNotFound {
+		heartbeat = &store.Heartbeat{Job: job, TeamID: teamID, ChannelID: channelID}
+	} else if err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to get heartbeat", err,
+			botcontext.Field{Key: "job", Value: job},
+		)
+		return
+	}
+
+	if runErr == nil {
+		heartbeat.LastSuccessAt = now
+		heartbeat.Failures = 0
+		heartbeat.LastError = ""
+	} else {
+		heartbeat.Failures++
+		heartbeat.LastError = runErr.Error()
+	}
+	heartbeat.UpdatedAt = now
+
+	if err := s.store.SaveHeartbeat(ctx, heartbeat); err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to save heartbeat", err,
+			botcontext.Field{Key: "job", Value: job},
+		)
+	}
+}
+
+// Monitor alerts an admin channel when the scheduler stops running or a
+// channel's daily summary keeps failing, going by the heartbeats they
+// record. It runs apart from the scheduler, so it still alerts when the
+// scheduler doesn't run at all.
+type Monitor struct {
+	service      *Service
+	botCtx       botcontext.BotContext
+	store        store.Store
+	teamID       string // Workspace of the alert channel
+	alertChannel string
+
+	schedulerMaxAge         time.Duration
+	summaryFailureThreshold int
+}
+
+// MonitorOption is a function that modifies a monitor.
+type MonitorOption func(*Monitor)
+
+// WithSchedulerMaxAge sets how long the scheduler may go without a
+// successful run before the monitor alerts.
+func WithSchedulerMaxAge(maxAge time.Duration) MonitorOption {
+	return func(m *Monitor) {
+		if maxAge > 0 {
+			m.schedulerMaxAge = maxAge
+		}
+	}
+}
+
+// WithSummaryFailureThreshold sets how many times in a row a channel's daily
+// summary may fail before the monitor alerts.
+func WithSummaryFailureThreshold(n int) MonitorOption {
+	return func(m *Monitor) {
+		if n > 0 {
+			m.summaryFailureThreshold = n
+		}
+	}
+}
+
+// MonitorOptionsFromEnv reads monitor options from the environment:
+// MONITOR_SCHEDULER_MAX_AGE (a duration) and MONITOR_SUMMARY_FAILURES.
+// Unset or invalid values keep the defaults.
+func MonitorOptionsFromEnv() []MonitorOption {
+	var opts []MonitorOption
+	if maxAge, err := time.ParseDuration(os.Getenv("MONITOR_SCHEDULER_MAX_AGE")); err == nil {
+		opts = append(opts, WithSchedulerMaxAge(maxAge))
+	}
+	if n, err := strconv.Atoi(os.Getenv("MONITOR_SUMMARY_FAILURES")); err == nil {
+		opts = append(opts, WithSummaryFailureThreshold(n))
+	}
+	return opts
+}
+
+// NewMonitor creates a monitor posting its alerts to alertChannel in the
+// workspace teamID, which is empty for single-workspace deployments.
+func NewMonitor(
+	service *Service,
+	botCtx botcontext.BotContext,
+	store store.Store,
+	teamID, alertChannel string,
+	opts ...MonitorOption,
+) *Monitor {
+	m := &Monitor{
+		service:                 service,
+		botCtx:                  botCtx,
+		store:                   store,
+		teamID:                  teamID,
+		alertChannel:            alertChannel,
+		schedulerMaxAge:         DefaultSchedulerMaxAge,
+		summaryFailureThreshold: DefaultSummaryFailureThreshold,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Check alerts about jobs that became unhealthy since the last check, and
+// posts a follow-up for those that recovered. Each problem is alerted once
+// until it recovers.
+func (m *Monitor) Check(ctx context.Context, now time.Time) error {
+	logger := m.botCtx.Logger()
+
+	heartbeats, err := m.store.ListHeartbeats(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list heartbeats: %w", err)
+	}
+
+	ctx, ok := m.service.WithTeam(ctx, m.teamID)
+	if !ok {
+		return fmt.Errorf("workspace not served: %s", security.SanitizeLogValue(m.teamID))
+	}
+
+	for _, heartbeat := range heartbeats {
+		problem := m.problem(heartbeat, now)
+
+		var text string
+		switch {
+		case problem != "" && heartbeat.AlertedAt == nil:
+			text = ":rotating_light: " + problem
+			heartbeat.AlertedAt = &now
+		case problem == "" && heartbeat.AlertedAt != nil:
+			text = ":white_check_mark: " + recoveredText(heartbeat)
+			heartbeat.AlertedAt = nil
+		default:
+			continue
+		}
+
+		if _, err := m.service.slackClient.PostMessage(ctx, m.alertChannel, slack.WithText(text)); err != nil {
+			return fmt.Errorf("failed to post alert: %w", err)
+		}
+		// A failed save alerts again on the next check rather than never
+		if err := m.store.SaveHeartbeat(ctx, heartbeat); err != nil {
+			logger.Error(ctx, "Failed to save heartbeat", err,
+				botcontext.Field{Key: "job", Value: heartbeat.Job},
+			)
+		}
+
+		if heartbeat.AlertedAt != nil {
+			logger.Info(ctx, "Alerted about unhealthy job",
+				botcontext.Field{Key: "job", Value: heartbeat.Job},
+				botcontext.Field{Key: "channel_id", Value: heartbeat.ChannelID},
+				botcontext.Metric("MonitorAlerts", 1),
+			)
+		}
+	}
+
+	return nil
+}
+
+// problem describes what's wrong with a job, or returns "" if it's healthy.
+func (m *Monitor) problem(heartbeat *store.Heartbeat, now time.Time) string {
+	switch heartbeat.Job {
+	case store.HeartbeatScheduler:
+		if now.Sub(heartbeat.LastSuccessAt) <= m.schedulerMaxAge {
+			return ""
+		}
+		text := "The standup scheduler hasn't completed a run"
+		if !heartbeat.LastSuccessAt.IsZero() {
+			text += " since " + heartbeat.LastSuccessAt.UTC().Format("2006-01-02 15:04 UTC")
+		}
+		text += ", so reminders and summaries may not be going out."
+		if heartbeat.Failures > 0 {
+			text += fmt.Sprintf("\nIt failed %d times in a row, most recently with: `%s`",
+				heartbeat.Failures, heartbeat.LastError)
+		}
+		return text
+
+	case store.HeartbeatSummary:
+		if heartbeat.Failures < m.summaryFailureThreshold {
+			return ""
+		}
+		return fmt.Sprintf("The daily summary in <#%s> failed %d times in a row, most recently with: `%s`",
+			heartbeat.ChannelID, heartbeat.Failures, heartbeat.LastError)
+	}
+	return ""
+}
+
+// recoveredText tells the alert channel that a job it was alerted about is
+// healthy again.
+func recoveredText(heartbeat *store.Heartbeat) string {
+	if heartbeat.Job == store.HeartbeatSummary {
+		return fmt.Sprintf("The daily summary in <#%s> is posting again.", heartbeat.ChannelID)
+	}
+	return "The standup scheduler is running again."
+}
@@ -0,0 +1,63 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestMonitor(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	scheduler := NewScheduler(s.Service, s.botCtx, s.data)
+	monitor := NewMonitor(s.Service, s.botCtx, s.data, "", "C0ADMIN0001", WithSummaryFailureThreshold(2))
+
+	require.NoError(t, scheduler.ProcessScheduledTasks(ctx))
+	heartbeat, err := s.data.GetHeartbeat(ctx, "", store.HeartbeatScheduler, "")
+	require.NoError(t, err)
+	s.client.Reset()
+
+	// A recent run is healthy
+	require.NoError(t, monitor.Check(ctx, heartbeat.LastSuccessAt.Add(time.Minute)))
+	assert.Empty(t, s.client.Calls("chat.postMessage"))
+
+	// A scheduler that stopped running is alerted about once
+	later := heartbeat.LastSuccessAt.Add(DefaultSchedulerMaxAge + time.Minute)
+	require.NoError(t, monitor.Check(ctx, later))
+	require.NoError(t, monitor.Check(ctx, later.Add(time.Minute)))
+	calls := s.client.Calls("chat.postMessage")
+	require.Len(t, calls, 1)
+	assert.Equal(t, "C0ADMIN0001", calls[0].Channel)
+	assert.Contains(t, calls[0].Message.Text, "scheduler hasn't completed a run since")
+
+	// And followed up on once it runs again
+	require.NoError(t, scheduler.ProcessScheduledTasks(ctx))
+	require.NoError(t, monitor.Check(ctx, time.Now()))
+	calls = s.client.Calls("chat.postMessage")
+	require.Len(t, calls, 2)
+	assert.Contains(t, calls[1].Message.Text, "scheduler is running again")
+
+	// Summaries are alerted about once they fail repeatedly
+	s.recordHeartbeat(ctx, store.HeartbeatSummary, "C1234567890", errors.New("channel_not_found"))
+	require.NoError(t, monitor.Check(ctx, time.Now()))
+	require.Len(t, s.client.Calls("chat.postMessage"), 2)
+
+	s.recordHeartbeat(ctx, store.HeartbeatSummary, "C1234567890", errors.New("channel_not_found"))
+	require.NoError(t, monitor.Check(ctx, time.Now()))
+	calls = s.client.Calls("chat.postMessage")
+	require.Len(t, calls, 3)
+	assert.Contains(t, calls[2].Message.Text, "summary in <#C1234567890> failed 2 times in a row")
+	assert.Contains(t, calls[2].Message.Text, "channel_not_found")
+
+	s.recordHeartbeat(ctx, store.HeartbeatSummary, "C1234567890", nil)
+	require.NoError(t, monitor.Check(ctx, time.Now()))
+	calls = s.client.Calls("chat.postMessage")
+	require.Len(t, calls, 4)
+	assert.Contains(t, calls[3].Message.Text, "summary in <#C1234567890> is posting again")
+}
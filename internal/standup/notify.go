@@ -0,0 +1,29 @@
+package standup
+
+import (
+	"context"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/notify"
+)
+
+// WithMirrors also delivers reminders and summaries with the given
+// notifiers, e.g. to a mailing list. Slack remains the primary destination.
+func WithMirrors(mirrors ...notify.Notifier) ServiceOption {
+	return func(s *Service) {
+		s.mirrors = append(s.mirrors, mirrors...)
+	}
+}
+
+// mirror delivers to each mirror. Failures are logged, since the delivery to
+// Slack already succeeded.
+func (s *Service) mirror(ctx context.Context, deliver func(n notify.Notifier) error) {
+	for _, n := range s.mirrors {
+		if err := deliver(n); err != nil {
+			s.botCtx.Logger().Error(ctx, "Failed to deliver to mirror", err,
+				botcontext.Field{Key: "notifier", Value: n.Name()},
+				botcontext.Metric("MirrorDeliveryFailures", 1),
+			)
+		}
+	}
+}
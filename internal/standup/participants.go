@@ -0,0 +1,86 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// expandParticipants looks up the members of the channel's user groups,
+// and of the channel itself if they're participants, to be cached on the
+// day's session. Groups or channels that can't be listed, e.g. without the
+// usergroups:read scope, are logged and skipped so the standup still starts.
+func (s *Service) expandParticipants(ctx context.Context, channel botconfig.ChannelConfig) []string {
+	var members []string
+	if channel.Participants() == botconfig.ParticipantsChannelMembers {
+		channelMembers, err := s.slackClient.ListChannelMembers(ctx, channel.ID())
+		if err != nil {
+			s.botCtx.Logger().Error(ctx, "Failed to list channel members", err,
+				botcontext.Field{Key: "channel_id", Value: channel.ID()},
+			)
+		}
+		members = append(members, s.withoutBots(ctx, channelMembers)...)
+	}
+
+	for _, groupID := range channel.UserGroups() {
+		users, err := s.slackClient.ListUserGroupMembers(ctx, groupID)
+		if err != nil {
+			s.botCtx.Logger().Error(ctx, "Failed to list user group members", err,
+				botcontext.Field{Key: "channel_id", Value: channel.ID()},
+				botcontext.Field{Key: "user_group", Value: security.SanitizeLogValue(groupID)},
+			)
+			continue
+		}
+		members = append(members, users...)
+	}
+
+	// Excluded users are left out along with the configured ones
+	configured := slices.Clone(channel.ExcludedUsers())
+	for _, user := range channel.Users() {
+		configured = append(configured, user.ID())
+	}
+	return extraMembers(configured, members)
+}
+
+// withoutBots leaves out bots, including this app, and deactivated users.
+// Users whose info can't be fetched are kept; reminders flag them later if
+// they're gone.
+func (s *Service) withoutBots(ctx context.Context, userIDs []string) []string {
+	return slices.DeleteFunc(userIDs, func(userID string) bool {
+		if userID == "USLACKBOT" {
+			return true
+		}
+		info, err := s.slackClient.GetUserInfo(ctx, userID)
+		return err == nil && (info.IsBot || info.Deleted)
+	})
+}
+
+// extraMembers returns the distinct members who aren't among the configured
+// users, sorted.
+func extraMembers(configured, members []string) []string {
+	members = slices.Clone(members)
+	slices.Sort(members)
+	members = slices.Compact(members)
+	return slices.DeleteFunc(members, func(userID string) bool {
+		return slices.Contains(configured, userID)
+	})
+}
+
+// groupMembers returns the user group members cached on the channel's
+// session for date, or nil if it hasn't started.
+func (s *Service) groupMembers(ctx context.Context, channelID, date string) ([]string, error) {
+	session, err := s.store.GetSession(ctx, channelID, date)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return session.GroupMembers, nil
+}
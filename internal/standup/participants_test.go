@@ -0,0 +1,17 @@
+package standup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtraMembers(t *testing.T) {
+	configured := []string{"U0000000001"}
+	members := []string{"U0000000003", "U0000000001", "U0000000002", "U0000000003"}
+
+	assert.Equal(t, []string{"U0000000002", "U0000000003"}, extraMembers(configured, members))
+	// The session's cached members aren't reordered
+	assert.Equal(t, "U0000000003", members[0])
+	assert.Empty(t, extraMembers(configured, nil))
+}
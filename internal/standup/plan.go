@@ -0,0 +1,90 @@
+package standup
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+const (
+	// maxPlanLength caps how much of a plan is carried into the modal, so
+	// the modal's private metadata stays within Slack's limit.
+	maxPlanLength = 500
+
+	// planLookbackDays is how far back a user's previous standup is looked
+	// for, e.g. across holidays.
+	planLookbackDays = 14
+)
+
+// planQuestionIndex returns the index of the text question asking what's
+// planned for today, or -1 when the channel doesn't ask one.
+func planQuestionIndex(questions []botconfig.Question) int {
+	for i, question := range questions {
+		switch question.Type {
+		case "", botconfig.QuestionText:
+		default:
+			continue
+		}
+		text := strings.ToLower(question.Text)
+		if strings.Contains(text, "today") && !strings.Contains(text, "yesterday") {
+			return i
+		}
+	}
+	return -1
+}
+
+// previousPlan returns the user's answer to "What will you do today?" in
+// their previous standup in the channel, to show above "What did you do
+// yesterday?" so they can compare the two. It's nil when the channel doesn't
+// ask both or the user has no earlier answer.
+func (s *Service) previousPlan(
+	ctx context.Context,
+	channel botconfig.ChannelConfig,
+	userID, date string,
+) *slack.CarriedAnswer {
+	activity := activityQuestionIndex(questionsOn(channel, date))
+	if activity < 0 {
+		return nil
+	}
+
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil
+	}
+	responses, err := s.store.ListUserResponsesByUser(ctx, channel.ID(), userID,
+		day.AddDate(0, 0, -planLookbackDays).Format("2006-01-02"), day.AddDate(0, 0, -1).Format("2006-01-02"))
+	if err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to get previous standup", err,
+			botcontext.Field{Key: "channel_id", Value: channel.ID()},
+		)
+		return nil
+	}
+	if len(responses) == 0 {
+		return nil
+	}
+
+	// Questions may differ by day, so the answer is looked up by the
+	// questions asked then
+	previous := responses[0]
+	index := planQuestionIndex(questionsOn(channel, previous.Date))
+	if index < 0 {
+		return nil
+	}
+	text := strings.TrimSpace(previous.Responses[slack.QuestionBlockID(index)])
+	if text == "" {
+		return nil
+	}
+	if runes := []rune(text); len(runes) > maxPlanLength {
+		text = string(runes[:maxPlanLength]) + "…"
+	}
+
+	return &slack.CarriedAnswer{
+		BlockID: slack.QuestionBlockID(activity),
+		Date:    previous.Date,
+		Text:    text,
+	}
+}
@@ -0,0 +1,89 @@
+package standup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestPlanQuestionIndex(t *testing.T) {
+	assert.Equal(t, 1, planQuestionIndex([]botconfig.Question{
+		{Text: "What did you do yesterday instead of today?"},
+		{Text: "What will you do today?"},
+	}))
+	assert.Equal(t, -1, planQuestionIndex([]botconfig.Question{
+		{Text: "Working on anything today?", Type: botconfig.QuestionYesNo},
+		{Text: "Any blockers?"},
+	}))
+}
+
+func TestOpenStandupModalShowsPreviousPlan(t *testing.T) {
+	s := newTestService(t, withConfig(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    enabled: true
+    schedule:
+      timezone: "UTC"
+      summary_time: "23:59"
+    questions:
+      - text: "What did you do yesterday?"
+      - text: "What will you do today?"
+`))
+	ctx := context.Background()
+
+	// Without an earlier standup there's nothing to show
+	require.NoError(t, s.OpenStandupModal(ctx, "trigger-1", "C1234567890", "U1234567890"))
+	opened := s.client.Calls("views.open")
+	require.Len(t, opened, 1)
+	assert.Nil(t, modalPlan(t, opened[0].Modal))
+
+	previous := time.Now().AddDate(0, 0, -3).Format("2006-01-02")
+	require.NoError(t, s.data.SaveUserResponse(ctx, &store.UserResponse{
+		SessionID: "3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f",
+		ChannelID: "C1234567890",
+		Date:      previous,
+		UserID:    "U1234567890",
+		Responses: map[string]string{"question_0": "Fixed bugs", "question_1": "Ship the release\nWrite docs"},
+	}))
+
+	require.NoError(t, s.OpenStandupModal(ctx, "trigger-2", "C1234567890", "U1234567890"))
+	opened = s.client.Calls("views.open")
+	require.Len(t, opened, 2)
+	assert.Equal(t, &slack.CarriedAnswer{
+		BlockID: "question_0",
+		Date:    previous,
+		Text:    "Ship the release\nWrite docs",
+	}, modalPlan(t, opened[1].Modal))
+
+	// The plan is shown above the question it's compared with
+	var texts []string
+	for _, block := range opened[1].Modal.Blocks {
+		switch block := block.(type) {
+		case *slack.SectionBlock:
+			texts = append(texts, block.Text.Text)
+		case slack.InputBlock:
+			texts = append(texts, block.BlockID)
+		}
+	}
+	assert.Equal(t, []string{
+		"Please answer the following questions:",
+		"🗓️ *Your plan on " + previous + ":*\n> Ship the release\n> Write docs",
+		"question_0",
+		"question_1",
+	}, texts)
+}
+
+// modalPlan returns the plan kept in a standup modal's metadata.
+func modalPlan(t *testing.T, modal *slack.Modal) *slack.CarriedAnswer {
+	metadata, err := slack.ParseModalMetadata(modal.PrivateMetadata)
+	require.NoError(t, err)
+	return metadata.Plan
+}
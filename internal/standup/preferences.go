@@ -0,0 +1,207 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/i18n"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// Reminder preferences that can be changed with "/standup prefs set".
+const (
+	PreferenceReminders    = "reminders"     // on or off
+	PreferenceReminderTime = "reminder_time" // HH:MM, or "default" for the channel's reminder times
+	PreferenceDelivery     = "delivery"      // dm or channel
+	PreferenceLanguage     = "language"      // A supported locale, or "auto" for the user's Slack language
+)
+
+// PreferenceKeys lists the reminder preferences that can be changed, in the
+// order they're shown.
+var PreferenceKeys = []string{PreferenceReminders, PreferenceReminderTime, PreferenceDelivery, PreferenceLanguage}
+
+// UserPreferences returns a user's reminder preferences, or the defaults if
+// they haven't set any.
+func (s *Service) UserPreferences(ctx context.Context, userID string) (*store.UserPreferences, error) {
+	prefs, err := s.store.GetUserPreferences(ctx, userID)
+	if err == store.ErrNotFound {
+		return &store.UserPreferences{UserID: userID, Delivery: store.DeliverDM}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// UpdateUserPreference sets one of a user's reminder preferences and returns
+// the preferences as saved. The reminder time is HH:MM in the timezone of
+// each channel the user is reminded in.
+func (s *Service) UpdateUserPreference(ctx context.Context, userID, key, value string) (*store.UserPreferences, error) {
+	prefs, err := s.UserPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyPreference(prefs, key, value); err != nil {
+		return nil, err
+	}
+
+	prefs.UpdatedAt = time.Now()
+	if err := s.store.SaveUserPreferences(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("failed to save user preferences: %w", err)
+	}
+
+	if err := s.syncUserSchedules(ctx, userID); err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to sync schedules", err,
+			botcontext.Field{Key: "user_id", Value: userID},
+		)
+	}
+
+	s.botCtx.Logger().Info(ctx, "Updated user preference",
+		botcontext.Field{Key: "user_id", Value: userID},
+		botcontext.Field{Key: "preference", Value: key},
+	)
+
+	return prefs, nil
+}
+
+// applyPreference validates value and sets it on prefs.
+func applyPreference(prefs *store.UserPreferences, key, value string) error {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	switch key {
+	case PreferenceReminders:
+		switch value {
+		case "on":
+			prefs.RemindersOff = false
+		case "off":
+			prefs.RemindersOff = true
+		default:
+			return fmt.Errorf("%w: reminders are either on or off", ErrInvalidSetting)
+		}
+
+	case PreferenceReminderTime:
+		if value == "default" {
+			prefs.ReminderTime = ""
+			return nil
+		}
+		reminderTime, err := parseClock(value)
+		if err != nil {
+			return err
+		}
+		prefs.ReminderTime = reminderTime
+
+	case PreferenceDelivery:
+		switch delivery := store.ReminderDelivery(value); delivery {
+		case store.DeliverDM, store.DeliverChannel:
+			prefs.Delivery = delivery
+		default:
+			return fmt.Errorf("%w: delivery is either dm or channel", ErrInvalidSetting)
+		}
+
+	case PreferenceLanguage:
+		if value == "auto" {
+			prefs.Locale = ""
+			return nil
+		}
+		locale, ok := i18n.Parse(value)
+		if !ok {
+			return fmt.Errorf("%w: language is one of %s, or auto for your Slack language",
+				ErrInvalidSetting, strings.Join(botconfig.Locales, ", "))
+		}
+		prefs.Locale = string(locale)
+
+	default:
+		return fmt.Errorf("%w: unknown preference %q", ErrInvalidSetting, key)
+	}
+
+	return nil
+}
+
+// reminderPreferences returns the saved preferences of the workspace's
+// users, keyed by user ID. Failures are logged rather than returned, leaving
+// everyone on the defaults.
+func (s *Service) reminderPreferences(ctx context.Context) map[string]*store.UserPreferences {
+	list, err := s.store.ListUserPreferences(ctx)
+	if err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to list user preferences", err)
+		return nil
+	}
+
+	prefs := make(map[string]*store.UserPreferences, len(list))
+	for _, p := range list {
+		prefs[p.UserID] = p
+	}
+	return prefs
+}
+
+// preferredReminderTimes returns the reminder times users of a channel chose
+// instead of the channel's, leaving out times the channel reminds at anyway.
+func (s *Service) preferredReminderTimes(ctx context.Context, config *store.ChannelConfig) []string {
+	if len(config.Users) == 0 {
+		return nil
+	}
+
+	prefs := s.reminderPreferences(ctx)
+	var times []string
+	for _, userID := range config.Users {
+		p, ok := prefs[userID]
+		if !ok || p.RemindersOff || p.ReminderTime == "" {
+			continue
+		}
+		if !slices.Contains(config.Schedule.ReminderTimes, p.ReminderTime) && !slices.Contains(times, p.ReminderTime) {
+			times = append(times, p.ReminderTime)
+		}
+	}
+	return times
+}
+
+// dueForReminder returns the users to remind at reminderTime. Users who chose
+// a reminder time are reminded only then, and everyone else at the channel's
+// reminder times. Reminders without a time, sent by hand, go to everyone who
+// hasn't turned reminders off.
+func dueForReminder(
+	userIDs []string,
+	prefs map[string]*store.UserPreferences,
+	reminderTime string,
+	channelTimes []string,
+) []string {
+	var due []string
+	for _, userID := range userIDs {
+		p := prefs[userID]
+		switch {
+		case p != nil && p.RemindersOff:
+			continue
+		case reminderTime == "":
+		case p != nil && p.ReminderTime != "":
+			if p.ReminderTime != reminderTime {
+				continue
+			}
+		case !slices.Contains(channelTimes, reminderTime):
+			continue
+		}
+		due = append(due, userID)
+	}
+	return due
+}
+
+// preferredLocale returns the locale the user with prefs chose, if any.
+func preferredLocale(prefs *store.UserPreferences) string {
+	if prefs == nil {
+		return ""
+	}
+	return prefs.Locale
+}
+
+// reminderDelivery returns how the user with prefs is reminded.
+func reminderDelivery(prefs *store.UserPreferences) store.ReminderDelivery {
+	if prefs == nil || prefs.Delivery == "" {
+		return store.DeliverDM
+	}
+	return prefs.Delivery
+}
@@ -0,0 +1,59 @@
+package standup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestApplyPreference(t *testing.T) {
+	prefs := &store.UserPreferences{UserID: "U123"}
+
+	require.NoError(t, applyPreference(prefs, PreferenceReminders, "Off"))
+	assert.True(t, prefs.RemindersOff)
+
+	require.NoError(t, applyPreference(prefs, PreferenceReminderTime, "9:30"))
+	assert.Equal(t, "09:30", prefs.ReminderTime)
+
+	require.NoError(t, applyPreference(prefs, PreferenceDelivery, "channel"))
+	assert.Equal(t, store.DeliverChannel, prefs.Delivery)
+
+	require.NoError(t, applyPreference(prefs, PreferenceLanguage, "es-ES"))
+	assert.Equal(t, "es", prefs.Locale)
+
+	for key, value := range map[string]string{
+		PreferenceReminders:    "sometimes",
+		PreferenceReminderTime: "after lunch",
+		PreferenceDelivery:     "email",
+		PreferenceLanguage:     "klingon",
+		"timezone":             "UTC",
+	} {
+		assert.ErrorIs(t, applyPreference(prefs, key, value), ErrInvalidSetting, key)
+	}
+	assert.Equal(t, "09:30", prefs.ReminderTime)
+
+	require.NoError(t, applyPreference(prefs, PreferenceReminderTime, "default"))
+	assert.Empty(t, prefs.ReminderTime)
+
+	require.NoError(t, applyPreference(prefs, PreferenceLanguage, "auto"))
+	assert.Empty(t, prefs.Locale)
+}
+
+func TestDueForReminder(t *testing.T) {
+	users := []string{"U1", "U2", "U3"}
+	prefs := map[string]*store.UserPreferences{
+		"U2": {UserID: "U2", RemindersOff: true},
+		"U3": {UserID: "U3", ReminderTime: "10:30"},
+	}
+	channelTimes := []string{"09:00", "11:00"}
+
+	assert.Equal(t, []string{"U1"}, dueForReminder(users, prefs, "09:00", channelTimes))
+	assert.Equal(t, []string{"U3"}, dueForReminder(users, prefs, "10:30", channelTimes))
+	assert.Empty(t, dueForReminder(users, prefs, "10:00", channelTimes))
+
+	// Reminders sent by hand go to everyone who hasn't opted out
+	assert.Equal(t, []string{"U1", "U3"}, dueForReminder(users, prefs, "", channelTimes))
+}
@@ -0,0 +1,86 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/synaptiq/standup-bot/internal/i18n"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// IsPrivate reports whether config keeps the channel's responses out of the
+// channel, sending them only to the privacy policy's recipients.
+func IsPrivate(config *store.ChannelConfig) bool {
+	return config.Schedule.Privacy != nil && config.Schedule.Privacy.Private
+}
+
+// privateChannelConfig returns the stored config of a channel if it's
+// private, or nil if it isn't. Channels without a stored config aren't.
+func (s *Service) privateChannelConfig(ctx context.Context, channelID string) (*store.ChannelConfig, error) {
+	config, err := s.store.GetChannelConfig(ctx, store.TeamScope(ctx), channelID)
+	if err == store.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel config: %w", err)
+	}
+	if !IsPrivate(config) {
+		return nil, nil
+	}
+	return config, nil
+}
+
+// reportRecipients returns who gets the full reports of a private channel:
+// the privacy policy's recipients, or the channel's admins if it names none.
+func reportRecipients(config *store.ChannelConfig) []string {
+	if recipients := config.Schedule.Privacy.Recipients; len(recipients) > 0 {
+		return recipients
+	}
+	return config.Admins
+}
+
+// sendPrivateReport DMs the day's full report of a private channel to the
+// privacy policy's recipients, or the channel's admins if it names none.
+func (s *Service) sendPrivateReport(
+	ctx context.Context,
+	config *store.ChannelConfig,
+	date string,
+	users []*slack.UserResponseSummary,
+) error {
+	recipients := reportRecipients(config)
+	if len(recipients) == 0 {
+		return fmt.Errorf("no recipients for the private report of channel %s", config.ChannelID)
+	}
+
+	header := fmt.Sprintf("🔒 #%s standup report — {{.Date}}", config.ChannelName)
+	blocks := slack.BuildDigestMessage(date, header, users, slack.GroupByUser, "", i18n.Pick(config.Schedule.Locale))
+	text := fmt.Sprintf("Standup report for <#%s>", config.ChannelID)
+
+	var errs []error
+	for _, recipient := range recipients {
+		dmChannel, err := s.slackClient.OpenDM(ctx, recipient)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to open recipient DM: %w", err))
+			continue
+		}
+		if _, err := s.slackClient.PostMessage(ctx, dmChannel, slack.WithText(text), slack.WithBlocks(blocks...)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send private report: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// completionOnly returns copies of users without their answers or skip
+// reasons, for summaries of private channels.
+func completionOnly(users []*slack.UserResponseSummary) []*slack.UserResponseSummary {
+	status := make([]*slack.UserResponseSummary, 0, len(users))
+	for _, user := range users {
+		copied := *user
+		copied.Answers = nil
+		copied.SkipReason = ""
+		status = append(status, &copied)
+	}
+	return status
+}
@@ -0,0 +1,39 @@
+package standup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestIsPrivate(t *testing.T) {
+	config := &store.ChannelConfig{}
+	assert.False(t, IsPrivate(config))
+
+	config.Schedule.Privacy = &store.PrivacyPolicy{Recipients: []string{"U123"}}
+	assert.False(t, IsPrivate(config))
+
+	config.Schedule.Privacy.Private = true
+	assert.True(t, IsPrivate(config))
+}
+
+func TestCompletionOnly(t *testing.T) {
+	users := []*slack.UserResponseSummary{
+		{UserID: "U1", Submitted: true, Time: "9:05 AM",
+			Answers: []slack.SummaryAnswer{{Question: "Today?", Text: "Secret project"}}},
+		{UserID: "U2", Skipped: true, SkipReason: "Doctor's appointment"},
+	}
+
+	status := completionOnly(users)
+	assert.Equal(t, []*slack.UserResponseSummary{
+		{UserID: "U1", Submitted: true, Time: "9:05 AM"},
+		{UserID: "U2", Skipped: true},
+	}, status)
+
+	// The full report keeps the details
+	assert.Len(t, users[0].Answers, 1)
+	assert.Equal(t, "Doctor's appointment", users[1].SkipReason)
+}
@@ -0,0 +1,54 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/validation"
+)
+
+// PurgeUserData erases everything the bot stored about a user in ctx's
+// workspace, e.g. to honour a GDPR erasure request, and returns how many
+// records it deleted. Purges are logged with an audit field naming who asked
+// for them; the entry carries a metric, so it's kept whatever the log level.
+// Callers check the user asking is a workspace admin.
+//
+// The user stays on channel rosters in the config; remove them there to
+// stop asking them for standups.
+func (s *Service) PurgeUserData(ctx context.Context, userID, requestedBy string) (int, error) {
+	if err := validation.ValidateUserID(userID); err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidUser, userID)
+	}
+
+	deleted, err := s.store.DeleteUserData(ctx, userID)
+	if err != nil {
+		// Part of the data may be gone, so a failed purge is audited too
+		s.botCtx.Logger().Error(ctx, "Failed to purge user data", err,
+			botcontext.Field{Key: "audit", Value: "purge_user_data"},
+			botcontext.Field{Key: "purged_user_id", Value: userID},
+			botcontext.Field{Key: "requested_by", Value: requestedBy},
+			botcontext.Field{Key: "deleted", Value: deleted},
+		)
+		return deleted, fmt.Errorf("failed to delete user data: %w", err)
+	}
+
+	// Their reminder time went with their preferences
+	if err := s.syncUserSchedules(ctx, userID); err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to sync schedules", err,
+			botcontext.Field{Key: "user_id", Value: userID},
+		)
+	}
+
+	s.botCtx.Logger().Info(ctx, "Purged user data",
+		botcontext.Field{Key: "audit", Value: "purge_user_data"},
+		botcontext.Field{Key: "team_id", Value: store.TeamScope(ctx)},
+		botcontext.Field{Key: "purged_user_id", Value: userID},
+		botcontext.Field{Key: "requested_by", Value: requestedBy},
+		botcontext.Field{Key: "deleted", Value: deleted},
+		botcontext.Metric("UserDataPurges", 1),
+	)
+
+	return deleted, nil
+}
@@ -0,0 +1,40 @@
+package standup
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestPurgeUserData(t *testing.T) {
+	s := newTestService(t)
+	ctx := s.ctx
+
+	require.NoError(t, s.data.SaveUserResponse(ctx, &store.UserResponse{
+		ChannelID: "C1234567890", Date: "2024-01-15", UserID: "U1111111111", SubmittedAt: time.Now(),
+	}))
+	require.NoError(t, s.data.SaveUserPreferences(ctx, &store.UserPreferences{UserID: "U1111111111"}))
+
+	deleted, err := s.PurgeUserData(ctx, "U1111111111", "U9999999999")
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	_, err = s.data.GetUserPreferences(ctx, "U1111111111")
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	// The purge is audited even though info entries are dropped
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(s.logs.Bytes(), &entry))
+	assert.Equal(t, "purge_user_data", entry["audit"])
+	assert.Equal(t, "T1234567890", entry["team_id"])
+	assert.Equal(t, "U1111111111", entry["purged_user_id"])
+	assert.Equal(t, "U9999999999", entry["requested_by"])
+	assert.Equal(t, float64(2), entry["deleted"])
+
+	_, err = s.PurgeUserData(ctx, "alice", "U9999999999")
+	assert.ErrorIs(t, err, ErrInvalidUser)
+}
@@ -0,0 +1,86 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/notify"
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+// SummaryCopyError is returned by PostSummaryNow when the summary was posted
+// to its channel, but copying it to some of the channel's summary recipients
+// failed.
+type SummaryCopyError struct {
+	Failed map[string]error // Keyed by recipient ID
+}
+
+func (e *SummaryCopyError) Error() string {
+	return fmt.Sprintf("failed to copy summary to %s", strings.Join(e.Recipients(), ", "))
+}
+
+// Recipients returns the IDs of the recipients the summary wasn't copied to,
+// sorted.
+func (e *SummaryCopyError) Recipients() []string {
+	recipients := make([]string, 0, len(e.Failed))
+	for recipient := range e.Failed {
+		recipients = append(recipients, recipient)
+	}
+	slices.Sort(recipients)
+	return recipients
+}
+
+// copySummary posts a copy of summary to each of the channel's summary
+// recipients: users by DM and channels as a message. Each recipient is tried
+// regardless of the others failing; failures are logged and returned in a
+// *SummaryCopyError.
+func (s *Service) copySummary(ctx context.Context, channel botconfig.ChannelConfig, summary *notify.Summary) error {
+	recipients := channel.SummaryRecipients()
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	// Summaries are marked reviewed in their channel
+	copied := *summary
+	copied.Review = false
+	blocks := copied.Blocks()
+	text := fmt.Sprintf("Standup summary for <#%s>", summary.ChannelID)
+
+	failed := make(map[string]error)
+	for _, recipient := range recipients {
+		target := recipient
+		if isUserID(recipient) {
+			dmChannel, err := s.slackClient.OpenDM(ctx, recipient)
+			if err != nil {
+				failed[recipient] = fmt.Errorf("failed to open DM: %w", err)
+				continue
+			}
+			target = dmChannel
+		}
+		if _, err := s.slackClient.PostMessage(ctx, target, slack.WithText(text), slack.WithBlocks(blocks...)); err != nil {
+			failed[recipient] = fmt.Errorf("failed to post summary copy: %w", err)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+
+	copyErr := &SummaryCopyError{Failed: failed}
+	for _, recipient := range copyErr.Recipients() {
+		s.botCtx.Logger().Error(ctx, "Failed to copy summary", failed[recipient],
+			botcontext.Field{Key: "channel_id", Value: summary.ChannelID},
+			botcontext.Field{Key: "recipient", Value: recipient},
+			botcontext.Metric("SummaryCopyFailures", 1),
+		)
+	}
+	return copyErr
+}
+
+// isUserID reports whether id is a Slack user ID rather than a channel ID.
+func isUserID(id string) bool {
+	return strings.HasPrefix(id, "U") || strings.HasPrefix(id, "W")
+}
@@ -0,0 +1,43 @@
+package standup
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummaryRecipients(t *testing.T) {
+	s := newTestService(t, withConfig(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+    summary_recipients: ["U9999999999", "C2222222222", "U8888888888"]
+`))
+	ctx := s.ctx
+
+	// One manager's DM can't be opened; the others still get their copy
+	s.client.FailNextWithCode("conversations.open", "user_not_found")
+	err := s.PostSummaryNow(ctx, "C1234567890", false)
+	var copyErr *SummaryCopyError
+	require.True(t, errors.As(err, &copyErr), err)
+	assert.Equal(t, []string{"U9999999999"}, copyErr.Recipients())
+
+	var channels []string
+	for _, call := range s.client.Calls("chat.postMessage") {
+		channels = append(channels, call.Channel)
+	}
+	assert.Equal(t, []string{"C1234567890", "C2222222222", "DU8888888888"}, channels)
+	assert.Contains(t, s.client.Calls("chat.postMessage")[1].Message.Text, "<#C1234567890>")
+
+	// The summary itself is posted, so it isn't posted again
+	session, err := s.data.GetSession(ctx, "C1234567890", time.Now().Format("2006-01-02"))
+	require.NoError(t, err)
+	assert.True(t, session.SummaryPosted)
+	assert.ErrorIs(t, s.PostSummaryNow(ctx, "C1234567890", false), ErrSummaryPosted)
+}
@@ -0,0 +1,325 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/metrics"
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/templates"
+)
+
+// Reminder batch defaults.
+const (
+	DefaultReminderConcurrency = 10
+	DefaultReminderTimeout     = 10 * time.Second
+
+	// DefaultReminderFailureThreshold is how many reminders in a channel may
+	// fail in a day before its admins are alerted.
+	DefaultReminderFailureThreshold = 3
+)
+
+// SendRemindersResult summarizes a batch of reminders.
+type SendRemindersResult struct {
+	Sent    int
+	Failed  int
+	Skipped int              // Users who skipped today, were deactivated or were not reached before the deadline
+	Errors  map[string]error // Failures by user ID
+	// Deactivated lists users found to be deactivated or removed from the
+	// workspace. They're counted as skipped.
+	Deactivated []string
+	// Deferred counts users in Do Not Disturb, who are reminded once it ends
+	Deferred int
+}
+
+// Err returns the per-user failures joined into one error, or nil.
+func (r *SendRemindersResult) Err() error {
+	errs := make([]error, 0, len(r.Errors))
+	for userID, err := range r.Errors {
+		errs = append(errs, fmt.Errorf("%s: %w", userID, err))
+	}
+	return errors.Join(errs...)
+}
+
+// ServiceOption is a function that modifies a service.
+type ServiceOption func(*Service)
+
+// WithReminderConcurrency sets how many reminders are sent at once.
+func WithReminderConcurrency(n int) ServiceOption {
+	return func(s *Service) {
+		if n > 0 {
+			s.reminderConcurrency = n
+		}
+	}
+}
+
+// WithReminderTimeout sets how long a single user's reminder may take.
+func WithReminderTimeout(timeout time.Duration) ServiceOption {
+	return func(s *Service) {
+		if timeout > 0 {
+			s.reminderTimeout = timeout
+		}
+	}
+}
+
+// WithReminderFailureThreshold sets how many reminders in a channel may fail
+// in a day before its admins are alerted.
+func WithReminderFailureThreshold(n int) ServiceOption {
+	return func(s *Service) {
+		if n > 0 {
+			s.reminderFailureThreshold = n
+		}
+	}
+}
+
+// WithMetrics sets where the service records counters such as reminders
+// sent and summaries posted. The default publishes to CloudWatch.
+func WithMetrics(sink metrics.Sink) ServiceOption {
+	return func(s *Service) {
+		if sink != nil {
+			s.metrics = sink
+		}
+	}
+}
+
+// ReminderOptionsFromEnv reads REMINDER_CONCURRENCY, REMINDER_TIMEOUT (a
+// duration such as "15s") and REMINDER_FAILURE_THRESHOLD. Unset or invalid
+// values keep the defaults.
+func ReminderOptionsFromEnv() []ServiceOption {
+	var opts []ServiceOption
+	if n, err := strconv.Atoi(os.Getenv("REMINDER_CONCURRENCY")); err == nil {
+		opts = append(opts, WithReminderConcurrency(n))
+	}
+	if timeout, err := time.ParseDuration(os.Getenv("REMINDER_TIMEOUT")); err == nil {
+		opts = append(opts, WithReminderTimeout(timeout))
+	}
+	if n, err := strconv.Atoi(os.Getenv("REMINDER_FAILURE_THRESHOLD")); err == nil {
+		opts = append(opts, WithReminderFailureThreshold(n))
+	}
+	return opts
+}
+
+// sendBatch calls send for each user with at most concurrency calls in
+// flight, each limited to timeout. Users not started before ctx is done are
+// counted as skipped.
+func sendBatch(
+	ctx context.Context,
+	userIDs []string,
+	concurrency int,
+	timeout time.Duration,
+	send func(ctx context.Context, userID string) error,
+) *SendRemindersResult {
+	result := &SendRemindersResult{Errors: make(map[string]error)}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+
+	for i, userID := range userIDs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		// Stop once the deadline passes, even if a slot was free
+		if ctx.Err() != nil {
+			mu.Lock()
+			result.Skipped += len(userIDs) - i
+			mu.Unlock()
+			break
+		}
+
+		wg.Add(1)
+		go func(userID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			userCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			err := send(userCtx, userID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if errors.Is(err, errReminderDeferred) {
+				result.Deferred++
+				return
+			}
+			if errors.Is(err, errUserDeactivated) {
+				result.Skipped++
+				result.Deactivated = append(result.Deactivated, userID)
+				return
+			}
+			if err != nil {
+				result.Failed++
+				result.Errors[userID] = err
+				return
+			}
+			result.Sent++
+		}(userID)
+	}
+
+	wg.Wait()
+	return result
+}
+
+// newFailedReminder builds the record of a reminder that couldn't be sent.
+func newFailedReminder(channelID, date, userID, reminderTime string, err error, failedAt time.Time) *store.FailedReminder {
+	return &store.FailedReminder{
+		ChannelID: channelID,
+		Date:      date,
+		UserID:    userID,
+		Time:      reminderTime,
+		ErrorCode: slack.ErrorCode(err),
+		Error:     security.SanitizeLogValue(err.Error()),
+		FailedAt:  failedAt,
+	}
+}
+
+// crossesFailureThreshold reports whether added failures took a day's total
+// over threshold, so admins are alerted once rather than on every failure.
+func crossesFailureThreshold(total, added, threshold int) bool {
+	return total > threshold && total-added <= threshold
+}
+
+// recordFailedReminders saves the reminders in errs, by user ID, that
+// couldn't be sent, and alerts the channel's admins if that takes the day's
+// failures over the threshold. Errors are logged rather than returned, since
+// the reminders have already failed.
+func (s *Service) recordFailedReminders(
+	ctx context.Context,
+	config *store.ChannelConfig,
+	date, reminderTime string,
+	errs map[string]error,
+) {
+	logger := s.botCtx.Logger()
+
+	added := 0
+	for userID, err := range errs {
+		failure := newFailedReminder(config.ChannelID, date, userID, reminderTime, err, time.Now())
+		if err := s.store.SaveFailedReminder(ctx, failure); err != nil {
+			logger.Error(ctx, "Failed to save failed reminder", err,
+				botcontext.Field{Key: "user_id", Value: userID},
+			)
+			continue
+		}
+		added++
+	}
+	if added == 0 {
+		return
+	}
+
+	failures, err := s.store.ListFailedReminders(ctx, config.ChannelID, date, date)
+	if err != nil {
+		logger.Error(ctx, "Failed to list failed reminders", err,
+			botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		)
+		return
+	}
+	if !crossesFailureThreshold(len(failures), added, s.reminderFailureThreshold) {
+		return
+	}
+
+	if err := s.notifyAdmins(ctx, config, failedRemindersText(config.ChannelID, failures)); err != nil {
+		logger.Error(ctx, "Failed to alert admins about failed reminders", err,
+			botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		)
+		return
+	}
+	logger.Info(ctx, "Alerted admins about failed reminders",
+		botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		botcontext.Field{Key: "failures", Value: len(failures)},
+		botcontext.Metric("ReminderFailureAlerts", 1),
+	)
+}
+
+// failedRemindersText lists who couldn't be reminded and why, one line per
+// user with their latest error.
+func failedRemindersText(channelID string, failures []*store.FailedReminder) string {
+	seen := make(map[string]bool)
+	var lines []string
+	for _, failure := range failures {
+		if seen[failure.UserID] {
+			continue
+		}
+		seen[failure.UserID] = true
+
+		reason := failure.ErrorCode
+		if reason == "" {
+			reason = failure.Error
+		}
+		lines = append(lines, fmt.Sprintf("• <@%s>: `%s`", failure.UserID, reason))
+	}
+
+	return fmt.Sprintf(":warning: %d standup reminders in <#%s> couldn't be delivered today:\n%s\n"+
+		"Check that the bot can message these people, for example that they're in the channel.",
+		len(failures), channelID, strings.Join(lines, "\n"))
+}
+
+// TestReminder describes the reminders a test reminder stands in for.
+type TestReminder struct {
+	Times    []string // The channel's reminder times, HH:MM
+	Timezone string
+	// TemplateError is why the channel's reminder template couldn't be
+	// rendered, if it couldn't; reminders then show the template as written
+	TemplateError error
+}
+
+// SendTestReminder sends a user the channel's reminder by DM, rendered with
+// their name and language. It isn't recorded, so nobody else is reminded and
+// the day's reminders go out as usual.
+func (s *Service) SendTestReminder(ctx context.Context, teamID, channelID, userID string) (*TestReminder, error) {
+	config, err := s.store.GetChannelConfig(ctx, teamID, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel config: %w", err)
+	}
+	channel, found := s.Config(ctx).ChannelByID(channelID)
+	if !found {
+		return nil, fmt.Errorf("channel not configured: %s", security.SanitizeLogValue(channelID))
+	}
+
+	userInfo, err := s.slackClient.GetUserInfo(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
+	}
+	prefs, err := s.UserPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	today := s.now().In(channelLocation(config)).Format("2006-01-02")
+	session, err := s.store.GetSession(ctx, channelID, today)
+	if err != nil && err != store.ErrNotFound {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	reminder := newReminder(ctx, channel, userID, userInfo, config.ChannelName, prefs.Locale, today, session)
+	blocks := slack.BuildTestReminderMessage(reminder.UserName, reminder.ChannelName, channelID,
+		reminder.Template, reminder.Status)
+
+	dmChannel, err := s.slackClient.OpenDM(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open DM: %w", err)
+	}
+	if _, err := s.slackClient.PostMessage(ctx, dmChannel, slack.WithBlocks(blocks...)); err != nil {
+		return nil, fmt.Errorf("failed to send test reminder: %w", err)
+	}
+
+	_, templateErr := templates.Execute(reminder.Template,
+		templates.Vars{"UserName": reminder.UserName, "ChannelName": reminder.ChannelName})
+
+	return &TestReminder{
+		Times:         config.Schedule.ReminderTimes,
+		Timezone:      config.Schedule.Timezone,
+		TemplateError: templateErr,
+	}, nil
+}
@@ -0,0 +1,178 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestSendBatch(t *testing.T) {
+	userIDs := []string{"U0000000001", "U0000000002", "U0000000003", "U0000000004", "U0000000005"}
+
+	t.Run("bounded concurrency and partial failure", func(t *testing.T) {
+		var inFlight, peak atomic.Int32
+		result := sendBatch(context.Background(), userIDs, 2, time.Second,
+			func(ctx context.Context, userID string) error {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+
+				if userID == "U0000000003" {
+					return errors.New("user_not_found")
+				}
+				return nil
+			})
+
+		assert.LessOrEqual(t, peak.Load(), int32(2))
+		assert.Equal(t, 4, result.Sent)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, 0, result.Skipped)
+		assert.ErrorContains(t, result.Err(), "U0000000003: user_not_found")
+	})
+
+	t.Run("per-user timeout", func(t *testing.T) {
+		result := sendBatch(context.Background(), userIDs[:1], 1, time.Millisecond,
+			func(ctx context.Context, userID string) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})
+
+		assert.Equal(t, 1, result.Failed)
+		assert.ErrorIs(t, result.Errors["U0000000001"], context.DeadlineExceeded)
+	})
+
+	t.Run("deactivated users are skipped", func(t *testing.T) {
+		result := sendBatch(context.Background(), userIDs[:2], 1, time.Second,
+			func(ctx context.Context, userID string) error {
+				if userID == "U0000000002" {
+					return errUserDeactivated
+				}
+				return nil
+			})
+
+		assert.Equal(t, 1, result.Sent)
+		assert.Equal(t, 1, result.Skipped)
+		assert.Equal(t, []string{"U0000000002"}, result.Deactivated)
+		assert.NoError(t, result.Err())
+	})
+
+	t.Run("deadline skips remaining users", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		result := sendBatch(ctx, userIDs, 1, time.Second,
+			func(ctx context.Context, userID string) error {
+				cancel()
+				return nil
+			})
+
+		assert.Equal(t, 1, result.Sent)
+		assert.Equal(t, len(userIDs)-1, result.Skipped)
+		assert.NoError(t, result.Err())
+	})
+}
+
+func TestNewFailedReminder(t *testing.T) {
+	err := fmt.Errorf("failed to open DM: %w", &slack.APIError{Code: "cannot_dm_bot"})
+	failure := newFailedReminder("C1234567890", "2024-01-15", "U0000000001", "09:00", err, time.Now())
+	assert.Equal(t, "cannot_dm_bot", failure.ErrorCode)
+	assert.Equal(t, "failed to open DM: slack API error: cannot_dm_bot", failure.Error)
+
+	failure = newFailedReminder("C1234567890", "2024-01-15", "U0000000001", "09:00", context.DeadlineExceeded, time.Now())
+	assert.Empty(t, failure.ErrorCode)
+	assert.Equal(t, "context deadline exceeded", failure.Error)
+}
+
+func TestCrossesFailureThreshold(t *testing.T) {
+	assert.False(t, crossesFailureThreshold(3, 1, 3))
+	assert.True(t, crossesFailureThreshold(4, 1, 3))
+	assert.True(t, crossesFailureThreshold(5, 5, 3))
+	// Admins were already alerted
+	assert.False(t, crossesFailureThreshold(5, 1, 3))
+}
+
+func TestFailedRemindersText(t *testing.T) {
+	text := failedRemindersText("C1234567890", []*store.FailedReminder{
+		{UserID: "U0000000001", ErrorCode: "cannot_dm_bot"},
+		{UserID: "U0000000002", Error: "context deadline exceeded"},
+		{UserID: "U0000000001", ErrorCode: "user_not_found"},
+	})
+	assert.Contains(t, text, "3 standup reminders in <#C1234567890>")
+	assert.Contains(t, text, "• <@U0000000001>: `cannot_dm_bot`\n• <@U0000000002>: `context deadline exceeded`\n")
+	assert.NotContains(t, text, "user_not_found")
+}
+
+func TestSendTestReminder(t *testing.T) {
+	newService := func(template string) *testService {
+		s := newTestService(t, withConfig(fmt.Sprintf(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+    templates:
+      reminder: %q
+`, template)))
+		require.NoError(t, s.data.SaveChannelConfig(s.ctx, &store.ChannelConfig{
+			TeamID:      "T1234567890",
+			ChannelID:   "C1234567890",
+			ChannelName: "engineering",
+			Enabled:     true,
+			Users:       []string{"U1111111111", "U2222222222"},
+			Schedule: store.ScheduleConfig{
+				Timezone:      "America/New_York",
+				ReminderTimes: []string{"09:30", "11:00"},
+			},
+		}))
+		s.client.AddUser(&slack.UserInfo{ID: "U1111111111", Name: "alice"})
+		return s
+	}
+
+	s := newService("Hi {{.UserName}}, standup time in #{{.ChannelName}}")
+	ctx := s.ctx
+	test, err := s.SendTestReminder(ctx, "T1234567890", "C1234567890", "U1111111111")
+	require.NoError(t, err)
+	assert.Equal(t, &TestReminder{Times: []string{"09:30", "11:00"}, Timezone: "America/New_York"}, test)
+
+	// Only the admin is messaged, and the reminder isn't recorded
+	posted := s.client.Calls("chat.postMessage")
+	require.Len(t, posted, 1)
+	assert.Equal(t, "DU1111111111", posted[0].Channel)
+	assert.Contains(t, blockText(posted[0].Message.Blocks), "Hi alice, standup time in #engineering")
+	reminders, err := s.data.ListReminders(ctx, "C1234567890", time.Now().Format("2006-01-02"))
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+
+	// Broken templates are sent as written and reported
+	s = newService("Hi {{.Nickname}}")
+	test, err = s.SendTestReminder(ctx, "T1234567890", "C1234567890", "U1111111111")
+	require.NoError(t, err)
+	assert.Error(t, test.TemplateError)
+	assert.Contains(t, blockText(s.client.Calls("chat.postMessage")[0].Message.Blocks), "Hi {{.Nickname}}")
+}
+
+// blockText joins the text of the section blocks in a message.
+func blockText(blocks []slack.Block) string {
+	var texts []string
+	for _, block := range blocks {
+		if section, ok := block.(*slack.SectionBlock); ok && section.Text != nil {
+			texts = append(texts, section.Text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,69 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+// ReviewStandup returns the screen showing a user their answers before the
+// standup is submitted, in channels that review answers. It returns nil when
+// the answers should be submitted right away, and an *InvalidAnswersError
+// for answers that break their questions' rules.
+func (s *Service) ReviewStandup(
+	ctx context.Context,
+	metadata *slack.StandupModalMetadata,
+	answers map[string]slack.Answer,
+) (*slack.Modal, error) {
+	channel, found := s.Config(ctx).ChannelByID(metadata.ChannelID)
+	if !found || !channel.ReviewAnswers() {
+		return nil, nil
+	}
+
+	questions := questionsOn(channel, metadata.Date)
+	if errs := slack.ValidateAnswers(questions, answers, metadata.Locale); len(errs) > 0 {
+		return nil, &InvalidAnswersError{Errors: errs}
+	}
+
+	modal, ok := slack.BuildStandupReview(metadata, questions, answers)
+	if !ok {
+		s.botCtx.Logger().Info(ctx, "Answers too long to review, submitting them",
+			botcontext.Field{Key: "channel_id", Value: metadata.ChannelID},
+		)
+		return nil, nil
+	}
+
+	return modal, nil
+}
+
+// EditStandup takes a user from the review screen back to their answers.
+// Nothing changes if the screen changed since the click, e.g. after a second
+// click on the edit button.
+func (s *Service) EditStandup(ctx context.Context, view *slack.View) error {
+	metadata, err := slack.ParseModalMetadata(view.PrivateMetadata)
+	if err != nil {
+		return err
+	}
+
+	channel, found := s.Config(ctx).ChannelByID(metadata.ChannelID)
+	if !found {
+		return fmt.Errorf("channel not configured: %s", security.SanitizeLogValue(metadata.ChannelID))
+	}
+
+	answers := metadata.Answers
+	metadata.Answers = nil
+
+	modal := slack.BuildStandupModalWithAnswers(metadata, questionsOn(channel, metadata.Date), answers)
+	err = s.slackClient.UpdateModalWithHash(ctx, view.ID, view.Hash, modal)
+	if slack.ErrorCode(err) == "hash_conflict" {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update modal: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package standup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+func TestReviewAndEditStandup(t *testing.T) {
+	s := newTestService(t, withConfig(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+    review_answers: true
+    questions:
+      - text: "Yesterday?"
+        min_length: 5
+`))
+	ctx := s.ctx
+	metadata := &slack.StandupModalMetadata{ChannelID: "C1234567890", Date: "2024-01-15"}
+
+	// Answers are checked before they're reviewed
+	_, err := s.ReviewStandup(ctx, metadata, map[string]slack.Answer{"question_0": {Value: "PRs"}})
+	var invalid *InvalidAnswersError
+	require.ErrorAs(t, err, &invalid)
+	assert.Contains(t, invalid.Errors, "question_0")
+
+	review, err := s.ReviewStandup(ctx, metadata, map[string]slack.Answer{"question_0": {Value: "Reviewed PRs"}})
+	require.NoError(t, err)
+	require.NotNil(t, review)
+
+	// Editing redraws the form unless the review screen changed since
+	view := &slack.View{ID: "V1234567890", Hash: "hash-1", PrivateMetadata: review.PrivateMetadata}
+	s.client.FailNextWithCode("views.update", "hash_conflict")
+	require.NoError(t, s.EditStandup(ctx, view))
+	require.NoError(t, s.EditStandup(ctx, view))
+
+	calls := s.client.Calls("views.update")
+	require.Len(t, calls, 2)
+	assert.Equal(t, slack.StandupCallbackID, calls[1].Modal.CallbackID)
+	for _, block := range calls[1].Modal.Blocks {
+		if input, ok := block.(slack.InputBlock); ok {
+			assert.Equal(t, "Reviewed PRs", input.Element.(slack.PlainTextInputElement).InitialValue)
+		}
+	}
+}
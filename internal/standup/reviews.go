@@ -0,0 +1,113 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/analytics"
+	"github.com/synaptiq/standup-bot/internal/authz"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// reviewReaction is the emoji leads react to a summary with to mark it
+// reviewed, besides its button.
+const reviewReaction = "eyes"
+
+// ReviewSummary records a lead acknowledging a channel's summary for date,
+// and updates the summary to show who reviewed it.
+func (s *Service) ReviewSummary(ctx context.Context, channelID, date, userID string) error {
+	if err := s.store.MarkSessionReviewed(ctx, channelID, date, userID); err != nil {
+		return fmt.Errorf("failed to mark summary reviewed: %w", err)
+	}
+
+	s.botCtx.Logger().Info(ctx, "Summary reviewed",
+		botcontext.Field{Key: "channel_id", Value: channelID},
+		botcontext.Field{Key: "date", Value: date},
+		botcontext.Field{Key: "user_id", Value: userID},
+		botcontext.Metric("SummariesReviewed", 1),
+	)
+
+	return s.updateDailySummary(ctx, channelID, date)
+}
+
+// summaryReviews counts the summaries posted between the stats' start and
+// end dates that leads reviewed, with the button or a 👀 reaction.
+func (s *Service) summaryReviews(
+	ctx context.Context,
+	config *store.ChannelConfig,
+	stats *analytics.ChannelStats,
+) (*analytics.ReviewStats, error) {
+	reviews := &analytics.ReviewStats{Reviewers: make(map[string]int)}
+	if stats.ActiveDays == 0 {
+		return reviews, nil
+	}
+
+	sessions, err := s.store.ListSessions(ctx, config.ChannelID, stats.StartDate, stats.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	for _, session := range sessions {
+		if !session.SummaryPosted {
+			continue
+		}
+		reviews.Summaries++
+
+		reviewers := s.summaryReviewers(ctx, session)
+		if len(reviewers) > 0 {
+			reviews.Reviewed++
+		}
+		for _, userID := range reviewers {
+			reviews.Reviewers[userID]++
+		}
+	}
+
+	return reviews, nil
+}
+
+// summaryReviewers returns the leads who acknowledged a session's summary:
+// those who clicked its button, and channel or workspace admins who reacted
+// with 👀. Reactions that can't be fetched are logged and left out.
+func (s *Service) summaryReviewers(ctx context.Context, session *store.Session) []string {
+	reviewers := slices.Clone(session.ReviewedBy)
+	if session.SummaryTS == "" {
+		return reviewers
+	}
+
+	reactions, err := s.slackClient.GetReactions(ctx, session.ChannelID, session.SummaryTS)
+	if err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to get summary reactions", err,
+			botcontext.Field{Key: "channel_id", Value: session.ChannelID},
+			botcontext.Field{Key: "date", Value: session.Date},
+		)
+		return reviewers
+	}
+
+	leads := authz.NewAuthorizer(s, s.slackClient)
+	for _, reaction := range reactions {
+		if reaction.Name != reviewReaction {
+			continue
+		}
+		for _, userID := range reaction.Users {
+			if slices.Contains(reviewers, userID) {
+				continue
+			}
+			err := leads.Require(ctx, session.ChannelID, userID, authz.RoleChannelAdmin)
+			if errors.Is(err, authz.ErrForbidden) {
+				continue
+			}
+			if err != nil {
+				s.botCtx.Logger().Error(ctx, "Failed to check reviewer", err,
+					botcontext.Field{Key: "user_id", Value: userID},
+				)
+				continue
+			}
+			reviewers = append(reviewers, userID)
+		}
+	}
+
+	return reviewers
+}
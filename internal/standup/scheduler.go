@@ -2,26 +2,38 @@ package standup
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/analytics"
+	"github.com/synaptiq/standup-bot/internal/holiday"
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/slack"
 	"github.com/synaptiq/standup-bot/internal/store"
 )
 
 // Scheduler handles scheduled standup tasks.
 type Scheduler struct {
-	service *Service
-	botCtx  botcontext.BotContext
-	store   store.Store
+	service   *Service
+	botCtx    botcontext.BotContext
+	store     store.Store
+	analytics *analytics.Engine
+	holidays  *holiday.Calendar
 }
 
 // NewScheduler creates a new scheduler.
 func NewScheduler(service *Service, botCtx botcontext.BotContext, store store.Store) *Scheduler {
 	return &Scheduler{
-		service: service,
-		botCtx:  botCtx,
-		store:   store,
+		service:   service,
+		botCtx:    botCtx,
+		store:     store,
+		analytics: analytics.NewEngine(store),
+		holidays:  holiday.NewCalendar(nil, holiday.DefaultRefreshInterval),
 	}
 }
 
@@ -33,7 +45,9 @@ func (s *Scheduler) ProcessScheduledTasks(ctx context.Context) error {
 	// Get all active channel configurations
 	configs, err := s.store.ListActiveChannelConfigs(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to list active configs: %w", err)
+		err = fmt.Errorf("failed to list active configs: %w", err)
+		s.service.recordHeartbeat(ctx, store.HeartbeatScheduler, "", err)
+		return err
 	}
 
 	logger.Info(ctx, "Processing scheduled tasks",
@@ -42,34 +56,116 @@ func (s *Scheduler) ProcessScheduledTasks(ctx context.Context) error {
 	)
 
 	for _, config := range configs {
-		// Skip if not an active day
-		if !s.isActiveDay(config, now) {
+		// Each channel's tasks run as its workspace
+		ctx, ok := s.service.WithTeam(ctx, config.TeamID)
+		if !ok {
 			continue
 		}
 
-		// Get channel's local time
-		channelTime := s.getChannelTime(config, now)
+		s.processChannel(ctx, config, now)
+	}
 
-		// Process reminders
-		if err := s.processReminders(ctx, config, channelTime); err != nil {
-			logger.Error(ctx, "Failed to process reminders", err,
-				botcontext.Field{Key: "channel_id", Value: config.ChannelID},
-			)
-		}
+	s.service.recordHeartbeat(ctx, store.HeartbeatScheduler, "", nil)
+	return nil
+}
 
-		// Process daily summary
-		if err := s.processDailySummary(ctx, config, channelTime); err != nil {
-			logger.Error(ctx, "Failed to process summary", err,
-				botcontext.Field{Key: "channel_id", Value: config.ChannelID},
-			)
-		}
+// ProcessChannel processes the tasks of one channel that need to run at the
+// current time, for invocations by the channel's own schedule.
+func (s *Scheduler) ProcessChannel(ctx context.Context, teamID, channelID string) error {
+	ctx, ok := s.service.WithTeam(ctx, teamID)
+	if !ok {
+		return fmt.Errorf("workspace not served: %s", security.SanitizeLogValue(teamID))
+	}
+
+	config, err := s.store.GetChannelConfig(ctx, teamID, channelID)
+	if err == store.ErrNotFound {
+		// The channel was removed; its schedule is stale
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get channel config: %w", err)
+	}
+	if !config.Active() {
+		return nil
 	}
 
+	s.processChannel(ctx, config, time.Now())
 	return nil
 }
 
-// isActiveDay checks if today is an active day for the channel.
-func (s *Scheduler) isActiveDay(config *store.ChannelConfig, now time.Time) bool {
+// processChannel runs the channel's tasks that are due at now.
+func (s *Scheduler) processChannel(ctx context.Context, config *store.ChannelConfig, now time.Time) {
+	// Get channel's local time
+	channelTime := s.getChannelTime(config, now)
+	runs := s.loadScheduledRuns(ctx, config.ChannelID)
+
+	if s.isActiveDay(ctx, config, now) {
+		s.processDailyTasks(ctx, config, runs, channelTime)
+	}
+
+	// Digests may be scheduled outside the channel's active days
+	if err := s.processDigests(ctx, config, runs, channelTime); err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to process digests", err,
+			botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		)
+	}
+}
+
+// processDailyTasks starts the day's session and sends due reminders and the
+// daily summary for an active day.
+func (s *Scheduler) processDailyTasks(ctx context.Context, config *store.ChannelConfig, runs scheduledRuns, channelTime time.Time) {
+	logger := s.botCtx.Logger()
+
+	// Skip people who are out of office before anyone is reminded
+	if err := s.processCalendarSync(ctx, config, runs, channelTime); err != nil {
+		logger.Error(ctx, "Failed to sync out-of-office calendars", err,
+			botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		)
+	}
+
+	// Start the session first so reminders sent this run see its anchor
+	err := s.processSessionStart(ctx, config, runs, channelTime)
+	if errors.Is(err, errChannelArchived) {
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "Failed to start session", err,
+			botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		)
+	}
+
+	// Process reminders
+	if err := s.processReminders(ctx, config, runs, channelTime); err != nil {
+		logger.Error(ctx, "Failed to process reminders", err,
+			botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		)
+	}
+
+	// Escalate users who ignored their reminders
+	if err := s.processEscalations(ctx, config, channelTime); err != nil {
+		logger.Error(ctx, "Failed to process escalations", err,
+			botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		)
+	}
+
+	// Re-send snoozed reminders that are due
+	if err := s.service.SendDueSnoozedReminders(ctx, config, channelTime); err != nil {
+		logger.Error(ctx, "Failed to process snoozed reminders", err,
+			botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		)
+	}
+
+	// Process daily summary
+	if err := s.processDailySummary(ctx, config, runs, channelTime); err != nil {
+		logger.Error(ctx, "Failed to process summary", err,
+			botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		)
+	}
+}
+
+// isActiveDay checks if today is an active day for the channel and not one
+// of its holidays.
+func (s *Scheduler) isActiveDay(ctx context.Context, config *store.ChannelConfig, now time.Time) bool {
 	// Convert to channel's timezone
 	loc, err := time.LoadLocation(config.Schedule.Timezone)
 	if err != nil {
@@ -87,68 +183,181 @@ func (s *Scheduler) isActiveDay(config *store.ChannelConfig, now time.Time) bool
 		"Sat": time.Saturday,
 	}
 
+	active := false
 	for _, activeDay := range config.Schedule.ActiveDays {
 		if day, ok := dayMap[activeDay]; ok && day == weekday {
-			return true
+			active = true
+			break
 		}
 	}
+	if !active {
+		return false
+	}
 
-	return false
+	isHoliday, err := s.holidays.IsHoliday(ctx, config.Schedule.Holidays, channelTime.Format("2006-01-02"))
+	if errors.Is(err, holiday.ErrMalformedEvents) {
+		s.botCtx.Logger().Warn(ctx, "Skipped malformed events in holiday feed",
+			botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+			botcontext.Field{Key: "error", Value: err.Error()},
+		)
+	} else if err != nil {
+		// Fall back to the explicit dates rather than skipping the day
+		s.botCtx.Logger().Error(ctx, "Failed to check holiday feed", err,
+			botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		)
+	}
+
+	return !isHoliday
 }
 
 // getChannelTime converts current time to channel's timezone.
 func (s *Scheduler) getChannelTime(config *store.ChannelConfig, now time.Time) time.Time {
+	return now.In(channelLocation(config))
+}
+
+// channelLocation returns the channel's timezone.
+func channelLocation(config *store.ChannelConfig) *time.Location {
 	loc, err := time.LoadLocation(config.Schedule.Timezone)
 	if err != nil {
 		// Default to UTC if timezone is invalid
-		return now.UTC()
+		return time.UTC
 	}
-	return now.In(loc)
+	return loc
 }
 
-// processReminders checks and sends reminders if it's time.
-func (s *Scheduler) processReminders(ctx context.Context, config *store.ChannelConfig, channelTime time.Time) error {
-	currentTimeStr := channelTime.Format("15:04")
+// processCalendarSync records skips for users who are out of office today at
+// the calendar integration's sync time.
+func (s *Scheduler) processCalendarSync(ctx context.Context, config *store.ChannelConfig, runs scheduledRuns, channelTime time.Time) error {
+	calendar := s.service.Config(ctx).Integrations().Calendar
+	if calendar == nil {
+		return nil
+	}
 
-	for _, reminderTime := range config.Schedule.ReminderTimes {
-		if !s.isTimeMatch(currentTimeStr, reminderTime) {
-			continue
+	return s.runIfDue(ctx, config, runs, taskCalendarSync, calendar.SyncTime, channelTime, func() error {
+		_, err := s.service.SyncOutOfOffice(ctx, config, channelTime.Format("2006-01-02"))
+		return err
+	})
+}
+
+// processSessionStart starts the day's session, posting its thread anchor,
+// at the channel's start time. Channels archived in Slack are paused instead,
+// returning errChannelArchived.
+func (s *Scheduler) processSessionStart(ctx context.Context, config *store.ChannelConfig, runs scheduledRuns, channelTime time.Time) error {
+	return s.runIfDue(ctx, config, runs, taskSessionStart, sessionStartTime(&config.Schedule), channelTime, func() error {
+		if s.service.pauseIfArchived(ctx, config) {
+			return errChannelArchived
 		}
 
-		// Check if we've already sent reminders for this time today
-		today := channelTime.Format("2006-01-02")
-		reminders, err := s.store.ListReminders(ctx, config.ChannelID, today)
-		if err != nil {
-			return fmt.Errorf("failed to list reminders: %w", err)
+		// StartStandupSession returns the existing session if one was started
+		if _, err := s.service.StartStandupSession(ctx, config.ChannelID); err != nil {
+			return fmt.Errorf("failed to start session: %w", err)
 		}
+		return nil
+	})
+}
 
-		// Check if we've already sent reminders for this time
-		alreadySent := false
-		for _, reminder := range reminders {
-			if reminder.Time == reminderTime {
-				alreadySent = true
-				break
-			}
+// processReminders checks and sends reminders if it's time.
+func (s *Scheduler) processReminders(ctx context.Context, config *store.ChannelConfig, runs scheduledRuns, channelTime time.Time) error {
+	// Users may have chosen their own time instead of the channel's
+	reminderTimes := slices.Concat(config.Schedule.ReminderTimes, s.service.preferredReminderTimes(ctx, config))
+	slices.Sort(reminderTimes)
+
+	var errs []error
+	for _, reminderTime := range slices.Compact(reminderTimes) {
+		err := s.runIfDue(ctx, config, runs, taskReminder+reminderTime, reminderTime, channelTime, func() error {
+			return s.sendReminders(ctx, config, reminderTime, channelTime)
+		})
+		if err != nil {
+			errs = append(errs, err)
 		}
+	}
 
-		if !alreadySent {
-			if err := s.service.SendReminders(ctx, config.ChannelID, reminderTime); err != nil {
-				return fmt.Errorf("failed to send reminders: %w", err)
-			}
+	return errors.Join(errs...)
+}
+
+// sendReminders sends the reminders for reminderTime unless they were
+// already sent today.
+func (s *Scheduler) sendReminders(ctx context.Context, config *store.ChannelConfig, reminderTime string, channelTime time.Time) error {
+	today := channelTime.Format("2006-01-02")
+	reminders, err := s.store.ListReminders(ctx, config.ChannelID, today)
+	if err != nil {
+		return fmt.Errorf("failed to list reminders: %w", err)
+	}
+
+	for _, reminder := range reminders {
+		if reminder.Time == reminderTime {
+			return nil
 		}
 	}
 
+	if _, err := s.service.SendReminders(ctx, config.ChannelID, reminderTime); err != nil {
+		return fmt.Errorf("failed to send reminders: %w", err)
+	}
 	return nil
 }
 
-// processDailySummary checks and posts summary if it's time.
-func (s *Scheduler) processDailySummary(ctx context.Context, config *store.ChannelConfig, channelTime time.Time) error {
-	currentTimeStr := channelTime.Format("15:04")
+// processEscalations escalates users who still haven't responded after the
+// number of reminders set by the channel's escalation policy.
+func (s *Scheduler) processEscalations(ctx context.Context, config *store.ChannelConfig, channelTime time.Time) error {
+	policy := config.Schedule.Escalation
+	if policy == nil || policy.AfterReminders <= 0 {
+		return nil
+	}
+
+	today := channelTime.Format("2006-01-02")
+	reminders, err := s.store.ListReminders(ctx, config.ChannelID, today)
+	if err != nil {
+		return fmt.Errorf("failed to list reminders: %w", err)
+	}
+
+	// Count delivered reminders; pending snoozes haven't been sent yet
+	counts := make(map[string]int)
+	for _, reminder := range reminders {
+		if reminder.SnoozedUntil == nil {
+			counts[reminder.UserID]++
+		}
+	}
 
-	if !s.isTimeMatch(currentTimeStr, config.Schedule.SummaryTime) {
+	var candidates []string
+	for userID, count := range counts {
+		if count >= policy.AfterReminders {
+			candidates = append(candidates, userID)
+		}
+	}
+	if len(candidates) == 0 {
 		return nil
 	}
 
+	pending, err := s.store.GetUsersWithoutResponse(ctx, config.ChannelID, today, candidates)
+	if err != nil {
+		return fmt.Errorf("failed to get missing users: %w", err)
+	}
+	pending, err = s.service.excludeSkippedUsers(ctx, config.ChannelID, today, pending)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range pending {
+		if err := s.service.EscalateUser(ctx, config, today, userID, counts[userID]); err != nil {
+			s.botCtx.Logger().Error(ctx, "Failed to escalate user", err,
+				botcontext.Field{Key: "user_id", Value: userID},
+				botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+			)
+		}
+	}
+
+	return nil
+}
+
+// processDailySummary checks and posts summary if it's time.
+func (s *Scheduler) processDailySummary(ctx context.Context, config *store.ChannelConfig, runs scheduledRuns, channelTime time.Time) error {
+	return s.runIfDue(ctx, config, runs, taskSummary, config.Schedule.SummaryTime, channelTime, func() error {
+		return s.postDailySummary(ctx, config, channelTime)
+	})
+}
+
+// postDailySummary posts the day's summary unless it was already posted.
+func (s *Scheduler) postDailySummary(ctx context.Context, config *store.ChannelConfig, channelTime time.Time) error {
 	// Check if summary already posted today
 	today := channelTime.Format("2006-01-02")
 	session, err := s.store.GetSession(ctx, config.ChannelID, today)
@@ -158,7 +367,9 @@ func (s *Scheduler) processDailySummary(ctx context.Context, config *store.Chann
 
 	// Post summary if not already posted
 	if session == nil || !session.SummaryPosted {
-		if err := s.service.PostDailySummary(ctx, config.ChannelID); err != nil {
+		err := s.service.PostDailySummary(ctx, config.ChannelID)
+		s.service.recordHeartbeat(ctx, store.HeartbeatSummary, config.ChannelID, err)
+		if err != nil {
 			return fmt.Errorf("failed to post summary: %w", err)
 		}
 	}
@@ -166,19 +377,294 @@ func (s *Scheduler) processDailySummary(ctx context.Context, config *store.Chann
 	return nil
 }
 
-// This allows for a 1-minute window to handle timing variations.
-func (s *Scheduler) isTimeMatch(currentTime, scheduledTime string) bool {
-	// Parse times
-	current, err1 := time.Parse("15:04", currentTime)
-	scheduled, err2 := time.Parse("15:04", scheduledTime)
+// processDigests posts weekly and monthly digests, and the streak
+// leaderboard, when they are due.
+func (s *Scheduler) processDigests(ctx context.Context, config *store.ChannelConfig, runs scheduledRuns, channelTime time.Time) error {
+	if weekly := s.weeklyDigestSchedule(ctx, config); weekly != nil && s.isWeeklyDigestDay(weekly, channelTime) {
+		err := s.runIfDue(ctx, config, runs, taskWeeklyDigest, weekly.Time, channelTime, func() error {
+			year, week := channelTime.ISOWeek()
+			periodKey := fmt.Sprintf("%d-W%02d", year, week)
+			return s.postDigest(ctx, config, weekly, store.DigestWeekly, periodKey, channelTime, 7)
+		})
+		if err != nil {
+			return err
+		}
+	}
 
-	if err1 != nil || err2 != nil {
-		return false
+	if monthly := config.Schedule.MonthlyDigest; monthly != nil && s.isMonthlyDigestDay(monthly, channelTime) {
+		err := s.runIfDue(ctx, config, runs, taskMonthlyDigest, monthly.Time, channelTime, func() error {
+			return s.postDigest(ctx, config, monthly, store.DigestMonthly, channelTime.Format("2006-01"), channelTime,
+				monthWindowDays(channelTime))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if streaks := config.Schedule.Streaks; streaks != nil && streaks.Leaderboard != nil &&
+		s.isMonthlyDigestDay(streaks.Leaderboard, channelTime) {
+		err := s.runIfDue(ctx, config, runs, taskLeaderboard, streaks.Leaderboard.Time, channelTime, func() error {
+			return s.postLeaderboard(ctx, config, streaks.Leaderboard, channelTime)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// monthWindowDays returns how many days there are in the month ending at
+// channelTime, the window of monthly digests.
+func monthWindowDays(channelTime time.Time) int {
+	periodStart := channelTime.AddDate(0, -1, 0)
+	return int(channelTime.Sub(periodStart).Hours() / 24)
+}
+
+// weeklyDigestSchedule returns the channel's weekly digest schedule. Without an
+// explicit schedule, channels with analytics enabled get a digest after the
+// summary on the last active day of the week.
+func (s *Scheduler) weeklyDigestSchedule(ctx context.Context, config *store.ChannelConfig) *store.DigestSchedule {
+	if config.Schedule.WeeklyDigest != nil {
+		return config.Schedule.WeeklyDigest
+	}
+	if !s.service.Config(ctx).IsFeatureEnabled("analytics_enabled") {
+		return nil
 	}
 
-	// Check if within 1-minute window
-	diff := current.Sub(scheduled).Minutes()
-	return diff >= 0 && diff < 1
+	lastDay := ""
+	lastWeekday := time.Sunday
+	for _, activeDay := range config.Schedule.ActiveDays {
+		if weekday, ok := analytics.ParseWeekday(activeDay); ok && (lastDay == "" || weekday > lastWeekday) {
+			lastDay, lastWeekday = activeDay, weekday
+		}
+	}
+	if lastDay == "" {
+		return nil
+	}
+
+	return &store.DigestSchedule{Day: lastDay, Time: config.Schedule.SummaryTime}
+}
+
+// isWeeklyDigestDay reports whether the weekly digest is posted today.
+func (s *Scheduler) isWeeklyDigestDay(schedule *store.DigestSchedule, channelTime time.Time) bool {
+	weekday, ok := analytics.ParseWeekday(schedule.Day)
+	return ok && weekday == channelTime.Weekday()
+}
+
+// isMonthlyDigestDay reports whether the monthly digest is posted today.
+func (s *Scheduler) isMonthlyDigestDay(schedule *store.DigestSchedule, channelTime time.Time) bool {
+	if strings.EqualFold(schedule.Day, "last") {
+		return channelTime.AddDate(0, 0, 1).Day() == 1
+	}
+	day, err := strconv.Atoi(schedule.Day)
+	return err == nil && day == channelTime.Day()
+}
+
+// postDigest posts the digest once per period, releasing its claim on the
+// period if posting fails.
+func (s *Scheduler) postDigest(
+	ctx context.Context,
+	config *store.ChannelConfig,
+	schedule *store.DigestSchedule,
+	period store.DigestPeriod,
+	periodKey string,
+	channelTime time.Time,
+	windowDays int,
+) error {
+	// Claim the period first so overlapping scheduler runs don't double post
+	record := &store.DigestRecord{
+		ChannelID: config.ChannelID,
+		Period:    period,
+		PeriodKey: periodKey,
+		PostedAt:  time.Now(),
+	}
+	if err := s.store.SaveDigestRecord(ctx, record); err != nil {
+		if err == store.ErrAlreadyExists {
+			return nil
+		}
+		return fmt.Errorf("failed to save %s digest record: %w", period, err)
+	}
+
+	if err := s.sendDigest(ctx, config, schedule, period, channelTime, windowDays); err != nil {
+		// Let the next run post it
+		if err := s.store.DeleteDigestRecord(ctx, config.ChannelID, period, periodKey); err != nil {
+			s.botCtx.Logger().Error(ctx, "Failed to release digest", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// sendDigest computes stats over the window ending today and posts them.
+func (s *Scheduler) sendDigest(
+	ctx context.Context,
+	config *store.ChannelConfig,
+	schedule *store.DigestSchedule,
+	period store.DigestPeriod,
+	channelTime time.Time,
+	windowDays int,
+) error {
+	stats, err := s.analytics.ChannelStats(ctx, config, channelTime, windowDays)
+	if err != nil {
+		return fmt.Errorf("failed to compute %s stats: %w", period, err)
+	}
+	// Blockers are response content, which private channels don't share
+	if IsPrivate(config) {
+		stats.TopBlockers = nil
+	}
+	if s.service.Config(ctx).IsFeatureEnabled("summary_reviews") {
+		stats.Reviews, err = s.service.summaryReviews(ctx, config, stats)
+		if err != nil {
+			return fmt.Errorf("failed to count %s reviews: %w", period, err)
+		}
+	}
+
+	title := "📅 Weekly Standup Digest"
+	if period == store.DigestMonthly {
+		title = "🗓️ Monthly Standup Digest — " + channelTime.Format("January 2006")
+	}
+
+	target := config.ChannelID
+	if schedule.TargetChannel != "" {
+		target = schedule.TargetChannel
+	}
+
+	blocks := analytics.BuildDigestMessage(title, stats)
+	if _, err := s.service.slackClient.PostMessage(ctx, target, slack.WithBlocks(blocks...)); err != nil {
+		return fmt.Errorf("failed to post %s digest: %w", period, err)
+	}
+
+	return nil
+}
+
+// errChannelArchived stops a channel's scheduled tasks once it's found to be
+// archived in Slack.
+var errChannelArchived = errors.New("channel archived")
+
+// catchUpWindow is how late a scheduled task still runs, e.g. when a
+// scheduler tick was delayed or the previous occurrence fell on a day without
+// standups.
+const catchUpWindow = time.Hour
+
+// Names of the scheduled tasks whose runs are recorded. Reminders are keyed
+// by their time, like "reminder#09:00".
+const (
+	taskCalendarSync  = "calendar_sync"
+	taskSessionStart  = "session_start"
+	taskReminder      = "reminder#"
+	taskSummary       = "summary"
+	taskWeeklyDigest  = "weekly_digest"
+	taskMonthlyDigest = "monthly_digest"
+	taskLeaderboard   = "leaderboard"
+)
+
+// scheduledRuns are a channel's recorded runs, by task.
+type scheduledRuns map[string]*store.ScheduledRun
+
+// loadScheduledRuns reads a channel's recorded runs. Without them, tasks
+// run only at the minute they're scheduled.
+func (s *Scheduler) loadScheduledRuns(ctx context.Context, channelID string) scheduledRuns {
+	runs := make(scheduledRuns)
+	list, err := s.store.ListScheduledRuns(ctx, channelID)
+	if err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to list scheduled runs", err,
+			botcontext.Field{Key: "channel_id", Value: channelID},
+		)
+		return runs
+	}
+	for _, run := range list {
+		runs[run.Task] = run
+	}
+	return runs
+}
+
+// runIfDue runs task if its daily clock time (HH:MM) has come in the
+// channel's timezone, and records when it next runs. channelTime is now in
+// that timezone. Each occurrence runs once, across DST changes and however
+// the scheduler's ticks fall.
+func (s *Scheduler) runIfDue(
+	ctx context.Context,
+	config *store.ChannelConfig,
+	runs scheduledRuns,
+	task, clock string,
+	channelTime time.Time,
+	run func() error,
+) error {
+	if clock == "" {
+		return nil
+	}
+
+	saved := runs[task]
+	next, err := nextRun(saved, clock, channelTime)
+	if err != nil {
+		// An invalid time never runs
+		return nil
+	}
+
+	var runErr error
+	due := !channelTime.Before(next)
+	if due {
+		runErr = run()
+		// The next occurrence is after the current minute
+		next, err = nextRunAt(clock, channelTime.Location(), channelTime.Truncate(time.Minute).Add(time.Minute))
+		if err != nil {
+			return err
+		}
+	}
+
+	if saved == nil || !saved.NextRunAt.Equal(next) || saved.Clock != clock || saved.Timezone != channelTime.Location().String() {
+		record := &store.ScheduledRun{
+			ChannelID: config.ChannelID,
+			Task:      task,
+			Clock:     clock,
+			Timezone:  channelTime.Location().String(),
+			NextRunAt: next,
+			UpdatedAt: time.Now(),
+		}
+		if err := s.store.SaveScheduledRun(ctx, record); err != nil {
+			return errors.Join(runErr, fmt.Errorf("failed to save %s run: %w", task, err))
+		}
+		runs[task] = record
+	}
+
+	return runErr
+}
+
+// nextRun returns when a task scheduled daily at clock is due, given its
+// recorded run and the time now in the channel's timezone. Unrecorded tasks,
+// or ones whose time or timezone changed, are due at the next occurrence
+// from the current minute. Runs missed by more than catchUpWindow are
+// dropped for the next occurrence within it.
+func nextRun(saved *store.ScheduledRun, clock string, now time.Time) (time.Time, error) {
+	loc := now.Location()
+	if saved == nil || saved.Clock != clock || saved.Timezone != loc.String() {
+		return nextRunAt(clock, loc, now.Truncate(time.Minute))
+	}
+	if now.Sub(saved.NextRunAt) > catchUpWindow {
+		return nextRunAt(clock, loc, now.Add(-catchUpWindow))
+	}
+	return saved.NextRunAt, nil
+}
+
+// nextRunAt returns the first time at or after from when clocks in loc show
+// clock (HH:MM). A time skipped when clocks spring forward runs once, at the
+// equivalent instant; a time repeated when they fall back runs once.
+func nextRunAt(clock string, loc *time.Location, from time.Time) (time.Time, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: %w", clock, err)
+	}
+
+	local := from.In(loc)
+	for day := 0; day <= 2; day++ {
+		candidate := time.Date(local.Year(), local.Month(), local.Day()+day, t.Hour(), t.Minute(), 0, 0, loc)
+		if !candidate.Before(from) {
+			return candidate, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("no run of %q after %s", clock, from)
 }
 
 // StartDailyStandups initializes standup sessions for all active channels.
@@ -194,7 +680,7 @@ func (s *Scheduler) StartDailyStandups(ctx context.Context) error {
 	startedCount := 0
 	for _, config := range configs {
 		// Check if today is an active day
-		if !s.isActiveDay(config, time.Now()) {
+		if !s.isActiveDay(ctx, config, time.Now()) {
 			continue
 		}
 
@@ -0,0 +1,110 @@
+package standup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestNextRunAt(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Later the same day, or else the next day
+	from := time.Date(2024, 3, 4, 8, 0, 0, 0, loc)
+	next, err := nextRunAt("09:30", loc, from)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 4, 9, 30, 0, 0, loc), next)
+
+	next, err = nextRunAt("07:30", loc, from)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 5, 7, 30, 0, 0, loc), next)
+
+	// Clocks sprang forward overnight: 09:00 is an hour earlier in UTC
+	next, err = nextRunAt("09:00", loc, time.Date(2024, 3, 9, 9, 1, 0, 0, loc))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 10, 13, 0, 0, 0, time.UTC), next.UTC())
+
+	// 02:30 doesn't exist on the day clocks spring forward, but still runs once
+	next, err = nextRunAt("02:30", loc, time.Date(2024, 3, 10, 0, 0, 0, 0, loc))
+	require.NoError(t, err)
+	assert.Equal(t, 10, next.Day())
+	next, err = nextRunAt("02:30", loc, next.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 11, 2, 30, 0, 0, loc), next)
+
+	// 01:30 happens twice on the day clocks fall back, but runs once
+	first, err := nextRunAt("01:30", loc, time.Date(2024, 11, 3, 0, 0, 0, 0, loc))
+	require.NoError(t, err)
+	next, err = nextRunAt("01:30", loc, first.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 11, 4, 1, 30, 0, 0, loc), next)
+
+	_, err = nextRunAt("9am", loc, from)
+	assert.Error(t, err)
+}
+
+func TestNextRun(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	now := time.Date(2024, 3, 11, 10, 0, 30, 0, loc)
+
+	// Unrecorded tasks run at their minute, as do changed ones
+	next, err := nextRun(nil, "10:00", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 11, 10, 0, 0, 0, loc), next)
+
+	saved := &store.ScheduledRun{Clock: "09:00", Timezone: "America/New_York", NextRunAt: time.Date(2024, 3, 12, 9, 0, 0, 0, loc)}
+	next, err = nextRun(saved, "10:00", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 11, 10, 0, 0, 0, loc), next)
+
+	// A late tick still runs a recorded run
+	saved = &store.ScheduledRun{Clock: "09:30", Timezone: "America/New_York", NextRunAt: time.Date(2024, 3, 11, 9, 30, 0, 0, loc)}
+	next, err = nextRun(saved, "09:30", now)
+	require.NoError(t, err)
+	assert.Equal(t, saved.NextRunAt, next)
+
+	// Runs missed for longer are dropped, e.g. over a weekend
+	saved.NextRunAt = time.Date(2024, 3, 9, 9, 30, 0, 0, loc)
+	next, err = nextRun(saved, "09:30", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 11, 9, 30, 0, 0, loc), next)
+
+	saved.NextRunAt = time.Date(2024, 3, 8, 8, 0, 0, 0, loc)
+	saved.Clock = "08:00"
+	next, err = nextRun(saved, "08:00", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 12, 8, 0, 0, 0, loc), next)
+}
+
+func TestPostDigestReleasesFailedClaim(t *testing.T) {
+	s := newTestService(t)
+	ctx := s.ctx
+	config := &store.ChannelConfig{
+		TeamID:    "T1234567890",
+		ChannelID: "C1234567890",
+		Enabled:   true,
+		Schedule: store.ScheduleConfig{
+			Timezone:   "UTC",
+			ActiveDays: []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+		},
+	}
+	require.NoError(t, s.data.SaveChannelConfig(ctx, config))
+
+	scheduler := NewScheduler(s.Service, s.botCtx, s.data)
+	schedule := &store.DigestSchedule{Day: "Fri", Time: "17:00"}
+	now := time.Date(2024, 1, 19, 17, 0, 0, 0, time.UTC)
+
+	// A failed post leaves the week for the next run
+	s.client.FailNextWithCode("chat.postMessage", "channel_not_found")
+	require.Error(t, scheduler.postDigest(ctx, config, schedule, store.DigestWeekly, "2024-W03", now, 7))
+
+	require.NoError(t, scheduler.postDigest(ctx, config, schedule, store.DigestWeekly, "2024-W03", now, 7))
+	require.NoError(t, scheduler.postDigest(ctx, config, schedule, store.DigestWeekly, "2024-W03", now, 7))
+	assert.Len(t, s.client.Calls("chat.postMessage"), 2)
+}
@@ -0,0 +1,153 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/schedules"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// WithSchedules keeps an EventBridge Scheduler schedule for each channel, so
+// the scheduler runs when a channel has work instead of every minute. A nil
+// client leaves the scheduler polling.
+func WithSchedules(client *schedules.Client) ServiceOption {
+	return func(s *Service) {
+		s.schedules = client
+	}
+}
+
+// scheduleTimes returns the times of day (HH:MM) the channel has scheduled
+// tasks: its start, reminders and summary, reminders at users' preferred
+// times, the calendar sync and digests.
+func (s *Service) scheduleTimes(ctx context.Context, config *store.ChannelConfig) []string {
+	times := []string{sessionStartTime(&config.Schedule), config.Schedule.SummaryTime}
+	times = append(times, config.Schedule.ReminderTimes...)
+	times = append(times, s.preferredReminderTimes(ctx, config)...)
+	if calendar := s.Config(ctx).Integrations().Calendar; calendar != nil {
+		times = append(times, calendar.SyncTime)
+	}
+	for _, digest := range []*store.DigestSchedule{config.Schedule.WeeklyDigest, config.Schedule.MonthlyDigest} {
+		if digest != nil {
+			times = append(times, digest.Time)
+		}
+	}
+
+	return slices.DeleteFunc(times, func(t string) bool { return t == "" })
+}
+
+// SyncSchedule creates or updates the channel's schedule to match its
+// config, or deletes it when the channel is disabled, archived or has
+// nothing scheduled.
+func (s *Service) SyncSchedule(ctx context.Context, config *store.ChannelConfig) error {
+	if s.schedules == nil {
+		return nil
+	}
+
+	name := schedules.Name(config.TeamID, config.ChannelID)
+	expression, err := schedules.Expression(s.scheduleTimes(ctx, config))
+	if !config.Active() || errors.Is(err, schedules.ErrNoTimes) {
+		return s.schedules.Delete(ctx, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Invalid timezones are scheduled in UTC, as the scheduler runs them
+	timezone := config.Schedule.Timezone
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+		timezone = "UTC"
+	}
+
+	return s.schedules.Put(ctx, &schedules.Schedule{
+		Name:       name,
+		Expression: expression,
+		Timezone:   timezone,
+		Event:      schedules.Event{TeamID: config.TeamID, ChannelID: config.ChannelID},
+	})
+}
+
+// syncUserSchedules updates the schedules of the channels a user is reminded
+// in, after they change their reminder time.
+func (s *Service) syncUserSchedules(ctx context.Context, userID string) error {
+	if s.schedules == nil {
+		return nil
+	}
+
+	configs, err := s.store.ListActiveChannelConfigs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list active configs: %w", err)
+	}
+
+	var errs []error
+	for _, config := range configs {
+		if !slices.Contains(config.Users, userID) {
+			continue
+		}
+		if teamID := store.TeamScope(ctx); teamID != "" && config.TeamID != teamID {
+			continue
+		}
+		if err := s.SyncSchedule(ctx, config); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// scheduleSnooze adds a one-time schedule running the channel's tasks when a
+// snoozed reminder is due, since its schedule only fires at set times.
+func (s *Service) scheduleSnooze(ctx context.Context, channelID, userID string, remindAt time.Time) error {
+	if s.schedules == nil {
+		return nil
+	}
+
+	config, err := s.store.GetChannelConfig(ctx, store.TeamScope(ctx), channelID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel config: %w", err)
+	}
+
+	return s.schedules.Put(ctx, &schedules.Schedule{
+		Name:           fmt.Sprintf("%s-snooze-%s-%d", schedules.Name(config.TeamID, channelID), userID, remindAt.Unix()),
+		Expression:     schedules.At(remindAt),
+		Timezone:       "UTC",
+		Event:          schedules.Event{TeamID: config.TeamID, ChannelID: channelID},
+		DeleteAfterRun: true,
+	})
+}
+
+// SyncSchedules brings the schedules of every active channel up to date,
+// e.g. after a deploy or a change of the workspace's calendar integration.
+func (s *Scheduler) SyncSchedules(ctx context.Context) error {
+	configs, err := s.store.ListActiveChannelConfigs(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to list active configs: %w", err)
+		s.service.recordHeartbeat(ctx, store.HeartbeatScheduler, "", err)
+		return err
+	}
+
+	synced := 0
+	for _, config := range configs {
+		ctx, ok := s.service.WithTeam(ctx, config.TeamID)
+		if !ok {
+			continue
+		}
+		if err := s.service.SyncSchedule(ctx, config); err != nil {
+			s.botCtx.Logger().Error(ctx, "Failed to sync schedule", err,
+				botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+			)
+			continue
+		}
+		synced++
+	}
+
+	s.botCtx.Logger().Info(ctx, "Synced channel schedules",
+		botcontext.Field{Key: "synced", Value: synced},
+		botcontext.Field{Key: "total_configs", Value: len(configs)},
+	)
+	s.service.recordHeartbeat(ctx, store.HeartbeatScheduler, "", nil)
+	return nil
+}
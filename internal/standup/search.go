@@ -0,0 +1,168 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// MaxSearchResults caps how many answers a search returns.
+const MaxSearchResults = 10
+
+// snippetRadius is how many characters are shown on each side of a match.
+const snippetRadius = 80
+
+// snippet returns the part of text around the first keyword it contains,
+// with the keyword in bold. Text without the keywords is shortened from
+// the start.
+func snippet(text string, keywords []string) string {
+	lower := strings.ToLower(text)
+	start, end := -1, 0
+	for _, keyword := range keywords {
+		if i := strings.Index(lower, keyword); i >= 0 && (start < 0 || i < start) {
+			start, end = i, i+len(keyword)
+		}
+	}
+	// Lowercasing can change byte lengths; fall back to the start of text
+	if start < 0 || len(lower) != len(text) {
+		return truncate(text, 2*snippetRadius)
+	}
+
+	from := max(0, start-snippetRadius)
+	for from > 0 && !utf8.RuneStart(text[from]) {
+		from--
+	}
+	to := min(len(text), end+snippetRadius)
+	for to < len(text) && !utf8.RuneStart(text[to]) {
+		to++
+	}
+
+	result := text[from:start] + "*" + text[start:end] + "*" + text[end:to]
+	if from > 0 {
+		result = "…" + result
+	}
+	if to < len(text) {
+		result += "…"
+	}
+	return result
+}
+
+// truncate shortens text to at most n runes.
+func truncate(text string, n int) string {
+	if utf8.RuneCountInString(text) <= n {
+		return text
+	}
+	return string([]rune(text)[:n]) + "…"
+}
+
+// SearchResponses searches a channel's standup answers, newest first. Each
+// result is the matching answer of a response, linked to its day's thread.
+// It reports whether there were more matches than MaxSearchResults.
+func (s *Service) SearchResponses(ctx context.Context, query *store.ResponseQuery) ([]slack.SearchResult, bool, error) {
+	limited := *query
+	limited.Limit = MaxSearchResults + 1
+
+	responses, err := s.store.SearchUserResponses(ctx, &limited)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to search responses: %w", err)
+	}
+	more := len(responses) > MaxSearchResults
+	if more {
+		responses = responses[:MaxSearchResults]
+	}
+	if len(responses) == 0 {
+		return nil, false, nil
+	}
+
+	// Link each day's thread when it has one
+	links := make(map[string]string)
+	sessions, err := s.store.ListSessions(ctx, query.ChannelID, responses[len(responses)-1].Date, responses[0].Date)
+	if err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to list sessions", err)
+	}
+	for _, session := range sessions {
+		if session.AnchorTS == "" {
+			continue
+		}
+		link, err := s.slackClient.GetPermalink(ctx, query.ChannelID, session.AnchorTS)
+		if err != nil {
+			s.botCtx.Logger().Error(ctx, "Failed to get thread link", err)
+			continue
+		}
+		links[session.Date] = link
+	}
+
+	var questions []string
+	if channel, found := s.Config(ctx).ChannelByID(query.ChannelID); found {
+		questions = channel.Questions()
+	}
+
+	keywords := query.Keywords()
+	results := make([]slack.SearchResult, 0, len(responses))
+	for _, response := range responses {
+		result := slack.SearchResult{
+			Date:   response.Date,
+			UserID: response.UserID,
+			Link:   links[response.Date],
+		}
+
+		// Show the first answer, in question order, with a keyword
+		for _, i := range answeredQuestions(response) {
+			key := slack.QuestionBlockID(i)
+			answer := response.Responses[key]
+			if !containsAny(answer, keywords) {
+				continue
+			}
+			result.Snippet = snippet(answer, keywords)
+			if typed, ok := response.Answers[key]; ok && typed.Question != "" {
+				result.Question = typed.Question
+			} else if i < len(questions) {
+				result.Question = questions[i]
+			}
+			break
+		}
+		if result.Snippet == "" {
+			// The keywords are spread over several answers
+			answers := make([]string, 0, len(response.Responses))
+			for _, i := range answeredQuestions(response) {
+				answers = append(answers, response.Responses[slack.QuestionBlockID(i)])
+			}
+			result.Snippet = truncate(strings.Join(answers, " · "), 2*snippetRadius)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, more, nil
+}
+
+// containsAny reports whether text contains any of the lowercase keywords,
+// ignoring case.
+func containsAny(text string, keywords []string) bool {
+	lower := strings.ToLower(text)
+	for _, keyword := range keywords {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// answeredQuestions returns the indexes of the questions a response
+// answers, in order.
+func answeredQuestions(response *store.UserResponse) []int {
+	indexes := make([]int, 0, len(response.Responses))
+	for key := range response.Responses {
+		if i, err := strconv.Atoi(strings.TrimPrefix(key, "question_")); err == nil {
+			indexes = append(indexes, i)
+		}
+	}
+	slices.Sort(indexes)
+	return indexes
+}
@@ -0,0 +1,33 @@
+package standup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestSnippet(t *testing.T) {
+	assert.Equal(t, "Fixed the *deploy* script", snippet("Fixed the deploy script", []string{"deploy"}))
+	assert.Equal(t, "*Deploy* failed, then deploy again", snippet("Deploy failed, then deploy again", []string{"again", "deploy"}))
+
+	long := strings.Repeat("a", 100) + " needle " + strings.Repeat("b", 100)
+	s := snippet(long, []string{"needle"})
+	assert.True(t, strings.HasPrefix(s, "…"))
+	assert.True(t, strings.HasSuffix(s, "…"))
+	assert.Contains(t, s, " *needle* ")
+
+	assert.Equal(t, "No match", snippet("No match", []string{"deploy"}))
+}
+
+func TestAnsweredQuestions(t *testing.T) {
+	response := &store.UserResponse{Responses: map[string]string{
+		"question_10": "c",
+		"question_2":  "b",
+		"question_0":  "a",
+		"yesterday":   "legacy",
+	}}
+	assert.Equal(t, []int{0, 2, 10}, answeredQuestions(response))
+}
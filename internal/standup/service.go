@@ -2,12 +2,27 @@ package standup
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 
+	botconfig "github.com/synaptiq/standup-bot/config"
 	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/analytics"
+	"github.com/synaptiq/standup-bot/internal/apperr"
+	"github.com/synaptiq/standup-bot/internal/archive"
+	"github.com/synaptiq/standup-bot/internal/configprovider"
+	"github.com/synaptiq/standup-bot/internal/i18n"
+	"github.com/synaptiq/standup-bot/internal/metrics"
+	"github.com/synaptiq/standup-bot/internal/notify"
+	"github.com/synaptiq/standup-bot/internal/outbound"
+	"github.com/synaptiq/standup-bot/internal/queue"
+	"github.com/synaptiq/standup-bot/internal/schedules"
 	"github.com/synaptiq/standup-bot/internal/security"
 	"github.com/synaptiq/standup-bot/internal/slack"
 	"github.com/synaptiq/standup-bot/internal/store"
@@ -18,21 +33,78 @@ type Service struct {
 	botCtx      botcontext.BotContext
 	store       store.Store
 	slackClient slack.Client
+	notifier    notify.Notifier   // Delivers reminders and summaries; Slack
+	mirrors     []notify.Notifier // Also receive reminders and summaries
+	metrics     metrics.Sink
+	tasks       *queue.Sender       // nil skips queued work
+	schedules   *schedules.Client   // nil leaves the scheduler polling
+	events      *outbound.Publisher // nil publishes no events
+	archiver    *archive.Archiver   // nil archives no summaries
+	degraded    map[string]string   // Capabilities the function started without
+	delivering  sync.WaitGroup      // Events being delivered in the background
+	now         func() time.Time    // Clock for snoozes, replaced in tests
+
+	reminderConcurrency      int
+	reminderTimeout          time.Duration
+	reminderFailureThreshold int
 }
 
 // NewService creates a new standup service.
-func NewService(botCtx botcontext.BotContext, store store.Store, slackClient slack.Client) *Service {
-	return &Service{
-		botCtx:      botCtx,
-		store:       store,
-		slackClient: slackClient,
+func NewService(
+	botCtx botcontext.BotContext,
+	store store.Store,
+	slackClient slack.Client,
+	opts ...ServiceOption,
+) *Service {
+	s := &Service{
+		botCtx:              botCtx,
+		store:               store,
+		slackClient:         slackClient,
+		notifier:            notify.NewSlackNotifier(slackClient),
+		metrics:             metrics.Default(),
+		now:                 time.Now,
+		reminderConcurrency: DefaultReminderConcurrency,
+		reminderTimeout:     DefaultReminderTimeout,
+
+		reminderFailureThreshold: DefaultReminderFailureThreshold,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// WithTeam scopes ctx to a workspace when the deployment serves several, so
+// the store and Slack client use that workspace's records and token. It
+// reports false for workspaces this deployment doesn't serve.
+func (s *Service) WithTeam(ctx context.Context, teamID string) (context.Context, bool) {
+	teams, ok := s.botCtx.Config().(configprovider.TeamConfigs)
+	if !ok {
+		return ctx, true
+	}
+	if _, ok := teams.ForTeam(teamID); !ok {
+		return ctx, false
+	}
+	return s.botCtx.WithTeamID(ctx, teamID), true
+}
+
+// Config returns the configuration of the workspace ctx is scoped to.
+func (s *Service) Config(ctx context.Context) botconfig.Config {
+	cfg := s.botCtx.Config()
+	if teams, ok := cfg.(configprovider.TeamConfigs); ok {
+		if teamCfg, ok := teams.ForTeam(store.TeamScope(ctx)); ok {
+			return teamCfg
+		}
 	}
+	return cfg
 }
 
 // StartStandupSession starts a new standup session for a channel.
 func (s *Service) StartStandupSession(ctx context.Context, channelID string) (*store.Session, error) {
 	logger := s.botCtx.Logger()
-	today := time.Now().Format("2006-01-02")
+	today := s.Today(ctx, channelID)
 
 	// Check if session already exists
 	existingSession, err := s.store.GetSession(ctx, channelID, today)
@@ -53,6 +125,9 @@ func (s *Service) StartStandupSession(ctx context.Context, channelID string) (*s
 		SummaryPosted: false,
 		CreatedAt:     time.Now(),
 	}
+	if channel, found := s.Config(ctx).ChannelByID(channelID); found {
+		session.GroupMembers = s.expandParticipants(ctx, channel)
+	}
 
 	if err := s.store.CreateSession(ctx, session); err != nil {
 		if err == store.ErrAlreadyExists {
@@ -67,12 +142,22 @@ func (s *Service) StartStandupSession(ctx context.Context, channelID string) (*s
 		botcontext.Field{Key: "channel_id", Value: channelID},
 	)
 
+	// Post the daily thread anchor that responses will be threaded under
+	if s.Config(ctx).IsFeatureEnabled("threading_enabled") {
+		if err := s.postStandupAnchor(ctx, session); err != nil {
+			logger.Error(ctx, "Failed to post standup anchor", err,
+				botcontext.Field{Key: "channel_id", Value: channelID},
+			)
+			// Don't fail the session if the anchor can't be posted
+		}
+	}
+
 	return session, nil
 }
 
 // OpenStandupModal opens the standup submission modal for a user.
 func (s *Service) OpenStandupModal(ctx context.Context, triggerID, channelID, userID string) error {
-	cfg := s.botCtx.Config()
+	cfg := s.Config(ctx)
 	channel, found := cfg.ChannelByID(channelID)
 	if !found {
 		return fmt.Errorf("channel not configured: %s", security.SanitizeLogValue(channelID))
@@ -88,12 +173,37 @@ func (s *Service) OpenStandupModal(ctx context.Context, triggerID, channelID, us
 		return fmt.Errorf("failed to start session: %w", err)
 	}
 
+	closed, err := s.standupClosed(ctx, session)
+	if err != nil {
+		return err
+	}
+	if closed {
+		return ErrStandupClosed
+	}
+
 	// Build and open modal
-	modal := slack.BuildStandupModal(channelID, session.SessionID, channel.Questions())
+	locale := s.UserLocale(ctx, channelID, userID)
+	metadata := &slack.StandupModalMetadata{
+		ChannelID: channelID,
+		SessionID: session.SessionID,
+		Date:      session.Date,
+		Timestamp: time.Now(),
+		Locale:    locale,
+		Plan:      s.previousPlan(ctx, channel, userID, session.Date),
+	}
+	modal := slack.BuildStandupModalWithAnswers(metadata, questionsOn(channel, session.Date), nil)
+	modal.ExternalID = fmt.Sprintf("standup:%s:%s:%d", session.SessionID, userID, time.Now().UnixNano())
 	if err := s.slackClient.OpenModal(ctx, triggerID, modal); err != nil {
 		return fmt.Errorf("failed to open modal: %w", err)
 	}
 
+	if s.tasks != nil {
+		if err := s.queueGitHubPrefill(ctx, channel, session, userID, modal.ExternalID, locale); err != nil {
+			// The modal is open; it just won't be prefilled
+			s.botCtx.Logger().Error(ctx, "Failed to queue GitHub prefill", err)
+		}
+	}
+
 	return nil
 }
 
@@ -101,86 +211,666 @@ func (s *Service) OpenStandupModal(ctx context.Context, triggerID, channelID, us
 func (s *Service) SubmitStandupResponse(ctx context.Context, submission *Submission) error {
 	logger := s.botCtx.Logger()
 
+	if err := s.validateAnswers(ctx, submission); err != nil {
+		return err
+	}
+	s.dropHiddenAnswers(ctx, submission)
+
+	// Submissions after the summary are late, until the grace period ends
+	summarySession, late, err := s.lateSubmission(ctx, submission)
+	if err != nil {
+		return err
+	}
+
 	// Create user response
+	now := time.Now()
 	response := &store.UserResponse{
-		SessionID:     submission.SessionID,
-		ChannelID:     submission.ChannelID,
-		Date:          submission.Date,
-		UserID:        submission.UserID,
-		UserName:      submission.UserName,
-		Responses:     submission.Responses,
-		SubmittedAt:   time.Now(),
-		ReminderCount: 0,
+		SessionID:      submission.SessionID,
+		ChannelID:      submission.ChannelID,
+		Date:           submission.Date,
+		UserID:         submission.UserID,
+		UserName:       submission.UserName,
+		Responses:      submission.Responses,
+		Answers:        s.typedAnswers(ctx, submission),
+		SubmittedAt:    now,
+		ReminderCount:  0,
+		Late:           late,
+		UnchangedSince: submission.OpenedAt,
+	}
+
+	// Saved with the session so its response count never drifts
+	session := &store.Session{
+		SessionID: submission.SessionID,
+		ChannelID: submission.ChannelID,
+		Date:      submission.Date,
+		Status:    store.SessionPending,
+		CreatedAt: now,
 	}
 
-	if err := s.store.SaveUserResponse(ctx, response); err != nil {
+	err = s.store.SubmitUserResponse(ctx, session, response)
+	if errors.Is(err, store.ErrConflict) {
+		logger.Info(ctx, "Standup changed since the form was opened",
+			botcontext.Field{Key: "user_id", Value: submission.UserID},
+			botcontext.Field{Key: "channel_id", Value: submission.ChannelID},
+			botcontext.Metric("SubmissionConflicts", 1),
+		)
+		return ErrSubmissionConflict
+	}
+	if err != nil {
 		return fmt.Errorf("failed to save response: %w", err)
 	}
 
 	logger.Info(ctx, "Saved standup response",
 		botcontext.Field{Key: "user_id", Value: submission.UserID},
 		botcontext.Field{Key: "channel_id", Value: submission.ChannelID},
+		botcontext.Metric("SubmissionCount", 1),
 	)
 
+	// Show the submission on the day's reminder DMs
+	if err := s.markRemindersSubmitted(ctx, submission, now); err != nil {
+		logger.Error(ctx, "Failed to update reminders", err)
+	}
+	if err := s.cancelScheduledReminders(ctx, submission.ChannelID, submission.UserID); err != nil {
+		logger.Error(ctx, "Failed to cancel scheduled reminders", err)
+	}
+
+	// Responses in private channels stay out of the channel; when in doubt,
+	// nothing is posted
+	private, err := s.privateChannelConfig(ctx, submission.ChannelID)
+	if err != nil {
+		logger.Error(ctx, "Failed to check channel privacy", err)
+	}
+	public := err == nil && private == nil
+
+	// Late additions go under the summary instead of the daily thread
+	if summarySession != nil {
+		if err := s.addLateSubmission(ctx, summarySession, submission, public); err != nil {
+			logger.Error(ctx, "Failed to add late submission", err,
+				botcontext.Field{Key: "channel_id", Value: submission.ChannelID},
+			)
+		}
+	}
+
 	// Post to channel in thread if threading is enabled
-	if s.botCtx.Config().IsFeatureEnabled("threading_enabled") {
-		if err := s.postResponseToChannel(ctx, submission); err != nil {
+	var messageTS string
+	if public && !late && s.Config(ctx).IsFeatureEnabled("threading_enabled") {
+		ts, err := s.postResponseToChannel(ctx, submission)
+		if err != nil {
 			logger.Error(ctx, "Failed to post response to channel", err)
 			// Don't fail the submission if posting fails
 		}
+		messageTS = ts
+	}
+
+	// Answers in private channels stay out of Jira too
+	if public {
+		if err := s.commentOnIssues(ctx, submission, messageTS); err != nil {
+			logger.Error(ctx, "Failed to comment on Jira issues", err,
+				botcontext.Field{Key: "channel_id", Value: submission.ChannelID},
+			)
+		}
+	}
+
+	// Cross-post blockers so leads can triage them in one place
+	if public && s.Config(ctx).IsFeatureEnabled("blockers_routing") {
+		if err := s.routeBlocker(ctx, submission, messageTS); err != nil {
+			logger.Error(ctx, "Failed to route blocker", err,
+				botcontext.Field{Key: "channel_id", Value: submission.ChannelID},
+			)
+		}
+	}
+
+	s.publish(ctx, outbound.EventResponseSubmitted, submission.ChannelID,
+		s.responseSubmittedData(ctx, submission, now, late, public))
+
+	return nil
+}
+
+// routeBlocker posts the submission's blocker, if it reported one, to the
+// configured blockers channel. messageTS is the submission's post in the
+// standup channel; without one the link points at the daily thread.
+func (s *Service) routeBlocker(ctx context.Context, submission *Submission, messageTS string) error {
+	cfg := s.Config(ctx)
+	channel, found := cfg.ChannelByID(submission.ChannelID)
+	if !found {
+		return nil
+	}
+
+	blocker := blockerAnswer(questionsOn(channel, submission.Date), submission.Responses)
+	if blocker == "" {
+		return nil
+	}
+
+	if messageTS == "" {
+		session, err := s.store.GetSession(ctx, submission.ChannelID, submission.Date)
+		if err != nil && err != store.ErrNotFound {
+			return fmt.Errorf("failed to get session: %w", err)
+		}
+		if session != nil {
+			messageTS = session.AnchorTS
+		}
+	}
+
+	var link string
+	if messageTS != "" {
+		permalink, err := s.slackClient.GetPermalink(ctx, submission.ChannelID, messageTS)
+		if err != nil {
+			// Still worth posting the blocker without the link
+			s.botCtx.Logger().Error(ctx, "Failed to get standup permalink", err)
+		}
+		link = permalink
+	}
+
+	blocks := slack.BuildBlockerMessage(submission.UserID, submission.ChannelID, blocker, link)
+	if _, err := s.slackClient.PostMessage(ctx, cfg.BlockersChannel(), slack.WithBlocks(blocks...)); err != nil {
+		return fmt.Errorf("failed to post blocker: %w", err)
 	}
 
 	return nil
 }
 
-// SendReminders sends reminders to users who haven't submitted.
-func (s *Service) SendReminders(ctx context.Context, channelID, reminderTime string) error {
+// blockerAnswer returns the blocker reported in responses, or "" if there is
+// none. Answers to questions shown only for the blocker question, e.g. the
+// details asked after a yes_no "Blocked?", are included with it.
+func blockerAnswer(questions []botconfig.Question, responses map[string]string) string {
+	key := analytics.BlockerQuestionKey(questionTexts(questions))
+	answer := responses[key]
+	if key == "" || analytics.NormalizeBlocker(answer) == "" {
+		return ""
+	}
+
+	var blockerQuestion botconfig.Question
+	for i, question := range questions {
+		if slack.QuestionBlockID(i) == key {
+			blockerQuestion = question
+		}
+	}
+
+	var parts []string
+	if blockerQuestion.Type != botconfig.QuestionYesNo {
+		parts = append(parts, answer)
+	}
+	for i, question := range questions {
+		if blockerQuestion.ID == "" || question.ShowIf == nil || question.ShowIf.Question != blockerQuestion.ID {
+			continue
+		}
+		if details := strings.TrimSpace(responses[slack.QuestionBlockID(i)]); details != "" {
+			parts = append(parts, details)
+		}
+	}
+	if len(parts) == 0 {
+		parts = append(parts, answer)
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// typedAnswers converts the submitted answers into stored answers tagged
+// with their configured question type.
+func (s *Service) typedAnswers(ctx context.Context, submission *Submission) map[string]store.Answer {
+	if len(submission.Answers) == 0 {
+		return nil
+	}
+
+	var questions []botconfig.Question
+	if channel, found := s.Config(ctx).ChannelByID(submission.ChannelID); found {
+		questions = questionsOn(channel, submission.Date)
+	}
+
+	answers := make(map[string]store.Answer, len(submission.Answers))
+	for i, question := range questions {
+		key := slack.QuestionBlockID(i)
+		answer, ok := submission.Answers[key]
+		if !ok {
+			continue
+		}
+
+		answers[key] = store.Answer{
+			Type:       string(question.Type),
+			Value:      answer.Value,
+			Values:     answer.Values,
+			Question:   question.Text,
+			QuestionID: question.ID,
+		}
+	}
+
+	return answers
+}
+
+// validateAnswers checks a submission's answers against the rules of the
+// channel's questions, returning an *InvalidAnswersError for any that fail.
+func (s *Service) validateAnswers(ctx context.Context, submission *Submission) error {
+	channel, found := s.Config(ctx).ChannelByID(submission.ChannelID)
+	if !found {
+		return nil
+	}
+
+	errs := slack.ValidateAnswers(questionsOn(channel, submission.Date), submission.Answers, submission.Locale)
+	if len(errs) > 0 {
+		return &InvalidAnswersError{Errors: errs}
+	}
+	return nil
+}
+
+// dropHiddenAnswers removes answers to conditional questions the rest of the
+// submission hides, e.g. details left over from an answer that was changed.
+func (s *Service) dropHiddenAnswers(ctx context.Context, submission *Submission) {
+	channel, found := s.Config(ctx).ChannelByID(submission.ChannelID)
+	if !found {
+		return
+	}
+
+	for i, shown := range slack.ShownQuestions(questionsOn(channel, submission.Date), submission.Answers) {
+		if !shown {
+			delete(submission.Responses, slack.QuestionBlockID(i))
+			delete(submission.Answers, slack.QuestionBlockID(i))
+		}
+	}
+}
+
+// UpdateStandupModal redraws an open standup modal after a question other
+// questions depend on was answered, showing or hiding those questions.
+func (s *Service) UpdateStandupModal(ctx context.Context, view *slack.View) error {
+	metadata, err := slack.ParseModalMetadata(view.PrivateMetadata)
+	if err != nil {
+		return err
+	}
+
+	channel, found := s.Config(ctx).ChannelByID(metadata.ChannelID)
+	if !found {
+		return fmt.Errorf("channel not configured: %s", security.SanitizeLogValue(metadata.ChannelID))
+	}
+
+	answers, err := slack.ParseModalAnswers(view)
+	if err != nil {
+		return err
+	}
+
+	// A modal changed since, by a later answer, isn't redrawn from older ones
+	modal := slack.BuildStandupModalWithAnswers(metadata, questionsOn(channel, metadata.Date), answers)
+	modal.ExternalID = view.ExternalID
+	err = s.slackClient.UpdateModalWithHash(ctx, view.ID, view.Hash, modal)
+	if slack.ErrorCode(err) == "hash_conflict" {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update modal: %w", err)
+	}
+
+	return nil
+}
+
+// SendReminders sends reminders to users who haven't submitted, several at
+// a time. Failures for individual users are logged and counted in the result
+// rather than returned.
+func (s *Service) SendReminders(ctx context.Context, channelID, reminderTime string) (*SendRemindersResult, error) {
 	logger := s.botCtx.Logger()
-	today := time.Now().Format("2006-01-02")
 
 	// Get channel configuration
-	teamID := "" // TODO: Get from channel config or workspace lookup
-
-	channelConfig, err := s.store.GetChannelConfig(ctx, teamID, channelID)
+	channelConfig, err := s.store.GetChannelConfig(ctx, store.TeamScope(ctx), channelID)
 	if err != nil {
-		return fmt.Errorf("failed to get channel config: %w", err)
+		return nil, fmt.Errorf("failed to get channel config: %w", err)
 	}
+	today := s.now().In(channelLocation(channelConfig)).Format("2006-01-02")
 
-	if !channelConfig.Enabled {
-		return nil // Skip disabled channels
+	if !channelConfig.Active() {
+		return &SendRemindersResult{}, nil // Skip disabled and archived channels
 	}
 
 	// Get users without responses
-	missingUsers, err := s.store.GetUsersWithoutResponse(ctx, channelID, today, channelConfig.Users)
+	activeUsers := slices.DeleteFunc(slices.Clone(channelConfig.Users), func(userID string) bool {
+		return slices.Contains(channelConfig.DeactivatedUsers, userID)
+	})
+	// Members of the channel's user groups were looked up when the session started
+	members, err := s.groupMembers(ctx, channelID, today)
+	if err != nil {
+		return nil, err
+	}
+	activeUsers = append(activeUsers, extraMembers(activeUsers, members)...)
+
+	// Part-timers aren't reminded on their days off
+	if day, err := time.Parse("2006-01-02", today); err == nil {
+		activeUsers = slices.DeleteFunc(activeUsers, func(userID string) bool {
+			return !channelConfig.Schedule.IsWorkingDay(userID, day.Weekday())
+		})
+	}
+
+	missingUsers, err := s.store.GetUsersWithoutResponse(ctx, channelID, today, activeUsers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get missing users: %w", err)
+	}
+
+	// Don't remind users who skipped today
+	pendingUsers, err := s.excludeSkippedUsers(ctx, channelID, today, missingUsers)
 	if err != nil {
-		return fmt.Errorf("failed to get missing users: %w", err)
+		return nil, err
 	}
 
+	// Only remind users due at this time who haven't turned reminders off
+	prefs := s.reminderPreferences(ctx)
+	pendingUsers = dueForReminder(pendingUsers, prefs, reminderTime, channelConfig.Schedule.ReminderTimes)
+
 	// Send reminders
-	for _, userID := range missingUsers {
-		if err := s.sendReminderToUser(ctx, userID, channelID, channelConfig.ChannelName, reminderTime); err != nil {
-			logger.Error(ctx, "Failed to send reminder", err,
-				botcontext.Field{Key: "user_id", Value: userID},
+	result := sendBatch(ctx, pendingUsers, s.reminderConcurrency, s.reminderTimeout,
+		func(ctx context.Context, userID string) error {
+			err := s.sendReminderToUser(ctx, userID, channelID, channelConfig.ChannelName, reminderTime,
+				reminderDelivery(prefs[userID]), preferredLocale(prefs[userID]))
+			if err != nil {
+				logger.Error(ctx, "Failed to send reminder", err,
+					botcontext.Field{Key: "user_id", Value: userID},
+					botcontext.Metric("ReminderSendFailures", 1),
+				)
+			}
+			return err
+		})
+	result.Skipped += len(missingUsers) - len(pendingUsers)
+	metrics.Count(ctx, s.metrics, "RemindersSent", float64(result.Sent))
+	metrics.Count(ctx, s.metrics, "RemindersDeferred", float64(result.Deferred))
+
+	if len(result.Errors) > 0 {
+		s.recordFailedReminders(ctx, channelConfig, today, reminderTime, result.Errors)
+	}
+
+	if len(result.Deactivated) > 0 {
+		if err := s.DeactivateUsers(ctx, channelConfig.TeamID, channelID, result.Deactivated); err != nil {
+			logger.Error(ctx, "Failed to deactivate users", err,
+				botcontext.Field{Key: "channel_id", Value: channelID},
 			)
-			continue
 		}
 	}
 
 	logger.Info(ctx, "Sent reminders",
 		botcontext.Field{Key: "channel_id", Value: channelID},
-		botcontext.Field{Key: "reminder_count", Value: len(missingUsers)},
+		botcontext.Field{Key: "sent", Value: result.Sent},
+		botcontext.Field{Key: "failed", Value: result.Failed},
+		botcontext.Field{Key: "skipped", Value: result.Skipped},
+		botcontext.Field{Key: "deferred", Value: result.Deferred},
+	)
+
+	return result, nil
+}
+
+// EscalateUser applies the channel's escalation policy to a user who hasn't
+// responded after reminderCount reminders. Each user is escalated at most
+// once per day.
+func (s *Service) EscalateUser(
+	ctx context.Context,
+	config *store.ChannelConfig,
+	date, userID string,
+	reminderCount int,
+) error {
+	policy := config.Schedule.Escalation
+
+	// Claim the escalation first so overlapping scheduler runs don't repeat it
+	record := &store.EscalationRecord{
+		ChannelID:     config.ChannelID,
+		Date:          date,
+		UserID:        userID,
+		Action:        policy.Action,
+		ReminderCount: reminderCount,
+		EscalatedAt:   time.Now(),
+	}
+	if err := s.store.SaveEscalationRecord(ctx, record); err != nil {
+		if err == store.ErrAlreadyExists {
+			return nil
+		}
+		return fmt.Errorf("failed to save escalation record: %w", err)
+	}
+
+	if err := s.escalate(ctx, config, date, userID, reminderCount); err != nil {
+		// Let the next run try again
+		if err := s.store.DeleteEscalationRecord(ctx, config.ChannelID, date, userID); err != nil {
+			s.botCtx.Logger().Error(ctx, "Failed to release escalation", err)
+		}
+		return err
+	}
+
+	s.botCtx.Logger().Info(ctx, "Escalated missing standup",
+		botcontext.Field{Key: "user_id", Value: userID},
+		botcontext.Field{Key: "channel_id", Value: config.ChannelID},
+		botcontext.Field{Key: "action", Value: string(policy.Action)},
+		botcontext.Metric("Escalations", 1),
+	)
+
+	return nil
+}
+
+// escalate takes the escalation policy's action for a user.
+func (s *Service) escalate(ctx context.Context, config *store.ChannelConfig, date, userID string, reminderCount int) error {
+	policy := config.Schedule.Escalation
+
+	switch policy.Action {
+	case store.EscalateNotifyManager:
+		dmChannel, err := s.slackClient.OpenDM(ctx, policy.ManagerID)
+		if err != nil {
+			return fmt.Errorf("failed to open manager DM: %w", err)
+		}
+
+		blocks := slack.BuildManagerEscalationMessage(userID, config.ChannelID, reminderCount)
+		if _, err := s.slackClient.PostMessage(ctx, dmChannel, slack.WithBlocks(blocks...)); err != nil {
+			return fmt.Errorf("failed to notify manager: %w", err)
+		}
+
+	case store.EscalatePublicNudge:
+		// Nudge in the daily thread if there is one
+		opts := []slack.MessageOption{slack.WithBlocks(slack.BuildNudgeMessage(userID)...)}
+		session, err := s.store.GetSession(ctx, config.ChannelID, date)
+		if err != nil && err != store.ErrNotFound {
+			return fmt.Errorf("failed to get session: %w", err)
+		}
+		if session != nil && session.AnchorTS != "" {
+			opts = append(opts, slack.WithThreadTS(session.AnchorTS))
+		}
+
+		if _, err := s.slackClient.PostMessage(ctx, config.ChannelID, opts...); err != nil {
+			return fmt.Errorf("failed to post nudge: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("unknown escalation action: %s", security.SanitizeLogValue(string(policy.Action)))
+	}
+
+	return nil
+}
+
+// SkipToday records that a user is skipping today's standup.
+func (s *Service) SkipToday(ctx context.Context, channelID, userID, reason string) error {
+	skip := &store.SkippedResponse{
+		ChannelID: channelID,
+		Date:      s.Today(ctx, channelID),
+		UserID:    userID,
+		Reason:    reason,
+		SkippedAt: time.Now(),
+	}
+
+	if err := s.store.SaveSkippedResponse(ctx, skip); err != nil {
+		return fmt.Errorf("failed to save skip: %w", err)
+	}
+
+	if err := s.cancelScheduledReminders(ctx, channelID, userID); err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to cancel scheduled reminders", err)
+	}
+
+	s.botCtx.Logger().Info(ctx, "User skipped standup",
+		botcontext.Field{Key: "user_id", Value: userID},
+		botcontext.Field{Key: "channel_id", Value: channelID},
+		botcontext.Field{Key: "reason", Value: security.SanitizeLogValue(reason)},
 	)
 
 	return nil
 }
 
-// PostDailySummary posts the daily standup summary.
+// channelDate returns the date at t in a channel's timezone, which its
+// sessions, responses, skips and reminders are kept under.
+func (s *Service) channelDate(ctx context.Context, channelID string, t time.Time) string {
+	return t.In(s.location(ctx, channelID)).Format("2006-01-02")
+}
+
+// location returns the timezone a channel's schedule runs in: the stored
+// channel config's, as the scheduler uses, or else the config file's.
+// Channels that aren't configured use UTC.
+func (s *Service) location(ctx context.Context, channelID string) *time.Location {
+	if config, err := s.store.GetChannelConfig(ctx, store.TeamScope(ctx), channelID); err == nil {
+		return channelLocation(config)
+	}
+	if channel, found := s.Config(ctx).ChannelByID(channelID); found && channel.Timezone() != nil {
+		return channel.Timezone()
+	}
+	return time.UTC
+}
+
+// Today returns the current date in a channel's timezone (YYYY-MM-DD). Every
+// per-day record of the channel is kept under it.
+func (s *Service) Today(ctx context.Context, channelID string) string {
+	return s.channelDate(ctx, channelID, s.now())
+}
+
+// SnoozeReminder schedules another reminder for a user after the given delay.
+func (s *Service) SnoozeReminder(ctx context.Context, channelID, userID string, delay time.Duration) (time.Time, error) {
+	now := s.now()
+	remindAt := now.Add(delay)
+
+	// The snooze record is keyed on its own time slot; delivering it overwrites
+	// the record and clears SnoozedUntil.
+	reminder := &store.Reminder{
+		ChannelID:    channelID,
+		Date:         s.channelDate(ctx, channelID, now),
+		UserID:       userID,
+		Time:         "snooze-" + remindAt.Format("15:04"),
+		SentAt:       now,
+		SnoozedUntil: &remindAt,
+	}
+
+	if err := s.store.SaveReminder(ctx, reminder); err != nil {
+		return time.Time{}, fmt.Errorf("failed to save snooze: %w", err)
+	}
+
+	if err := s.scheduleSnooze(ctx, channelID, userID, remindAt); err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to schedule snoozed reminder", err,
+			botcontext.Field{Key: "channel_id", Value: channelID},
+		)
+	}
+
+	return remindAt, nil
+}
+
+// SendDueSnoozedReminders re-sends reminders whose snooze period has elapsed.
+func (s *Service) SendDueSnoozedReminders(ctx context.Context, config *store.ChannelConfig, now time.Time) error {
+	today := now.In(channelLocation(config)).Format("2006-01-02")
+
+	reminders, err := s.store.ListReminders(ctx, config.ChannelID, today)
+	if err != nil {
+		return fmt.Errorf("failed to list reminders: %w", err)
+	}
+
+	var prefs map[string]*store.UserPreferences
+	for _, reminder := range reminders {
+		if reminder.SnoozedUntil == nil || now.Before(*reminder.SnoozedUntil) {
+			continue
+		}
+		if slices.Contains(config.DeactivatedUsers, reminder.UserID) {
+			continue
+		}
+
+		// Skip users who submitted or skipped while snoozed
+		pending, err := s.store.GetUsersWithoutResponse(ctx, config.ChannelID, today, []string{reminder.UserID})
+		if err != nil {
+			return fmt.Errorf("failed to check response: %w", err)
+		}
+		pending, err = s.excludeSkippedUsers(ctx, config.ChannelID, today, pending)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		if prefs == nil {
+			prefs = s.reminderPreferences(ctx)
+		}
+
+		// Snoozing is done from a DM, so the reminder comes back as one
+		err = s.sendReminderToUser(ctx, reminder.UserID, config.ChannelID, config.ChannelName, reminder.Time,
+			store.DeliverDM, preferredLocale(prefs[reminder.UserID]))
+		switch {
+		case err == nil:
+			metrics.Count(ctx, s.metrics, "RemindersSent", 1)
+		case errors.Is(err, errReminderDeferred):
+			err = nil // Sent once the user's Do Not Disturb ends
+		case errors.Is(err, errUserDeactivated):
+			err = s.DeactivateUsers(ctx, config.TeamID, config.ChannelID, []string{reminder.UserID})
+		default:
+			s.recordFailedReminders(ctx, config, today, reminder.Time, map[string]error{reminder.UserID: err})
+		}
+		if err != nil {
+			s.botCtx.Logger().Error(ctx, "Failed to send snoozed reminder", err,
+				botcontext.Field{Key: "user_id", Value: reminder.UserID},
+				botcontext.Metric("ReminderSendFailures", 1),
+			)
+		}
+	}
+
+	return nil
+}
+
+// excludeSkippedUsers removes users who skipped the given day from userIDs.
+func (s *Service) excludeSkippedUsers(ctx context.Context, channelID, date string, userIDs []string) ([]string, error) {
+	skips, err := s.store.ListSkippedResponses(ctx, channelID, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list skipped responses: %w", err)
+	}
+	if len(skips) == 0 {
+		return userIDs, nil
+	}
+
+	skipped := make(map[string]bool, len(skips))
+	for _, skip := range skips {
+		skipped[skip.UserID] = true
+	}
+
+	remaining := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if !skipped[userID] {
+			remaining = append(remaining, userID)
+		}
+	}
+
+	return remaining, nil
+}
+
+// ErrSummaryPosted is returned by PostSummaryNow when today's summary is
+// already posted and posting isn't forced.
+var ErrSummaryPosted = errors.New("summary already posted")
+
+// PostDailySummary posts the daily standup summary, unless it's already
+// posted. Failed copies to summary recipients are only logged, since the
+// summary itself is posted and mustn't be posted again.
 func (s *Service) PostDailySummary(ctx context.Context, channelID string) error {
+	err := s.PostSummaryNow(ctx, channelID, false)
+	if errors.Is(err, ErrSummaryPosted) {
+		s.botCtx.Logger().Info(ctx, "Summary already posted",
+			botcontext.Field{Key: "channel_id", Value: channelID},
+		)
+		return nil
+	}
+	var copyErr *SummaryCopyError
+	if errors.As(err, &copyErr) {
+		return nil
+	}
+	return err
+}
+
+// PostSummaryNow posts today's summary without waiting for the scheduled
+// time, e.g. after a failed run. A summary that's already posted is only
+// posted again when forced; late submissions then update the new one. The
+// summary is copied to the channel's summary recipients too; if some copies
+// fail, a *SummaryCopyError is returned once everything else is done.
+func (s *Service) PostSummaryNow(ctx context.Context, channelID string, force bool) error {
 	logger := s.botCtx.Logger()
-	today := time.Now().Format("2006-01-02")
+	today := s.Today(ctx, channelID)
 
-	// Get session
-	session, err := s.store.GetSession(ctx, channelID, today)
+	// Get session and responses together
+	session, responses, err := s.store.GetSessionWithResponses(ctx, channelID, today)
 	if err != nil && err != store.ErrNotFound {
 		return fmt.Errorf("failed to get session: %w", err)
 	}
@@ -191,65 +881,76 @@ func (s *Service) PostDailySummary(ctx context.Context, channelID string) error
 		if err != nil {
 			return fmt.Errorf("failed to create session: %w", err)
 		}
-	}
 
-	// Check if summary already posted
-	if session.SummaryPosted {
-		logger.Info(ctx, "Summary already posted",
-			botcontext.Field{Key: "channel_id", Value: channelID},
-		)
-		return nil
+		responses, err = s.store.ListUserResponses(ctx, channelID, today)
+		if err != nil {
+			return fmt.Errorf("failed to list responses: %w", err)
+		}
 	}
 
-	// Get all responses
-	responses, err := s.store.ListUserResponses(ctx, channelID, today)
-	if err != nil {
-		return fmt.Errorf("failed to list responses: %w", err)
+	// Check if summary already posted
+	if session.SummaryPosted && !force {
+		return ErrSummaryPosted
 	}
 
 	// Get channel configuration
-	cfg := s.botCtx.Config()
+	cfg := s.Config(ctx)
 	channel, found := cfg.ChannelByID(channelID)
 	if !found {
 		return fmt.Errorf("channel not configured: %s", security.SanitizeLogValue(channelID))
 	}
 
-	// Build summary
-	summaries := make([]*slack.UserResponseSummary, 0, len(channel.Users()))
-	respondedUsers := make(map[string]bool)
+	// Private channels get the full report by DM instead
+	private, err := s.privateChannelConfig(ctx, channelID)
+	if err != nil {
+		return err
+	}
 
-	for _, resp := range responses {
-		summaries = append(summaries, &slack.UserResponseSummary{
-			UserID:    resp.UserID,
-			UserName:  resp.UserName,
-			Submitted: true,
-			Time:      resp.SubmittedAt.Format("3:04 PM"),
-		})
-		respondedUsers[resp.UserID] = true
+	summary, summaries, err := s.dailySummary(ctx, channel, session, responses, private)
+	if err != nil {
+		return err
 	}
 
-	// Add missing users
-	for _, user := range channel.Users() {
-		if !respondedUsers[user.ID()] {
-			summaries = append(summaries, &slack.UserResponseSummary{
-				UserID:    user.ID(),
-				UserName:  user.Name(),
-				Submitted: false,
-			})
+	// Claim the summary first, so that overlapping runs don't both post it
+	if !force {
+		if err := s.store.ClaimSummary(ctx, channelID, today); err != nil {
+			if errors.Is(err, store.ErrAlreadyExists) {
+				return ErrSummaryPosted
+			}
+			return fmt.Errorf("failed to claim summary: %w", err)
 		}
 	}
 
-	// Post summary
-	blocks := slack.BuildSummaryMessage(today, channel.Templates().SummaryHeader(), summaries)
-	_, err = s.slackClient.PostMessage(ctx, channelID, slack.WithBlocks(blocks...))
+	summaryTS, err := s.notifier.NotifySummary(ctx, summary)
 	if err != nil {
-		return fmt.Errorf("failed to post summary: %w", err)
+		if !force {
+			// Let the next run post it
+			if err := s.store.ReleaseSummary(ctx, channelID, today); err != nil {
+				logger.Error(ctx, "Failed to release summary", err)
+			}
+		}
+		return err
+	}
+	s.mirror(ctx, func(n notify.Notifier) error {
+		_, err := n.NotifySummary(ctx, summary)
+		return err
+	})
+	s.publish(ctx, outbound.EventSummaryPosted, channelID, summaryPostedData(summary))
+	copyErr := s.copySummary(ctx, channel, summary)
+
+	if private != nil {
+		if err := s.sendPrivateReport(ctx, private, today, summaries); err != nil {
+			logger.Error(ctx, "Failed to send private report", err,
+				botcontext.Field{Key: "channel_id", Value: channelID},
+			)
+		}
 	}
 
-	// Mark summary as posted
-	if err := s.store.MarkSummaryPosted(ctx, channelID, today); err != nil {
+	// Record the summary's timestamp, keeping it to update for late
+	// submissions
+	if err := s.store.MarkSummaryPosted(ctx, channelID, today, summaryTS); err != nil {
 		logger.Error(ctx, "Failed to mark summary posted", err)
-		// Don't fail if we can't update the flag
+		// Don't fail if we can't record it
 	}
 
 	// Update session status
@@ -257,46 +958,255 @@ func (s *Service) PostDailySummary(ctx context.Context, channelID string) error
 		logger.Error(ctx, "Failed to update session status", err)
 	}
 
+	s.archiveSummary(ctx, channel, session, responses, summaries, summaryTS)
+
+	metrics.Count(ctx, s.metrics, "SummariesPosted", 1)
 	logger.Info(ctx, "Posted daily summary",
 		botcontext.Field{Key: "channel_id", Value: channelID},
 		botcontext.Field{Key: "total_users", Value: len(summaries)},
 		botcontext.Field{Key: "responded", Value: len(responses)},
 	)
 
-	return nil
+	return copyErr
 }
 
-// postResponseToChannel posts a user's response to the channel.
-func (s *Service) postResponseToChannel(ctx context.Context, submission *Submission) error {
-	cfg := s.botCtx.Config()
+// dailySummary builds the summary of a session from its responses. It also
+// returns every user's status with their answers, which the summary leaves
+// out in private channels.
+func (s *Service) dailySummary(
+	ctx context.Context,
+	channel botconfig.ChannelConfig,
+	session *store.Session,
+	responses []*store.UserResponse,
+	private *store.ChannelConfig,
+) (*notify.Summary, []*slack.UserResponseSummary, error) {
+	skips, err := s.store.ListSkippedResponses(ctx, session.ChannelID, session.Date)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list skips: %w", err)
+	}
+
+	// Build summary
+	cfg := s.Config(ctx)
+	includeAnswers := private != nil || cfg.IsFeatureEnabled("summary_include_answers")
+	users := workingUsers(channel, session.Date)
+	summaries := make([]*slack.UserResponseSummary, 0, len(users))
+	respondedUsers := make(map[string]bool)
+
+	for _, resp := range responses {
+		summary := &slack.UserResponseSummary{
+			UserID:    resp.UserID,
+			UserName:  resp.UserName,
+			Submitted: true,
+			Time:      submittedTime(resp),
+		}
+		if includeAnswers {
+			summary.Answers = summaryAnswers(questionTexts(questionsOn(channel, session.Date)), resp.Responses)
+		}
+		summaries = append(summaries, summary)
+		respondedUsers[resp.UserID] = true
+	}
+
+	skipReasons := make(map[string]string, len(skips))
+	for _, skip := range skips {
+		skipReasons[skip.UserID] = skip.Reason
+	}
+
+	// Add skipped and missing users; deactivated users and part-timers on
+	// their days off aren't missing
+	deactivated := s.deactivatedUsers(ctx, session.ChannelID)
+	addMissing := func(userID, userName string) {
+		if respondedUsers[userID] || slices.Contains(deactivated, userID) {
+			return
+		}
+		reason, skipped := skipReasons[userID]
+		summaries = append(summaries, &slack.UserResponseSummary{
+			UserID:     userID,
+			UserName:   userName,
+			Submitted:  false,
+			Skipped:    skipped,
+			SkipReason: reason,
+		})
+	}
+	for _, user := range users {
+		addMissing(user.ID(), user.Name())
+	}
+	// User group members have no configured name
+	for _, userID := range session.GroupMembers {
+		if _, configured := channel.UserByID(userID); !configured {
+			addMissing(userID, userID)
+		}
+	}
+
+	summary := &notify.Summary{
+		ChannelID:   session.ChannelID,
+		ChannelName: channel.Name(),
+		Date:        session.Date,
+		Header:      channel.Templates().SummaryHeader(),
+		Users:       summaries,
+		Review:      cfg.IsFeatureEnabled("summary_reviews"),
+		ReviewedBy:  session.ReviewedBy,
+		Locale:      messageLocale("", nil, channel),
+	}
+	s.labelUsers(ctx, channel, summaries)
+	summary.Streaks = s.countStreaks(ctx, session, summaries)
+	if private != nil {
+		summary.Users = completionOnly(summaries)
+	} else if includeAnswers {
+		summary.Grouping = slack.SummaryGrouping(cfg.SummaryGroupBy())
+		if session.AnchorTS != "" {
+			link, err := s.slackClient.GetPermalink(ctx, session.ChannelID, session.AnchorTS)
+			if err != nil {
+				s.botCtx.Logger().Error(ctx, "Failed to get thread link", err)
+			}
+			summary.ThreadLink = link
+		}
+	}
+
+	return summary, summaries, nil
+}
+
+// submittedTime formats when a response was submitted for the summary.
+func submittedTime(response *store.UserResponse) string {
+	if response.Late {
+		return response.SubmittedAt.Format("3:04 PM") + " (late)"
+	}
+	return response.SubmittedAt.Format("3:04 PM")
+}
+
+// summaryAnswers returns a user's non-empty answers in question order.
+func summaryAnswers(questions []string, responses map[string]string) []slack.SummaryAnswer {
+	var answers []slack.SummaryAnswer
+	for i, question := range questions {
+		if text := strings.TrimSpace(responses[fmt.Sprintf("question_%d", i)]); text != "" {
+			answers = append(answers, slack.SummaryAnswer{Question: question, Text: text})
+		}
+	}
+	return answers
+}
+
+// postResponseToChannel posts a user's response to the channel and returns
+// the timestamp of the posted message.
+func (s *Service) postResponseToChannel(ctx context.Context, submission *Submission) (string, error) {
+	blocks, err := s.responseBlocks(ctx, submission, "Standup Update")
+	if err != nil {
+		return "", err
+	}
+
+	// Post in the daily thread if there is one
+	opts := []slack.MessageOption{slack.WithBlocks(blocks...)}
+	session, err := s.store.GetSession(ctx, submission.ChannelID, submission.Date)
+	if err != nil && err != store.ErrNotFound {
+		return "", fmt.Errorf("failed to get session: %w", err)
+	}
+	if session != nil && session.AnchorTS != "" {
+		opts = append(opts, slack.WithThreadTS(session.AnchorTS))
+	}
+
+	messageTS, err := s.slackClient.PostMessage(ctx, submission.ChannelID, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	if session != nil && session.AnchorTS != "" {
+		if err := s.updateStandupAnchor(ctx, session); err != nil {
+			s.botCtx.Logger().Error(ctx, "Failed to update standup anchor", err)
+		}
+	}
+
+	return messageTS, nil
+}
+
+// responseBlocks builds the message showing a submission's answers under
+// a title such as "Standup Update".
+func (s *Service) responseBlocks(ctx context.Context, submission *Submission, title string) ([]slack.Block, error) {
+	cfg := s.Config(ctx)
 	channel, found := cfg.ChannelByID(submission.ChannelID)
 	if !found {
-		return fmt.Errorf("channel not configured")
+		return nil, fmt.Errorf("channel not configured")
 	}
 
 	// Build message
 	builder := slack.NewMessageBuilder()
-	builder.AddSection(fmt.Sprintf("*Standup Update from <@%s>*", security.SanitizeLogValue(submission.UserID)))
+	builder.AddSection(fmt.Sprintf("*%s from <@%s>*", title, security.SanitizeLogValue(submission.UserID)))
 
-	questions := channel.Questions()
+	questions := questionsOn(channel, submission.Date)
 	for i, question := range questions {
 		answer := submission.Responses[fmt.Sprintf("question_%d", i)]
 		if answer != "" {
-			builder.AddSection(fmt.Sprintf("*%s*\n%s", question, answer))
+			builder.AddSection(fmt.Sprintf("*%s*\n%s", question.Text, answer))
 		}
 	}
 
-	blocks := builder.Build()
+	// Link the Jira issues mentioned in the answers
+	if integration := cfg.Integrations().Jira; integration != nil {
+		if keys := issueKeys(integration, questions, submission.Responses); len(keys) > 0 {
+			builder.AddSection(issueLinks(integration, keys))
+		}
+	}
 
-	// Post to channel
-	// TODO: Post in thread if there's a daily thread
-	_, err := s.slackClient.PostMessage(ctx, submission.ChannelID, slack.WithBlocks(blocks...))
-	return err
+	return builder.Build(), nil
 }
 
-// sendReminderToUser sends a reminder DM to a user.
-func (s *Service) sendReminderToUser(ctx context.Context, userID, channelID, channelName, reminderTime string) error {
-	cfg := s.botCtx.Config()
+// postStandupAnchor posts the daily thread anchor and records it on the session.
+func (s *Service) postStandupAnchor(ctx context.Context, session *store.Session) error {
+	blocks := slack.BuildStandupAnchorMessage(session.Date, 0, s.requiredUserCount(ctx, session))
+
+	anchorTS, err := s.slackClient.PostMessage(ctx, session.ChannelID, slack.WithBlocks(blocks...))
+	if err != nil {
+		return fmt.Errorf("failed to post anchor: %w", err)
+	}
+
+	if err := s.store.SetSessionAnchor(ctx, session.ChannelID, session.Date, anchorTS); err != nil {
+		return fmt.Errorf("failed to save anchor: %w", err)
+	}
+
+	session.AnchorTS = anchorTS
+	return nil
+}
+
+// updateStandupAnchor refreshes the submission count shown on the anchor message.
+func (s *Service) updateStandupAnchor(ctx context.Context, session *store.Session) error {
+	responses, err := s.store.ListUserResponses(ctx, session.ChannelID, session.Date)
+	if err != nil {
+		return fmt.Errorf("failed to list responses: %w", err)
+	}
+
+	blocks := slack.BuildStandupAnchorMessage(session.Date, len(responses), s.requiredUserCount(ctx, session))
+	return s.slackClient.UpdateMessage(ctx, session.ChannelID, session.AnchorTS, slack.WithBlocks(blocks...))
+}
+
+// requiredUserCount returns the number of users expected to submit in a
+// session, including its user group members.
+func (s *Service) requiredUserCount(ctx context.Context, session *store.Session) int {
+	channel, found := s.Config(ctx).ChannelByID(session.ChannelID)
+	if !found {
+		return 0
+	}
+	return len(workingUsers(channel, session.Date)) + len(session.GroupMembers)
+}
+
+// workingUsers returns the channel's users who work on date, leaving out
+// part-timers on their days off.
+func workingUsers(channel botconfig.ChannelConfig, date string) []botconfig.UserConfig {
+	users := channel.Users()
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return users
+	}
+	return slices.DeleteFunc(users, func(user botconfig.UserConfig) bool {
+		return !user.IsWorkingDay(day.Weekday())
+	})
+}
+
+// sendReminderToUser reminds a user by DM or with a mention in the channel,
+// in the locale they chose, if any, or else their Slack locale.
+func (s *Service) sendReminderToUser(
+	ctx context.Context,
+	userID, channelID, channelName, reminderTime string,
+	delivery store.ReminderDelivery,
+	locale string,
+) error {
+	cfg := s.Config(ctx)
 	channel, found := cfg.ChannelByID(channelID)
 	if !found {
 		return fmt.Errorf("channel not configured")
@@ -304,41 +1214,66 @@ func (s *Service) sendReminderToUser(ctx context.Context, userID, channelID, cha
 
 	// Get user info
 	userInfo, err := s.slackClient.GetUserInfo(ctx, userID)
+	if errors.Is(err, slack.ErrUserNotFound) {
+		return errUserDeactivated
+	}
 	if err != nil {
 		return fmt.Errorf("failed to get user info: %w", err)
 	}
+	if userInfo.Deleted {
+		return errUserDeactivated
+	}
 
-	// Build reminder message
-	blocks := slack.BuildReminderMessage(userInfo.Name, channelName, channel.Templates().Reminder())
+	today := s.Today(ctx, channelID)
+	session, err := s.store.GetSession(ctx, channelID, today)
+	if err != nil && err != store.ErrNotFound {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	reminder := newReminder(ctx, channel, userID, userInfo, channelName, locale, today, session)
 
-	// Open DM and send message
-	dmChannel, err := s.slackClient.OpenDM(ctx, userID)
-	if err != nil {
-		return fmt.Errorf("failed to open DM: %w", err)
+	// Users in Do Not Disturb are reminded once it ends
+	if delivery != store.DeliverChannel && cfg.IsFeatureEnabled("respect_dnd") {
+		if err := s.deferForDND(ctx, reminder, reminderTime); err != nil {
+			return err
+		}
 	}
 
-	msgTS, err := s.slackClient.PostMessage(ctx, dmChannel, slack.WithBlocks(blocks...))
+	var msgTS string
+	if delivery == store.DeliverChannel {
+		// Channel mentions aren't updated on submission, so no timestamp is kept
+		err = s.remindInChannel(ctx, userID, channelID, session)
+	} else {
+		msgTS, err = s.notifier.NotifyReminder(ctx, reminder)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to send reminder: %w", err)
+		return err
 	}
+	s.mirror(ctx, func(n notify.Notifier) error {
+		_, err := n.NotifyReminder(ctx, reminder)
+		return err
+	})
+	s.publish(ctx, outbound.EventReminderSent, channelID, &outbound.ReminderSentData{
+		UserID:       userID,
+		Date:         today,
+		ReminderTime: reminderTime,
+	})
 
 	// Save reminder record
-	reminder := &store.Reminder{
+	record := &store.Reminder{
 		ChannelID: channelID,
-		Date:      time.Now().Format("2006-01-02"),
+		Date:      today,
 		UserID:    userID,
 		Time:      reminderTime,
 		SentAt:    time.Now(),
 		MessageTS: msgTS,
 	}
 
-	if err := s.store.SaveReminder(ctx, reminder); err != nil {
+	if err := s.store.SaveReminder(ctx, record); err != nil {
 		// Log but don't fail
 		s.botCtx.Logger().Error(ctx, "Failed to save reminder record", err)
 	}
 
 	// Increment reminder count
-	today := time.Now().Format("2006-01-02")
 	if err := s.store.IncrementReminderCount(ctx, channelID, today, userID); err != nil {
 		// Log but don't fail
 		s.botCtx.Logger().Error(ctx, "Failed to increment reminder count", err)
@@ -347,6 +1282,120 @@ func (s *Service) sendReminderToUser(ctx context.Context, userID, channelID, cha
 	return nil
 }
 
+// newReminder builds a user's reminder with the day's progress so far. The
+// session is nil before the day's standup starts.
+func newReminder(
+	ctx context.Context,
+	channel botconfig.ChannelConfig,
+	userID string,
+	userInfo *slack.UserInfo,
+	channelName, locale, today string,
+	session *store.Session,
+) *notify.Reminder {
+	status := slack.ReminderStatus{
+		Questions: askedQuestions(questionsOn(channel, today)),
+		Total:     len(workingUsers(channel, today)),
+		TeamID:    store.TeamScope(ctx),
+		Locale:    messageLocale(locale, userInfo, channel),
+	}
+	if session != nil {
+		status.Submitted = session.ResponseCount
+		status.Total += len(session.GroupMembers)
+	}
+
+	return &notify.Reminder{
+		UserID:      userID,
+		UserName:    userInfo.Name,
+		UserEmail:   userInfo.Profile.Email,
+		ChannelID:   channel.ID(),
+		ChannelName: channelName,
+		Template:    channel.Templates().Reminder(),
+		Status:      status,
+	}
+}
+
+// remindInChannel mentions a user in the standup channel, in the daily
+// thread if there is one.
+func (s *Service) remindInChannel(ctx context.Context, userID, channelID string, session *store.Session) error {
+	opts := []slack.MessageOption{
+		slack.WithText(fmt.Sprintf("<@%s>, time for your standup update!", userID)),
+		slack.WithBlocks(slack.BuildChannelReminderMessage(userID, channelID)...),
+	}
+	if session != nil && session.AnchorTS != "" {
+		opts = append(opts, slack.WithThreadTS(session.AnchorTS))
+	}
+
+	if _, err := s.slackClient.PostMessage(ctx, channelID, opts...); err != nil {
+		return fmt.Errorf("failed to post channel reminder: %w", err)
+	}
+	return nil
+}
+
+// questionsOn returns the channel's questions for date (YYYY-MM-DD), which
+// may differ by weekday. Its default questions are returned if date can't be
+// parsed.
+func questionsOn(channel botconfig.ChannelConfig, date string) []botconfig.Question {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return channel.TypedQuestions()
+	}
+	return channel.QuestionsFor(day.Weekday())
+}
+
+// questionTexts returns the text of each question.
+func questionTexts(questions []botconfig.Question) []string {
+	texts := make([]string, len(questions))
+	for i, question := range questions {
+		texts[i] = question.Text
+	}
+	return texts
+}
+
+// askedQuestions returns the text of the questions everyone is asked, leaving
+// out conditional ones.
+func askedQuestions(questions []botconfig.Question) []string {
+	asked := make([]string, 0, len(questions))
+	for _, question := range questions {
+		if question.ShowIf == nil {
+			asked = append(asked, question.Text)
+		}
+	}
+	return asked
+}
+
+// markRemindersSubmitted replaces the buttons on the day's reminder DMs to a
+// user with the time they submitted.
+func (s *Service) markRemindersSubmitted(ctx context.Context, submission *Submission, submittedAt time.Time) error {
+	reminders, err := s.store.ListReminders(ctx, submission.ChannelID, submission.Date)
+	if err != nil {
+		return fmt.Errorf("failed to list reminders: %w", err)
+	}
+
+	blocks := slack.BuildReminderSubmittedMessage(submission.ChannelID, submittedAt)
+
+	var dmChannel string
+	for _, reminder := range reminders {
+		if reminder.UserID != submission.UserID || reminder.MessageTS == "" {
+			continue
+		}
+
+		// Reminders are DMs, so the DM channel is the same for all of them
+		if dmChannel == "" {
+			dmChannel, err = s.slackClient.OpenDM(ctx, submission.UserID)
+			if err != nil {
+				return fmt.Errorf("failed to open DM: %w", err)
+			}
+		}
+
+		if err := s.slackClient.UpdateMessage(ctx, dmChannel, reminder.MessageTS,
+			slack.WithText("✅ Standup submitted"), slack.WithBlocks(blocks...)); err != nil {
+			return fmt.Errorf("failed to update reminder: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // Submission represents a standup submission.
 type Submission struct {
 	SessionID string
@@ -355,4 +1404,23 @@ type Submission struct {
 	UserID    string
 	UserName  string
 	Responses map[string]string
+	Answers   map[string]slack.Answer // Typed answers, keyed like Responses
+	Locale    i18n.Locale             // Language of the errors for invalid answers
+	// OpenedAt is when the standup form was opened. A response submitted
+	// after it, e.g. from another device, isn't replaced; zero replaces it
+	OpenedAt time.Time
+}
+
+// ErrSubmissionConflict is returned for submissions from a form opened
+// before the user's standup was last submitted, e.g. on another device.
+var ErrSubmissionConflict = apperr.New(apperr.KindConflict, "standup changed since the form was opened")
+
+// InvalidAnswersError is returned for submissions with answers that break
+// their questions' rules.
+type InvalidAnswersError struct {
+	Errors map[string]string // Messages keyed by question block ID
+}
+
+func (e *InvalidAnswersError) Error() string {
+	return fmt.Sprintf("%d invalid answers", len(e.Errors))
 }
@@ -0,0 +1,239 @@
+package standup
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestBlockerAnswer(t *testing.T) {
+	textQuestions := []botconfig.Question{
+		{Text: "Yesterday?", Type: botconfig.QuestionText},
+		{Text: "Any blockers?", Type: botconfig.QuestionText},
+	}
+	assert.Equal(t, "Waiting on the API keys", blockerAnswer(textQuestions, map[string]string{
+		"question_1": "Waiting on the API keys",
+	}))
+	assert.Equal(t, "", blockerAnswer(textQuestions, map[string]string{"question_1": "None."}))
+	assert.Equal(t, "", blockerAnswer(textQuestions[:1], map[string]string{"question_0": "Shipped it"}))
+
+	// A yes/no blocker question reports the details asked after it
+	conditional := []botconfig.Question{
+		{ID: "blocked", Text: "Any blockers?", Type: botconfig.QuestionYesNo},
+		{Text: "What's blocking you?", Type: botconfig.QuestionText,
+			ShowIf: &botconfig.Condition{Question: "blocked", Equals: []string{"yes"}}},
+	}
+	assert.Equal(t, "Flaky CI", blockerAnswer(conditional, map[string]string{
+		"question_0": "yes",
+		"question_1": "Flaky CI",
+	}))
+	assert.Equal(t, "yes", blockerAnswer(conditional, map[string]string{"question_0": "yes"}))
+	assert.Equal(t, "", blockerAnswer(conditional, map[string]string{"question_0": "no"}))
+}
+
+func TestPostSummaryNow(t *testing.T) {
+	s := newTestService(t)
+	ctx := s.ctx
+	today := time.Now().Format("2006-01-02")
+
+	// A failed post is released for the next run to retry
+	s.client.FailNext("chat.postMessage", errors.New("connection reset"))
+	require.Error(t, s.PostSummaryNow(ctx, "C1234567890", false))
+	session, err := s.data.GetSession(ctx, "C1234567890", today)
+	require.NoError(t, err)
+	assert.False(t, session.SummaryPosted)
+	s.client.Reset()
+
+	// Overlapping runs post it once
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.PostDailySummary(ctx, "C1234567890")
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, errors.Join(errs...))
+	require.Len(t, s.client.Calls("chat.postMessage"), 1)
+
+	// A posted summary isn't posted again unless forced
+	require.ErrorIs(t, s.PostSummaryNow(ctx, "C1234567890", false), ErrSummaryPosted)
+	require.NoError(t, s.PostDailySummary(ctx, "C1234567890"))
+	require.Len(t, s.client.Calls("chat.postMessage"), 1)
+	first, err := s.data.GetSession(ctx, "C1234567890", today)
+	require.NoError(t, err)
+
+	require.NoError(t, s.PostSummaryNow(ctx, "C1234567890", true))
+	require.Len(t, s.client.Calls("chat.postMessage"), 2)
+
+	// Late submissions update the newer summary
+	session, err = s.data.GetSession(ctx, "C1234567890", today)
+	require.NoError(t, err)
+	assert.True(t, session.SummaryPosted)
+	assert.NotEqual(t, first.SummaryTS, session.SummaryTS)
+}
+
+func TestPartTimersOnDaysOff(t *testing.T) {
+	s := newTestService(t, withConfig(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+    users:
+      - id: "U1111111111"
+        name: "alice"
+      - id: "U2222222222"
+        name: "bob"
+        active_days: ["Mon", "Tue", "Wed"]
+`))
+	ctx := s.ctx
+	channel, _ := s.botCtx.Config().ChannelByID("C1234567890")
+
+	missing := func(date string) []string {
+		summary, _, err := s.dailySummary(ctx, channel, &store.Session{ChannelID: "C1234567890", Date: date}, nil, nil)
+		require.NoError(t, err)
+		var userIDs []string
+		for _, user := range summary.Users {
+			userIDs = append(userIDs, user.UserID)
+		}
+		return userIDs
+	}
+
+	// Bob works Wednesdays but not Thursdays
+	assert.ElementsMatch(t, []string{"U1111111111", "U2222222222"}, missing("2026-10-14"))
+	assert.Equal(t, []string{"U1111111111"}, missing("2026-10-15"))
+	assert.Equal(t, 2, s.requiredUserCount(ctx, &store.Session{ChannelID: "C1234567890", Date: "2026-10-14"}))
+	assert.Equal(t, 1, s.requiredUserCount(ctx, &store.Session{ChannelID: "C1234567890", Date: "2026-10-15"}))
+}
+
+func TestSnoozeNearMidnightInChannelTimezone(t *testing.T) {
+	s := newTestService(t, withConfig(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "Pacific/Auckland"
+      summary_time: "10:00"
+`))
+	ctx := s.ctx
+	s.client.AddUser(&slack.UserInfo{ID: "U1111111111", Name: "alice"})
+	config := &store.ChannelConfig{
+		TeamID:      "T1234567890",
+		ChannelID:   "C1234567890",
+		ChannelName: "engineering",
+		Schedule:    store.ScheduleConfig{Timezone: "Pacific/Auckland"},
+	}
+
+	// 00:30 on the 17th in Auckland, still the 16th in UTC
+	now := time.Date(2026, 10, 16, 11, 30, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	remindAt, err := s.SnoozeReminder(ctx, "C1234567890", "U1111111111", 15*time.Minute)
+	require.NoError(t, err)
+	reminders, err := s.data.ListReminders(ctx, "C1234567890", "2026-10-17")
+	require.NoError(t, err)
+	require.Len(t, reminders, 1, "the snooze isn't kept under the channel's date")
+
+	// The scheduler passes the channel's time, but any zone names the same instant
+	require.NoError(t, s.SendDueSnoozedReminders(ctx, config, remindAt.UTC()))
+	posted := s.client.Calls("chat.postMessage")
+	require.Len(t, posted, 1)
+	assert.Equal(t, "DU1111111111", posted[0].Channel)
+}
+
+func TestDayRecordsUseChannelDate(t *testing.T) {
+	s := newTestService(t, withConfig(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "Pacific/Auckland"
+      summary_time: "10:00"
+    users:
+      - id: "U1111111111"
+        name: "alice"
+      - id: "U2222222222"
+        name: "bob"
+`))
+	ctx := s.ctx
+	require.NoError(t, s.data.SaveChannelConfig(ctx, &store.ChannelConfig{
+		TeamID:      "T1234567890",
+		ChannelID:   "C1234567890",
+		ChannelName: "engineering",
+		Enabled:     true,
+		Users:       []string{"U1111111111", "U2222222222"},
+		Schedule:    store.ScheduleConfig{Timezone: "Pacific/Auckland", ReminderTimes: []string{"09:00"}},
+	}))
+	s.client.AddUser(&slack.UserInfo{ID: "U1111111111", Name: "alice"})
+	s.client.AddUser(&slack.UserInfo{ID: "U2222222222", Name: "bob"})
+
+	// 00:30 on the 17th in Auckland, still the 16th in UTC
+	s.now = func() time.Time { return time.Date(2026, 10, 16, 11, 30, 0, 0, time.UTC) }
+	const today = "2026-10-17"
+	assert.Equal(t, today, s.Today(ctx, "C1234567890"))
+
+	session, err := s.StartStandupSession(ctx, "C1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, today, session.Date)
+
+	// Bob's skip is seen by the reminders, so only alice is reminded
+	require.NoError(t, s.SkipToday(ctx, "C1234567890", "U2222222222", "out sick"))
+	result, err := s.SendReminders(ctx, "C1234567890", "09:00")
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Sent)
+	reminders, err := s.data.ListReminders(ctx, "C1234567890", today)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, "U1111111111", reminders[0].UserID)
+
+	// As is alice's response once they submit
+	require.NoError(t, s.data.SubmitUserResponse(ctx, session, &store.UserResponse{
+		ChannelID:   "C1234567890",
+		Date:        session.Date,
+		UserID:      "U1111111111",
+		Responses:   map[string]string{"question_0": "Reviews"},
+		SubmittedAt: s.now(),
+	}))
+	result, err = s.SendReminders(ctx, "C1234567890", "09:00")
+	require.NoError(t, err)
+	assert.Zero(t, result.Sent)
+}
+
+func TestEscalateUserReleasesFailedClaim(t *testing.T) {
+	s := newTestService(t)
+	ctx := s.ctx
+
+	config := &store.ChannelConfig{
+		ChannelID: "C1234567890",
+		Schedule: store.ScheduleConfig{
+			Escalation: &store.EscalationPolicy{
+				AfterReminders: 2,
+				Action:         store.EscalateNotifyManager,
+				ManagerID:      "U9999999999",
+			},
+		},
+	}
+
+	// A failed DM leaves the escalation for the next run
+	s.client.FailNextWithCode("chat.postMessage", "channel_not_found")
+	require.Error(t, s.EscalateUser(ctx, config, "2026-10-16", "U1111111111", 2))
+
+	require.NoError(t, s.EscalateUser(ctx, config, "2026-10-16", "U1111111111", 2))
+	assert.Len(t, s.client.Calls("chat.postMessage"), 2)
+
+	// Which then escalates only once
+	require.NoError(t, s.EscalateUser(ctx, config, "2026-10-16", "U1111111111", 3))
+	assert.Len(t, s.client.Calls("chat.postMessage"), 2)
+}
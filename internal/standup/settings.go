@@ -0,0 +1,335 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/analytics"
+	"github.com/synaptiq/standup-bot/internal/apperr"
+	"github.com/synaptiq/standup-bot/internal/i18n"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// ErrInvalidSetting is returned when a channel setting is given a value it
+// can't take. Its message is meant for the user who gave it.
+var ErrInvalidSetting = apperr.New(apperr.KindInvalid, "invalid setting")
+
+// Channel settings that can be changed with "/standup config set".
+const (
+	SettingStartTime     = "start_time"
+	SettingSummaryTime   = "summary_time"
+	SettingReminderTimes = "reminder_times"
+	SettingTimezone      = "timezone"
+	SettingGracePeriod   = "grace_period"
+	SettingLocale        = "locale"
+	SettingMentions      = "mentions"
+	SettingWeeklyDigest  = "weekly_digest"
+	SettingMonthlyDigest = "monthly_digest"
+)
+
+// SettingKeys lists the channel settings that can be changed, in the order
+// they're shown.
+var SettingKeys = []string{
+	SettingStartTime, SettingSummaryTime, SettingReminderTimes, SettingTimezone, SettingGracePeriod, SettingLocale,
+	SettingMentions, SettingWeeklyDigest, SettingMonthlyDigest,
+}
+
+// ChannelSettings returns the changeable settings of a channel, keyed as in
+// SettingKeys.
+func (s *Service) ChannelSettings(ctx context.Context, teamID, channelID string) (map[string]string, error) {
+	settings, _, err := s.VersionedChannelSettings(ctx, teamID, channelID)
+	return settings, err
+}
+
+// VersionedChannelSettings returns the changeable settings of a channel with
+// the version of its config, to change them with UpdateChannelSettings.
+func (s *Service) VersionedChannelSettings(
+	ctx context.Context, teamID, channelID string,
+) (map[string]string, int64, error) {
+	config, err := s.store.GetChannelConfig(ctx, teamID, channelID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get channel config: %w", err)
+	}
+
+	return channelSettings(config), config.Version, nil
+}
+
+// channelSettings returns the changeable settings of config, keyed as in
+// SettingKeys.
+func channelSettings(config *store.ChannelConfig) map[string]string {
+	return map[string]string{
+		SettingStartTime:     sessionStartTime(&config.Schedule),
+		SettingSummaryTime:   config.Schedule.SummaryTime,
+		SettingReminderTimes: strings.Join(config.Schedule.ReminderTimes, ", "),
+		SettingTimezone:      config.Schedule.Timezone,
+		SettingGracePeriod:   config.Schedule.GracePeriod,
+		SettingLocale:        config.Schedule.Locale,
+		SettingMentions:      config.Schedule.Mentions,
+		SettingWeeklyDigest:  digestSetting(config.Schedule.WeeklyDigest),
+		SettingMonthlyDigest: digestSetting(config.Schedule.MonthlyDigest),
+	}
+}
+
+// UpdateChannelSetting sets one of a channel's settings. Times are HH:MM in
+// the channel's timezone; reminder times are comma separated. The grace
+// period is a duration like 2h, or "unlimited". The locale is a supported
+// language such as "es", or empty for English. Mentions is how summaries
+// show users: mention, name or both. Digests are a day and a time, like
+// "Fri 17:00" weekly or "last 17:00" monthly, or "off".
+func (s *Service) UpdateChannelSetting(ctx context.Context, teamID, channelID, key, value string) error {
+	config, err := s.store.GetChannelConfig(ctx, teamID, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel config: %w", err)
+	}
+
+	if err := applySetting(&config.Schedule, key, value); err != nil {
+		return err
+	}
+
+	config.UpdatedAt = time.Now()
+	if err := s.store.SaveChannelConfig(ctx, config); err != nil {
+		return fmt.Errorf("failed to save channel config: %w", err)
+	}
+
+	// The saved setting applies either way; the daily sync retries
+	if err := s.SyncSchedule(ctx, config); err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to sync schedule", err,
+			botcontext.Field{Key: "channel_id", Value: channelID},
+		)
+	}
+
+	s.botCtx.Logger().Info(ctx, "Updated channel setting",
+		botcontext.Field{Key: "channel_id", Value: channelID},
+		botcontext.Field{Key: "setting", Value: key},
+	)
+
+	return nil
+}
+
+// UpdateChannelSettings changes the settings of a channel whose config is
+// still at version, as read with VersionedChannelSettings; otherwise someone
+// changed it meanwhile and a *store.ConflictError is returned. Settings are
+// keyed as in SettingKeys, and only those that differ from the current ones
+// are changed. Either all of them are saved or, if any are invalid, none:
+// the invalid ones are reported in an *InvalidSettingsError.
+func (s *Service) UpdateChannelSettings(
+	ctx context.Context,
+	teamID, channelID string,
+	version int64,
+	settings map[string]string,
+) error {
+	config, err := s.store.GetChannelConfig(ctx, teamID, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel config: %w", err)
+	}
+	if config.Version != version {
+		return &store.ConflictError{TeamID: teamID, ChannelID: channelID, Expected: version, Current: config.Version}
+	}
+
+	current := channelSettings(config)
+	var changed []string
+	invalid := make(map[string]string)
+	for _, key := range SettingKeys {
+		value, ok := settings[key]
+		if !ok || strings.TrimSpace(value) == current[key] {
+			continue
+		}
+		if err := applySetting(&config.Schedule, key, value); err != nil {
+			invalid[key] = strings.TrimPrefix(err.Error(), ErrInvalidSetting.Error()+": ")
+			continue
+		}
+		changed = append(changed, key)
+	}
+	if len(invalid) > 0 {
+		return &InvalidSettingsError{Errors: invalid}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	config.UpdatedAt = time.Now()
+	if err := s.store.SaveChannelConfig(ctx, config); err != nil {
+		return fmt.Errorf("failed to save channel config: %w", err)
+	}
+
+	// The saved settings apply either way; the daily sync retries
+	if err := s.SyncSchedule(ctx, config); err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to sync schedule", err,
+			botcontext.Field{Key: "channel_id", Value: channelID},
+		)
+	}
+
+	s.botCtx.Logger().Info(ctx, "Updated channel settings",
+		botcontext.Field{Key: "channel_id", Value: channelID},
+		botcontext.Field{Key: "settings", Value: strings.Join(changed, ",")},
+		botcontext.Field{Key: "version", Value: config.Version},
+	)
+
+	return nil
+}
+
+// applySetting validates value and sets it on schedule.
+func applySetting(schedule *store.ScheduleConfig, key, value string) error {
+	value = strings.TrimSpace(value)
+
+	switch key {
+	case SettingStartTime:
+		startTime, err := parseClock(value)
+		if err != nil {
+			return err
+		}
+		schedule.StartTime = startTime
+
+	case SettingSummaryTime:
+		summaryTime, err := parseClock(value)
+		if err != nil {
+			return err
+		}
+		schedule.SummaryTime = summaryTime
+
+	case SettingReminderTimes:
+		var reminderTimes []string
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			reminderTime, err := parseClock(part)
+			if err != nil {
+				return err
+			}
+			reminderTimes = append(reminderTimes, reminderTime)
+		}
+		if len(reminderTimes) == 0 {
+			return fmt.Errorf("%w: give at least one reminder time", ErrInvalidSetting)
+		}
+		schedule.ReminderTimes = reminderTimes
+
+	case SettingTimezone:
+		if _, err := time.LoadLocation(value); err != nil || value == "" || strings.EqualFold(value, "local") {
+			return fmt.Errorf("%w: unknown timezone %q, use a name like America/New_York", ErrInvalidSetting, value)
+		}
+		schedule.Timezone = value
+
+	case SettingGracePeriod:
+		if value == "" || strings.EqualFold(value, "unlimited") {
+			schedule.GracePeriod = ""
+			break
+		}
+		if grace, err := time.ParseDuration(value); err != nil || grace < 0 {
+			return fmt.Errorf("%w: %q isn't a duration, use one like 2h or 30m, or unlimited", ErrInvalidSetting, value)
+		}
+		schedule.GracePeriod = value
+
+	case SettingLocale:
+		if value == "" {
+			schedule.Locale = ""
+			break
+		}
+		locale, ok := i18n.Parse(value)
+		if !ok {
+			return fmt.Errorf("%w: unknown locale %q, use one of %s", ErrInvalidSetting, value,
+				strings.Join(botconfig.Locales, ", "))
+		}
+		schedule.Locale = string(locale)
+
+	case SettingMentions:
+		value = strings.ToLower(value)
+		if value != "" && !slices.Contains(botconfig.MentionStyles, value) {
+			return fmt.Errorf("%w: unknown mention style %q, use one of %s", ErrInvalidSetting, value,
+				strings.Join(botconfig.MentionStyles, ", "))
+		}
+		schedule.Mentions = value
+
+	case SettingWeeklyDigest:
+		return applyDigestSetting(&schedule.WeeklyDigest, key, value)
+
+	case SettingMonthlyDigest:
+		return applyDigestSetting(&schedule.MonthlyDigest, key, value)
+
+	default:
+		return fmt.Errorf("%w: unknown setting %q", ErrInvalidSetting, key)
+	}
+
+	return nil
+}
+
+// digestSetting formats a digest schedule as its setting, e.g. "Fri 17:00".
+func digestSetting(digest *store.DigestSchedule) string {
+	if digest == nil {
+		return ""
+	}
+	return digest.Day + " " + digest.Time
+}
+
+// applyDigestSetting sets a digest's day and time from its setting, keeping
+// the channel it's posted to. Empty or "off" turns the digest off.
+func applyDigestSetting(digest **store.DigestSchedule, key, value string) error {
+	if value == "" || strings.EqualFold(value, "off") {
+		*digest = nil
+		return nil
+	}
+
+	example := "Fri 17:00"
+	if key == SettingMonthlyDigest {
+		example = "last 17:00"
+	}
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return fmt.Errorf("%w: %q isn't a day and time, use one like %s, or off", ErrInvalidSetting, value, example)
+	}
+
+	day := fields[0]
+	if key == SettingWeeklyDigest {
+		weekday, ok := analytics.ParseWeekday(day)
+		if !ok {
+			return fmt.Errorf("%w: %q isn't a weekday, use one like Fri", ErrInvalidSetting, day)
+		}
+		day = weekday.String()[:3]
+	} else if strings.EqualFold(day, "last") {
+		day = "last"
+	} else if n, err := strconv.Atoi(day); err == nil && n >= 1 && n <= 28 {
+		day = strconv.Itoa(n)
+	} else {
+		// Later days don't come every month
+		return fmt.Errorf("%w: %q isn't a day of the month, use 1-28 or last", ErrInvalidSetting, day)
+	}
+
+	clock, err := parseClock(fields[1])
+	if err != nil {
+		return err
+	}
+
+	updated := &store.DigestSchedule{Day: day, Time: clock}
+	if *digest != nil {
+		updated.TargetChannel = (*digest).TargetChannel
+	}
+	*digest = updated
+	return nil
+}
+
+// sessionStartTime returns when the day's session starts: the configured
+// start time, or else the earliest reminder time. It's "" if neither is set.
+func sessionStartTime(schedule *store.ScheduleConfig) string {
+	if schedule.StartTime != "" {
+		return schedule.StartTime
+	}
+	if len(schedule.ReminderTimes) == 0 {
+		return ""
+	}
+	return slices.Min(schedule.ReminderTimes)
+}
+
+// parseClock parses an HH:MM time of day, normalizing e.g. "9:30" to "09:30".
+func parseClock(value string) (string, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q isn't a time, use HH:MM like 09:30", ErrInvalidSetting, value)
+	}
+	return t.Format("15:04"), nil
+}
@@ -0,0 +1,111 @@
+package standup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestApplySetting(t *testing.T) {
+	schedule := &store.ScheduleConfig{Timezone: "UTC", SummaryTime: "10:00", ReminderTimes: []string{"09:00"}}
+
+	require.NoError(t, applySetting(schedule, SettingSummaryTime, "9:30"))
+	assert.Equal(t, "09:30", schedule.SummaryTime)
+
+	require.NoError(t, applySetting(schedule, SettingReminderTimes, "08:45, 9:15,"))
+	assert.Equal(t, []string{"08:45", "09:15"}, schedule.ReminderTimes)
+
+	require.NoError(t, applySetting(schedule, SettingTimezone, "America/New_York"))
+	assert.Equal(t, "America/New_York", schedule.Timezone)
+
+	require.NoError(t, applySetting(schedule, SettingGracePeriod, "2h"))
+	assert.Equal(t, "2h", schedule.GracePeriod)
+
+	require.NoError(t, applySetting(schedule, SettingLocale, "FR"))
+	assert.Equal(t, "fr", schedule.Locale)
+
+	// Digests keep the channel they're posted to
+	schedule.WeeklyDigest = &store.DigestSchedule{Day: "Mon", Time: "09:00", TargetChannel: "C0987654321"}
+	require.NoError(t, applySetting(schedule, SettingWeeklyDigest, "friday 5:00"))
+	assert.Equal(t, &store.DigestSchedule{Day: "Fri", Time: "05:00", TargetChannel: "C0987654321"}, schedule.WeeklyDigest)
+	require.NoError(t, applySetting(schedule, SettingMonthlyDigest, "Last 17:00"))
+	assert.Equal(t, &store.DigestSchedule{Day: "last", Time: "17:00"}, schedule.MonthlyDigest)
+	require.NoError(t, applySetting(schedule, SettingMonthlyDigest, "off"))
+	assert.Nil(t, schedule.MonthlyDigest)
+
+	for key, value := range map[string]string{
+		SettingSummaryTime:   "half past nine",
+		SettingReminderTimes: " , ",
+		SettingTimezone:      "Mars/Olympus_Mons",
+		SettingGracePeriod:   "-1h",
+		SettingLocale:        "pt",
+		SettingWeeklyDigest:  "Fri",
+		SettingMonthlyDigest: "31 17:00",
+		"active_days":        "Mon",
+	} {
+		assert.ErrorIs(t, applySetting(schedule, key, value), ErrInvalidSetting, key)
+	}
+	assert.Equal(t, "09:30", schedule.SummaryTime)
+	assert.Equal(t, "America/New_York", schedule.Timezone)
+	assert.Equal(t, "2h", schedule.GracePeriod)
+	assert.Equal(t, "Fri", schedule.WeeklyDigest.Day)
+
+	require.NoError(t, applySetting(schedule, SettingGracePeriod, "Unlimited"))
+	assert.Empty(t, schedule.GracePeriod)
+}
+
+func TestSessionStartTime(t *testing.T) {
+	schedule := &store.ScheduleConfig{ReminderTimes: []string{"09:15", "08:45"}}
+	assert.Equal(t, "08:45", sessionStartTime(schedule))
+
+	require.NoError(t, applySetting(schedule, SettingStartTime, "8:00"))
+	assert.Equal(t, "08:00", sessionStartTime(schedule))
+
+	assert.Empty(t, sessionStartTime(&store.ScheduleConfig{}))
+}
+
+func TestUpdateChannelSettings(t *testing.T) {
+	s := newTestService(t)
+	ctx := s.ctx
+	require.NoError(t, s.data.SaveChannelConfig(ctx, &store.ChannelConfig{
+		TeamID:    "T1234567890",
+		ChannelID: "C1234567890",
+		Enabled:   true,
+		Schedule:  store.ScheduleConfig{Timezone: "UTC", SummaryTime: "10:00", ReminderTimes: []string{"09:00"}},
+	}))
+
+	settings, version, err := s.VersionedChannelSettings(ctx, "T1234567890", "C1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+
+	// Nothing is saved while any setting is invalid
+	settings[SettingSummaryTime] = "9:30"
+	settings[SettingTimezone] = "Mars/Olympus_Mons"
+	err = s.UpdateChannelSettings(ctx, "T1234567890", "C1234567890", version, settings)
+	var invalid *InvalidSettingsError
+	require.ErrorAs(t, err, &invalid)
+	assert.Contains(t, invalid.Errors, SettingTimezone)
+	assert.NotContains(t, invalid.Errors, SettingSummaryTime)
+
+	settings[SettingTimezone] = "Europe/Berlin"
+	require.NoError(t, s.UpdateChannelSettings(ctx, "T1234567890", "C1234567890", version, settings))
+	config, err := s.data.GetChannelConfig(ctx, "T1234567890", "C1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, "09:30", config.Schedule.SummaryTime)
+	assert.Equal(t, "Europe/Berlin", config.Schedule.Timezone)
+	assert.Equal(t, int64(2), config.Version)
+
+	// Settings read before someone else's change don't overwrite it
+	settings[SettingSummaryTime] = "11:00"
+	err = s.UpdateChannelSettings(ctx, "T1234567890", "C1234567890", version, settings)
+	var conflict *store.ConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, int64(2), conflict.Current)
+	assert.ErrorIs(t, err, store.ErrConflict)
+	config, err = s.data.GetChannelConfig(ctx, "T1234567890", "C1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, "09:30", config.Schedule.SummaryTime)
+}
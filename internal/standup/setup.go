@@ -0,0 +1,178 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// ErrChannelConfigured is returned when setting up standups in a channel
+// that already has them.
+var ErrChannelConfigured = errors.New("channel already configured")
+
+// SetupSettingKeys lists the settings asked for when setting up a channel,
+// in the order they're shown.
+var SetupSettingKeys = []string{SettingTimezone, SettingReminderTimes, SettingSummaryTime}
+
+// DefaultSetupSettings are suggested for a channel being set up, keyed as in
+// SetupSettingKeys.
+var DefaultSetupSettings = map[string]string{
+	SettingTimezone:      "UTC",
+	SettingReminderTimes: "09:00, 09:45",
+	SettingSummaryTime:   "10:00",
+}
+
+// DefaultSetupQuestions are suggested for a channel being set up.
+var DefaultSetupQuestions = []string{
+	"What did you do yesterday?",
+	"What will you do today?",
+	"Any blockers?",
+}
+
+// setupActiveDays are the days standups set up from Slack run on.
+var setupActiveDays = []string{"Mon", "Tue", "Wed", "Thu", "Fri"}
+
+// InvalidSettingsError is returned for setups and settings changes with
+// settings that can't be used. Its messages are meant for the user who
+// entered them.
+type InvalidSettingsError struct {
+	Errors map[string]string // Messages keyed by setting
+}
+
+func (e *InvalidSettingsError) Error() string {
+	return fmt.Sprintf("%d invalid settings", len(e.Errors))
+}
+
+// PromptChannelSetup offers to set up standups in a channel the bot was added
+// to. The offer is shown only to whoever added the bot, or posted in the
+// channel if nobody did, e.g. when it was added from the channel's settings.
+// Channels that already have standups get nothing.
+func (s *Service) PromptChannelSetup(ctx context.Context, teamID, channelID, inviterID string) error {
+	configured, err := s.ChannelConfigured(ctx, teamID, channelID)
+	if err != nil || configured {
+		return err
+	}
+
+	text := fmt.Sprintf("Thanks for adding me to <#%s>! Want to run daily standups here?", channelID)
+	opts := []slack.MessageOption{slack.WithText(text), slack.WithBlocks(slack.BuildChannelSetupPrompt(channelID)...)}
+	if inviterID != "" {
+		_, err = s.slackClient.PostEphemeral(ctx, channelID, inviterID, opts...)
+	} else {
+		_, err = s.slackClient.PostMessage(ctx, channelID, opts...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to post setup prompt: %w", err)
+	}
+
+	return nil
+}
+
+// SetupChannel starts standups in a channel with the settings and questions
+// from the setup wizard. Everyone in the channel takes part, on weekdays, and
+// the user who set it up becomes its admin. Settings are keyed as in
+// SetupSettingKeys; invalid ones are reported in an *InvalidSettingsError.
+func (s *Service) SetupChannel(
+	ctx context.Context,
+	teamID, channelID, userID string,
+	settings map[string]string,
+	questions []string,
+) error {
+	configured, err := s.ChannelConfigured(ctx, teamID, channelID)
+	if err != nil {
+		return err
+	}
+	if configured {
+		return ErrChannelConfigured
+	}
+
+	config := &store.ChannelConfig{
+		TeamID:    teamID,
+		ChannelID: channelID,
+		Enabled:   true,
+		Schedule: store.ScheduleConfig{
+			ActiveDays:   slices.Clone(setupActiveDays),
+			Participants: &store.ParticipantsPolicy{ChannelMembers: true},
+		},
+		Admins:    []string{userID},
+		Questions: questions,
+		UpdatedAt: time.Now(),
+	}
+	if len(config.Questions) == 0 {
+		config.Questions = slices.Clone(DefaultSetupQuestions)
+	}
+
+	invalid := make(map[string]string)
+	for _, key := range SetupSettingKeys {
+		if err := applySetting(&config.Schedule, key, settings[key]); err != nil {
+			invalid[key] = strings.TrimPrefix(err.Error(), ErrInvalidSetting.Error()+": ")
+		}
+	}
+	if len(invalid) > 0 {
+		return &InvalidSettingsError{Errors: invalid}
+	}
+
+	if info, err := s.slackClient.GetChannelInfo(ctx, channelID); err == nil {
+		config.ChannelName = info.Name
+	} else {
+		// The name only labels messages; the ID is enough to run standups
+		s.botCtx.Logger().Error(ctx, "Failed to get channel info", err,
+			botcontext.Field{Key: "channel_id", Value: channelID},
+		)
+	}
+
+	err = s.store.SaveChannelConfig(ctx, config)
+	if errors.Is(err, store.ErrConflict) {
+		// Someone else set it up meanwhile
+		return ErrChannelConfigured
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save channel config: %w", err)
+	}
+
+	// The config is saved either way; the daily sync retries
+	if err := s.SyncSchedule(ctx, config); err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to sync schedule", err,
+			botcontext.Field{Key: "channel_id", Value: channelID},
+		)
+	}
+
+	text := fmt.Sprintf("📣 <@%s> set up daily standups here. Reminders go out on weekdays at %s (%s), "+
+		"and the summary is posted at %s. Use `/standup` to fill yours in.",
+		userID, strings.Join(config.Schedule.ReminderTimes, ", "), config.Schedule.Timezone, config.Schedule.SummaryTime)
+	if _, err := s.slackClient.PostMessage(ctx, channelID, slack.WithText(text)); err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to announce standups", err,
+			botcontext.Field{Key: "channel_id", Value: channelID},
+		)
+	}
+
+	s.botCtx.Logger().Info(ctx, "Set up channel",
+		botcontext.Field{Key: "channel_id", Value: channelID},
+		botcontext.Field{Key: "user_id", Value: userID},
+	)
+
+	return nil
+}
+
+// ChannelConfigured reports whether a channel already has standups, in the
+// bot's configuration or in the store.
+func (s *Service) ChannelConfigured(ctx context.Context, teamID, channelID string) (bool, error) {
+	if _, found := s.Config(ctx).ChannelByID(channelID); found {
+		return true, nil
+	}
+
+	_, err := s.store.GetChannelConfig(ctx, teamID, channelID)
+	if err == store.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get channel config: %w", err)
+	}
+	return true, nil
+}
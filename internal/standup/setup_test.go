@@ -0,0 +1,72 @@
+package standup
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestChannelSetup(t *testing.T) {
+	s := newTestService(t)
+	ctx := s.ctx
+	s.client.Channels["C2222222222"] = &slack.ConversationInfo{ID: "C2222222222", Name: "design"}
+
+	// Only channels without standups are offered setup, privately when someone added the bot
+	require.NoError(t, s.PromptChannelSetup(ctx, "T1234567890", "C1234567890", "U1111111111"))
+	require.NoError(t, s.PromptChannelSetup(ctx, "T1234567890", "C2222222222", "U1111111111"))
+	prompts := s.client.Calls("chat.postEphemeral")
+	require.Len(t, prompts, 1)
+	assert.Equal(t, "C2222222222", prompts[0].Channel)
+	assert.Equal(t, "U1111111111", prompts[0].User)
+	require.NoError(t, s.PromptChannelSetup(ctx, "T1234567890", "C2222222222", ""))
+	assert.Len(t, s.client.Calls("chat.postMessage"), 1)
+
+	settings := map[string]string{
+		SettingTimezone:      "Mars/Olympus",
+		SettingReminderTimes: "9:00, 9:30",
+		SettingSummaryTime:   "ten",
+	}
+	err := s.SetupChannel(ctx, "T1234567890", "C2222222222", "U1111111111", settings, nil)
+	var invalid *InvalidSettingsError
+	require.ErrorAs(t, err, &invalid)
+	assert.ElementsMatch(t, []string{SettingTimezone, SettingSummaryTime}, slices.Collect(maps.Keys(invalid.Errors)))
+	assert.NotContains(t, invalid.Errors[SettingTimezone], ErrInvalidSetting.Error())
+
+	settings[SettingTimezone] = "Europe/Berlin"
+	settings[SettingSummaryTime] = "10:00"
+	require.NoError(t, s.SetupChannel(ctx, "T1234567890", "C2222222222", "U1111111111", settings, nil))
+	config, err := s.data.GetChannelConfig(ctx, "T1234567890", "C2222222222")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChannelConfig{
+		TeamID:      "T1234567890",
+		ChannelID:   "C2222222222",
+		ChannelName: "design",
+		Enabled:     true,
+		Schedule: store.ScheduleConfig{
+			Timezone:      "Europe/Berlin",
+			SummaryTime:   "10:00",
+			ReminderTimes: []string{"09:00", "09:30"},
+			ActiveDays:    []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+			Participants:  &store.ParticipantsPolicy{ChannelMembers: true},
+		},
+		Admins:    []string{"U1111111111"},
+		Questions: DefaultSetupQuestions,
+		UpdatedAt: config.UpdatedAt,
+		Version:   1,
+	}, config)
+
+	// The channel hears about it, and isn't offered or set up again
+	posted := s.client.Calls("chat.postMessage")
+	require.Len(t, posted, 2)
+	assert.Contains(t, posted[1].Message.Text, "09:00, 09:30 (Europe/Berlin)")
+	require.NoError(t, s.PromptChannelSetup(ctx, "T1234567890", "C2222222222", "U1111111111"))
+	assert.Len(t, s.client.Calls("chat.postEphemeral"), 1)
+	err = s.SetupChannel(ctx, "T1234567890", "C2222222222", "U2222222222", settings, []string{"Today?"})
+	assert.ErrorIs(t, err, ErrChannelConfigured)
+}
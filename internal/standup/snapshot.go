@@ -0,0 +1,80 @@
+package standup
+
+import (
+	"context"
+	"time"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/archive"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// WithArchiver writes a snapshot of each day's standup to S3 once its
+// summary is posted. A nil archiver archives nothing.
+func WithArchiver(archiver *archive.Archiver) ServiceOption {
+	return func(s *Service) {
+		s.archiver = archiver
+	}
+}
+
+// archiveSummary writes a snapshot of a session with everyone's answers, as
+// its summary was posted. Failures are logged, since the summary is posted
+// regardless.
+func (s *Service) archiveSummary(
+	ctx context.Context,
+	channel botconfig.ChannelConfig,
+	session *store.Session,
+	responses []*store.UserResponse,
+	summaries []*slack.UserResponseSummary,
+	summaryTS string,
+) {
+	if s.archiver == nil {
+		return
+	}
+
+	questions := questionTexts(questionsOn(channel, session.Date))
+	byUser := make(map[string]*store.UserResponse, len(responses))
+	for _, resp := range responses {
+		byUser[resp.UserID] = resp
+	}
+
+	snapshot := &archive.Snapshot{
+		TeamID:       store.TeamScope(ctx),
+		ChannelID:    session.ChannelID,
+		ChannelName:  channel.Name(),
+		Date:         session.Date,
+		SessionID:    session.SessionID,
+		SummaryTS:    summaryTS,
+		ArchivedAt:   time.Now().UTC(),
+		Questions:    questions,
+		Participants: make([]archive.Participant, 0, len(summaries)),
+	}
+	for _, summary := range summaries {
+		participant := archive.Participant{
+			UserID:     summary.UserID,
+			UserName:   summary.UserName,
+			Submitted:  summary.Submitted,
+			Skipped:    summary.Skipped && !summary.Submitted,
+			SkipReason: summary.SkipReason,
+		}
+		if resp, ok := byUser[summary.UserID]; ok {
+			submittedAt := resp.SubmittedAt
+			participant.SubmittedAt = &submittedAt
+			participant.Late = resp.Late
+			for _, answer := range summaryAnswers(questions, resp.Responses) {
+				participant.Answers = append(participant.Answers,
+					archive.Answer{Question: answer.Question, Answer: answer.Text})
+			}
+		}
+		snapshot.Participants = append(snapshot.Participants, participant)
+	}
+
+	if err := s.archiver.Archive(ctx, snapshot); err != nil {
+		s.botCtx.Logger().Error(ctx, "Failed to archive summary", err,
+			botcontext.Field{Key: "channel_id", Value: session.ChannelID},
+			botcontext.Metric("SummaryArchiveFailures", 1),
+		)
+	}
+}
@@ -0,0 +1,84 @@
+package standup
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/archive"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// recordingS3 keeps the objects put to it by key.
+type recordingS3 struct {
+	objects map[string][]byte
+}
+
+func (r *recordingS3) PutObject(ctx context.Context, params *s3.PutObjectInput,
+	optFns ...func(*s3.Options),
+) (*s3.PutObjectOutput, error) {
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.objects[*params.Key] = data
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestArchiveSummary(t *testing.T) {
+	bucket := &recordingS3{objects: make(map[string][]byte)}
+	s := newTestService(t, withConfig(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+      active_days: ["Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"]
+    users:
+      - id: "U1111111111"
+        name: "alice"
+      - id: "U2222222222"
+        name: "bob"
+    questions: ["What did you do?"]
+`), withServiceOptions(WithArchiver(archive.NewArchiver(bucket, "lake", ""))))
+	ctx := s.ctx
+
+	today := time.Now().Format("2006-01-02")
+	session, err := s.StartStandupSession(ctx, "C1234567890")
+	require.NoError(t, err)
+	require.NoError(t, s.data.SubmitUserResponse(ctx, session, &store.UserResponse{
+		ChannelID:   "C1234567890",
+		Date:        today,
+		UserID:      "U1111111111",
+		UserName:    "alice",
+		Responses:   map[string]string{"question_0": "Shipped the export"},
+		SubmittedAt: time.Now(),
+	}))
+	require.NoError(t, s.data.SaveSkippedResponse(ctx, &store.SkippedResponse{
+		ChannelID: "C1234567890", Date: today, UserID: "U2222222222", Reason: "out sick",
+	}))
+
+	require.NoError(t, s.PostSummaryNow(ctx, "C1234567890", false))
+
+	data, ok := bucket.objects["standups/date="+today+"/channel=C1234567890/standup.json"]
+	require.True(t, ok)
+	var snapshot archive.Snapshot
+	require.NoError(t, json.Unmarshal(data, &snapshot))
+	assert.Equal(t, "T1234567890", snapshot.TeamID)
+	assert.Equal(t, session.SessionID, snapshot.SessionID)
+	assert.NotEmpty(t, snapshot.SummaryTS)
+	assert.Equal(t, []string{"What did you do?"}, snapshot.Questions)
+	require.Len(t, snapshot.Participants, 2)
+	assert.Equal(t, []archive.Answer{{Question: "What did you do?", Answer: "Shipped the export"}},
+		snapshot.Participants[0].Answers)
+	assert.NotNil(t, snapshot.Participants[0].SubmittedAt)
+	assert.True(t, snapshot.Participants[1].Skipped)
+	assert.Equal(t, "out sick", snapshot.Participants[1].SkipReason)
+}
@@ -0,0 +1,80 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// Status is how far along a channel's standup is on a date, for pipelines
+// that wait on it, e.g. to gate a deploy.
+type Status struct {
+	ChannelID string
+	Date      string
+	Started   bool     // The day's session has started
+	Complete  bool     // Every required user submitted or skipped
+	Submitted []string // User IDs, sorted
+	Skipped   []string // Sorted
+	Pending   []string // Required users yet to submit, sorted
+}
+
+// StandupStatus returns the status of a channel's standup on date
+// (YYYY-MM-DD). The required users are those the summary lists: the
+// channel's users working that day and, once the session has started, its
+// user group members, leaving out deactivated users. It returns
+// store.ErrNotFound for channels without standups.
+func (s *Service) StandupStatus(ctx context.Context, channelID, date string) (*Status, error) {
+	channel, found := s.Config(ctx).ChannelByID(channelID)
+	if !found {
+		return nil, fmt.Errorf("channel not configured: %s: %w", security.SanitizeLogValue(channelID), store.ErrNotFound)
+	}
+
+	session, err := s.store.GetSession(ctx, channelID, date)
+	if err != nil && err != store.ErrNotFound {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	responses, err := s.store.ListUserResponses(ctx, channelID, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list responses: %w", err)
+	}
+	skips, err := s.store.ListSkippedResponses(ctx, channelID, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list skips: %w", err)
+	}
+
+	status := &Status{ChannelID: channelID, Date: date, Started: session != nil}
+	done := make(map[string]bool, len(responses)+len(skips))
+	for _, response := range responses {
+		status.Submitted = append(status.Submitted, response.UserID)
+		done[response.UserID] = true
+	}
+	for _, skip := range skips {
+		if !done[skip.UserID] {
+			status.Skipped = append(status.Skipped, skip.UserID)
+			done[skip.UserID] = true
+		}
+	}
+
+	var required []string
+	for _, user := range workingUsers(channel, date) {
+		required = append(required, user.ID())
+	}
+	if session != nil {
+		required = append(required, session.GroupMembers...)
+	}
+	deactivated := s.deactivatedUsers(ctx, channelID)
+	for _, userID := range required {
+		if !done[userID] && !slices.Contains(deactivated, userID) && !slices.Contains(status.Pending, userID) {
+			status.Pending = append(status.Pending, userID)
+		}
+	}
+	slices.Sort(status.Submitted)
+	slices.Sort(status.Skipped)
+	slices.Sort(status.Pending)
+	status.Complete = len(status.Pending) == 0
+
+	return status, nil
+}
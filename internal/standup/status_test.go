@@ -0,0 +1,105 @@
+package standup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestStandupStatus(t *testing.T) {
+	s := newTestService(t, withConfig(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+    users:
+      - id: "U1111111111"
+        name: "alice"
+      - id: "U2222222222"
+        name: "bob"
+      - id: "U3333333333"
+        name: "carol"
+        active_days: ["Mon"]
+`))
+	ctx := s.ctx
+
+	// A Wednesday, when carol is off
+	const date = "2026-10-14"
+	status, err := s.StandupStatus(ctx, "C1234567890", date)
+	require.NoError(t, err)
+	assert.False(t, status.Started)
+	assert.False(t, status.Complete)
+	assert.Equal(t, []string{"U1111111111", "U2222222222"}, status.Pending)
+
+	require.NoError(t, s.data.CreateSession(ctx, &store.Session{
+		ChannelID:    "C1234567890",
+		Date:         date,
+		GroupMembers: []string{"U4444444444"},
+	}))
+	require.NoError(t, s.data.SaveUserResponse(ctx, &store.UserResponse{
+		ChannelID:   "C1234567890",
+		Date:        date,
+		UserID:      "U1111111111",
+		SubmittedAt: time.Now(),
+	}))
+	require.NoError(t, s.data.SaveSkippedResponse(ctx, &store.SkippedResponse{
+		ChannelID: "C1234567890",
+		Date:      date,
+		UserID:    "U2222222222",
+	}))
+
+	status, err = s.StandupStatus(ctx, "C1234567890", date)
+	require.NoError(t, err)
+	assert.True(t, status.Started)
+	assert.False(t, status.Complete)
+	assert.Equal(t, []string{"U1111111111"}, status.Submitted)
+	assert.Equal(t, []string{"U2222222222"}, status.Skipped)
+	assert.Equal(t, []string{"U4444444444"}, status.Pending)
+
+	require.NoError(t, s.data.SaveUserResponse(ctx, &store.UserResponse{
+		ChannelID:   "C1234567890",
+		Date:        date,
+		UserID:      "U4444444444",
+		SubmittedAt: time.Now(),
+	}))
+	status, err = s.StandupStatus(ctx, "C1234567890", date)
+	require.NoError(t, err)
+	assert.True(t, status.Complete)
+	assert.Empty(t, status.Pending)
+
+	_, err = s.StandupStatus(ctx, "C0000000000", date)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func TestToday(t *testing.T) {
+	s := newTestService(t, withConfig(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "Pacific/Auckland"
+      summary_time: "10:00"
+    users:
+      - id: "U1111111111"
+        name: "alice"
+`))
+	// 00:30 on the 17th in Auckland, still the 16th in UTC
+	s.now = func() time.Time { return time.Date(2026, 10, 16, 11, 30, 0, 0, time.UTC) }
+
+	assert.Equal(t, "2026-10-17", s.Today(s.ctx, "C1234567890"))
+	assert.Equal(t, "2026-10-16", s.Today(s.ctx, "C0000000000"))
+
+	// The status endpoint's default date finds the standup started now
+	_, err := s.StartStandupSession(s.ctx, "C1234567890")
+	require.NoError(t, err)
+	status, err := s.StandupStatus(s.ctx, "C1234567890", s.Today(s.ctx, "C1234567890"))
+	require.NoError(t, err)
+	assert.True(t, status.Started)
+	assert.Equal(t, []string{"U1111111111"}, status.Pending)
+}
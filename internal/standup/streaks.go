@@ -0,0 +1,155 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/analytics"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// countStreaks counts the session's day in the streaks of its channel's
+// users, if the channel tracks them, and returns the users who reached a
+// milestone that day. Each day is counted once, so summaries rebuilt for late
+// submissions only pick up those. Failures are logged rather than holding up
+// the summary.
+func (s *Service) countStreaks(
+	ctx context.Context,
+	session *store.Session,
+	users []*slack.UserResponseSummary,
+) []slack.StreakMilestone {
+	logger := s.botCtx.Logger()
+
+	config, err := s.store.GetChannelConfig(ctx, store.TeamScope(ctx), session.ChannelID)
+	if err != nil {
+		if err != store.ErrNotFound {
+			logger.Error(ctx, "Failed to get streak policy", err,
+				botcontext.Field{Key: "channel_id", Value: session.ChannelID},
+			)
+		}
+		return nil
+	}
+	policy := config.Schedule.Streaks
+	if policy == nil {
+		return nil
+	}
+	milestones := policy.Milestones
+	if len(milestones) == 0 {
+		milestones = store.DefaultStreakMilestones
+	}
+
+	list, err := s.store.ListStreaks(ctx, session.ChannelID)
+	if err != nil {
+		logger.Error(ctx, "Failed to list streaks", err,
+			botcontext.Field{Key: "channel_id", Value: session.ChannelID},
+		)
+		return nil
+	}
+	streaks := make(map[string]*store.Streak, len(list))
+	for _, streak := range list {
+		streaks[streak.UserID] = streak
+	}
+
+	var reached []slack.StreakMilestone
+	for _, user := range users {
+		streak := streaks[user.UserID]
+		if streak == nil {
+			streak = &store.Streak{ChannelID: session.ChannelID, UserID: user.UserID}
+		}
+
+		outcome := analytics.Missed
+		switch {
+		case user.Submitted:
+			outcome = analytics.Submitted
+		case user.Skipped:
+			outcome = analytics.Skipped
+		}
+
+		if analytics.CountStreak(streak, session.Date, outcome) {
+			streak.UpdatedAt = time.Now()
+			if err := s.store.SaveStreak(ctx, streak); err != nil {
+				logger.Error(ctx, "Failed to save streak", err,
+					botcontext.Field{Key: "channel_id", Value: session.ChannelID},
+					botcontext.Field{Key: "user_id", Value: user.UserID},
+				)
+				continue
+			}
+		}
+
+		if analytics.Milestone(streak, session.Date, outcome, milestones) {
+			reached = append(reached, slack.StreakMilestone{
+				UserID:   user.UserID,
+				UserName: user.UserName,
+				Days:     streak.Current,
+			})
+		}
+	}
+
+	return reached
+}
+
+// postLeaderboard posts the channel's streak leaderboard for the month ending
+// today, once per month.
+func (s *Scheduler) postLeaderboard(
+	ctx context.Context,
+	config *store.ChannelConfig,
+	schedule *store.DigestSchedule,
+	channelTime time.Time,
+) error {
+	// Claim the month first so overlapping scheduler runs don't double post
+	record := &store.DigestRecord{
+		ChannelID: config.ChannelID,
+		Period:    store.DigestLeaderboard,
+		PeriodKey: channelTime.Format("2006-01"),
+		PostedAt:  time.Now(),
+	}
+	if err := s.store.SaveDigestRecord(ctx, record); err != nil {
+		if err == store.ErrAlreadyExists {
+			return nil
+		}
+		return fmt.Errorf("failed to save leaderboard record: %w", err)
+	}
+
+	if err := s.sendLeaderboard(ctx, config, schedule, channelTime); err != nil {
+		// Let the next run post it
+		if err := s.store.DeleteDigestRecord(ctx, config.ChannelID, record.Period, record.PeriodKey); err != nil {
+			s.botCtx.Logger().Error(ctx, "Failed to release leaderboard", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// sendLeaderboard posts the leaderboard of the month ending today.
+func (s *Scheduler) sendLeaderboard(
+	ctx context.Context,
+	config *store.ChannelConfig,
+	schedule *store.DigestSchedule,
+	channelTime time.Time,
+) error {
+	stats, err := s.analytics.ChannelStats(ctx, config, channelTime, monthWindowDays(channelTime))
+	if err != nil {
+		return fmt.Errorf("failed to compute leaderboard stats: %w", err)
+	}
+	streaks, err := s.store.ListStreaks(ctx, config.ChannelID)
+	if err != nil {
+		return fmt.Errorf("failed to list streaks: %w", err)
+	}
+
+	target := config.ChannelID
+	if schedule.TargetChannel != "" {
+		target = schedule.TargetChannel
+	}
+
+	title := "🏆 Standup Leaderboard — " + channelTime.Format("January 2006")
+	blocks := analytics.BuildLeaderboardMessage(title, stats, streaks)
+	if _, err := s.service.slackClient.PostMessage(ctx, target, slack.WithBlocks(blocks...)); err != nil {
+		return fmt.Errorf("failed to post leaderboard: %w", err)
+	}
+
+	return nil
+}
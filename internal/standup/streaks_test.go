@@ -0,0 +1,74 @@
+package standup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestStreaks(t *testing.T) {
+	s := newTestService(t)
+	ctx := s.ctx
+	config := &store.ChannelConfig{
+		TeamID:    "T1234567890",
+		ChannelID: "C1234567890",
+		Enabled:   true,
+		Schedule: store.ScheduleConfig{
+			Timezone:   "UTC",
+			ActiveDays: []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+			Streaks:    &store.StreakPolicy{Milestones: []int{2}},
+		},
+	}
+	require.NoError(t, s.data.SaveChannelConfig(ctx, config))
+
+	users := func(aliceSubmitted bool) []*slack.UserResponseSummary {
+		return []*slack.UserResponseSummary{
+			{UserID: "U1111111111", UserName: "alice", Submitted: aliceSubmitted},
+			{UserID: "U2222222222", UserName: "bob", Submitted: true},
+		}
+	}
+
+	// Milestones are celebrated the day they're reached, once
+	assert.Empty(t, s.countStreaks(ctx, &store.Session{ChannelID: "C1234567890", Date: "2024-01-15"}, users(true)))
+	day := &store.Session{ChannelID: "C1234567890", Date: "2024-01-16"}
+	assert.Equal(t, []slack.StreakMilestone{
+		{UserID: "U1111111111", UserName: "alice", Days: 2},
+		{UserID: "U2222222222", UserName: "bob", Days: 2},
+	}, s.countStreaks(ctx, day, users(true)))
+	assert.Len(t, s.countStreaks(ctx, day, users(true)), 2)
+
+	// A miss ends the run, unless the user submits late that day
+	day = &store.Session{ChannelID: "C1234567890", Date: "2024-01-17"}
+	assert.Empty(t, s.countStreaks(ctx, day, users(false)))
+	streaks, err := s.data.ListStreaks(ctx, "C1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, 0, streaks[0].Current)
+	assert.Equal(t, 3, streaks[1].Current)
+	s.countStreaks(ctx, day, users(true))
+	streaks, err = s.data.ListStreaks(ctx, "C1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, 3, streaks[0].Current)
+
+	// The leaderboard is posted once a month
+	scheduler := NewScheduler(s.Service, s.botCtx, s.data)
+	schedule := &store.DigestSchedule{Day: "last", Time: "17:00"}
+	now := time.Date(2024, 1, 31, 17, 0, 0, 0, time.UTC)
+	require.NoError(t, scheduler.postLeaderboard(ctx, config, schedule, now))
+	require.NoError(t, scheduler.postLeaderboard(ctx, config, schedule, now))
+	posts := s.client.Calls("chat.postMessage")
+	require.Len(t, posts, 1)
+	assert.Equal(t, "C1234567890", posts[0].Channel)
+
+	// Channels without a streak policy aren't tracked
+	config.Schedule.Streaks = nil
+	require.NoError(t, s.data.SaveChannelConfig(ctx, config))
+	assert.Empty(t, s.countStreaks(ctx, &store.Session{ChannelID: "C1234567890", Date: "2024-01-18"}, users(true)))
+	streaks, err = s.data.ListStreaks(ctx, "C1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-17", streaks[0].LastDate)
+}
@@ -0,0 +1,64 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+// UserDayReport returns what a user reported on a date in each of the
+// workspace's channels, by channel ID. Private channels are left out unless
+// requesterID is the user or gets the channel's full reports; the number left
+// out is returned too.
+func (s *Service) UserDayReport(
+	ctx context.Context,
+	userID, date, requesterID string,
+) ([]slack.UserDayChannel, int, error) {
+	responses, err := s.store.ListResponsesForUserOnDate(ctx, userID, date)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list responses: %w", err)
+	}
+
+	cfg := s.Config(ctx)
+	var channels []slack.UserDayChannel
+	hidden := 0
+	for _, response := range responses {
+		if requesterID != userID {
+			private, err := s.privateChannelConfig(ctx, response.ChannelID)
+			if err != nil {
+				return nil, 0, err
+			}
+			if private != nil && !slices.Contains(reportRecipients(private), requesterID) {
+				hidden++
+				continue
+			}
+		}
+
+		var questions []string
+		if channel, found := cfg.ChannelByID(response.ChannelID); found {
+			questions = questionTexts(questionsOn(channel, date))
+		}
+
+		channel := slack.UserDayChannel{ChannelID: response.ChannelID, Late: response.Late}
+		for _, i := range answeredQuestions(response) {
+			key := slack.QuestionBlockID(i)
+			text := strings.TrimSpace(response.Responses[key])
+			if text == "" {
+				continue
+			}
+			question := fmt.Sprintf("Question %d", i+1)
+			if typed, ok := response.Answers[key]; ok && typed.Question != "" {
+				question = typed.Question
+			} else if i < len(questions) {
+				question = questions[i]
+			}
+			channel.Answers = append(channel.Answers, slack.SummaryAnswer{Question: question, Text: text})
+		}
+		channels = append(channels, channel)
+	}
+
+	return channels, hidden, nil
+}
@@ -0,0 +1,68 @@
+package standup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func TestUserDayReport(t *testing.T) {
+	s := newTestService(t, withConfig(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+    questions: ["What did you do?", "Any blockers?"]
+`))
+	ctx := s.ctx
+	require.NoError(t, s.data.SaveChannelConfig(ctx, &store.ChannelConfig{
+		TeamID:    "T1234567890",
+		ChannelID: "C2222222222",
+		Enabled:   true,
+		Schedule:  store.ScheduleConfig{Privacy: &store.PrivacyPolicy{Private: true}},
+		Admins:    []string{"U3333333333"},
+	}))
+
+	for _, channelID := range []string{"C1234567890", "C2222222222"} {
+		session := &store.Session{SessionID: "S" + channelID, ChannelID: channelID, Date: "2024-01-15"}
+		require.NoError(t, s.data.SubmitUserResponse(ctx, session, &store.UserResponse{
+			SessionID:   session.SessionID,
+			ChannelID:   channelID,
+			Date:        "2024-01-15",
+			UserID:      "U1111111111",
+			Responses:   map[string]string{"question_0": "Shipped the export", "question_1": " "},
+			SubmittedAt: time.Now(),
+			Late:        channelID == "C2222222222",
+		}))
+	}
+
+	// The private channel's answers are left out for other admins
+	channels, hidden, err := s.UserDayReport(ctx, "U1111111111", "2024-01-15", "U9999999999")
+	require.NoError(t, err)
+	assert.Equal(t, 1, hidden)
+	assert.Equal(t, []slack.UserDayChannel{{
+		ChannelID: "C1234567890",
+		Answers:   []slack.SummaryAnswer{{Question: "What did you do?", Text: "Shipped the export"}},
+	}}, channels)
+
+	channels, hidden, err = s.UserDayReport(ctx, "U1111111111", "2024-01-15", "U3333333333")
+	require.NoError(t, err)
+	assert.Zero(t, hidden)
+	require.Len(t, channels, 2)
+	assert.Equal(t, slack.UserDayChannel{
+		ChannelID: "C2222222222",
+		Late:      true,
+		Answers:   []slack.SummaryAnswer{{Question: "Question 1", Text: "Shipped the export"}},
+	}, channels[1])
+
+	channels, _, err = s.UserDayReport(ctx, "U1111111111", "2024-01-16", "U3333333333")
+	require.NoError(t, err)
+	assert.Empty(t, channels)
+}
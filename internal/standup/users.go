@@ -0,0 +1,150 @@
+package standup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/apperr"
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/validation"
+)
+
+// ErrInvalidUser is returned when adding or removing something that isn't a
+// Slack user ID.
+var ErrInvalidUser = apperr.New(apperr.KindInvalid, "invalid user")
+
+// maxRosterAttempts is how many times a roster change is tried when the
+// channel's config keeps changing underneath it, e.g. as admins edit it at
+// the same time.
+const maxRosterAttempts = 3
+
+// Roster lists a channel's required users. Deactivated users are listed in
+// Users too.
+type Roster struct {
+	Users       []string
+	Deactivated []string
+}
+
+// RosterChange is the outcome of adding or removing users.
+type RosterChange struct {
+	Roster             // The roster after the change
+	Changed   []string // Users added or removed
+	Unchanged []string // Users already on, or not on, the roster
+}
+
+// ChannelRoster returns the channel's required users.
+func (s *Service) ChannelRoster(ctx context.Context, teamID, channelID string) (*Roster, error) {
+	config, err := s.store.GetChannelConfig(ctx, teamID, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel config: %w", err)
+	}
+	return rosterOf(config), nil
+}
+
+// AddChannelUsers adds users to the channel's required users. Deactivated
+// users are reactivated. Callers check the user asking is a channel admin.
+func (s *Service) AddChannelUsers(
+	ctx context.Context,
+	teamID, channelID string,
+	userIDs []string,
+) (*RosterChange, error) {
+	return s.changeRoster(ctx, teamID, channelID, userIDs, func(config *store.ChannelConfig, userID string) bool {
+		deactivated := slices.Contains(config.DeactivatedUsers, userID)
+		if slices.Contains(config.Users, userID) && !deactivated {
+			return false
+		}
+		config.DeactivatedUsers = slices.DeleteFunc(config.DeactivatedUsers, func(id string) bool { return id == userID })
+		if !slices.Contains(config.Users, userID) {
+			config.Users = append(config.Users, userID)
+		}
+		return true
+	})
+}
+
+// RemoveChannelUsers removes users from the channel's required users.
+// Callers check the user asking is a channel admin.
+func (s *Service) RemoveChannelUsers(
+	ctx context.Context,
+	teamID, channelID string,
+	userIDs []string,
+) (*RosterChange, error) {
+	return s.changeRoster(ctx, teamID, channelID, userIDs, func(config *store.ChannelConfig, userID string) bool {
+		if !slices.Contains(config.Users, userID) {
+			return false
+		}
+		isUser := func(id string) bool { return id == userID }
+		config.Users = slices.DeleteFunc(config.Users, isUser)
+		config.DeactivatedUsers = slices.DeleteFunc(config.DeactivatedUsers, isUser)
+		return true
+	})
+}
+
+// changeRoster applies change to each user on the channel's roster and saves
+// the result, unless the config was changed since it was read; then the
+// change is tried again on the new config. change reports whether it changed
+// anything.
+func (s *Service) changeRoster(
+	ctx context.Context,
+	teamID, channelID string,
+	userIDs []string,
+	change func(config *store.ChannelConfig, userID string) bool,
+) (*RosterChange, error) {
+	for _, userID := range userIDs {
+		if err := validation.ValidateUserID(userID); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidUser, userID)
+		}
+	}
+	userIDs = slices.Compact(slices.Sorted(slices.Values(userIDs)))
+
+	for attempt := 1; ; attempt++ {
+		config, err := s.store.GetChannelConfig(ctx, teamID, channelID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get channel config: %w", err)
+		}
+
+		result := &RosterChange{}
+		for _, userID := range userIDs {
+			if change(config, userID) {
+				result.Changed = append(result.Changed, userID)
+			} else {
+				result.Unchanged = append(result.Unchanged, userID)
+			}
+		}
+		result.Roster = *rosterOf(config)
+		if len(result.Changed) == 0 {
+			return result, nil
+		}
+
+		err = s.store.SaveChannelConfig(ctx, config)
+		if errors.Is(err, store.ErrConflict) && attempt < maxRosterAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to save channel config: %w", err)
+		}
+
+		// Users' preferred reminder times are scheduled; the daily sync retries
+		if err := s.SyncSchedule(ctx, config); err != nil {
+			s.botCtx.Logger().Error(ctx, "Failed to sync schedule", err,
+				botcontext.Field{Key: "channel_id", Value: channelID},
+			)
+		}
+
+		s.botCtx.Logger().Info(ctx, "Changed channel roster",
+			botcontext.Field{Key: "channel_id", Value: channelID},
+			botcontext.Field{Key: "users", Value: len(result.Changed)},
+		)
+
+		return result, nil
+	}
+}
+
+func rosterOf(config *store.ChannelConfig) *Roster {
+	return &Roster{
+		Users:       slices.Clone(config.Users),
+		Deactivated: slices.Clone(config.DeactivatedUsers),
+	}
+}
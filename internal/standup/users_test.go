@@ -0,0 +1,79 @@
+package standup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// racingStore saves another admin's change to the config before each of the
+// first conflicts saves, as if they raced.
+type racingStore struct {
+	store.Store
+	conflicts int
+}
+
+func (s *racingStore) SaveChannelConfig(ctx context.Context, config *store.ChannelConfig) error {
+	if s.conflicts > 0 {
+		s.conflicts--
+		other, err := s.Store.GetChannelConfig(ctx, config.TeamID, config.ChannelID)
+		if err != nil {
+			return err
+		}
+		other.Users = append(other.Users, "U3333333333")
+		if err := s.Store.SaveChannelConfig(ctx, other); err != nil {
+			return err
+		}
+	}
+	return s.Store.SaveChannelConfig(ctx, config)
+}
+
+func TestChannelRoster(t *testing.T) {
+	racing := &racingStore{}
+	s := newTestService(t, withStore(func(data store.Store) store.Store {
+		racing.Store = data
+		return racing
+	}))
+	ctx := s.ctx
+	require.NoError(t, s.data.SaveChannelConfig(ctx, &store.ChannelConfig{
+		TeamID:           "T1234567890",
+		ChannelID:        "C1234567890",
+		Enabled:          true,
+		Users:            []string{"U1111111111"},
+		DeactivatedUsers: []string{"U1111111111"},
+	}))
+
+	_, err := s.AddChannelUsers(ctx, "T1234567890", "C1234567890", []string{"U2222222222", "@bob"})
+	require.ErrorIs(t, err, ErrInvalidUser)
+	assert.Contains(t, err.Error(), "@bob")
+	_, err = s.AddChannelUsers(ctx, "T1234567890", "C9999999999", []string{"U2222222222"})
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	// Deactivated users are reactivated and duplicates counted once
+	change, err := s.AddChannelUsers(ctx, "T1234567890", "C1234567890",
+		[]string{"U2222222222", "U1111111111", "U2222222222"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U1111111111", "U2222222222"}, change.Changed)
+	assert.Empty(t, change.Unchanged)
+	assert.Equal(t, []string{"U1111111111", "U2222222222"}, change.Users)
+	assert.Empty(t, change.Deactivated)
+
+	// Concurrent changes are kept
+	racing.conflicts = 1
+	change, err = s.RemoveChannelUsers(ctx, "T1234567890", "C1234567890", []string{"U1111111111", "U4444444444"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U1111111111"}, change.Changed)
+	assert.Equal(t, []string{"U4444444444"}, change.Unchanged)
+	roster, err := s.ChannelRoster(ctx, "T1234567890", "C1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U2222222222", "U3333333333"}, roster.Users)
+
+	// Until they keep conflicting
+	racing.conflicts = maxRosterAttempts
+	_, err = s.AddChannelUsers(ctx, "T1234567890", "C1234567890", []string{"U5555555555"})
+	require.ErrorIs(t, err, store.ErrConflict)
+}
@@ -0,0 +1,106 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// BackfillSessionIndex adds the GSI1 keys to sessions and responses written
+// before ListSessions and ListSessionResponses used the index, so they're
+// listed too. It scans the whole table, can be run again safely, and returns
+// how many items it updated. Responses saved without a session ID can't be
+// indexed and are left as they are.
+func BackfillSessionIndex(ctx context.Context, client Client, tableName string) (int, error) {
+	filter := expression.AttributeNotExists(expression.Name("GSI1PK")).
+		And(expression.BeginsWith(expression.Name("PK"), "SESSION#"))
+	expr, err := expression.NewBuilder().WithFilter(filter).Build()
+	if err != nil {
+		return 0, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	updated := 0
+	paginator := dynamodb.NewScanPaginator(client, &dynamodb.ScanInput{
+		TableName:                 aws.String(tableName),
+		FilterExpression:          expr.Filter(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return updated, &store.Error{Code: "SCAN_ERROR", Message: "Failed to scan sessions", Err: err}
+		}
+
+		for _, item := range page.Items {
+			gsi1pk, gsi1sk, ok := sessionIndexKeys(item)
+			if !ok {
+				continue
+			}
+
+			update := expression.Set(expression.Name("GSI1PK"), expression.Value(gsi1pk)).
+				Set(expression.Name("GSI1SK"), expression.Value(gsi1sk))
+			// Leave items alone that were deleted or indexed since the scan
+			condition := expression.AttributeExists(expression.Name("PK")).
+				And(expression.AttributeNotExists(expression.Name("GSI1PK")))
+			expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+			if err != nil {
+				return updated, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+			}
+
+			_, err = client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+				TableName:                 aws.String(tableName),
+				Key:                       map[string]types.AttributeValue{"PK": item["PK"], "SK": item["SK"]},
+				UpdateExpression:          expr.Update(),
+				ConditionExpression:       expr.Condition(),
+				ExpressionAttributeNames:  expr.Names(),
+				ExpressionAttributeValues: expr.Values(),
+			})
+			if err != nil {
+				var cfe *types.ConditionalCheckFailedException
+				if errors.As(err, &cfe) {
+					continue
+				}
+				return updated, &store.Error{Code: "UPDATE_ERROR", Message: "Failed to index session item", Err: err}
+			}
+			updated++
+		}
+	}
+
+	return updated, nil
+}
+
+// sessionIndexKeys returns the GSI1 keys of a session or response item, as
+// CreateSession and SaveUserResponse write them. ok is false for other items
+// in a session's partition, such as skips and escalations.
+func sessionIndexKeys(item map[string]types.AttributeValue) (pk, sk string, ok bool) {
+	var keys struct {
+		PK        string `dynamodbav:"PK"`
+		SK        string `dynamodbav:"SK"`
+		Date      string `dynamodbav:"date"`
+		SessionID string `dynamodbav:"session_id"`
+	}
+	if err := attributevalue.UnmarshalMap(item, &keys); err != nil {
+		return "", "", false
+	}
+
+	switch {
+	case keys.PK == keys.SK && keys.Date != "":
+		// A session, keyed SESSION#<channel scope>#<date>
+		scope := strings.TrimSuffix(strings.TrimPrefix(keys.PK, "SESSION#"), "#"+keys.Date)
+		return "CHANNEL#" + scope, "SESSION#" + keys.Date, true
+	case strings.HasPrefix(keys.SK, "USER#") && keys.SessionID != "":
+		return "SESSIONID#" + keys.SessionID, keys.SK, true
+	default:
+		return "", "", false
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -16,6 +20,20 @@ import (
 	"github.com/synaptiq/standup-bot/internal/validation"
 )
 
+// processedEventRetention is how long processed Slack event IDs are kept.
+const processedEventRetention = 24 * time.Hour
+
+const (
+	// batchGetLimit is the most keys one BatchGetItem request may read.
+	batchGetLimit = 100
+	// batchGetRetries limits the retries of keys DynamoDB left unprocessed.
+	batchGetRetries = 5
+	// batchWriteLimit is the most items one BatchWriteItem request may write.
+	batchWriteLimit = 25
+	// queryConcurrency limits the queries in flight for multi-channel reads.
+	queryConcurrency = 8
+)
+
 // Store implements the Store interface using DynamoDB.
 type Store struct {
 	client    Client
@@ -32,7 +50,30 @@ func NewStore(client Client, tableName string, ttlDays int) store.Store {
 	}
 }
 
+// channelScope returns the channel part of keys for channel-level records.
+// When ctx is scoped to a workspace the channel is prefixed with its team ID;
+// single-workspace deployments keep the original unprefixed keys.
+func channelScope(ctx context.Context, channelID string) string {
+	if teamID := store.TeamScope(ctx); teamID != "" {
+		return teamID + "#" + channelID
+	}
+	return channelID
+}
+
 // Helper functions for key generation.
+
+// userHistoryKey is the GSI3 sort key of a user's response, ordering a
+// user's responses in a channel by date.
+func userHistoryKey(channelScope, date string) string {
+	return fmt.Sprintf("RESPONSE#%s#%s", channelScope, date)
+}
+
+// userDayKey is the GSI4 sort key of a user's response, grouping a user's
+// responses in all channels by date.
+func userDayKey(date, channelScope string) string {
+	return fmt.Sprintf("DATE#%s#%s", date, channelScope)
+}
+
 func workspaceKey(teamID string) (pk, sk string) {
 	return fmt.Sprintf("WORKSPACE#%s", teamID), fmt.Sprintf("WORKSPACE#%s", teamID)
 }
@@ -53,6 +94,51 @@ func reminderKey(channelID, date, userID, time string) (pk, sk string) {
 	return fmt.Sprintf("REMINDER#%s#%s", channelID, date), fmt.Sprintf("USER#%s#%s", userID, time)
 }
 
+// failedReminderKey keeps a channel's failed reminders in one partition,
+// sorted by date, so a range of days is read with a single query.
+func failedReminderKey(channelID, date, userID, time string) (pk, sk string) {
+	return fmt.Sprintf("FAILED_REMINDER#%s", channelID), fmt.Sprintf("%s#USER#%s#%s", date, userID, time)
+}
+
+func escalationKey(channelID, date, userID string) (pk, sk string) {
+	return fmt.Sprintf("SESSION#%s#%s", channelID, date), fmt.Sprintf("ESCALATION#%s", userID)
+}
+
+func digestKey(channelID string, period store.DigestPeriod, periodKey string) (pk, sk string) {
+	return fmt.Sprintf("DIGEST#%s", channelID), fmt.Sprintf("%s#%s", period, periodKey)
+}
+
+// scheduleKey keeps a channel's scheduled runs in one partition, so they're
+// read with a single query on every scheduler tick.
+func scheduleKey(channelID, task string) (pk, sk string) {
+	return fmt.Sprintf("SCHEDULE#%s", channelID), fmt.Sprintf("TASK#%s", task)
+}
+
+// streakKey keeps a channel's streaks in one partition, so each summary
+// reads them with a single query.
+func streakKey(channelID, userID string) (pk, sk string) {
+	return fmt.Sprintf("STREAK#%s", channelID), fmt.Sprintf("USER#%s", userID)
+}
+
+// heartbeatKey keeps every heartbeat in one partition, so the monitor reads
+// them with a single query.
+func heartbeatKey(teamID, job, channelID string) (pk, sk string) {
+	return "HEARTBEAT", fmt.Sprintf("%s#%s#%s", job, teamID, channelID)
+}
+
+func preferencesKey(teamScope, userID string) (pk, sk string) {
+	return fmt.Sprintf("PREFS#%s", teamScope), fmt.Sprintf("USER#%s", userID)
+}
+
+func processedEventKey(eventID string) (pk, sk string) {
+	key := fmt.Sprintf("EVENT#%s", eventID)
+	return key, key
+}
+
+func skippedResponseKey(channelID, date, userID string) (pk, sk string) {
+	return fmt.Sprintf("SESSION#%s#%s", channelID, date), fmt.Sprintf("SKIP#%s", userID)
+}
+
 // calculateTTL calculates TTL timestamp for records.
 func (s *Store) calculateTTL(baseTime time.Time) *int64 {
 	if s.ttlDays <= 0 {
@@ -64,10 +150,15 @@ func (s *Store) calculateTTL(baseTime time.Time) *int64 {
 
 // SaveWorkspaceConfig saves workspace configuration.
 func (s *Store) SaveWorkspaceConfig(ctx context.Context, config *store.WorkspaceConfig) error {
-	// Validate team ID
-	if err := validation.ValidateTeamID(config.TeamID); err != nil {
+	// Validate team ID, or enterprise ID for org-wide installs
+	if err := validation.ValidateWorkspaceID(config.TeamID); err != nil {
 		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
 	}
+	if config.EnterpriseID != "" {
+		if err := validation.ValidateEnterpriseID(config.EnterpriseID); err != nil {
+			return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid enterprise ID", Err: err}
+		}
+	}
 
 	pk, sk := workspaceKey(config.TeamID)
 
@@ -80,6 +171,13 @@ func (s *Store) SaveWorkspaceConfig(ctx context.Context, config *store.Workspace
 		"app_token":    config.AppToken,
 		"installed_at": config.InstalledAt,
 		"updated_at":   time.Now(),
+		"integrations": config.Integrations,
+	}
+	if config.EnterpriseID != "" {
+		item["enterprise_id"] = config.EnterpriseID
+	}
+	if config.IsEnterpriseInstall {
+		item["is_enterprise_install"] = true
 	}
 
 	av, err := attributevalue.MarshalMap(item)
@@ -100,8 +198,8 @@ func (s *Store) SaveWorkspaceConfig(ctx context.Context, config *store.Workspace
 
 // GetWorkspaceConfig retrieves workspace configuration.
 func (s *Store) GetWorkspaceConfig(ctx context.Context, teamID string) (*store.WorkspaceConfig, error) {
-	// Validate team ID
-	if err := validation.ValidateTeamID(teamID); err != nil {
+	// Validate team ID, or enterprise ID for org-wide installs
+	if err := validation.ValidateWorkspaceID(teamID); err != nil {
 		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
 	}
 
@@ -143,35 +241,125 @@ func (s *Store) SaveChannelConfig(ctx context.Context, config *store.ChannelConf
 	pk, sk := channelConfigKey(config.TeamID, config.ChannelID)
 
 	item := map[string]interface{}{
-		"PK":           pk,
-		"SK":           sk,
-		"team_id":      config.TeamID,
-		"channel_id":   config.ChannelID,
-		"channel_name": config.ChannelName,
-		"enabled":      config.Enabled,
-		"schedule":     config.Schedule,
-		"users":        config.Users,
-		"templates":    config.Templates,
-		"questions":    config.Questions,
-		"updated_at":   time.Now(),
+		"PK":                pk,
+		"SK":                sk,
+		"team_id":           config.TeamID,
+		"channel_id":        config.ChannelID,
+		"channel_name":      config.ChannelName,
+		"enabled":           config.Enabled,
+		"schedule":          config.Schedule,
+		"users":             config.Users,
+		"admins":            config.Admins,
+		"deactivated_users": config.DeactivatedUsers,
+		"user_groups":       config.UserGroups,
+		"templates":         config.Templates,
+		"questions":         config.Questions,
+		"updated_at":        time.Now(),
+		"version":           config.Version + 1,
 		// GSI1 for querying active channels
-		"GSI1PK": fmt.Sprintf("ACTIVE#%t", config.Enabled),
+		"GSI1PK": fmt.Sprintf("ACTIVE#%t", config.Active()),
 		"GSI1SK": fmt.Sprintf("CHANNEL#%s#%s", config.TeamID, config.ChannelID),
 	}
 
+	if config.ArchivedAt != nil {
+		item["archived_at"] = *config.ArchivedAt
+	}
+
 	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
 		return &store.Error{Code: "MARSHAL_ERROR", Message: "Failed to marshal item", Err: err}
 	}
 
+	// Version 0 is a new config, or one stored before versions were kept
+	condition := expression.AttributeNotExists(expression.Name("version"))
+	if config.Version > 0 {
+		condition = expression.Name("version").Equal(expression.Value(config.Version))
+	}
+	expr, err := expression.NewBuilder().WithCondition(condition).Build()
+	if err != nil {
+		return &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
 	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: aws.String(s.tableName),
-		Item:      av,
+		TableName:                           aws.String(s.tableName),
+		Item:                                av,
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
 	})
 	if err != nil {
+		var cfe *types.ConditionalCheckFailedException
+		if errors.As(err, &cfe) {
+			conflict := &store.ConflictError{TeamID: config.TeamID, ChannelID: config.ChannelID, Expected: config.Version}
+			// The stored config comes back with the failure
+			var current struct {
+				Version int64 `dynamodbav:"version"`
+			}
+			if err := attributevalue.UnmarshalMap(cfe.Item, &current); err == nil {
+				conflict.Current = current.Version
+			}
+			return conflict
+		}
 		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save channel config", Err: err}
 	}
 
+	config.Version++
+	return nil
+}
+
+// DisableChannelConfig turns a channel's standups off, keeping its config.
+func (s *Store) DisableChannelConfig(ctx context.Context, teamID, channelID string) error {
+	if err := validateChannelConfigKey(teamID, channelID); err != nil {
+		return err
+	}
+
+	pk, sk := channelConfigKey(teamID, channelID)
+	update := expression.Set(expression.Name("enabled"), expression.Value(false)).
+		Set(expression.Name("GSI1PK"), expression.Value("ACTIVE#false")).
+		Set(expression.Name("updated_at"), expression.Value(time.Now())).
+		Add(expression.Name("version"), expression.Value(1))
+	return s.updateItem(ctx, pk, sk, update, "Failed to disable channel config")
+}
+
+// ArchiveChannelConfig pauses a channel's standups while it's archived.
+func (s *Store) ArchiveChannelConfig(ctx context.Context, teamID, channelID string, archivedAt time.Time) error {
+	if err := validateChannelConfigKey(teamID, channelID); err != nil {
+		return err
+	}
+
+	pk, sk := channelConfigKey(teamID, channelID)
+	update := expression.Set(expression.Name("archived_at"), expression.Value(archivedAt)).
+		Set(expression.Name("GSI1PK"), expression.Value("ACTIVE#false")).
+		Set(expression.Name("updated_at"), expression.Value(time.Now())).
+		Add(expression.Name("version"), expression.Value(1))
+	return s.updateItem(ctx, pk, sk, update, "Failed to archive channel config")
+}
+
+// UnarchiveChannelConfig resumes the standups of an unarchived channel.
+// Whether it's active again depends on whether it's enabled, so the config
+// is saved again rather than updated in place.
+func (s *Store) UnarchiveChannelConfig(ctx context.Context, teamID, channelID string) error {
+	config, err := s.GetChannelConfig(ctx, teamID, channelID)
+	if err != nil {
+		return err
+	}
+	if config.ArchivedAt == nil {
+		return nil
+	}
+
+	config.ArchivedAt = nil
+	return s.SaveChannelConfig(ctx, config)
+}
+
+// validateChannelConfigKey checks the IDs a channel config is stored under.
+func validateChannelConfigKey(teamID, channelID string) error {
+	if err := validation.ValidateTeamID(teamID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
+	}
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
 	return nil
 }
 
@@ -302,7 +490,7 @@ func (s *Store) CreateSession(ctx context.Context, session *store.Session) error
 		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
 	}
 
-	pk, sk := sessionKey(session.ChannelID, session.Date)
+	pk, sk := sessionKey(channelScope(ctx, session.ChannelID), session.Date)
 
 	item := map[string]interface{}{
 		"PK":             pk,
@@ -312,8 +500,13 @@ func (s *Store) CreateSession(ctx context.Context, session *store.Session) error
 		"date":           session.Date,
 		"status":         session.Status,
 		"summary_posted": session.SummaryPosted,
+		"anchor_ts":      session.AnchorTS,
+		"group_members":  session.GroupMembers,
 		"created_at":     session.CreatedAt,
 		"TTL":            s.calculateTTL(session.CreatedAt),
+		// GSI1 for listing a channel's sessions by date
+		"GSI1PK": fmt.Sprintf("CHANNEL#%s", channelScope(ctx, session.ChannelID)),
+		"GSI1SK": fmt.Sprintf("SESSION#%s", session.Date),
 	}
 
 	av, err := attributevalue.MarshalMap(item)
@@ -350,7 +543,7 @@ func (s *Store) GetSession(ctx context.Context, channelID, date string) (*store.
 		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
 	}
 
-	pk, sk := sessionKey(channelID, date)
+	pk, sk := sessionKey(channelScope(ctx, channelID), date)
 
 	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: aws.String(s.tableName),
@@ -375,6 +568,153 @@ func (s *Store) GetSession(ctx context.Context, channelID, date string) (*store.
 	return &session, nil
 }
 
+// GetSessionWithResponses retrieves a session together with its responses.
+// They share a partition, so a single query reads both.
+func (s *Store) GetSessionWithResponses(
+	ctx context.Context,
+	channelID, date string,
+) (*store.Session, []*store.UserResponse, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return nil, nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+
+	pk, sessionSK := sessionKey(channelScope(ctx, channelID), date)
+
+	expr, err := expression.NewBuilder().WithKeyCondition(expression.Key("PK").Equal(expression.Value(pk))).Build()
+	if err != nil {
+		return nil, nil, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	var (
+		session   *store.Session
+		responses []*store.UserResponse
+	)
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query session", Err: err}
+		}
+
+		for _, item := range page.Items {
+			sk, _ := item["SK"].(*types.AttributeValueMemberS)
+			switch {
+			case sk == nil:
+				continue
+			case sk.Value == sessionSK:
+				session = &store.Session{}
+				if err := attributevalue.UnmarshalMap(item, session); err != nil {
+					return nil, nil, &store.Error{Code: "UNMARSHAL_ERROR", Message: "Failed to unmarshal item", Err: err}
+				}
+			case strings.HasPrefix(sk.Value, "USER#"):
+				var response store.UserResponse
+				if err := attributevalue.UnmarshalMap(item, &response); err != nil {
+					continue // Skip invalid items
+				}
+				responses = append(responses, &response)
+			}
+		}
+	}
+
+	if session == nil {
+		return nil, nil, store.ErrNotFound
+	}
+
+	return session, responses, nil
+}
+
+// GetSessions retrieves the sessions of several channels on a date with
+// BatchGetItem, keyed by channel ID.
+func (s *Store) GetSessions(ctx context.Context, channelIDs []string, date string) (map[string]*store.Session, error) {
+	// Validate inputs
+	for _, channelID := range channelIDs {
+		if err := validation.ValidateChannelID(channelID); err != nil {
+			return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+		}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+
+	seen := make(map[string]bool, len(channelIDs))
+	keys := make([]map[string]types.AttributeValue, 0, len(channelIDs))
+	for _, channelID := range channelIDs {
+		if seen[channelID] {
+			continue // BatchGetItem rejects duplicate keys
+		}
+		seen[channelID] = true
+
+		pk, sk := sessionKey(channelScope(ctx, channelID), date)
+		keys = append(keys, map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: pk},
+			"SK": &types.AttributeValueMemberS{Value: sk},
+		})
+	}
+
+	sessions := make(map[string]*store.Session, len(keys))
+	for start := 0; start < len(keys); start += batchGetLimit {
+		items, err := s.batchGetItems(ctx, keys[start:min(start+batchGetLimit, len(keys))])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			var session store.Session
+			if err := attributevalue.UnmarshalMap(item, &session); err != nil {
+				return nil, &store.Error{Code: "UNMARSHAL_ERROR", Message: "Failed to unmarshal item", Err: err}
+			}
+			sessions[session.ChannelID] = &session
+		}
+	}
+
+	return sessions, nil
+}
+
+// batchGetItems reads up to batchGetLimit items, retrying the keys DynamoDB
+// leaves unprocessed when throttled.
+func (s *Store) batchGetItems(
+	ctx context.Context,
+	keys []map[string]types.AttributeValue,
+) ([]map[string]types.AttributeValue, error) {
+	var items []map[string]types.AttributeValue
+	request := map[string]types.KeysAndAttributes{
+		s.tableName: {Keys: keys},
+	}
+
+	for attempt := 0; len(request) > 0; attempt++ {
+		if attempt > batchGetRetries {
+			return nil, &store.Error{Code: "BATCH_GET_ERROR", Message: "Keys left unprocessed after retries"}
+		}
+		if attempt > 0 {
+			select {
+			case <-time.After(time.Duration(1<<attempt) * 25 * time.Millisecond):
+			case <-ctx.Done():
+				return nil, &store.Error{Code: "BATCH_GET_ERROR", Message: "Failed to batch get items", Err: ctx.Err()}
+			}
+		}
+
+		result, err := s.client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{RequestItems: request})
+		if err != nil {
+			return nil, &store.Error{Code: "BATCH_GET_ERROR", Message: "Failed to batch get items", Err: err}
+		}
+
+		items = append(items, result.Responses[s.tableName]...)
+		request = result.UnprocessedKeys
+	}
+
+	return items, nil
+}
+
 // UpdateSessionStatus updates the status of a session.
 func (s *Store) UpdateSessionStatus(
 	ctx context.Context,
@@ -389,14 +729,26 @@ func (s *Store) UpdateSessionStatus(
 		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
 	}
 
-	pk, sk := sessionKey(channelID, date)
+	pk, sk := sessionKey(channelScope(ctx, channelID), date)
 
 	update := expression.Set(expression.Name("status"), expression.Value(status))
 	if status == store.SessionCompleted {
 		update = update.Set(expression.Name("completed_at"), expression.Value(time.Now()))
 	}
 
-	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	return s.updateItem(ctx, pk, sk, update, "Failed to update session status")
+}
+
+// updateItem applies update to an existing item, such as a session,
+// returning ErrNotFound rather than creating a partial item.
+func (s *Store) updateItem(
+	ctx context.Context,
+	pk, sk string,
+	update expression.UpdateBuilder,
+	message string,
+) error {
+	condition := expression.AttributeExists(expression.Name("PK"))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
 	if err != nil {
 		return &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
 	}
@@ -408,18 +760,24 @@ func (s *Store) UpdateSessionStatus(
 			"SK": &types.AttributeValueMemberS{Value: sk},
 		},
 		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
 	})
 	if err != nil {
-		return &store.Error{Code: "UPDATE_ERROR", Message: "Failed to update session status", Err: err}
+		var cfe *types.ConditionalCheckFailedException
+		if errors.As(err, &cfe) {
+			return store.ErrNotFound
+		}
+		return &store.Error{Code: "UPDATE_ERROR", Message: message, Err: err}
 	}
 
 	return nil
 }
 
-// MarkSummaryPosted marks a session summary as posted.
-func (s *Store) MarkSummaryPosted(ctx context.Context, channelID, date string) error {
+// ClaimSummary marks a session summary as posted before posting it,
+// returning ErrAlreadyExists if it already was.
+func (s *Store) ClaimSummary(ctx context.Context, channelID, date string) error {
 	// Validate inputs
 	if err := validation.ValidateChannelID(channelID); err != nil {
 		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
@@ -428,10 +786,15 @@ func (s *Store) MarkSummaryPosted(ctx context.Context, channelID, date string) e
 		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
 	}
 
-	pk, sk := sessionKey(channelID, date)
+	pk, sk := sessionKey(channelScope(ctx, channelID), date)
 
-	update := expression.Set(expression.Name("summary_posted"), expression.Value(true))
-	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	summaryPosted := expression.Name("summary_posted")
+	update := expression.Set(summaryPosted, expression.Value(true))
+	condition := expression.AttributeExists(expression.Name("PK")).And(expression.Or(
+		expression.AttributeNotExists(summaryPosted),
+		expression.Equal(summaryPosted, expression.Value(false)),
+	))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
 	if err != nil {
 		return &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
 	}
@@ -443,113 +806,481 @@ func (s *Store) MarkSummaryPosted(ctx context.Context, channelID, date string) e
 			"SK": &types.AttributeValueMemberS{Value: sk},
 		},
 		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
+		// Tells a missing session from one already posted
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
 	})
 	if err != nil {
-		return &store.Error{Code: "UPDATE_ERROR", Message: "Failed to mark summary posted", Err: err}
+		var cfe *types.ConditionalCheckFailedException
+		if errors.As(err, &cfe) {
+			if len(cfe.Item) == 0 {
+				return store.ErrNotFound
+			}
+			return store.ErrAlreadyExists
+		}
+		return &store.Error{Code: "UPDATE_ERROR", Message: "Failed to claim summary", Err: err}
 	}
 
 	return nil
 }
 
-// SaveUserResponse saves a user's standup response.
-func (s *Store) SaveUserResponse(ctx context.Context, response *store.UserResponse) error {
+// ReleaseSummary clears a session's summary claim after posting failed.
+func (s *Store) ReleaseSummary(ctx context.Context, channelID, date string) error {
 	// Validate inputs
-	if err := validation.ValidateChannelID(response.ChannelID); err != nil {
+	if err := validation.ValidateChannelID(channelID); err != nil {
 		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
 	}
-	if err := validation.ValidateDate(response.Date); err != nil {
+	if err := validation.ValidateDate(date); err != nil {
 		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
 	}
-	if err := validation.ValidateUserID(response.UserID); err != nil {
-		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
-	}
 
-	pk, sk := userResponseKey(response.ChannelID, response.Date, response.UserID)
+	pk, sk := sessionKey(channelScope(ctx, channelID), date)
 
-	item := map[string]interface{}{
-		"PK":             pk,
-		"SK":             sk,
-		"session_id":     response.SessionID,
-		"channel_id":     response.ChannelID,
-		"date":           response.Date,
-		"user_id":        response.UserID,
-		"user_name":      response.UserName,
-		"responses":      response.Responses,
-		"submitted_at":   response.SubmittedAt,
-		"reminder_count": response.ReminderCount,
-		"TTL":            s.calculateTTL(response.SubmittedAt),
+	update := expression.Set(expression.Name("summary_posted"), expression.Value(false))
+	return s.updateItem(ctx, pk, sk, update, "Failed to release summary")
+}
+
+// MarkSummaryPosted marks a session summary as posted, recording the
+// timestamp of the summary message.
+func (s *Store) MarkSummaryPosted(ctx context.Context, channelID, date, summaryTS string) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
 	}
 
-	av, err := attributevalue.MarshalMap(item)
-	if err != nil {
-		return &store.Error{Code: "MARSHAL_ERROR", Message: "Failed to marshal item", Err: err}
+	pk, sk := sessionKey(channelScope(ctx, channelID), date)
+
+	update := expression.Set(expression.Name("summary_posted"), expression.Value(true))
+	if summaryTS != "" {
+		update = update.Set(expression.Name("summary_ts"), expression.Value(summaryTS))
 	}
+	return s.updateItem(ctx, pk, sk, update, "Failed to mark summary posted")
+}
 
-	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: aws.String(s.tableName),
-		Item:      av,
-	})
-	if err != nil {
-		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save user response", Err: err}
+// SetSessionAnchor records the timestamp of the daily thread anchor message.
+func (s *Store) SetSessionAnchor(ctx context.Context, channelID, date, anchorTS string) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
 	}
 
-	return nil
+	pk, sk := sessionKey(channelScope(ctx, channelID), date)
+
+	update := expression.Set(expression.Name("anchor_ts"), expression.Value(anchorTS))
+	return s.updateItem(ctx, pk, sk, update, "Failed to set session anchor")
 }
 
-// GetUserResponse retrieves a user's standup response.
-func (s *Store) GetUserResponse(
-	ctx context.Context,
-	channelID, date, userID string,
-) (*store.UserResponse, error) {
+// MarkSessionReviewed records a lead acknowledging the session's summary.
+func (s *Store) MarkSessionReviewed(ctx context.Context, channelID, date, userID string) error {
 	// Validate inputs
 	if err := validation.ValidateChannelID(channelID); err != nil {
-		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
 	}
 	if err := validation.ValidateDate(date); err != nil {
-		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
 	}
 	if err := validation.ValidateUserID(userID); err != nil {
-		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
 	}
 
-	pk, sk := userResponseKey(channelID, date, userID)
+	pk, sk := sessionKey(channelScope(ctx, channelID), date)
 
-	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+	reviewedBy := expression.Name("reviewed_by")
+	update := expression.Set(reviewedBy, expression.ListAppend(
+		expression.IfNotExists(reviewedBy, expression.Value([]string{})),
+		expression.Value([]string{userID}),
+	))
+	condition := expression.AttributeExists(expression.Name("PK")).
+		And(expression.Not(expression.Contains(reviewedBy, userID)))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		return &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
 		TableName: aws.String(s.tableName),
 		Key: map[string]types.AttributeValue{
 			"PK": &types.AttributeValueMemberS{Value: pk},
 			"SK": &types.AttributeValueMemberS{Value: sk},
 		},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		// Tells a missing session from one already reviewed by this user
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
 	})
 	if err != nil {
-		return nil, &store.Error{Code: "GET_ERROR", Message: "Failed to get user response", Err: err}
-	}
-
-	if result.Item == nil {
-		return nil, store.ErrNotFound
-	}
-
-	var response store.UserResponse
-	if err := attributevalue.UnmarshalMap(result.Item, &response); err != nil {
-		return nil, &store.Error{Code: "UNMARSHAL_ERROR", Message: "Failed to unmarshal item", Err: err}
+		var cfe *types.ConditionalCheckFailedException
+		if errors.As(err, &cfe) {
+			if len(cfe.Item) == 0 {
+				return store.ErrNotFound
+			}
+			return nil
+		}
+		return &store.Error{Code: "UPDATE_ERROR", Message: "Failed to mark session reviewed", Err: err}
 	}
 
-	return &response, nil
+	return nil
 }
 
-// ListUserResponses lists all user responses for a session.
-func (s *Store) ListUserResponses(ctx context.Context, channelID, date string) ([]*store.UserResponse, error) {
+// ListSessions lists a channel's sessions between two dates, newest first.
+func (s *Store) ListSessions(ctx context.Context, channelID, startDate, endDate string) ([]*store.Session, error) {
 	// Validate inputs
 	if err := validation.ValidateChannelID(channelID); err != nil {
 		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
 	}
-	if err := validation.ValidateDate(date); err != nil {
-		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	if err := validation.ValidateDate(startDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid start date", Err: err}
+	}
+	if err := validation.ValidateDate(endDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid end date", Err: err}
 	}
 
-	pk := fmt.Sprintf("SESSION#%s#%s", channelID, date)
+	keyCond := expression.Key("GSI1PK").Equal(expression.Value(fmt.Sprintf("CHANNEL#%s", channelScope(ctx, channelID)))).And(
+		expression.Key("GSI1SK").Between(
+			expression.Value(fmt.Sprintf("SESSION#%s", startDate)),
+			expression.Value(fmt.Sprintf("SESSION#%s", endDate)),
+		),
+	)
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	var sessions []*store.Session
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		IndexName:                 aws.String("GSI1"),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ScanIndexForward:          aws.Bool(false),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query sessions", Err: err}
+		}
+
+		for _, item := range page.Items {
+			var session store.Session
+			if err := attributevalue.UnmarshalMap(item, &session); err != nil {
+				continue // Skip invalid items
+			}
+			sessions = append(sessions, &session)
+		}
+	}
+
+	return sessions, nil
+}
+
+// SaveUserResponse saves a user's standup response.
+func (s *Store) SaveUserResponse(ctx context.Context, response *store.UserResponse) error {
+	av, err := s.userResponseItem(ctx, response)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.tableName),
+		Item:      av,
+	})
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save user response", Err: err}
+	}
+
+	return nil
+}
+
+// SubmitUserResponse saves a user's first response for a session in one
+// transaction that also creates the session if needed and increments its
+// response count. Later edits replace the response without counting it again.
+func (s *Store) SubmitUserResponse(ctx context.Context, session *store.Session, response *store.UserResponse) error {
+	if session.ChannelID != response.ChannelID || session.Date != response.Date {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Response does not belong to session"}
+	}
+
+	responseItem, err := s.userResponseItem(ctx, response)
+	if err != nil {
+		return err
+	}
+
+	pk, sk := sessionKey(channelScope(ctx, session.ChannelID), session.Date)
+
+	// Create the session fields only if missing, and count the response
+	update := expression.Set(expression.Name("session_id"),
+		expression.IfNotExists(expression.Name("session_id"), expression.Value(session.SessionID))).
+		Set(expression.Name("channel_id"), expression.Value(session.ChannelID)).
+		Set(expression.Name("date"), expression.Value(session.Date)).
+		Set(expression.Name("status"),
+			expression.IfNotExists(expression.Name("status"), expression.Value(session.Status))).
+		Set(expression.Name("summary_posted"),
+			expression.IfNotExists(expression.Name("summary_posted"), expression.Value(session.SummaryPosted))).
+		Set(expression.Name("created_at"),
+			expression.IfNotExists(expression.Name("created_at"), expression.Value(session.CreatedAt))).
+		Set(expression.Name("TTL"),
+			expression.IfNotExists(expression.Name("TTL"), expression.Value(s.calculateTTL(session.CreatedAt)))).
+		Set(expression.Name("GSI1PK"), expression.Value(fmt.Sprintf("CHANNEL#%s", channelScope(ctx, session.ChannelID)))).
+		Set(expression.Name("GSI1SK"), expression.Value(fmt.Sprintf("SESSION#%s", session.Date))).
+		Add(expression.Name("response_count"), expression.Value(1))
+
+	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	if err != nil {
+		return &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Update: &types.Update{
+					TableName: aws.String(s.tableName),
+					Key: map[string]types.AttributeValue{
+						"PK": &types.AttributeValueMemberS{Value: pk},
+						"SK": &types.AttributeValueMemberS{Value: sk},
+					},
+					UpdateExpression:          expr.Update(),
+					ExpressionAttributeNames:  expr.Names(),
+					ExpressionAttributeValues: expr.Values(),
+				},
+			},
+			{
+				Put: &types.Put{
+					TableName:           aws.String(s.tableName),
+					Item:                responseItem,
+					ConditionExpression: aws.String("attribute_not_exists(PK)"),
+				},
+			},
+		},
+	})
+	if err == nil {
+		return nil
+	}
+
+	// The response already exists, so this is an edit
+	var tce *types.TransactionCanceledException
+	if errors.As(err, &tce) && len(tce.CancellationReasons) == 2 &&
+		aws.ToString(tce.CancellationReasons[1].Code) == "ConditionalCheckFailed" {
+		if response.UnchangedSince.IsZero() {
+			return s.SaveUserResponse(ctx, response)
+		}
+		return s.replaceUserResponse(ctx, response)
+	}
+
+	return &store.Error{Code: "TRANSACTION_ERROR", Message: "Failed to submit user response", Err: err}
+}
+
+// replaceUserResponse replaces a response unless it was submitted after
+// response.UnchangedSince, returning store.ErrConflict if it was. The put is
+// conditional on the submission time read, so a response saved in between
+// isn't replaced either.
+func (s *Store) replaceUserResponse(ctx context.Context, response *store.UserResponse) error {
+	existing, err := s.GetUserResponse(ctx, response.ChannelID, response.Date, response.UserID)
+	if err != nil {
+		return err
+	}
+	if existing.SubmittedAt.After(response.UnchangedSince) {
+		return store.ErrConflict
+	}
+
+	av, err := s.userResponseItem(ctx, response)
+	if err != nil {
+		return err
+	}
+	submittedAt, err := attributevalue.Marshal(existing.SubmittedAt)
+	if err != nil {
+		return &store.Error{Code: "MARSHAL_ERROR", Message: "Failed to marshal submission time", Err: err}
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(s.tableName),
+		Item:                      av,
+		ConditionExpression:       aws.String("submitted_at = :submitted_at"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{":submitted_at": submittedAt},
+	})
+	if err != nil {
+		var cfe *types.ConditionalCheckFailedException
+		if errors.As(err, &cfe) {
+			return store.ErrConflict
+		}
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save user response", Err: err}
+	}
+
+	return nil
+}
+
+// userResponseItem validates a response and marshals it into a table item.
+func (s *Store) userResponseItem(ctx context.Context, response *store.UserResponse) (map[string]types.AttributeValue, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(response.ChannelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(response.Date); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+	if err := validation.ValidateUserID(response.UserID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	pk, sk := userResponseKey(channelScope(ctx, response.ChannelID), response.Date, response.UserID)
+
+	item := map[string]interface{}{
+		"PK":             pk,
+		"SK":             sk,
+		"session_id":     response.SessionID,
+		"channel_id":     response.ChannelID,
+		"date":           response.Date,
+		"user_id":        response.UserID,
+		"user_name":      response.UserName,
+		"responses":      response.Responses,
+		"submitted_at":   response.SubmittedAt,
+		"reminder_count": response.ReminderCount,
+		"TTL":            s.calculateTTL(response.SubmittedAt),
+		// GSI1 for listing responses by session ID
+		"GSI1PK": fmt.Sprintf("SESSIONID#%s", response.SessionID),
+		"GSI1SK": fmt.Sprintf("USER#%s", response.UserID),
+		// GSI3 for listing a user's responses across dates
+		"GSI3PK": fmt.Sprintf("USER#%s", response.UserID),
+		"GSI3SK": userHistoryKey(channelScope(ctx, response.ChannelID), response.Date),
+		// GSI4 for listing a user's responses on a date across channels
+		"GSI4PK": fmt.Sprintf("USER#%s", response.UserID),
+		"GSI4SK": userDayKey(response.Date, channelScope(ctx, response.ChannelID)),
+	}
+	if len(response.Answers) > 0 {
+		item["answers"] = response.Answers
+	}
+	if response.Late {
+		item["late"] = true
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return nil, &store.Error{Code: "MARSHAL_ERROR", Message: "Failed to marshal item", Err: err}
+	}
+
+	return av, nil
+}
+
+// GetUserResponse retrieves a user's standup response.
+func (s *Store) GetUserResponse(
+	ctx context.Context,
+	channelID, date, userID string,
+) (*store.UserResponse, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+	if err := validation.ValidateUserID(userID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	pk, sk := userResponseKey(channelScope(ctx, channelID), date, userID)
+
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: pk},
+			"SK": &types.AttributeValueMemberS{Value: sk},
+		},
+	})
+	if err != nil {
+		return nil, &store.Error{Code: "GET_ERROR", Message: "Failed to get user response", Err: err}
+	}
+
+	if result.Item == nil {
+		return nil, store.ErrNotFound
+	}
+
+	var response store.UserResponse
+	if err := attributevalue.UnmarshalMap(result.Item, &response); err != nil {
+		return nil, &store.Error{Code: "UNMARSHAL_ERROR", Message: "Failed to unmarshal item", Err: err}
+	}
+
+	return &response, nil
+}
+
+// ListUserResponses lists all user responses for a session.
+func (s *Store) ListUserResponses(ctx context.Context, channelID, date string) ([]*store.UserResponse, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+
+	pk := fmt.Sprintf("SESSION#%s#%s", channelScope(ctx, channelID), date)
+
+	keyCond := expression.Key("PK").Equal(expression.Value(pk)).And(
+		expression.Key("SK").BeginsWith("USER#"),
+	)
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	var responses []*store.UserResponse
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query user responses", Err: err}
+		}
+
+		for _, item := range page.Items {
+			var response store.UserResponse
+			if err := attributevalue.UnmarshalMap(item, &response); err != nil {
+				continue // Skip invalid items
+			}
+			responses = append(responses, &response)
+		}
+	}
+
+	return responses, nil
+}
+
+// ListUserResponsesPage lists a page of a session's responses by user ID.
+// One more response than the page holds is read to tell whether it's the
+// last page.
+func (s *Store) ListUserResponsesPage(
+	ctx context.Context,
+	channelID, date string,
+	limit int,
+	token string,
+) (*store.ResponsePage, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+	if err := store.ValidatePageToken(token); err != nil {
+		return nil, err
+	}
+	limit = store.PageLimit(limit)
+
+	pk := fmt.Sprintf("SESSION#%s#%s", channelScope(ctx, channelID), date)
 
 	keyCond := expression.Key("PK").Equal(expression.Value(pk)).And(
 		expression.Key("SK").BeginsWith("USER#"),
@@ -560,7 +1291,933 @@ func (s *Store) ListUserResponses(ctx context.Context, channelID, date string) (
 		return nil, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
 	}
 
-	var responses []*store.UserResponse
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Limit:                     aws.Int32(int32(min(limit+1, math.MaxInt32))),
+	}
+	if token != "" {
+		_, sk := userResponseKey(channelScope(ctx, channelID), date, token)
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: pk},
+			"SK": &types.AttributeValueMemberS{Value: sk},
+		}
+	}
+
+	result, err := s.client.Query(ctx, input)
+	if err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query user responses", Err: err}
+	}
+
+	items := result.Items
+	more := len(items) > limit || result.LastEvaluatedKey != nil
+	if len(items) > limit {
+		items = items[:limit]
+	}
+
+	page := &store.ResponsePage{}
+	for _, item := range items {
+		var response store.UserResponse
+		if err := attributevalue.UnmarshalMap(item, &response); err != nil {
+			continue // Skip invalid items
+		}
+		page.Responses = append(page.Responses, &response)
+	}
+	if more && len(page.Responses) > 0 {
+		page.NextToken = page.Responses[len(page.Responses)-1].UserID
+	}
+
+	return page, nil
+}
+
+// ListChannelsUserResponses lists the responses of several channels on a
+// date, querying the channels' session partitions in parallel. It's keyed by
+// channel ID.
+func (s *Store) ListChannelsUserResponses(
+	ctx context.Context,
+	channelIDs []string,
+	date string,
+) (map[string][]*store.UserResponse, error) {
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		sem       = make(chan struct{}, queryConcurrency)
+		firstErr  error
+		responses = make(map[string][]*store.UserResponse, len(channelIDs))
+	)
+
+	for _, channelID := range channelIDs {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(channelID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			list, err := s.ListUserResponses(ctx, channelID, date)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			if len(list) > 0 {
+				responses[channelID] = list
+			}
+		}(channelID)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return responses, nil
+}
+
+// ListUserResponsesByUser lists a user's responses in a channel between two
+// dates, newest first, without reading each day's session partition.
+func (s *Store) ListUserResponsesByUser(
+	ctx context.Context,
+	channelID, userID, startDate, endDate string,
+) ([]*store.UserResponse, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateUserID(userID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+	if err := validation.ValidateDate(startDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid start date", Err: err}
+	}
+	if err := validation.ValidateDate(endDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid end date", Err: err}
+	}
+
+	scope := channelScope(ctx, channelID)
+	keyCond := expression.Key("GSI3PK").Equal(expression.Value(fmt.Sprintf("USER#%s", userID))).And(
+		expression.Key("GSI3SK").Between(
+			expression.Value(userHistoryKey(scope, startDate)),
+			expression.Value(userHistoryKey(scope, endDate)),
+		),
+	)
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	var responses []*store.UserResponse
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		IndexName:                 aws.String("GSI3"),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ScanIndexForward:          aws.Bool(false),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query user history", Err: err}
+		}
+
+		for _, item := range page.Items {
+			var response store.UserResponse
+			if err := attributevalue.UnmarshalMap(item, &response); err != nil {
+				continue // Skip invalid items
+			}
+			responses = append(responses, &response)
+		}
+	}
+
+	return responses, nil
+}
+
+// ListResponsesForUserOnDate lists a user's responses on a date in every
+// channel of the workspace, by channel ID. Responses submitted before GSI4
+// was added aren't in it, so they aren't listed.
+func (s *Store) ListResponsesForUserOnDate(ctx context.Context, userID, date string) ([]*store.UserResponse, error) {
+	// Validate inputs
+	if err := validation.ValidateUserID(userID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+
+	// The workspace's channel scopes all start with its team ID
+	prefix := userDayKey(date, "")
+	if teamID := store.TeamScope(ctx); teamID != "" {
+		prefix = userDayKey(date, teamID+"#")
+	}
+	keyCond := expression.Key("GSI4PK").Equal(expression.Value(fmt.Sprintf("USER#%s", userID))).And(
+		expression.Key("GSI4SK").BeginsWith(prefix),
+	)
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	var responses []*store.UserResponse
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		IndexName:                 aws.String("GSI4"),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query user responses", Err: err}
+		}
+
+		for _, item := range page.Items {
+			var response store.UserResponse
+			if err := attributevalue.UnmarshalMap(item, &response); err != nil {
+				continue // Skip invalid items
+			}
+			responses = append(responses, &response)
+		}
+	}
+
+	// Sorted by scope, which orders channels by ID within a workspace
+	return responses, nil
+}
+
+// SearchUserResponses returns the responses matching query, newest first.
+// DynamoDB can't match text inside the answers, so the user's history or
+// each day's session partition is read and the answers are matched here.
+func (s *Store) SearchUserResponses(ctx context.Context, query *store.ResponseQuery) ([]*store.UserResponse, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	var candidates []*store.UserResponse
+	if query.UserID != "" {
+		responses, err := s.ListUserResponsesByUser(ctx, query.ChannelID, query.UserID, query.StartDate, query.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		candidates = responses
+	} else {
+		sessions, err := s.ListSessions(ctx, query.ChannelID, query.StartDate, query.EndDate)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			wg    sync.WaitGroup
+			sem   = make(chan struct{}, queryConcurrency)
+			errs  = make([]error, len(sessions))
+			byDay = make([][]*store.UserResponse, len(sessions))
+		)
+		for i, session := range sessions {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(i int, date string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				byDay[i], errs[i] = s.ListUserResponses(ctx, query.ChannelID, date)
+			}(i, session.Date)
+		}
+		wg.Wait()
+
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+		// Sessions are listed newest first
+		candidates = slices.Concat(byDay...)
+	}
+
+	var matches []*store.UserResponse
+	for _, response := range candidates {
+		if !query.Matches(response) {
+			continue
+		}
+		matches = append(matches, response)
+		if query.Limit > 0 && len(matches) == query.Limit {
+			break
+		}
+	}
+	return matches, nil
+}
+
+// ListSessionResponses lists all user responses for a session ID.
+func (s *Store) ListSessionResponses(ctx context.Context, sessionID string) ([]*store.UserResponse, error) {
+	// Validate inputs
+	if err := validation.ValidateSessionID(sessionID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid session ID", Err: err}
+	}
+
+	keyCond := expression.Key("GSI1PK").Equal(expression.Value(fmt.Sprintf("SESSIONID#%s", sessionID)))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	var responses []*store.UserResponse
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		IndexName:                 aws.String("GSI1"),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query session responses", Err: err}
+		}
+
+		for _, item := range page.Items {
+			var response store.UserResponse
+			if err := attributevalue.UnmarshalMap(item, &response); err != nil {
+				continue // Skip invalid items
+			}
+			responses = append(responses, &response)
+		}
+	}
+
+	return responses, nil
+}
+
+// IncrementReminderCount increments the reminder count on a user's response.
+// Users who have not responded have no response, so nothing is counted for them.
+func (s *Store) IncrementReminderCount(ctx context.Context, channelID, date, userID string) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+	if err := validation.ValidateUserID(userID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	pk, sk := userResponseKey(channelScope(ctx, channelID), date, userID)
+
+	update := expression.Add(expression.Name("reminder_count"), expression.Value(1))
+	condition := expression.AttributeExists(expression.Name("PK"))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		return &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: pk},
+			"SK": &types.AttributeValueMemberS{Value: sk},
+		},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	var cfe *types.ConditionalCheckFailedException
+	if errors.As(err, &cfe) {
+		return nil
+	}
+	if err != nil {
+		return &store.Error{Code: "UPDATE_ERROR", Message: "Failed to increment reminder count", Err: err}
+	}
+
+	return nil
+}
+
+// SaveReminder saves a reminder record.
+func (s *Store) SaveReminder(ctx context.Context, reminder *store.Reminder) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(reminder.ChannelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(reminder.Date); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+	if err := validation.ValidateUserID(reminder.UserID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	pk, sk := reminderKey(channelScope(ctx, reminder.ChannelID), reminder.Date, reminder.UserID, reminder.Time)
+
+	item := map[string]interface{}{
+		"PK":         pk,
+		"SK":         sk,
+		"channel_id": reminder.ChannelID,
+		"date":       reminder.Date,
+		"user_id":    reminder.UserID,
+		"time":       reminder.Time,
+		"sent_at":    reminder.SentAt,
+		"message_ts": reminder.MessageTS,
+		"TTL":        s.calculateTTL(reminder.SentAt),
+	}
+	if reminder.SnoozedUntil != nil {
+		item["snoozed_until"] = reminder.SnoozedUntil
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return &store.Error{Code: "MARSHAL_ERROR", Message: "Failed to marshal item", Err: err}
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.tableName),
+		Item:      av,
+	})
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save reminder", Err: err}
+	}
+
+	return nil
+}
+
+// ListReminders lists all reminders for a channel and date.
+func (s *Store) ListReminders(ctx context.Context, channelID, date string) ([]*store.Reminder, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+
+	pk := fmt.Sprintf("REMINDER#%s#%s", channelScope(ctx, channelID), date)
+
+	keyCond := expression.Key("PK").Equal(expression.Value(pk))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	var reminders []*store.Reminder
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query reminders", Err: err}
+		}
+
+		for _, item := range page.Items {
+			var reminder store.Reminder
+			if err := attributevalue.UnmarshalMap(item, &reminder); err != nil {
+				continue // Skip invalid items
+			}
+			reminders = append(reminders, &reminder)
+		}
+	}
+
+	return reminders, nil
+}
+
+// SaveFailedReminder records a reminder that couldn't be delivered.
+func (s *Store) SaveFailedReminder(ctx context.Context, failure *store.FailedReminder) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(failure.ChannelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(failure.Date); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+	if err := validation.ValidateUserID(failure.UserID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	pk, sk := failedReminderKey(channelScope(ctx, failure.ChannelID), failure.Date, failure.UserID, failure.Time)
+
+	item := map[string]interface{}{
+		"PK":         pk,
+		"SK":         sk,
+		"channel_id": failure.ChannelID,
+		"date":       failure.Date,
+		"user_id":    failure.UserID,
+		"time":       failure.Time,
+		"error_code": failure.ErrorCode,
+		"error":      failure.Error,
+		"failed_at":  failure.FailedAt,
+		"TTL":        s.calculateTTL(failure.FailedAt),
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return &store.Error{Code: "MARSHAL_ERROR", Message: "Failed to marshal item", Err: err}
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.tableName),
+		Item:      av,
+	})
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save failed reminder", Err: err}
+	}
+
+	return nil
+}
+
+// ListFailedReminders lists a channel's failed reminders between two dates,
+// newest first.
+func (s *Store) ListFailedReminders(ctx context.Context, channelID, startDate, endDate string) ([]*store.FailedReminder, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(startDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid start date", Err: err}
+	}
+	if err := validation.ValidateDate(endDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid end date", Err: err}
+	}
+
+	pk, _ := failedReminderKey(channelScope(ctx, channelID), startDate, "", "")
+
+	// Sort keys start with the date, and "$" sorts after every "#" suffix
+	keyCond := expression.Key("PK").Equal(expression.Value(pk)).And(
+		expression.Key("SK").Between(
+			expression.Value(startDate),
+			expression.Value(endDate+"$"),
+		),
+	)
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	var failures []*store.FailedReminder
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ScanIndexForward:          aws.Bool(false),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query failed reminders", Err: err}
+		}
+
+		for _, item := range page.Items {
+			var failure store.FailedReminder
+			if err := attributevalue.UnmarshalMap(item, &failure); err != nil {
+				continue // Skip invalid items
+			}
+			failures = append(failures, &failure)
+		}
+	}
+
+	return failures, nil
+}
+
+// SaveSkippedResponse records that a user skipped a day's standup.
+func (s *Store) SaveSkippedResponse(ctx context.Context, skip *store.SkippedResponse) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(skip.ChannelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(skip.Date); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+	if err := validation.ValidateUserID(skip.UserID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	pk, sk := skippedResponseKey(channelScope(ctx, skip.ChannelID), skip.Date, skip.UserID)
+
+	item := map[string]interface{}{
+		"PK":         pk,
+		"SK":         sk,
+		"channel_id": skip.ChannelID,
+		"date":       skip.Date,
+		"user_id":    skip.UserID,
+		"reason":     skip.Reason,
+		"skipped_at": skip.SkippedAt,
+		"TTL":        s.calculateTTL(skip.SkippedAt),
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return &store.Error{Code: "MARSHAL_ERROR", Message: "Failed to marshal item", Err: err}
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.tableName),
+		Item:      av,
+	})
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save skipped response", Err: err}
+	}
+
+	return nil
+}
+
+// ListSkippedResponses lists all users who skipped a session.
+func (s *Store) ListSkippedResponses(ctx context.Context, channelID, date string) ([]*store.SkippedResponse, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+
+	pk := fmt.Sprintf("SESSION#%s#%s", channelScope(ctx, channelID), date)
+
+	keyCond := expression.Key("PK").Equal(expression.Value(pk)).And(
+		expression.Key("SK").BeginsWith("SKIP#"),
+	)
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	var skips []*store.SkippedResponse
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query skipped responses", Err: err}
+		}
+
+		for _, item := range page.Items {
+			var skip store.SkippedResponse
+			if err := attributevalue.UnmarshalMap(item, &skip); err != nil {
+				continue // Skip invalid items
+			}
+			skips = append(skips, &skip)
+		}
+	}
+
+	return skips, nil
+}
+
+// SaveEscalationRecord records an escalation, returning ErrAlreadyExists if
+// the user was already escalated for that day.
+func (s *Store) SaveEscalationRecord(ctx context.Context, record *store.EscalationRecord) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(record.ChannelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(record.Date); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+	if err := validation.ValidateUserID(record.UserID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	pk, sk := escalationKey(channelScope(ctx, record.ChannelID), record.Date, record.UserID)
+
+	item := map[string]interface{}{
+		"PK":             pk,
+		"SK":             sk,
+		"channel_id":     record.ChannelID,
+		"date":           record.Date,
+		"user_id":        record.UserID,
+		"action":         record.Action,
+		"reminder_count": record.ReminderCount,
+		"escalated_at":   record.EscalatedAt,
+		"TTL":            s.calculateTTL(record.EscalatedAt),
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return &store.Error{Code: "MARSHAL_ERROR", Message: "Failed to marshal item", Err: err}
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.tableName),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	})
+	if err != nil {
+		var cfe *types.ConditionalCheckFailedException
+		if errors.As(err, &cfe) {
+			return store.ErrAlreadyExists
+		}
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save escalation record", Err: err}
+	}
+
+	return nil
+}
+
+// DeleteEscalationRecord releases a user's escalation claim for a day.
+func (s *Store) DeleteEscalationRecord(ctx context.Context, channelID, date, userID string) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+	if err := validation.ValidateUserID(userID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	pk, sk := escalationKey(channelScope(ctx, channelID), date, userID)
+	return s.deleteItem(ctx, pk, sk, "Failed to delete escalation record")
+}
+
+// SaveDigestRecord records a posted digest, returning ErrAlreadyExists if the
+// digest for that period was already recorded.
+func (s *Store) SaveDigestRecord(ctx context.Context, record *store.DigestRecord) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(record.ChannelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	pk, sk := digestKey(channelScope(ctx, record.ChannelID), record.Period, record.PeriodKey)
+
+	item := map[string]interface{}{
+		"PK":         pk,
+		"SK":         sk,
+		"channel_id": record.ChannelID,
+		"period":     record.Period,
+		"period_key": record.PeriodKey,
+		"posted_at":  record.PostedAt,
+		"TTL":        s.calculateTTL(record.PostedAt),
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return &store.Error{Code: "MARSHAL_ERROR", Message: "Failed to marshal item", Err: err}
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.tableName),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	})
+	if err != nil {
+		var cfe *types.ConditionalCheckFailedException
+		if errors.As(err, &cfe) {
+			return store.ErrAlreadyExists
+		}
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save digest record", Err: err}
+	}
+
+	return nil
+}
+
+// DeleteDigestRecord releases a digest's claim on its period.
+func (s *Store) DeleteDigestRecord(
+	ctx context.Context, channelID string, period store.DigestPeriod, periodKey string,
+) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	pk, sk := digestKey(channelScope(ctx, channelID), period, periodKey)
+	return s.deleteItem(ctx, pk, sk, "Failed to delete digest record")
+}
+
+// SaveScheduledRun saves when a channel's scheduled task next runs. Runs
+// have no TTL, since a task may not run for weeks.
+func (s *Store) SaveScheduledRun(ctx context.Context, run *store.ScheduledRun) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(run.ChannelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if run.Task == "" {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Task is required"}
+	}
+
+	pk, sk := scheduleKey(channelScope(ctx, run.ChannelID), run.Task)
+
+	item := map[string]interface{}{
+		"PK":          pk,
+		"SK":          sk,
+		"channel_id":  run.ChannelID,
+		"task":        run.Task,
+		"clock":       run.Clock,
+		"timezone":    run.Timezone,
+		"next_run_at": run.NextRunAt,
+		"updated_at":  run.UpdatedAt,
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return &store.Error{Code: "MARSHAL_ERROR", Message: "Failed to marshal item", Err: err}
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.tableName),
+		Item:      av,
+	})
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save scheduled run", Err: err}
+	}
+
+	return nil
+}
+
+// ListScheduledRuns lists when a channel's scheduled tasks next run, by task.
+func (s *Store) ListScheduledRuns(ctx context.Context, channelID string) ([]*store.ScheduledRun, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	pk, _ := scheduleKey(channelScope(ctx, channelID), "")
+
+	keyCond := expression.Key("PK").Equal(expression.Value(pk))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	var runs []*store.ScheduledRun
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query scheduled runs", Err: err}
+		}
+
+		for _, item := range page.Items {
+			var run store.ScheduledRun
+			if err := attributevalue.UnmarshalMap(item, &run); err != nil {
+				continue // Skip invalid items
+			}
+			runs = append(runs, &run)
+		}
+	}
+
+	return runs, nil
+}
+
+// validateHeartbeatKey validates the job and, for per-channel jobs, the
+// channel ID that identify a heartbeat.
+func validateHeartbeatKey(job, channelID string) error {
+	if job == "" {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Job is required"}
+	}
+	if channelID != "" {
+		if err := validation.ValidateChannelID(channelID); err != nil {
+			return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+		}
+	}
+	return nil
+}
+
+// SaveHeartbeat saves a recurring job's health. Heartbeats have no TTL, so
+// a job that stops running is still there to alert on.
+func (s *Store) SaveHeartbeat(ctx context.Context, heartbeat *store.Heartbeat) error {
+	// Validate inputs
+	if err := validateHeartbeatKey(heartbeat.Job, heartbeat.ChannelID); err != nil {
+		return err
+	}
+
+	pk, sk := heartbeatKey(heartbeat.TeamID, heartbeat.Job, heartbeat.ChannelID)
+
+	item := map[string]interface{}{
+		"PK":              pk,
+		"SK":              sk,
+		"job":             heartbeat.Job,
+		"team_id":         heartbeat.TeamID,
+		"channel_id":      heartbeat.ChannelID,
+		"last_success_at": heartbeat.LastSuccessAt,
+		"failures":        heartbeat.Failures,
+		"last_error":      heartbeat.LastError,
+		"updated_at":      heartbeat.UpdatedAt,
+	}
+	if heartbeat.AlertedAt != nil {
+		item["alerted_at"] = *heartbeat.AlertedAt
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return &store.Error{Code: "MARSHAL_ERROR", Message: "Failed to marshal item", Err: err}
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.tableName),
+		Item:      av,
+	})
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save heartbeat", Err: err}
+	}
+
+	return nil
+}
+
+// GetHeartbeat retrieves a recurring job's health.
+func (s *Store) GetHeartbeat(ctx context.Context, teamID, job, channelID string) (*store.Heartbeat, error) {
+	// Validate inputs
+	if err := validateHeartbeatKey(job, channelID); err != nil {
+		return nil, err
+	}
+
+	pk, sk := heartbeatKey(teamID, job, channelID)
+
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: pk},
+			"SK": &types.AttributeValueMemberS{Value: sk},
+		},
+	})
+	if err != nil {
+		return nil, &store.Error{Code: "GET_ERROR", Message: "Failed to get heartbeat", Err: err}
+	}
+
+	if result.Item == nil {
+		return nil, store.ErrNotFound
+	}
+
+	var heartbeat store.Heartbeat
+	if err := attributevalue.UnmarshalMap(result.Item, &heartbeat); err != nil {
+		return nil, &store.Error{Code: "UNMARSHAL_ERROR", Message: "Failed to unmarshal item", Err: err}
+	}
+
+	return &heartbeat, nil
+}
+
+// ListHeartbeats lists the heartbeats of every workspace.
+func (s *Store) ListHeartbeats(ctx context.Context) ([]*store.Heartbeat, error) {
+	pk, _ := heartbeatKey("", "", "")
+
+	keyCond := expression.Key("PK").Equal(expression.Value(pk))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	var list []*store.Heartbeat
 	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
 		TableName:                 aws.String(s.tableName),
 		KeyConditionExpression:    expr.KeyCondition(),
@@ -571,84 +2228,123 @@ func (s *Store) ListUserResponses(ctx context.Context, channelID, date string) (
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(ctx)
 		if err != nil {
-			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query user responses", Err: err}
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query heartbeats", Err: err}
 		}
 
 		for _, item := range page.Items {
-			var response store.UserResponse
-			if err := attributevalue.UnmarshalMap(item, &response); err != nil {
+			var heartbeat store.Heartbeat
+			if err := attributevalue.UnmarshalMap(item, &heartbeat); err != nil {
 				continue // Skip invalid items
 			}
-			responses = append(responses, &response)
+			list = append(list, &heartbeat)
 		}
 	}
 
-	return responses, nil
+	return list, nil
 }
 
-// IncrementReminderCount increments the reminder count for a user.
-func (s *Store) IncrementReminderCount(ctx context.Context, channelID, date, userID string) error {
+// SaveStreak saves a user's streak in a channel. Streaks have no TTL, as
+// they outlast the responses they count.
+func (s *Store) SaveStreak(ctx context.Context, streak *store.Streak) error {
 	// Validate inputs
-	if err := validation.ValidateChannelID(channelID); err != nil {
+	if err := validation.ValidateChannelID(streak.ChannelID); err != nil {
 		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
 	}
-	if err := validation.ValidateDate(date); err != nil {
-		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
-	}
-	if err := validation.ValidateUserID(userID); err != nil {
+	if err := validation.ValidateUserID(streak.UserID); err != nil {
 		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
 	}
 
-	pk, sk := userResponseKey(channelID, date, userID)
+	pk, sk := streakKey(channelScope(ctx, streak.ChannelID), streak.UserID)
 
-	update := expression.Add(expression.Name("reminder_count"), expression.Value(1))
-	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	item := map[string]interface{}{
+		"PK":         pk,
+		"SK":         sk,
+		"channel_id": streak.ChannelID,
+		"user_id":    streak.UserID,
+		"current":    streak.Current,
+		"longest":    streak.Longest,
+		"last_date":  streak.LastDate,
+		"missed":     streak.Missed,
+		"broken":     streak.Broken,
+		"updated_at": streak.UpdatedAt,
+	}
+
+	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
-		return &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+		return &store.Error{Code: "MARSHAL_ERROR", Message: "Failed to marshal item", Err: err}
 	}
 
-	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
 		TableName: aws.String(s.tableName),
-		Key: map[string]types.AttributeValue{
-			"PK": &types.AttributeValueMemberS{Value: pk},
-			"SK": &types.AttributeValueMemberS{Value: sk},
-		},
-		UpdateExpression:          expr.Update(),
-		ExpressionAttributeNames:  expr.Names(),
-		ExpressionAttributeValues: expr.Values(),
+		Item:      av,
 	})
 	if err != nil {
-		return &store.Error{Code: "UPDATE_ERROR", Message: "Failed to increment reminder count", Err: err}
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save streak", Err: err}
 	}
 
 	return nil
 }
 
-// SaveReminder saves a reminder record.
-func (s *Store) SaveReminder(ctx context.Context, reminder *store.Reminder) error {
+// ListStreaks lists the streaks of a channel's users, by user.
+func (s *Store) ListStreaks(ctx context.Context, channelID string) ([]*store.Streak, error) {
 	// Validate inputs
-	if err := validation.ValidateChannelID(reminder.ChannelID); err != nil {
-		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
 	}
-	if err := validation.ValidateDate(reminder.Date); err != nil {
-		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+
+	pk, _ := streakKey(channelScope(ctx, channelID), "")
+
+	keyCond := expression.Key("PK").Equal(expression.Value(pk))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
 	}
-	if err := validation.ValidateUserID(reminder.UserID); err != nil {
+
+	var streaks []*store.Streak
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query streaks", Err: err}
+		}
+
+		for _, item := range page.Items {
+			var streak store.Streak
+			if err := attributevalue.UnmarshalMap(item, &streak); err != nil {
+				continue // Skip invalid items
+			}
+			streaks = append(streaks, &streak)
+		}
+	}
+
+	return streaks, nil
+}
+
+// SaveUserPreferences saves a user's reminder preferences.
+func (s *Store) SaveUserPreferences(ctx context.Context, prefs *store.UserPreferences) error {
+	// Validate inputs
+	if err := validation.ValidateUserID(prefs.UserID); err != nil {
 		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
 	}
 
-	pk, sk := reminderKey(reminder.ChannelID, reminder.Date, reminder.UserID, reminder.Time)
+	pk, sk := preferencesKey(store.TeamScope(ctx), prefs.UserID)
 
 	item := map[string]interface{}{
-		"PK":         pk,
-		"SK":         sk,
-		"channel_id": reminder.ChannelID,
-		"date":       reminder.Date,
-		"user_id":    reminder.UserID,
-		"time":       reminder.Time,
-		"sent_at":    reminder.SentAt,
-		"message_ts": reminder.MessageTS,
-		"TTL":        s.calculateTTL(reminder.SentAt),
+		"PK":            pk,
+		"SK":            sk,
+		"user_id":       prefs.UserID,
+		"reminders_off": prefs.RemindersOff,
+		"reminder_time": prefs.ReminderTime,
+		"delivery":      prefs.Delivery,
+		"locale":        prefs.Locale,
+		"updated_at":    prefs.UpdatedAt,
 	}
 
 	av, err := attributevalue.MarshalMap(item)
@@ -661,23 +2357,47 @@ func (s *Store) SaveReminder(ctx context.Context, reminder *store.Reminder) erro
 		Item:      av,
 	})
 	if err != nil {
-		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save reminder", Err: err}
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save user preferences", Err: err}
 	}
 
 	return nil
 }
 
-// ListReminders lists all reminders for a channel and date.
-func (s *Store) ListReminders(ctx context.Context, channelID, date string) ([]*store.Reminder, error) {
+// GetUserPreferences retrieves a user's reminder preferences.
+func (s *Store) GetUserPreferences(ctx context.Context, userID string) (*store.UserPreferences, error) {
 	// Validate inputs
-	if err := validation.ValidateChannelID(channelID); err != nil {
-		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	if err := validation.ValidateUserID(userID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
 	}
-	if err := validation.ValidateDate(date); err != nil {
-		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+
+	pk, sk := preferencesKey(store.TeamScope(ctx), userID)
+
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: pk},
+			"SK": &types.AttributeValueMemberS{Value: sk},
+		},
+	})
+	if err != nil {
+		return nil, &store.Error{Code: "GET_ERROR", Message: "Failed to get user preferences", Err: err}
+	}
+
+	if result.Item == nil {
+		return nil, store.ErrNotFound
+	}
+
+	var prefs store.UserPreferences
+	if err := attributevalue.UnmarshalMap(result.Item, &prefs); err != nil {
+		return nil, &store.Error{Code: "UNMARSHAL_ERROR", Message: "Failed to unmarshal item", Err: err}
 	}
 
-	pk := fmt.Sprintf("REMINDER#%s#%s", channelID, date)
+	return &prefs, nil
+}
+
+// ListUserPreferences lists the saved preferences of every user.
+func (s *Store) ListUserPreferences(ctx context.Context) ([]*store.UserPreferences, error) {
+	pk, _ := preferencesKey(store.TeamScope(ctx), "")
 
 	keyCond := expression.Key("PK").Equal(expression.Value(pk))
 
@@ -686,7 +2406,7 @@ func (s *Store) ListReminders(ctx context.Context, channelID, date string) ([]*s
 		return nil, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
 	}
 
-	var reminders []*store.Reminder
+	var list []*store.UserPreferences
 	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
 		TableName:                 aws.String(s.tableName),
 		KeyConditionExpression:    expr.KeyCondition(),
@@ -697,19 +2417,198 @@ func (s *Store) ListReminders(ctx context.Context, channelID, date string) ([]*s
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(ctx)
 		if err != nil {
-			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query reminders", Err: err}
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query user preferences", Err: err}
 		}
 
 		for _, item := range page.Items {
-			var reminder store.Reminder
-			if err := attributevalue.UnmarshalMap(item, &reminder); err != nil {
+			var prefs store.UserPreferences
+			if err := attributevalue.UnmarshalMap(item, &prefs); err != nil {
 				continue // Skip invalid items
 			}
-			reminders = append(reminders, &reminder)
+			list = append(list, &prefs)
 		}
 	}
 
-	return reminders, nil
+	return list, nil
+}
+
+// DeleteUserData deletes a user's records in ctx's workspace. They're spread
+// over session, reminder, streak and preference partitions, so the table is
+// scanned for items with the user's user_id a page at a time, and each
+// page's items are deleted in batches. Scans read the whole table, so this
+// is only meant for occasional erasure requests.
+func (s *Store) DeleteUserData(ctx context.Context, userID string) (int, error) {
+	// Validate inputs
+	if err := validation.ValidateUserID(userID); err != nil {
+		return 0, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	filter := expression.Name("user_id").Equal(expression.Value(userID))
+	if teamID := store.TeamScope(ctx); teamID != "" {
+		// Channel-level keys hold the scope between separators, and the
+		// preferences partition ends with it
+		prefsPK, _ := preferencesKey(teamID, "")
+		filter = filter.And(expression.Or(
+			expression.Name("PK").Contains("#"+teamID+"#"),
+			expression.Name("PK").Equal(expression.Value(prefsPK)),
+		))
+	}
+
+	expr, err := expression.NewBuilder().
+		WithFilter(filter).
+		WithProjection(expression.NamesList(expression.Name("PK"), expression.Name("SK"))).
+		Build()
+	if err != nil {
+		return 0, &store.Error{Code: "EXPRESSION_ERROR", Message: "Failed to build expression", Err: err}
+	}
+
+	deleted := 0
+	paginator := dynamodb.NewScanPaginator(s.client, &dynamodb.ScanInput{
+		TableName:                 aws.String(s.tableName),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return deleted, &store.Error{Code: "SCAN_ERROR", Message: "Failed to scan for user data", Err: err}
+		}
+
+		for batch := range slices.Chunk(page.Items, batchWriteLimit) {
+			if err := s.batchDeleteItems(ctx, batch); err != nil {
+				return deleted, err
+			}
+			deleted += len(batch)
+		}
+	}
+
+	return deleted, nil
+}
+
+// deleteItem deletes the item with the given key, if there is one.
+func (s *Store) deleteItem(ctx context.Context, pk, sk, message string) error {
+	_, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: pk},
+			"SK": &types.AttributeValueMemberS{Value: sk},
+		},
+	})
+	if err != nil {
+		return &store.Error{Code: "DELETE_ERROR", Message: message, Err: err}
+	}
+	return nil
+}
+
+// batchDeleteItems deletes up to batchWriteLimit items by key, retrying the
+// requests DynamoDB leaves unprocessed when throttled.
+func (s *Store) batchDeleteItems(ctx context.Context, keys []map[string]types.AttributeValue) error {
+	requests := make([]types.WriteRequest, len(keys))
+	for i, key := range keys {
+		requests[i] = types.WriteRequest{DeleteRequest: &types.DeleteRequest{Key: key}}
+	}
+	request := map[string][]types.WriteRequest{s.tableName: requests}
+
+	for attempt := 0; len(request) > 0; attempt++ {
+		if attempt > batchGetRetries {
+			return &store.Error{Code: "BATCH_WRITE_ERROR", Message: "Deletes left unprocessed after retries"}
+		}
+		if attempt > 0 {
+			select {
+			case <-time.After(time.Duration(1<<attempt) * 25 * time.Millisecond):
+			case <-ctx.Done():
+				return &store.Error{Code: "BATCH_WRITE_ERROR", Message: "Failed to batch delete items", Err: ctx.Err()}
+			}
+		}
+
+		result, err := s.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{RequestItems: request})
+		if err != nil {
+			return &store.Error{Code: "BATCH_WRITE_ERROR", Message: "Failed to batch delete items", Err: err}
+		}
+		request = result.UnprocessedItems
+	}
+
+	return nil
+}
+
+// SaveProcessedEvent claims a Slack event for handling, returning
+// ErrAlreadyExists if it was already recorded. Records expire after
+// processedEventRetention, well past Slack's retry window.
+func (s *Store) SaveProcessedEvent(ctx context.Context, event *store.ProcessedEvent) error {
+	// Validate inputs
+	if err := validation.ValidateEventID(event.EventID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid event ID", Err: err}
+	}
+
+	pk, sk := processedEventKey(event.EventID)
+
+	item := map[string]interface{}{
+		"PK":           pk,
+		"SK":           sk,
+		"event_id":     event.EventID,
+		"team_id":      event.TeamID,
+		"event_type":   event.EventType,
+		"processed_at": event.ProcessedAt,
+		"TTL":          event.ProcessedAt.Add(processedEventRetention).Unix(),
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return &store.Error{Code: "MARSHAL_ERROR", Message: "Failed to marshal item", Err: err}
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.tableName),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	})
+	if err != nil {
+		var cfe *types.ConditionalCheckFailedException
+		if errors.As(err, &cfe) {
+			return store.ErrAlreadyExists
+		}
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save processed event", Err: err}
+	}
+
+	return nil
+}
+
+// DeleteProcessedEvent releases a Slack event's claim.
+func (s *Store) DeleteProcessedEvent(ctx context.Context, eventID string) error {
+	// Validate inputs
+	if err := validation.ValidateEventID(eventID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid event ID", Err: err}
+	}
+
+	pk, sk := processedEventKey(eventID)
+	return s.deleteItem(ctx, pk, sk, "Failed to delete processed event")
+}
+
+// IsEventProcessed reports whether a Slack event was already handled.
+func (s *Store) IsEventProcessed(ctx context.Context, eventID string) (bool, error) {
+	// Validate inputs
+	if err := validation.ValidateEventID(eventID); err != nil {
+		return false, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid event ID", Err: err}
+	}
+
+	pk, sk := processedEventKey(eventID)
+
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: pk},
+			"SK": &types.AttributeValueMemberS{Value: sk},
+		},
+		ProjectionExpression: aws.String("PK"),
+	})
+	if err != nil {
+		return false, &store.Error{Code: "GET_ERROR", Message: "Failed to get processed event", Err: err}
+	}
+
+	return result.Item != nil, nil
 }
 
 // GetPendingSessions gets all sessions that need processing.
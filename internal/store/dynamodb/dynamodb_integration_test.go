@@ -0,0 +1,211 @@
+//go:build integration
+
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/store/dynamodb/dynamotest"
+	"github.com/synaptiq/standup-bot/internal/store/storetest"
+)
+
+// TestStoreContract runs the store conformance tests against DynamoDB Local,
+// each in its own table.
+func TestStoreContract(t *testing.T) {
+	client := dynamotest.NewClient(t)
+
+	storetest.TestStore(t, func(t *testing.T) store.Store {
+		return NewStore(client, dynamotest.CreateTable(t, client), 30)
+	})
+}
+
+func TestTTLAttributes(t *testing.T) {
+	ctx := context.Background()
+	client := dynamotest.NewClient(t)
+	table := dynamotest.CreateTable(t, client)
+	submittedAt := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	session := &store.Session{
+		SessionID: "3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f",
+		ChannelID: "C1234567890",
+		Date:      "2024-01-15",
+		CreatedAt: submittedAt,
+	}
+	response := &store.UserResponse{
+		SessionID:   session.SessionID,
+		ChannelID:   session.ChannelID,
+		Date:        session.Date,
+		UserID:      "U1234567890",
+		SubmittedAt: submittedAt,
+	}
+
+	// Records expire ttlDays after they're made
+	s := NewStore(client, table, 30)
+	require.NoError(t, s.SubmitUserResponse(ctx, session, response))
+	expires := strconv.FormatInt(submittedAt.AddDate(0, 0, 30).Unix(), 10)
+	assert.Equal(t, expires, ttl(t, client, table)(sessionKey(session.ChannelID, session.Date)))
+	assert.Equal(t, expires, ttl(t, client, table)(userResponseKey(session.ChannelID, session.Date, response.UserID)))
+
+	// Processed events expire after the retention window whatever ttlDays is
+	processedAt := time.Now().Truncate(time.Second)
+	require.NoError(t, s.SaveProcessedEvent(ctx, &store.ProcessedEvent{EventID: "Ev0123ABCD", ProcessedAt: processedAt}))
+	assert.Equal(t, strconv.FormatInt(processedAt.Add(processedEventRetention).Unix(), 10),
+		ttl(t, client, table)(processedEventKey("Ev0123ABCD")))
+
+	// Without ttlDays, records are kept
+	s = NewStore(client, table, 0)
+	session.Date, response.Date = "2024-01-16", "2024-01-16"
+	require.NoError(t, s.SubmitUserResponse(ctx, session, response))
+	assert.Empty(t, ttl(t, client, table)(sessionKey(session.ChannelID, session.Date)))
+	assert.Empty(t, ttl(t, client, table)(userResponseKey(session.ChannelID, session.Date, response.UserID)))
+}
+
+// ttl returns a function reading the TTL attribute of the item at a key,
+// or "" when it has none, so it can take a key function's results directly.
+func ttl(t *testing.T, client *dynamodb.Client, table string) func(pk, sk string) string {
+	return func(pk, sk string) string {
+		t.Helper()
+
+		result, err := client.GetItem(context.Background(), &dynamodb.GetItemInput{
+			TableName: aws.String(table),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: pk},
+				"SK": &types.AttributeValueMemberS{Value: sk},
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result.Item, "no item at %s/%s", pk, sk)
+
+		if value, ok := result.Item["TTL"].(*types.AttributeValueMemberN); ok {
+			return value.Value
+		}
+		return ""
+	}
+}
+
+func TestConditionalWrites(t *testing.T) {
+	ctx := context.Background()
+	client := dynamotest.NewClient(t)
+	s := NewStore(client, dynamotest.CreateTable(t, client), 30)
+
+	// Writes that may happen once succeed once, however many race
+	writes := map[string]func() error{
+		"CreateSession": func() error {
+			return s.CreateSession(ctx, &store.Session{
+				SessionID: "3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f",
+				ChannelID: "C1234567890",
+				Date:      "2024-01-15",
+			})
+		},
+		"SaveEscalationRecord": func() error {
+			return s.SaveEscalationRecord(ctx, &store.EscalationRecord{
+				ChannelID: "C1234567890", Date: "2024-01-15", UserID: "U1234567890",
+			})
+		},
+		"SaveDigestRecord": func() error {
+			return s.SaveDigestRecord(ctx, &store.DigestRecord{
+				ChannelID: "C1234567890", Period: store.DigestWeekly, PeriodKey: "2024-W03",
+			})
+		},
+		"SaveProcessedEvent": func() error {
+			return s.SaveProcessedEvent(ctx, &store.ProcessedEvent{EventID: "Ev0123ABCD", ProcessedAt: time.Now()})
+		},
+	}
+
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			const racers = 8
+			var (
+				wg   sync.WaitGroup
+				errs = make([]error, racers)
+			)
+			for i := range racers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs[i] = write()
+				}()
+			}
+			wg.Wait()
+
+			succeeded := 0
+			for _, err := range errs {
+				if err == nil {
+					succeeded++
+					continue
+				}
+				assert.True(t, errors.Is(err, store.ErrAlreadyExists), "unexpected error: %v", err)
+			}
+			assert.Equal(t, 1, succeeded)
+		})
+	}
+}
+
+func TestBackfillSessionIndex(t *testing.T) {
+	ctx := context.WithValue(context.Background(), botcontext.TeamIDKey, "T1234567890")
+	client := dynamotest.NewClient(t)
+	table := dynamotest.CreateTable(t, client)
+	s := NewStore(client, table, 30)
+	session := &store.Session{
+		SessionID: "3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f",
+		ChannelID: "C1234567890",
+		Date:      "2024-01-15",
+	}
+	response := &store.UserResponse{
+		SessionID:   session.SessionID,
+		ChannelID:   session.ChannelID,
+		Date:        session.Date,
+		UserID:      "U1234567890",
+		SubmittedAt: time.Now(),
+	}
+	require.NoError(t, s.SubmitUserResponse(ctx, session, response))
+
+	// Items written before the index was used have no GSI1 keys
+	scope := channelScope(ctx, session.ChannelID)
+	sessionPK, sessionSK := sessionKey(scope, session.Date)
+	responsePK, responseSK := userResponseKey(scope, session.Date, response.UserID)
+	for _, key := range [][2]string{{sessionPK, sessionSK}, {responsePK, responseSK}} {
+		_, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+			TableName: aws.String(table),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: key[0]},
+				"SK": &types.AttributeValueMemberS{Value: key[1]},
+			},
+			UpdateExpression: aws.String("REMOVE GSI1PK, GSI1SK"),
+		})
+		require.NoError(t, err)
+	}
+	sessions, err := s.ListSessions(ctx, session.ChannelID, "2024-01-01", "2024-01-31")
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+
+	updated, err := BackfillSessionIndex(ctx, client, table)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+
+	sessions, err = s.ListSessions(ctx, session.ChannelID, "2024-01-01", "2024-01-31")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, session.SessionID, sessions[0].SessionID)
+	responses, err := s.ListSessionResponses(ctx, session.SessionID)
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	assert.Equal(t, response.UserID, responses[0].UserID)
+
+	// Running it again finds nothing left to index
+	updated, err = BackfillSessionIndex(ctx, client, table)
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+}
@@ -2,15 +2,21 @@ package dynamodb
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	botcontext "github.com/synaptiq/standup-bot/context"
 	"github.com/synaptiq/standup-bot/internal/store"
 )
 
@@ -35,6 +41,14 @@ func (m *MockDynamoDBClient) GetItem(ctx context.Context, params *dynamodb.GetIt
 	return args.Get(0).(*dynamodb.GetItemOutput), args.Error(1)
 }
 
+func (m *MockDynamoDBClient) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dynamodb.BatchGetItemOutput), args.Error(1)
+}
+
 func (m *MockDynamoDBClient) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
 	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
@@ -43,6 +57,14 @@ func (m *MockDynamoDBClient) UpdateItem(ctx context.Context, params *dynamodb.Up
 	return args.Get(0).(*dynamodb.UpdateItemOutput), args.Error(1)
 }
 
+func (m *MockDynamoDBClient) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dynamodb.DeleteItemOutput), args.Error(1)
+}
+
 func (m *MockDynamoDBClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
 	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
@@ -51,6 +73,30 @@ func (m *MockDynamoDBClient) Query(ctx context.Context, params *dynamodb.QueryIn
 	return args.Get(0).(*dynamodb.QueryOutput), args.Error(1)
 }
 
+func (m *MockDynamoDBClient) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dynamodb.ScanOutput), args.Error(1)
+}
+
+func (m *MockDynamoDBClient) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dynamodb.BatchWriteItemOutput), args.Error(1)
+}
+
+func (m *MockDynamoDBClient) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dynamodb.TransactWriteItemsOutput), args.Error(1)
+}
+
 func TestSaveWorkspaceConfig(t *testing.T) {
 	mockClient := new(MockDynamoDBClient)
 	s := NewStore(mockClient, "test-table", 30)
@@ -72,6 +118,22 @@ func TestSaveWorkspaceConfig(t *testing.T) {
 
 	err := s.SaveWorkspaceConfig(context.Background(), config)
 	assert.NoError(t, err)
+
+	// Org-wide installs are keyed by the enterprise ID
+	mockClient.On("PutItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		install, ok := input.Item["is_enterprise_install"].(*types.AttributeValueMemberBOOL)
+		return input.Item["PK"].(*types.AttributeValueMemberS).Value == "WORKSPACE#E1234567890" &&
+			input.Item["enterprise_id"].(*types.AttributeValueMemberS).Value == "E1234567890" &&
+			ok && install.Value
+	})).Return(&dynamodb.PutItemOutput{}, nil)
+
+	err = s.SaveWorkspaceConfig(context.Background(), &store.WorkspaceConfig{
+		TeamID:              "E1234567890",
+		EnterpriseID:        "E1234567890",
+		IsEnterpriseInstall: true,
+		BotToken:            "xoxb-org-token",
+	})
+	assert.NoError(t, err)
 	mockClient.AssertExpectations(t)
 }
 
@@ -129,11 +191,31 @@ func TestSaveChannelConfig(t *testing.T) {
 		return *input.TableName == "test-table" &&
 			input.Item["PK"].(*types.AttributeValueMemberS).Value == "WORKSPACE#T1234567890" &&
 			input.Item["SK"].(*types.AttributeValueMemberS).Value == "CONFIG#C1234567890" &&
-			input.Item["GSI1PK"].(*types.AttributeValueMemberS).Value == "ACTIVE#true"
-	})).Return(&dynamodb.PutItemOutput{}, nil)
+			input.Item["GSI1PK"].(*types.AttributeValueMemberS).Value == "ACTIVE#true" &&
+			input.Item["version"].(*types.AttributeValueMemberN).Value == "1" &&
+			strings.HasPrefix(*input.ConditionExpression, "attribute_not_exists")
+	})).Return(&dynamodb.PutItemOutput{}, nil).Once()
 
 	err := s.SaveChannelConfig(context.Background(), config)
 	assert.NoError(t, err)
+	assert.Equal(t, int64(1), config.Version)
+
+	// A config saved since it was read is reported with its version
+	mockClient.On("PutItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return input.Item["version"].(*types.AttributeValueMemberN).Value == "2" &&
+			input.ExpressionAttributeValues[":0"].(*types.AttributeValueMemberN).Value == "1" &&
+			input.ReturnValuesOnConditionCheckFailure == types.ReturnValuesOnConditionCheckFailureAllOld
+	})).Return(nil, &types.ConditionalCheckFailedException{
+		Item: map[string]types.AttributeValue{"version": &types.AttributeValueMemberN{Value: "3"}},
+	}).Once()
+
+	err = s.SaveChannelConfig(context.Background(), config)
+	var conflict *store.ConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.ErrorIs(t, err, store.ErrConflict)
+	assert.Equal(t, int64(1), conflict.Expected)
+	assert.Equal(t, int64(3), conflict.Current)
+	assert.Equal(t, int64(1), config.Version)
 	mockClient.AssertExpectations(t)
 }
 
@@ -172,6 +254,109 @@ func TestCreateSession(t *testing.T) {
 	})
 }
 
+func TestSetSessionAnchor(t *testing.T) {
+	mockClient := new(MockDynamoDBClient)
+	s := NewStore(mockClient, "test-table", 30)
+
+	mockClient.On("UpdateItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return *input.TableName == "test-table" &&
+			input.Key["PK"].(*types.AttributeValueMemberS).Value == "SESSION#C1234567890#2024-01-15" &&
+			input.Key["SK"].(*types.AttributeValueMemberS).Value == "SESSION#C1234567890#2024-01-15"
+	})).Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	err := s.SetSessionAnchor(context.Background(), "C1234567890", "2024-01-15", "1705312800.000100")
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+
+	err = s.SetSessionAnchor(context.Background(), "invalid", "2024-01-15", "1705312800.000100")
+	assert.Error(t, err)
+}
+
+func TestClaimSummary(t *testing.T) {
+	mockClient := new(MockDynamoDBClient)
+	s := NewStore(mockClient, "test-table", 30)
+	ctx := context.Background()
+
+	mockClient.On("UpdateItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return input.Key["PK"].(*types.AttributeValueMemberS).Value == "SESSION#C1234567890#2024-01-15" &&
+			input.ConditionExpression != nil
+	})).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+	require.NoError(t, s.ClaimSummary(ctx, "C1234567890", "2024-01-15"))
+
+	// A failed condition returning the session means it's already posted
+	mockClient.On("UpdateItem", mock.Anything, mock.Anything).Return(nil, &types.ConditionalCheckFailedException{
+		Item: map[string]types.AttributeValue{"summary_posted": &types.AttributeValueMemberBOOL{Value: true}},
+	}).Once()
+	assert.Equal(t, store.ErrAlreadyExists, s.ClaimSummary(ctx, "C1234567890", "2024-01-15"))
+
+	mockClient.On("UpdateItem", mock.Anything, mock.Anything).Return(nil, &types.ConditionalCheckFailedException{}).Once()
+	assert.Equal(t, store.ErrNotFound, s.ClaimSummary(ctx, "C1234567890", "2024-01-15"))
+	mockClient.AssertExpectations(t)
+}
+
+func TestSaveProcessedEvent(t *testing.T) {
+	mockClient := new(MockDynamoDBClient)
+	s := NewStore(mockClient, "test-table", 30)
+
+	event := &store.ProcessedEvent{
+		EventID:     "Ev0123ABCD",
+		TeamID:      "T1234567890",
+		EventType:   "app_mention",
+		ProcessedAt: time.Now(),
+	}
+
+	mockClient.On("PutItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return *input.TableName == "test-table" &&
+			*input.ConditionExpression == "attribute_not_exists(PK)" &&
+			input.Item["PK"].(*types.AttributeValueMemberS).Value == "EVENT#Ev0123ABCD"
+	})).Return(&dynamodb.PutItemOutput{}, nil).Once()
+
+	err := s.SaveProcessedEvent(context.Background(), event)
+	assert.NoError(t, err)
+
+	// Redelivery of the same event
+	mockClient.On("PutItem", mock.Anything, mock.Anything).
+		Return(nil, &types.ConditionalCheckFailedException{}).Once()
+
+	err = s.SaveProcessedEvent(context.Background(), event)
+	assert.Equal(t, store.ErrAlreadyExists, err)
+	mockClient.AssertExpectations(t)
+
+	err = s.SaveProcessedEvent(context.Background(), &store.ProcessedEvent{EventID: "EVENT#bad"})
+	assert.Error(t, err)
+}
+
+func TestSaveEscalationRecord(t *testing.T) {
+	mockClient := new(MockDynamoDBClient)
+	s := NewStore(mockClient, "test-table", 30)
+
+	record := &store.EscalationRecord{
+		ChannelID:     "C1234567890",
+		Date:          "2024-01-15",
+		UserID:        "U1234567890",
+		Action:        store.EscalatePublicNudge,
+		ReminderCount: 2,
+		EscalatedAt:   time.Now(),
+	}
+
+	mockClient.On("PutItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return *input.ConditionExpression == "attribute_not_exists(PK)" &&
+			input.Item["PK"].(*types.AttributeValueMemberS).Value == "SESSION#C1234567890#2024-01-15" &&
+			input.Item["SK"].(*types.AttributeValueMemberS).Value == "ESCALATION#U1234567890"
+	})).Return(&dynamodb.PutItemOutput{}, nil).Once()
+
+	err := s.SaveEscalationRecord(context.Background(), record)
+	assert.NoError(t, err)
+
+	// Second escalation the same day
+	mockClient.On("PutItem", mock.Anything, mock.Anything).
+		Return(nil, &types.ConditionalCheckFailedException{}).Once()
+
+	err = s.SaveEscalationRecord(context.Background(), record)
+	assert.Equal(t, store.ErrAlreadyExists, err)
+	mockClient.AssertExpectations(t)
+}
+
 func TestSaveUserResponse(t *testing.T) {
 	mockClient := new(MockDynamoDBClient)
 	s := NewStore(mockClient, "test-table", 30)
@@ -193,7 +378,9 @@ func TestSaveUserResponse(t *testing.T) {
 	mockClient.On("PutItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
 		return *input.TableName == "test-table" &&
 			input.Item["PK"].(*types.AttributeValueMemberS).Value == "SESSION#C1234567890#2024-01-15" &&
-			input.Item["SK"].(*types.AttributeValueMemberS).Value == "USER#U1234567890"
+			input.Item["SK"].(*types.AttributeValueMemberS).Value == "USER#U1234567890" &&
+			input.Item["GSI3PK"].(*types.AttributeValueMemberS).Value == "USER#U1234567890" &&
+			input.Item["GSI3SK"].(*types.AttributeValueMemberS).Value == "RESPONSE#C1234567890#2024-01-15"
 	})).Return(&dynamodb.PutItemOutput{}, nil)
 
 	err := s.SaveUserResponse(context.Background(), response)
@@ -201,6 +388,376 @@ func TestSaveUserResponse(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestSubmitUserResponse(t *testing.T) {
+	session := &store.Session{
+		SessionID: "sess-123",
+		ChannelID: "C1234567890",
+		Date:      "2024-01-15",
+		Status:    store.SessionPending,
+		CreatedAt: time.Now(),
+	}
+	response := &store.UserResponse{
+		SessionID:   "sess-123",
+		ChannelID:   "C1234567890",
+		Date:        "2024-01-15",
+		UserID:      "U1234567890",
+		UserName:    "alice",
+		Responses:   map[string]string{"question_0": "Worked on feature X"},
+		SubmittedAt: time.Now(),
+	}
+
+	t.Run("first response", func(t *testing.T) {
+		mockClient := new(MockDynamoDBClient)
+		s := NewStore(mockClient, "test-table", 30)
+
+		mockClient.On("TransactWriteItems", mock.Anything, mock.MatchedBy(func(input *dynamodb.TransactWriteItemsInput) bool {
+			if len(input.TransactItems) != 2 {
+				return false
+			}
+			update, put := input.TransactItems[0].Update, input.TransactItems[1].Put
+			return update != nil && put != nil &&
+				update.Key["PK"].(*types.AttributeValueMemberS).Value == "SESSION#C1234567890#2024-01-15" &&
+				strings.Contains(*update.UpdateExpression, "ADD") &&
+				put.Item["SK"].(*types.AttributeValueMemberS).Value == "USER#U1234567890" &&
+				*put.ConditionExpression == "attribute_not_exists(PK)"
+		})).Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+		err := s.SubmitUserResponse(context.Background(), session, response)
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("edited response", func(t *testing.T) {
+		mockClient := new(MockDynamoDBClient)
+		s := NewStore(mockClient, "test-table", 30)
+
+		mockClient.On("TransactWriteItems", mock.Anything, mock.Anything).Return(nil, &types.TransactionCanceledException{
+			CancellationReasons: []types.CancellationReason{
+				{Code: aws.String("None")},
+				{Code: aws.String("ConditionalCheckFailed")},
+			},
+		})
+		mockClient.On("PutItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+			return input.ConditionExpression == nil &&
+				input.Item["SK"].(*types.AttributeValueMemberS).Value == "USER#U1234567890"
+		})).Return(&dynamodb.PutItemOutput{}, nil)
+
+		err := s.SubmitUserResponse(context.Background(), session, response)
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("edit from a stale form", func(t *testing.T) {
+		mockClient := new(MockDynamoDBClient)
+		s := NewStore(mockClient, "test-table", 30)
+
+		mockClient.On("TransactWriteItems", mock.Anything, mock.Anything).Return(nil, &types.TransactionCanceledException{
+			CancellationReasons: []types.CancellationReason{
+				{Code: aws.String("None")},
+				{Code: aws.String("ConditionalCheckFailed")},
+			},
+		})
+		existing, err := attributevalue.MarshalMap(map[string]interface{}{
+			"channel_id":   "C1234567890",
+			"date":         "2024-01-15",
+			"user_id":      "U1234567890",
+			"submitted_at": response.SubmittedAt,
+		})
+		require.NoError(t, err)
+		mockClient.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{Item: existing}, nil)
+		mockClient.On("PutItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+			return aws.ToString(input.ConditionExpression) == "submitted_at = :submitted_at"
+		})).Return(&dynamodb.PutItemOutput{}, nil)
+
+		// Opened before the stored response was submitted
+		stale := *response
+		stale.UnchangedSince = response.SubmittedAt.Add(-time.Minute)
+		assert.ErrorIs(t, s.SubmitUserResponse(context.Background(), session, &stale), store.ErrConflict)
+		mockClient.AssertNotCalled(t, "PutItem", mock.Anything, mock.Anything)
+
+		// Opened after it
+		stale.UnchangedSince = response.SubmittedAt.Add(time.Minute)
+		assert.NoError(t, s.SubmitUserResponse(context.Background(), session, &stale))
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("mismatched session", func(t *testing.T) {
+		s := NewStore(new(MockDynamoDBClient), "test-table", 30)
+
+		other := *session
+		other.Date = "2024-01-16"
+		err := s.SubmitUserResponse(context.Background(), &other, response)
+		assert.Error(t, err)
+	})
+}
+
+func TestListSessions(t *testing.T) {
+	mockClient := new(MockDynamoDBClient)
+	s := NewStore(mockClient, "test-table", 30)
+
+	mockClient.On("Query", mock.Anything, mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return *input.IndexName == "GSI1" && !*input.ScanIndexForward
+	})).Return(&dynamodb.QueryOutput{
+		Items: []map[string]types.AttributeValue{
+			{
+				"session_id": &types.AttributeValueMemberS{Value: "sess-2"},
+				"date":       &types.AttributeValueMemberS{Value: "2024-01-16"},
+			},
+			{
+				"session_id": &types.AttributeValueMemberS{Value: "sess-1"},
+				"date":       &types.AttributeValueMemberS{Value: "2024-01-15"},
+			},
+		},
+	}, nil)
+
+	sessions, err := s.ListSessions(context.Background(), "C1234567890", "2024-01-01", "2024-01-31")
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, "2024-01-16", sessions[0].Date)
+	mockClient.AssertExpectations(t)
+
+	_, err = s.ListSessions(context.Background(), "C1234567890", "2024-01-01", "tomorrow")
+	assert.Error(t, err)
+}
+
+func TestListUserResponsesPage(t *testing.T) {
+	mockClient := new(MockDynamoDBClient)
+	s := NewStore(mockClient, "test-table", 30)
+
+	// One item past the page shows there's another
+	mockClient.On("Query", mock.Anything, mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return *input.Limit == 3 &&
+			input.ExclusiveStartKey["SK"].(*types.AttributeValueMemberS).Value == "USER#U1111111111"
+	})).Return(&dynamodb.QueryOutput{
+		Items: []map[string]types.AttributeValue{
+			{"user_id": &types.AttributeValueMemberS{Value: "U2222222222"}},
+			{"user_id": &types.AttributeValueMemberS{Value: "U3333333333"}},
+			{"user_id": &types.AttributeValueMemberS{Value: "U4444444444"}},
+		},
+	}, nil)
+
+	page, err := s.ListUserResponsesPage(context.Background(), "C1234567890", "2024-01-15", 2, "U1111111111")
+	require.NoError(t, err)
+	require.Len(t, page.Responses, 2)
+	assert.Equal(t, "U3333333333", page.NextToken)
+	mockClient.AssertExpectations(t)
+
+	_, err = s.ListUserResponsesPage(context.Background(), "C1234567890", "2024-01-15", 2, "USER#U1")
+	assert.Error(t, err)
+}
+
+func TestGetSessionWithResponses(t *testing.T) {
+	mockClient := new(MockDynamoDBClient)
+	s := NewStore(mockClient, "test-table", 30)
+
+	mockClient.On("Query", mock.Anything, mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return input.IndexName == nil
+	})).Return(&dynamodb.QueryOutput{
+		Items: []map[string]types.AttributeValue{
+			{
+				"SK":         &types.AttributeValueMemberS{Value: "ESCALATION#U1234567890"},
+				"user_id":    &types.AttributeValueMemberS{Value: "U1234567890"},
+				"escalation": &types.AttributeValueMemberBOOL{Value: true},
+			},
+			{
+				"SK":         &types.AttributeValueMemberS{Value: "SESSION#C1234567890#2024-01-15"},
+				"session_id": &types.AttributeValueMemberS{Value: "sess-1"},
+			},
+			{
+				"SK":      &types.AttributeValueMemberS{Value: "USER#U1234567890"},
+				"user_id": &types.AttributeValueMemberS{Value: "U1234567890"},
+			},
+		},
+	}, nil).Once()
+
+	session, responses, err := s.GetSessionWithResponses(context.Background(), "C1234567890", "2024-01-15")
+	require.NoError(t, err)
+	assert.Equal(t, "sess-1", session.SessionID)
+	require.Len(t, responses, 1)
+	assert.Equal(t, "U1234567890", responses[0].UserID)
+
+	// Responses without a session are not found
+	mockClient.On("Query", mock.Anything, mock.Anything).Return(&dynamodb.QueryOutput{
+		Items: []map[string]types.AttributeValue{
+			{"SK": &types.AttributeValueMemberS{Value: "USER#U1234567890"}},
+		},
+	}, nil).Once()
+	_, _, err = s.GetSessionWithResponses(context.Background(), "C1234567890", "2024-01-15")
+	assert.Equal(t, store.ErrNotFound, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetSessions(t *testing.T) {
+	mockClient := new(MockDynamoDBClient)
+	s := NewStore(mockClient, "test-table", 30)
+
+	sessionItem := func(channelID string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"channel_id": &types.AttributeValueMemberS{Value: channelID},
+			"date":       &types.AttributeValueMemberS{Value: "2024-01-15"},
+		}
+	}
+	unprocessed := map[string]types.KeysAndAttributes{
+		"test-table": {Keys: []map[string]types.AttributeValue{{
+			"PK": &types.AttributeValueMemberS{Value: "SESSION#C0987654321#2024-01-15"},
+			"SK": &types.AttributeValueMemberS{Value: "SESSION#C0987654321#2024-01-15"},
+		}}},
+	}
+
+	// Keys DynamoDB leaves unprocessed are retried
+	mockClient.On("BatchGetItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.BatchGetItemInput) bool {
+		return len(input.RequestItems["test-table"].Keys) == 3
+	})).Return(&dynamodb.BatchGetItemOutput{
+		Responses:       map[string][]map[string]types.AttributeValue{"test-table": {sessionItem("C1234567890")}},
+		UnprocessedKeys: unprocessed,
+	}, nil).Once()
+	mockClient.On("BatchGetItem", mock.Anything, &dynamodb.BatchGetItemInput{RequestItems: unprocessed}).
+		Return(&dynamodb.BatchGetItemOutput{
+			Responses: map[string][]map[string]types.AttributeValue{"test-table": {sessionItem("C0987654321")}},
+		}, nil).Once()
+
+	channelIDs := []string{"C1234567890", "C0987654321", "C1111111111", "C1234567890"}
+	sessions, err := s.GetSessions(context.Background(), channelIDs, "2024-01-15")
+	require.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.Contains(t, sessions, "C0987654321")
+	mockClient.AssertExpectations(t)
+
+	_, err = s.GetSessions(context.Background(), []string{"general"}, "2024-01-15")
+	assert.Error(t, err)
+}
+
+func TestListUserResponsesByUser(t *testing.T) {
+	mockClient := new(MockDynamoDBClient)
+	s := NewStore(mockClient, "test-table", 30)
+
+	mockClient.On("Query", mock.Anything, mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		keys := make(map[string]bool)
+		for _, v := range input.ExpressionAttributeValues {
+			keys[v.(*types.AttributeValueMemberS).Value] = true
+		}
+		return *input.IndexName == "GSI3" && !*input.ScanIndexForward &&
+			keys["USER#U1234567890"] &&
+			keys["RESPONSE#T1234567890#C1234567890#2024-01-01"] &&
+			keys["RESPONSE#T1234567890#C1234567890#2024-01-31"]
+	})).Return(&dynamodb.QueryOutput{
+		Items: []map[string]types.AttributeValue{
+			{
+				"user_id": &types.AttributeValueMemberS{Value: "U1234567890"},
+				"date":    &types.AttributeValueMemberS{Value: "2024-01-16"},
+			},
+		},
+	}, nil)
+
+	ctx := context.WithValue(context.Background(), botcontext.TeamIDKey, "T1234567890")
+	responses, err := s.ListUserResponsesByUser(ctx, "C1234567890", "U1234567890", "2024-01-01", "2024-01-31")
+	assert.NoError(t, err)
+	assert.Len(t, responses, 1)
+	assert.Equal(t, "2024-01-16", responses[0].Date)
+	mockClient.AssertExpectations(t)
+
+	_, err = s.ListUserResponsesByUser(ctx, "C1234567890", "alice", "2024-01-01", "2024-01-31")
+	assert.Error(t, err)
+}
+
+func TestListResponsesForUserOnDate(t *testing.T) {
+	mockClient := new(MockDynamoDBClient)
+	s := NewStore(mockClient, "test-table", 30)
+
+	mockClient.On("Query", mock.Anything, mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		keys := make(map[string]bool)
+		for _, v := range input.ExpressionAttributeValues {
+			keys[v.(*types.AttributeValueMemberS).Value] = true
+		}
+		return *input.IndexName == "GSI4" && strings.Contains(*input.KeyConditionExpression, "begins_with") &&
+			keys["USER#U1234567890"] && keys["DATE#2024-01-15#T1234567890#"]
+	})).Return(&dynamodb.QueryOutput{
+		Items: []map[string]types.AttributeValue{
+			{
+				"channel_id": &types.AttributeValueMemberS{Value: "C0987654321"},
+				"user_id":    &types.AttributeValueMemberS{Value: "U1234567890"},
+				"date":       &types.AttributeValueMemberS{Value: "2024-01-15"},
+			},
+			{
+				"channel_id": &types.AttributeValueMemberS{Value: "C1234567890"},
+				"user_id":    &types.AttributeValueMemberS{Value: "U1234567890"},
+				"date":       &types.AttributeValueMemberS{Value: "2024-01-15"},
+			},
+		},
+	}, nil)
+
+	ctx := context.WithValue(context.Background(), botcontext.TeamIDKey, "T1234567890")
+	responses, err := s.ListResponsesForUserOnDate(ctx, "U1234567890", "2024-01-15")
+	require.NoError(t, err)
+	require.Len(t, responses, 2)
+	assert.Equal(t, "C0987654321", responses[0].ChannelID)
+	mockClient.AssertExpectations(t)
+
+	_, err = s.ListResponsesForUserOnDate(ctx, "U1234567890", "yesterday")
+	assert.Error(t, err)
+}
+
+func TestDeleteUserData(t *testing.T) {
+	mockClient := new(MockDynamoDBClient)
+	s := NewStore(mockClient, "test-table", 30)
+
+	key := func(pk, sk string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: pk},
+			"SK": &types.AttributeValueMemberS{Value: sk},
+		}
+	}
+	firstPage := make([]map[string]types.AttributeValue, 30)
+	for i := range firstPage {
+		firstPage[i] = key(fmt.Sprintf("SESSION#T1234567890#C1234567890#2024-01-%02d", i+1), "USER#U1234567890")
+	}
+	prefs := key("PREFS#T1234567890", "USER#U1234567890")
+
+	// The scan is filtered to the user in the workspace and reads only keys
+	scoped := mock.MatchedBy(func(input *dynamodb.ScanInput) bool {
+		values := make(map[string]bool)
+		for _, v := range input.ExpressionAttributeValues {
+			values[v.(*types.AttributeValueMemberS).Value] = true
+		}
+		return values["U1234567890"] && values["#T1234567890#"] && values["PREFS#T1234567890"] &&
+			input.ProjectionExpression != nil
+	})
+	mockClient.On("Scan", mock.Anything, scoped).Return(&dynamodb.ScanOutput{
+		Items:            firstPage,
+		LastEvaluatedKey: firstPage[29],
+	}, nil).Once()
+	mockClient.On("Scan", mock.Anything, scoped).Return(&dynamodb.ScanOutput{
+		Items: []map[string]types.AttributeValue{prefs},
+	}, nil).Once()
+
+	// Pages are deleted in batches, retrying unprocessed deletes
+	unprocessed := map[string][]types.WriteRequest{
+		"test-table": {{DeleteRequest: &types.DeleteRequest{Key: firstPage[0]}}},
+	}
+	mockClient.On("BatchWriteItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.BatchWriteItemInput) bool {
+		return len(input.RequestItems["test-table"]) == 25
+	})).Return(&dynamodb.BatchWriteItemOutput{UnprocessedItems: unprocessed}, nil).Once()
+	mockClient.On("BatchWriteItem", mock.Anything, &dynamodb.BatchWriteItemInput{RequestItems: unprocessed}).
+		Return(&dynamodb.BatchWriteItemOutput{}, nil).Once()
+	mockClient.On("BatchWriteItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.BatchWriteItemInput) bool {
+		return len(input.RequestItems["test-table"]) == 5
+	})).Return(&dynamodb.BatchWriteItemOutput{}, nil).Once()
+	mockClient.On("BatchWriteItem", mock.Anything, &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{
+			"test-table": {{DeleteRequest: &types.DeleteRequest{Key: prefs}}},
+		},
+	}).Return(&dynamodb.BatchWriteItemOutput{}, nil).Once()
+
+	ctx := context.WithValue(context.Background(), botcontext.TeamIDKey, "T1234567890")
+	deleted, err := s.DeleteUserData(ctx, "U1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, 31, deleted)
+	mockClient.AssertExpectations(t)
+
+	_, err = s.DeleteUserData(ctx, "alice")
+	assert.Error(t, err)
+}
+
 func TestGetUsersWithoutResponse(t *testing.T) {
 	mockClient := new(MockDynamoDBClient)
 	s := &Store{
@@ -252,6 +809,14 @@ func TestKeyGeneration(t *testing.T) {
 			wantPK: "WORKSPACE#T123456",
 			wantSK: "CONFIG#C789012",
 		},
+		{
+			name: "preferences key",
+			fn: func() (string, string) {
+				return preferencesKey("T123456", "U345678")
+			},
+			wantPK: "PREFS#T123456",
+			wantSK: "USER#U345678",
+		},
 		{
 			name: "session key",
 			fn: func() (string, string) {
@@ -260,6 +825,15 @@ func TestKeyGeneration(t *testing.T) {
 			wantPK: "SESSION#C123456#2024-01-15",
 			wantSK: "SESSION#C123456#2024-01-15",
 		},
+		{
+			name: "team scoped session key",
+			fn: func() (string, string) {
+				ctx := context.WithValue(context.Background(), botcontext.TeamIDKey, "T123456")
+				return sessionKey(channelScope(ctx, "C123456"), "2024-01-15")
+			},
+			wantPK: "SESSION#T123456#C123456#2024-01-15",
+			wantSK: "SESSION#T123456#C123456#2024-01-15",
+		},
 		{
 			name: "user response key",
 			fn: func() (string, string) {
@@ -268,6 +842,30 @@ func TestKeyGeneration(t *testing.T) {
 			wantPK: "SESSION#C123456#2024-01-15",
 			wantSK: "USER#U789012",
 		},
+		{
+			name: "digest key",
+			fn: func() (string, string) {
+				return digestKey("C123456", store.DigestWeekly, "2024-W03")
+			},
+			wantPK: "DIGEST#C123456",
+			wantSK: "weekly#2024-W03",
+		},
+		{
+			name: "processed event key",
+			fn: func() (string, string) {
+				return processedEventKey("Ev0123ABCD")
+			},
+			wantPK: "EVENT#Ev0123ABCD",
+			wantSK: "EVENT#Ev0123ABCD",
+		},
+		{
+			name: "skipped response key",
+			fn: func() (string, string) {
+				return skippedResponseKey("C123456", "2024-01-15", "U789012")
+			},
+			wantPK: "SESSION#C123456#2024-01-15",
+			wantSK: "SKIP#U789012",
+		},
 		{
 			name: "reminder key",
 			fn: func() (string, string) {
@@ -276,6 +874,22 @@ func TestKeyGeneration(t *testing.T) {
 			wantPK: "REMINDER#C123456#2024-01-15",
 			wantSK: "USER#U789012#08:30",
 		},
+		{
+			name: "failed reminder key",
+			fn: func() (string, string) {
+				return failedReminderKey("C123456", "2024-01-15", "U789012", "08:30")
+			},
+			wantPK: "FAILED_REMINDER#C123456",
+			wantSK: "2024-01-15#USER#U789012#08:30",
+		},
+		{
+			name: "schedule key",
+			fn: func() (string, string) {
+				return scheduleKey("C123456", "reminder#08:30")
+			},
+			wantPK: "SCHEDULE#C123456",
+			wantSK: "TASK#reminder#08:30",
+		},
 	}
 
 	for _, tt := range tests {
@@ -302,3 +916,40 @@ func TestCalculateTTL(t *testing.T) {
 	ttl = s.calculateTTL(baseTime)
 	assert.Nil(t, ttl)
 }
+
+func TestBackfillSessionIndexKeys(t *testing.T) {
+	mockClient := new(MockDynamoDBClient)
+	s := func(value string) types.AttributeValue { return &types.AttributeValueMemberS{Value: value} }
+
+	mockClient.On("Scan", mock.Anything, mock.Anything).Return(&dynamodb.ScanOutput{
+		Items: []map[string]types.AttributeValue{
+			// A session and a response in a workspace's scope
+			{"PK": s("SESSION#T1234567890#C1234567890#2024-01-15"), "SK": s("SESSION#T1234567890#C1234567890#2024-01-15"),
+				"date": s("2024-01-15")},
+			{"PK": s("SESSION#T1234567890#C1234567890#2024-01-15"), "SK": s("USER#U1234567890"),
+				"session_id": s("3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f")},
+			// A response without a session ID, and a skip, aren't indexed
+			{"PK": s("SESSION#C1234567890#2024-01-15"), "SK": s("USER#U2222222222")},
+			{"PK": s("SESSION#C1234567890#2024-01-15"), "SK": s("SKIP#U3333333333")},
+		},
+	}, nil)
+
+	indexed := make(map[string]string)
+	mockClient.On("UpdateItem", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		input := args.Get(1).(*dynamodb.UpdateItemInput)
+		var values []string
+		for _, v := range input.ExpressionAttributeValues {
+			values = append(values, v.(*types.AttributeValueMemberS).Value)
+		}
+		slices.Sort(values)
+		indexed[input.Key["SK"].(*types.AttributeValueMemberS).Value] = strings.Join(values, " ")
+	}).Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	updated, err := BackfillSessionIndex(context.Background(), mockClient, "test-table")
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+	assert.Equal(t, map[string]string{
+		"SESSION#T1234567890#C1234567890#2024-01-15": "CHANNEL#T1234567890#C1234567890 SESSION#2024-01-15",
+		"USER#U1234567890":                           "SESSIONID#3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f USER#U1234567890",
+	}, indexed)
+}
@@ -0,0 +1,151 @@
+// Package dynamotest runs DynamoDB Local for integration tests of the
+// DynamoDB store, creating tables shaped like the deployed one.
+package dynamotest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Image is the DynamoDB Local image started when no endpoint is given.
+const Image = "amazon/dynamodb-local:latest"
+
+// startTimeout is how long DynamoDB Local gets to accept requests.
+const startTimeout = 30 * time.Second
+
+// tables numbers the tables created by this process.
+var tables atomic.Int64
+
+// NewClient returns a client for DynamoDB Local. It uses the endpoint in
+// DYNAMO_ENDPOINT, e.g. http://localhost:8000 from `make dev`, or else starts
+// a container with docker that is removed when the test ends. The test is
+// skipped when neither is available.
+func NewClient(t testing.TB) *dynamodb.Client {
+	t.Helper()
+
+	endpoint := os.Getenv("DYNAMO_ENDPOINT")
+	if endpoint == "" {
+		endpoint = startContainer(t)
+	}
+
+	client := dynamodb.NewFromConfig(aws.Config{
+		Region: "us-east-1",
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "local", SecretAccessKey: "local"}, nil
+		}),
+	}, func(o *dynamodb.Options) {
+		o.BaseEndpoint = aws.String(endpoint)
+	})
+
+	waitUntilReady(t, client)
+	return client
+}
+
+// startContainer runs DynamoDB Local on a free port and returns its endpoint.
+func startContainer(t testing.TB) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Skip("DYNAMO_ENDPOINT is not set and docker is not installed")
+	}
+
+	out, err := exec.Command("docker", "run", "--rm", "-d", "-p", "127.0.0.1::8000",
+		Image, "-jar", "DynamoDBLocal.jar", "-inMemory").Output()
+	if err != nil {
+		t.Skipf("DYNAMO_ENDPOINT is not set and DynamoDB Local didn't start: %v", err)
+	}
+	id := strings.TrimSpace(string(out))
+	t.Cleanup(func() { _ = exec.Command("docker", "stop", id).Run() })
+
+	out, err = exec.Command("docker", "port", id, "8000/tcp").Output()
+	if err != nil {
+		t.Fatalf("failed to read DynamoDB Local port: %v", err)
+	}
+	// Only the first line; docker lists IPv4 and IPv6 bindings separately
+	address, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	return "http://" + address
+}
+
+// waitUntilReady waits for DynamoDB Local to answer requests.
+func waitUntilReady(t testing.TB, client *dynamodb.Client) {
+	t.Helper()
+
+	deadline := time.Now().Add(startTimeout)
+	for {
+		_, err := client.ListTables(context.Background(), &dynamodb.ListTablesInput{Limit: aws.Int32(1)})
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("DynamoDB Local isn't answering: %v", err)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+// CreateTable creates an empty table with the keys, indexes and TTL
+// attribute of the one in template.yaml, and deletes it when the test ends.
+func CreateTable(t testing.TB, client *dynamodb.Client) string {
+	t.Helper()
+	ctx := context.Background()
+	name := fmt.Sprintf("standup-bot-test-%d-%d", time.Now().UnixNano(), tables.Add(1))
+
+	keys := func(pk, sk string) []types.KeySchemaElement {
+		return []types.KeySchemaElement{
+			{AttributeName: aws.String(pk), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String(sk), KeyType: types.KeyTypeRange},
+		}
+	}
+	index := func(name string) types.GlobalSecondaryIndex {
+		return types.GlobalSecondaryIndex{
+			IndexName:  aws.String(name),
+			KeySchema:  keys(name+"PK", name+"SK"),
+			Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+		}
+	}
+
+	var attributes []types.AttributeDefinition
+	for _, attribute := range []string{"PK", "SK", "GSI1PK", "GSI1SK", "GSI3PK", "GSI3SK", "GSI4PK", "GSI4SK"} {
+		attributes = append(attributes, types.AttributeDefinition{
+			AttributeName: aws.String(attribute),
+			AttributeType: types.ScalarAttributeTypeS,
+		})
+	}
+
+	_, err := client.CreateTable(ctx, &dynamodb.CreateTableInput{
+		TableName:              aws.String(name),
+		AttributeDefinitions:   attributes,
+		KeySchema:              keys("PK", "SK"),
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{index("GSI1"), index("GSI3"), index("GSI4")},
+		BillingMode:            types.BillingModePayPerRequest,
+	})
+	if err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = client.DeleteTable(context.Background(), &dynamodb.DeleteTableInput{TableName: aws.String(name)})
+	})
+
+	_, err = client.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(name),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			AttributeName: aws.String("TTL"),
+			Enabled:       aws.Bool(true),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to enable TTL: %v", err)
+	}
+
+	return name
+}
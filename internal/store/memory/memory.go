@@ -0,0 +1,1309 @@
+// Package memory implements the store in process memory for local
+// development and tests. Data is lost when the process exits.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/validation"
+)
+
+// processedEventRetention is how long processed Slack event IDs are kept.
+const processedEventRetention = 24 * time.Hour
+
+// Keys of channel-level records include the workspace from store.TeamScope,
+// which is empty for single-workspace deployments.
+
+type channelKey struct{ teamID, channelID string }
+
+type sessionKey struct{ teamID, channelID, date string }
+
+type userKey struct{ teamID, channelID, date, userID string }
+
+type reminderKey struct{ teamID, channelID, date, userID, time string }
+
+type preferencesKey struct{ teamID, userID string }
+
+type scheduleKey struct{ teamID, channelID, task string }
+
+type streakKey struct{ teamID, channelID, userID string }
+
+type heartbeatKey struct{ teamID, job, channelID string }
+
+type digestKey struct {
+	teamID    string
+	channelID string
+	period    store.DigestPeriod
+	periodKey string
+}
+
+// Store implements the Store interface with mutex-protected maps. Values are
+// copied on the way in and out, so callers cannot modify stored records.
+type Store struct {
+	now func() time.Time
+
+	mu          sync.RWMutex
+	workspaces  map[string]store.WorkspaceConfig
+	channels    map[channelKey]store.ChannelConfig
+	sessions    map[sessionKey]store.Session
+	responses   map[userKey]store.UserResponse
+	reminders   map[reminderKey]store.Reminder
+	failures    map[reminderKey]store.FailedReminder
+	skips       map[userKey]store.SkippedResponse
+	escalations map[userKey]store.EscalationRecord
+	digests     map[digestKey]store.DigestRecord
+	schedule    map[scheduleKey]store.ScheduledRun
+	streaks     map[streakKey]store.Streak
+	heartbeats  map[heartbeatKey]store.Heartbeat
+	preferences map[preferencesKey]store.UserPreferences
+	events      map[string]store.ProcessedEvent
+}
+
+// NewStore creates an empty in-memory store.
+func NewStore() store.Store {
+	return &Store{
+		now:         time.Now,
+		workspaces:  make(map[string]store.WorkspaceConfig),
+		channels:    make(map[channelKey]store.ChannelConfig),
+		sessions:    make(map[sessionKey]store.Session),
+		responses:   make(map[userKey]store.UserResponse),
+		reminders:   make(map[reminderKey]store.Reminder),
+		failures:    make(map[reminderKey]store.FailedReminder),
+		skips:       make(map[userKey]store.SkippedResponse),
+		escalations: make(map[userKey]store.EscalationRecord),
+		digests:     make(map[digestKey]store.DigestRecord),
+		schedule:    make(map[scheduleKey]store.ScheduledRun),
+		streaks:     make(map[streakKey]store.Streak),
+		heartbeats:  make(map[heartbeatKey]store.Heartbeat),
+		preferences: make(map[preferencesKey]store.UserPreferences),
+		events:      make(map[string]store.ProcessedEvent),
+	}
+}
+
+// validateSessionKey validates the channel ID and date that identify a session.
+func validateSessionKey(channelID, date string) error {
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+	return nil
+}
+
+// validateUserKey validates the channel ID, date and user ID that identify a
+// user's record for a session.
+func validateUserKey(channelID, date, userID string) error {
+	if err := validateSessionKey(channelID, date); err != nil {
+		return err
+	}
+	if err := validation.ValidateUserID(userID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+	return nil
+}
+
+func copyWorkspaceConfig(config store.WorkspaceConfig) *store.WorkspaceConfig {
+	if config.Integrations.Jira != nil {
+		jira := *config.Integrations.Jira
+		jira.Projects = slices.Clone(jira.Projects)
+		config.Integrations.Jira = &jira
+	}
+	if config.Integrations.GitHub != nil {
+		github := *config.Integrations.GitHub
+		github.Repos = slices.Clone(github.Repos)
+		github.Users = maps.Clone(github.Users)
+		config.Integrations.GitHub = &github
+	}
+	if config.Integrations.Calendar != nil {
+		calendar := *config.Integrations.Calendar
+		config.Integrations.Calendar = &calendar
+	}
+	return &config
+}
+
+func copySession(session store.Session) *store.Session {
+	if session.CompletedAt != nil {
+		completedAt := *session.CompletedAt
+		session.CompletedAt = &completedAt
+	}
+	session.GroupMembers = slices.Clone(session.GroupMembers)
+	session.ReviewedBy = slices.Clone(session.ReviewedBy)
+	return &session
+}
+
+func copyUserResponse(response store.UserResponse) *store.UserResponse {
+	response.Responses = maps.Clone(response.Responses)
+	if response.Answers != nil {
+		answers := make(map[string]store.Answer, len(response.Answers))
+		for key, answer := range response.Answers {
+			answer.Values = slices.Clone(answer.Values)
+			answers[key] = answer
+		}
+		response.Answers = answers
+	}
+	return &response
+}
+
+func copyReminder(reminder store.Reminder) *store.Reminder {
+	if reminder.SnoozedUntil != nil {
+		snoozedUntil := *reminder.SnoozedUntil
+		reminder.SnoozedUntil = &snoozedUntil
+	}
+	return &reminder
+}
+
+// SaveWorkspaceConfig saves workspace configuration.
+func (s *Store) SaveWorkspaceConfig(ctx context.Context, config *store.WorkspaceConfig) error {
+	// Validate team ID, or enterprise ID for org-wide installs
+	if err := validation.ValidateWorkspaceID(config.TeamID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
+	}
+	if config.EnterpriseID != "" {
+		if err := validation.ValidateEnterpriseID(config.EnterpriseID); err != nil {
+			return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid enterprise ID", Err: err}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := *copyWorkspaceConfig(*config)
+	saved.UpdatedAt = s.now()
+	s.workspaces[config.TeamID] = saved
+	return nil
+}
+
+// GetWorkspaceConfig retrieves workspace configuration.
+func (s *Store) GetWorkspaceConfig(ctx context.Context, teamID string) (*store.WorkspaceConfig, error) {
+	// Validate team ID, or enterprise ID for org-wide installs
+	if err := validation.ValidateWorkspaceID(teamID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	config, ok := s.workspaces[teamID]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return copyWorkspaceConfig(config), nil
+}
+
+// SaveChannelConfig saves channel configuration.
+func (s *Store) SaveChannelConfig(ctx context.Context, config *store.ChannelConfig) error {
+	// Validate IDs
+	if err := validation.ValidateTeamID(config.TeamID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
+	}
+	if err := validation.ValidateChannelID(config.ChannelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := channelKey{config.TeamID, config.ChannelID}
+	if existing := s.channels[key]; existing.Version != config.Version {
+		return &store.ConflictError{
+			TeamID: config.TeamID, ChannelID: config.ChannelID, Expected: config.Version, Current: existing.Version,
+		}
+	}
+
+	saved := config.Clone()
+	saved.UpdatedAt = s.now()
+	saved.Version++
+	s.channels[key] = *saved
+	config.Version = saved.Version
+	return nil
+}
+
+// GetChannelConfig retrieves channel configuration.
+func (s *Store) GetChannelConfig(ctx context.Context, teamID, channelID string) (*store.ChannelConfig, error) {
+	// Validate IDs
+	if err := validation.ValidateTeamID(teamID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
+	}
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	config, ok := s.channels[channelKey{teamID, channelID}]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return config.Clone(), nil
+}
+
+// ListChannelConfigs lists all channel configurations for a workspace.
+func (s *Store) ListChannelConfigs(ctx context.Context, teamID string) ([]*store.ChannelConfig, error) {
+	// Validate team ID
+	if err := validation.ValidateTeamID(teamID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
+	}
+
+	return s.listChannelConfigs(func(config *store.ChannelConfig) bool {
+		return config.TeamID == teamID
+	}), nil
+}
+
+// ListActiveChannelConfigs lists all active channel configurations across all workspaces.
+func (s *Store) ListActiveChannelConfigs(ctx context.Context) ([]*store.ChannelConfig, error) {
+	return s.listChannelConfigs(func(config *store.ChannelConfig) bool {
+		return config.Active()
+	}), nil
+}
+
+// DisableChannelConfig turns a channel's standups off, keeping its config.
+func (s *Store) DisableChannelConfig(ctx context.Context, teamID, channelID string) error {
+	return s.updateChannelConfig(teamID, channelID, func(config *store.ChannelConfig) {
+		config.Enabled = false
+	})
+}
+
+// ArchiveChannelConfig pauses a channel's standups while it's archived.
+func (s *Store) ArchiveChannelConfig(ctx context.Context, teamID, channelID string, archivedAt time.Time) error {
+	return s.updateChannelConfig(teamID, channelID, func(config *store.ChannelConfig) {
+		config.ArchivedAt = &archivedAt
+	})
+}
+
+// UnarchiveChannelConfig resumes the standups of an unarchived channel.
+func (s *Store) UnarchiveChannelConfig(ctx context.Context, teamID, channelID string) error {
+	return s.updateChannelConfig(teamID, channelID, func(config *store.ChannelConfig) {
+		config.ArchivedAt = nil
+	})
+}
+
+// updateChannelConfig applies update to an existing channel config.
+func (s *Store) updateChannelConfig(teamID, channelID string, update func(*store.ChannelConfig)) error {
+	if err := validation.ValidateTeamID(teamID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
+	}
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := channelKey{teamID, channelID}
+	config, ok := s.channels[key]
+	if !ok {
+		return store.ErrNotFound
+	}
+	update(&config)
+	config.UpdatedAt = s.now()
+	config.Version++
+	s.channels[key] = config
+	return nil
+}
+
+func (s *Store) listChannelConfigs(match func(*store.ChannelConfig) bool) []*store.ChannelConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var configs []*store.ChannelConfig
+	for _, config := range s.channels {
+		if match(&config) {
+			configs = append(configs, config.Clone())
+		}
+	}
+
+	sort.Slice(configs, func(i, j int) bool {
+		if configs[i].TeamID != configs[j].TeamID {
+			return configs[i].TeamID < configs[j].TeamID
+		}
+		return configs[i].ChannelID < configs[j].ChannelID
+	})
+	return configs
+}
+
+// CreateSession creates a new standup session, returning ErrAlreadyExists if
+// the channel already has a session for that date.
+func (s *Store) CreateSession(ctx context.Context, session *store.Session) error {
+	// Validate inputs
+	if err := validateSessionKey(session.ChannelID, session.Date); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := sessionKey{store.TeamScope(ctx), session.ChannelID, session.Date}
+	if _, ok := s.sessions[key]; ok {
+		return store.ErrAlreadyExists
+	}
+	s.sessions[key] = *copySession(*session)
+	return nil
+}
+
+// GetSession retrieves a standup session.
+func (s *Store) GetSession(ctx context.Context, channelID, date string) (*store.Session, error) {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[sessionKey{store.TeamScope(ctx), channelID, date}]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return copySession(session), nil
+}
+
+// updateSession applies update to an existing session.
+func (s *Store) updateSession(ctx context.Context, channelID, date string, update func(*store.Session)) error {
+	if err := validateSessionKey(channelID, date); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := sessionKey{store.TeamScope(ctx), channelID, date}
+	session, ok := s.sessions[key]
+	if !ok {
+		return store.ErrNotFound
+	}
+	update(&session)
+	s.sessions[key] = session
+	return nil
+}
+
+// UpdateSessionStatus updates the status of a session.
+func (s *Store) UpdateSessionStatus(
+	ctx context.Context,
+	channelID, date string,
+	status store.SessionStatus,
+) error {
+	now := s.now()
+	return s.updateSession(ctx, channelID, date, func(session *store.Session) {
+		session.Status = status
+		if status == store.SessionCompleted {
+			session.CompletedAt = &now
+		}
+	})
+}
+
+// ClaimSummary marks a session summary as posted before posting it,
+// returning ErrAlreadyExists if it already was.
+func (s *Store) ClaimSummary(ctx context.Context, channelID, date string) error {
+	claimed := false
+	err := s.updateSession(ctx, channelID, date, func(session *store.Session) {
+		claimed = !session.SummaryPosted
+		session.SummaryPosted = true
+	})
+	if err == nil && !claimed {
+		return store.ErrAlreadyExists
+	}
+	return err
+}
+
+// ReleaseSummary clears a session's summary claim after posting failed.
+func (s *Store) ReleaseSummary(ctx context.Context, channelID, date string) error {
+	return s.updateSession(ctx, channelID, date, func(session *store.Session) {
+		session.SummaryPosted = false
+	})
+}
+
+// MarkSummaryPosted marks a session summary as posted, recording the
+// timestamp of the summary message.
+func (s *Store) MarkSummaryPosted(ctx context.Context, channelID, date, summaryTS string) error {
+	return s.updateSession(ctx, channelID, date, func(session *store.Session) {
+		session.SummaryPosted = true
+		session.SummaryTS = summaryTS
+	})
+}
+
+// SetSessionAnchor records the timestamp of the daily thread anchor message.
+func (s *Store) SetSessionAnchor(ctx context.Context, channelID, date, anchorTS string) error {
+	return s.updateSession(ctx, channelID, date, func(session *store.Session) {
+		session.AnchorTS = anchorTS
+	})
+}
+
+// MarkSessionReviewed records a lead acknowledging the session's summary.
+func (s *Store) MarkSessionReviewed(ctx context.Context, channelID, date, userID string) error {
+	if err := validation.ValidateUserID(userID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	return s.updateSession(ctx, channelID, date, func(session *store.Session) {
+		if !slices.Contains(session.ReviewedBy, userID) {
+			session.ReviewedBy = append(session.ReviewedBy, userID)
+		}
+	})
+}
+
+// ListSessions lists a channel's sessions between two dates, newest first.
+func (s *Store) ListSessions(ctx context.Context, channelID, startDate, endDate string) ([]*store.Session, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(startDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid start date", Err: err}
+	}
+	if err := validation.ValidateDate(endDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid end date", Err: err}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	teamID := store.TeamScope(ctx)
+	var sessions []*store.Session
+	for key, session := range s.sessions {
+		if key.teamID == teamID && key.channelID == channelID && key.date >= startDate && key.date <= endDate {
+			sessions = append(sessions, copySession(session))
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Date > sessions[j].Date })
+	return sessions, nil
+}
+
+// GetSessionWithResponses retrieves a session together with its responses.
+func (s *Store) GetSessionWithResponses(
+	ctx context.Context,
+	channelID, date string,
+) (*store.Session, []*store.UserResponse, error) {
+	session, err := s.GetSession(ctx, channelID, date)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses, err := s.ListUserResponses(ctx, channelID, date)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return session, responses, nil
+}
+
+// GetSessions retrieves the sessions of several channels on a date, keyed by
+// channel ID.
+func (s *Store) GetSessions(ctx context.Context, channelIDs []string, date string) (map[string]*store.Session, error) {
+	// Validate inputs
+	for _, channelID := range channelIDs {
+		if err := validateSessionKey(channelID, date); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	teamID := store.TeamScope(ctx)
+	sessions := make(map[string]*store.Session, len(channelIDs))
+	for _, channelID := range channelIDs {
+		if session, ok := s.sessions[sessionKey{teamID, channelID, date}]; ok {
+			sessions[channelID] = copySession(session)
+		}
+	}
+
+	return sessions, nil
+}
+
+// SaveUserResponse saves a user's standup response.
+func (s *Store) SaveUserResponse(ctx context.Context, response *store.UserResponse) error {
+	// Validate inputs
+	if err := validateUserKey(response.ChannelID, response.Date, response.UserID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses[userKey{store.TeamScope(ctx), response.ChannelID, response.Date, response.UserID}] = *copyUserResponse(*response)
+	return nil
+}
+
+// SubmitUserResponse saves a user's first response for a session, creating
+// the session if needed and incrementing its response count. Later edits
+// replace the response without counting it again.
+func (s *Store) SubmitUserResponse(ctx context.Context, session *store.Session, response *store.UserResponse) error {
+	if session.ChannelID != response.ChannelID || session.Date != response.Date {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Response does not belong to session"}
+	}
+	if err := validateUserKey(response.ChannelID, response.Date, response.UserID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := userKey{store.TeamScope(ctx), response.ChannelID, response.Date, response.UserID}
+	existing, edit := s.responses[key]
+	if edit && !response.UnchangedSince.IsZero() && existing.SubmittedAt.After(response.UnchangedSince) {
+		return store.ErrConflict
+	}
+	s.responses[key] = *copyUserResponse(*response)
+	if edit {
+		return nil
+	}
+
+	sKey := sessionKey{store.TeamScope(ctx), session.ChannelID, session.Date}
+	stored, ok := s.sessions[sKey]
+	if !ok {
+		stored = *copySession(*session)
+		stored.ResponseCount = 0
+	}
+	stored.ResponseCount++
+	s.sessions[sKey] = stored
+	return nil
+}
+
+// GetUserResponse retrieves a user's standup response.
+func (s *Store) GetUserResponse(
+	ctx context.Context,
+	channelID, date, userID string,
+) (*store.UserResponse, error) {
+	// Validate inputs
+	if err := validateUserKey(channelID, date, userID); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	response, ok := s.responses[userKey{store.TeamScope(ctx), channelID, date, userID}]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return copyUserResponse(response), nil
+}
+
+// ListUserResponses lists all user responses for a session.
+func (s *Store) ListUserResponses(ctx context.Context, channelID, date string) ([]*store.UserResponse, error) {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return nil, err
+	}
+
+	teamID := store.TeamScope(ctx)
+	return s.listUserResponses(func(key userKey, _ *store.UserResponse) bool {
+		return key.teamID == teamID && key.channelID == channelID && key.date == date
+	}), nil
+}
+
+// ListUserResponsesPage lists a page of a session's responses by user ID.
+func (s *Store) ListUserResponsesPage(
+	ctx context.Context,
+	channelID, date string,
+	limit int,
+	token string,
+) (*store.ResponsePage, error) {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return nil, err
+	}
+	if err := store.ValidatePageToken(token); err != nil {
+		return nil, err
+	}
+	limit = store.PageLimit(limit)
+
+	teamID := store.TeamScope(ctx)
+	responses := s.listUserResponses(func(key userKey, _ *store.UserResponse) bool {
+		return key.teamID == teamID && key.channelID == channelID && key.date == date && key.userID > token
+	})
+
+	page := &store.ResponsePage{Responses: responses}
+	if len(responses) > limit {
+		page.Responses = responses[:limit]
+		page.NextToken = page.Responses[limit-1].UserID
+	}
+	return page, nil
+}
+
+// ListChannelsUserResponses lists the responses of several channels on a
+// date, keyed by channel ID.
+func (s *Store) ListChannelsUserResponses(
+	ctx context.Context,
+	channelIDs []string,
+	date string,
+) (map[string][]*store.UserResponse, error) {
+	// Validate inputs
+	for _, channelID := range channelIDs {
+		if err := validateSessionKey(channelID, date); err != nil {
+			return nil, err
+		}
+	}
+
+	teamID := store.TeamScope(ctx)
+	responses := make(map[string][]*store.UserResponse, len(channelIDs))
+	for _, response := range s.listUserResponses(func(key userKey, _ *store.UserResponse) bool {
+		return key.teamID == teamID && key.date == date && slices.Contains(channelIDs, key.channelID)
+	}) {
+		responses[response.ChannelID] = append(responses[response.ChannelID], response)
+	}
+
+	return responses, nil
+}
+
+// ListUserResponsesByUser lists a user's responses in a channel between two
+// dates, newest first.
+func (s *Store) ListUserResponsesByUser(
+	ctx context.Context,
+	channelID, userID, startDate, endDate string,
+) ([]*store.UserResponse, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateUserID(userID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+	if err := validation.ValidateDate(startDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid start date", Err: err}
+	}
+	if err := validation.ValidateDate(endDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid end date", Err: err}
+	}
+
+	teamID := store.TeamScope(ctx)
+	responses := s.listUserResponses(func(key userKey, _ *store.UserResponse) bool {
+		return key.teamID == teamID && key.channelID == channelID && key.userID == userID &&
+			key.date >= startDate && key.date <= endDate
+	})
+
+	sort.Slice(responses, func(i, j int) bool { return responses[i].Date > responses[j].Date })
+	return responses, nil
+}
+
+// ListResponsesForUserOnDate lists a user's responses on a date in every
+// channel of the workspace, by channel ID.
+func (s *Store) ListResponsesForUserOnDate(ctx context.Context, userID, date string) ([]*store.UserResponse, error) {
+	// Validate inputs
+	if err := validation.ValidateUserID(userID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+
+	teamID := store.TeamScope(ctx)
+	responses := s.listUserResponses(func(key userKey, _ *store.UserResponse) bool {
+		return key.teamID == teamID && key.userID == userID && key.date == date
+	})
+
+	sort.Slice(responses, func(i, j int) bool { return responses[i].ChannelID < responses[j].ChannelID })
+	return responses, nil
+}
+
+// SearchUserResponses returns the responses matching query, newest first.
+func (s *Store) SearchUserResponses(ctx context.Context, query *store.ResponseQuery) ([]*store.UserResponse, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	teamID := store.TeamScope(ctx)
+	responses := s.listUserResponses(func(key userKey, response *store.UserResponse) bool {
+		return key.teamID == teamID && key.channelID == query.ChannelID &&
+			(query.UserID == "" || key.userID == query.UserID) &&
+			key.date >= query.StartDate && key.date <= query.EndDate &&
+			query.Matches(response)
+	})
+
+	sort.SliceStable(responses, func(i, j int) bool { return responses[i].Date > responses[j].Date })
+	if query.Limit > 0 && len(responses) > query.Limit {
+		responses = responses[:query.Limit]
+	}
+	return responses, nil
+}
+
+// ListSessionResponses lists all user responses for a session ID.
+func (s *Store) ListSessionResponses(ctx context.Context, sessionID string) ([]*store.UserResponse, error) {
+	// Validate inputs
+	if err := validation.ValidateSessionID(sessionID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid session ID", Err: err}
+	}
+
+	return s.listUserResponses(func(_ userKey, response *store.UserResponse) bool {
+		return response.SessionID == sessionID
+	}), nil
+}
+
+func (s *Store) listUserResponses(match func(userKey, *store.UserResponse) bool) []*store.UserResponse {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var responses []*store.UserResponse
+	for key, response := range s.responses {
+		if match(key, &response) {
+			responses = append(responses, copyUserResponse(response))
+		}
+	}
+
+	sort.Slice(responses, func(i, j int) bool { return responses[i].UserID < responses[j].UserID })
+	return responses
+}
+
+// IncrementReminderCount increments the reminder count on a user's response.
+// Users who have not responded have no response, so nothing is counted for them.
+func (s *Store) IncrementReminderCount(ctx context.Context, channelID, date, userID string) error {
+	// Validate inputs
+	if err := validateUserKey(channelID, date, userID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := userKey{store.TeamScope(ctx), channelID, date, userID}
+	if response, ok := s.responses[key]; ok {
+		response.ReminderCount++
+		s.responses[key] = response
+	}
+	return nil
+}
+
+// SaveReminder saves a reminder record.
+func (s *Store) SaveReminder(ctx context.Context, reminder *store.Reminder) error {
+	// Validate inputs
+	if err := validateUserKey(reminder.ChannelID, reminder.Date, reminder.UserID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := reminderKey{store.TeamScope(ctx), reminder.ChannelID, reminder.Date, reminder.UserID, reminder.Time}
+	s.reminders[key] = *copyReminder(*reminder)
+	return nil
+}
+
+// ListReminders lists all reminders for a channel and date.
+func (s *Store) ListReminders(ctx context.Context, channelID, date string) ([]*store.Reminder, error) {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	teamID := store.TeamScope(ctx)
+	var reminders []*store.Reminder
+	for key, reminder := range s.reminders {
+		if key.teamID == teamID && key.channelID == channelID && key.date == date {
+			reminders = append(reminders, copyReminder(reminder))
+		}
+	}
+
+	sort.Slice(reminders, func(i, j int) bool {
+		if reminders[i].UserID != reminders[j].UserID {
+			return reminders[i].UserID < reminders[j].UserID
+		}
+		return reminders[i].Time < reminders[j].Time
+	})
+	return reminders, nil
+}
+
+// SaveFailedReminder records a reminder that couldn't be delivered.
+func (s *Store) SaveFailedReminder(ctx context.Context, failure *store.FailedReminder) error {
+	// Validate inputs
+	if err := validateUserKey(failure.ChannelID, failure.Date, failure.UserID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := reminderKey{store.TeamScope(ctx), failure.ChannelID, failure.Date, failure.UserID, failure.Time}
+	s.failures[key] = *failure
+	return nil
+}
+
+// ListFailedReminders lists a channel's failed reminders between two dates,
+// newest first.
+func (s *Store) ListFailedReminders(ctx context.Context, channelID, startDate, endDate string) ([]*store.FailedReminder, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(startDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid start date", Err: err}
+	}
+	if err := validation.ValidateDate(endDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid end date", Err: err}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	teamID := store.TeamScope(ctx)
+	var failures []*store.FailedReminder
+	for key, failure := range s.failures {
+		if key.teamID == teamID && key.channelID == channelID && key.date >= startDate && key.date <= endDate {
+			failures = append(failures, &failure)
+		}
+	}
+
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].Date != failures[j].Date {
+			return failures[i].Date > failures[j].Date
+		}
+		if failures[i].Time != failures[j].Time {
+			return failures[i].Time > failures[j].Time
+		}
+		return failures[i].UserID < failures[j].UserID
+	})
+	return failures, nil
+}
+
+// SaveSkippedResponse records that a user skipped a day's standup.
+func (s *Store) SaveSkippedResponse(ctx context.Context, skip *store.SkippedResponse) error {
+	// Validate inputs
+	if err := validateUserKey(skip.ChannelID, skip.Date, skip.UserID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.skips[userKey{store.TeamScope(ctx), skip.ChannelID, skip.Date, skip.UserID}] = *skip
+	return nil
+}
+
+// ListSkippedResponses lists all users who skipped a session.
+func (s *Store) ListSkippedResponses(ctx context.Context, channelID, date string) ([]*store.SkippedResponse, error) {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	teamID := store.TeamScope(ctx)
+	var skips []*store.SkippedResponse
+	for key, skip := range s.skips {
+		if key.teamID == teamID && key.channelID == channelID && key.date == date {
+			skips = append(skips, &skip)
+		}
+	}
+
+	sort.Slice(skips, func(i, j int) bool { return skips[i].UserID < skips[j].UserID })
+	return skips, nil
+}
+
+// SaveEscalationRecord records an escalation, returning ErrAlreadyExists if
+// the user was already escalated for that day.
+func (s *Store) SaveEscalationRecord(ctx context.Context, record *store.EscalationRecord) error {
+	// Validate inputs
+	if err := validateUserKey(record.ChannelID, record.Date, record.UserID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := userKey{store.TeamScope(ctx), record.ChannelID, record.Date, record.UserID}
+	if _, ok := s.escalations[key]; ok {
+		return store.ErrAlreadyExists
+	}
+	s.escalations[key] = *record
+	return nil
+}
+
+// DeleteEscalationRecord releases a user's escalation claim for a day.
+func (s *Store) DeleteEscalationRecord(ctx context.Context, channelID, date, userID string) error {
+	// Validate inputs
+	if err := validateUserKey(channelID, date, userID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.escalations, userKey{store.TeamScope(ctx), channelID, date, userID})
+	return nil
+}
+
+// SaveDigestRecord records a posted digest, returning ErrAlreadyExists if the
+// digest for that period was already recorded.
+func (s *Store) SaveDigestRecord(ctx context.Context, record *store.DigestRecord) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(record.ChannelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := digestKey{store.TeamScope(ctx), record.ChannelID, record.Period, record.PeriodKey}
+	if _, ok := s.digests[key]; ok {
+		return store.ErrAlreadyExists
+	}
+	s.digests[key] = *record
+	return nil
+}
+
+// DeleteDigestRecord releases a digest's claim on its period.
+func (s *Store) DeleteDigestRecord(
+	ctx context.Context, channelID string, period store.DigestPeriod, periodKey string,
+) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.digests, digestKey{store.TeamScope(ctx), channelID, period, periodKey})
+	return nil
+}
+
+// SaveScheduledRun saves when a channel's scheduled task next runs.
+func (s *Store) SaveScheduledRun(ctx context.Context, run *store.ScheduledRun) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(run.ChannelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if run.Task == "" {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Task is required"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.schedule[scheduleKey{store.TeamScope(ctx), run.ChannelID, run.Task}] = *run
+	return nil
+}
+
+// ListScheduledRuns lists when a channel's scheduled tasks next run, by task.
+func (s *Store) ListScheduledRuns(ctx context.Context, channelID string) ([]*store.ScheduledRun, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	teamID := store.TeamScope(ctx)
+	var runs []*store.ScheduledRun
+	for key, run := range s.schedule {
+		if key.teamID == teamID && key.channelID == channelID {
+			runs = append(runs, &run)
+		}
+	}
+
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Task < runs[j].Task })
+	return runs, nil
+}
+
+// validateHeartbeatKey validates the job and, for per-channel jobs, the
+// channel ID that identify a heartbeat.
+func validateHeartbeatKey(job, channelID string) error {
+	if job == "" {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Job is required"}
+	}
+	if channelID != "" {
+		if err := validation.ValidateChannelID(channelID); err != nil {
+			return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+		}
+	}
+	return nil
+}
+
+// SaveHeartbeat saves a recurring job's health.
+func (s *Store) SaveHeartbeat(ctx context.Context, heartbeat *store.Heartbeat) error {
+	// Validate inputs
+	if err := validateHeartbeatKey(heartbeat.Job, heartbeat.ChannelID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.heartbeats[heartbeatKey{heartbeat.TeamID, heartbeat.Job, heartbeat.ChannelID}] = *heartbeat
+	return nil
+}
+
+// GetHeartbeat retrieves a recurring job's health.
+func (s *Store) GetHeartbeat(ctx context.Context, teamID, job, channelID string) (*store.Heartbeat, error) {
+	// Validate inputs
+	if err := validateHeartbeatKey(job, channelID); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	heartbeat, ok := s.heartbeats[heartbeatKey{teamID, job, channelID}]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return &heartbeat, nil
+}
+
+// ListHeartbeats lists the heartbeats of every workspace.
+func (s *Store) ListHeartbeats(ctx context.Context) ([]*store.Heartbeat, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*store.Heartbeat, 0, len(s.heartbeats))
+	for _, heartbeat := range s.heartbeats {
+		list = append(list, &heartbeat)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.Job != b.Job {
+			return a.Job < b.Job
+		}
+		if a.TeamID != b.TeamID {
+			return a.TeamID < b.TeamID
+		}
+		return a.ChannelID < b.ChannelID
+	})
+	return list, nil
+}
+
+// SaveStreak saves a user's streak in a channel.
+func (s *Store) SaveStreak(ctx context.Context, streak *store.Streak) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(streak.ChannelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateUserID(streak.UserID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.streaks[streakKey{store.TeamScope(ctx), streak.ChannelID, streak.UserID}] = *streak
+	return nil
+}
+
+// ListStreaks lists the streaks of a channel's users, by user.
+func (s *Store) ListStreaks(ctx context.Context, channelID string) ([]*store.Streak, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	teamID := store.TeamScope(ctx)
+	var streaks []*store.Streak
+	for key, streak := range s.streaks {
+		if key.teamID == teamID && key.channelID == channelID {
+			streaks = append(streaks, &streak)
+		}
+	}
+
+	sort.Slice(streaks, func(i, j int) bool { return streaks[i].UserID < streaks[j].UserID })
+	return streaks, nil
+}
+
+// SaveUserPreferences saves a user's reminder preferences.
+func (s *Store) SaveUserPreferences(ctx context.Context, prefs *store.UserPreferences) error {
+	// Validate inputs
+	if err := validation.ValidateUserID(prefs.UserID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.preferences[preferencesKey{store.TeamScope(ctx), prefs.UserID}] = *prefs
+	return nil
+}
+
+// GetUserPreferences retrieves a user's reminder preferences.
+func (s *Store) GetUserPreferences(ctx context.Context, userID string) (*store.UserPreferences, error) {
+	// Validate inputs
+	if err := validation.ValidateUserID(userID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	prefs, ok := s.preferences[preferencesKey{store.TeamScope(ctx), userID}]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return &prefs, nil
+}
+
+// ListUserPreferences lists the saved preferences of every user.
+func (s *Store) ListUserPreferences(ctx context.Context) ([]*store.UserPreferences, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	teamID := store.TeamScope(ctx)
+	var list []*store.UserPreferences
+	for key, prefs := range s.preferences {
+		if key.teamID == teamID {
+			list = append(list, &prefs)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].UserID < list[j].UserID })
+	return list, nil
+}
+
+// DeleteUserData deletes a user's records in ctx's workspace.
+func (s *Store) DeleteUserData(ctx context.Context, userID string) (int, error) {
+	// Validate inputs
+	if err := validation.ValidateUserID(userID); err != nil {
+		return 0, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	teamID := store.TeamScope(ctx)
+	isUser := func(key userKey) bool { return key.teamID == teamID && key.userID == userID }
+	isUserReminder := func(key reminderKey) bool { return key.teamID == teamID && key.userID == userID }
+
+	deleted := deleteKeys(s.responses, isUser) +
+		deleteKeys(s.skips, isUser) +
+		deleteKeys(s.escalations, isUser) +
+		deleteKeys(s.reminders, isUserReminder) +
+		deleteKeys(s.failures, isUserReminder) +
+		deleteKeys(s.streaks, func(key streakKey) bool { return key.teamID == teamID && key.userID == userID }) +
+		deleteKeys(s.preferences, func(key preferencesKey) bool { return key == preferencesKey{teamID, userID} })
+	return deleted, nil
+}
+
+// deleteKeys deletes the entries of m whose keys match, returning how many
+// it deleted.
+func deleteKeys[K comparable, V any](m map[K]V, match func(K) bool) int {
+	deleted := 0
+	for key := range m {
+		if match(key) {
+			delete(m, key)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// SaveProcessedEvent claims a Slack event for handling, returning
+// ErrAlreadyExists if it was recorded within processedEventRetention.
+func (s *Store) SaveProcessedEvent(ctx context.Context, event *store.ProcessedEvent) error {
+	// Validate inputs
+	if err := validation.ValidateEventID(event.EventID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid event ID", Err: err}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.events[event.EventID]; ok &&
+		!existing.ProcessedAt.Before(event.ProcessedAt.Add(-processedEventRetention)) {
+		return store.ErrAlreadyExists
+	}
+	s.events[event.EventID] = *event
+	return nil
+}
+
+// DeleteProcessedEvent releases a Slack event's claim.
+func (s *Store) DeleteProcessedEvent(ctx context.Context, eventID string) error {
+	// Validate inputs
+	if err := validation.ValidateEventID(eventID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid event ID", Err: err}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.events, eventID)
+	return nil
+}
+
+// IsEventProcessed reports whether a Slack event was already handled.
+func (s *Store) IsEventProcessed(ctx context.Context, eventID string) (bool, error) {
+	// Validate inputs
+	if err := validation.ValidateEventID(eventID); err != nil {
+		return false, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid event ID", Err: err}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	event, ok := s.events[eventID]
+	return ok && !event.ProcessedAt.Before(s.now().Add(-processedEventRetention)), nil
+}
+
+// GetPendingSessions gets all unfinished sessions dated on or before currentTime.
+func (s *Store) GetPendingSessions(ctx context.Context, currentTime time.Time) ([]*store.Session, error) {
+	today := currentTime.Format("2006-01-02")
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var sessions []*store.Session
+	for _, session := range s.sessions {
+		if session.Status != store.SessionCompleted && session.Date <= today {
+			sessions = append(sessions, copySession(session))
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].Date != sessions[j].Date {
+			return sessions[i].Date < sessions[j].Date
+		}
+		return sessions[i].ChannelID < sessions[j].ChannelID
+	})
+	return sessions, nil
+}
+
+// GetUsersWithoutResponse gets users who haven't submitted responses.
+func (s *Store) GetUsersWithoutResponse(
+	ctx context.Context,
+	channelID, date string,
+	userIDs []string,
+) ([]string, error) {
+	// Validate channel ID and date (user IDs are validated individually below)
+	if err := validateSessionKey(channelID, date); err != nil {
+		return nil, err
+	}
+
+	// Validate all user IDs
+	for _, userID := range userIDs {
+		if err := validation.ValidateUserID(userID); err != nil {
+			return nil, &store.Error{
+				Code:    "VALIDATION_ERROR",
+				Message: fmt.Sprintf("Invalid user ID: %s", userID),
+				Err:     err,
+			}
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Find users who haven't responded
+	var missingUsers []string
+	for _, userID := range userIDs {
+		if _, ok := s.responses[userKey{store.TeamScope(ctx), channelID, date, userID}]; !ok {
+			missingUsers = append(missingUsers, userID)
+		}
+	}
+
+	return missingUsers, nil
+}
@@ -0,0 +1,388 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/store/storetest"
+)
+
+func TestSessions(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	session := &store.Session{
+		SessionID: "3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f",
+		ChannelID: "C1234567890",
+		Date:      "2024-01-15",
+		Status:    store.SessionPending,
+		CreatedAt: time.Now(),
+	}
+
+	require.NoError(t, s.CreateSession(ctx, session))
+	assert.Equal(t, store.ErrAlreadyExists, s.CreateSession(ctx, session))
+
+	require.NoError(t, s.SetSessionAnchor(ctx, "C1234567890", "2024-01-15", "1700000000.000100"))
+	require.NoError(t, s.UpdateSessionStatus(ctx, "C1234567890", "2024-01-15", store.SessionCompleted))
+	require.NoError(t, s.MarkSummaryPosted(ctx, "C1234567890", "2024-01-15", "1700000000.000200"))
+	require.NoError(t, s.MarkSessionReviewed(ctx, "C1234567890", "2024-01-15", "U1234567890"))
+	require.NoError(t, s.MarkSessionReviewed(ctx, "C1234567890", "2024-01-15", "U1234567890"))
+
+	got, err := s.GetSession(ctx, "C1234567890", "2024-01-15")
+	require.NoError(t, err)
+	assert.Equal(t, "1700000000.000100", got.AnchorTS)
+	assert.True(t, got.SummaryPosted)
+	assert.Equal(t, "1700000000.000200", got.SummaryTS)
+	assert.Equal(t, store.SessionCompleted, got.Status)
+	assert.NotNil(t, got.CompletedAt)
+	assert.Equal(t, []string{"U1234567890"}, got.ReviewedBy)
+
+	// Returned values are copies
+	got.Status = store.SessionPending
+	got, err = s.GetSession(ctx, "C1234567890", "2024-01-15")
+	require.NoError(t, err)
+	assert.Equal(t, store.SessionCompleted, got.Status)
+
+	_, err = s.GetSession(ctx, "C1234567890", "2024-01-16")
+	assert.Equal(t, store.ErrNotFound, err)
+	assert.Equal(t, store.ErrNotFound, s.MarkSummaryPosted(ctx, "C1234567890", "2024-01-16", "1700000000.000200"))
+
+	session.Date, session.SessionID, session.Status = "2024-01-16", "7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", store.SessionPending
+	require.NoError(t, s.CreateSession(ctx, session))
+
+	sessions, err := s.ListSessions(ctx, "C1234567890", "2024-01-01", "2024-01-31")
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, "2024-01-16", sessions[0].Date)
+
+	pending, err := s.GetPendingSessions(ctx, time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "2024-01-16", pending[0].Date)
+
+	var storeErr *store.Error
+	require.ErrorAs(t, s.CreateSession(ctx, &store.Session{ChannelID: "invalid", Date: "2024-01-15"}), &storeErr)
+	assert.Equal(t, "VALIDATION_ERROR", storeErr.Code)
+}
+
+func TestSubmitUserResponse(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	session := &store.Session{
+		SessionID: "3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f",
+		ChannelID: "C1234567890",
+		Date:      "2024-01-15",
+		Status:    store.SessionInProgress,
+		CreatedAt: time.Now(),
+	}
+	userIDs := []string{"U0000000001", "U0000000002", "U0000000003"}
+
+	// Concurrent first submissions each count once
+	var wg sync.WaitGroup
+	for _, userID := range userIDs[:2] {
+		wg.Add(1)
+		go func(userID string) {
+			defer wg.Done()
+			assert.NoError(t, s.SubmitUserResponse(ctx, session, &store.UserResponse{
+				SessionID: session.SessionID,
+				ChannelID: session.ChannelID,
+				Date:      session.Date,
+				UserID:    userID,
+				Responses: map[string]string{"today": "Testing"},
+			}))
+		}(userID)
+	}
+	wg.Wait()
+
+	// An edit replaces the response without counting it
+	edit := &store.UserResponse{
+		SessionID: session.SessionID,
+		ChannelID: session.ChannelID,
+		Date:      session.Date,
+		UserID:    userIDs[0],
+		Responses: map[string]string{"today": "Edited"},
+	}
+	require.NoError(t, s.SubmitUserResponse(ctx, session, edit))
+
+	got, err := s.GetSession(ctx, "C1234567890", "2024-01-15")
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.ResponseCount)
+
+	response, err := s.GetUserResponse(ctx, "C1234567890", "2024-01-15", userIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, "Edited", response.Responses["today"])
+
+	responses, err := s.ListSessionResponses(ctx, session.SessionID)
+	require.NoError(t, err)
+	assert.Len(t, responses, 2)
+
+	missing, err := s.GetUsersWithoutResponse(ctx, "C1234567890", "2024-01-15", userIDs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U0000000003"}, missing)
+
+	// An edit from a form opened before the last submission doesn't replace it
+	submittedAt := time.Now()
+	edit.SubmittedAt = submittedAt
+	require.NoError(t, s.SubmitUserResponse(ctx, session, edit))
+
+	stale := *edit
+	stale.Responses = map[string]string{"today": "Stale"}
+	stale.UnchangedSince = submittedAt.Add(-time.Minute)
+	assert.ErrorIs(t, s.SubmitUserResponse(ctx, session, &stale), store.ErrConflict)
+
+	stale.UnchangedSince = submittedAt
+	assert.NoError(t, s.SubmitUserResponse(ctx, session, &stale))
+}
+
+func TestBatchReads(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	for _, channelID := range []string{"C1234567890", "C0987654321"} {
+		require.NoError(t, s.CreateSession(ctx, &store.Session{
+			SessionID: "3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f",
+			ChannelID: channelID,
+			Date:      "2024-01-15",
+		}))
+	}
+	require.NoError(t, s.SaveUserResponse(ctx, &store.UserResponse{
+		ChannelID: "C1234567890", Date: "2024-01-15", UserID: "U1234567890",
+	}))
+
+	session, responses, err := s.GetSessionWithResponses(ctx, "C1234567890", "2024-01-15")
+	require.NoError(t, err)
+	assert.Equal(t, "C1234567890", session.ChannelID)
+	assert.Len(t, responses, 1)
+
+	_, _, err = s.GetSessionWithResponses(ctx, "C1234567890", "2024-01-16")
+	assert.Equal(t, store.ErrNotFound, err)
+
+	sessions, err := s.GetSessions(ctx, []string{"C1234567890", "C0987654321", "C1111111111"}, "2024-01-15")
+	require.NoError(t, err)
+	assert.Len(t, sessions, 2)
+
+	byChannel, err := s.ListChannelsUserResponses(ctx, []string{"C1234567890", "C0987654321"}, "2024-01-15")
+	require.NoError(t, err)
+	assert.Len(t, byChannel, 1)
+	assert.Len(t, byChannel["C1234567890"], 1)
+}
+
+func TestListUserResponsesByUser(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	for _, date := range []string{"2024-01-14", "2024-01-15", "2024-01-16", "2024-02-01"} {
+		require.NoError(t, s.SaveUserResponse(ctx, &store.UserResponse{
+			ChannelID: "C1234567890", Date: date, UserID: "U0000000001",
+		}))
+	}
+	require.NoError(t, s.SaveUserResponse(ctx, &store.UserResponse{
+		ChannelID: "C1234567890", Date: "2024-01-15", UserID: "U0000000002",
+	}))
+
+	responses, err := s.ListUserResponsesByUser(ctx, "C1234567890", "U0000000001", "2024-01-15", "2024-01-31")
+	require.NoError(t, err)
+	require.Len(t, responses, 2)
+	assert.Equal(t, "2024-01-16", responses[0].Date)
+	assert.Equal(t, "2024-01-15", responses[1].Date)
+}
+
+func TestSearchUserResponses(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	for _, response := range []*store.UserResponse{
+		{ChannelID: "C1234567890", Date: "2024-01-14", UserID: "U0000000001",
+			Responses: map[string]string{"question_0": "Fixed the deploy script"}},
+		{ChannelID: "C1234567890", Date: "2024-01-15", UserID: "U0000000002",
+			Responses: map[string]string{"question_0": "Deploy failed", "question_1": "Retry the DEPLOY"}},
+		{ChannelID: "C1234567890", Date: "2024-01-16", UserID: "U0000000001",
+			Responses: map[string]string{"question_0": "Reviewed PRs"}},
+		{ChannelID: "C0987654321", Date: "2024-01-15", UserID: "U0000000001",
+			Responses: map[string]string{"question_0": "Deploy day"}},
+	} {
+		require.NoError(t, s.SaveUserResponse(ctx, response))
+	}
+
+	query := &store.ResponseQuery{ChannelID: "C1234567890", StartDate: "2024-01-01", EndDate: "2024-01-31", Text: "deploy"}
+	responses, err := s.SearchUserResponses(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, responses, 2)
+	assert.Equal(t, "2024-01-15", responses[0].Date)
+	assert.Equal(t, "2024-01-14", responses[1].Date)
+
+	// Every keyword must appear, in any answer
+	query.Text = "failed retry"
+	responses, err = s.SearchUserResponses(ctx, query)
+	require.NoError(t, err)
+	assert.Len(t, responses, 1)
+
+	query.Text, query.UserID, query.Limit = "deploy", "U0000000001", 1
+	responses, err = s.SearchUserResponses(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	assert.Equal(t, "2024-01-14", responses[0].Date)
+}
+
+func TestFailedReminders(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	for _, failure := range []*store.FailedReminder{
+		{ChannelID: "C1234567890", Date: "2024-01-15", UserID: "U0000000001", Time: "09:00", ErrorCode: "cannot_dm_bot"},
+		{ChannelID: "C1234567890", Date: "2024-01-16", UserID: "U0000000001", Time: "09:00", ErrorCode: "cannot_dm_bot"},
+		{ChannelID: "C1234567890", Date: "2024-01-16", UserID: "U0000000002", Time: "14:00", ErrorCode: "user_not_in_channel"},
+		{ChannelID: "C1234567890", Date: "2024-02-01", UserID: "U0000000001", Time: "09:00"},
+		{ChannelID: "C0987654321", Date: "2024-01-16", UserID: "U0000000001", Time: "09:00"},
+	} {
+		require.NoError(t, s.SaveFailedReminder(ctx, failure))
+	}
+
+	failures, err := s.ListFailedReminders(ctx, "C1234567890", "2024-01-01", "2024-01-31")
+	require.NoError(t, err)
+	require.Len(t, failures, 3)
+	assert.Equal(t, "U0000000002", failures[0].UserID)
+	assert.Equal(t, "user_not_in_channel", failures[0].ErrorCode)
+	assert.Equal(t, "2024-01-16", failures[1].Date)
+	assert.Equal(t, "2024-01-15", failures[2].Date)
+
+	_, err = s.ListFailedReminders(ctx, "C1234567890", "2024-01-01", "January")
+	assert.Error(t, err)
+}
+
+func TestScheduledRuns(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	nextRun := time.Date(2024, 3, 11, 14, 0, 0, 0, time.UTC)
+
+	for _, run := range []*store.ScheduledRun{
+		{ChannelID: "C1234567890", Task: "summary", Clock: "10:00", Timezone: "America/New_York", NextRunAt: nextRun},
+		{ChannelID: "C1234567890", Task: "reminder#09:00", Clock: "09:00", Timezone: "America/New_York"},
+		{ChannelID: "C0987654321", Task: "summary", Clock: "17:00", Timezone: "UTC"},
+	} {
+		require.NoError(t, s.SaveScheduledRun(ctx, run))
+	}
+
+	// Saving a task again replaces its run
+	require.NoError(t, s.SaveScheduledRun(ctx, &store.ScheduledRun{
+		ChannelID: "C1234567890", Task: "summary", Clock: "10:00", Timezone: "America/New_York", NextRunAt: nextRun.AddDate(0, 0, 1),
+	}))
+
+	runs, err := s.ListScheduledRuns(ctx, "C1234567890")
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "reminder#09:00", runs[0].Task)
+	assert.Equal(t, "summary", runs[1].Task)
+	assert.Equal(t, nextRun.AddDate(0, 0, 1), runs[1].NextRunAt)
+
+	assert.Error(t, s.SaveScheduledRun(ctx, &store.ScheduledRun{ChannelID: "C1234567890"}))
+}
+
+func TestEnterpriseInstalls(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	// Org-wide installs are saved under the enterprise ID
+	require.NoError(t, s.SaveWorkspaceConfig(ctx, &store.WorkspaceConfig{
+		TeamID: "E0000000001", EnterpriseID: "E0000000001", IsEnterpriseInstall: true, BotToken: "xoxb-org",
+	}))
+	require.NoError(t, s.SaveWorkspaceConfig(ctx, &store.WorkspaceConfig{
+		TeamID: "T0000000001", EnterpriseID: "E0000000001",
+	}))
+
+	org, err := s.GetWorkspaceConfig(ctx, "E0000000001")
+	require.NoError(t, err)
+	assert.True(t, org.IsEnterpriseInstall)
+	assert.Equal(t, "xoxb-org", org.BotToken)
+
+	workspace, err := s.GetWorkspaceConfig(ctx, "T0000000001")
+	require.NoError(t, err)
+	assert.Equal(t, "E0000000001", workspace.EnterpriseID)
+
+	assert.Error(t, s.SaveWorkspaceConfig(ctx, &store.WorkspaceConfig{TeamID: "T0000000002", EnterpriseID: "T0000000001"}))
+}
+
+func TestTeamScope(t *testing.T) {
+	s := NewStore()
+	teamA := context.WithValue(context.Background(), botcontext.TeamIDKey, "T0000000001")
+	teamB := context.WithValue(context.Background(), botcontext.TeamIDKey, "T0000000002")
+
+	session := &store.Session{
+		SessionID: "3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f",
+		ChannelID: "C1234567890",
+		Date:      "2024-01-15",
+		Status:    store.SessionPending,
+	}
+	require.NoError(t, s.CreateSession(teamA, session))
+
+	// The same channel and date is a different session in another workspace
+	_, err := s.GetSession(teamB, "C1234567890", "2024-01-15")
+	assert.Equal(t, store.ErrNotFound, err)
+	require.NoError(t, s.CreateSession(teamB, session))
+
+	require.NoError(t, s.SaveSkippedResponse(teamA, &store.SkippedResponse{
+		ChannelID: "C1234567890", Date: "2024-01-15", UserID: "U0000000001",
+	}))
+	skips, err := s.ListSkippedResponses(teamB, "C1234567890", "2024-01-15")
+	require.NoError(t, err)
+	assert.Empty(t, skips)
+}
+
+func TestUserPreferences(t *testing.T) {
+	s := NewStore()
+	teamA := context.WithValue(context.Background(), botcontext.TeamIDKey, "T0000000001")
+	teamB := context.WithValue(context.Background(), botcontext.TeamIDKey, "T0000000002")
+
+	_, err := s.GetUserPreferences(teamA, "U0000000001")
+	assert.Equal(t, store.ErrNotFound, err)
+
+	prefs := &store.UserPreferences{UserID: "U0000000001", ReminderTime: "10:00", Delivery: store.DeliverChannel}
+	require.NoError(t, s.SaveUserPreferences(teamA, prefs))
+
+	got, err := s.GetUserPreferences(teamA, "U0000000001")
+	require.NoError(t, err)
+	assert.Equal(t, prefs, got)
+
+	// Preferences are kept per workspace
+	list, err := s.ListUserPreferences(teamA)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+	list, err = s.ListUserPreferences(teamB)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestProcessedEvents(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore().(*Store)
+
+	now := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	event := &store.ProcessedEvent{EventID: "Ev1234567890", ProcessedAt: now}
+	require.NoError(t, s.SaveProcessedEvent(ctx, event))
+	assert.Equal(t, store.ErrAlreadyExists, s.SaveProcessedEvent(ctx, event))
+
+	processed, err := s.IsEventProcessed(ctx, "Ev1234567890")
+	require.NoError(t, err)
+	assert.True(t, processed)
+
+	// Records expire after the retention period
+	now = now.Add(processedEventRetention + time.Minute)
+	processed, err = s.IsEventProcessed(ctx, "Ev1234567890")
+	require.NoError(t, err)
+	assert.False(t, processed)
+	assert.NoError(t, s.SaveProcessedEvent(ctx, &store.ProcessedEvent{EventID: "Ev1234567890", ProcessedAt: now}))
+}
+
+func TestStoreContract(t *testing.T) {
+	storetest.TestStore(t, func(t *testing.T) store.Store { return NewStore() })
+}
@@ -0,0 +1,98 @@
+CREATE TABLE workspaces (
+    team_id      TEXT PRIMARY KEY,
+    team_name    TEXT NOT NULL DEFAULT '',
+    bot_token    TEXT NOT NULL DEFAULT '',
+    app_token    TEXT NOT NULL DEFAULT '',
+    installed_at TIMESTAMPTZ NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE channel_configs (
+    team_id      TEXT NOT NULL,
+    channel_id   TEXT NOT NULL,
+    channel_name TEXT NOT NULL DEFAULT '',
+    enabled      BOOLEAN NOT NULL DEFAULT FALSE,
+    schedule     JSONB NOT NULL DEFAULT '{}',
+    users        JSONB NOT NULL DEFAULT '[]',
+    templates    JSONB NOT NULL DEFAULT '{}',
+    questions    JSONB NOT NULL DEFAULT '[]',
+    updated_at   TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (team_id, channel_id)
+);
+
+CREATE INDEX channel_configs_enabled_idx ON channel_configs (enabled);
+
+CREATE TABLE sessions (
+    channel_id     TEXT NOT NULL,
+    date           TEXT NOT NULL,
+    session_id     TEXT NOT NULL,
+    status         TEXT NOT NULL,
+    summary_posted BOOLEAN NOT NULL DEFAULT FALSE,
+    anchor_ts      TEXT NOT NULL DEFAULT '',
+    response_count INTEGER NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ NOT NULL,
+    completed_at   TIMESTAMPTZ,
+    PRIMARY KEY (channel_id, date)
+);
+
+CREATE INDEX sessions_status_idx ON sessions (status, date);
+
+CREATE TABLE user_responses (
+    channel_id     TEXT NOT NULL,
+    date           TEXT NOT NULL,
+    user_id        TEXT NOT NULL,
+    session_id     TEXT NOT NULL,
+    user_name      TEXT NOT NULL DEFAULT '',
+    responses      JSONB NOT NULL DEFAULT '{}',
+    answers        JSONB NOT NULL DEFAULT '{}',
+    submitted_at   TIMESTAMPTZ NOT NULL,
+    reminder_count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (channel_id, date, user_id)
+);
+
+CREATE INDEX user_responses_session_idx ON user_responses (session_id, user_id);
+
+CREATE TABLE reminders (
+    channel_id    TEXT NOT NULL,
+    date          TEXT NOT NULL,
+    user_id       TEXT NOT NULL,
+    time          TEXT NOT NULL,
+    sent_at       TIMESTAMPTZ NOT NULL,
+    message_ts    TEXT NOT NULL DEFAULT '',
+    snoozed_until TIMESTAMPTZ,
+    PRIMARY KEY (channel_id, date, user_id, time)
+);
+
+CREATE TABLE skipped_responses (
+    channel_id TEXT NOT NULL,
+    date       TEXT NOT NULL,
+    user_id    TEXT NOT NULL,
+    reason     TEXT NOT NULL DEFAULT '',
+    skipped_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (channel_id, date, user_id)
+);
+
+CREATE TABLE escalations (
+    channel_id     TEXT NOT NULL,
+    date           TEXT NOT NULL,
+    user_id        TEXT NOT NULL,
+    action         TEXT NOT NULL,
+    reminder_count INTEGER NOT NULL DEFAULT 0,
+    escalated_at   TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (channel_id, date, user_id)
+);
+
+CREATE TABLE digests (
+    channel_id TEXT NOT NULL,
+    period     TEXT NOT NULL,
+    period_key TEXT NOT NULL,
+    posted_at  TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (channel_id, period, period_key)
+);
+
+CREATE TABLE processed_events (
+    event_id     TEXT PRIMARY KEY,
+    team_id      TEXT NOT NULL DEFAULT '',
+    event_type   TEXT NOT NULL DEFAULT '',
+    processed_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,20 @@
+-- Scope channel-level records to a workspace so one deployment can serve
+-- several. Existing rows belong to the single workspace and keep team_id ''.
+
+ALTER TABLE sessions ADD COLUMN team_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE sessions DROP CONSTRAINT sessions_pkey, ADD PRIMARY KEY (team_id, channel_id, date);
+
+ALTER TABLE user_responses ADD COLUMN team_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE user_responses DROP CONSTRAINT user_responses_pkey, ADD PRIMARY KEY (team_id, channel_id, date, user_id);
+
+ALTER TABLE reminders ADD COLUMN team_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE reminders DROP CONSTRAINT reminders_pkey, ADD PRIMARY KEY (team_id, channel_id, date, user_id, time);
+
+ALTER TABLE skipped_responses ADD COLUMN team_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE skipped_responses DROP CONSTRAINT skipped_responses_pkey, ADD PRIMARY KEY (team_id, channel_id, date, user_id);
+
+ALTER TABLE escalations ADD COLUMN team_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE escalations DROP CONSTRAINT escalations_pkey, ADD PRIMARY KEY (team_id, channel_id, date, user_id);
+
+ALTER TABLE digests ADD COLUMN team_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE digests DROP CONSTRAINT digests_pkey, ADD PRIMARY KEY (team_id, channel_id, period, period_key);
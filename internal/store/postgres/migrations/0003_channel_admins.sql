@@ -0,0 +1,4 @@
+-- Users allowed to change a channel's settings, export reports and force
+-- summaries, in addition to workspace admins.
+
+ALTER TABLE channel_configs ADD COLUMN admins JSONB NOT NULL DEFAULT '[]';
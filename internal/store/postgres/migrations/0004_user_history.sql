@@ -0,0 +1,3 @@
+-- Index a user's responses across dates for per-user history and streaks.
+
+CREATE INDEX user_responses_user_idx ON user_responses (team_id, channel_id, user_id, date);
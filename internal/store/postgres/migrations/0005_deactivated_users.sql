@@ -0,0 +1,4 @@
+-- Required users who were deactivated or removed from the workspace. They
+-- aren't reminded or reported missing.
+
+ALTER TABLE channel_configs ADD COLUMN deactivated_users JSONB NOT NULL DEFAULT '[]';
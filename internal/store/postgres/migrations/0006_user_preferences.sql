@@ -0,0 +1,11 @@
+-- Users' reminder preferences, applied in every channel they're required in.
+
+CREATE TABLE user_preferences (
+    team_id       TEXT NOT NULL DEFAULT '',
+    user_id       TEXT NOT NULL,
+    reminders_off BOOLEAN NOT NULL DEFAULT FALSE,
+    reminder_time TEXT NOT NULL DEFAULT '',
+    delivery      TEXT NOT NULL DEFAULT '',
+    updated_at    TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (team_id, user_id)
+);
@@ -0,0 +1,3 @@
+-- Third-party service settings of each workspace, such as Jira.
+
+ALTER TABLE workspaces ADD COLUMN integrations JSONB NOT NULL DEFAULT '{}';
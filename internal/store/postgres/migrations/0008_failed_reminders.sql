@@ -0,0 +1,14 @@
+-- Reminders that couldn't be delivered, kept so admins can see who isn't
+-- being reached.
+
+CREATE TABLE failed_reminders (
+    team_id    TEXT NOT NULL DEFAULT '',
+    channel_id TEXT NOT NULL,
+    date       TEXT NOT NULL,
+    user_id    TEXT NOT NULL,
+    time       TEXT NOT NULL,
+    error_code TEXT NOT NULL DEFAULT '',
+    error      TEXT NOT NULL DEFAULT '',
+    failed_at  TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (team_id, channel_id, date, user_id, time)
+);
@@ -0,0 +1,6 @@
+-- Late submissions: the summary message is kept so it can be updated, and
+-- responses first submitted after it are flagged.
+
+ALTER TABLE sessions ADD COLUMN summary_ts TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE user_responses ADD COLUMN late BOOLEAN NOT NULL DEFAULT FALSE;
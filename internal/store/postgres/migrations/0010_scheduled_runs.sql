@@ -0,0 +1,13 @@
+-- When each channel's scheduled tasks next run, so a task runs once per
+-- occurrence across DST changes and late scheduler ticks.
+
+CREATE TABLE scheduled_runs (
+    team_id     TEXT NOT NULL DEFAULT '',
+    channel_id  TEXT NOT NULL,
+    task        TEXT NOT NULL,
+    clock       TEXT NOT NULL,
+    timezone    TEXT NOT NULL,
+    next_run_at TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (team_id, channel_id, task)
+);
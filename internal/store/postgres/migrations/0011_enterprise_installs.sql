@@ -0,0 +1,6 @@
+-- Enterprise Grid: org-wide installs are saved under their enterprise ID,
+-- and workspaces of a grid record the organization they belong to.
+
+ALTER TABLE workspaces ADD COLUMN enterprise_id TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE workspaces ADD COLUMN is_enterprise_install BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,6 @@
+-- Slack user groups whose members are required in a channel, and the members
+-- they had when each session started.
+
+ALTER TABLE channel_configs ADD COLUMN user_groups JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE sessions ADD COLUMN group_members JSONB NOT NULL DEFAULT '[]';
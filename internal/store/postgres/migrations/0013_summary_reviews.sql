@@ -0,0 +1,3 @@
+-- Leads who acknowledged each session's summary.
+
+ALTER TABLE sessions ADD COLUMN reviewed_by JSONB NOT NULL DEFAULT '[]';
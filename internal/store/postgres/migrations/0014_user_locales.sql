@@ -0,0 +1,3 @@
+-- Languages users chose for the bot's messages to them.
+
+ALTER TABLE user_preferences ADD COLUMN locale TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,14 @@
+-- Each user's run of consecutive standups in a channel.
+
+CREATE TABLE streaks (
+    team_id    TEXT NOT NULL DEFAULT '',
+    channel_id TEXT NOT NULL,
+    user_id    TEXT NOT NULL,
+    current    INTEGER NOT NULL DEFAULT 0,
+    longest    INTEGER NOT NULL DEFAULT 0,
+    last_date  TEXT NOT NULL DEFAULT '',
+    missed     BOOLEAN NOT NULL DEFAULT FALSE,
+    broken     INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (team_id, channel_id, user_id)
+);
@@ -0,0 +1,4 @@
+-- When a channel's Slack channel was archived; its standups are paused
+-- until it's unarchived.
+
+ALTER TABLE channel_configs ADD COLUMN archived_at TIMESTAMPTZ;
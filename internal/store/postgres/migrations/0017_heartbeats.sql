@@ -0,0 +1,14 @@
+-- The health of recurring jobs, such as the scheduler's runs and each
+-- channel's daily summary, for the monitor to alert on.
+
+CREATE TABLE heartbeats (
+    team_id         TEXT NOT NULL DEFAULT '',
+    job             TEXT NOT NULL,
+    channel_id      TEXT NOT NULL DEFAULT '',
+    last_success_at TIMESTAMPTZ,
+    failures        INTEGER NOT NULL DEFAULT 0,
+    last_error      TEXT NOT NULL DEFAULT '',
+    alerted_at      TIMESTAMPTZ,
+    updated_at      TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (team_id, job, channel_id)
+);
@@ -0,0 +1,4 @@
+-- Counts each channel config's changes, so a save only replaces the version
+-- it read. Existing configs start at version 0.
+
+ALTER TABLE channel_configs ADD COLUMN version BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Index a user's responses by date for reports across channels.
+
+CREATE INDEX user_responses_user_date_idx ON user_responses (team_id, user_id, date);
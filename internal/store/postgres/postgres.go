@@ -0,0 +1,1733 @@
+// Package postgres implements the store on PostgreSQL for self-hosted
+// deployments that do not use DynamoDB.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq" // Also registers the "postgres" driver
+
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/validation"
+)
+
+// Driver is the DATABASE_DRIVER value that selects this store.
+const Driver = "postgres"
+
+// processedEventRetention is how long processed Slack event IDs are kept.
+const processedEventRetention = 24 * time.Hour
+
+// Column lists shared by the queries and scan functions below.
+const (
+	channelConfigColumns = `team_id, channel_id, channel_name, enabled, schedule, users, admins, templates, questions,
+		updated_at, deactivated_users, user_groups, archived_at, version`
+	sessionColumns = `session_id, channel_id, date, status, summary_posted, anchor_ts, response_count, created_at,
+		completed_at, summary_ts, group_members, reviewed_by`
+	userResponseColumns = `session_id, channel_id, date, user_id, user_name, responses, answers, submitted_at,
+		reminder_count, late`
+	heartbeatColumns = `team_id, job, channel_id, last_success_at, failures, last_error, alerted_at, updated_at`
+)
+
+// userDataTables are the tables holding records about a user, erased by
+// DeleteUserData.
+var userDataTables = []string{
+	"user_responses", "skipped_responses", "escalations", "reminders", "failed_reminders", "streaks",
+	"user_preferences",
+}
+
+// deleteBatchSize is the most rows DeleteUserData deletes per statement, so
+// a prolific user's purge doesn't hold locks on a table for long.
+const deleteBatchSize = 500
+
+// likeEscaper escapes LIKE wildcards so searches match them literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// Store implements the Store interface using PostgreSQL.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore creates a new PostgreSQL store. Run Migrate before first use.
+func NewStore(db *sql.DB) store.Store {
+	return &Store{db: db}
+}
+
+// Open connects to the database at dsn and applies pending migrations.
+func Open(ctx context.Context, dsn string) (*sql.DB, error) {
+	db, err := sql.Open(Driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if err := Migrate(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// jsonb stores a Go value in a JSONB column.
+type jsonb struct {
+	v any
+}
+
+// Value returns the JSON as a string; lib/pq would send []byte as bytea.
+func (j jsonb) Value() (driver.Value, error) {
+	data, err := json.Marshal(j.v)
+	return string(data), err
+}
+
+func (j jsonb) Scan(src any) error {
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(data, j.v)
+	case string:
+		return json.Unmarshal([]byte(data), j.v)
+	default:
+		return fmt.Errorf("unsupported JSON column type %T", src)
+	}
+}
+
+// validateSessionKey validates the channel ID and date that identify a session.
+func validateSessionKey(channelID, date string) error {
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+	return nil
+}
+
+// validateUserKey validates the channel ID, date and user ID that identify a
+// user's record for a session.
+func validateUserKey(channelID, date, userID string) error {
+	if err := validateSessionKey(channelID, date); err != nil {
+		return err
+	}
+	if err := validation.ValidateUserID(userID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+	return nil
+}
+
+// requireRow returns ErrNotFound when an update matched no rows.
+func requireRow(result sql.Result) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return &store.Error{Code: "UPDATE_ERROR", Message: "Failed to read affected rows", Err: err}
+	}
+	if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// insertOnce runs an INSERT ... ON CONFLICT DO NOTHING, returning
+// ErrAlreadyExists when the row was already there.
+func (s *Store) insertOnce(ctx context.Context, message, query string, args ...any) error {
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: message, Err: err}
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: message, Err: err}
+	}
+	if n == 0 {
+		return store.ErrAlreadyExists
+	}
+	return nil
+}
+
+// SaveWorkspaceConfig saves workspace configuration.
+func (s *Store) SaveWorkspaceConfig(ctx context.Context, config *store.WorkspaceConfig) error {
+	// Validate team ID, or enterprise ID for org-wide installs
+	if err := validation.ValidateWorkspaceID(config.TeamID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
+	}
+	if config.EnterpriseID != "" {
+		if err := validation.ValidateEnterpriseID(config.EnterpriseID); err != nil {
+			return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid enterprise ID", Err: err}
+		}
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO workspaces (team_id, team_name, bot_token, app_token, installed_at, updated_at, integrations,
+			enterprise_id, is_enterprise_install)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (team_id) DO UPDATE SET
+			team_name = EXCLUDED.team_name,
+			bot_token = EXCLUDED.bot_token,
+			app_token = EXCLUDED.app_token,
+			installed_at = EXCLUDED.installed_at,
+			updated_at = EXCLUDED.updated_at,
+			integrations = EXCLUDED.integrations,
+			enterprise_id = EXCLUDED.enterprise_id,
+			is_enterprise_install = EXCLUDED.is_enterprise_install`,
+		config.TeamID, config.TeamName, config.BotToken, config.AppToken, config.InstalledAt, time.Now(),
+		jsonb{config.Integrations}, config.EnterpriseID, config.IsEnterpriseInstall,
+	)
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save workspace config", Err: err}
+	}
+
+	return nil
+}
+
+// GetWorkspaceConfig retrieves workspace configuration.
+func (s *Store) GetWorkspaceConfig(ctx context.Context, teamID string) (*store.WorkspaceConfig, error) {
+	// Validate team ID, or enterprise ID for org-wide installs
+	if err := validation.ValidateWorkspaceID(teamID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
+	}
+
+	var config store.WorkspaceConfig
+	err := s.db.QueryRowContext(ctx, `
+		SELECT team_id, team_name, bot_token, app_token, installed_at, updated_at, integrations,
+			enterprise_id, is_enterprise_install
+		FROM workspaces WHERE team_id = $1`, teamID,
+	).Scan(&config.TeamID, &config.TeamName, &config.BotToken, &config.AppToken, &config.InstalledAt, &config.UpdatedAt,
+		jsonb{&config.Integrations}, &config.EnterpriseID, &config.IsEnterpriseInstall)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, &store.Error{Code: "GET_ERROR", Message: "Failed to get workspace config", Err: err}
+	}
+
+	return &config, nil
+}
+
+// SaveChannelConfig saves channel configuration.
+func (s *Store) SaveChannelConfig(ctx context.Context, config *store.ChannelConfig) error {
+	// Validate IDs
+	if err := validation.ValidateTeamID(config.TeamID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
+	}
+	if err := validation.ValidateChannelID(config.ChannelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO channel_configs (`+channelConfigColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14 + 1)
+		ON CONFLICT (team_id, channel_id) DO UPDATE SET
+			channel_name = EXCLUDED.channel_name,
+			enabled = EXCLUDED.enabled,
+			schedule = EXCLUDED.schedule,
+			users = EXCLUDED.users,
+			admins = EXCLUDED.admins,
+			templates = EXCLUDED.templates,
+			questions = EXCLUDED.questions,
+			updated_at = EXCLUDED.updated_at,
+			deactivated_users = EXCLUDED.deactivated_users,
+			user_groups = EXCLUDED.user_groups,
+			archived_at = EXCLUDED.archived_at,
+			version = EXCLUDED.version
+		WHERE channel_configs.version = $14`,
+		config.TeamID, config.ChannelID, config.ChannelName, config.Enabled,
+		jsonb{config.Schedule}, jsonb{config.Users}, jsonb{config.Admins}, jsonb{config.Templates},
+		jsonb{config.Questions}, time.Now(), jsonb{config.DeactivatedUsers}, jsonb{config.UserGroups},
+		config.ArchivedAt, config.Version,
+	)
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save channel config", Err: err}
+	}
+
+	saved, err := result.RowsAffected()
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save channel config", Err: err}
+	}
+	if saved == 0 {
+		conflict := &store.ConflictError{TeamID: config.TeamID, ChannelID: config.ChannelID, Expected: config.Version}
+		err := s.db.QueryRowContext(ctx, `
+			SELECT version FROM channel_configs WHERE team_id = $1 AND channel_id = $2`,
+			config.TeamID, config.ChannelID,
+		).Scan(&conflict.Current)
+		if err != nil && err != sql.ErrNoRows {
+			return &store.Error{Code: "GET_ERROR", Message: "Failed to read channel config version", Err: err}
+		}
+		return conflict
+	}
+
+	config.Version++
+	return nil
+}
+
+func scanChannelConfig(row scanner) (*store.ChannelConfig, error) {
+	var (
+		config     store.ChannelConfig
+		archivedAt sql.NullTime
+	)
+	err := row.Scan(&config.TeamID, &config.ChannelID, &config.ChannelName, &config.Enabled,
+		jsonb{&config.Schedule}, jsonb{&config.Users}, jsonb{&config.Admins}, jsonb{&config.Templates},
+		jsonb{&config.Questions}, &config.UpdatedAt, jsonb{&config.DeactivatedUsers},
+		jsonb{&config.UserGroups}, &archivedAt, &config.Version)
+	if archivedAt.Valid {
+		config.ArchivedAt = &archivedAt.Time
+	}
+	return &config, err
+}
+
+// GetChannelConfig retrieves channel configuration.
+func (s *Store) GetChannelConfig(ctx context.Context, teamID, channelID string) (*store.ChannelConfig, error) {
+	// Validate IDs
+	if err := validation.ValidateTeamID(teamID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
+	}
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	config, err := scanChannelConfig(s.db.QueryRowContext(ctx, `
+		SELECT `+channelConfigColumns+` FROM channel_configs
+		WHERE team_id = $1 AND channel_id = $2`, teamID, channelID))
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, &store.Error{Code: "GET_ERROR", Message: "Failed to get channel config", Err: err}
+	}
+
+	return config, nil
+}
+
+// ListChannelConfigs lists all channel configurations for a workspace.
+func (s *Store) ListChannelConfigs(ctx context.Context, teamID string) ([]*store.ChannelConfig, error) {
+	// Validate team ID
+	if err := validation.ValidateTeamID(teamID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
+	}
+
+	return s.listChannelConfigs(ctx, "Failed to query channel configs", `
+		SELECT `+channelConfigColumns+` FROM channel_configs
+		WHERE team_id = $1 ORDER BY channel_id`, teamID)
+}
+
+// ListActiveChannelConfigs lists all active channel configurations across all workspaces.
+func (s *Store) ListActiveChannelConfigs(ctx context.Context) ([]*store.ChannelConfig, error) {
+	return s.listChannelConfigs(ctx, "Failed to query active configs", `
+		SELECT `+channelConfigColumns+` FROM channel_configs
+		WHERE enabled AND archived_at IS NULL ORDER BY team_id, channel_id`)
+}
+
+// DisableChannelConfig turns a channel's standups off, keeping its config.
+func (s *Store) DisableChannelConfig(ctx context.Context, teamID, channelID string) error {
+	return s.updateChannelConfig(ctx, "Failed to disable channel config", `
+		UPDATE channel_configs SET enabled = FALSE, updated_at = $3, version = version + 1
+		WHERE team_id = $1 AND channel_id = $2`, teamID, channelID)
+}
+
+// ArchiveChannelConfig pauses a channel's standups while it's archived.
+func (s *Store) ArchiveChannelConfig(ctx context.Context, teamID, channelID string, archivedAt time.Time) error {
+	return s.updateChannelConfig(ctx, "Failed to archive channel config", `
+		UPDATE channel_configs SET archived_at = $4, updated_at = $3, version = version + 1
+		WHERE team_id = $1 AND channel_id = $2`, teamID, channelID, archivedAt)
+}
+
+// UnarchiveChannelConfig resumes the standups of an unarchived channel.
+func (s *Store) UnarchiveChannelConfig(ctx context.Context, teamID, channelID string) error {
+	return s.updateChannelConfig(ctx, "Failed to unarchive channel config", `
+		UPDATE channel_configs SET archived_at = NULL, updated_at = $3, version = version + 1
+		WHERE team_id = $1 AND channel_id = $2`, teamID, channelID)
+}
+
+// updateChannelConfig runs an UPDATE of a channel's config. Its arguments
+// are the team and channel IDs, the update time, then args.
+func (s *Store) updateChannelConfig(ctx context.Context, message, query, teamID, channelID string, args ...any) error {
+	if err := validation.ValidateTeamID(teamID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid team ID", Err: err}
+	}
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	result, err := s.db.ExecContext(ctx, query, append([]any{teamID, channelID, time.Now()}, args...)...)
+	if err != nil {
+		return &store.Error{Code: "UPDATE_ERROR", Message: message, Err: err}
+	}
+
+	return requireRow(result)
+}
+
+func (s *Store) listChannelConfigs(
+	ctx context.Context,
+	message, query string,
+	args ...any,
+) ([]*store.ChannelConfig, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: message, Err: err}
+	}
+	defer rows.Close()
+
+	var configs []*store.ChannelConfig
+	for rows.Next() {
+		config, err := scanChannelConfig(rows)
+		if err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: message, Err: err}
+		}
+		configs = append(configs, config)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: message, Err: err}
+	}
+
+	return configs, nil
+}
+
+// CreateSession creates a new standup session, returning ErrAlreadyExists if
+// the channel already has a session for that date.
+func (s *Store) CreateSession(ctx context.Context, session *store.Session) error {
+	// Validate inputs
+	if err := validateSessionKey(session.ChannelID, session.Date); err != nil {
+		return err
+	}
+
+	return s.insertOnce(ctx, "Failed to create session", `
+		INSERT INTO sessions (`+sessionColumns+`, team_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (team_id, channel_id, date) DO NOTHING`,
+		session.SessionID, session.ChannelID, session.Date, session.Status, session.SummaryPosted,
+		session.AnchorTS, session.ResponseCount, session.CreatedAt, session.CompletedAt, session.SummaryTS,
+		jsonb{session.GroupMembers}, jsonb{session.ReviewedBy}, store.TeamScope(ctx),
+	)
+}
+
+func scanSession(row scanner) (*store.Session, error) {
+	var (
+		session     store.Session
+		completedAt sql.NullTime
+	)
+	err := row.Scan(&session.SessionID, &session.ChannelID, &session.Date, &session.Status,
+		&session.SummaryPosted, &session.AnchorTS, &session.ResponseCount, &session.CreatedAt, &completedAt,
+		&session.SummaryTS, jsonb{&session.GroupMembers}, jsonb{&session.ReviewedBy})
+	if completedAt.Valid {
+		session.CompletedAt = &completedAt.Time
+	}
+	return &session, err
+}
+
+// GetSession retrieves a standup session.
+func (s *Store) GetSession(ctx context.Context, channelID, date string) (*store.Session, error) {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return nil, err
+	}
+
+	session, err := scanSession(s.db.QueryRowContext(ctx, `
+		SELECT `+sessionColumns+` FROM sessions
+		WHERE channel_id = $1 AND date = $2 AND team_id = $3`, channelID, date, store.TeamScope(ctx)))
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, &store.Error{Code: "GET_ERROR", Message: "Failed to get session", Err: err}
+	}
+
+	return session, nil
+}
+
+// UpdateSessionStatus updates the status of a session.
+func (s *Store) UpdateSessionStatus(
+	ctx context.Context,
+	channelID, date string,
+	status store.SessionStatus,
+) error {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return err
+	}
+
+	var completedAt *time.Time
+	if status == store.SessionCompleted {
+		now := time.Now()
+		completedAt = &now
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE sessions SET status = $3, completed_at = COALESCE($4, completed_at)
+		WHERE channel_id = $1 AND date = $2 AND team_id = $5`, channelID, date, status, completedAt, store.TeamScope(ctx))
+	if err != nil {
+		return &store.Error{Code: "UPDATE_ERROR", Message: "Failed to update session status", Err: err}
+	}
+
+	return requireRow(result)
+}
+
+// ClaimSummary marks a session summary as posted before posting it,
+// returning ErrAlreadyExists if it already was.
+func (s *Store) ClaimSummary(ctx context.Context, channelID, date string) error {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return err
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE sessions SET summary_posted = TRUE
+		WHERE channel_id = $1 AND date = $2 AND team_id = $3 AND NOT summary_posted`,
+		channelID, date, store.TeamScope(ctx))
+	if err != nil {
+		return &store.Error{Code: "UPDATE_ERROR", Message: "Failed to claim summary", Err: err}
+	}
+
+	if err := requireRow(result); err != store.ErrNotFound {
+		return err
+	}
+	// Tell a missing session from one already posted
+	if _, err := s.GetSession(ctx, channelID, date); err != nil {
+		return err
+	}
+	return store.ErrAlreadyExists
+}
+
+// ReleaseSummary clears a session's summary claim after posting failed.
+func (s *Store) ReleaseSummary(ctx context.Context, channelID, date string) error {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return err
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE sessions SET summary_posted = FALSE
+		WHERE channel_id = $1 AND date = $2 AND team_id = $3`, channelID, date, store.TeamScope(ctx))
+	if err != nil {
+		return &store.Error{Code: "UPDATE_ERROR", Message: "Failed to release summary", Err: err}
+	}
+
+	return requireRow(result)
+}
+
+// MarkSummaryPosted marks a session summary as posted, recording the
+// timestamp of the summary message.
+func (s *Store) MarkSummaryPosted(ctx context.Context, channelID, date, summaryTS string) error {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return err
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE sessions SET summary_posted = TRUE, summary_ts = $3
+		WHERE channel_id = $1 AND date = $2 AND team_id = $4`, channelID, date, summaryTS, store.TeamScope(ctx))
+	if err != nil {
+		return &store.Error{Code: "UPDATE_ERROR", Message: "Failed to mark summary posted", Err: err}
+	}
+
+	return requireRow(result)
+}
+
+// SetSessionAnchor records the timestamp of the daily thread anchor message.
+func (s *Store) SetSessionAnchor(ctx context.Context, channelID, date, anchorTS string) error {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return err
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE sessions SET anchor_ts = $3
+		WHERE channel_id = $1 AND date = $2 AND team_id = $4`, channelID, date, anchorTS, store.TeamScope(ctx))
+	if err != nil {
+		return &store.Error{Code: "UPDATE_ERROR", Message: "Failed to set session anchor", Err: err}
+	}
+
+	return requireRow(result)
+}
+
+// MarkSessionReviewed records a lead acknowledging the session's summary.
+func (s *Store) MarkSessionReviewed(ctx context.Context, channelID, date, userID string) error {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return err
+	}
+	if err := validation.ValidateUserID(userID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE sessions SET reviewed_by = CASE
+			WHEN jsonb_typeof(reviewed_by) <> 'array' THEN jsonb_build_array($3::text)
+			WHEN reviewed_by @> jsonb_build_array($3::text) THEN reviewed_by
+			ELSE reviewed_by || jsonb_build_array($3::text) END
+		WHERE channel_id = $1 AND date = $2 AND team_id = $4`, channelID, date, userID, store.TeamScope(ctx))
+	if err != nil {
+		return &store.Error{Code: "UPDATE_ERROR", Message: "Failed to mark session reviewed", Err: err}
+	}
+
+	return requireRow(result)
+}
+
+// ListSessions lists a channel's sessions between two dates, newest first.
+func (s *Store) ListSessions(ctx context.Context, channelID, startDate, endDate string) ([]*store.Session, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(startDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid start date", Err: err}
+	}
+	if err := validation.ValidateDate(endDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid end date", Err: err}
+	}
+
+	return s.listSessions(ctx, "Failed to query sessions", `
+		SELECT `+sessionColumns+` FROM sessions
+		WHERE channel_id = $1 AND date BETWEEN $2 AND $3 AND team_id = $4
+		ORDER BY date DESC`, channelID, startDate, endDate, store.TeamScope(ctx))
+}
+
+// GetSessionWithResponses retrieves a session together with its responses.
+func (s *Store) GetSessionWithResponses(
+	ctx context.Context,
+	channelID, date string,
+) (*store.Session, []*store.UserResponse, error) {
+	session, err := s.GetSession(ctx, channelID, date)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses, err := s.ListUserResponses(ctx, channelID, date)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return session, responses, nil
+}
+
+// GetSessions retrieves the sessions of several channels on a date in one
+// query, keyed by channel ID.
+func (s *Store) GetSessions(ctx context.Context, channelIDs []string, date string) (map[string]*store.Session, error) {
+	// Validate inputs
+	for _, channelID := range channelIDs {
+		if err := validateSessionKey(channelID, date); err != nil {
+			return nil, err
+		}
+	}
+
+	list, err := s.listSessions(ctx, "Failed to query sessions", `
+		SELECT `+sessionColumns+` FROM sessions
+		WHERE channel_id = ANY($1) AND date = $2 AND team_id = $3`, pq.Array(channelIDs), date, store.TeamScope(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make(map[string]*store.Session, len(list))
+	for _, session := range list {
+		sessions[session.ChannelID] = session
+	}
+	return sessions, nil
+}
+
+func (s *Store) listSessions(ctx context.Context, message, query string, args ...any) ([]*store.Session, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: message, Err: err}
+	}
+	defer rows.Close()
+
+	var sessions []*store.Session
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: message, Err: err}
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: message, Err: err}
+	}
+
+	return sessions, nil
+}
+
+// SaveUserResponse saves a user's standup response.
+func (s *Store) SaveUserResponse(ctx context.Context, response *store.UserResponse) error {
+	// Validate inputs
+	if err := validateUserKey(response.ChannelID, response.Date, response.UserID); err != nil {
+		return err
+	}
+
+	if err := upsertUserResponse(ctx, s.db, store.TeamScope(ctx), response); err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save user response", Err: err}
+	}
+
+	return nil
+}
+
+// upsertUserResponse writes a response, replacing any earlier one.
+func upsertUserResponse(ctx context.Context, db execer, teamID string, response *store.UserResponse) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO user_responses (`+userResponseColumns+`, team_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (team_id, channel_id, date, user_id) DO UPDATE SET
+			session_id = EXCLUDED.session_id,
+			user_name = EXCLUDED.user_name,
+			responses = EXCLUDED.responses,
+			answers = EXCLUDED.answers,
+			submitted_at = EXCLUDED.submitted_at,
+			reminder_count = EXCLUDED.reminder_count,
+			late = EXCLUDED.late`,
+		userResponseArgs(teamID, response)...,
+	)
+	return err
+}
+
+// replaceUserResponse replaces a response unless it was submitted after
+// response.UnchangedSince, returning store.ErrConflict if it was.
+func replaceUserResponse(ctx context.Context, db execer, teamID string, response *store.UserResponse) error {
+	result, err := db.ExecContext(ctx, `
+		UPDATE user_responses SET
+			session_id = $1, user_name = $2, responses = $3, answers = $4,
+			submitted_at = $5, reminder_count = $6, late = $7
+		WHERE team_id = $8 AND channel_id = $9 AND date = $10 AND user_id = $11
+			AND submitted_at <= $12`,
+		response.SessionID, response.UserName, jsonb{response.Responses}, jsonb{response.Answers},
+		response.SubmittedAt, response.ReminderCount, response.Late,
+		teamID, response.ChannelID, response.Date, response.UserID, response.UnchangedSince,
+	)
+	if err != nil {
+		return err
+	}
+
+	replaced, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if replaced == 0 {
+		return store.ErrConflict
+	}
+	return nil
+}
+
+func userResponseArgs(teamID string, response *store.UserResponse) []any {
+	return []any{
+		response.SessionID, response.ChannelID, response.Date, response.UserID, response.UserName,
+		jsonb{response.Responses}, jsonb{response.Answers}, response.SubmittedAt, response.ReminderCount,
+		response.Late, teamID,
+	}
+}
+
+// SubmitUserResponse saves a user's first response for a session in one
+// transaction that also creates the session if needed and increments its
+// response count. Later edits replace the response without counting it again.
+func (s *Store) SubmitUserResponse(ctx context.Context, session *store.Session, response *store.UserResponse) error {
+	if session.ChannelID != response.ChannelID || session.Date != response.Date {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Response does not belong to session"}
+	}
+	if err := validateUserKey(response.ChannelID, response.Date, response.UserID); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &store.Error{Code: "TRANSACTION_ERROR", Message: "Failed to submit user response", Err: err}
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = submitUserResponse(ctx, tx, store.TeamScope(ctx), session, response)
+	if errors.Is(err, store.ErrConflict) {
+		return err
+	}
+	if err != nil {
+		return &store.Error{Code: "TRANSACTION_ERROR", Message: "Failed to submit user response", Err: err}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &store.Error{Code: "TRANSACTION_ERROR", Message: "Failed to submit user response", Err: err}
+	}
+
+	return nil
+}
+
+func submitUserResponse(
+	ctx context.Context,
+	tx *sql.Tx,
+	teamID string,
+	session *store.Session,
+	response *store.UserResponse,
+) error {
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO user_responses (`+userResponseColumns+`, team_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (team_id, channel_id, date, user_id) DO NOTHING`,
+		userResponseArgs(teamID, response)...,
+	)
+	if err != nil {
+		return err
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// The response already exists, so this is an edit
+	if inserted == 0 && response.UnchangedSince.IsZero() {
+		return upsertUserResponse(ctx, tx, teamID, response)
+	}
+	if inserted == 0 {
+		return replaceUserResponse(ctx, tx, teamID, response)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO sessions (`+sessionColumns+`, team_id)
+		VALUES ($1, $2, $3, $4, $5, $6, 1, $7, NULL, $8, $9, $10, $11)
+		ON CONFLICT (team_id, channel_id, date) DO UPDATE SET response_count = sessions.response_count + 1`,
+		session.SessionID, session.ChannelID, session.Date, session.Status, session.SummaryPosted,
+		session.AnchorTS, session.CreatedAt, session.SummaryTS, jsonb{session.GroupMembers},
+		jsonb{session.ReviewedBy}, teamID,
+	)
+	return err
+}
+
+func scanUserResponse(row scanner) (*store.UserResponse, error) {
+	var response store.UserResponse
+	err := row.Scan(&response.SessionID, &response.ChannelID, &response.Date, &response.UserID,
+		&response.UserName, jsonb{&response.Responses}, jsonb{&response.Answers}, &response.SubmittedAt,
+		&response.ReminderCount, &response.Late)
+	return &response, err
+}
+
+// GetUserResponse retrieves a user's standup response.
+func (s *Store) GetUserResponse(
+	ctx context.Context,
+	channelID, date, userID string,
+) (*store.UserResponse, error) {
+	// Validate inputs
+	if err := validateUserKey(channelID, date, userID); err != nil {
+		return nil, err
+	}
+
+	response, err := scanUserResponse(s.db.QueryRowContext(ctx, `
+		SELECT `+userResponseColumns+` FROM user_responses
+		WHERE channel_id = $1 AND date = $2 AND user_id = $3 AND team_id = $4`,
+		channelID, date, userID, store.TeamScope(ctx)))
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, &store.Error{Code: "GET_ERROR", Message: "Failed to get user response", Err: err}
+	}
+
+	return response, nil
+}
+
+// ListUserResponses lists all user responses for a session.
+func (s *Store) ListUserResponses(ctx context.Context, channelID, date string) ([]*store.UserResponse, error) {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return nil, err
+	}
+
+	return s.listUserResponses(ctx, "Failed to query user responses", `
+		SELECT `+userResponseColumns+` FROM user_responses
+		WHERE channel_id = $1 AND date = $2 AND team_id = $3 ORDER BY user_id`, channelID, date, store.TeamScope(ctx))
+}
+
+// ListUserResponsesPage lists a page of a session's responses by user ID.
+// One more row than the page holds is read to tell whether it's the last
+// page.
+func (s *Store) ListUserResponsesPage(
+	ctx context.Context,
+	channelID, date string,
+	limit int,
+	token string,
+) (*store.ResponsePage, error) {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return nil, err
+	}
+	if err := store.ValidatePageToken(token); err != nil {
+		return nil, err
+	}
+	limit = store.PageLimit(limit)
+
+	responses, err := s.listUserResponses(ctx, "Failed to query user responses", `
+		SELECT `+userResponseColumns+` FROM user_responses
+		WHERE channel_id = $1 AND date = $2 AND team_id = $3 AND user_id > $4
+		ORDER BY user_id LIMIT $5`, channelID, date, store.TeamScope(ctx), token, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &store.ResponsePage{Responses: responses}
+	if len(responses) > limit {
+		page.Responses = responses[:limit]
+		page.NextToken = page.Responses[limit-1].UserID
+	}
+	return page, nil
+}
+
+// ListChannelsUserResponses lists the responses of several channels on a
+// date in one query, keyed by channel ID.
+func (s *Store) ListChannelsUserResponses(
+	ctx context.Context,
+	channelIDs []string,
+	date string,
+) (map[string][]*store.UserResponse, error) {
+	// Validate inputs
+	for _, channelID := range channelIDs {
+		if err := validateSessionKey(channelID, date); err != nil {
+			return nil, err
+		}
+	}
+
+	list, err := s.listUserResponses(ctx, "Failed to query user responses", `
+		SELECT `+userResponseColumns+` FROM user_responses
+		WHERE channel_id = ANY($1) AND date = $2 AND team_id = $3 ORDER BY channel_id, user_id`,
+		pq.Array(channelIDs), date, store.TeamScope(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make(map[string][]*store.UserResponse, len(channelIDs))
+	for _, response := range list {
+		responses[response.ChannelID] = append(responses[response.ChannelID], response)
+	}
+	return responses, nil
+}
+
+// ListUserResponsesByUser lists a user's responses in a channel between two
+// dates, newest first.
+func (s *Store) ListUserResponsesByUser(
+	ctx context.Context,
+	channelID, userID, startDate, endDate string,
+) ([]*store.UserResponse, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateUserID(userID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+	if err := validation.ValidateDate(startDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid start date", Err: err}
+	}
+	if err := validation.ValidateDate(endDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid end date", Err: err}
+	}
+
+	return s.listUserResponses(ctx, "Failed to query user history", `
+		SELECT `+userResponseColumns+` FROM user_responses
+		WHERE channel_id = $1 AND user_id = $2 AND date BETWEEN $3 AND $4 AND team_id = $5
+		ORDER BY date DESC`, channelID, userID, startDate, endDate, store.TeamScope(ctx))
+}
+
+// ListResponsesForUserOnDate lists a user's responses on a date in every
+// channel of the workspace, by channel ID.
+func (s *Store) ListResponsesForUserOnDate(ctx context.Context, userID, date string) ([]*store.UserResponse, error) {
+	// Validate inputs
+	if err := validation.ValidateUserID(userID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+	if err := validation.ValidateDate(date); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid date", Err: err}
+	}
+
+	return s.listUserResponses(ctx, "Failed to query user responses", `
+		SELECT `+userResponseColumns+` FROM user_responses
+		WHERE user_id = $1 AND date = $2 AND team_id = $3 ORDER BY channel_id`,
+		userID, date, store.TeamScope(ctx))
+}
+
+// SearchUserResponses returns the responses matching query, newest first.
+func (s *Store) SearchUserResponses(ctx context.Context, query *store.ResponseQuery) ([]*store.UserResponse, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	stmt := `
+		SELECT ` + userResponseColumns + ` FROM user_responses
+		WHERE channel_id = $1 AND date BETWEEN $2 AND $3 AND team_id = $4`
+	args := []any{query.ChannelID, query.StartDate, query.EndDate, store.TeamScope(ctx)}
+
+	if query.UserID != "" {
+		args = append(args, query.UserID)
+		stmt += fmt.Sprintf(" AND user_id = $%d", len(args))
+	}
+	for _, keyword := range query.Keywords() {
+		args = append(args, "%"+likeEscaper.Replace(keyword)+"%")
+		stmt += fmt.Sprintf(` AND (SELECT string_agg(value, E'\n') FROM jsonb_each_text(responses)) ILIKE $%d`, len(args))
+	}
+
+	stmt += " ORDER BY date DESC, user_id"
+	if query.Limit > 0 {
+		args = append(args, query.Limit)
+		stmt += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return s.listUserResponses(ctx, "Failed to search user responses", stmt, args...)
+}
+
+// ListSessionResponses lists all user responses for a session ID.
+func (s *Store) ListSessionResponses(ctx context.Context, sessionID string) ([]*store.UserResponse, error) {
+	// Validate inputs
+	if err := validation.ValidateSessionID(sessionID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid session ID", Err: err}
+	}
+
+	return s.listUserResponses(ctx, "Failed to query session responses", `
+		SELECT `+userResponseColumns+` FROM user_responses
+		WHERE session_id = $1 ORDER BY user_id`, sessionID)
+}
+
+func (s *Store) listUserResponses(
+	ctx context.Context,
+	message, query string,
+	args ...any,
+) ([]*store.UserResponse, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: message, Err: err}
+	}
+	defer rows.Close()
+
+	var responses []*store.UserResponse
+	for rows.Next() {
+		response, err := scanUserResponse(rows)
+		if err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: message, Err: err}
+		}
+		responses = append(responses, response)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: message, Err: err}
+	}
+
+	return responses, nil
+}
+
+// IncrementReminderCount increments the reminder count on a user's response.
+// Users who have not responded have no row, so nothing is counted for them.
+func (s *Store) IncrementReminderCount(ctx context.Context, channelID, date, userID string) error {
+	// Validate inputs
+	if err := validateUserKey(channelID, date, userID); err != nil {
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE user_responses SET reminder_count = reminder_count + 1
+		WHERE channel_id = $1 AND date = $2 AND user_id = $3 AND team_id = $4`,
+		channelID, date, userID, store.TeamScope(ctx))
+	if err != nil {
+		return &store.Error{Code: "UPDATE_ERROR", Message: "Failed to increment reminder count", Err: err}
+	}
+
+	return nil
+}
+
+// SaveReminder saves a reminder record.
+func (s *Store) SaveReminder(ctx context.Context, reminder *store.Reminder) error {
+	// Validate inputs
+	if err := validateUserKey(reminder.ChannelID, reminder.Date, reminder.UserID); err != nil {
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO reminders (channel_id, date, user_id, time, sent_at, message_ts, snoozed_until, team_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (team_id, channel_id, date, user_id, time) DO UPDATE SET
+			sent_at = EXCLUDED.sent_at,
+			message_ts = EXCLUDED.message_ts,
+			snoozed_until = EXCLUDED.snoozed_until`,
+		reminder.ChannelID, reminder.Date, reminder.UserID, reminder.Time, reminder.SentAt,
+		reminder.MessageTS, reminder.SnoozedUntil, store.TeamScope(ctx),
+	)
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save reminder", Err: err}
+	}
+
+	return nil
+}
+
+// ListReminders lists all reminders for a channel and date.
+func (s *Store) ListReminders(ctx context.Context, channelID, date string) ([]*store.Reminder, error) {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT channel_id, date, user_id, time, sent_at, message_ts, snoozed_until FROM reminders
+		WHERE channel_id = $1 AND date = $2 AND team_id = $3 ORDER BY user_id, time`, channelID, date, store.TeamScope(ctx))
+	if err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query reminders", Err: err}
+	}
+	defer rows.Close()
+
+	var reminders []*store.Reminder
+	for rows.Next() {
+		var (
+			reminder     store.Reminder
+			snoozedUntil sql.NullTime
+		)
+		if err := rows.Scan(&reminder.ChannelID, &reminder.Date, &reminder.UserID, &reminder.Time,
+			&reminder.SentAt, &reminder.MessageTS, &snoozedUntil); err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query reminders", Err: err}
+		}
+		if snoozedUntil.Valid {
+			reminder.SnoozedUntil = &snoozedUntil.Time
+		}
+		reminders = append(reminders, &reminder)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query reminders", Err: err}
+	}
+
+	return reminders, nil
+}
+
+// SaveFailedReminder records a reminder that couldn't be delivered.
+func (s *Store) SaveFailedReminder(ctx context.Context, failure *store.FailedReminder) error {
+	// Validate inputs
+	if err := validateUserKey(failure.ChannelID, failure.Date, failure.UserID); err != nil {
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO failed_reminders (team_id, channel_id, date, user_id, time, error_code, error, failed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (team_id, channel_id, date, user_id, time) DO UPDATE SET
+			error_code = EXCLUDED.error_code,
+			error = EXCLUDED.error,
+			failed_at = EXCLUDED.failed_at`,
+		store.TeamScope(ctx), failure.ChannelID, failure.Date, failure.UserID, failure.Time,
+		failure.ErrorCode, failure.Error, failure.FailedAt,
+	)
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save failed reminder", Err: err}
+	}
+
+	return nil
+}
+
+// ListFailedReminders lists a channel's failed reminders between two dates,
+// newest first.
+func (s *Store) ListFailedReminders(ctx context.Context, channelID, startDate, endDate string) ([]*store.FailedReminder, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateDate(startDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid start date", Err: err}
+	}
+	if err := validation.ValidateDate(endDate); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid end date", Err: err}
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT channel_id, date, user_id, time, error_code, error, failed_at FROM failed_reminders
+		WHERE channel_id = $1 AND date BETWEEN $2 AND $3 AND team_id = $4
+		ORDER BY date DESC, time DESC, user_id`, channelID, startDate, endDate, store.TeamScope(ctx))
+	if err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query failed reminders", Err: err}
+	}
+	defer rows.Close()
+
+	var failures []*store.FailedReminder
+	for rows.Next() {
+		var failure store.FailedReminder
+		if err := rows.Scan(&failure.ChannelID, &failure.Date, &failure.UserID, &failure.Time,
+			&failure.ErrorCode, &failure.Error, &failure.FailedAt); err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query failed reminders", Err: err}
+		}
+		failures = append(failures, &failure)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query failed reminders", Err: err}
+	}
+
+	return failures, nil
+}
+
+// SaveSkippedResponse records that a user skipped a day's standup.
+func (s *Store) SaveSkippedResponse(ctx context.Context, skip *store.SkippedResponse) error {
+	// Validate inputs
+	if err := validateUserKey(skip.ChannelID, skip.Date, skip.UserID); err != nil {
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO skipped_responses (channel_id, date, user_id, reason, skipped_at, team_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (team_id, channel_id, date, user_id) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			skipped_at = EXCLUDED.skipped_at`,
+		skip.ChannelID, skip.Date, skip.UserID, skip.Reason, skip.SkippedAt, store.TeamScope(ctx),
+	)
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save skipped response", Err: err}
+	}
+
+	return nil
+}
+
+// ListSkippedResponses lists all users who skipped a session.
+func (s *Store) ListSkippedResponses(ctx context.Context, channelID, date string) ([]*store.SkippedResponse, error) {
+	// Validate inputs
+	if err := validateSessionKey(channelID, date); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT channel_id, date, user_id, reason, skipped_at FROM skipped_responses
+		WHERE channel_id = $1 AND date = $2 AND team_id = $3 ORDER BY user_id`, channelID, date, store.TeamScope(ctx))
+	if err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query skipped responses", Err: err}
+	}
+	defer rows.Close()
+
+	var skips []*store.SkippedResponse
+	for rows.Next() {
+		var skip store.SkippedResponse
+		if err := rows.Scan(&skip.ChannelID, &skip.Date, &skip.UserID, &skip.Reason, &skip.SkippedAt); err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query skipped responses", Err: err}
+		}
+		skips = append(skips, &skip)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query skipped responses", Err: err}
+	}
+
+	return skips, nil
+}
+
+// SaveEscalationRecord records an escalation, returning ErrAlreadyExists if
+// the user was already escalated for that day.
+func (s *Store) SaveEscalationRecord(ctx context.Context, record *store.EscalationRecord) error {
+	// Validate inputs
+	if err := validateUserKey(record.ChannelID, record.Date, record.UserID); err != nil {
+		return err
+	}
+
+	return s.insertOnce(ctx, "Failed to save escalation record", `
+		INSERT INTO escalations (channel_id, date, user_id, action, reminder_count, escalated_at, team_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (team_id, channel_id, date, user_id) DO NOTHING`,
+		record.ChannelID, record.Date, record.UserID, record.Action, record.ReminderCount, record.EscalatedAt,
+		store.TeamScope(ctx),
+	)
+}
+
+// DeleteEscalationRecord releases a user's escalation claim for a day.
+func (s *Store) DeleteEscalationRecord(ctx context.Context, channelID, date, userID string) error {
+	// Validate inputs
+	if err := validateUserKey(channelID, date, userID); err != nil {
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM escalations
+		WHERE channel_id = $1 AND date = $2 AND user_id = $3 AND team_id = $4`,
+		channelID, date, userID, store.TeamScope(ctx))
+	if err != nil {
+		return &store.Error{Code: "DELETE_ERROR", Message: "Failed to delete escalation record", Err: err}
+	}
+
+	return nil
+}
+
+// SaveDigestRecord records a posted digest, returning ErrAlreadyExists if the
+// digest for that period was already recorded.
+func (s *Store) SaveDigestRecord(ctx context.Context, record *store.DigestRecord) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(record.ChannelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	return s.insertOnce(ctx, "Failed to save digest record", `
+		INSERT INTO digests (channel_id, period, period_key, posted_at, team_id)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (team_id, channel_id, period, period_key) DO NOTHING`,
+		record.ChannelID, record.Period, record.PeriodKey, record.PostedAt, store.TeamScope(ctx),
+	)
+}
+
+// DeleteDigestRecord releases a digest's claim on its period.
+func (s *Store) DeleteDigestRecord(
+	ctx context.Context, channelID string, period store.DigestPeriod, periodKey string,
+) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM digests
+		WHERE channel_id = $1 AND period = $2 AND period_key = $3 AND team_id = $4`,
+		channelID, period, periodKey, store.TeamScope(ctx))
+	if err != nil {
+		return &store.Error{Code: "DELETE_ERROR", Message: "Failed to delete digest record", Err: err}
+	}
+
+	return nil
+}
+
+// SaveScheduledRun saves when a channel's scheduled task next runs.
+func (s *Store) SaveScheduledRun(ctx context.Context, run *store.ScheduledRun) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(run.ChannelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if run.Task == "" {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Task is required"}
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO scheduled_runs (team_id, channel_id, task, clock, timezone, next_run_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (team_id, channel_id, task) DO UPDATE SET
+			clock = EXCLUDED.clock,
+			timezone = EXCLUDED.timezone,
+			next_run_at = EXCLUDED.next_run_at,
+			updated_at = EXCLUDED.updated_at`,
+		store.TeamScope(ctx), run.ChannelID, run.Task, run.Clock, run.Timezone, run.NextRunAt, run.UpdatedAt,
+	)
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save scheduled run", Err: err}
+	}
+
+	return nil
+}
+
+// ListScheduledRuns lists when a channel's scheduled tasks next run, by task.
+func (s *Store) ListScheduledRuns(ctx context.Context, channelID string) ([]*store.ScheduledRun, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT channel_id, task, clock, timezone, next_run_at, updated_at FROM scheduled_runs
+		WHERE channel_id = $1 AND team_id = $2
+		ORDER BY task`, channelID, store.TeamScope(ctx))
+	if err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query scheduled runs", Err: err}
+	}
+	defer rows.Close()
+
+	var runs []*store.ScheduledRun
+	for rows.Next() {
+		var run store.ScheduledRun
+		if err := rows.Scan(&run.ChannelID, &run.Task, &run.Clock, &run.Timezone, &run.NextRunAt, &run.UpdatedAt); err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query scheduled runs", Err: err}
+		}
+		runs = append(runs, &run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query scheduled runs", Err: err}
+	}
+
+	return runs, nil
+}
+
+// validateHeartbeatKey validates the job and, for per-channel jobs, the
+// channel ID that identify a heartbeat.
+func validateHeartbeatKey(job, channelID string) error {
+	if job == "" {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Job is required"}
+	}
+	if channelID != "" {
+		if err := validation.ValidateChannelID(channelID); err != nil {
+			return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+		}
+	}
+	return nil
+}
+
+// SaveHeartbeat saves a recurring job's health.
+func (s *Store) SaveHeartbeat(ctx context.Context, heartbeat *store.Heartbeat) error {
+	// Validate inputs
+	if err := validateHeartbeatKey(heartbeat.Job, heartbeat.ChannelID); err != nil {
+		return err
+	}
+
+	// A job that never succeeded has no success time
+	var lastSuccessAt *time.Time
+	if !heartbeat.LastSuccessAt.IsZero() {
+		lastSuccessAt = &heartbeat.LastSuccessAt
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO heartbeats
+			(team_id, job, channel_id, last_success_at, failures, last_error, alerted_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (team_id, job, channel_id) DO UPDATE SET
+			last_success_at = EXCLUDED.last_success_at,
+			failures = EXCLUDED.failures,
+			last_error = EXCLUDED.last_error,
+			alerted_at = EXCLUDED.alerted_at,
+			updated_at = EXCLUDED.updated_at`,
+		heartbeat.TeamID, heartbeat.Job, heartbeat.ChannelID, lastSuccessAt,
+		heartbeat.Failures, heartbeat.LastError, heartbeat.AlertedAt, heartbeat.UpdatedAt,
+	)
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save heartbeat", Err: err}
+	}
+
+	return nil
+}
+
+// GetHeartbeat retrieves a recurring job's health.
+func (s *Store) GetHeartbeat(ctx context.Context, teamID, job, channelID string) (*store.Heartbeat, error) {
+	// Validate inputs
+	if err := validateHeartbeatKey(job, channelID); err != nil {
+		return nil, err
+	}
+
+	heartbeat, err := scanHeartbeat(s.db.QueryRowContext(ctx, `
+		SELECT `+heartbeatColumns+` FROM heartbeats
+		WHERE team_id = $1 AND job = $2 AND channel_id = $3`, teamID, job, channelID))
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, &store.Error{Code: "GET_ERROR", Message: "Failed to get heartbeat", Err: err}
+	}
+
+	return heartbeat, nil
+}
+
+// ListHeartbeats lists the heartbeats of every workspace.
+func (s *Store) ListHeartbeats(ctx context.Context) ([]*store.Heartbeat, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+heartbeatColumns+` FROM heartbeats
+		ORDER BY job, team_id, channel_id`)
+	if err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query heartbeats", Err: err}
+	}
+	defer rows.Close()
+
+	var list []*store.Heartbeat
+	for rows.Next() {
+		heartbeat, err := scanHeartbeat(rows)
+		if err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query heartbeats", Err: err}
+		}
+		list = append(list, heartbeat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query heartbeats", Err: err}
+	}
+
+	return list, nil
+}
+
+func scanHeartbeat(row scanner) (*store.Heartbeat, error) {
+	var (
+		heartbeat     store.Heartbeat
+		lastSuccessAt sql.NullTime
+		alertedAt     sql.NullTime
+	)
+	err := row.Scan(&heartbeat.TeamID, &heartbeat.Job, &heartbeat.ChannelID, &lastSuccessAt, &heartbeat.Failures,
+		&heartbeat.LastError, &alertedAt, &heartbeat.UpdatedAt)
+	heartbeat.LastSuccessAt = lastSuccessAt.Time
+	if alertedAt.Valid {
+		heartbeat.AlertedAt = &alertedAt.Time
+	}
+	return &heartbeat, err
+}
+
+// SaveStreak saves a user's streak in a channel.
+func (s *Store) SaveStreak(ctx context.Context, streak *store.Streak) error {
+	// Validate inputs
+	if err := validation.ValidateChannelID(streak.ChannelID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if err := validation.ValidateUserID(streak.UserID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO streaks (team_id, channel_id, user_id, current, longest, last_date, missed, broken, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (team_id, channel_id, user_id) DO UPDATE SET
+			current = EXCLUDED.current,
+			longest = EXCLUDED.longest,
+			last_date = EXCLUDED.last_date,
+			missed = EXCLUDED.missed,
+			broken = EXCLUDED.broken,
+			updated_at = EXCLUDED.updated_at`,
+		store.TeamScope(ctx), streak.ChannelID, streak.UserID, streak.Current, streak.Longest,
+		streak.LastDate, streak.Missed, streak.Broken, streak.UpdatedAt,
+	)
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save streak", Err: err}
+	}
+
+	return nil
+}
+
+// ListStreaks lists the streaks of a channel's users, by user.
+func (s *Store) ListStreaks(ctx context.Context, channelID string) ([]*store.Streak, error) {
+	// Validate inputs
+	if err := validation.ValidateChannelID(channelID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT channel_id, user_id, current, longest, last_date, missed, broken, updated_at FROM streaks
+		WHERE channel_id = $1 AND team_id = $2
+		ORDER BY user_id`, channelID, store.TeamScope(ctx))
+	if err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query streaks", Err: err}
+	}
+	defer rows.Close()
+
+	var streaks []*store.Streak
+	for rows.Next() {
+		var streak store.Streak
+		if err := rows.Scan(&streak.ChannelID, &streak.UserID, &streak.Current, &streak.Longest,
+			&streak.LastDate, &streak.Missed, &streak.Broken, &streak.UpdatedAt); err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query streaks", Err: err}
+		}
+		streaks = append(streaks, &streak)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query streaks", Err: err}
+	}
+
+	return streaks, nil
+}
+
+// SaveUserPreferences saves a user's reminder preferences.
+func (s *Store) SaveUserPreferences(ctx context.Context, prefs *store.UserPreferences) error {
+	// Validate inputs
+	if err := validation.ValidateUserID(prefs.UserID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO user_preferences (team_id, user_id, reminders_off, reminder_time, delivery, locale, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (team_id, user_id) DO UPDATE SET
+			reminders_off = EXCLUDED.reminders_off,
+			reminder_time = EXCLUDED.reminder_time,
+			delivery = EXCLUDED.delivery,
+			locale = EXCLUDED.locale,
+			updated_at = EXCLUDED.updated_at`,
+		store.TeamScope(ctx), prefs.UserID, prefs.RemindersOff, prefs.ReminderTime, prefs.Delivery, prefs.Locale,
+		prefs.UpdatedAt,
+	)
+	if err != nil {
+		return &store.Error{Code: "PUT_ERROR", Message: "Failed to save user preferences", Err: err}
+	}
+
+	return nil
+}
+
+// GetUserPreferences retrieves a user's reminder preferences.
+func (s *Store) GetUserPreferences(ctx context.Context, userID string) (*store.UserPreferences, error) {
+	// Validate inputs
+	if err := validation.ValidateUserID(userID); err != nil {
+		return nil, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	var prefs store.UserPreferences
+	err := s.db.QueryRowContext(ctx, `
+		SELECT user_id, reminders_off, reminder_time, delivery, locale, updated_at FROM user_preferences
+		WHERE team_id = $1 AND user_id = $2`, store.TeamScope(ctx), userID,
+	).Scan(&prefs.UserID, &prefs.RemindersOff, &prefs.ReminderTime, &prefs.Delivery, &prefs.Locale, &prefs.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, &store.Error{Code: "GET_ERROR", Message: "Failed to get user preferences", Err: err}
+	}
+
+	return &prefs, nil
+}
+
+// ListUserPreferences lists the saved preferences of every user.
+func (s *Store) ListUserPreferences(ctx context.Context) ([]*store.UserPreferences, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT user_id, reminders_off, reminder_time, delivery, locale, updated_at FROM user_preferences
+		WHERE team_id = $1 ORDER BY user_id`, store.TeamScope(ctx))
+	if err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query user preferences", Err: err}
+	}
+	defer rows.Close()
+
+	var list []*store.UserPreferences
+	for rows.Next() {
+		var prefs store.UserPreferences
+		if err := rows.Scan(&prefs.UserID, &prefs.RemindersOff, &prefs.ReminderTime, &prefs.Delivery,
+			&prefs.Locale, &prefs.UpdatedAt); err != nil {
+			return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query user preferences", Err: err}
+		}
+		list = append(list, &prefs)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &store.Error{Code: "QUERY_ERROR", Message: "Failed to query user preferences", Err: err}
+	}
+
+	return list, nil
+}
+
+// DeleteUserData deletes a user's records in ctx's workspace, table by
+// table, deleteBatchSize rows at a time.
+func (s *Store) DeleteUserData(ctx context.Context, userID string) (int, error) {
+	// Validate inputs
+	if err := validation.ValidateUserID(userID); err != nil {
+		return 0, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+	}
+
+	deleted := 0
+	for _, table := range userDataTables {
+		for {
+			result, err := s.db.ExecContext(ctx, `
+				DELETE FROM `+table+` WHERE ctid IN (
+					SELECT ctid FROM `+table+` WHERE team_id = $1 AND user_id = $2 LIMIT $3
+				)`, store.TeamScope(ctx), userID, deleteBatchSize,
+			)
+			if err != nil {
+				return deleted, &store.Error{Code: "DELETE_ERROR", Message: "Failed to delete from " + table, Err: err}
+			}
+			n, err := result.RowsAffected()
+			if err != nil {
+				return deleted, &store.Error{Code: "DELETE_ERROR", Message: "Failed to read affected rows", Err: err}
+			}
+			deleted += int(n)
+			if n < deleteBatchSize {
+				break
+			}
+		}
+	}
+
+	return deleted, nil
+}
+
+// SaveProcessedEvent claims a Slack event for handling, returning
+// ErrAlreadyExists if it was recorded within processedEventRetention. Older
+// records are overwritten, mirroring the DynamoDB store's TTL.
+func (s *Store) SaveProcessedEvent(ctx context.Context, event *store.ProcessedEvent) error {
+	// Validate inputs
+	if err := validation.ValidateEventID(event.EventID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid event ID", Err: err}
+	}
+
+	return s.insertOnce(ctx, "Failed to save processed event", `
+		INSERT INTO processed_events (event_id, team_id, event_type, processed_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id) DO UPDATE SET
+			team_id = EXCLUDED.team_id,
+			event_type = EXCLUDED.event_type,
+			processed_at = EXCLUDED.processed_at
+		WHERE processed_events.processed_at < $5`,
+		event.EventID, event.TeamID, event.EventType, event.ProcessedAt,
+		event.ProcessedAt.Add(-processedEventRetention),
+	)
+}
+
+// DeleteProcessedEvent releases a Slack event's claim.
+func (s *Store) DeleteProcessedEvent(ctx context.Context, eventID string) error {
+	// Validate inputs
+	if err := validation.ValidateEventID(eventID); err != nil {
+		return &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid event ID", Err: err}
+	}
+
+	_, err := s.db.ExecContext(ctx, `DELETE FROM processed_events WHERE event_id = $1`, eventID)
+	if err != nil {
+		return &store.Error{Code: "DELETE_ERROR", Message: "Failed to delete processed event", Err: err}
+	}
+
+	return nil
+}
+
+// IsEventProcessed reports whether a Slack event was already handled.
+func (s *Store) IsEventProcessed(ctx context.Context, eventID string) (bool, error) {
+	// Validate inputs
+	if err := validation.ValidateEventID(eventID); err != nil {
+		return false, &store.Error{Code: "VALIDATION_ERROR", Message: "Invalid event ID", Err: err}
+	}
+
+	var processed bool
+	err := s.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM processed_events WHERE event_id = $1 AND processed_at >= $2)`,
+		eventID, time.Now().Add(-processedEventRetention),
+	).Scan(&processed)
+	if err != nil {
+		return false, &store.Error{Code: "GET_ERROR", Message: "Failed to get processed event", Err: err}
+	}
+
+	return processed, nil
+}
+
+// GetPendingSessions gets all unfinished sessions dated on or before currentTime.
+func (s *Store) GetPendingSessions(ctx context.Context, currentTime time.Time) ([]*store.Session, error) {
+	return s.listSessions(ctx, "Failed to query pending sessions", `
+		SELECT `+sessionColumns+` FROM sessions
+		WHERE status <> $1 AND date <= $2
+		ORDER BY date, channel_id`, store.SessionCompleted, currentTime.Format("2006-01-02"))
+}
+
+// GetUsersWithoutResponse gets users who haven't submitted responses.
+func (s *Store) GetUsersWithoutResponse(
+	ctx context.Context,
+	channelID, date string,
+	userIDs []string,
+) ([]string, error) {
+	// Validate channel ID and date (user IDs are validated individually below)
+	if err := validateSessionKey(channelID, date); err != nil {
+		return nil, err
+	}
+
+	// Validate all user IDs
+	for _, userID := range userIDs {
+		if err := validation.ValidateUserID(userID); err != nil {
+			return nil, &store.Error{
+				Code:    "VALIDATION_ERROR",
+				Message: fmt.Sprintf("Invalid user ID: %s", userID),
+				Err:     err,
+			}
+		}
+	}
+
+	// Get all responses for the session
+	responses, err := s.ListUserResponses(ctx, channelID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a map of users who have responded
+	respondedUsers := make(map[string]bool)
+	for _, resp := range responses {
+		respondedUsers[resp.UserID] = true
+	}
+
+	// Find users who haven't responded
+	var missingUsers []string
+	for _, userID := range userIDs {
+		if !respondedUsers[userID] {
+			missingUsers = append(missingUsers, userID)
+		}
+	}
+
+	return missingUsers, nil
+}
@@ -0,0 +1,141 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/store/postgres"
+	"github.com/synaptiq/standup-bot/internal/store/postgres/pgtest"
+	"github.com/synaptiq/standup-bot/internal/store/storetest"
+)
+
+// TestStoreContract runs the store conformance tests against PostgreSQL,
+// each in its own database.
+func TestStoreContract(t *testing.T) {
+	dsn := pgtest.NewServer(t)
+
+	storetest.TestStore(t, func(t *testing.T) store.Store {
+		return postgres.NewStore(pgtest.NewDatabase(t, dsn))
+	})
+}
+
+// race runs write concurrently and returns each call's error.
+func race(write func() error) []error {
+	const racers = 8
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, racers)
+	)
+	for i := range racers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = write()
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// assertOneSucceeded checks that exactly one racer succeeded, and that the
+// others failed with want.
+func assertOneSucceeded(t *testing.T, errs []error, want error) {
+	t.Helper()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.True(t, errors.Is(err, want), "unexpected error: %v", err)
+	}
+	assert.Equal(t, 1, succeeded)
+}
+
+func TestConditionalWrites(t *testing.T) {
+	ctx := context.Background()
+	dsn := pgtest.NewServer(t)
+	newSession := func() *store.Session {
+		return &store.Session{
+			SessionID: "3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f",
+			ChannelID: "C1234567890",
+			Date:      "2024-01-15",
+			CreatedAt: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+		}
+	}
+
+	// Writes that may happen once succeed once, however many race
+	writes := map[string]func(t *testing.T, s store.Store) func() error{
+		"CreateSession": func(_ *testing.T, s store.Store) func() error {
+			return func() error { return s.CreateSession(ctx, newSession()) }
+		},
+		"SaveEscalationRecord": func(_ *testing.T, s store.Store) func() error {
+			return func() error {
+				return s.SaveEscalationRecord(ctx, &store.EscalationRecord{
+					ChannelID: "C1234567890", Date: "2024-01-15", UserID: "U1234567890",
+				})
+			}
+		},
+		"SaveDigestRecord": func(_ *testing.T, s store.Store) func() error {
+			return func() error {
+				return s.SaveDigestRecord(ctx, &store.DigestRecord{
+					ChannelID: "C1234567890", Period: store.DigestWeekly, PeriodKey: "2024-W03",
+				})
+			}
+		},
+		"SaveProcessedEvent": func(_ *testing.T, s store.Store) func() error {
+			return func() error {
+				return s.SaveProcessedEvent(ctx, &store.ProcessedEvent{EventID: "Ev0123ABCD", ProcessedAt: time.Now()})
+			}
+		},
+		"ClaimSummary": func(t *testing.T, s store.Store) func() error {
+			require.NoError(t, s.CreateSession(ctx, newSession()))
+			return func() error { return s.ClaimSummary(ctx, "C1234567890", "2024-01-15") }
+		},
+	}
+
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			s := postgres.NewStore(pgtest.NewDatabase(t, dsn))
+			assertOneSucceeded(t, race(write(t, s)), store.ErrAlreadyExists)
+		})
+	}
+
+	// Of edits racing from the same form, only one replaces the response
+	t.Run("SubmitUserResponse", func(t *testing.T) {
+		s := postgres.NewStore(pgtest.NewDatabase(t, dsn))
+		session := newSession()
+		first := &store.UserResponse{
+			SessionID:   session.SessionID,
+			ChannelID:   session.ChannelID,
+			Date:        session.Date,
+			UserID:      "U1234567890",
+			Responses:   map[string]string{"question_0": "First"},
+			SubmittedAt: session.CreatedAt,
+		}
+		require.NoError(t, s.SubmitUserResponse(ctx, session, first))
+
+		errs := race(func() error {
+			edit := *first
+			edit.Responses = map[string]string{"question_0": "Edit"}
+			edit.SubmittedAt = first.SubmittedAt.Add(time.Hour)
+			edit.UnchangedSince = first.SubmittedAt
+			return s.SubmitUserResponse(ctx, session, &edit)
+		})
+		assertOneSucceeded(t, errs, store.ErrConflict)
+
+		got, err := s.GetSession(ctx, session.ChannelID, session.Date)
+		require.NoError(t, err)
+		assert.Equal(t, 1, got.ResponseCount)
+	})
+}
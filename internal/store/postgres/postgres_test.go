@@ -0,0 +1,549 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+func newMockStore(t *testing.T) (*Store, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return NewStore(db).(*Store), mock
+}
+
+func TestMigrate(t *testing.T) {
+	s, mock := newMockStore(t)
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0001_init").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE workspaces")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0001_init").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0002_team_scope").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE sessions ADD COLUMN team_id")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0002_team_scope").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0003_channel_admins").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE channel_configs ADD COLUMN admins")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0003_channel_admins").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0004_user_history").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE INDEX user_responses_user_idx")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0004_user_history").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0005_deactivated_users").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE channel_configs ADD COLUMN deactivated_users")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0005_deactivated_users").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0006_user_preferences").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE user_preferences")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0006_user_preferences").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0007_workspace_integrations").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE workspaces ADD COLUMN integrations")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0007_workspace_integrations").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0008_failed_reminders").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE failed_reminders")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0008_failed_reminders").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0009_late_submissions").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE sessions ADD COLUMN summary_ts")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0009_late_submissions").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0010_scheduled_runs").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE scheduled_runs")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0010_scheduled_runs").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0011_enterprise_installs").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE workspaces ADD COLUMN enterprise_id")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0011_enterprise_installs").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0012_user_groups").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE channel_configs ADD COLUMN user_groups")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0012_user_groups").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0013_summary_reviews").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE sessions ADD COLUMN reviewed_by")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0013_summary_reviews").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0014_user_locales").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE user_preferences ADD COLUMN locale")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0014_user_locales").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0015_streaks").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE streaks")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0015_streaks").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0016_archived_channels").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE channel_configs ADD COLUMN archived_at")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0016_archived_channels").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0017_heartbeats").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE heartbeats")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0017_heartbeats").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0018_channel_config_versions").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE channel_configs ADD COLUMN version")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0018_channel_config_versions").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+		WithArgs("0019_user_day_responses").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE INDEX user_responses_user_date_idx")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs("0019_user_day_responses").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, Migrate(context.Background(), s.db))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Applied migrations are skipped
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	for _, version := range []string{
+		"0001_init", "0002_team_scope", "0003_channel_admins", "0004_user_history", "0005_deactivated_users",
+		"0006_user_preferences", "0007_workspace_integrations", "0008_failed_reminders",
+		"0009_late_submissions", "0010_scheduled_runs", "0011_enterprise_installs", "0012_user_groups",
+		"0013_summary_reviews", "0014_user_locales", "0015_streaks", "0016_archived_channels", "0017_heartbeats",
+		"0018_channel_config_versions", "0019_user_day_responses",
+	} {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM schema_migrations")).
+			WithArgs(version).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+	}
+
+	require.NoError(t, Migrate(context.Background(), s.db))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateSession(t *testing.T) {
+	ctx := context.Background()
+	s, mock := newMockStore(t)
+
+	session := &store.Session{
+		SessionID: "3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f",
+		ChannelID: "C1234567890",
+		Date:      "2024-01-15",
+		Status:    store.SessionPending,
+		CreatedAt: time.Now(),
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO sessions")).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, s.CreateSession(ctx, session))
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO sessions")).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.Equal(t, store.ErrAlreadyExists, s.CreateSession(ctx, session))
+
+	session.ChannelID = "invalid"
+	err := s.CreateSession(ctx, session)
+	var storeErr *store.Error
+	require.ErrorAs(t, err, &storeErr)
+	assert.Equal(t, "VALIDATION_ERROR", storeErr.Code)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSession(t *testing.T) {
+	ctx := context.Background()
+	s, mock := newMockStore(t)
+
+	columns := []string{"session_id", "channel_id", "date", "status", "summary_posted", "anchor_ts",
+		"response_count", "created_at", "completed_at", "summary_ts", "group_members", "reviewed_by"}
+	createdAt := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM sessions")).
+		WithArgs("C1234567890", "2024-01-15", "").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(
+			"3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f", "C1234567890", "2024-01-15", "in_progress", false, "1700000000.000100",
+			3, createdAt, nil, "", []byte(`["U2222222222"]`), []byte(`["U3333333333"]`)))
+
+	session, err := s.GetSession(ctx, "C1234567890", "2024-01-15")
+	require.NoError(t, err)
+	assert.Equal(t, store.SessionInProgress, session.Status)
+	assert.Equal(t, 3, session.ResponseCount)
+	assert.Equal(t, "1700000000.000100", session.AnchorTS)
+	assert.Nil(t, session.CompletedAt)
+	assert.Equal(t, []string{"U2222222222"}, session.GroupMembers)
+	assert.Equal(t, []string{"U3333333333"}, session.ReviewedBy)
+
+	// Workspaces sharing a deployment only see their own sessions
+	mock.ExpectQuery(regexp.QuoteMeta("FROM sessions")).
+		WithArgs("C1234567890", "2024-01-15", "T1234567890").
+		WillReturnError(sql.ErrNoRows)
+
+	teamCtx := context.WithValue(ctx, botcontext.TeamIDKey, "T1234567890")
+	_, err = s.GetSession(teamCtx, "C1234567890", "2024-01-15")
+	assert.Equal(t, store.ErrNotFound, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetChannelConfig(t *testing.T) {
+	s, mock := newMockStore(t)
+
+	columns := []string{"team_id", "channel_id", "channel_name", "enabled", "schedule", "users", "admins",
+		"templates", "questions", "updated_at", "deactivated_users", "user_groups", "archived_at", "version"}
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM channel_configs")).
+		WithArgs("T1234567890", "C1234567890").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(
+			"T1234567890", "C1234567890", "engineering", true,
+			[]byte(`{"Timezone": "America/New_York", "SummaryTime": "10:00", "ActiveDays": ["Mon", "Tue"]}`),
+			[]byte(`["U1234567890"]`), []byte(`["U0987654321"]`), []byte(`{}`), []byte(`["What did you do?"]`),
+			time.Now(), []byte(`["U1111111111"]`), []byte(`["S1234567890"]`), nil, 4))
+
+	config, err := s.GetChannelConfig(context.Background(), "T1234567890", "C1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", config.Schedule.Timezone)
+	assert.Equal(t, []string{"Mon", "Tue"}, config.Schedule.ActiveDays)
+	assert.Equal(t, []string{"U1234567890"}, config.Users)
+	assert.Equal(t, []string{"U0987654321"}, config.Admins)
+	assert.Equal(t, []string{"U1111111111"}, config.DeactivatedUsers)
+	assert.Equal(t, []string{"S1234567890"}, config.UserGroups)
+	assert.Equal(t, []string{"What did you do?"}, config.Questions)
+	assert.Equal(t, int64(4), config.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveChannelConfig(t *testing.T) {
+	s, mock := newMockStore(t)
+	config := &store.ChannelConfig{TeamID: "T1234567890", ChannelID: "C1234567890", Version: 4}
+
+	// The config is replaced only at the version it was read at
+	mock.ExpectExec(regexp.QuoteMeta("WHERE channel_configs.version = $14")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, s.SaveChannelConfig(context.Background(), config))
+	assert.Equal(t, int64(5), config.Version)
+
+	mock.ExpectExec(regexp.QuoteMeta("WHERE channel_configs.version = $14")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version FROM channel_configs")).
+		WithArgs("T1234567890", "C1234567890").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(7))
+	err := s.SaveChannelConfig(context.Background(), config)
+	require.ErrorIs(t, err, store.ErrConflict)
+	var conflict *store.ConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, int64(5), conflict.Expected)
+	assert.Equal(t, int64(7), conflict.Current)
+	assert.Equal(t, int64(5), config.Version)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListUserResponsesByUser(t *testing.T) {
+	s, mock := newMockStore(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE channel_id = $1 AND user_id = $2 AND date BETWEEN $3 AND $4")).
+		WithArgs("C1234567890", "U1234567890", "2024-01-01", "2024-01-31", "").
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	responses, err := s.ListUserResponsesByUser(context.Background(),
+		"C1234567890", "U1234567890", "2024-01-01", "2024-01-31")
+	require.NoError(t, err)
+	assert.Empty(t, responses)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListUserResponsesPage(t *testing.T) {
+	s, mock := newMockStore(t)
+
+	columns := []string{"session_id", "channel_id", "date", "user_id", "user_name", "responses", "answers",
+		"submitted_at", "reminder_count", "late"}
+	rows := sqlmock.NewRows(columns)
+	for _, userID := range []string{"U2222222222", "U3333333333", "U4444444444"} {
+		rows.AddRow("3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f", "C1234567890", "2024-01-15", userID, "",
+			[]byte(`{}`), nil, time.Now(), 0, false)
+	}
+
+	// One row past the page shows there's another
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE channel_id = $1 AND date = $2 AND team_id = $3 AND user_id > $4")).
+		WithArgs("C1234567890", "2024-01-15", "", "U1111111111", 3).
+		WillReturnRows(rows)
+
+	page, err := s.ListUserResponsesPage(context.Background(), "C1234567890", "2024-01-15", 2, "U1111111111")
+	require.NoError(t, err)
+	require.Len(t, page.Responses, 2)
+	assert.Equal(t, "U3333333333", page.NextToken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = s.ListUserResponsesPage(context.Background(), "C1234567890", "2024-01-15", 2, "not-a-token")
+	assert.Error(t, err)
+}
+
+func TestSearchUserResponses(t *testing.T) {
+	s, mock := newMockStore(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE channel_id = $1 AND date BETWEEN $2 AND $3 AND team_id = $4 AND user_id = $5 AND "+
+		"(SELECT string_agg(value, E'\\n') FROM jsonb_each_text(responses)) ILIKE $6")).
+		WithArgs("C1234567890", "2024-01-01", "2024-01-31", "", "U1234567890", `%100\%%`, 10).
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	responses, err := s.SearchUserResponses(context.Background(), &store.ResponseQuery{
+		ChannelID: "C1234567890",
+		UserID:    "U1234567890",
+		StartDate: "2024-01-01",
+		EndDate:   "2024-01-31",
+		Text:      "100%",
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, responses)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubmitUserResponse(t *testing.T) {
+	ctx := context.Background()
+
+	session := &store.Session{
+		SessionID: "3f2c1a9e-8b7d-4c6e-9f01-2a3b4c5d6e7f",
+		ChannelID: "C1234567890",
+		Date:      "2024-01-15",
+		Status:    store.SessionInProgress,
+		CreatedAt: time.Now(),
+	}
+	response := &store.UserResponse{
+		SessionID:   session.SessionID,
+		ChannelID:   "C1234567890",
+		Date:        "2024-01-15",
+		UserID:      "U1234567890",
+		UserName:    "alice",
+		Responses:   map[string]string{"yesterday": "Reviewed PRs"},
+		SubmittedAt: time.Now(),
+	}
+
+	t.Run("first response counts", func(t *testing.T) {
+		s, mock := newMockStore(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_responses")).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("response_count = sessions.response_count + 1")).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, s.SubmitUserResponse(ctx, session, response))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("edit replaces without counting", func(t *testing.T) {
+		s, mock := newMockStore(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DO NOTHING")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("DO UPDATE SET")).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, s.SubmitUserResponse(ctx, session, response))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("edit from a stale form conflicts", func(t *testing.T) {
+		s, mock := newMockStore(t)
+
+		stale := *response
+		stale.UnchangedSince = time.Now().Add(-time.Hour)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DO NOTHING")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("AND submitted_at <= $12")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, s.SubmitUserResponse(ctx, session, &stale), store.ErrConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("response from another session", func(t *testing.T) {
+		s, mock := newMockStore(t)
+
+		other := *response
+		other.Date = "2024-01-16"
+		assert.Error(t, s.SubmitUserResponse(ctx, session, &other))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSaveProcessedEvent(t *testing.T) {
+	ctx := context.Background()
+	s, mock := newMockStore(t)
+
+	event := &store.ProcessedEvent{EventID: "Ev1234567890", ProcessedAt: time.Now()}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO processed_events")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, s.SaveProcessedEvent(ctx, event))
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO processed_events")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.Equal(t, store.ErrAlreadyExists, s.SaveProcessedEvent(ctx, event))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteUserData(t *testing.T) {
+	ctx := context.WithValue(context.Background(), botcontext.TeamIDKey, "T1234567890")
+	s, mock := newMockStore(t)
+
+	// Full batches are followed by another until one comes up short
+	for _, table := range userDataTables {
+		deleted := []int64{0}
+		if table == "user_responses" {
+			deleted = []int64{deleteBatchSize, 3}
+		}
+		for _, n := range deleted {
+			mock.ExpectExec(regexp.QuoteMeta("DELETE FROM "+table+" WHERE ctid IN")).
+				WithArgs("T1234567890", "U1234567890", deleteBatchSize).
+				WillReturnResult(sqlmock.NewResult(0, n))
+		}
+	}
+
+	deleted, err := s.DeleteUserData(ctx, "U1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, deleteBatchSize+3, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = s.DeleteUserData(ctx, "not-a-user")
+	assert.Error(t, err)
+}
@@ -2,7 +2,11 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"time"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/apperr"
 )
 
 // Store defines the interface for data persistence.
@@ -11,39 +15,139 @@ type Store interface {
 	SaveWorkspaceConfig(ctx context.Context, config *WorkspaceConfig) error
 	GetWorkspaceConfig(ctx context.Context, teamID string) (*WorkspaceConfig, error)
 
-	// Channel configuration operations
+	// Channel configuration operations. SaveChannelConfig saves config if
+	// the stored one is still at config.Version, returning a *ConflictError
+	// otherwise, and sets config.Version to the version saved
 	SaveChannelConfig(ctx context.Context, config *ChannelConfig) error
 	GetChannelConfig(ctx context.Context, teamID, channelID string) (*ChannelConfig, error)
 	ListChannelConfigs(ctx context.Context, teamID string) ([]*ChannelConfig, error)
+	// ListActiveChannelConfigs leaves out disabled and archived channels
 	ListActiveChannelConfigs(ctx context.Context) ([]*ChannelConfig, error)
+	// DisableChannelConfig turns a channel's standups off, keeping its
+	// config. ArchiveChannelConfig pauses them while the Slack channel is
+	// archived and UnarchiveChannelConfig resumes them. Each returns
+	// ErrNotFound for channels without a config
+	DisableChannelConfig(ctx context.Context, teamID, channelID string) error
+	ArchiveChannelConfig(ctx context.Context, teamID, channelID string, archivedAt time.Time) error
+	UnarchiveChannelConfig(ctx context.Context, teamID, channelID string) error
 
 	// Session operations
 	CreateSession(ctx context.Context, session *Session) error
 	GetSession(ctx context.Context, channelID, date string) (*Session, error)
 	UpdateSessionStatus(ctx context.Context, channelID, date string, status SessionStatus) error
-	MarkSummaryPosted(ctx context.Context, channelID, date string) error
+	// ClaimSummary marks the session's summary posted before it's posted,
+	// returning ErrAlreadyExists if it already was, so only one caller posts
+	// it. ReleaseSummary undoes a claim when posting fails
+	ClaimSummary(ctx context.Context, channelID, date string) error
+	ReleaseSummary(ctx context.Context, channelID, date string) error
+	MarkSummaryPosted(ctx context.Context, channelID, date, summaryTS string) error
+	SetSessionAnchor(ctx context.Context, channelID, date, anchorTS string) error
+	// MarkSessionReviewed adds userID to the session's ReviewedBy, unless
+	// they already reviewed it
+	MarkSessionReviewed(ctx context.Context, channelID, date, userID string) error
+	ListSessions(ctx context.Context, channelID, startDate, endDate string) ([]*Session, error)
+	// GetSessionWithResponses returns ErrNotFound when there is no session
+	GetSessionWithResponses(ctx context.Context, channelID, date string) (*Session, []*UserResponse, error)
+	// GetSessions leaves out channels without a session on date
+	GetSessions(ctx context.Context, channelIDs []string, date string) (map[string]*Session, error)
 
 	// User response operations
 	SaveUserResponse(ctx context.Context, response *UserResponse) error
+	SubmitUserResponse(ctx context.Context, session *Session, response *UserResponse) error
 	GetUserResponse(ctx context.Context, channelID, date, userID string) (*UserResponse, error)
 	ListUserResponses(ctx context.Context, channelID, date string) ([]*UserResponse, error)
+	// ListUserResponsesPage lists up to limit of a session's responses by
+	// user ID, after those of the page token came from; see ResponsePage
+	ListUserResponsesPage(ctx context.Context, channelID, date string, limit int, token string) (*ResponsePage, error)
+	ListChannelsUserResponses(ctx context.Context, channelIDs []string, date string) (map[string][]*UserResponse, error)
+	ListSessionResponses(ctx context.Context, sessionID string) ([]*UserResponse, error)
+	ListUserResponsesByUser(ctx context.Context, channelID, userID, startDate, endDate string) ([]*UserResponse, error)
+	// ListResponsesForUserOnDate lists a user's responses on a date in every
+	// channel of the workspace, by channel ID
+	ListResponsesForUserOnDate(ctx context.Context, userID, date string) ([]*UserResponse, error)
+	// SearchUserResponses returns matching responses, newest first
+	SearchUserResponses(ctx context.Context, query *ResponseQuery) ([]*UserResponse, error)
 	IncrementReminderCount(ctx context.Context, channelID, date, userID string) error
 
 	// Reminder operations
 	SaveReminder(ctx context.Context, reminder *Reminder) error
 	ListReminders(ctx context.Context, channelID, date string) ([]*Reminder, error)
+	SaveFailedReminder(ctx context.Context, failure *FailedReminder) error
+	// ListFailedReminders returns failures between the dates, newest first
+	ListFailedReminders(ctx context.Context, channelID, startDate, endDate string) ([]*FailedReminder, error)
+
+	// Skip operations
+	SaveSkippedResponse(ctx context.Context, skip *SkippedResponse) error
+	ListSkippedResponses(ctx context.Context, channelID, date string) ([]*SkippedResponse, error)
+
+	// Escalation operations. SaveEscalationRecord claims a user's
+	// escalation for the day; DeleteEscalationRecord releases the claim when
+	// escalating fails. Deleting a missing record isn't an error
+	SaveEscalationRecord(ctx context.Context, record *EscalationRecord) error
+	DeleteEscalationRecord(ctx context.Context, channelID, date, userID string) error
+
+	// Digest operations. SaveDigestRecord claims a digest's period;
+	// DeleteDigestRecord releases the claim when posting fails. Deleting a
+	// missing record isn't an error
+	SaveDigestRecord(ctx context.Context, record *DigestRecord) error
+	DeleteDigestRecord(ctx context.Context, channelID string, period DigestPeriod, periodKey string) error
+
+	// Schedule operations
+	SaveScheduledRun(ctx context.Context, run *ScheduledRun) error
+	ListScheduledRuns(ctx context.Context, channelID string) ([]*ScheduledRun, error)
+
+	// Heartbeat operations. Heartbeats belong to the deployment rather than
+	// a workspace, so they're keyed by their TeamID, not ctx's
+	SaveHeartbeat(ctx context.Context, heartbeat *Heartbeat) error
+	GetHeartbeat(ctx context.Context, teamID, job, channelID string) (*Heartbeat, error)
+	// ListHeartbeats lists the heartbeats of every workspace, by job
+	ListHeartbeats(ctx context.Context) ([]*Heartbeat, error)
+
+	// Streak operations
+	SaveStreak(ctx context.Context, streak *Streak) error
+	ListStreaks(ctx context.Context, channelID string) ([]*Streak, error)
+
+	// User preference operations
+	SaveUserPreferences(ctx context.Context, prefs *UserPreferences) error
+	GetUserPreferences(ctx context.Context, userID string) (*UserPreferences, error)
+	ListUserPreferences(ctx context.Context) ([]*UserPreferences, error)
+
+	// User data operations. DeleteUserData erases a user's responses,
+	// skips, reminders, failed reminders, escalations, streaks and
+	// preferences in ctx's workspace, e.g. for a GDPR erasure request, and
+	// returns how many records it deleted. Sessions keep their response
+	// counts
+	DeleteUserData(ctx context.Context, userID string) (int, error)
+
+	// Event idempotency operations. SaveProcessedEvent claims an event,
+	// returning ErrAlreadyExists if it was already claimed;
+	// DeleteProcessedEvent releases the claim when handling the event fails.
+	// Deleting a missing record isn't an error
+	SaveProcessedEvent(ctx context.Context, event *ProcessedEvent) error
+	DeleteProcessedEvent(ctx context.Context, eventID string) error
+	IsEventProcessed(ctx context.Context, eventID string) (bool, error)
 
 	// Query operations
 	GetPendingSessions(ctx context.Context, currentTime time.Time) ([]*Session, error)
 	GetUsersWithoutResponse(ctx context.Context, channelID, date string, userIDs []string) ([]string, error)
 }
 
+// TeamScope returns the workspace ctx was scoped to with WithTeamID, or ""
+// when the deployment serves a single workspace. Stores key channel-level
+// records by it, so workspaces sharing a deployment never read or overwrite
+// each other's sessions and responses.
+func TeamScope(ctx context.Context) string {
+	teamID, _ := ctx.Value(botcontext.TeamIDKey).(string)
+	return teamID
+}
+
 // Errors.
 var (
 	ErrNotFound        = &Error{Code: "NOT_FOUND", Message: "Item not found"}
 	ErrAlreadyExists   = &Error{Code: "ALREADY_EXISTS", Message: "Item already exists"}
 	ErrInvalidInput    = &Error{Code: "INVALID_INPUT", Message: "Invalid input provided"}
 	ErrOperationFailed = &Error{Code: "OPERATION_FAILED", Message: "Operation failed"}
+	ErrConflict        = &Error{Code: "CONFLICT", Message: "Item changed since it was read"}
 )
 
 // Error represents a store-specific error.
@@ -63,3 +167,41 @@ func (e *Error) Error() string {
 func (e *Error) Unwrap() error {
 	return e.Err
 }
+
+// ConflictError is returned when saving a channel config that was changed
+// since it was read. It matches ErrConflict with errors.Is.
+type ConflictError struct {
+	TeamID    string
+	ChannelID string
+	Expected  int64 // The version the save expected
+	Current   int64 // The stored version
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("channel config %s changed since it was read: version %d, expected %d",
+		e.ChannelID, e.Current, e.Expected)
+}
+
+// Is matches ErrConflict, so callers needn't tell conflicts apart.
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// ErrorKind classifies the conflict for the user.
+func (e *ConflictError) ErrorKind() apperr.Kind {
+	return apperr.KindConflict
+}
+
+// ErrorKind classifies e for the user by its code.
+func (e *Error) ErrorKind() apperr.Kind {
+	switch e.Code {
+	case ErrNotFound.Code:
+		return apperr.KindNotFound
+	case ErrAlreadyExists.Code, ErrConflict.Code:
+		return apperr.KindConflict
+	case ErrInvalidInput.Code:
+		return apperr.KindInvalid
+	default:
+		return apperr.KindInternal
+	}
+}
@@ -0,0 +1,1052 @@
+// Package storetest checks that a store.Store implementation behaves the way
+// the rest of the bot relies on, so every backend can run the same suite.
+//
+// A backend's tests call TestStore with a function returning an empty store:
+//
+//	func TestStoreContract(t *testing.T) {
+//		storetest.TestStore(t, func(t *testing.T) store.Store { return NewStore() })
+//	}
+package storetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// Fixtures shared by the tests. IDs pass the validation every store applies.
+const (
+	teamID    = "T1234567890"
+	otherTeam = "T0987654321"
+	channelID = "C1234567890"
+	otherChan = "C0987654321"
+	alice     = "U1111111111"
+	bob       = "U2222222222"
+	carol     = "U3333333333"
+	day       = "2024-01-15"
+	nextDay   = "2024-01-16"
+)
+
+// base is a time every backend stores without losing precision.
+var base = time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+// Factory returns an empty store for one test.
+type Factory func(t *testing.T) store.Store
+
+// TestStore runs the conformance tests, each against a new store from
+// newStore.
+func TestStore(t *testing.T, newStore Factory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, s store.Store)
+	}{
+		{"Workspaces", testWorkspaces},
+		{"ChannelConfigs", testChannelConfigs},
+		{"Sessions", testSessions},
+		{"SubmitUserResponse", testSubmitUserResponse},
+		{"UserResponses", testUserResponses},
+		{"UserResponsePages", testUserResponsePages},
+		{"SearchUserResponses", testSearchUserResponses},
+		{"Reminders", testReminders},
+		{"FailedReminders", testFailedReminders},
+		{"SkippedResponses", testSkippedResponses},
+		{"OnceOnlyRecords", testOnceOnlyRecords},
+		{"ScheduledRuns", testScheduledRuns},
+		{"Streaks", testStreaks},
+		{"Heartbeats", testHeartbeats},
+		{"UserPreferences", testUserPreferences},
+		{"DeleteUserData", testDeleteUserData},
+		{"ProcessedEvents", testProcessedEvents},
+		{"PendingSessions", testPendingSessions},
+		{"TeamScope", testTeamScope},
+		{"Validation", testValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStore(t))
+		})
+	}
+}
+
+// sessionID returns a valid session ID numbered n.
+func sessionID(n int) string {
+	return fmt.Sprintf("3f2c1a9e-8b7d-4c6e-9f01-%012d", n)
+}
+
+func newSession(n int, date string) *store.Session {
+	return &store.Session{
+		SessionID: sessionID(n),
+		ChannelID: channelID,
+		Date:      date,
+		Status:    store.SessionPending,
+		CreatedAt: base,
+	}
+}
+
+func newResponse(session *store.Session, userID, answer string) *store.UserResponse {
+	return &store.UserResponse{
+		SessionID:   session.SessionID,
+		ChannelID:   session.ChannelID,
+		Date:        session.Date,
+		UserID:      userID,
+		UserName:    userID,
+		Responses:   map[string]string{"question_0": answer},
+		SubmittedAt: base,
+	}
+}
+
+func userIDs(responses []*store.UserResponse) []string {
+	ids := make([]string, 0, len(responses))
+	for _, response := range responses {
+		ids = append(ids, response.UserID)
+	}
+	return ids
+}
+
+func channelIDs(responses []*store.UserResponse) []string {
+	ids := make([]string, 0, len(responses))
+	for _, response := range responses {
+		ids = append(ids, response.ChannelID)
+	}
+	return ids
+}
+
+func dates(responses []*store.UserResponse) []string {
+	list := make([]string, 0, len(responses))
+	for _, response := range responses {
+		list = append(list, response.Date)
+	}
+	return list
+}
+
+func testWorkspaces(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	_, err := s.GetWorkspaceConfig(ctx, teamID)
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	config := &store.WorkspaceConfig{
+		TeamID:      teamID,
+		TeamName:    "Acme",
+		BotToken:    "xoxb-test",
+		InstalledAt: base,
+		Integrations: store.Integrations{
+			Jira: &store.JiraIntegration{BaseURL: "https://acme.atlassian.net", Projects: []string{"ENG"}},
+		},
+	}
+	require.NoError(t, s.SaveWorkspaceConfig(ctx, config))
+
+	got, err := s.GetWorkspaceConfig(ctx, teamID)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", got.TeamName)
+	assert.Equal(t, "xoxb-test", got.BotToken)
+	assert.WithinDuration(t, base, got.InstalledAt, 0)
+	require.NotNil(t, got.Integrations.Jira)
+	assert.Equal(t, []string{"ENG"}, got.Integrations.Jira.Projects)
+	assert.Nil(t, got.Integrations.GitHub)
+
+	// Saving again replaces the config
+	config.TeamName = "Acme Corp"
+	require.NoError(t, s.SaveWorkspaceConfig(ctx, config))
+	got, err = s.GetWorkspaceConfig(ctx, teamID)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Corp", got.TeamName)
+
+	// Org-wide installs are saved under the enterprise ID
+	require.NoError(t, s.SaveWorkspaceConfig(ctx, &store.WorkspaceConfig{
+		TeamID:              "E1234567890",
+		IsEnterpriseInstall: true,
+		BotToken:            "xoxb-org",
+	}))
+	got, err = s.GetWorkspaceConfig(ctx, "E1234567890")
+	require.NoError(t, err)
+	assert.True(t, got.IsEnterpriseInstall)
+}
+
+func testChannelConfigs(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	_, err := s.GetChannelConfig(ctx, teamID, channelID)
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	config := &store.ChannelConfig{
+		TeamID:      teamID,
+		ChannelID:   channelID,
+		ChannelName: "engineering",
+		Enabled:     true,
+		Schedule: store.ScheduleConfig{
+			Timezone:       "America/New_York",
+			SummaryTime:    "10:00",
+			ReminderTimes:  []string{"09:00", "09:30"},
+			ActiveDays:     []string{"Mon", "Tue"},
+			UserActiveDays: map[string][]string{bob: {"Mon"}},
+			Escalation:     &store.EscalationPolicy{AfterReminders: 2, Action: store.EscalatePublicNudge},
+			UserTags:       map[string]map[string]string{alice: {"project": "payments"}},
+		},
+		Users:     []string{alice, bob},
+		Templates: map[string]string{"reminder": "Hi {{.UserName}}"},
+		Questions: []string{"Yesterday?", "Today?"},
+	}
+	require.NoError(t, s.SaveChannelConfig(ctx, config))
+	assert.Equal(t, int64(1), config.Version)
+	require.NoError(t, s.SaveChannelConfig(ctx, &store.ChannelConfig{
+		TeamID: teamID, ChannelID: otherChan, ChannelName: "design",
+	}))
+	require.NoError(t, s.SaveChannelConfig(ctx, &store.ChannelConfig{
+		TeamID: otherTeam, ChannelID: channelID, ChannelName: "sales", Enabled: true,
+	}))
+
+	got, err := s.GetChannelConfig(ctx, teamID, channelID)
+	require.NoError(t, err)
+	assert.Equal(t, "engineering", got.ChannelName)
+	assert.True(t, got.Enabled)
+	assert.Equal(t, "America/New_York", got.Schedule.Timezone)
+	assert.Equal(t, []string{"09:00", "09:30"}, got.Schedule.ReminderTimes)
+	assert.Equal(t, map[string][]string{bob: {"Mon"}}, got.Schedule.UserActiveDays)
+	assert.Equal(t, map[string]map[string]string{alice: {"project": "payments"}}, got.Schedule.UserTags)
+	require.NotNil(t, got.Schedule.Escalation)
+	assert.Equal(t, 2, got.Schedule.Escalation.AfterReminders)
+	assert.Equal(t, []string{alice, bob}, got.Users)
+	assert.Equal(t, "Hi {{.UserName}}", got.Templates["reminder"])
+	assert.Equal(t, []string{"Yesterday?", "Today?"}, got.Questions)
+	assert.Equal(t, int64(1), got.Version)
+
+	// Channels are kept per workspace
+	got, err = s.GetChannelConfig(ctx, otherTeam, channelID)
+	require.NoError(t, err)
+	assert.Equal(t, "sales", got.ChannelName)
+
+	configs, err := s.ListChannelConfigs(ctx, teamID)
+	require.NoError(t, err)
+	names := make([]string, 0, len(configs))
+	for _, config := range configs {
+		names = append(names, config.ChannelName)
+	}
+	assert.ElementsMatch(t, []string{"engineering", "design"}, names)
+
+	// Only enabled channels are active, in every workspace
+	configs, err = s.ListActiveChannelConfigs(ctx)
+	require.NoError(t, err)
+	names = names[:0]
+	for _, config := range configs {
+		names = append(names, config.ChannelName)
+	}
+	assert.ElementsMatch(t, []string{"engineering", "sales"}, names)
+
+	// Archived channels are paused until they're unarchived
+	archivedAt := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, s.ArchiveChannelConfig(ctx, teamID, channelID, archivedAt))
+	got, err = s.GetChannelConfig(ctx, teamID, channelID)
+	require.NoError(t, err)
+	require.NotNil(t, got.ArchivedAt)
+	assert.True(t, archivedAt.Equal(*got.ArchivedAt))
+	assert.True(t, got.Enabled)
+	assert.Equal(t, int64(2), got.Version)
+	configs, err = s.ListActiveChannelConfigs(ctx)
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "sales", configs[0].ChannelName)
+
+	require.NoError(t, s.UnarchiveChannelConfig(ctx, teamID, channelID))
+	configs, err = s.ListActiveChannelConfigs(ctx)
+	require.NoError(t, err)
+	assert.Len(t, configs, 2)
+
+	// Disabling keeps the config
+	require.NoError(t, s.DisableChannelConfig(ctx, teamID, channelID))
+	got, err = s.GetChannelConfig(ctx, teamID, channelID)
+	require.NoError(t, err)
+	assert.False(t, got.Enabled)
+	assert.Nil(t, got.ArchivedAt)
+	assert.Equal(t, []string{alice, bob}, got.Users)
+	configs, err = s.ListActiveChannelConfigs(ctx)
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "sales", configs[0].ChannelName)
+
+	// Unarchiving a disabled channel doesn't enable it
+	require.NoError(t, s.ArchiveChannelConfig(ctx, teamID, channelID, archivedAt))
+	require.NoError(t, s.UnarchiveChannelConfig(ctx, teamID, channelID))
+	configs, err = s.ListActiveChannelConfigs(ctx)
+	require.NoError(t, err)
+	assert.Len(t, configs, 1)
+
+	// Saving a config as read replaces it, and it can be saved again
+	config, err = s.GetChannelConfig(ctx, teamID, channelID)
+	require.NoError(t, err)
+	config.Enabled = true
+	require.NoError(t, s.SaveChannelConfig(ctx, config))
+	config.Enabled = false
+	require.NoError(t, s.SaveChannelConfig(ctx, config))
+	configs, err = s.ListActiveChannelConfigs(ctx)
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "sales", configs[0].ChannelName)
+
+	// A config changed since it was read isn't replaced
+	got, err = s.GetChannelConfig(ctx, teamID, channelID)
+	require.NoError(t, err)
+	stale := got.Clone()
+	stale.Users = []string{alice}
+	require.NoError(t, s.DisableChannelConfig(ctx, teamID, channelID))
+	err = s.SaveChannelConfig(ctx, stale)
+	require.ErrorIs(t, err, store.ErrConflict)
+	var conflict *store.ConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, got.Version, conflict.Expected)
+	assert.Equal(t, got.Version+1, conflict.Current)
+	assert.Equal(t, got.Version, stale.Version)
+
+	fresh, err := s.GetChannelConfig(ctx, teamID, channelID)
+	require.NoError(t, err)
+	fresh.Users = []string{bob}
+	require.NoError(t, s.SaveChannelConfig(ctx, fresh))
+	got, err = s.GetChannelConfig(ctx, teamID, channelID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{bob}, got.Users)
+	assert.Equal(t, fresh.Version, got.Version)
+
+	// Nor is one created meanwhile by a new config
+	require.ErrorIs(t, s.SaveChannelConfig(ctx, &store.ChannelConfig{TeamID: teamID, ChannelID: otherChan}),
+		store.ErrConflict)
+
+	// Channels without a config aren't created
+	require.ErrorIs(t, s.DisableChannelConfig(ctx, teamID, "C9999999999"), store.ErrNotFound)
+	require.ErrorIs(t, s.ArchiveChannelConfig(ctx, teamID, "C9999999999", archivedAt), store.ErrNotFound)
+	require.ErrorIs(t, s.UnarchiveChannelConfig(ctx, teamID, "C9999999999"), store.ErrNotFound)
+	_, err = s.GetChannelConfig(ctx, teamID, "C9999999999")
+	require.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func testSessions(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	_, err := s.GetSession(ctx, channelID, day)
+	require.ErrorIs(t, err, store.ErrNotFound)
+	_, _, err = s.GetSessionWithResponses(ctx, channelID, day)
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	// Updating a missing session doesn't create it
+	require.ErrorIs(t, s.UpdateSessionStatus(ctx, channelID, day, store.SessionInProgress), store.ErrNotFound)
+	require.ErrorIs(t, s.ClaimSummary(ctx, channelID, day), store.ErrNotFound)
+	require.ErrorIs(t, s.ReleaseSummary(ctx, channelID, day), store.ErrNotFound)
+	require.ErrorIs(t, s.MarkSummaryPosted(ctx, channelID, day, "1700000000.000200"), store.ErrNotFound)
+	require.ErrorIs(t, s.SetSessionAnchor(ctx, channelID, day, "1700000000.000100"), store.ErrNotFound)
+	require.ErrorIs(t, s.MarkSessionReviewed(ctx, channelID, day, alice), store.ErrNotFound)
+	_, err = s.GetSession(ctx, channelID, day)
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	session := newSession(1, day)
+	session.GroupMembers = []string{carol}
+	require.NoError(t, s.CreateSession(ctx, session))
+	require.ErrorIs(t, s.CreateSession(ctx, session), store.ErrAlreadyExists)
+
+	require.NoError(t, s.SetSessionAnchor(ctx, channelID, day, "1700000000.000100"))
+	require.NoError(t, s.UpdateSessionStatus(ctx, channelID, day, store.SessionCompleted))
+	// Only one caller claims the summary, until it's released
+	require.NoError(t, s.ClaimSummary(ctx, channelID, day))
+	require.ErrorIs(t, s.ClaimSummary(ctx, channelID, day), store.ErrAlreadyExists)
+	require.NoError(t, s.ReleaseSummary(ctx, channelID, day))
+	require.NoError(t, s.ClaimSummary(ctx, channelID, day))
+	require.NoError(t, s.MarkSummaryPosted(ctx, channelID, day, "1700000000.000200"))
+	require.ErrorIs(t, s.ClaimSummary(ctx, channelID, day), store.ErrAlreadyExists)
+	require.NoError(t, s.MarkSessionReviewed(ctx, channelID, day, alice))
+	require.NoError(t, s.MarkSessionReviewed(ctx, channelID, day, bob))
+	require.NoError(t, s.MarkSessionReviewed(ctx, channelID, day, alice))
+
+	got, err := s.GetSession(ctx, channelID, day)
+	require.NoError(t, err)
+	assert.Equal(t, sessionID(1), got.SessionID)
+	assert.Equal(t, store.SessionCompleted, got.Status)
+	assert.NotNil(t, got.CompletedAt)
+	assert.True(t, got.SummaryPosted)
+	assert.Equal(t, "1700000000.000100", got.AnchorTS)
+	assert.Equal(t, "1700000000.000200", got.SummaryTS)
+	assert.Equal(t, []string{alice, bob}, got.ReviewedBy)
+	assert.Equal(t, []string{carol}, got.GroupMembers)
+	assert.WithinDuration(t, base, got.CreatedAt, 0)
+
+	got, responses, err := s.GetSessionWithResponses(ctx, channelID, day)
+	require.NoError(t, err)
+	assert.Equal(t, sessionID(1), got.SessionID)
+	assert.Empty(t, responses)
+
+	// Lists span several days, newest first
+	for i := 2; i <= 12; i++ {
+		session := newSession(i, base.AddDate(0, 0, i-1).Format(time.DateOnly))
+		require.NoError(t, s.CreateSession(ctx, session))
+	}
+	sessions, err := s.ListSessions(ctx, channelID, "2024-01-16", "2024-01-25")
+	require.NoError(t, err)
+	require.Len(t, sessions, 10)
+	assert.Equal(t, "2024-01-25", sessions[0].Date)
+	assert.Equal(t, "2024-01-16", sessions[9].Date)
+
+	byChannel, err := s.GetSessions(ctx, []string{channelID, otherChan}, day)
+	require.NoError(t, err)
+	require.Len(t, byChannel, 1)
+	assert.Equal(t, sessionID(1), byChannel[channelID].SessionID)
+}
+
+func testSubmitUserResponse(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	session := newSession(1, day)
+
+	// The first response creates the session, and each user counts once
+	require.NoError(t, s.SubmitUserResponse(ctx, session, newResponse(session, alice, "Wrote tests")))
+	require.NoError(t, s.SubmitUserResponse(ctx, session, newResponse(session, bob, "Reviewed PRs")))
+
+	edit := newResponse(session, alice, "Wrote more tests")
+	edit.SubmittedAt = base.Add(time.Hour)
+	require.NoError(t, s.SubmitUserResponse(ctx, session, edit))
+
+	got, err := s.GetSession(ctx, channelID, day)
+	require.NoError(t, err)
+	assert.Equal(t, sessionID(1), got.SessionID)
+	assert.Equal(t, 2, got.ResponseCount)
+
+	response, err := s.GetUserResponse(ctx, channelID, day, alice)
+	require.NoError(t, err)
+	assert.Equal(t, "Wrote more tests", response.Responses["question_0"])
+
+	// A form opened before the last submission can't replace it
+	stale := newResponse(session, alice, "Stale")
+	stale.SubmittedAt = base.Add(2 * time.Hour)
+	stale.UnchangedSince = base.Add(30 * time.Minute)
+	require.ErrorIs(t, s.SubmitUserResponse(ctx, session, stale), store.ErrConflict)
+
+	fresh := newResponse(session, alice, "Fresh")
+	fresh.SubmittedAt = base.Add(2 * time.Hour)
+	fresh.UnchangedSince = base.Add(time.Hour)
+	require.NoError(t, s.SubmitUserResponse(ctx, session, fresh))
+
+	response, err = s.GetUserResponse(ctx, channelID, day, alice)
+	require.NoError(t, err)
+	assert.Equal(t, "Fresh", response.Responses["question_0"])
+
+	// The response must belong to the session
+	other := newResponse(newSession(2, nextDay), carol, "Wrong day")
+	require.Error(t, s.SubmitUserResponse(ctx, session, other))
+}
+
+func testUserResponses(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	_, err := s.GetUserResponse(ctx, channelID, day, alice)
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	session := newSession(1, day)
+	response := newResponse(session, bob, "Reviewed PRs")
+	response.Answers = map[string]store.Answer{
+		"question_0": {Type: "text", Value: "Reviewed PRs", Question: "Yesterday?"},
+		"blocked":    {Type: "multi_select", Values: []string{"infra", "reviews"}, QuestionID: "blocked"},
+	}
+	response.Late = true
+	require.NoError(t, s.SubmitUserResponse(ctx, session, response))
+	require.NoError(t, s.SubmitUserResponse(ctx, session, newResponse(session, alice, "Wrote tests")))
+
+	got, err := s.GetUserResponse(ctx, channelID, day, bob)
+	require.NoError(t, err)
+	assert.Equal(t, sessionID(1), got.SessionID)
+	assert.Equal(t, bob, got.UserName)
+	assert.Equal(t, "Reviewed PRs", got.Responses["question_0"])
+	assert.Equal(t, []string{"infra", "reviews"}, got.Answers["blocked"].Values)
+	assert.Equal(t, "Yesterday?", got.Answers["question_0"].Question)
+	assert.True(t, got.Late)
+	assert.WithinDuration(t, base, got.SubmittedAt, 0)
+
+	// Reminders are counted on responses, and not for users who haven't
+	// responded
+	require.NoError(t, s.IncrementReminderCount(ctx, channelID, day, bob))
+	require.NoError(t, s.IncrementReminderCount(ctx, channelID, day, bob))
+	require.NoError(t, s.IncrementReminderCount(ctx, channelID, day, carol))
+	got, err = s.GetUserResponse(ctx, channelID, day, bob)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.ReminderCount)
+	_, err = s.GetUserResponse(ctx, channelID, day, carol)
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	responses, err := s.ListUserResponses(ctx, channelID, day)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{alice, bob}, userIDs(responses))
+
+	_, responses, err = s.GetSessionWithResponses(ctx, channelID, day)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{alice, bob}, userIDs(responses))
+
+	responses, err = s.ListSessionResponses(ctx, sessionID(1))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{alice, bob}, userIDs(responses))
+
+	missing, err := s.GetUsersWithoutResponse(ctx, channelID, day, []string{alice, bob, carol})
+	require.NoError(t, err)
+	assert.Equal(t, []string{carol}, missing)
+
+	// Responses of other channels and days
+	otherSession := &store.Session{SessionID: sessionID(2), ChannelID: otherChan, Date: day, CreatedAt: base}
+	require.NoError(t, s.SubmitUserResponse(ctx, otherSession, newResponse(otherSession, carol, "Designs")))
+	tomorrow := newSession(3, nextDay)
+	require.NoError(t, s.SubmitUserResponse(ctx, tomorrow, newResponse(tomorrow, bob, "Deployed")))
+
+	byChannel, err := s.ListChannelsUserResponses(ctx, []string{channelID, otherChan}, day)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{alice, bob}, userIDs(byChannel[channelID]))
+	assert.Equal(t, []string{carol}, userIDs(byChannel[otherChan]))
+
+	responses, err = s.ListUserResponsesByUser(ctx, channelID, bob, "2024-01-01", "2024-01-31")
+	require.NoError(t, err)
+	assert.Equal(t, []string{nextDay, day}, dates(responses))
+
+	require.NoError(t, s.SubmitUserResponse(ctx, otherSession, newResponse(otherSession, bob, "Paired on designs")))
+	responses, err = s.ListResponsesForUserOnDate(ctx, bob, day)
+	require.NoError(t, err)
+	assert.Equal(t, []string{otherChan, channelID}, channelIDs(responses))
+	responses, err = s.ListResponsesForUserOnDate(ctx, carol, nextDay)
+	require.NoError(t, err)
+	assert.Empty(t, responses)
+
+	// Saving replaces a response without counting it
+	response.Responses = map[string]string{"question_0": "Edited"}
+	require.NoError(t, s.SaveUserResponse(ctx, response))
+	got, err = s.GetUserResponse(ctx, channelID, day, bob)
+	require.NoError(t, err)
+	assert.Equal(t, "Edited", got.Responses["question_0"])
+	session, err = s.GetSession(ctx, channelID, day)
+	require.NoError(t, err)
+	assert.Equal(t, 2, session.ResponseCount)
+}
+
+func testUserResponsePages(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	page, err := s.ListUserResponsesPage(ctx, channelID, day, 3, "")
+	require.NoError(t, err)
+	assert.Empty(t, page.Responses)
+	assert.Empty(t, page.NextToken)
+
+	// Seven users answer, and a user the next day
+	session := newSession(1, day)
+	var want []string
+	for i := 7; i >= 1; i-- {
+		userID := fmt.Sprintf("U%010d", i)
+		want = append([]string{userID}, want...)
+		require.NoError(t, s.SubmitUserResponse(ctx, session, newResponse(session, userID, "Wrote tests")))
+	}
+	tomorrow := newSession(2, nextDay)
+	require.NoError(t, s.SubmitUserResponse(ctx, tomorrow, newResponse(tomorrow, alice, "Deployed")))
+
+	// Pages follow each other by user ID, with no token after the last
+	var got []string
+	var sizes []int
+	token := ""
+	for {
+		page, err := s.ListUserResponsesPage(ctx, channelID, day, 3, token)
+		require.NoError(t, err)
+		got = append(got, userIDs(page.Responses)...)
+		sizes = append(sizes, len(page.Responses))
+		if page.NextToken == "" {
+			break
+		}
+		token = page.NextToken
+	}
+	assert.Equal(t, want, got)
+	assert.Equal(t, []int{3, 3, 1}, sizes)
+
+	// A page that ends with the last response is the last
+	page, err = s.ListUserResponsesPage(ctx, channelID, day, 7, "")
+	require.NoError(t, err)
+	assert.Len(t, page.Responses, 7)
+	assert.Empty(t, page.NextToken)
+
+	// Without a limit, pages hold store.DefaultPageSize responses
+	page, err = s.ListUserResponsesPage(ctx, channelID, day, 0, "")
+	require.NoError(t, err)
+	assert.Len(t, page.Responses, 7)
+
+	_, err = s.ListUserResponsesPage(ctx, channelID, day, 3, "not a token")
+	assert.Error(t, err)
+}
+
+func testSearchUserResponses(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	// Two users answer on each of twelve days
+	for i := range 12 {
+		session := newSession(i+1, base.AddDate(0, 0, i).Format(time.DateOnly))
+		answer := "Fixed bugs"
+		if i%3 == 0 {
+			answer = "Deployed the API"
+		}
+		require.NoError(t, s.SubmitUserResponse(ctx, session, newResponse(session, alice, answer)))
+		require.NoError(t, s.SubmitUserResponse(ctx, session, newResponse(session, bob, "Reviewed PRs")))
+	}
+
+	query := &store.ResponseQuery{ChannelID: channelID, StartDate: "2024-01-01", EndDate: "2024-01-31"}
+	responses, err := s.SearchUserResponses(ctx, query)
+	require.NoError(t, err)
+	assert.Len(t, responses, 24)
+
+	// Newest first, up to the limit
+	query.Limit = 5
+	responses, err = s.SearchUserResponses(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, responses, 5)
+	assert.Equal(t, []string{"2024-01-26", "2024-01-26", "2024-01-25", "2024-01-25", "2024-01-24"}, dates(responses))
+
+	query = &store.ResponseQuery{
+		ChannelID: channelID, StartDate: "2024-01-01", EndDate: "2024-01-31", Text: "deployed api",
+	}
+	responses, err = s.SearchUserResponses(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2024-01-24", "2024-01-21", "2024-01-18", day}, dates(responses))
+
+	query = &store.ResponseQuery{
+		ChannelID: channelID, UserID: bob, StartDate: "2024-01-20", EndDate: "2024-01-22", Limit: 2,
+	}
+	responses, err = s.SearchUserResponses(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2024-01-22", "2024-01-21"}, dates(responses))
+	assert.Equal(t, []string{bob, bob}, userIDs(responses))
+
+	query = &store.ResponseQuery{ChannelID: channelID, StartDate: "2024-02-01", EndDate: "2024-02-29"}
+	responses, err = s.SearchUserResponses(ctx, query)
+	require.NoError(t, err)
+	assert.Empty(t, responses)
+}
+
+func testReminders(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	reminders, err := s.ListReminders(ctx, channelID, day)
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+
+	snoozedUntil := base.Add(time.Hour)
+	require.NoError(t, s.SaveReminder(ctx, &store.Reminder{
+		ChannelID: channelID, Date: day, UserID: bob, Time: "09:00", SentAt: base, MessageTS: "1700000000.000100",
+	}))
+	require.NoError(t, s.SaveReminder(ctx, &store.Reminder{
+		ChannelID: channelID, Date: day, UserID: alice, Time: "09:00", SentAt: base, MessageTS: "1700000000.000200",
+		SnoozedUntil: &snoozedUntil,
+	}))
+	require.NoError(t, s.SaveReminder(ctx, &store.Reminder{
+		ChannelID: channelID, Date: nextDay, UserID: alice, Time: "09:00", SentAt: base,
+	}))
+
+	reminders, err = s.ListReminders(ctx, channelID, day)
+	require.NoError(t, err)
+	require.Len(t, reminders, 2)
+	byUser := make(map[string]*store.Reminder, len(reminders))
+	for _, reminder := range reminders {
+		byUser[reminder.UserID] = reminder
+	}
+	assert.Equal(t, "1700000000.000100", byUser[bob].MessageTS)
+	assert.Nil(t, byUser[bob].SnoozedUntil)
+	require.NotNil(t, byUser[alice].SnoozedUntil)
+	assert.WithinDuration(t, snoozedUntil, *byUser[alice].SnoozedUntil, 0)
+}
+
+func testFailedReminders(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	for i := range 10 {
+		require.NoError(t, s.SaveFailedReminder(ctx, &store.FailedReminder{
+			ChannelID: channelID,
+			Date:      base.AddDate(0, 0, i).Format(time.DateOnly),
+			UserID:    alice,
+			Time:      "09:00",
+			ErrorCode: "cannot_dm_bot",
+			Error:     "cannot DM bot",
+			FailedAt:  base.AddDate(0, 0, i),
+		}))
+	}
+
+	failures, err := s.ListFailedReminders(ctx, channelID, "2024-01-17", "2024-01-20")
+	require.NoError(t, err)
+	require.Len(t, failures, 4)
+	assert.Equal(t, "2024-01-20", failures[0].Date)
+	assert.Equal(t, "2024-01-17", failures[3].Date)
+	assert.Equal(t, "cannot_dm_bot", failures[0].ErrorCode)
+	assert.WithinDuration(t, base.AddDate(0, 0, 5), failures[0].FailedAt, 0)
+
+	failures, err = s.ListFailedReminders(ctx, otherChan, "2024-01-01", "2024-01-31")
+	require.NoError(t, err)
+	assert.Empty(t, failures)
+}
+
+func testSkippedResponses(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	require.NoError(t, s.SaveSkippedResponse(ctx, &store.SkippedResponse{
+		ChannelID: channelID, Date: day, UserID: bob, Reason: "Vacation", SkippedAt: base,
+	}))
+	require.NoError(t, s.SaveSkippedResponse(ctx, &store.SkippedResponse{
+		ChannelID: channelID, Date: day, UserID: alice, SkippedAt: base,
+	}))
+	require.NoError(t, s.SaveSkippedResponse(ctx, &store.SkippedResponse{
+		ChannelID: channelID, Date: nextDay, UserID: carol, SkippedAt: base,
+	}))
+
+	skips, err := s.ListSkippedResponses(ctx, channelID, day)
+	require.NoError(t, err)
+	require.Len(t, skips, 2)
+	assert.Equal(t, alice, skips[0].UserID)
+	assert.Equal(t, bob, skips[1].UserID)
+	assert.Equal(t, "Vacation", skips[1].Reason)
+}
+
+func testOnceOnlyRecords(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	escalation := &store.EscalationRecord{
+		ChannelID: channelID, Date: day, UserID: alice, Action: store.EscalatePublicNudge, ReminderCount: 2,
+		EscalatedAt: base,
+	}
+	require.NoError(t, s.SaveEscalationRecord(ctx, escalation))
+	require.ErrorIs(t, s.SaveEscalationRecord(ctx, escalation), store.ErrAlreadyExists)
+	escalation.UserID = bob
+	require.NoError(t, s.SaveEscalationRecord(ctx, escalation))
+
+	// A released claim can be claimed again
+	require.NoError(t, s.DeleteEscalationRecord(ctx, channelID, day, bob))
+	require.NoError(t, s.DeleteEscalationRecord(ctx, channelID, day, bob))
+	require.NoError(t, s.SaveEscalationRecord(ctx, escalation))
+
+	digest := &store.DigestRecord{ChannelID: channelID, Period: store.DigestWeekly, PeriodKey: "2024-W03", PostedAt: base}
+	require.NoError(t, s.SaveDigestRecord(ctx, digest))
+	require.ErrorIs(t, s.SaveDigestRecord(ctx, digest), store.ErrAlreadyExists)
+	digest.Period, digest.PeriodKey = store.DigestMonthly, "2024-01"
+	require.NoError(t, s.SaveDigestRecord(ctx, digest))
+
+	require.NoError(t, s.DeleteDigestRecord(ctx, channelID, store.DigestMonthly, "2024-01"))
+	require.NoError(t, s.DeleteDigestRecord(ctx, channelID, store.DigestMonthly, "2024-01"))
+	require.NoError(t, s.SaveDigestRecord(ctx, digest))
+	// Releasing one period leaves the others claimed
+	digest.Period, digest.PeriodKey = store.DigestWeekly, "2024-W03"
+	require.ErrorIs(t, s.SaveDigestRecord(ctx, digest), store.ErrAlreadyExists)
+}
+
+func testScheduledRuns(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	runs, err := s.ListScheduledRuns(ctx, channelID)
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+
+	for _, task := range []string{"summary", "reminder#09:30", "reminder#09:00"} {
+		require.NoError(t, s.SaveScheduledRun(ctx, &store.ScheduledRun{
+			ChannelID: channelID, Task: task, Clock: "09:00", Timezone: "UTC", NextRunAt: base, UpdatedAt: base,
+		}))
+	}
+	require.NoError(t, s.SaveScheduledRun(ctx, &store.ScheduledRun{
+		ChannelID: channelID, Task: "summary", Clock: "10:00", Timezone: "UTC",
+		NextRunAt: base.Add(time.Hour), UpdatedAt: base,
+	}))
+
+	runs, err = s.ListScheduledRuns(ctx, channelID)
+	require.NoError(t, err)
+	require.Len(t, runs, 3)
+	assert.Equal(t, "reminder#09:00", runs[0].Task)
+	assert.Equal(t, "reminder#09:30", runs[1].Task)
+	assert.Equal(t, "summary", runs[2].Task)
+	assert.Equal(t, "10:00", runs[2].Clock)
+	assert.WithinDuration(t, base.Add(time.Hour), runs[2].NextRunAt, 0)
+}
+
+func testStreaks(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	streaks, err := s.ListStreaks(ctx, channelID)
+	require.NoError(t, err)
+	assert.Empty(t, streaks)
+
+	for _, userID := range []string{bob, alice} {
+		require.NoError(t, s.SaveStreak(ctx, &store.Streak{
+			ChannelID: channelID, UserID: userID, Current: 1, Longest: 1, LastDate: day, UpdatedAt: base,
+		}))
+	}
+	require.NoError(t, s.SaveStreak(ctx, &store.Streak{
+		ChannelID: channelID, UserID: bob, Longest: 1, LastDate: nextDay, Missed: true, Broken: 1, UpdatedAt: base,
+	}))
+	require.NoError(t, s.SaveStreak(ctx, &store.Streak{
+		ChannelID: otherChan, UserID: carol, Current: 3, Longest: 3, LastDate: day, UpdatedAt: base,
+	}))
+
+	streaks, err = s.ListStreaks(ctx, channelID)
+	require.NoError(t, err)
+	require.Len(t, streaks, 2)
+	assert.Equal(t, alice, streaks[0].UserID)
+	assert.Equal(t, 1, streaks[0].Current)
+	assert.Equal(t, bob, streaks[1].UserID)
+	assert.Equal(t, 0, streaks[1].Current)
+	assert.Equal(t, 1, streaks[1].Longest)
+	assert.Equal(t, nextDay, streaks[1].LastDate)
+	assert.True(t, streaks[1].Missed)
+	assert.Equal(t, 1, streaks[1].Broken)
+}
+
+func testHeartbeats(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	heartbeats, err := s.ListHeartbeats(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, heartbeats)
+	_, err = s.GetHeartbeat(ctx, "", store.HeartbeatScheduler, "")
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	alerted := base.Add(time.Minute)
+	require.NoError(t, s.SaveHeartbeat(ctx, &store.Heartbeat{
+		Job: store.HeartbeatSummary, TeamID: teamID, ChannelID: channelID,
+		Failures: 2, LastError: "channel_not_found", AlertedAt: &alerted, UpdatedAt: base,
+	}))
+	require.NoError(t, s.SaveHeartbeat(ctx, &store.Heartbeat{
+		Job: store.HeartbeatScheduler, Failures: 1, UpdatedAt: base,
+	}))
+	require.NoError(t, s.SaveHeartbeat(ctx, &store.Heartbeat{
+		Job: store.HeartbeatScheduler, LastSuccessAt: base, UpdatedAt: base,
+	}))
+
+	got, err := s.GetHeartbeat(ctx, "", store.HeartbeatScheduler, "")
+	require.NoError(t, err)
+	assert.WithinDuration(t, base, got.LastSuccessAt, 0)
+	assert.Equal(t, 0, got.Failures)
+	assert.Nil(t, got.AlertedAt)
+
+	// Heartbeats of every workspace are listed
+	heartbeats, err = s.ListHeartbeats(ctx)
+	require.NoError(t, err)
+	require.Len(t, heartbeats, 2)
+	assert.Equal(t, store.HeartbeatScheduler, heartbeats[0].Job)
+	summary := heartbeats[1]
+	assert.Equal(t, store.HeartbeatSummary, summary.Job)
+	assert.Equal(t, teamID, summary.TeamID)
+	assert.Equal(t, channelID, summary.ChannelID)
+	assert.True(t, summary.LastSuccessAt.IsZero())
+	assert.Equal(t, 2, summary.Failures)
+	assert.Equal(t, "channel_not_found", summary.LastError)
+	require.NotNil(t, summary.AlertedAt)
+	assert.WithinDuration(t, alerted, *summary.AlertedAt, 0)
+}
+
+func testUserPreferences(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	_, err := s.GetUserPreferences(ctx, alice)
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	require.NoError(t, s.SaveUserPreferences(ctx, &store.UserPreferences{
+		UserID: bob, RemindersOff: true, UpdatedAt: base,
+	}))
+	require.NoError(t, s.SaveUserPreferences(ctx, &store.UserPreferences{
+		UserID: alice, ReminderTime: "08:30", Delivery: store.DeliverChannel, Locale: "es", UpdatedAt: base,
+	}))
+
+	prefs, err := s.GetUserPreferences(ctx, alice)
+	require.NoError(t, err)
+	assert.Equal(t, "08:30", prefs.ReminderTime)
+	assert.Equal(t, store.DeliverChannel, prefs.Delivery)
+	assert.Equal(t, "es", prefs.Locale)
+	assert.False(t, prefs.RemindersOff)
+
+	list, err := s.ListUserPreferences(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, alice, list[0].UserID)
+	assert.Equal(t, bob, list[1].UserID)
+	assert.True(t, list[1].RemindersOff)
+}
+
+func testDeleteUserData(t *testing.T, s store.Store) {
+	acme := context.WithValue(context.Background(), botcontext.TeamIDKey, teamID)
+	other := context.WithValue(context.Background(), botcontext.TeamIDKey, otherTeam)
+
+	for _, ctx := range []context.Context{acme, other} {
+		for _, userID := range []string{alice, bob} {
+			for i, date := range []string{day, nextDay} {
+				session := newSession(i+1, date)
+				require.NoError(t, s.SubmitUserResponse(ctx, session, newResponse(session, userID, "Wrote tests")))
+				require.NoError(t, s.SaveReminder(ctx, &store.Reminder{
+					ChannelID: channelID, Date: date, UserID: userID, Time: "09:00", SentAt: base,
+				}))
+			}
+			require.NoError(t, s.SaveSkippedResponse(ctx, &store.SkippedResponse{
+				ChannelID: otherChan, Date: day, UserID: userID, SkippedAt: base,
+			}))
+			require.NoError(t, s.SaveFailedReminder(ctx, &store.FailedReminder{
+				ChannelID: channelID, Date: day, UserID: userID, Time: "09:00", FailedAt: base,
+			}))
+			require.NoError(t, s.SaveEscalationRecord(ctx, &store.EscalationRecord{
+				ChannelID: channelID, Date: day, UserID: userID, Action: store.EscalatePublicNudge, EscalatedAt: base,
+			}))
+			require.NoError(t, s.SaveStreak(ctx, &store.Streak{
+				ChannelID: channelID, UserID: userID, Current: 1, Longest: 1, LastDate: day, UpdatedAt: base,
+			}))
+			require.NoError(t, s.SaveUserPreferences(ctx, &store.UserPreferences{UserID: userID, UpdatedAt: base}))
+		}
+	}
+
+	// Two responses and reminders, a skip, failed reminder, escalation,
+	// streak and preferences
+	deleted, err := s.DeleteUserData(acme, alice)
+	require.NoError(t, err)
+	assert.Equal(t, 9, deleted)
+
+	_, err = s.GetUserResponse(acme, channelID, day, alice)
+	require.ErrorIs(t, err, store.ErrNotFound)
+	_, err = s.GetUserPreferences(acme, alice)
+	require.ErrorIs(t, err, store.ErrNotFound)
+	for _, date := range []string{day, nextDay} {
+		reminders, err := s.ListReminders(acme, channelID, date)
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, bob, reminders[0].UserID)
+	}
+	skips, err := s.ListSkippedResponses(acme, otherChan, day)
+	require.NoError(t, err)
+	require.Len(t, skips, 1)
+	assert.Equal(t, bob, skips[0].UserID)
+	failures, err := s.ListFailedReminders(acme, channelID, day, day)
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, bob, failures[0].UserID)
+	streaks, err := s.ListStreaks(acme, channelID)
+	require.NoError(t, err)
+	require.Len(t, streaks, 1)
+	assert.Equal(t, bob, streaks[0].UserID)
+	// The escalation is gone, so it can be recorded again
+	require.NoError(t, s.SaveEscalationRecord(acme, &store.EscalationRecord{
+		ChannelID: channelID, Date: day, UserID: alice, Action: store.EscalatePublicNudge, EscalatedAt: base,
+	}))
+
+	// Other users and workspaces keep theirs
+	_, err = s.GetUserResponse(acme, channelID, day, bob)
+	require.NoError(t, err)
+	_, err = s.GetUserResponse(other, channelID, nextDay, alice)
+	require.NoError(t, err)
+	_, err = s.GetUserPreferences(other, alice)
+	require.NoError(t, err)
+
+	// Nothing is left to delete
+	deleted, err = s.DeleteUserData(acme, carol)
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+}
+
+func testProcessedEvents(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	processed, err := s.IsEventProcessed(ctx, "Ev0123ABCD")
+	require.NoError(t, err)
+	assert.False(t, processed)
+
+	event := &store.ProcessedEvent{
+		EventID: "Ev0123ABCD", TeamID: teamID, EventType: "message", ProcessedAt: time.Now().UTC(),
+	}
+	require.NoError(t, s.SaveProcessedEvent(ctx, event))
+	require.ErrorIs(t, s.SaveProcessedEvent(ctx, event), store.ErrAlreadyExists)
+
+	processed, err = s.IsEventProcessed(ctx, "Ev0123ABCD")
+	require.NoError(t, err)
+	assert.True(t, processed)
+
+	// A released event can be claimed again
+	require.NoError(t, s.DeleteProcessedEvent(ctx, "Ev0123ABCD"))
+	require.NoError(t, s.DeleteProcessedEvent(ctx, "Ev0123ABCD"))
+	processed, err = s.IsEventProcessed(ctx, "Ev0123ABCD")
+	require.NoError(t, err)
+	assert.False(t, processed)
+	require.NoError(t, s.SaveProcessedEvent(ctx, event))
+}
+
+func testPendingSessions(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	require.NoError(t, s.CreateSession(ctx, newSession(1, day)))
+	require.NoError(t, s.CreateSession(ctx, newSession(2, nextDay)))
+	require.NoError(t, s.CreateSession(ctx, newSession(3, "2024-01-14")))
+	require.NoError(t, s.UpdateSessionStatus(ctx, channelID, "2024-01-14", store.SessionCompleted))
+
+	sessions, err := s.GetPendingSessions(ctx, base)
+	var storeErr *store.Error
+	if errors.As(err, &storeErr) && storeErr.Code == "NOT_IMPLEMENTED" {
+		t.Skip("GetPendingSessions isn't implemented by this store")
+	}
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, day, sessions[0].Date)
+}
+
+func testTeamScope(t *testing.T, s store.Store) {
+	acme := context.WithValue(context.Background(), botcontext.TeamIDKey, teamID)
+	other := context.WithValue(context.Background(), botcontext.TeamIDKey, otherTeam)
+
+	session := newSession(1, day)
+	require.NoError(t, s.SubmitUserResponse(acme, session, newResponse(session, alice, "Wrote tests")))
+	require.NoError(t, s.SaveUserPreferences(acme, &store.UserPreferences{UserID: alice, RemindersOff: true}))
+
+	// Another workspace sees none of it, and can use the same IDs
+	_, err := s.GetSession(other, channelID, day)
+	require.ErrorIs(t, err, store.ErrNotFound)
+	_, err = s.GetUserResponse(other, channelID, day, alice)
+	require.ErrorIs(t, err, store.ErrNotFound)
+	_, err = s.GetUserPreferences(other, alice)
+	require.ErrorIs(t, err, store.ErrNotFound)
+	responses, err := s.ListResponsesForUserOnDate(other, alice, day)
+	require.NoError(t, err)
+	assert.Empty(t, responses)
+	require.NoError(t, s.CreateSession(other, newSession(2, day)))
+
+	got, err := s.GetSession(acme, channelID, day)
+	require.NoError(t, err)
+	assert.Equal(t, sessionID(1), got.SessionID)
+	assert.Equal(t, 1, got.ResponseCount)
+	responses, err = s.ListResponsesForUserOnDate(acme, alice, day)
+	require.NoError(t, err)
+	assert.Equal(t, []string{channelID}, channelIDs(responses))
+
+	got, err = s.GetSession(other, channelID, day)
+	require.NoError(t, err)
+	assert.Equal(t, sessionID(2), got.SessionID)
+}
+
+func testValidation(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	// Invalid IDs and dates are refused before reaching the backend
+	_, err := s.GetWorkspaceConfig(ctx, "invalid")
+	assert.Error(t, err)
+	assert.Error(t, s.SaveChannelConfig(ctx, &store.ChannelConfig{TeamID: teamID, ChannelID: "C#123"}))
+	_, err = s.ListChannelConfigs(ctx, "")
+	assert.Error(t, err)
+	assert.Error(t, s.CreateSession(ctx, &store.Session{SessionID: sessionID(1), ChannelID: channelID, Date: "01/15"}))
+	_, err = s.GetSession(ctx, "invalid", day)
+	assert.Error(t, err)
+	_, err = s.ListSessions(ctx, channelID, "2024-01-01", "soon")
+	assert.Error(t, err)
+	_, err = s.GetUserResponse(ctx, channelID, day, "alice")
+	assert.Error(t, err)
+	_, err = s.ListSessionResponses(ctx, "session-1")
+	assert.Error(t, err)
+	_, err = s.SearchUserResponses(ctx, &store.ResponseQuery{ChannelID: channelID, StartDate: "2024-01-01"})
+	assert.Error(t, err)
+	_, err = s.GetUsersWithoutResponse(ctx, channelID, day, []string{alice, "U#1"})
+	assert.Error(t, err)
+	assert.Error(t, s.SaveReminder(ctx, &store.Reminder{ChannelID: channelID, Date: day, UserID: ""}))
+	assert.Error(t, s.SaveScheduledRun(ctx, &store.ScheduledRun{ChannelID: channelID}))
+	assert.Error(t, s.SaveUserPreferences(ctx, &store.UserPreferences{UserID: "invalid"}))
+	_, err = s.IsEventProcessed(ctx, "event-1")
+	assert.Error(t, err)
+
+	// Nothing invalid was saved
+	_, err = s.GetSession(ctx, channelID, day)
+	require.ErrorIs(t, err, store.ErrNotFound)
+}
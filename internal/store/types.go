@@ -1,7 +1,13 @@
 package store
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/synaptiq/standup-bot/internal/validation"
 )
 
 // SessionStatus represents the status of a standup session.
@@ -21,8 +27,17 @@ type Session struct {
 	Date          string        `dynamodbav:"date"` // YYYY-MM-DD format
 	Status        SessionStatus `dynamodbav:"status"`
 	SummaryPosted bool          `dynamodbav:"summary_posted"`
+	AnchorTS      string        `dynamodbav:"anchor_ts,omitempty"`  // Daily thread anchor message
+	SummaryTS     string        `dynamodbav:"summary_ts,omitempty"` // Summary message, updated for late submissions
+	ResponseCount int           `dynamodbav:"response_count"`       // Distinct users who responded
 	CreatedAt     time.Time     `dynamodbav:"created_at"`
 	CompletedAt   *time.Time    `dynamodbav:"completed_at,omitempty"`
+	// GroupMembers caches the members of the channel's user groups, and of
+	// the channel itself if they're participants, when the session started,
+	// besides its configured users.
+	GroupMembers []string `dynamodbav:"group_members,omitempty"`
+	// ReviewedBy lists the leads who acknowledged the summary, in order.
+	ReviewedBy []string `dynamodbav:"reviewed_by,omitempty"`
 }
 
 // UserResponse represents a user's standup response.
@@ -33,8 +48,105 @@ type UserResponse struct {
 	UserID        string            `dynamodbav:"user_id"`
 	UserName      string            `dynamodbav:"user_name"`
 	Responses     map[string]string `dynamodbav:"responses"`
+	Answers       map[string]Answer `dynamodbav:"answers,omitempty"` // Typed answers, keyed like Responses
 	SubmittedAt   time.Time         `dynamodbav:"submitted_at"`
 	ReminderCount int               `dynamodbav:"reminder_count"`
+	Late          bool              `dynamodbav:"late,omitempty"` // First submitted after the summary was posted
+
+	// UnchangedSince makes SubmitUserResponse fail with ErrConflict instead
+	// of replacing a response submitted after it, e.g. from another device.
+	// It isn't stored.
+	UnchangedSince time.Time `dynamodbav:"-"`
+}
+
+// DefaultPageSize is the page size of paged lists given no limit.
+const DefaultPageSize = 100
+
+// ResponsePage is a page of a session's responses, ordered by user ID.
+type ResponsePage struct {
+	Responses []*UserResponse
+	// NextToken gets the next page; it's empty on the last page. Tokens are
+	// opaque to callers.
+	NextToken string
+}
+
+// PageLimit returns the page size for a requested limit.
+func PageLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultPageSize
+	}
+	return limit
+}
+
+// ValidatePageToken checks a token from ResponsePage.NextToken, which holds
+// the last user ID of its page. An empty token starts from the first page.
+func ValidatePageToken(token string) error {
+	if token == "" {
+		return nil
+	}
+	if err := validation.ValidateUserID(token); err != nil {
+		return &Error{Code: "VALIDATION_ERROR", Message: "Invalid page token", Err: err}
+	}
+	return nil
+}
+
+// ResponseQuery selects responses to search in a channel.
+type ResponseQuery struct {
+	ChannelID string
+	UserID    string // Only this user's responses when set
+	StartDate string // YYYY-MM-DD, inclusive
+	EndDate   string // YYYY-MM-DD, inclusive
+	Text      string // Words that must all appear in the answers, ignoring case
+	Limit     int    // Most responses to return; 0 for all
+}
+
+// Validate checks the query's IDs and dates.
+func (q *ResponseQuery) Validate() error {
+	if err := validation.ValidateChannelID(q.ChannelID); err != nil {
+		return &Error{Code: "VALIDATION_ERROR", Message: "Invalid channel ID", Err: err}
+	}
+	if q.UserID != "" {
+		if err := validation.ValidateUserID(q.UserID); err != nil {
+			return &Error{Code: "VALIDATION_ERROR", Message: "Invalid user ID", Err: err}
+		}
+	}
+	if err := validation.ValidateDate(q.StartDate); err != nil {
+		return &Error{Code: "VALIDATION_ERROR", Message: "Invalid start date", Err: err}
+	}
+	if err := validation.ValidateDate(q.EndDate); err != nil {
+		return &Error{Code: "VALIDATION_ERROR", Message: "Invalid end date", Err: err}
+	}
+	return nil
+}
+
+// Keywords returns the lowercased words of the query's text.
+func (q *ResponseQuery) Keywords() []string {
+	return strings.Fields(strings.ToLower(q.Text))
+}
+
+// Matches reports whether a response's answers contain every keyword.
+func (q *ResponseQuery) Matches(response *UserResponse) bool {
+	answers := make([]string, 0, len(response.Responses))
+	for _, answer := range response.Responses {
+		answers = append(answers, answer)
+	}
+	text := strings.ToLower(strings.Join(answers, "\n"))
+
+	for _, keyword := range q.Keywords() {
+		if !strings.Contains(text, keyword) {
+			return false
+		}
+	}
+	return true
+}
+
+// Answer is a structured answer to a typed question.
+type Answer struct {
+	Type       string   `dynamodbav:"type"` // text, select, multi_select, yes_no, date, number
+	Value      string   `dynamodbav:"value,omitempty"`
+	Values     []string `dynamodbav:"values,omitempty"`
+	Question   string   `dynamodbav:"question,omitempty"`    // Question text as asked
+	QuestionID string   `dynamodbav:"question_id,omitempty"` // Configured question ID, if any
 }
 
 // Reminder represents a reminder sent to a user.
@@ -45,16 +157,155 @@ type Reminder struct {
 	Time      string    `dynamodbav:"time"` // HH:MM format
 	SentAt    time.Time `dynamodbav:"sent_at"`
 	MessageTS string    `dynamodbav:"message_ts"`
+	// SnoozedUntil is set while a snoozed reminder is waiting to be re-sent.
+	SnoozedUntil *time.Time `dynamodbav:"snoozed_until,omitempty"`
+}
+
+// FailedReminder records a reminder that couldn't be delivered, so admins
+// can see who isn't being reached.
+type FailedReminder struct {
+	ChannelID string    `dynamodbav:"channel_id"`
+	Date      string    `dynamodbav:"date"`
+	UserID    string    `dynamodbav:"user_id"`
+	Time      string    `dynamodbav:"time"`                 // HH:MM format
+	ErrorCode string    `dynamodbav:"error_code,omitempty"` // Slack error such as "cannot_dm_bot", if any
+	Error     string    `dynamodbav:"error"`
+	FailedAt  time.Time `dynamodbav:"failed_at"`
+}
+
+// SkippedResponse records a user opting out of a day's standup.
+type SkippedResponse struct {
+	ChannelID string    `dynamodbav:"channel_id"`
+	Date      string    `dynamodbav:"date"`
+	UserID    string    `dynamodbav:"user_id"`
+	Reason    string    `dynamodbav:"reason,omitempty"`
+	SkippedAt time.Time `dynamodbav:"skipped_at"`
+}
+
+// EscalationRecord records that a user's missing standup was escalated.
+type EscalationRecord struct {
+	ChannelID     string           `dynamodbav:"channel_id"`
+	Date          string           `dynamodbav:"date"`
+	UserID        string           `dynamodbav:"user_id"`
+	Action        EscalationAction `dynamodbav:"action"`
+	ReminderCount int              `dynamodbav:"reminder_count"`
+	EscalatedAt   time.Time        `dynamodbav:"escalated_at"`
+}
+
+// ReminderDelivery is how a user's reminders reach them.
+type ReminderDelivery string
+
+// Reminder deliveries.
+const (
+	DeliverDM      ReminderDelivery = "dm"      // Direct message; the default
+	DeliverChannel ReminderDelivery = "channel" // Mention in the standup channel
+)
+
+// UserPreferences are a user's reminder preferences, applied in every
+// channel they're required in.
+type UserPreferences struct {
+	UserID       string           `dynamodbav:"user_id"`
+	RemindersOff bool             `dynamodbav:"reminders_off"`           // Opted out of reminders
+	ReminderTime string           `dynamodbav:"reminder_time,omitempty"` // HH:MM; replaces the channel's reminder times
+	Delivery     ReminderDelivery `dynamodbav:"delivery,omitempty"`      // Defaults to DeliverDM
+	Locale       string           `dynamodbav:"locale,omitempty"`        // e.g. "es"; empty for Slack's locale
+	UpdatedAt    time.Time        `dynamodbav:"updated_at"`
+}
+
+// ProcessedEvent records a Slack event that is being or was handled
+// successfully, so redeliveries of the same event can be dropped.
+type ProcessedEvent struct {
+	EventID     string    `dynamodbav:"event_id"`
+	TeamID      string    `dynamodbav:"team_id,omitempty"`
+	EventType   string    `dynamodbav:"event_type,omitempty"`
+	ProcessedAt time.Time `dynamodbav:"processed_at"`
 }
 
 // WorkspaceConfig represents workspace-level configuration.
+//
+// An Enterprise Grid org-wide install is saved under the organization's
+// enterprise ID (E...) in TeamID, with IsEnterpriseInstall set. Workspaces of
+// the grid record its EnterpriseID and use its bot token unless they have one
+// of their own.
 type WorkspaceConfig struct {
-	TeamID      string    `dynamodbav:"team_id"`
-	TeamName    string    `dynamodbav:"team_name"`
-	BotToken    string    `dynamodbav:"bot_token"`
-	AppToken    string    `dynamodbav:"app_token,omitempty"`
-	InstalledAt time.Time `dynamodbav:"installed_at"`
-	UpdatedAt   time.Time `dynamodbav:"updated_at"`
+	TeamID              string    `dynamodbav:"team_id"`
+	TeamName            string    `dynamodbav:"team_name"`
+	EnterpriseID        string    `dynamodbav:"enterprise_id,omitempty"`
+	IsEnterpriseInstall bool      `dynamodbav:"is_enterprise_install,omitempty"`
+	BotToken            string    `dynamodbav:"bot_token"`
+	AppToken            string    `dynamodbav:"app_token,omitempty"`
+	InstalledAt         time.Time `dynamodbav:"installed_at"`
+	UpdatedAt           time.Time `dynamodbav:"updated_at"`
+	// Integrations override those of the seed config for this workspace
+	Integrations Integrations `dynamodbav:"integrations"`
+}
+
+// redact hides a secret when a record is formatted, e.g. in a log entry,
+// while showing whether it's set.
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+// String formats the config with its tokens redacted.
+func (c WorkspaceConfig) String() string {
+	type plain WorkspaceConfig // Without this method, so formatting doesn't recurse
+	c.BotToken = redact(c.BotToken)
+	c.AppToken = redact(c.AppToken)
+	return fmt.Sprintf("%+v", plain(c))
+}
+
+// Integrations holds a workspace's third-party service settings. Each is nil
+// when not configured.
+type Integrations struct {
+	Jira     *JiraIntegration     `dynamodbav:"jira,omitempty"`
+	GitHub   *GitHubIntegration   `dynamodbav:"github,omitempty"`
+	Calendar *CalendarIntegration `dynamodbav:"calendar,omitempty"`
+}
+
+// JiraIntegration links Jira issues mentioned in standup answers.
+type JiraIntegration struct {
+	BaseURL  string   `dynamodbav:"base_url"`
+	Email    string   `dynamodbav:"email,omitempty"`
+	APIToken string   `dynamodbav:"api_token,omitempty"`
+	Projects []string `dynamodbav:"projects,omitempty"`
+	Comment  bool     `dynamodbav:"comment"`
+}
+
+// String formats the integration with its API token redacted.
+func (j JiraIntegration) String() string {
+	type plain JiraIntegration
+	j.APIToken = redact(j.APIToken)
+	return fmt.Sprintf("%+v", plain(j))
+}
+
+// GitHubIntegration prefills the standup modal with users' GitHub activity.
+type GitHubIntegration struct {
+	Token string            `dynamodbav:"token"`
+	Repos []string          `dynamodbav:"repos"`
+	Users map[string]string `dynamodbav:"users,omitempty"` // GitHub logins keyed by Slack user ID
+}
+
+// String formats the integration with its token redacted.
+func (g GitHubIntegration) String() string {
+	type plain GitHubIntegration
+	g.Token = redact(g.Token)
+	return fmt.Sprintf("%+v", plain(g))
+}
+
+// CalendarIntegration skips the standups of users who are out of office.
+type CalendarIntegration struct {
+	Credentials string `dynamodbav:"credentials"`         // Google service account JSON key
+	SyncTime    string `dynamodbav:"sync_time,omitempty"` // HH:MM
+}
+
+// String formats the integration with its credentials redacted.
+func (c CalendarIntegration) String() string {
+	type plain CalendarIntegration
+	c.Credentials = redact(c.Credentials)
+	return fmt.Sprintf("%+v", plain(c))
 }
 
 // ChannelConfig represents channel-specific standup configuration.
@@ -65,17 +316,285 @@ type ChannelConfig struct {
 	Enabled     bool              `dynamodbav:"enabled"`
 	Schedule    ScheduleConfig    `dynamodbav:"schedule"`
 	Users       []string          `dynamodbav:"users"`
+	Admins      []string          `dynamodbav:"admins,omitempty"` // May change settings, export and force summaries
 	Templates   map[string]string `dynamodbav:"templates"`
 	Questions   []string          `dynamodbav:"questions"`
 	UpdatedAt   time.Time         `dynamodbav:"updated_at"`
+	// DeactivatedUsers lists Users who were deactivated or removed from the
+	// workspace. They aren't reminded or reported missing.
+	DeactivatedUsers []string `dynamodbav:"deactivated_users,omitempty"`
+	// UserGroups lists Slack user groups (S...) whose members are required
+	// too. They're expanded when each day's session starts.
+	UserGroups []string `dynamodbav:"user_groups,omitempty"`
+	// ArchivedAt is when the Slack channel was archived. Standups in an
+	// archived channel are paused until it's unarchived.
+	ArchivedAt *time.Time `dynamodbav:"archived_at,omitempty"`
+	// Version counts the config's changes. SaveChannelConfig only replaces
+	// the stored config at the same version, so a config changed since it
+	// was read, e.g. by another admin, isn't overwritten; new configs, and
+	// configs stored before versions were kept, are at version 0.
+	Version int64 `dynamodbav:"version"`
+}
+
+// Active reports whether standups run in the channel: it's enabled and the
+// Slack channel isn't archived.
+func (c *ChannelConfig) Active() bool {
+	return c.Enabled && c.ArchivedAt == nil
+}
+
+// Clone returns a copy of c that shares no slices, maps or policies with it,
+// so either can be changed without affecting the other.
+func (c *ChannelConfig) Clone() *ChannelConfig {
+	config := *c
+	config.Users = slices.Clone(config.Users)
+	config.Admins = slices.Clone(config.Admins)
+	config.DeactivatedUsers = slices.Clone(config.DeactivatedUsers)
+	config.UserGroups = slices.Clone(config.UserGroups)
+	config.Templates = maps.Clone(config.Templates)
+	config.Questions = slices.Clone(config.Questions)
+	config.Schedule.ReminderTimes = slices.Clone(config.Schedule.ReminderTimes)
+	config.Schedule.ActiveDays = slices.Clone(config.Schedule.ActiveDays)
+	config.Schedule.SummaryRecipients = slices.Clone(config.Schedule.SummaryRecipients)
+	if config.Schedule.UserActiveDays != nil {
+		userActiveDays := make(map[string][]string, len(config.Schedule.UserActiveDays))
+		for userID, days := range config.Schedule.UserActiveDays {
+			userActiveDays[userID] = slices.Clone(days)
+		}
+		config.Schedule.UserActiveDays = userActiveDays
+	}
+	if config.Schedule.UserTags != nil {
+		userTags := make(map[string]map[string]string, len(config.Schedule.UserTags))
+		for userID, tags := range config.Schedule.UserTags {
+			userTags[userID] = maps.Clone(tags)
+		}
+		config.Schedule.UserTags = userTags
+	}
+	if c.ArchivedAt != nil {
+		archivedAt := *c.ArchivedAt
+		config.ArchivedAt = &archivedAt
+	}
+	if config.Schedule.Holidays != nil {
+		holidays := *config.Schedule.Holidays
+		holidays.Dates = slices.Clone(holidays.Dates)
+		config.Schedule.Holidays = &holidays
+	}
+	if config.Schedule.WeeklyDigest != nil {
+		digest := *config.Schedule.WeeklyDigest
+		config.Schedule.WeeklyDigest = &digest
+	}
+	if config.Schedule.MonthlyDigest != nil {
+		digest := *config.Schedule.MonthlyDigest
+		config.Schedule.MonthlyDigest = &digest
+	}
+	if config.Schedule.Escalation != nil {
+		escalation := *config.Schedule.Escalation
+		config.Schedule.Escalation = &escalation
+	}
+	if config.Schedule.Onboarding != nil {
+		onboarding := *config.Schedule.Onboarding
+		config.Schedule.Onboarding = &onboarding
+	}
+	if config.Schedule.Privacy != nil {
+		privacy := *config.Schedule.Privacy
+		privacy.Recipients = slices.Clone(privacy.Recipients)
+		config.Schedule.Privacy = &privacy
+	}
+	if config.Schedule.Participants != nil {
+		participants := *config.Schedule.Participants
+		participants.Exclude = slices.Clone(participants.Exclude)
+		config.Schedule.Participants = &participants
+	}
+	if config.Schedule.Streaks != nil {
+		streaks := *config.Schedule.Streaks
+		streaks.Milestones = slices.Clone(streaks.Milestones)
+		if streaks.Leaderboard != nil {
+			leaderboard := *streaks.Leaderboard
+			streaks.Leaderboard = &leaderboard
+		}
+		config.Schedule.Streaks = &streaks
+	}
+	return &config
 }
 
 // ScheduleConfig represents scheduling configuration.
 type ScheduleConfig struct {
 	Timezone      string   `dynamodbav:"timezone"`
-	SummaryTime   string   `dynamodbav:"summary_time"`   // HH:MM format
-	ReminderTimes []string `dynamodbav:"reminder_times"` // HH:MM format
-	ActiveDays    []string `dynamodbav:"active_days"`    // Mon, Tue, etc.
+	StartTime     string   `dynamodbav:"start_time,omitempty"` // HH:MM; defaults to the first reminder time
+	SummaryTime   string   `dynamodbav:"summary_time"`         // HH:MM format
+	ReminderTimes []string `dynamodbav:"reminder_times"`       // HH:MM format
+	ActiveDays    []string `dynamodbav:"active_days"`          // Mon, Tue, etc.
+	// UserActiveDays are the days part-timers work on, like ActiveDays,
+	// keyed by user ID. Other users work every active day.
+	UserActiveDays map[string][]string `dynamodbav:"user_active_days,omitempty"`
+	// GracePeriod is how long after the summary late submissions are
+	// accepted, as a duration like "2h". Empty accepts them all day.
+	GracePeriod string `dynamodbav:"grace_period,omitempty"`
+
+	Holidays *HolidayCalendar `dynamodbav:"holidays,omitempty"`
+
+	WeeklyDigest  *DigestSchedule   `dynamodbav:"weekly_digest,omitempty"`
+	MonthlyDigest *DigestSchedule   `dynamodbav:"monthly_digest,omitempty"`
+	Escalation    *EscalationPolicy `dynamodbav:"escalation,omitempty"`
+	Onboarding    *OnboardingPolicy `dynamodbav:"onboarding,omitempty"`
+	Privacy       *PrivacyPolicy    `dynamodbav:"privacy,omitempty"`
+
+	Participants *ParticipantsPolicy `dynamodbav:"participants,omitempty"`
+
+	Streaks *StreakPolicy `dynamodbav:"streaks,omitempty"`
+
+	// Locale is the language of the channel's messages, e.g. "es"; empty
+	// for English
+	Locale string `dynamodbav:"locale,omitempty"`
+
+	// ReviewAnswers shows users their answers to review before the standup
+	// is submitted
+	ReviewAnswers bool `dynamodbav:"review_answers,omitempty"`
+
+	// Mentions is how summaries show users: "mention", "name" or "both";
+	// empty for mentions
+	Mentions string `dynamodbav:"mentions,omitempty"`
+
+	// SummaryRecipients get a copy of each daily summary: users by DM and
+	// other channels as a message
+	SummaryRecipients []string `dynamodbav:"summary_recipients,omitempty"`
+
+	// UserTags group users' standups in reports across channels, e.g.
+	// project: payments, keyed by user ID
+	UserTags map[string]map[string]string `dynamodbav:"user_tags,omitempty"`
+}
+
+// IsWorkingDay reports whether the user works on day: on one of their
+// UserActiveDays if they have any, or else on any day.
+func (s *ScheduleConfig) IsWorkingDay(userID string, day time.Weekday) bool {
+	days, ok := s.UserActiveDays[userID]
+	return !ok || slices.Contains(days, day.String()[:3])
+}
+
+// HolidayCalendar lists days a channel skips standups.
+type HolidayCalendar struct {
+	Dates  []string `dynamodbav:"dates,omitempty"`   // YYYY-MM-DD in the channel's timezone
+	ICSURL string   `dynamodbav:"ics_url,omitempty"` // iCalendar feed of further holidays
+}
+
+// EscalationAction is what happens when a user ignores their reminders.
+type EscalationAction string
+
+// Escalation actions.
+const (
+	EscalateNotifyManager EscalationAction = "notify_manager" // DM the designated manager
+	EscalatePublicNudge   EscalationAction = "public_nudge"   // Mention the user in the standup thread
+)
+
+// EscalationPolicy configures escalation after unanswered reminders.
+type EscalationPolicy struct {
+	AfterReminders int              `dynamodbav:"after_reminders"` // Reminders sent before escalating
+	Action         EscalationAction `dynamodbav:"action"`
+	ManagerID      string           `dynamodbav:"manager_id,omitempty"` // Required for notify_manager
+}
+
+// OnboardingPolicy configures what happens when someone joins the channel.
+type OnboardingPolicy struct {
+	AutoAdd bool   `dynamodbav:"auto_add"`           // Offer to add new members to Users
+	AdminID string `dynamodbav:"admin_id,omitempty"` // Approves additions; required for auto_add
+}
+
+// ParticipantsPolicy requires the channel's members besides its Users. They
+// are looked up when each day's session starts, leaving out bots.
+type ParticipantsPolicy struct {
+	ChannelMembers bool     `dynamodbav:"channel_members"`
+	Exclude        []string `dynamodbav:"exclude,omitempty"` // Members who aren't required, e.g. managers
+}
+
+// PrivacyPolicy keeps a channel's responses out of the channel. Private
+// channels get no threaded posts or blocker cross-posts, and their summary
+// shows only who submitted; the full report is DMed to the recipients.
+type PrivacyPolicy struct {
+	Private    bool     `dynamodbav:"private"`
+	Recipients []string `dynamodbav:"recipients,omitempty"` // Get the full report; defaults to the channel's admins
+}
+
+// StreakPolicy tracks each user's run of consecutive standups in a channel
+// and celebrates milestones in the daily summary.
+type StreakPolicy struct {
+	Milestones []int `dynamodbav:"milestones,omitempty"` // Run lengths celebrated; defaults to DefaultStreakMilestones
+	// Leaderboard posts the most consistent users once a month; Day and
+	// Time work as for the monthly digest
+	Leaderboard *DigestSchedule `dynamodbav:"leaderboard,omitempty"`
+}
+
+// DefaultStreakMilestones are the run lengths celebrated when a channel's
+// streak policy lists none.
+var DefaultStreakMilestones = []int{5, 10, 30}
+
+// Streak is a user's run of consecutive standups in a channel. Skipped days
+// and days without a standup don't end it; missed days do.
+type Streak struct {
+	ChannelID string    `dynamodbav:"channel_id"`
+	UserID    string    `dynamodbav:"user_id"`
+	Current   int       `dynamodbav:"current"`
+	Longest   int       `dynamodbav:"longest"`
+	LastDate  string    `dynamodbav:"last_date"` // Last standup day counted, YYYY-MM-DD
+	Missed    bool      `dynamodbav:"missed"`    // The user missed LastDate
+	Broken    int       `dynamodbav:"broken"`    // The run the miss ended, restored if the user submits late
+	UpdatedAt time.Time `dynamodbav:"updated_at"`
+}
+
+// DigestSchedule configures when a periodic digest is posted and where.
+type DigestSchedule struct {
+	Day           string `dynamodbav:"day"`                      // Mon..Sun for weekly; 1-28 or "last" for monthly
+	Time          string `dynamodbav:"time"`                     // HH:MM format
+	TargetChannel string `dynamodbav:"target_channel,omitempty"` // Defaults to the standup channel
+}
+
+// DigestPeriod identifies the kind of periodic digest.
+type DigestPeriod string
+
+// Digest periods.
+const (
+	DigestWeekly  DigestPeriod = "weekly"
+	DigestMonthly DigestPeriod = "monthly"
+	// DigestLeaderboard is the monthly streak leaderboard
+	DigestLeaderboard DigestPeriod = "leaderboard"
+)
+
+// DigestRecord records that a periodic digest was posted.
+type DigestRecord struct {
+	ChannelID string       `dynamodbav:"channel_id"`
+	Period    DigestPeriod `dynamodbav:"period"`
+	PeriodKey string       `dynamodbav:"period_key"` // e.g. 2024-W03 or 2024-01
+	PostedAt  time.Time    `dynamodbav:"posted_at"`
+}
+
+// ScheduledRun records when one of a channel's scheduled tasks next runs, so
+// each occurrence runs once however the scheduler's ticks fall.
+type ScheduledRun struct {
+	ChannelID string    `dynamodbav:"channel_id"`
+	Task      string    `dynamodbav:"task"`     // e.g. "summary" or "reminder#09:00"
+	Clock     string    `dynamodbav:"clock"`    // HH:MM time NextRunAt was computed from
+	Timezone  string    `dynamodbav:"timezone"` // Zone NextRunAt was computed in
+	NextRunAt time.Time `dynamodbav:"next_run_at"`
+	UpdatedAt time.Time `dynamodbav:"updated_at"`
+}
+
+// Heartbeat jobs.
+const (
+	// HeartbeatScheduler is the scheduler's run on each tick
+	HeartbeatScheduler = "scheduler"
+	// HeartbeatSummary is a channel's daily summary
+	HeartbeatSummary = "summary"
+)
+
+// Heartbeat records the health of a recurring job, so a monitor can alert
+// when it stops running or keeps failing.
+type Heartbeat struct {
+	Job           string     `dynamodbav:"job"`
+	TeamID        string     `dynamodbav:"team_id,omitempty"`
+	ChannelID     string     `dynamodbav:"channel_id,omitempty"` // Set for per-channel jobs
+	LastSuccessAt time.Time  `dynamodbav:"last_success_at"`      // Zero if it never succeeded
+	Failures      int        `dynamodbav:"failures"`             // Failures in a row since LastSuccessAt
+	LastError     string     `dynamodbav:"last_error,omitempty"`
+	AlertedAt     *time.Time `dynamodbav:"alerted_at,omitempty"` // Set while the monitor's alert is open
+	UpdatedAt     time.Time  `dynamodbav:"updated_at"`
 }
 
 // DynamoDBItem represents the base structure for all DynamoDB items.
@@ -86,4 +605,10 @@ type DynamoDBItem struct {
 	// GSI1 indexes for queries
 	GSI1PK string `dynamodbav:"GSI1PK,omitempty"`
 	GSI1SK string `dynamodbav:"GSI1SK,omitempty"`
+	// GSI3 indexes a user's responses across dates
+	GSI3PK string `dynamodbav:"GSI3PK,omitempty"`
+	GSI3SK string `dynamodbav:"GSI3SK,omitempty"`
+	// GSI4 indexes a user's responses by date across channels
+	GSI4PK string `dynamodbav:"GSI4PK,omitempty"`
+	GSI4SK string `dynamodbav:"GSI4SK,omitempty"`
 }
@@ -0,0 +1,37 @@
+// Package templates renders the message templates of channel configs, such
+// as reminders and summary headers, with text/template.
+package templates
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Vars are the variables a template is rendered with, used as {{.Name}}.
+type Vars map[string]string
+
+// Execute renders text with vars. Templates using a variable missing from
+// vars fail rather than render "<no value>".
+func Execute(text string, vars Vars) (string, error) {
+	tmpl, err := template.New("message").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, vars); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return out.String(), nil
+}
+
+// Render renders text with vars like Execute, returning text unrendered if
+// it fails, so a broken template still delivers its message.
+func Render(text string, vars Vars) string {
+	rendered, err := Execute(text, vars)
+	if err != nil {
+		return text
+	}
+	return rendered
+}
@@ -0,0 +1,28 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute(t *testing.T) {
+	vars := Vars{"UserName": "alice", "ChannelName": "engineering"}
+
+	text, err := Execute("Hey {{.UserName}}{{if .ChannelName}}, post in #{{.ChannelName}}{{end}}!", vars)
+	require.NoError(t, err)
+	assert.Equal(t, "Hey alice, post in #engineering!", text)
+
+	_, err = Execute("Hey {{.UserName}} at {{.Time}}", vars)
+	require.Error(t, err)
+	_, err = Execute("Hey {{.UserName", vars)
+	require.Error(t, err)
+}
+
+func TestRender(t *testing.T) {
+	assert.Equal(t, "Summary for 2024-01-15", Render("Summary for {{.Date}}", Vars{"Date": "2024-01-15"}))
+
+	// Broken templates are delivered as is
+	assert.Equal(t, "Summary for {{.Day}}", Render("Summary for {{.Day}}", Vars{"Date": "2024-01-15"}))
+}
@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// InstrumentAWS records every AWS SDK call made with cfg, retries included,
+// as a subsegment named after the service.
+func (t *XRayTracer) InstrumentAWS(cfg *aws.Config) {
+	cfg.APIOptions = append(cfg.APIOptions, func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("XRayTracing",
+			func(
+				ctx context.Context,
+				in middleware.FinalizeInput,
+				next middleware.FinalizeHandler,
+			) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				ctx, s := t.begin(ctx, awsmiddleware.GetServiceID(ctx))
+				if s == nil {
+					return next.HandleFinalize(ctx, in)
+				}
+				defer t.end(s)
+
+				s.Namespace = "aws"
+				s.AWS = map[string]interface{}{
+					"operation": awsmiddleware.GetOperationName(ctx),
+					"region":    awsmiddleware.GetRegion(ctx),
+				}
+
+				out, metadata, err := next.HandleFinalize(ctx, in)
+				if err != nil {
+					s.setError(false)
+				}
+				return out, metadata, err
+			}), middleware.Before)
+	})
+}
+
+// Transport wraps base so each HTTP request is recorded as a subsegment
+// named after the host. A nil base uses http.DefaultTransport.
+func (t *XRayTracer) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &roundTripper{tracer: t, base: base}
+}
+
+type roundTripper struct {
+	tracer *XRayTracer
+	base   http.RoundTripper
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, s := rt.tracer.begin(req.Context(), req.URL.Hostname())
+	if s == nil {
+		return rt.base.RoundTrip(req)
+	}
+	defer rt.tracer.end(s)
+
+	// Query strings can carry tokens, so only the path is recorded
+	target := url.URL{Scheme: req.URL.Scheme, Host: req.URL.Host, Path: req.URL.Path}
+	s.Namespace = "remote"
+	s.HTTP = &httpData{Request: httpRequest{Method: req.Method, URL: target.String()}}
+
+	resp, err := rt.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		s.setError(true)
+		return resp, err
+	}
+
+	s.HTTP.Response = &httpResponse{Status: resp.StatusCode}
+	switch {
+	case resp.StatusCode >= 500:
+		s.setError(true)
+	case resp.StatusCode >= 400:
+		s.setError(false)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,257 @@
+// Package tracing implements context.Tracer on AWS X-Ray.
+//
+// Spans are sent to the X-Ray daemon as subsegments of the segment Lambda
+// creates for each invocation, so no SDK is needed at runtime.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+)
+
+// XRay is the tracer name that selects X-Ray in lambda.Initialize.
+const XRay = "xray"
+
+const (
+	// defaultDaemonAddress is where the daemon listens when
+	// AWS_XRAY_DAEMON_ADDRESS is not set.
+	defaultDaemonAddress = "127.0.0.1:2000"
+
+	// daemonHeader precedes every document sent to the daemon.
+	daemonHeader = `{"format": "json", "version": 1}` + "\n"
+
+	// lambdaTraceHeaderKey is the context key the Lambda runtime stores the
+	// invocation's trace header under.
+	lambdaTraceHeaderKey = "x-amzn-trace-id"
+)
+
+// spanKey is the context key for the current span.
+type spanKey struct{}
+
+// XRayTracer records spans as X-Ray subsegments.
+type XRayTracer struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewXRayTracer creates a tracer that sends subsegments to the daemon at
+// address. An empty address uses AWS_XRAY_DAEMON_ADDRESS.
+func NewXRayTracer(address string) (*XRayTracer, error) {
+	if address == "" {
+		address = daemonAddress(os.Getenv("AWS_XRAY_DAEMON_ADDRESS"))
+	}
+
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to X-Ray daemon: %w", err)
+	}
+
+	return newXRayTracer(conn), nil
+}
+
+func newXRayTracer(out io.Writer) *XRayTracer {
+	return &XRayTracer{out: out}
+}
+
+// StartSpan begins a subsegment annotated with the channel and user in ctx.
+// Outside a sampled invocation it does nothing.
+func (t *XRayTracer) StartSpan(ctx context.Context, name string) (context.Context, func()) {
+	ctx, s := t.begin(ctx, name)
+	if s == nil {
+		return ctx, func() {}
+	}
+
+	if channelID, ok := ctx.Value(botcontext.ChannelIDKey).(string); ok && channelID != "" {
+		s.annotate("channel_id", channelID)
+	}
+	if userID, ok := ctx.Value(botcontext.UserIDKey).(string); ok && userID != "" {
+		s.annotate("user_id", userID)
+	}
+	if teamID, ok := ctx.Value(botcontext.TeamIDKey).(string); ok && teamID != "" {
+		s.annotate("team_id", teamID)
+	}
+
+	return ctx, func() { t.end(s) }
+}
+
+// AddAnnotation annotates the current span. Values other than strings,
+// numbers and booleans are recorded as strings.
+func (t *XRayTracer) AddAnnotation(ctx context.Context, key string, value interface{}) {
+	if s, ok := ctx.Value(spanKey{}).(*span); ok {
+		s.annotate(key, value)
+	}
+}
+
+// begin starts a span under the current span, or under the Lambda segment
+// when there is none. It returns a nil span when the request is not sampled.
+func (t *XRayTracer) begin(ctx context.Context, name string) (context.Context, *span) {
+	var traceID, parentID string
+	if parent, ok := ctx.Value(spanKey{}).(*span); ok {
+		traceID, parentID = parent.TraceID, parent.ID
+	} else {
+		header, _ := ctx.Value(lambdaTraceHeaderKey).(string)
+		var sampled bool
+		traceID, parentID, sampled = parseTraceHeader(header)
+		if !sampled {
+			return ctx, nil
+		}
+	}
+
+	s := &span{
+		ID:        newSpanID(),
+		TraceID:   traceID,
+		ParentID:  parentID,
+		Type:      "subsegment",
+		Name:      truncate(name, 200),
+		StartTime: epochSeconds(time.Now()),
+	}
+
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// end closes a span and sends it to the daemon. Send errors are dropped;
+// tracing must never fail a request.
+func (t *XRayTracer) end(s *span) {
+	s.mu.Lock()
+	s.EndTime = epochSeconds(time.Now())
+	doc, err := json.Marshal(s)
+	s.mu.Unlock()
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = t.out.Write(append([]byte(daemonHeader), doc...))
+}
+
+// span is an X-Ray subsegment document.
+type span struct {
+	mu sync.Mutex
+
+	ID          string                 `json:"id"`
+	TraceID     string                 `json:"trace_id"`
+	ParentID    string                 `json:"parent_id"`
+	Type        string                 `json:"type"`
+	Name        string                 `json:"name"`
+	StartTime   float64                `json:"start_time"`
+	EndTime     float64                `json:"end_time"`
+	Namespace   string                 `json:"namespace,omitempty"`
+	Error       bool                   `json:"error,omitempty"`
+	Fault       bool                   `json:"fault,omitempty"`
+	Annotations map[string]interface{} `json:"annotations,omitempty"`
+	HTTP        *httpData              `json:"http,omitempty"`
+	AWS         map[string]interface{} `json:"aws,omitempty"`
+}
+
+type httpData struct {
+	Request  httpRequest   `json:"request"`
+	Response *httpResponse `json:"response,omitempty"`
+}
+
+type httpRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+type httpResponse struct {
+	Status int `json:"status"`
+}
+
+func (s *span) annotate(key string, value interface{}) {
+	switch value.(type) {
+	case bool, string, int, int32, int64, uint, uint32, uint64, float32, float64:
+	default:
+		value = fmt.Sprint(value)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Annotations == nil {
+		s.Annotations = make(map[string]interface{})
+	}
+	s.Annotations[annotationKey(key)] = value
+}
+
+// setError flags the span as failed. Faults are server-side errors.
+func (s *span) setError(fault bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if fault {
+		s.Fault = true
+	} else {
+		s.Error = true
+	}
+}
+
+// parseTraceHeader reads a header of the form
+// "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1".
+func parseTraceHeader(header string) (traceID, parentID string, sampled bool) {
+	for _, part := range strings.Split(header, ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "Root":
+			traceID = value
+		case "Parent":
+			parentID = value
+		case "Sampled":
+			sampled = value == "1"
+		}
+	}
+
+	return traceID, parentID, sampled && traceID != "" && parentID != ""
+}
+
+// daemonAddress picks the UDP address out of AWS_XRAY_DAEMON_ADDRESS, which
+// is either "host:port" or "tcp:host:port udp:host:port".
+func daemonAddress(value string) string {
+	for _, part := range strings.Fields(value) {
+		if address, ok := strings.CutPrefix(part, "udp:"); ok {
+			return address
+		}
+		if !strings.HasPrefix(part, "tcp:") {
+			return part
+		}
+	}
+	return defaultDaemonAddress
+}
+
+// annotationKey replaces characters X-Ray does not allow in annotation keys.
+func annotationKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+func newSpanID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func epochSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,139 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+)
+
+const testTraceHeader = "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1"
+
+// recorder collects documents sent to the daemon.
+type recorder struct {
+	docs []map[string]interface{}
+}
+
+func (r *recorder) Write(p []byte) (int, error) {
+	header, body, ok := bytes.Cut(p, []byte("\n"))
+	if !ok || string(header)+"\n" != daemonHeader {
+		return 0, nil
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return 0, err
+	}
+	r.docs = append(r.docs, doc)
+	return len(p), nil
+}
+
+//nolint:staticcheck // The Lambda runtime uses a string key
+func tracedContext() context.Context {
+	return context.WithValue(context.Background(), lambdaTraceHeaderKey, testTraceHeader)
+}
+
+func TestXRayTracerStartSpan(t *testing.T) {
+	out := &recorder{}
+	tracer := newXRayTracer(out)
+
+	ctx := context.WithValue(tracedContext(), botcontext.ChannelIDKey, "C1234567890")
+	ctx = context.WithValue(ctx, botcontext.UserIDKey, "U1234567890")
+
+	outerCtx, endOuter := tracer.StartSpan(ctx, "lambda_handler")
+	tracer.AddAnnotation(outerCtx, "http-method", "POST")
+	tracer.AddAnnotation(outerCtx, "retries", 2)
+	tracer.AddAnnotation(outerCtx, "status", struct{ Code int }{200})
+
+	_, endInner := tracer.StartSpan(outerCtx, "send_reminder")
+	endInner()
+	endOuter()
+
+	require.Len(t, out.docs, 2)
+	inner, outer := out.docs[0], out.docs[1]
+
+	assert.Equal(t, "lambda_handler", outer["name"])
+	assert.Equal(t, "subsegment", outer["type"])
+	assert.Equal(t, "1-5759e988-bd862e3fe1be46a994272793", outer["trace_id"])
+	assert.Equal(t, "53995c3f42cd8ad8", outer["parent_id"])
+	assert.Equal(t, map[string]interface{}{
+		"channel_id":  "C1234567890",
+		"user_id":     "U1234567890",
+		"http_method": "POST",
+		"retries":     float64(2),
+		"status":      "{200}",
+	}, outer["annotations"])
+
+	assert.Equal(t, "send_reminder", inner["name"])
+	assert.Equal(t, outer["id"], inner["parent_id"])
+	assert.Equal(t, outer["trace_id"], inner["trace_id"])
+}
+
+func TestXRayTracerNotSampled(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{name: "no trace header", ctx: context.Background()},
+		//nolint:staticcheck // The Lambda runtime uses a string key
+		{name: "not sampled", ctx: context.WithValue(context.Background(), lambdaTraceHeaderKey,
+			strings.Replace(testTraceHeader, "Sampled=1", "Sampled=0", 1))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &recorder{}
+			tracer := newXRayTracer(out)
+
+			ctx, done := tracer.StartSpan(tt.ctx, "lambda_handler")
+			tracer.AddAnnotation(ctx, "key", "value")
+			done()
+
+			assert.Equal(t, tt.ctx, ctx)
+			assert.Empty(t, out.docs)
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	out := &recorder{}
+	tracer := newXRayTracer(out)
+	client := &http.Client{Transport: tracer.Transport(nil)}
+
+	req, err := http.NewRequestWithContext(tracedContext(), http.MethodPost,
+		server.URL+"/api/chat.postMessage?token=secret", http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Len(t, out.docs, 1)
+	doc := out.docs[0]
+	assert.Equal(t, "127.0.0.1", doc["name"])
+	assert.Equal(t, "remote", doc["namespace"])
+	assert.Equal(t, true, doc["error"])
+	assert.Equal(t, map[string]interface{}{
+		"request":  map[string]interface{}{"method": "POST", "url": server.URL + "/api/chat.postMessage"},
+		"response": map[string]interface{}{"status": float64(429)},
+	}, doc["http"])
+}
+
+func TestDaemonAddress(t *testing.T) {
+	assert.Equal(t, defaultDaemonAddress, daemonAddress(""))
+	assert.Equal(t, "169.254.79.129:2000", daemonAddress("169.254.79.129:2000"))
+	assert.Equal(t, "10.0.0.2:2000", daemonAddress("tcp:10.0.0.1:2000 udp:10.0.0.2:2000"))
+}
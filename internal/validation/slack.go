@@ -9,9 +9,14 @@ import (
 
 var (
 	// Slack ID format validation.
-	userIDRegex    = regexp.MustCompile(`^[UW][A-Z0-9]{8,}$`)
-	channelIDRegex = regexp.MustCompile(`^[CG][A-Z0-9]{8,}$`)
-	teamIDRegex    = regexp.MustCompile(`^T[A-Z0-9]{8,}$`)
+	userIDRegex       = regexp.MustCompile(`^[UW][A-Z0-9]{8,}$`)
+	channelIDRegex    = regexp.MustCompile(`^[CG][A-Z0-9]{8,}$`)
+	teamIDRegex       = regexp.MustCompile(`^T[A-Z0-9]{8,}$`)
+	enterpriseIDRegex = regexp.MustCompile(`^E[A-Z0-9]{8,}$`)
+	eventIDRegex      = regexp.MustCompile(`^Ev[A-Z0-9]{6,}$`)
+
+	// Session IDs are UUIDs generated by the bot.
+	sessionIDRegex = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
 
 	// Maximum lengths based on Slack documentation.
 	maxIDLength = 50
@@ -22,6 +27,12 @@ var (
 	ErrInvalidChannelID = errors.New("invalid channel ID format")
 	// ErrInvalidTeamID is returned when a Slack team ID has an invalid format.
 	ErrInvalidTeamID = errors.New("invalid team ID format")
+	// ErrInvalidEnterpriseID is returned when a Slack Enterprise Grid ID has an invalid format.
+	ErrInvalidEnterpriseID = errors.New("invalid enterprise ID format")
+	// ErrInvalidEventID is returned when a Slack event ID has an invalid format.
+	ErrInvalidEventID = errors.New("invalid event ID format")
+	// ErrInvalidSessionID is returned when a session ID is not a UUID.
+	ErrInvalidSessionID = errors.New("invalid session ID format")
 	// ErrIDTooLong is returned when a Slack ID exceeds the maximum allowed length.
 	ErrIDTooLong = errors.New("ID exceeds maximum length")
 	// ErrEmptyID is returned when a Slack ID is empty.
@@ -61,6 +72,14 @@ func ValidateSlackID(id, idType string) error {
 		if !teamIDRegex.MatchString(id) {
 			return ErrInvalidTeamID
 		}
+	case "enterprise":
+		if !enterpriseIDRegex.MatchString(id) {
+			return ErrInvalidEnterpriseID
+		}
+	case "event":
+		if !eventIDRegex.MatchString(id) {
+			return ErrInvalidEventID
+		}
 	default:
 		return fmt.Errorf("unknown ID type: %s", idType)
 	}
@@ -83,6 +102,36 @@ func ValidateTeamID(teamID string) error {
 	return ValidateSlackID(teamID, "team")
 }
 
+// ValidateEnterpriseID validates a Slack Enterprise Grid organization ID.
+func ValidateEnterpriseID(enterpriseID string) error {
+	return ValidateSlackID(enterpriseID, "enterprise")
+}
+
+// ValidateWorkspaceID validates the ID a workspace config is saved under: a
+// team ID, or an enterprise ID for an org-wide install.
+func ValidateWorkspaceID(id string) error {
+	if ValidateEnterpriseID(id) == nil {
+		return nil
+	}
+	return ValidateTeamID(id)
+}
+
+// ValidateEventID validates a Slack Events API event ID.
+func ValidateEventID(eventID string) error {
+	return ValidateSlackID(eventID, "event")
+}
+
+// ValidateSessionID validates a standup session ID.
+func ValidateSessionID(sessionID string) error {
+	if sessionID == "" {
+		return ErrEmptyID
+	}
+	if !sessionIDRegex.MatchString(sessionID) {
+		return ErrInvalidSessionID
+	}
+	return nil
+}
+
 // ValidateDate validates a date string in YYYY-MM-DD format.
 func ValidateDate(date string) error {
 	if date == "" {
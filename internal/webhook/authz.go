@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/synaptiq/standup-bot/internal/authz"
+	"github.com/synaptiq/standup-bot/internal/command"
+	"github.com/synaptiq/standup-bot/internal/i18n"
+	"github.com/synaptiq/standup-bot/internal/lambda"
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+// requireRole wraps a slash command handler so only users with at least role
+// in the command's channel can run it.
+func (h *Handler) requireRole(role authz.Role, handler slashHandler) slashHandler {
+	return func(ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation) (events.APIGatewayProxyResponse, error) {
+		err := h.authz.Require(ctx, cmd.ChannelID, cmd.UserID, role)
+		switch {
+		case errors.Is(err, authz.ErrForbidden):
+			return lambda.SlackEphemeralResponse(h.locale(ctx, cmd).T(i18n.ErrorForbidden, inv.Command.Path())), nil
+		case err != nil:
+			return h.errorResponse(ctx, cmd, h.locale(ctx, cmd).T(i18n.ErrorCheckPermissions), err), nil
+		}
+
+		return handler(ctx, cmd, inv)
+	}
+}
+
+// requireActionRole wraps a block action handler so only users with at least
+// role in the channel the action affects, as returned by channelOf, can
+// take it.
+func (h *Handler) requireActionRole(
+	role authz.Role,
+	channelOf func(action *slack.Action) (string, error),
+	handler slack.ActionHandler,
+) slack.ActionHandler {
+	return func(ctx context.Context, payload *slack.InteractionCallback, action *slack.Action) error {
+		channelID, err := channelOf(action)
+		if err != nil {
+			return err
+		}
+
+		if err := h.authz.Require(ctx, channelID, payload.User.ID, role); err != nil {
+			return err
+		}
+
+		return handler(ctx, payload, action)
+	}
+}
+
+// memberActionChannel returns the channel a member prompt's buttons add to.
+func memberActionChannel(action *slack.Action) (string, error) {
+	channelID, _, err := slack.ParseMemberActionValue(action.Value)
+	return channelID, err
+}
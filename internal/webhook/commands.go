@@ -0,0 +1,249 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	"github.com/synaptiq/standup-bot/internal/analytics"
+	"github.com/synaptiq/standup-bot/internal/authz"
+	"github.com/synaptiq/standup-bot/internal/command"
+	"github.com/synaptiq/standup-bot/internal/report"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/standup"
+	"github.com/synaptiq/standup-bot/internal/validation"
+)
+
+// slashHandler handles a parsed slash command.
+type slashHandler func(ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation) (events.APIGatewayProxyResponse, error)
+
+type slashCommandKey struct{}
+
+// slash adapts a slashHandler to run with the slash command being handled.
+func slash(handler slashHandler) command.Handler {
+	return func(ctx context.Context, inv *command.Invocation) (events.APIGatewayProxyResponse, error) {
+		cmd, _ := ctx.Value(slashCommandKey{}).(*slack.SlashCommand)
+		return handler(ctx, cmd, inv)
+	}
+}
+
+// newCommands registers the slash commands the handler serves.
+func (h *Handler) newCommands() *command.Set {
+	return command.NewSet(
+		&command.Command{
+			Name:    "/standup",
+			Summary: "Fill in today's standup",
+			Run:     slash(h.handleStandupCommand),
+			Subcommands: []*command.Command{
+				{
+					Name:    "skip",
+					Summary: "Skip today's standup",
+					Args:    []command.Arg{{Name: "reason", Optional: true, Rest: true}},
+					Run:     slash(h.handleSkipCommand),
+				},
+				{
+					Name:    "summary",
+					Summary: "Post today's summary now (admins only)",
+					Flags:   []command.Flag{forceSummaryFlag},
+					Run:     slash(h.requireRole(authz.RoleChannelAdmin, h.handleSummaryCommand)),
+				},
+				h.configCommand("config"),
+				{
+					Name:    "search",
+					Summary: "Search this channel's standup answers; quote queries of several words",
+					Args: []command.Arg{
+						{Name: "query"},
+						{Name: "user", Optional: true, Validate: validateUserArg},
+						{Name: "days", Optional: true, Validate: validateWindowDays},
+					},
+					Run: slash(h.handleSearchCommand),
+				},
+				{
+					Name:    "prefs",
+					Summary: "View or change your reminder preferences",
+					Run:     slash(h.handlePrefsShowCommand),
+					Subcommands: []*command.Command{
+						{
+							Name:    "show",
+							Summary: "Show your reminder preferences",
+							Run:     slash(h.handlePrefsShowCommand),
+						},
+						{
+							Name:    "set",
+							Summary: "Change a preference; the reminder time is HH:MM in the channel's timezone",
+							Args: []command.Arg{
+								{Name: "key", Choices: standup.PreferenceKeys},
+								{Name: "value", Rest: true},
+							},
+							Run: slash(h.handlePrefsSetCommand),
+						},
+					},
+				},
+			},
+		},
+		h.configCommand("/standup-config"),
+		&command.Command{
+			Name:    "/standup-report",
+			Summary: "Report on standup responses",
+			Subcommands: []*command.Command{
+				{
+					Name:    "export",
+					Summary: fmt.Sprintf("Export up to %d days of responses; dates are YYYY-MM-DD (admins only)", report.MaxRangeDays),
+					Args: []command.Arg{
+						{Name: "format", Optional: true, Choices: []string{string(report.FormatCSV), string(report.FormatJSON)}},
+						{Name: "start", Optional: true, Validate: validation.ValidateDate},
+						{Name: "end", Optional: true, Validate: validation.ValidateDate},
+					},
+					Run: slash(h.requireRole(authz.RoleChannelAdmin, h.handleExportCommand)),
+				},
+				{
+					Name:    "tags",
+					Summary: "Roll up every channel's participation or responses by a user and question tag (workspace admins only)",
+					Args: []command.Arg{
+						{Name: "tag", Validate: validateTagName},
+						{Name: "mode", Optional: true, Choices: []string{string(report.TagParticipation), string(report.TagResponses)}},
+						{Name: "format", Optional: true, Choices: []string{string(report.FormatCSV), string(report.FormatJSON)}},
+						{Name: "start", Optional: true, Validate: validation.ValidateDate},
+						{Name: "end", Optional: true, Validate: validation.ValidateDate},
+					},
+					Run: slash(h.requireRole(authz.RoleWorkspaceAdmin, h.handleTagReportCommand)),
+				},
+				{
+					Name:    "user",
+					Summary: "Show what a user reported in every channel on a day, today unless given (workspace admins only)",
+					Args: []command.Arg{
+						{Name: "user", Validate: validateUserArg},
+						{Name: "date", Optional: true, Validate: validateReportDate},
+					},
+					Run: slash(h.requireRole(authz.RoleWorkspaceAdmin, h.handleUserReportCommand)),
+				},
+			},
+		},
+		&command.Command{
+			Name:    "/standup-stats",
+			Summary: "Show participation stats for this channel",
+			Args: []command.Arg{
+				{Name: "days", Optional: true, Validate: validateWindowDays},
+				{Name: "me", Optional: true, Choices: []string{"me"}},
+			},
+			Run: slash(h.handleStatsCommand),
+		},
+		&command.Command{
+			Name:    "/standup-debug",
+			Summary: "Check the bot's connections, scheduler and this channel's scheduled tasks (admins only)",
+			Run:     slash(h.requireRole(authz.RoleChannelAdmin, h.handleDebugCommand)),
+		},
+	)
+}
+
+// configCommand builds the channel settings command. It's registered both
+// as "/standup config" and as "/standup-config".
+func (h *Handler) configCommand(name string) *command.Command {
+	return &command.Command{
+		Name:    name,
+		Summary: "View or change this channel's standup settings",
+		Run:     slash(h.handleConfigShowCommand),
+		Subcommands: []*command.Command{
+			{
+				Name:    "show",
+				Summary: "Show this channel's settings",
+				Run:     slash(h.handleConfigShowCommand),
+			},
+			{
+				Name:    "setup",
+				Summary: "Set up standups in this channel (workspace admins only)",
+				Run:     slash(h.requireRole(authz.RoleWorkspaceAdmin, h.handleSetupCommand)),
+			},
+			{
+				Name:    "set",
+				Summary: "Change a setting; times are HH:MM in the channel's timezone (admins only)",
+				Args: []command.Arg{
+					{Name: "key", Choices: standup.SettingKeys},
+					{Name: "value", Rest: true},
+				},
+				Run: slash(h.requireRole(authz.RoleChannelAdmin, h.handleConfigSetCommand)),
+			},
+			{
+				Name:    "users",
+				Summary: "List or change the users required to fill in standups",
+				Run:     slash(h.handleUsersListCommand),
+				Subcommands: []*command.Command{
+					{
+						Name:    "list",
+						Summary: "List the required users",
+						Run:     slash(h.handleUsersListCommand),
+					},
+					{
+						Name:    "add",
+						Summary: "Require users, e.g. add @alice @bob (admins only)",
+						Args:    []command.Arg{usersArg},
+						Run:     slash(h.requireRole(authz.RoleChannelAdmin, h.handleUsersAddCommand)),
+					},
+					{
+						Name:    "remove",
+						Summary: "Stop requiring users (admins only)",
+						Args:    []command.Arg{usersArg},
+						Run:     slash(h.requireRole(authz.RoleChannelAdmin, h.handleUsersRemoveCommand)),
+					},
+				},
+			},
+			{
+				Name:    "test-reminder",
+				Summary: "Send yourself this channel's reminder to check its template (admins only)",
+				Run:     slash(h.requireRole(authz.RoleChannelAdmin, h.handleTestReminderCommand)),
+			},
+			{
+				Name:    "post-summary",
+				Summary: "Post today's summary now, e.g. if the scheduled one failed (admins only)",
+				Flags:   []command.Flag{forceSummaryFlag},
+				Run:     slash(h.requireRole(authz.RoleChannelAdmin, h.handleSummaryCommand)),
+			},
+			{
+				Name:    "purge-user",
+				Summary: "Permanently delete everything stored about a user in this workspace (workspace admins only)",
+				Args:    []command.Arg{{Name: "user"}},
+				Flags:   []command.Flag{confirmPurgeFlag},
+				Run:     slash(h.requireRole(authz.RoleWorkspaceAdmin, h.handlePurgeUserCommand)),
+			},
+		},
+	}
+}
+
+// forceSummaryFlag posts today's summary even if it's already posted.
+var forceSummaryFlag = command.Flag{Name: "force", Usage: "Post the summary again if it's already posted", Bool: true}
+
+// confirmPurgeFlag confirms "/standup config purge-user", which can't be
+// undone.
+var confirmPurgeFlag = command.Flag{Name: "confirm", Usage: "Delete the user's data", Bool: true}
+
+// validateTagName checks the tag argument of "/standup-report tags".
+func validateTagName(value string) error {
+	if !botconfig.TagNamePattern.MatchString(value) {
+		return fmt.Errorf("tag must be a name like project")
+	}
+	return nil
+}
+
+// validateReportDate checks the date argument of "/standup-report user".
+func validateReportDate(value string) error {
+	if strings.EqualFold(value, "today") || strings.EqualFold(value, "yesterday") {
+		return nil
+	}
+	if err := validation.ValidateDate(value); err != nil {
+		return fmt.Errorf("date must be today, yesterday or YYYY-MM-DD")
+	}
+	return nil
+}
+
+// validateWindowDays checks the days argument of "/standup-stats".
+func validateWindowDays(value string) error {
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 1 || days > analytics.MaxWindowDays {
+		return fmt.Errorf("days must be between 1 and %d", analytics.MaxWindowDays)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/uuid"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/apperr"
+	"github.com/synaptiq/standup-bot/internal/i18n"
+	"github.com/synaptiq/standup-bot/internal/lambda"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// errorResponse replies to a command that failed with err. failure says
+// what failed, e.g. "Failed to post the summary."
+func (h *Handler) errorResponse(
+	ctx context.Context, cmd *slack.SlashCommand, failure string, err error,
+) events.APIGatewayProxyResponse {
+	return lambda.SlackEphemeralResponse(h.errorText(ctx, cmd.ChannelID, cmd.UserID, failure, err))
+}
+
+// errorKeys explain each kind of error the user can do something about.
+var errorKeys = map[apperr.Kind]i18n.Key{
+	apperr.KindForbidden:   i18n.ErrorNotAllowed,
+	apperr.KindNotFound:    i18n.ErrorNotFound,
+	apperr.KindConflict:    i18n.ErrorConflict,
+	apperr.KindNoAccess:    i18n.ErrorNoAccess,
+	apperr.KindUnavailable: i18n.ErrorUnavailable,
+}
+
+// errorText explains why failure happened as far as the user can do
+// something about it. Errors they can't, like bugs and outages, are logged
+// with a reference they can pass on to find the log entry.
+func (h *Handler) errorText(ctx context.Context, channelID, userID, failure string, err error) string {
+	locale := h.service.UserLocale(ctx, channelID, userID)
+	kind := apperr.KindOf(err)
+	logMsg := strings.TrimSuffix(failure, ".")
+	kindField := botcontext.Field{Key: "error_kind", Value: kind.String()}
+
+	if kind == apperr.KindNotConfigured {
+		return locale.T(i18n.ErrorNotConfigured)
+	}
+	if key, ok := errorKeys[kind]; ok {
+		h.botCtx.Logger().Warn(ctx, logMsg, kindField, botcontext.Field{Key: "error", Value: err.Error()})
+		return failure + " " + locale.T(key)
+	}
+
+	ref := h.errorReference(ctx)
+	h.botCtx.Logger().Error(ctx, logMsg, err, kindField, botcontext.Field{Key: "error_ref", Value: ref})
+	return failure + " " + locale.T(i18n.ErrorInternal, ref)
+}
+
+// errorReference returns the ID a failed request is logged under: its
+// request ID, or a new one for requests without.
+func (h *Handler) errorReference(ctx context.Context) string {
+	if requestID := h.botCtx.RequestID(ctx); requestID != "" {
+		return requestID
+	}
+	return uuid.New().String()
+}
+
+// channelConfigError classifies an error from loading a channel's standup,
+// where a missing record means the channel isn't configured.
+func channelConfigError(err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return apperr.Wrap(apperr.KindNotConfigured, err)
+	}
+	return err
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/synaptiq/standup-bot/internal/analytics"
+	"github.com/synaptiq/standup-bot/internal/command"
+	"github.com/synaptiq/standup-bot/internal/lambda"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/standup"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// userMentionPattern matches an escaped user mention like <@U123|alice>.
+var userMentionPattern = regexp.MustCompile(`^<@([UW][A-Z0-9]+)(\|[^>]*)?>$`)
+
+// validateUserArg checks a user argument: "me" or a user mention.
+func validateUserArg(value string) error {
+	if strings.EqualFold(value, "me") || userMentionPattern.MatchString(value) {
+		return nil
+	}
+	return fmt.Errorf("user must be `me` or an @mention")
+}
+
+// handleSearchCommand handles "/standup search <query> [user] [days]",
+// searching the channel's standup answers. Private channels only let
+// users search their own answers.
+func (h *Handler) handleSearchCommand(
+	ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	days := analytics.DefaultWindowDays
+	if value := inv.Arg("days"); value != "" {
+		days, _ = strconv.Atoi(value) // Checked by validateWindowDays
+	}
+
+	userID := ""
+	switch user := inv.Arg("user"); {
+	case strings.EqualFold(user, "me"):
+		userID = cmd.UserID
+	case user != "":
+		userID = userMentionPattern.FindStringSubmatch(user)[1] // Checked by validateUserArg
+	}
+
+	channelConfig, err := h.store.GetChannelConfig(ctx, cmd.TeamID, cmd.ChannelID)
+	if err != nil {
+		return h.errorResponse(ctx, cmd, "Failed to search.", channelConfigError(err)), nil
+	}
+	if standup.IsPrivate(channelConfig) {
+		if userID != "" && userID != cmd.UserID {
+			return lambda.SlackEphemeralResponse("This channel's standups are private, so you can only search your own answers."), nil
+		}
+		userID = cmd.UserID
+	}
+
+	now := time.Now()
+	query := &store.ResponseQuery{
+		ChannelID: cmd.ChannelID,
+		UserID:    userID,
+		StartDate: now.AddDate(0, 0, -days+1).Format("2006-01-02"),
+		EndDate:   now.Format("2006-01-02"),
+		Text:      inv.Arg("query"),
+	}
+
+	results, more, err := h.service.SearchResponses(ctx, query)
+	if err != nil {
+		return h.errorResponse(ctx, cmd, "Failed to search.", err), nil
+	}
+
+	return lambda.SlackEphemeralBlockResponse(slack.BuildSearchResults(query.Text, days, results, more)), nil
+}
@@ -0,0 +1,276 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/synaptiq/standup-bot/internal/authz"
+	"github.com/synaptiq/standup-bot/internal/command"
+	"github.com/synaptiq/standup-bot/internal/lambda"
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/standup"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// settingLabels labels the channel settings in the settings modal.
+var settingLabels = map[string]string{
+	standup.SettingStartTime:     "Start time",
+	standup.SettingSummaryTime:   "Summary time",
+	standup.SettingReminderTimes: "Reminder times",
+	standup.SettingTimezone:      "Timezone",
+	standup.SettingGracePeriod:   "Grace period for late submissions",
+	standup.SettingLocale:        "Language (en, es, de or fr)",
+	standup.SettingMentions:      "Show users in summaries as (mention, name or both)",
+	standup.SettingWeeklyDigest:  "Weekly digest (day and time, like Fri 17:00)",
+	standup.SettingMonthlyDigest: "Monthly digest (1-28 or last, and a time)",
+}
+
+// handleShortcut handles global shortcuts, started from Slack's shortcuts
+// menu anywhere in the workspace.
+func (h *Handler) handleShortcut(
+	ctx context.Context,
+	payload *slack.InteractionCallback,
+) (events.APIGatewayProxyResponse, error) {
+	switch payload.CallbackID {
+	case slack.ConfigShortcutCallbackID:
+		modal := slack.BuildChannelConfigModal(&slack.ChannelConfigForm{})
+		if err := h.slack.OpenModal(ctx, payload.TriggerID, modal); err != nil {
+			h.botCtx.Logger().Error(ctx, "Failed to open settings modal", err)
+		}
+		return lambda.OK(""), nil
+	default:
+		return lambda.BadRequest("Unknown shortcut"), nil
+	}
+}
+
+// updateChannelConfigModal redraws the settings modal with the settings of
+// the channel just picked in it.
+func (h *Handler) updateChannelConfigModal(ctx context.Context, payload *slack.InteractionCallback) error {
+	channelID, _, _, err := slack.ParseChannelConfigSubmission(payload.View)
+	if err != nil {
+		return err
+	}
+
+	form, err := h.channelConfigForm(ctx, payload.WorkspaceID(), channelID, payload.User.ID)
+	if err != nil {
+		return err
+	}
+
+	return h.slack.UpdateModal(ctx, payload.View.ID, slack.BuildChannelConfigModal(form))
+}
+
+// channelConfigForm returns what the settings modal shows userID for a
+// channel. Settings are only shown to the channel's admins.
+func (h *Handler) channelConfigForm(ctx context.Context, teamID, channelID, userID string) (*slack.ChannelConfigForm, error) {
+	form := &slack.ChannelConfigForm{ChannelID: channelID}
+	if channelID == "" {
+		return form, nil
+	}
+
+	err := h.authz.Require(ctx, channelID, userID, authz.RoleChannelAdmin)
+	if errors.Is(err, authz.ErrForbidden) {
+		form.Notice = fmt.Sprintf("🔒 Only channel admins and workspace admins can change the settings of <#%s>.", channelID)
+		return form, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permissions: %w", err)
+	}
+
+	settings, version, err := h.service.VersionedChannelSettings(ctx, teamID, channelID)
+	if errors.Is(err, store.ErrNotFound) {
+		form.Notice = fmt.Sprintf("Standups aren't configured for <#%s>.", channelID)
+		return form, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range standup.SettingKeys {
+		form.Settings = append(form.Settings, slack.ChannelSetting{
+			Key:   key,
+			Label: settingLabels[key],
+			Value: settings[key],
+			Optional: key == standup.SettingGracePeriod || key == standup.SettingLocale ||
+				key == standup.SettingMentions || key == standup.SettingWeeklyDigest ||
+				key == standup.SettingMonthlyDigest,
+		})
+	}
+	form.Version = version
+	return form, nil
+}
+
+// handleChannelConfigSubmission saves the settings changed in the settings
+// modal. Invalid values are shown next to their inputs, keeping the modal
+// open, and nothing is saved until they're fixed. Settings changed by someone
+// else since the modal showed them aren't overwritten.
+func (h *Handler) handleChannelConfigSubmission(
+	ctx context.Context,
+	payload *slack.InteractionCallback,
+) (events.APIGatewayProxyResponse, error) {
+	channelID, values, version, err := slack.ParseChannelConfigSubmission(payload.View)
+	if err != nil {
+		return lambda.BadRequest("Failed to parse submission"), err
+	}
+	if channelID == "" {
+		return lambda.SlackViewErrors(map[string]string{
+			slack.ChannelConfigChannelBlockID: "Pick a channel to configure.",
+		}), nil
+	}
+
+	// The modal only shows settings to admins, but the submission is checked again
+	err = h.authz.Require(ctx, channelID, payload.User.ID, authz.RoleChannelAdmin)
+	switch {
+	case errors.Is(err, authz.ErrForbidden):
+		return lambda.SlackViewErrors(map[string]string{
+			slack.ChannelConfigChannelBlockID: "Only channel admins and workspace admins can change this channel's settings.",
+		}), nil
+	case err != nil:
+		h.botCtx.Logger().Error(ctx, "Failed to check permissions", err)
+		return lambda.InternalServerError("Failed to check your permissions. Please try again."), nil
+	}
+
+	err = h.service.UpdateChannelSettings(ctx, payload.WorkspaceID(), channelID, version, values)
+	var invalid *standup.InvalidSettingsError
+	switch {
+	case errors.As(err, &invalid):
+		fieldErrors := make(map[string]string, len(invalid.Errors))
+		for key, message := range invalid.Errors {
+			fieldErrors[slack.SettingBlockID(channelID, key)] = security.SanitizeLogValue(message)
+		}
+		return lambda.SlackViewErrors(fieldErrors), nil
+	case errors.Is(err, store.ErrConflict):
+		return lambda.SlackViewErrors(map[string]string{
+			slack.ChannelConfigChannelBlockID: "Another admin changed this channel's settings since you opened them. " +
+				"Close and reopen the settings to see their changes.",
+		}), nil
+	case err != nil:
+		return lambda.SlackViewErrors(map[string]string{
+			slack.ChannelConfigChannelBlockID: h.errorText(ctx, channelID, payload.User.ID,
+				"Failed to save this channel's settings.", channelConfigError(err)),
+		}), nil
+	}
+
+	// Return success (closes modal)
+	return lambda.OK(""), nil
+}
+
+// Replies to users who can't set up standups in a channel.
+const (
+	setupForbiddenText    = "🔒 Only workspace admins can set up standups."
+	channelConfiguredText = "Standups are already set up in this channel. " +
+		"See their settings with `/standup-config show`."
+)
+
+// handleSetupChannelAction opens the setup modal from the prompt shown when
+// the bot is added to a channel, filled in with suggestions. Whoever sets the
+// channel up becomes its admin, so only workspace admins may.
+func (h *Handler) handleSetupChannelAction(
+	ctx context.Context, payload *slack.InteractionCallback, action *slack.Action,
+) error {
+	err := h.authz.Require(ctx, action.Value, payload.User.ID, authz.RoleWorkspaceAdmin)
+	if errors.Is(err, authz.ErrForbidden) {
+		_, err = h.slack.PostEphemeral(ctx, action.Value, payload.User.ID, slack.WithText(setupForbiddenText))
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	configured, err := h.service.ChannelConfigured(ctx, payload.WorkspaceID(), action.Value)
+	if err != nil {
+		return err
+	}
+	if configured {
+		_, err = h.slack.PostEphemeral(ctx, action.Value, payload.User.ID, slack.WithText(channelConfiguredText))
+		return err
+	}
+
+	form := &slack.ChannelSetupForm{ChannelID: action.Value, Questions: standup.DefaultSetupQuestions}
+	for _, key := range standup.SetupSettingKeys {
+		form.Settings = append(form.Settings, slack.ChannelSetting{
+			Key:   key,
+			Label: settingLabels[key],
+			Value: standup.DefaultSetupSettings[key],
+		})
+	}
+	return h.slack.OpenModal(ctx, payload.TriggerID, slack.BuildChannelSetupModal(form))
+}
+
+// handleSetupCommand handles "/standup config setup", offering to set up
+// standups in a channel that has none, as when the bot is added to it.
+func (h *Handler) handleSetupCommand(
+	ctx context.Context, cmd *slack.SlashCommand, _ *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	configured, err := h.service.ChannelConfigured(ctx, cmd.TeamID, cmd.ChannelID)
+	switch {
+	case err != nil:
+		return h.errorResponse(ctx, cmd, "Failed to check this channel's standups.", err), nil
+	case configured:
+		return lambda.SlackEphemeralResponse(channelConfiguredText), nil
+	}
+
+	return lambda.SlackEphemeralBlockResponse(slack.BuildChannelSetupPrompt(cmd.ChannelID)), nil
+}
+
+// handleDismissSetupAction removes the setup prompt.
+func (h *Handler) handleDismissSetupAction(
+	ctx context.Context, payload *slack.InteractionCallback, _ *slack.Action,
+) error {
+	// The prompt may be ephemeral, so it can only be removed through the response URL
+	if payload.ResponseURL == "" {
+		return nil
+	}
+	return h.slack.PostToResponseURL(ctx, payload.ResponseURL, &slack.ResponseMessage{DeleteOriginal: true})
+}
+
+// handleChannelSetupSubmission starts standups in a channel with what was
+// entered in the setup modal. Invalid settings are shown next to their
+// inputs, keeping the modal open.
+func (h *Handler) handleChannelSetupSubmission(
+	ctx context.Context,
+	payload *slack.InteractionCallback,
+) (events.APIGatewayProxyResponse, error) {
+	channelID, values, questions, err := slack.ParseChannelSetupSubmission(payload.View)
+	if err != nil {
+		return lambda.BadRequest("Failed to parse submission"), err
+	}
+
+	// The modal is only opened for workspace admins, but the submission is checked again
+	err = h.authz.Require(ctx, channelID, payload.User.ID, authz.RoleWorkspaceAdmin)
+	switch {
+	case errors.Is(err, authz.ErrForbidden):
+		return lambda.SlackViewErrors(map[string]string{
+			slack.ChannelSetupQuestionsBlockID: setupForbiddenText,
+		}), nil
+	case err != nil:
+		h.botCtx.Logger().Error(ctx, "Failed to check permissions", err)
+		return lambda.InternalServerError("Failed to check your permissions. Please try again."), nil
+	}
+
+	err = h.service.SetupChannel(ctx, payload.WorkspaceID(), channelID, payload.User.ID, values, questions)
+	var invalid *standup.InvalidSettingsError
+	switch {
+	case errors.As(err, &invalid):
+		fieldErrors := make(map[string]string, len(invalid.Errors))
+		for key, message := range invalid.Errors {
+			fieldErrors[slack.SettingBlockID(channelID, key)] = security.SanitizeLogValue(message)
+		}
+		return lambda.SlackViewErrors(fieldErrors), nil
+	case errors.Is(err, standup.ErrChannelConfigured):
+		return lambda.SlackViewErrors(map[string]string{
+			slack.ChannelSetupQuestionsBlockID: "Standups are already set up in this channel.",
+		}), nil
+	case err != nil:
+		return lambda.SlackViewErrors(map[string]string{
+			slack.ChannelSetupQuestionsBlockID: h.errorText(ctx, channelID, payload.User.ID,
+				"Failed to set up standups.", err),
+		}), nil
+	}
+
+	// Return success (closes modal)
+	return lambda.OK(""), nil
+}
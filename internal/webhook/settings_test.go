@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botconfig "github.com/synaptiq/standup-bot/config"
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/slack/slacktest"
+	"github.com/synaptiq/standup-bot/internal/standup"
+	"github.com/synaptiq/standup-bot/internal/store"
+	"github.com/synaptiq/standup-bot/internal/store/memory"
+)
+
+const (
+	teamID       = "T1234567890"
+	setupChannel = "C9999999999" // Not configured
+	memberID     = "U1111111111"
+	adminID      = "U2222222222" // A workspace admin
+)
+
+// newSetupHandler creates a handler with a configured channel and a new one,
+// and a fake Slack client knowing a member and a workspace admin.
+func newSetupHandler(t *testing.T) (*Handler, *slacktest.Client, store.Store) {
+	t.Helper()
+
+	cfg, err := botconfig.ParseYAML([]byte(`version: "1.0"
+channels:
+  - id: "C1234567890"
+    name: "engineering"
+    schedule:
+      timezone: "UTC"
+      summary_time: "10:00"
+    users:
+      - id: "U1111111111"
+        name: "alice"
+    questions: ["Yesterday?"]
+`))
+	require.NoError(t, err)
+	botCtx, err := botcontext.New(botcontext.Options{
+		Config: cfg,
+		Logger: botcontext.NewLogger(io.Discard, botcontext.LevelError, ""),
+	})
+	require.NoError(t, err)
+
+	slackClient := slacktest.New()
+	slackClient.Users[memberID] = &slack.UserInfo{ID: memberID, Name: "alice"}
+	slackClient.Users[adminID] = &slack.UserInfo{ID: adminID, Name: "bob", IsAdmin: true}
+	dataStore := memory.NewStore()
+
+	h := New(Options{
+		BotContext:  botCtx,
+		Store:       dataStore,
+		SlackClient: slackClient,
+		Service:     standup.NewService(botCtx, dataStore, slackClient),
+	})
+	return h, slackClient, dataStore
+}
+
+// interact sends h an interaction payload.
+func interact(t *testing.T, h *Handler, payload *slack.InteractionCallback) map[string]any {
+	t.Helper()
+
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+	response, err := h.handleInteraction(context.Background(), string(body))
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	if response.Body != "" {
+		require.NoError(t, json.Unmarshal([]byte(response.Body), &decoded))
+	}
+	return decoded
+}
+
+func TestSetupCommandRequiresWorkspaceAdmin(t *testing.T) {
+	h, _, _ := newSetupHandler(t)
+
+	run := func(userID, channelID string) string {
+		response, err := h.handleSlashCommand(context.Background(), url.Values{
+			"team_id":    {teamID},
+			"command":    {"/standup-config"},
+			"text":       {"setup"},
+			"channel_id": {channelID},
+			"user_id":    {userID},
+		})
+		require.NoError(t, err)
+		return response.Body
+	}
+
+	assert.Contains(t, run(memberID, setupChannel), "Only channel admins and workspace admins")
+	assert.Contains(t, run(adminID, setupChannel), slack.ActionSetupChannel)
+	assert.Contains(t, run(adminID, "C1234567890"), "already set up")
+}
+
+func TestSetupActionRequiresWorkspaceAdmin(t *testing.T) {
+	h, slackClient, _ := newSetupHandler(t)
+
+	press := func(userID, channelID string) {
+		interact(t, h, &slack.InteractionCallback{
+			Type:      "block_actions",
+			TriggerID: "trigger-" + userID,
+			User:      slack.User{ID: userID},
+			Team:      slack.Team{ID: teamID},
+			Actions:   []slack.Action{{ActionID: slack.ActionSetupChannel, Value: channelID}},
+		})
+	}
+
+	press(memberID, setupChannel)
+	assert.Empty(t, slackClient.Calls("views.open"))
+	ephemerals := slackClient.Calls("chat.postEphemeral")
+	require.Len(t, ephemerals, 1)
+	assert.Equal(t, memberID, ephemerals[0].User)
+	assert.Contains(t, ephemerals[0].Message.Text, "Only workspace admins")
+
+	press(adminID, "C1234567890")
+	assert.Empty(t, slackClient.Calls("views.open"), "configured channels can't be set up again")
+
+	press(adminID, setupChannel)
+	opened := slackClient.Calls("views.open")
+	require.Len(t, opened, 1)
+	assert.Equal(t, "trigger-"+adminID, opened[0].ID)
+}
+
+func TestSetupSubmissionRequiresWorkspaceAdmin(t *testing.T) {
+	h, _, dataStore := newSetupHandler(t)
+
+	submit := func(userID string) map[string]any {
+		view := &slack.View{
+			CallbackID:      slack.ChannelSetupCallbackID,
+			PrivateMetadata: `{"channel_id":"` + setupChannel + `"}`,
+			State: &slack.ViewState{Values: map[string]map[string]slack.ViewStateValue{
+				slack.ChannelSetupQuestionsBlockID: {"questions": {Value: "Yesterday?\nToday?"}},
+			}},
+		}
+		for key, value := range standup.DefaultSetupSettings {
+			view.State.Values[slack.SettingBlockID(setupChannel, key)] = map[string]slack.ViewStateValue{
+				"value": {Value: value},
+			}
+		}
+		return interact(t, h, &slack.InteractionCallback{
+			Type: "view_submission",
+			User: slack.User{ID: userID},
+			Team: slack.Team{ID: teamID},
+			View: view,
+		})
+	}
+
+	response := submit(memberID)
+	assert.Equal(t, "errors", response["response_action"])
+	_, err := dataStore.GetChannelConfig(context.Background(), teamID, setupChannel)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	assert.Nil(t, submit(adminID)["response_action"])
+	config, err := dataStore.GetChannelConfig(context.Background(), teamID, setupChannel)
+	require.NoError(t, err)
+	assert.Equal(t, []string{adminID}, config.Admins)
+}
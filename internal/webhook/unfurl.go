@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/report"
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/slack"
+)
+
+// handleLinkShared unfurls download links to reports in the export bucket
+// into cards saying what they cover. The cards are built from the links
+// alone, so they show nothing the links don't already say.
+func (h *Handler) handleLinkShared(ctx context.Context, wrapper *slack.EventWrapper) {
+	if h.reportBucket == "" {
+		return
+	}
+
+	now := time.Now()
+	unfurls := make(map[string][]slack.Block)
+	for _, shared := range wrapper.Event.Links {
+		link, ok := report.ParseLink(shared.URL, h.reportBucket)
+		if !ok {
+			continue
+		}
+		unfurls[shared.URL] = slack.BuildReportUnfurl(reportPreview(link), now)
+	}
+	if len(unfurls) == 0 {
+		return
+	}
+
+	if err := h.slack.UnfurlLinks(ctx, wrapper.Event.UnfurlTarget(), unfurls); err != nil {
+		h.botCtx.Logger().Error(ctx, "Failed to unfurl report links", err,
+			botcontext.Field{Key: "channel_id", Value: security.SanitizeLogValue(wrapper.Event.Channel)},
+		)
+	}
+}
+
+// reportPreview describes a report link for its unfurl.
+func reportPreview(link *report.Link) *slack.ReportPreview {
+	title := fmt.Sprintf("📦 Standup export for <#%s>", security.SanitizeLogValue(link.ChannelID))
+	if link.Tag != "" {
+		title = fmt.Sprintf("📊 Standup report by %s", security.SanitizeLogValue(link.Tag))
+	}
+	return &slack.ReportPreview{
+		Title:     title,
+		StartDate: link.StartDate,
+		EndDate:   link.EndDate,
+		Format:    string(link.Format),
+		ExpiresAt: link.ExpiresAt,
+	}
+}
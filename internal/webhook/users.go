@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/synaptiq/standup-bot/internal/command"
+	"github.com/synaptiq/standup-bot/internal/lambda"
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/standup"
+	"github.com/synaptiq/standup-bot/internal/validation"
+)
+
+// usersArg takes the users of "/standup config users add|remove" as
+// mentions.
+var usersArg = command.Arg{Name: "users", Rest: true}
+
+// mentionedUserIDs returns the IDs of the users mentioned in text, like
+// "<@U123|alice> <@U456>". Words that aren't mentions are returned as typed,
+// for the service to reject.
+func mentionedUserIDs(text string) []string {
+	var userIDs []string
+	for _, word := range strings.Fields(text) {
+		if match := userMentionPattern.FindStringSubmatch(word); match != nil {
+			word = match[1]
+		}
+		userIDs = append(userIDs, word)
+	}
+	return userIDs
+}
+
+// handleUsersListCommand handles "/standup config users list", showing the
+// channel's required users.
+func (h *Handler) handleUsersListCommand(
+	ctx context.Context, cmd *slack.SlashCommand, _ *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	roster, err := h.service.ChannelRoster(ctx, cmd.TeamID, cmd.ChannelID)
+	if err != nil {
+		return h.errorResponse(ctx, cmd, "Failed to get the channel's users.", channelConfigError(err)), nil
+	}
+
+	return lambda.SlackEphemeralResponse(formatRoster(roster)), nil
+}
+
+// handleUsersAddCommand handles "/standup config users add @a @b".
+func (h *Handler) handleUsersAddCommand(
+	ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	change, err := h.service.AddChannelUsers(ctx, cmd.TeamID, cmd.ChannelID, mentionedUserIDs(inv.Arg("users")))
+	return h.rosterChangeResponse(ctx, cmd, inv, change, err, "Added", "already on the roster")
+}
+
+// handleUsersRemoveCommand handles "/standup config users remove @a @b".
+func (h *Handler) handleUsersRemoveCommand(
+	ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	change, err := h.service.RemoveChannelUsers(ctx, cmd.TeamID, cmd.ChannelID, mentionedUserIDs(inv.Arg("users")))
+	return h.rosterChangeResponse(ctx, cmd, inv, change, err, "Removed", "not on the roster")
+}
+
+// handlePurgeUserCommand handles "/standup config purge-user @alice",
+// erasing a user's standup data in the workspace once confirmed.
+func (h *Handler) handlePurgeUserCommand(
+	ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	userID := mentionedUserIDs(inv.Arg("user"))[0]
+	if err := validation.ValidateUserID(userID); err != nil {
+		return lambda.SlackEphemeralResponse(fmt.Sprintf("Mention the user like @alice.\nUsage: `%s`",
+			inv.Command.Usage())), nil
+	}
+	if !inv.Bool("confirm") {
+		return lambda.SlackEphemeralResponse(fmt.Sprintf(
+			"This permanently deletes <@%s>'s standup responses, skips, reminders, streaks and preferences in "+
+				"every channel of this workspace. Use `%s %s --confirm` to go ahead.",
+			userID, inv.Command.Path(), inv.Arg("user"))), nil
+	}
+
+	deleted, err := h.service.PurgeUserData(ctx, userID, cmd.UserID)
+	if err != nil {
+		return h.errorResponse(ctx, cmd, "Failed to purge the user's data; run the command again to finish.", err), nil
+	}
+
+	return lambda.SlackEphemeralResponse(fmt.Sprintf("🗑️ Deleted %d records about <@%s>.", deleted, userID)), nil
+}
+
+// rosterChangeResponse confirms a roster change, listing the roster after
+// it, or explains why it failed.
+func (h *Handler) rosterChangeResponse(
+	ctx context.Context,
+	cmd *slack.SlashCommand,
+	inv *command.Invocation,
+	change *standup.RosterChange,
+	err error,
+	changed, unchanged string,
+) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, standup.ErrInvalidUser):
+		return lambda.SlackEphemeralResponse(fmt.Sprintf("%s; mention users like @alice.\nUsage: `%s`",
+			security.SanitizeLogValue(err.Error()), inv.Command.Usage())), nil
+	case err != nil:
+		return h.errorResponse(ctx, cmd, "Failed to update the channel's users.", channelConfigError(err)), nil
+	}
+
+	var b strings.Builder
+	if len(change.Changed) > 0 {
+		fmt.Fprintf(&b, "✅ %s %s.\n", changed, mentions(change.Changed))
+	}
+	if len(change.Unchanged) > 0 {
+		fmt.Fprintf(&b, "%s %s.\n", mentions(change.Unchanged), unchanged)
+	}
+	b.WriteString(formatRoster(&change.Roster))
+
+	return lambda.SlackEphemeralResponse(b.String()), nil
+}
+
+// formatRoster lists a channel's required users, flagging deactivated ones.
+func formatRoster(roster *standup.Roster) string {
+	if len(roster.Users) == 0 {
+		return "This channel has no required users. Add them with `/standup config users add @alice`."
+	}
+
+	names := make([]string, 0, len(roster.Users))
+	for _, userID := range roster.Users {
+		name := fmt.Sprintf("<@%s>", userID)
+		if slices.Contains(roster.Deactivated, userID) {
+			name += " (deactivated)"
+		}
+		names = append(names, name)
+	}
+	return fmt.Sprintf("*Required users (%d):* %s", len(roster.Users), strings.Join(names, ", "))
+}
+
+// mentions formats user IDs as mentions.
+func mentions(userIDs []string) string {
+	names := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		names[i] = fmt.Sprintf("<@%s>", userID)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,1053 @@
+// Package webhook handles Slack slash commands, interactions and events.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	botcontext "github.com/synaptiq/standup-bot/context"
+	"github.com/synaptiq/standup-bot/internal/analytics"
+	"github.com/synaptiq/standup-bot/internal/authz"
+	"github.com/synaptiq/standup-bot/internal/command"
+	"github.com/synaptiq/standup-bot/internal/i18n"
+	"github.com/synaptiq/standup-bot/internal/install"
+	"github.com/synaptiq/standup-bot/internal/lambda"
+	"github.com/synaptiq/standup-bot/internal/metrics"
+	"github.com/synaptiq/standup-bot/internal/queue"
+	"github.com/synaptiq/standup-bot/internal/report"
+	"github.com/synaptiq/standup-bot/internal/security"
+	"github.com/synaptiq/standup-bot/internal/slack"
+	"github.com/synaptiq/standup-bot/internal/standup"
+	"github.com/synaptiq/standup-bot/internal/store"
+)
+
+// defaultExportDays is the range of exports and reports when no dates are
+// given.
+const defaultExportDays = 30
+
+// snoozeDuration is how long the "Snooze" reminder button delays a reminder.
+const snoozeDuration = time.Hour
+
+// Options contains the dependencies of a webhook handler.
+type Options struct {
+	BotContext  botcontext.BotContext
+	Store       store.Store
+	SlackClient slack.Client
+	Service     *standup.Service
+	Verifier    *slack.RequestVerifier // nil skips signature checks; local development only
+	TaskQueue   *queue.Sender          // nil when long-running work is not queued
+	Metrics     metrics.Sink           // nil publishes to CloudWatch via metrics.Default
+	// ReportBucket is where exports are uploaded, to unfurl links to them;
+	// "" leaves shared links alone
+	ReportBucket string
+}
+
+// Handler routes Slack requests received by the webhook.
+type Handler struct {
+	botCtx   botcontext.BotContext
+	store    store.Store
+	slack    slack.Client
+	service  *standup.Service
+	verifier *slack.RequestVerifier
+	tasks    *queue.Sender
+	metrics  metrics.Sink
+	stats    *analytics.Engine
+	authz    *authz.Authorizer
+	actions  *slack.ActionRouter
+	commands *command.Set
+
+	reportBucket string
+}
+
+// New creates a webhook handler.
+func New(opts Options) *Handler {
+	h := &Handler{
+		botCtx:   opts.BotContext,
+		store:    opts.Store,
+		slack:    opts.SlackClient,
+		service:  opts.Service,
+		verifier: opts.Verifier,
+		tasks:    opts.TaskQueue,
+		metrics:  opts.Metrics,
+		stats:    analytics.NewEngine(opts.Store),
+		authz:    authz.NewAuthorizer(opts.Service, opts.SlackClient),
+
+		reportBucket: opts.ReportBucket,
+	}
+
+	if h.metrics == nil {
+		h.metrics = metrics.Default()
+	}
+
+	// Register block action handlers
+	h.actions = slack.NewActionRouter()
+	h.actions.Handle(slack.ActionSubmitNow, h.handleSubmitNowAction)
+	h.actions.Handle(slack.ActionSkipToday, h.handleSkipTodayAction)
+	h.actions.Handle(slack.ActionSnooze, h.handleSnoozeAction)
+	h.actions.Handle(slack.ActionOpenChannel, h.handleOpenChannelAction)
+	h.actions.Handle(slack.ActionPrefsReminders, h.handlePreferenceAction(standup.PreferenceReminders))
+	h.actions.Handle(slack.ActionPrefsDelivery, h.handlePreferenceAction(standup.PreferenceDelivery))
+	h.actions.Handle(slack.ActionAddMember,
+		h.requireActionRole(authz.RoleChannelAdmin, memberActionChannel, h.handleAddMemberAction))
+	h.actions.Handle(slack.ActionDismissMember,
+		h.requireActionRole(authz.RoleChannelAdmin, memberActionChannel, h.handleDismissMemberAction))
+	h.actions.Handle(slack.ActionReviewSummary, h.handleReviewSummaryAction)
+	h.actions.Handle(slack.ActionSetupChannel, h.handleSetupChannelAction)
+	h.actions.Handle(slack.ActionDismissSetup, h.handleDismissSetupAction)
+
+	h.commands = h.newCommands()
+
+	return h
+}
+
+// Lambda returns the handler wrapped in the standard middleware.
+func (h *Handler) Lambda() lambda.Handler {
+	return lambda.Chain(
+		lambda.StandardMiddleware(h.botCtx),
+		lambda.WithMetrics(h.metrics),
+		lambda.WithSlackVerification(h.botCtx, h.verifier, h.metrics),
+		lambda.WithIdempotency(h.botCtx, h.store),
+	)(h.handle)
+}
+
+//nolint:gocritic // Lambda requires value types for request
+func (h *Handler) handle(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Requests reaching here were verified by WithSlackVerification
+	contentType := lambda.Header(&request, "Content-Type")
+
+	// Handle URL verification challenge
+	if contentType == "application/json" {
+		var challenge struct {
+			Type      string `json:"type"`
+			Challenge string `json:"challenge"`
+		}
+		if err := json.Unmarshal([]byte(request.Body), &challenge); err == nil {
+			if challenge.Type == "url_verification" {
+				return lambda.OK(challenge.Challenge), nil
+			}
+		}
+	}
+
+	// Route based on content type
+	switch {
+	case contentType == "application/x-www-form-urlencoded":
+		// Slash command or interactive component
+		values, err := url.ParseQuery(request.Body)
+		if err != nil {
+			return lambda.BadRequest("Invalid form data"), err
+		}
+
+		if values.Get("command") != "" {
+			return h.handleSlashCommand(ctx, values)
+		} else if values.Get("payload") != "" {
+			return h.handleInteraction(ctx, values.Get("payload"))
+		}
+
+	case contentType == "application/json":
+		// Event subscription
+		return h.handleEvent(ctx, request.Body)
+	}
+
+	return lambda.BadRequest("Unsupported request type"), nil
+}
+
+func (h *Handler) handleSlashCommand(ctx context.Context, values url.Values) (events.APIGatewayProxyResponse, error) {
+	cmd := slack.SlashCommand{
+		Token:       values.Get("token"),
+		TeamID:      values.Get("team_id"),
+		TeamDomain:  values.Get("team_domain"),
+		ChannelID:   values.Get("channel_id"),
+		ChannelName: values.Get("channel_name"),
+		UserID:      values.Get("user_id"),
+		UserName:    values.Get("user_name"),
+		Command:     values.Get("command"),
+		Text:        values.Get("text"),
+		ResponseURL: values.Get("response_url"),
+		TriggerID:   values.Get("trigger_id"),
+
+		EnterpriseID:        values.Get("enterprise_id"),
+		IsEnterpriseInstall: values.Get("is_enterprise_install") == "true",
+	}
+
+	// Add user context
+	ctx = h.botCtx.WithUserID(ctx, cmd.UserID)
+	ctx = h.botCtx.WithChannelID(ctx, cmd.ChannelID)
+
+	// Scope to the workspace, in case the deployment serves several
+	ctx, ok := h.service.WithTeam(ctx, cmd.TeamID)
+	if !ok {
+		return lambda.SlackEphemeralResponse("Standups aren't set up for this workspace."), nil
+	}
+
+	logger := h.botCtx.Logger()
+	logger.Info(ctx, "Slash command received",
+		botcontext.Field{Key: "command", Value: security.SanitizeLogValue(cmd.Command)},
+		botcontext.Field{Key: "text", Value: security.SanitizeLogValue(cmd.Text)},
+	)
+
+	inv, err := h.commands.Parse(cmd.Command, cmd.Text)
+	var usageErr *command.UsageError
+	switch {
+	case errors.As(err, &usageErr):
+		return lambda.SlackEphemeralResponse(usageErr.Error()), nil
+	case err != nil:
+		return lambda.SlackEphemeralResponse("Unknown command"), nil
+	case inv.Help:
+		return lambda.SlackEphemeralResponse(inv.Command.Help()), nil
+	}
+
+	return inv.Command.Run(context.WithValue(ctx, slashCommandKey{}, &cmd), inv)
+}
+
+// locale returns the locale of replies to the user running cmd.
+func (h *Handler) locale(ctx context.Context, cmd *slack.SlashCommand) i18n.Locale {
+	return h.service.UserLocale(ctx, cmd.ChannelID, cmd.UserID)
+}
+
+func (h *Handler) handleStandupCommand(
+	ctx context.Context, cmd *slack.SlashCommand, _ *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	// Open standup modal
+	err := h.service.OpenStandupModal(ctx, cmd.TriggerID, cmd.ChannelID, cmd.UserID)
+	if errors.Is(err, standup.ErrStandupClosed) {
+		return lambda.SlackEphemeralResponse(h.locale(ctx, cmd).T(i18n.ErrorStandupClosed)), nil
+	}
+	if err != nil {
+		return h.errorResponse(ctx, cmd, h.locale(ctx, cmd).T(i18n.ErrorOpenStandup), channelConfigError(err)), nil
+	}
+
+	// Return empty response (modal will handle interaction)
+	return lambda.OK(""), nil
+}
+
+// handleSkipCommand handles "/standup skip [reason]", recording that the user
+// is skipping today's standup so summaries and stats don't count them as missing.
+func (h *Handler) handleSkipCommand(
+	ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	reason := inv.Arg("reason")
+	locale := h.locale(ctx, cmd)
+	if err := h.service.SkipToday(ctx, cmd.ChannelID, cmd.UserID, reason); err != nil {
+		return h.errorResponse(ctx, cmd, locale.T(i18n.ErrorSkip), channelConfigError(err)), nil
+	}
+
+	if reason == "" {
+		return lambda.SlackEphemeralResponse(locale.T(i18n.SkippedToday)), nil
+	}
+	return lambda.SlackEphemeralResponse(locale.T(i18n.SkippedTodayWithReason, security.SanitizeLogValue(reason))), nil
+}
+
+// handleConfigShowCommand handles "/standup config show", listing the
+// channel's settings.
+func (h *Handler) handleConfigShowCommand(
+	ctx context.Context, cmd *slack.SlashCommand, _ *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	settings, err := h.service.ChannelSettings(ctx, cmd.TeamID, cmd.ChannelID)
+	if err != nil {
+		return h.errorResponse(ctx, cmd, "Failed to load settings.", channelConfigError(err)), nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*Standup settings for <#%s>*", cmd.ChannelID)
+	for _, key := range standup.SettingKeys {
+		value := settings[key]
+		if value == "" {
+			value = "not set"
+		}
+		fmt.Fprintf(&b, "\n• `%s`: %s", key, value)
+	}
+	b.WriteString("\n\nChange one with `/standup config set <key> <value>`.")
+
+	return lambda.SlackEphemeralResponse(b.String()), nil
+}
+
+// handleConfigSetCommand handles "/standup config set <key> <value>".
+func (h *Handler) handleConfigSetCommand(
+	ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	key, value := inv.Arg("key"), inv.Arg("value")
+
+	err := h.service.UpdateChannelSetting(ctx, cmd.TeamID, cmd.ChannelID, key, value)
+	switch {
+	case errors.Is(err, standup.ErrInvalidSetting):
+		return lambda.SlackEphemeralResponse(fmt.Sprintf("%s\nUsage: `%s`",
+			security.SanitizeLogValue(err.Error()), inv.Command.Usage())), nil
+	case err != nil:
+		return h.errorResponse(ctx, cmd, "Failed to update the setting.", channelConfigError(err)), nil
+	}
+
+	return lambda.SlackEphemeralResponse(fmt.Sprintf("✅ Set `%s` to %s.", key, security.SanitizeLogValue(value))), nil
+}
+
+// handleTestReminderCommand handles "/standup config test-reminder", sending
+// the channel's reminder only to the admin who asked for it.
+func (h *Handler) handleTestReminderCommand(
+	ctx context.Context, cmd *slack.SlashCommand, _ *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	test, err := h.service.SendTestReminder(ctx, cmd.TeamID, cmd.ChannelID, cmd.UserID)
+	if err != nil {
+		return h.errorResponse(ctx, cmd, "Failed to send the test reminder.", channelConfigError(err)), nil
+	}
+
+	var b strings.Builder
+	b.WriteString("🧪 Sent you a test reminder by DM.")
+	if len(test.Times) > 0 {
+		fmt.Fprintf(&b, " Reminders go out at %s (%s).", strings.Join(test.Times, ", "), test.Timezone)
+	} else {
+		b.WriteString(" This channel has no reminder times; set them with `/standup config set reminder_times`.")
+	}
+	if test.TemplateError != nil {
+		fmt.Fprintf(&b, "\n:warning: The reminder template couldn't be rendered, so it's sent as written: `%s`",
+			security.SanitizeLogValue(test.TemplateError.Error()))
+	}
+
+	return lambda.SlackEphemeralResponse(b.String()), nil
+}
+
+// handlePrefsShowCommand handles "/standup prefs show", showing the user's
+// reminder preferences with buttons to change them.
+func (h *Handler) handlePrefsShowCommand(
+	ctx context.Context, cmd *slack.SlashCommand, _ *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	prefs, err := h.service.UserPreferences(ctx, cmd.UserID)
+	if err != nil {
+		return h.errorResponse(ctx, cmd, "Failed to load your preferences.", err), nil
+	}
+
+	return lambda.SlackEphemeralBlockResponse(preferencesMessage(prefs)), nil
+}
+
+// handlePrefsSetCommand handles "/standup prefs set <key> <value>".
+func (h *Handler) handlePrefsSetCommand(
+	ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	key, value := inv.Arg("key"), inv.Arg("value")
+
+	_, err := h.service.UpdateUserPreference(ctx, cmd.UserID, key, value)
+	switch {
+	case errors.Is(err, standup.ErrInvalidSetting):
+		return lambda.SlackEphemeralResponse(fmt.Sprintf("%s\nUsage: `%s`",
+			security.SanitizeLogValue(err.Error()), inv.Command.Usage())), nil
+	case err != nil:
+		return h.errorResponse(ctx, cmd, "Failed to update your preference.", err), nil
+	}
+
+	return lambda.SlackEphemeralResponse(fmt.Sprintf("✅ Set `%s` to %s.", key, security.SanitizeLogValue(value))), nil
+}
+
+// preferencesMessage builds the message showing prefs.
+func preferencesMessage(prefs *store.UserPreferences) []slack.Block {
+	return slack.BuildPreferencesMessage(prefs.RemindersOff, prefs.ReminderTime, prefs.Delivery == store.DeliverChannel,
+		prefs.Locale)
+}
+
+// handleSummaryCommand handles "/standup summary" and "/standup-config
+// post-summary", posting today's summary without waiting for the scheduled
+// time. A summary that's already posted is posted again with --force.
+func (h *Handler) handleSummaryCommand(
+	ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	err := h.service.PostSummaryNow(ctx, cmd.ChannelID, inv.Bool("force"))
+	if errors.Is(err, standup.ErrSummaryPosted) {
+		return lambda.SlackEphemeralResponse(fmt.Sprintf(
+			"Today's summary is already posted. Use `%s --force` to post it again.", inv.Command.Path())), nil
+	}
+	var copyErr *standup.SummaryCopyError
+	if errors.As(err, &copyErr) {
+		return lambda.SlackEphemeralResponse(fmt.Sprintf(
+			"📊 Today's summary is posted, but copying it to %s failed.", recipientMentions(copyErr.Recipients()))), nil
+	}
+	if err != nil {
+		return h.errorResponse(ctx, cmd, "Failed to post the summary.", channelConfigError(err)), nil
+	}
+
+	return lambda.SlackEphemeralResponse("📊 Today's summary is posted."), nil
+}
+
+// recipientMentions mentions summary recipients: users and channels.
+func recipientMentions(recipients []string) string {
+	mentioned := make([]string, 0, len(recipients))
+	for _, id := range recipients {
+		if strings.HasPrefix(id, "U") || strings.HasPrefix(id, "W") {
+			mentioned = append(mentioned, "<@"+id+">")
+		} else {
+			mentioned = append(mentioned, "<#"+id+">")
+		}
+	}
+	return strings.Join(mentioned, ", ")
+}
+
+// handleExportCommand handles "/standup-report export [csv|json] [start] [end]".
+// Dates are YYYY-MM-DD; the range defaults to the last 30 days.
+func (h *Handler) handleExportCommand(
+	ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	format := report.FormatCSV
+	if parsed, ok := report.ParseFormat(inv.Arg("format")); ok {
+		format = parsed
+	}
+
+	startDate, endDate := reportRange(inv)
+	if _, _, err := report.ParseRange(startDate, endDate); err != nil {
+		return lambda.SlackEphemeralResponse(fmt.Sprintf("The export range must cover at most %d days.\nUsage: `%s`",
+			report.MaxRangeDays, inv.Command.Usage())), nil
+	}
+	if h.tasks == nil {
+		return lambda.SlackEphemeralResponse("Exports aren't configured for this workspace."), nil
+	}
+
+	task := &queue.Task{
+		Type:        queue.TaskGenerateReport,
+		TeamID:      cmd.TeamID,
+		ChannelID:   cmd.ChannelID,
+		UserID:      cmd.UserID,
+		ResponseURL: cmd.ResponseURL,
+		Payload: map[string]interface{}{
+			"report_type": "export",
+			"format":      string(format),
+			"start_date":  startDate,
+			"end_date":    endDate,
+		},
+	}
+	if err := h.tasks.Send(ctx, task); err != nil {
+		return h.errorResponse(ctx, cmd, "Failed to start the export.", err), nil
+	}
+
+	return lambda.SlackEphemeralResponse(fmt.Sprintf(
+		"Exporting responses from %s to %s as %s. I'll DM you a download link when it's ready.",
+		startDate, endDate, strings.ToUpper(string(format)))), nil
+}
+
+// handleTagReportCommand handles "/standup-report tags <tag> [participation|responses]
+// [csv|json] [start] [end]", rolling up the standups of the workspace's
+// channels by the values of a user and question tag, e.g. project.
+func (h *Handler) handleTagReportCommand(
+	ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	mode := report.TagParticipation
+	if parsed, ok := report.ParseTagMode(inv.Arg("mode")); ok {
+		mode = parsed
+	}
+	format := report.FormatCSV
+	if parsed, ok := report.ParseFormat(inv.Arg("format")); ok {
+		format = parsed
+	}
+
+	startDate, endDate := reportRange(inv)
+	if _, _, err := report.ParseRange(startDate, endDate); err != nil {
+		return lambda.SlackEphemeralResponse(fmt.Sprintf("The report range must cover at most %d days.\nUsage: `%s`",
+			report.MaxRangeDays, inv.Command.Usage())), nil
+	}
+	if h.tasks == nil {
+		return lambda.SlackEphemeralResponse("Reports aren't configured for this workspace."), nil
+	}
+
+	tag := inv.Arg("tag")
+	task := &queue.Task{
+		Type:        queue.TaskGenerateReport,
+		TeamID:      cmd.TeamID,
+		ChannelID:   cmd.ChannelID,
+		UserID:      cmd.UserID,
+		ResponseURL: cmd.ResponseURL,
+		Payload: map[string]interface{}{
+			"report_type": "tags",
+			"tag":         tag,
+			"mode":        string(mode),
+			"format":      string(format),
+			"start_date":  startDate,
+			"end_date":    endDate,
+		},
+	}
+	if err := h.tasks.Send(ctx, task); err != nil {
+		return h.errorResponse(ctx, cmd, "Failed to start the report.", err), nil
+	}
+
+	return lambda.SlackEphemeralResponse(fmt.Sprintf(
+		"Rolling up %s by %s from %s to %s as %s. I'll DM you the report when it's ready.",
+		mode, tag, startDate, endDate, strings.ToUpper(string(format)))), nil
+}
+
+// handleUserReportCommand handles "/standup-report user <@user|me>
+// [today|yesterday|YYYY-MM-DD]", showing what a user reported in each of the
+// workspace's channels on a day.
+func (h *Handler) handleUserReportCommand(
+	ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	userID := cmd.UserID
+	if user := inv.Arg("user"); !strings.EqualFold(user, "me") {
+		userID = userMentionPattern.FindStringSubmatch(user)[1] // Checked by validateUserArg
+	}
+
+	// Today and yesterday are those of the channel the command is run in
+	date := h.service.Today(ctx, cmd.ChannelID)
+	switch value := inv.Arg("date"); {
+	case strings.EqualFold(value, "yesterday"):
+		if today, err := time.Parse("2006-01-02", date); err == nil {
+			date = today.AddDate(0, 0, -1).Format("2006-01-02")
+		}
+	case value != "" && !strings.EqualFold(value, "today"):
+		date = value // Checked by validateReportDate
+	}
+
+	channels, hidden, err := h.service.UserDayReport(ctx, userID, date, cmd.UserID)
+	if err != nil {
+		return h.errorResponse(ctx, cmd, "Failed to build the report.", err), nil
+	}
+
+	return lambda.SlackEphemeralBlockResponse(slack.BuildUserDayReport(userID, date, channels, hidden)), nil
+}
+
+// reportRange returns the dates of a report command, the last
+// defaultExportDays days unless given.
+func reportRange(inv *command.Invocation) (startDate, endDate string) {
+	now := time.Now()
+	startDate = now.AddDate(0, 0, -defaultExportDays+1).Format("2006-01-02")
+	endDate = now.Format("2006-01-02")
+	if start := inv.Arg("start"); start != "" {
+		startDate = start
+	}
+	if end := inv.Arg("end"); end != "" {
+		endDate = end
+	}
+	return startDate, endDate
+}
+
+// handleStatsCommand handles "/standup-stats [days] [me]".
+func (h *Handler) handleStatsCommand(
+	ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	windowDays := analytics.DefaultWindowDays
+	if days := inv.Arg("days"); days != "" {
+		windowDays, _ = strconv.Atoi(days) // Checked by validateWindowDays
+	}
+	personal := inv.Arg("me") != ""
+
+	channelConfig, err := h.store.GetChannelConfig(ctx, cmd.TeamID, cmd.ChannelID)
+	if err != nil {
+		return h.errorResponse(ctx, cmd, "Failed to compute stats.", channelConfigError(err)), nil
+	}
+
+	stats, err := h.stats.ChannelStats(ctx, channelConfig, time.Now(), windowDays)
+	if err != nil {
+		return h.errorResponse(ctx, cmd, "Failed to compute stats.", err), nil
+	}
+	// Blockers are response content, which private channels don't share
+	if standup.IsPrivate(channelConfig) {
+		stats.TopBlockers = nil
+	}
+
+	if personal {
+		user, found := stats.UserByID(cmd.UserID)
+		if !found {
+			return lambda.SlackEphemeralResponse("You're not a required participant in this channel's standup."), nil
+		}
+		return lambda.SlackEphemeralBlockResponse(analytics.BuildUserStatsMessage(stats, user)), nil
+	}
+
+	return lambda.SlackEphemeralBlockResponse(analytics.BuildStatsMessage(stats)), nil
+}
+
+// handleDebugCommand handles "/standup-debug".
+func (h *Handler) handleDebugCommand(
+	ctx context.Context, cmd *slack.SlashCommand, inv *command.Invocation,
+) (events.APIGatewayProxyResponse, error) {
+	diagnostics := h.service.Diagnose(ctx, cmd.ChannelID)
+	return lambda.SlackEphemeralResponse(standup.DiagnosticsText(diagnostics, time.Now())), nil
+}
+
+func (h *Handler) handleInteraction(ctx context.Context, payloadStr string) (events.APIGatewayProxyResponse, error) {
+	var payload slack.InteractionCallback
+	if err := json.Unmarshal([]byte(payloadStr), &payload); err != nil {
+		return lambda.BadRequest("Invalid interaction payload"), err
+	}
+
+	// Add user context
+	ctx = h.botCtx.WithUserID(ctx, payload.User.ID)
+	if payload.Channel.ID != "" {
+		ctx = h.botCtx.WithChannelID(ctx, payload.Channel.ID)
+	}
+
+	logger := h.botCtx.Logger()
+
+	ctx, ok := h.service.WithTeam(ctx, payload.WorkspaceID())
+	if !ok {
+		logger.Warn(ctx, "Ignoring interaction from unknown workspace",
+			botcontext.Field{Key: "team_id", Value: security.SanitizeLogValue(payload.WorkspaceID())},
+		)
+		return lambda.OK(""), nil
+	}
+
+	logger.Info(ctx, "Interaction received",
+		botcontext.Field{Key: "type", Value: security.SanitizeLogValue(payload.Type)},
+		botcontext.Field{Key: "callback_id", Value: security.SanitizeLogValue(payload.CallbackID)},
+		botcontext.Field{Key: "enterprise_install", Value: payload.IsEnterpriseInstall},
+	)
+
+	switch payload.Type {
+	case "view_submission":
+		return h.handleViewSubmission(ctx, &payload)
+	case "block_actions":
+		return h.handleBlockActions(ctx, &payload)
+	case "shortcut":
+		return h.handleShortcut(ctx, &payload)
+	case "view_closed":
+		// Nothing to do
+		return lambda.OK(""), nil
+	default:
+		return lambda.BadRequest("Unknown interaction type"), nil
+	}
+}
+
+func (h *Handler) handleViewSubmission(
+	ctx context.Context,
+	payload *slack.InteractionCallback,
+) (events.APIGatewayProxyResponse, error) {
+	if payload.View == nil {
+		return lambda.BadRequest("Missing view data"), nil
+	}
+
+	switch payload.View.CallbackID {
+	case slack.StandupCallbackID, slack.StandupReviewCallbackID:
+		return h.handleSubmission(ctx, payload)
+	case slack.ChannelConfigCallbackID:
+		return h.handleChannelConfigSubmission(ctx, payload)
+	case slack.ChannelSetupCallbackID:
+		return h.handleChannelSetupSubmission(ctx, payload)
+	default:
+		return lambda.BadRequest("Unknown view callback"), nil
+	}
+}
+
+func (h *Handler) handleSubmission(
+	ctx context.Context,
+	payload *slack.InteractionCallback,
+) (events.APIGatewayProxyResponse, error) {
+	// Parse modal metadata
+	metadata, err := slack.ParseModalMetadata(payload.View.PrivateMetadata)
+	if err != nil {
+		return lambda.BadRequest("Invalid modal metadata"), err
+	}
+
+	// Reviewed answers are kept with the review screen
+	answers := metadata.Answers
+	reviewed := payload.View.CallbackID == slack.StandupReviewCallbackID
+	if !reviewed {
+		answers, err = slack.ParseModalAnswers(payload.View)
+		if err != nil {
+			return lambda.BadRequest("Failed to parse submission"), err
+		}
+
+		review, err := h.service.ReviewStandup(ctx, metadata, answers)
+		var invalid *standup.InvalidAnswersError
+		if errors.As(err, &invalid) {
+			return lambda.SlackViewErrors(invalid.Errors), nil
+		}
+		if err != nil {
+			h.botCtx.Logger().Error(ctx, "Failed to review standup", err)
+			return lambda.InternalServerError("Failed to save your standup. Please try again."), nil
+		}
+		if review != nil {
+			return lambda.SlackViewUpdate(review), nil
+		}
+	}
+
+	responses := make(map[string]string, len(answers))
+	for blockID, answer := range answers {
+		responses[blockID] = answer.Text()
+	}
+
+	// Create submission
+	submission := &standup.Submission{
+		SessionID: metadata.SessionID,
+		ChannelID: metadata.ChannelID,
+		Date:      metadata.Date,
+		UserID:    payload.User.ID,
+		UserName:  payload.User.Name,
+		Responses: responses,
+		Answers:   answers,
+		Locale:    metadata.Locale,
+		OpenedAt:  metadata.Timestamp,
+	}
+
+	// Submit response
+	err = h.service.SubmitStandupResponse(ctx, submission)
+	var invalid *standup.InvalidAnswersError
+	switch {
+	case reviewed && errors.As(err, &invalid):
+		// The review screen has no answers to show errors under
+		messages := make([]string, 0, len(invalid.Errors))
+		for _, blockID := range slices.Sorted(maps.Keys(invalid.Errors)) {
+			messages = append(messages, invalid.Errors[blockID])
+		}
+		return standupRejected(strings.Join(messages, "\n"), metadata.Locale, true), nil
+	case errors.As(err, &invalid):
+		// Slack keeps the modal open and shows each error under its answer
+		return lambda.SlackViewErrors(invalid.Errors), nil
+	case errors.Is(err, standup.ErrStandupClosed):
+		return standupRejected(metadata.Locale.T(i18n.ErrorStandupClosed), metadata.Locale, reviewed), nil
+	case errors.Is(err, standup.ErrSubmissionConflict):
+		// Submitting again would replace the newer standup
+		return standupRejected(metadata.Locale.T(i18n.ErrorStandupChanged), metadata.Locale, reviewed), nil
+	}
+	if err != nil {
+		h.botCtx.Logger().Error(ctx, "Failed to submit standup", err)
+		return lambda.InternalServerError("Failed to save your standup. Please try again."), nil
+	}
+
+	// Return success (closes modal)
+	return lambda.OK(""), nil
+}
+
+// standupRejected answers a standup submission that can't be saved with
+// message, shown on the review screen's place or under the first answer.
+func standupRejected(message string, locale i18n.Locale, reviewed bool) events.APIGatewayProxyResponse {
+	if reviewed {
+		return lambda.SlackViewUpdate(slack.BuildStandupNotice(message, locale))
+	}
+	return lambda.SlackViewErrors(map[string]string{slack.QuestionBlockID(0): message})
+}
+
+func (h *Handler) handleBlockActions(
+	ctx context.Context,
+	payload *slack.InteractionCallback,
+) (events.APIGatewayProxyResponse, error) {
+	// The review screen's edit button goes back to the answers
+	if payload.View != nil && payload.View.CallbackID == slack.StandupReviewCallbackID {
+		if err := h.service.EditStandup(ctx, payload.View); err != nil {
+			h.botCtx.Logger().Error(ctx, "Failed to edit standup", err)
+		}
+		return lambda.OK(""), nil
+	}
+
+	// Answers in the standup modal show or hide conditional questions
+	if payload.View != nil && payload.View.CallbackID == slack.StandupCallbackID {
+		if err := h.service.UpdateStandupModal(ctx, payload.View); err != nil {
+			h.botCtx.Logger().Error(ctx, "Failed to update standup modal", err)
+		}
+		return lambda.OK(""), nil
+	}
+
+	// Picking a channel in the settings modal shows its settings
+	if payload.View != nil && payload.View.CallbackID == slack.ChannelConfigCallbackID {
+		if err := h.updateChannelConfigModal(ctx, payload); err != nil {
+			h.botCtx.Logger().Error(ctx, "Failed to update settings modal", err)
+		}
+		return lambda.OK(""), nil
+	}
+
+	if err := h.actions.Dispatch(ctx, payload); err != nil {
+		h.botCtx.Logger().Error(ctx, "Failed to handle block action", err)
+	}
+
+	// Slack only needs an acknowledgement for block actions
+	return lambda.OK(""), nil
+}
+
+func (h *Handler) handleSubmitNowAction(ctx context.Context, payload *slack.InteractionCallback, action *slack.Action) error {
+	err := h.service.OpenStandupModal(ctx, payload.TriggerID, action.Value, payload.User.ID)
+	if errors.Is(err, standup.ErrStandupClosed) {
+		locale := h.service.UserLocale(ctx, action.Value, payload.User.ID)
+		return h.acknowledgeAction(ctx, payload, locale.T(i18n.ErrorStandupClosed))
+	}
+	return err
+}
+
+func (h *Handler) handleSkipTodayAction(ctx context.Context, payload *slack.InteractionCallback, action *slack.Action) error {
+	if err := h.service.SkipToday(ctx, action.Value, payload.User.ID, ""); err != nil {
+		return err
+	}
+
+	return h.acknowledgeAction(ctx, payload, "⏭️ Skipped today's standup. See you next time!")
+}
+
+func (h *Handler) handleSnoozeAction(ctx context.Context, payload *slack.InteractionCallback, action *slack.Action) error {
+	remindAt, err := h.service.SnoozeReminder(ctx, action.Value, payload.User.ID, snoozeDuration)
+	if err != nil {
+		return err
+	}
+
+	text := fmt.Sprintf("😴 Snoozed. I'll remind you again <!date^%d^at {time}|in an hour>.", remindAt.Unix())
+	return h.acknowledgeAction(ctx, payload, text)
+}
+
+// handlePreferenceAction returns a handler for the buttons of the reminder
+// preferences message, setting the preference key to the button's value and
+// redrawing the message.
+func (h *Handler) handlePreferenceAction(key string) slack.ActionHandler {
+	return func(ctx context.Context, payload *slack.InteractionCallback, action *slack.Action) error {
+		prefs, err := h.service.UpdateUserPreference(ctx, payload.User.ID, key, action.Value)
+		if err != nil {
+			return err
+		}
+
+		// The message is ephemeral, so it can only be replaced through the response URL
+		if payload.ResponseURL == "" {
+			return nil
+		}
+		return h.slack.PostToResponseURL(ctx, payload.ResponseURL, &slack.ResponseMessage{
+			Text:            "Your reminder preferences",
+			Blocks:          preferencesMessage(prefs),
+			ReplaceOriginal: true,
+		})
+	}
+}
+
+// handleOpenChannelAction acknowledges the channel link on reminders; the
+// link itself is opened by Slack.
+func (h *Handler) handleOpenChannelAction(context.Context, *slack.InteractionCallback, *slack.Action) error {
+	return nil
+}
+
+func (h *Handler) handleAddMemberAction(ctx context.Context, payload *slack.InteractionCallback, action *slack.Action) error {
+	channelID, userID, err := slack.ParseMemberActionValue(action.Value)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.AddRequiredUser(ctx, payload.WorkspaceID(), channelID, userID); err != nil {
+		return err
+	}
+
+	return h.acknowledgeAction(ctx, payload, fmt.Sprintf("✅ Added <@%s> to the standup in <#%s>.", userID, channelID))
+}
+
+func (h *Handler) handleDismissMemberAction(ctx context.Context, payload *slack.InteractionCallback, action *slack.Action) error {
+	channelID, userID, err := slack.ParseMemberActionValue(action.Value)
+	if err != nil {
+		return err
+	}
+
+	return h.acknowledgeAction(ctx, payload, fmt.Sprintf("👍 <@%s> wasn't added to the standup in <#%s>.", userID, channelID))
+}
+
+// handleReviewSummaryAction marks a summary reviewed by the lead who clicked
+// its button. Others are told only leads can.
+func (h *Handler) handleReviewSummaryAction(ctx context.Context, payload *slack.InteractionCallback, action *slack.Action) error {
+	channelID, date, err := slack.ParseSummaryActionValue(action.Value)
+	if err != nil {
+		return err
+	}
+
+	err = h.authz.Require(ctx, channelID, payload.User.ID, authz.RoleChannelAdmin)
+	if errors.Is(err, authz.ErrForbidden) {
+		_, err = h.slack.PostEphemeral(ctx, channelID, payload.User.ID,
+			slack.WithText("🔒 Only channel admins and workspace admins can mark the standup reviewed."))
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	return h.service.ReviewSummary(ctx, channelID, date, payload.User.ID)
+}
+
+// acknowledgeAction replaces the buttons of the DM an action came from with a
+// status line.
+func (h *Handler) acknowledgeAction(ctx context.Context, payload *slack.InteractionCallback, text string) error {
+	if payload.Container == nil || payload.Container.MessageTS == "" {
+		return nil
+	}
+
+	blocks := slack.NewMessageBuilder().AddSection(text).Build()
+	return h.slack.UpdateMessage(ctx, payload.Container.ChannelID, payload.Container.MessageTS,
+		slack.WithText(text), slack.WithBlocks(blocks...))
+}
+
+func (h *Handler) handleEvent(ctx context.Context, body string) (events.APIGatewayProxyResponse, error) {
+	var wrapper slack.EventWrapper
+	if err := json.Unmarshal([]byte(body), &wrapper); err != nil {
+		return lambda.BadRequest("Invalid event payload"), err
+	}
+
+	// Handle different event types
+	switch wrapper.Type {
+	case "event_callback":
+		return h.handleEventCallback(ctx, &wrapper)
+	case "app_rate_limited":
+		h.botCtx.Logger().Warn(ctx, "Rate limited by Slack")
+		return lambda.OK(""), nil
+	default:
+		return lambda.BadRequest(fmt.Sprintf("Unknown event type: %s", security.SanitizeLogValue(wrapper.Type))), nil
+	}
+}
+
+func (h *Handler) handleEventCallback(ctx context.Context, wrapper *slack.EventWrapper) (events.APIGatewayProxyResponse, error) {
+	// Add context
+	if wrapper.Event.User != "" {
+		ctx = h.botCtx.WithUserID(ctx, wrapper.Event.User)
+	}
+	if wrapper.Event.Channel != "" {
+		ctx = h.botCtx.WithChannelID(ctx, wrapper.Event.Channel)
+	}
+
+	logger := h.botCtx.Logger()
+
+	// Slack sends bots' messages, including the bot's own, back as events;
+	// answering them could loop
+	if h.isBotMessage(wrapper) {
+		logger.Debug(ctx, "Ignoring bot message",
+			botcontext.Field{Key: "bot_id", Value: security.SanitizeLogValue(wrapper.Event.BotID)},
+		)
+		return lambda.OK(""), nil
+	}
+
+	// Access changes of an org-wide install name the workspaces they apply to
+	switch wrapper.Event.Type {
+	case "team_access_granted", "team_access_revoked":
+		h.handleTeamAccess(ctx, wrapper)
+		return lambda.OK(""), nil
+	}
+
+	ctx, ok := h.service.WithTeam(ctx, wrapper.WorkspaceID())
+	if !ok {
+		logger.Warn(ctx, "Ignoring event from unknown workspace",
+			botcontext.Field{Key: "team_id", Value: security.SanitizeLogValue(wrapper.WorkspaceID())},
+		)
+		return lambda.OK(""), nil
+	}
+
+	logger.Info(ctx, "Event received",
+		botcontext.Field{Key: "event_type", Value: security.SanitizeLogValue(wrapper.Event.Type)},
+	)
+
+	// Handle specific events
+	switch wrapper.Event.Type {
+	case "member_joined_channel":
+		h.handleMemberJoined(ctx, wrapper)
+	case "channel_archive", "group_archive", "channel_unarchive", "group_unarchive", "channel_deleted":
+		h.handleChannelLifecycle(ctx, wrapper)
+	case "link_shared":
+		h.handleLinkShared(ctx, wrapper)
+	case "app_mention":
+		// TODO: Handle mentions
+	case "message":
+		// TODO: Handle DM responses
+	}
+
+	// Always return 200 OK for events
+	return lambda.OK(""), nil
+}
+
+// botUserID returns the bot user of the installation an event is delivered
+// for, or else the one the Slack client verified its token as.
+func (h *Handler) botUserID(wrapper *slack.EventWrapper) string {
+	if userID := wrapper.BotUserID(); userID != "" {
+		return userID
+	}
+	return h.slack.BotUserID()
+}
+
+// isBotMessage reports whether an event is a message posted by a bot or an
+// integration, or by the bot's own user.
+func (h *Handler) isBotMessage(wrapper *slack.EventWrapper) bool {
+	event := &wrapper.Event
+	if event.Type != "message" && event.Type != "app_mention" {
+		return false
+	}
+	if event.BotID != "" || event.Subtype == "bot_message" {
+		return true
+	}
+	return event.User != "" && event.User == h.botUserID(wrapper)
+}
+
+// handleMemberJoined welcomes someone who joined a standup channel and, if the
+// channel's onboarding policy says so, asks its admin whether to add them.
+// When the bot itself joins a channel without standups, it offers to set
+// them up.
+func (h *Handler) handleMemberJoined(ctx context.Context, wrapper *slack.EventWrapper) {
+	event := &wrapper.Event
+	if event.User != "" && event.User == h.botUserID(wrapper) {
+		if err := h.service.PromptChannelSetup(ctx, wrapper.WorkspaceID(), event.Channel, event.Inviter); err != nil {
+			h.botCtx.Logger().Error(ctx, "Failed to offer channel setup", err)
+		}
+		return
+	}
+	if _, found := h.service.Config(ctx).ChannelByID(event.Channel); !found || event.User == "" {
+		return
+	}
+
+	logger := h.botCtx.Logger()
+
+	if h.tasks != nil {
+		task := &queue.Task{
+			Type:      queue.TaskSendWelcome,
+			TeamID:    wrapper.WorkspaceID(),
+			ChannelID: event.Channel,
+			UserID:    event.User,
+		}
+		if err := h.tasks.Send(ctx, task); err != nil {
+			logger.Error(ctx, "Failed to queue welcome message", err)
+		}
+	}
+
+	if err := h.service.PromptMemberApproval(ctx, wrapper.WorkspaceID(), event.Channel, event.User); err != nil {
+		logger.Error(ctx, "Failed to prompt channel admin", err)
+	}
+}
+
+// handleChannelLifecycle pauses the standups of channels archived in Slack,
+// resumes them when unarchived and turns them off when deleted.
+func (h *Handler) handleChannelLifecycle(ctx context.Context, wrapper *slack.EventWrapper) {
+	teamID, channelID := wrapper.WorkspaceID(), wrapper.Event.Channel
+	if channelID == "" {
+		return
+	}
+
+	var err error
+	switch wrapper.Event.Type {
+	case "channel_archive", "group_archive":
+		err = h.service.ArchiveChannel(ctx, teamID, channelID)
+	case "channel_unarchive", "group_unarchive":
+		err = h.service.UnarchiveChannel(ctx, teamID, channelID)
+	case "channel_deleted":
+		err = h.service.DisableChannel(ctx, teamID, channelID)
+	}
+	if err != nil {
+		h.botCtx.Logger().Error(ctx, "Failed to update channel state", err,
+			botcontext.Field{Key: "event_type", Value: wrapper.Event.Type},
+		)
+	}
+}
+
+// handleTeamAccess links workspaces an org-wide install was granted to, so
+// they use its bot token, and unlinks those it lost access to.
+func (h *Handler) handleTeamAccess(ctx context.Context, wrapper *slack.EventWrapper) {
+	logger := h.botCtx.Logger()
+	if wrapper.EnterpriseID == "" {
+		logger.Warn(ctx, "Ignoring team access event without an enterprise")
+		return
+	}
+
+	var err error
+	if wrapper.Event.Type == "team_access_granted" {
+		teams := make([]slack.Team, 0, len(wrapper.Event.TeamIDs))
+		for _, teamID := range wrapper.Event.TeamIDs {
+			teams = append(teams, slack.Team{ID: teamID})
+		}
+		err = install.GrantTeams(ctx, h.store, wrapper.EnterpriseID, teams)
+	} else {
+		err = install.RevokeTeams(ctx, h.store, wrapper.EnterpriseID, wrapper.Event.TeamIDs)
+	}
+	if err != nil {
+		logger.Error(ctx, "Failed to update enterprise workspaces", err,
+			botcontext.Field{Key: "enterprise_id", Value: security.SanitizeLogValue(wrapper.EnterpriseID)},
+		)
+		return
+	}
+
+	logger.Info(ctx, "Updated enterprise workspaces",
+		botcontext.Field{Key: "enterprise_id", Value: security.SanitizeLogValue(wrapper.EnterpriseID)},
+		botcontext.Field{Key: "event_type", Value: wrapper.Event.Type},
+		botcontext.Field{Key: "teams", Value: len(wrapper.Event.TeamIDs)},
+	)
+}